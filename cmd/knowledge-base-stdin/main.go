@@ -1,22 +1,44 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/server"
 	_ "github.com/ncruces/go-sqlite3/driver"
 	_ "github.com/ncruces/go-sqlite3/embed"
 
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/backup"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/bulkimport"
+	bulkimportmcp "github.com/red1r3ct/knowledge-graph-mcp/internal/bulkimport/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	connmcp "github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	connstorage "github.com/red1r3ct/knowledge-graph-mcp/internal/connection/sqlite"
+	graphmcp "github.com/red1r3ct/knowledge-graph-mcp/internal/graph/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/health"
+	healthmcp "github.com/red1r3ct/knowledge-graph-mcp/internal/health/mcp"
 	kbmcp "github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase/mcp"
 	kbstorage "github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase/sqlite"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/linkcheck"
+	linkcheckmcp "github.com/red1r3ct/knowledge-graph-mcp/internal/linkcheck/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/logging"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/metrics"
+	metricsmcp "github.com/red1r3ct/knowledge-graph-mcp/internal/metrics/mcp"
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/migrations"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
 	notemcp "github.com/red1r3ct/knowledge-graph-mcp/internal/note/mcp"
 	notestorage "github.com/red1r3ct/knowledge-graph-mcp/internal/note/sqlite"
-	connmcp "github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
-	connstorage "github.com/red1r3ct/knowledge-graph-mcp/internal/connection/sqlite"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/search"
+	searchmcp "github.com/red1r3ct/knowledge-graph-mcp/internal/search/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/sqlitedb"
 )
 
 const (
@@ -28,8 +50,78 @@ func main() {
 	var dbPath string
 	flag.StringVar(&dbPath, "db", defaultDBPath, "Path to SQLite database file")
 	flag.StringVar(&dbPath, "database", defaultDBPath, "Path to SQLite database file (shorthand)")
+	var migrationsDir string
+	flag.StringVar(&migrationsDir, "migrations-dir", "", "Directory of NNN_name.up.sql and .down.sql migrations to run instead of the built-in ones")
+	var noteTokenizerName string
+	flag.StringVar(&noteTokenizerName, "note-fts-tokenizer", "", "Tokenizer for note search: default, or unicode61 to fold every accent; changing it reindexes all notes at startup (default: keep the database's)")
+	var defaultStrength int
+	var connectionTypes, extraConnectionTypes string
+	flag.IntVar(&defaultStrength, "default-strength", connection.DefaultConnectionStrength, fmt.Sprintf("Default strength for new connections (%d-%d)", connection.MinStrength, connection.MaxStrength))
+	flag.StringVar(&connectionTypes, "connection-types", "", "Comma-separated list of allowed connection types (default: all built-in types)")
+	flag.StringVar(&extraConnectionTypes, "extra-connection-types", "", "Comma-separated list of custom connection types to allow in addition")
+	var extraNoteTypes string
+	flag.StringVar(&extraNoteTypes, "extra-note-types", "", "Comma-separated list of custom note types to allow in addition to text, markdown, code, link and image")
+	var selfLoopTypes string
+	flag.StringVar(&selfLoopTypes, "self-loop-types", "", "Comma-separated list of connection types that may connect a note to itself, or none (default: relates_to, references, similar_to)")
+	var edgeModeName string
+	flag.StringVar(&edgeModeName, "edge-mode", string(connection.EdgeModeUnique), "Whether two notes may share several connections of the same type: unique or multi")
+	var graphLimits connection.GraphLimits
+	flag.IntVar(&graphLimits.MaxNodes, "graph-max-nodes", connection.DefaultMaxGraphNodes, "Maximum notes for whole-graph operations such as PageRank (0 = unlimited)")
+	flag.IntVar(&graphLimits.MaxEdges, "graph-max-edges", connection.DefaultMaxGraphEdges, "Maximum connections for whole-graph operations such as PageRank (0 = unlimited)")
+	flag.DurationVar(&graphLimits.Timeout, "graph-timeout", connection.DefaultGraphTimeout, "Time limit for whole-graph operations such as PageRank (0 = unlimited)")
+	var traversalLimits connection.TraversalLimits
+	flag.IntVar(&traversalLimits.MaxNodes, "traversal-max-nodes", connection.DefaultMaxTraversalNodes, "Maximum notes a walk from a note, such as path finding or get_note_context, visits before returning a truncated result (0 = unlimited)")
+	flag.IntVar(&traversalLimits.MaxDepth, "traversal-max-depth", connection.DefaultMaxTraversalDepth, "Maximum connections a walk from a note goes out, lowering larger requested depths (0 = unlimited)")
+	flag.DurationVar(&traversalLimits.MaxDuration, "traversal-timeout", connection.DefaultTraversalTimeout, "Time after which a walk from a note stops and returns a truncated result (0 = unlimited)")
+	strengthWeights := connection.DefaultStrengthWeights()
+	flag.Float64Var(&strengthWeights.Tags, "strength-weight-tags", strengthWeights.Tags, "Weight of shared tags in suggest_connection_strength")
+	flag.Float64Var(&strengthWeights.Neighbors, "strength-weight-neighbors", strengthWeights.Neighbors, "Weight of shared connected notes in suggest_connection_strength")
+	flag.Float64Var(&strengthWeights.Content, "strength-weight-content", strengthWeights.Content, "Weight of title and content similarity in suggest_connection_strength")
+	listLimits := mcpx.DefaultListLimits()
+	flag.IntVar(&listLimits.Default, "default-list-limit", mcpx.DefaultListLimit, "Number of items list tools return when a call gives no limit")
+	flag.IntVar(&listLimits.Max, "max-list-limit", mcpx.DefaultMaxListLimit, "Largest limit a call may ask list tools for; lower it for clients with small context windows")
+	var maxArgumentBytes int
+	flag.IntVar(&maxArgumentBytes, "max-argument-bytes", mcpx.DefaultMaxArgumentBytes, "Maximum JSON size of a tool call's arguments in bytes (0 = unlimited)")
+	var maxResultChars int
+	var maxResultCharsPerTool string
+	flag.IntVar(&maxResultChars, "max-result-chars", 0, "Maximum characters of a tool result's text; longer JSON bodies lose trailing items (0 = unlimited)")
+	flag.StringVar(&maxResultCharsPerTool, "max-result-chars-per-tool", "", "Comma-separated tool=chars overrides of max-result-chars, e.g. list_notes=20000,export_connections_csv=0")
+	var compactJSON bool
+	flag.BoolVar(&compactJSON, "compact-json", false, "Write the JSON in tool results on one line instead of indented, to save client tokens")
+	retryPolicy := sqlitedb.DefaultRetryPolicy()
+	flag.IntVar(&retryPolicy.MaxRetries, "busy-retries", sqlitedb.DefaultMaxRetries, "Times to retry a write that fails because the database is locked, with exponential backoff (0 = no retries)")
+	flag.DurationVar(&retryPolicy.Delay, "busy-retry-delay", sqlitedb.DefaultRetryDelay, "Wait before the first retry of a locked write; it doubles after each retry up to 1s")
+	var idempotencyWindow time.Duration
+	flag.DurationVar(&idempotencyWindow, "idempotency-window", sqlitedb.DefaultIdempotencyWindow, "How long create tools remember an idempotency_key; a retry after this creates a new record (0 = forever)")
+	var connectionAudit bool
+	flag.BoolVar(&connectionAudit, "connection-audit", false, "Record connection creates, updates and deletes for get_connection_audit")
+	var normalizeTags bool
+	flag.BoolVar(&normalizeTags, "normalize-tags", false, "Trim, lowercase and deduplicate note and knowledge base tags on write")
+	var validateURLs bool
+	flag.BoolVar(&validateURLs, "validate-urls", false, "Reject link and image notes whose content is not an absolute http or https URL")
+	var uniqueNoteContent bool
+	flag.BoolVar(&uniqueNoteContent, "unique-note-content", false, "Reject notes whose content matches another note's up to whitespace")
+	var filesRoot string
+	flag.StringVar(&filesRoot, "files-root", "", "Directory that export_notes_to_directory and import_notes_from_directory are confined to; relative directories are taken from it (default: both tools fail)")
+	var backupInterval time.Duration
+	var backupDir string
+	var backupKeep int
+	flag.DurationVar(&backupInterval, "backup-interval", 0, "Interval between database snapshots, e.g. 1h (0 = no automatic backups)")
+	flag.StringVar(&backupDir, "backup-dir", "", "Directory for database snapshots (default: a backups directory next to the database)")
+	flag.IntVar(&backupKeep, "backup-keep", backup.DefaultKeep, "Number of database snapshots to keep")
+	var logLevel string
+	flag.StringVar(&logLevel, "log-level", "info", "Log level for tool calls and storage events written to stderr (debug, info, warn, error); debug also logs the schema version and row counts at startup")
 	flag.Parse()
 
+	// Stdout carries the MCP protocol, so logs go to stderr
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(logLevel)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid log level: %s\n", logLevel)
+		flag.Usage()
+		os.Exit(1)
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+
 	// Validate database path
 	if dbPath == "" {
 		fmt.Fprintf(os.Stderr, "Error: database path cannot be empty\n")
@@ -37,65 +129,346 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Validate backup settings
+	if backupInterval < 0 || backupKeep < 1 {
+		fmt.Fprintf(os.Stderr, "Error: backup-interval must not be negative and backup-keep must be at least 1\n")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	// Validate retry settings
+	if retryPolicy.MaxRetries < 0 || retryPolicy.Delay < 0 {
+		fmt.Fprintf(os.Stderr, "Error: busy-retries and busy-retry-delay must not be negative\n")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	// Validate idempotency settings
+	if idempotencyWindow < 0 {
+		fmt.Fprintf(os.Stderr, "Error: idempotency-window must not be negative\n")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	// Validate strength suggestion weights
+	if err := strengthWeights.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	// Validate list limits
+	if err := listLimits.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	// Validate result truncation settings
+	if maxResultChars < 0 {
+		fmt.Fprintf(os.Stderr, "Error: max-result-chars must not be negative\n")
+		flag.Usage()
+		os.Exit(1)
+	}
+	resultLimits, err := mcpx.ParseToolLimits(maxResultCharsPerTool)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: max-result-chars-per-tool: %v\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	noteTokenizer, err := migrations.ParseNoteTokenizer(noteTokenizerName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	edgeMode, err := connection.ParseEdgeMode(edgeModeName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	// Apply connection configuration before tool schemas are built
+	if err := connection.Configure(connection.Config{
+		DefaultStrength: defaultStrength,
+		AllowedTypes:    splitList(connectionTypes),
+		ExtraTypes:      splitList(extraConnectionTypes),
+		SelfLoopTypes:   parseSelfLoopTypes(selfLoopTypes),
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	// Apply note configuration before tool schemas are built
+	if err := note.Configure(note.Config{
+		ExtraTypes: splitList(extraNoteTypes),
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	// Migrations and each storage open their own pool, so a private in-memory
+	// database would leave them looking at different empty databases
+	_, kind, err := sqlitedb.Resolve(dbPath)
+	if err != nil {
+		log.Fatalf("Invalid database path: %v", err)
+	}
+	if kind == sqlitedb.PrivateMemory {
+		dbPath = "file::memory:?cache=shared"
+	}
+
 	// Check if file exists and is accessible
-	if _, err := os.Stat(dbPath); err != nil && !os.IsNotExist(err) {
-		log.Fatalf("Failed to access database file: %v", err)
+	if kind == sqlitedb.File {
+		if _, err := os.Stat(dbPath); err != nil && !os.IsNotExist(err) {
+			log.Fatalf("Failed to access database file: %v", err)
+		}
 	}
 
 	// Run migrations before initializing storage
-	if err := runMigrations(dbPath); err != nil {
+	migrationRunner, err := migrations.NewMigrationRunnerFromDir(dbPath, migrationsDir,
+		migrations.WithNoteTokenizer(noteTokenizer),
+		migrations.WithEdgeMode(edgeMode),
+	)
+	if err != nil {
+		log.Fatalf("Invalid migrations directory: %v", err)
+	}
+	if err := migrationRunner.RunMigrations(); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
 	// Initialize knowledgebase storage
-	kbStorage, err := kbstorage.NewStorage(dbPath)
+	kbStorage, err := kbstorage.NewStorage(dbPath,
+		kbstorage.WithLogger(logger),
+		kbstorage.WithTagNormalization(normalizeTags),
+		kbstorage.WithRetry(retryPolicy),
+		kbstorage.WithIdempotencyWindow(idempotencyWindow),
+	)
 	if err != nil {
 		log.Fatalf("Failed to initialize knowledgebase storage: %v", err)
 	}
 	defer kbStorage.Close()
 
 	// Initialize note storage
-	noteStorage, err := notestorage.NewStorage(dbPath)
+	noteStorage, err := notestorage.NewStorage(dbPath,
+		notestorage.WithLogger(logger),
+		notestorage.WithTagNormalization(normalizeTags),
+		notestorage.WithURLValidation(validateURLs),
+		notestorage.WithUniqueContent(uniqueNoteContent),
+		notestorage.WithRetry(retryPolicy),
+		notestorage.WithIdempotencyWindow(idempotencyWindow),
+		notestorage.WithFilesRoot(filesRoot),
+	)
 	if err != nil {
 		log.Fatalf("Failed to initialize note storage: %v", err)
 	}
 	defer noteStorage.Close()
 
 	// Initialize connection storage
-	connStorage, err := connstorage.NewStorage(dbPath)
+	connStorage, err := connstorage.NewStorage(dbPath,
+		connstorage.WithGraphLimits(graphLimits),
+		connstorage.WithTraversalLimits(traversalLimits),
+		connstorage.WithLogger(logger),
+		connstorage.WithAudit(connectionAudit),
+		connstorage.WithEdgeMode(edgeMode),
+		connstorage.WithStrengthWeights(strengthWeights),
+		connstorage.WithRetry(retryPolicy),
+		connstorage.WithIdempotencyWindow(idempotencyWindow),
+		connstorage.WithNoteInserter(noteStorage),
+	)
 	if err != nil {
 		log.Fatalf("Failed to initialize connection storage: %v", err)
 	}
 	defer connStorage.Close()
 
+	logStartupSummary(context.Background(), logger, dbPath, migrationRunner)
+
 	// Create MCP server
 	s := server.NewMCPServer(
 		"Knowledge Graph MCP Server",
 		"1.0.0",
 	)
 
+	// Indented JSON reads better but costs clients tokens
+	mcpx.SetCompactJSON(compactJSON)
+
+	// Tool call counts and latencies, reported by get_server_metrics
+	toolMetrics := metrics.New()
+
+	// Logging and metrics run first so they also see rejected calls
+	middleware := []mcpx.Middleware{
+		logging.Middleware(logger),
+		toolMetrics.Middleware(),
+		mcpx.MaxArgumentSize(maxArgumentBytes),
+		mcpx.MaxResultChars(maxResultChars, resultLimits),
+	}
+
 	// Register all knowledgebase tools
-	if err := kbmcp.RegisterTools(s, kbStorage); err != nil {
+	if err := kbmcp.RegisterTools(s, kbStorage, listLimits, middleware...); err != nil {
 		log.Fatalf("Failed to register knowledgebase tools: %v", err)
 	}
 
 	// Register all note tools
-	if err := notemcp.RegisterTools(s, noteStorage); err != nil {
+	if err := notemcp.RegisterTools(s, noteStorage, listLimits, middleware...); err != nil {
 		log.Fatalf("Failed to register note tools: %v", err)
 	}
 
+	// Register the dead-link checker over the note storage
+	linkChecker := linkcheck.New(noteStorage, linkcheck.WithLogger(logger))
+	if err := linkcheckmcp.RegisterTools(s, linkChecker, middleware...); err != nil {
+		log.Fatalf("Failed to register link check tools: %v", err)
+	}
+
 	// Register all connection tools
-	if err := connmcp.RegisterTools(s, connStorage); err != nil {
+	if err := connmcp.RegisterTools(s, connStorage, listLimits, middleware...); err != nil {
 		log.Fatalf("Failed to register connection tools: %v", err)
 	}
 
-	// Start the stdio server
-	if err := server.ServeStdio(s); err != nil {
+	// Register all cross-entity graph tools
+	if err := graphmcp.RegisterTools(s, kbStorage, noteStorage, connStorage, traversalLimits, middleware...); err != nil {
+		log.Fatalf("Failed to register graph tools: %v", err)
+	}
+
+	// Register the search over all entity types
+	searcher := search.New(kbStorage, noteStorage, connStorage)
+	if err := searchmcp.RegisterTools(s, searcher, middleware...); err != nil {
+		log.Fatalf("Failed to register search tools: %v", err)
+	}
+
+	// Register the server metrics tool
+	if err := metricsmcp.RegisterTools(s, toolMetrics, middleware...); err != nil {
+		log.Fatalf("Failed to register metrics tools: %v", err)
+	}
+
+	// Register the health check tool on its own pool, so a wedged storage pool
+	// does not hide a reachable database
+	healthDB, err := sqlitedb.Open(dbPath)
+	if err != nil {
+		log.Fatalf("Failed to open database for health checks: %v", err)
+	}
+	defer healthDB.Close()
+	checker := health.New(healthDB, migrationRunner)
+	if err := healthmcp.RegisterTools(s, checker, middleware...); err != nil {
+		log.Fatalf("Failed to register health tools: %v", err)
+	}
+
+	// Register the bulk import tool on its own pool, since it turns foreign
+	// keys off on the connection it holds while importing
+	importDB, err := sqlitedb.Open(dbPath)
+	if err != nil {
+		log.Fatalf("Failed to open database for bulk imports: %v", err)
+	}
+	defer importDB.Close()
+	importer := bulkimport.New(importDB,
+		bulkimport.WithLogger(logger),
+		bulkimport.WithEdgeMode(edgeMode),
+		bulkimport.WithURLValidation(validateURLs),
+		bulkimport.WithUniqueContent(uniqueNoteContent),
+	)
+	if err := bulkimportmcp.RegisterTools(s, importer, middleware...); err != nil {
+		log.Fatalf("Failed to register bulk import tools: %v", err)
+	}
+
+	// Snapshot the database in the background while the server runs
+	stopBackups := func() {}
+	if backupInterval > 0 {
+		if backupDir == "" {
+			backupDir = "backups"
+			if kind == sqlitedb.File {
+				backupDir = filepath.Join(filepath.Dir(dbPath), "backups")
+			}
+		}
+
+		backupDB, err := sqlitedb.Open(dbPath)
+		if err != nil {
+			log.Fatalf("Failed to open database for backups: %v", err)
+		}
+		defer backupDB.Close()
+
+		scheduler := backup.New(backupDB, backupDir, backupInterval,
+			backup.WithKeep(backupKeep),
+			backup.WithLogger(logger),
+		)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			scheduler.Run(ctx)
+		}()
+		stopBackups = func() {
+			cancel()
+			<-done
+		}
+	}
+
+	// Start the stdio server. Stdout is reserved for the protocol from here on.
+	err = mcpx.ServeStdio(s, logger)
+	// Let a running backup stop before the databases close
+	stopBackups()
+	if err != nil {
 		log.Fatalf("Server error: %v", err)
 	}
 }
 
-func runMigrations(dbPath string) error {
-	migrationRunner := migrations.NewMigrationRunner(dbPath)
-	return migrationRunner.RunMigrations()
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseSelfLoopTypes parses the self-loop-types flag. An unset flag keeps the
+// built-in policy and "none" forbids self-loops for every type.
+func parseSelfLoopTypes(value string) []string {
+	if strings.TrimSpace(value) == "none" {
+		return []string{}
+	}
+	return splitList(value)
+}
+
+// logStartupSummary logs the schema version and the number of knowledge
+// bases, notes and connections at debug level, so an operator can see which
+// database the server opened. Counting scans each table, so nothing is
+// queried unless debug logging is on. Failures are logged and do not stop
+// the server.
+func logStartupSummary(ctx context.Context, logger *slog.Logger, dbPath string, runner *migrations.MigrationRunner) {
+	if !logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	version, dirty, err := runner.GetVersion()
+	if err != nil {
+		logger.Warn("failed to read schema version for the startup summary", "error", err)
+		return
+	}
+
+	db, err := sqlitedb.Open(dbPath)
+	if err != nil {
+		logger.Warn("failed to open database for the startup summary", "error", err)
+		return
+	}
+	defer db.Close()
+
+	attrs := []any{"schema_version", version, "dirty", dirty}
+	for _, table := range []struct{ name, attr string }{
+		{"knowledge_base", "knowledge_bases"},
+		{"notes", "notes"},
+		{"connections", "connections"},
+	} {
+		var count int64
+		if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+table.name).Scan(&count); err != nil {
+			logger.Warn("failed to count rows for the startup summary", "table", table.name, "error", err)
+			return
+		}
+		attrs = append(attrs, table.attr, count)
+	}
+	logger.Debug("database ready", attrs...)
 }
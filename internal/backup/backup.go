@@ -0,0 +1,186 @@
+// Package backup takes periodic snapshots of the SQLite database so a
+// long-running server can recover from corruption or accidental deletes.
+//
+// Each snapshot is a complete, consistent database written with VACUUM INTO
+// under a timestamped name in the backup directory. Only the newest snapshots
+// are kept; older ones are deleted after every successful backup.
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/logging"
+)
+
+const (
+	// DefaultKeep is the number of snapshots kept when no retention is configured
+	DefaultKeep = 7
+
+	// snapshotPrefix and snapshotExt frame the timestamp in snapshot file names
+	snapshotPrefix = "snapshot-"
+	snapshotExt    = ".db"
+
+	// timestampLayout sorts lexically in time order and is safe in file names
+	timestampLayout = "20060102T150405.000Z"
+)
+
+// Scheduler writes database snapshots to a directory, keeping the newest ones
+type Scheduler struct {
+	db       *sql.DB
+	dir      string
+	interval time.Duration
+	keep     int
+	logger   *slog.Logger
+	now      func() time.Time
+}
+
+// Option configures a Scheduler
+type Option func(*Scheduler)
+
+// WithKeep sets how many snapshots are kept. Values below 1 keep DefaultKeep.
+func WithKeep(keep int) Option {
+	return func(s *Scheduler) {
+		if keep > 0 {
+			s.keep = keep
+		}
+	}
+}
+
+// WithLogger sets the logger used to report each backup and failure
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Scheduler) {
+		s.logger = logging.OrDiscard(logger)
+	}
+}
+
+// WithClock sets the clock used to name snapshots
+func WithClock(now func() time.Time) Option {
+	return func(s *Scheduler) {
+		s.now = now
+	}
+}
+
+// New creates a Scheduler that snapshots db into dir every interval
+func New(db *sql.DB, dir string, interval time.Duration, opts ...Option) *Scheduler {
+	s := &Scheduler{
+		db:       db,
+		dir:      dir,
+		interval: interval,
+		keep:     DefaultKeep,
+		logger:   logging.Discard(),
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Run takes a backup every interval until ctx is cancelled. A failed backup
+// is logged and retried at the next tick. Run returns once ctx is done and any
+// backup in progress has stopped.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.logger.Info("backup scheduler started", "dir", s.dir, "interval", s.interval, "keep", s.keep)
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("backup scheduler stopped")
+			return
+		case <-ticker.C:
+			if _, err := s.Backup(ctx); err != nil && ctx.Err() == nil {
+				s.logger.Error("backup failed", "dir", s.dir, "error", err)
+			}
+		}
+	}
+}
+
+// Backup writes one snapshot and deletes snapshots beyond the retention count.
+// It returns the path of the new snapshot.
+func (s *Scheduler) Backup(ctx context.Context) (string, error) {
+	start := time.Now()
+
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	path := filepath.Join(s.dir, snapshotPrefix+s.now().UTC().Format(timestampLayout)+snapshotExt)
+
+	// VACUUM INTO refuses to overwrite, and writing to a temporary name means a
+	// crash never leaves a partial file that looks like a snapshot
+	tmp := path + ".tmp"
+	if err := os.Remove(tmp); err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to remove stale temporary backup: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, "VACUUM INTO ?", tmp); err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("failed to write backup: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("failed to move backup into place: %w", err)
+	}
+
+	removed, err := s.prune()
+	if err != nil {
+		return path, err
+	}
+
+	s.logger.Info("backed up database", "path", path, "removed", removed, "duration", time.Since(start))
+	return path, nil
+}
+
+// Snapshots returns the paths of the snapshots in the backup directory, oldest first
+func (s *Scheduler) Snapshots() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list backup directory: %w", err)
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, snapshotPrefix) || !strings.HasSuffix(name, snapshotExt) {
+			continue
+		}
+		// Skip anything that merely looks similar, so unrelated files are never pruned
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, snapshotPrefix), snapshotExt)
+		if _, err := time.Parse(timestampLayout, stamp); err != nil {
+			continue
+		}
+		paths = append(paths, filepath.Join(s.dir, name))
+	}
+
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// prune deletes the oldest snapshots beyond the retention count
+func (s *Scheduler) prune() (int, error) {
+	paths, err := s.Snapshots()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for len(paths)-removed > s.keep {
+		if err := os.Remove(paths[removed]); err != nil {
+			return removed, fmt.Errorf("failed to remove old backup: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
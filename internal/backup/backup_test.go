@@ -0,0 +1,144 @@
+package backup_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/backup"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/sqlitedb"
+)
+
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sqlitedb.Open(filepath.Join(t.TempDir(), "source.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec("CREATE TABLE notes (id INTEGER PRIMARY KEY, title TEXT)")
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO notes (title) VALUES ('one'), ('two')")
+	require.NoError(t, err)
+
+	return db
+}
+
+// fakeClock returns a clock that advances by a minute on every call
+func fakeClock() func() time.Time {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		now = now.Add(time.Minute)
+		return now
+	}
+}
+
+func TestScheduler_Backup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("snapshot is a readable copy", func(t *testing.T) {
+		db := newTestDB(t)
+		dir := filepath.Join(t.TempDir(), "backups")
+		scheduler := backup.New(db, dir, time.Hour)
+
+		path, err := scheduler.Backup(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, dir, filepath.Dir(path))
+
+		snapshot, err := sqlitedb.Open(path)
+		require.NoError(t, err)
+		defer snapshot.Close()
+
+		var count int
+		require.NoError(t, snapshot.QueryRow("SELECT COUNT(*) FROM notes").Scan(&count))
+		assert.Equal(t, 2, count)
+	})
+
+	tests := []struct {
+		name      string
+		keep      int
+		backups   int
+		wantCount int
+	}{
+		{name: "fewer backups than retention", keep: 3, backups: 2, wantCount: 2},
+		{name: "oldest snapshots are rotated out", keep: 3, backups: 5, wantCount: 3},
+		{name: "keep one", keep: 1, backups: 3, wantCount: 1},
+		{name: "zero falls back to the default", keep: 0, backups: backup.DefaultKeep + 2, wantCount: backup.DefaultKeep},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+			dir := t.TempDir()
+			scheduler := backup.New(db, dir, time.Hour, backup.WithKeep(tt.keep), backup.WithClock(fakeClock()))
+
+			var written []string
+			for i := 0; i < tt.backups; i++ {
+				path, err := scheduler.Backup(ctx)
+				require.NoError(t, err)
+				written = append(written, path)
+			}
+
+			snapshots, err := scheduler.Snapshots()
+			require.NoError(t, err)
+			assert.Equal(t, written[len(written)-tt.wantCount:], snapshots)
+		})
+	}
+
+	t.Run("unrelated files are never pruned", func(t *testing.T) {
+		db := newTestDB(t)
+		dir := t.TempDir()
+		for _, name := range []string{"notes.txt", "snapshot-latest.db"} {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("keep me"), 0o644))
+		}
+		scheduler := backup.New(db, dir, time.Hour, backup.WithKeep(1), backup.WithClock(fakeClock()))
+
+		for i := 0; i < 3; i++ {
+			_, err := scheduler.Backup(ctx)
+			require.NoError(t, err)
+		}
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 3)
+		assert.FileExists(t, filepath.Join(dir, "notes.txt"))
+		assert.FileExists(t, filepath.Join(dir, "snapshot-latest.db"))
+	})
+}
+
+func TestScheduler_Run(t *testing.T) {
+	db := newTestDB(t)
+	dir := t.TempDir()
+	scheduler := backup.New(db, dir, 10*time.Millisecond, backup.WithKeep(2), backup.WithClock(fakeClock()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		scheduler.Run(ctx)
+		close(done)
+	}()
+
+	// Rotation keeps the count at the retention limit once it is reached
+	assert.Eventually(t, func() bool {
+		snapshots, err := scheduler.Snapshots()
+		return err == nil && len(snapshots) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not stop after the context was cancelled")
+	}
+
+	// No temporary files are left behind
+	matches, err := filepath.Glob(filepath.Join(dir, "*.tmp"))
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
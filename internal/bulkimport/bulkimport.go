@@ -0,0 +1,528 @@
+// Package bulkimport restores a graph bundle into the database in one pass.
+//
+// A bundle is loaded with its original note and connection IDs, so
+// connections can reference notes in any order. Foreign key checks are turned
+// off while the rows are inserted, which saves a lookup per row on large
+// graphs, and the whole bundle is checked with PRAGMA foreign_key_check
+// before it is committed. The rows are also held to the rules the storages
+// apply to every create: knowledge base references, self-connections,
+// description lengths, the unique edge mode and, when enabled, note URLs and
+// unique content. A bundle that
+// leaves any reference dangling or breaks any rule is rolled back and its
+// violations are reported.
+package bulkimport
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/graph"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/logging"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/sqlitedb"
+)
+
+// Violation is a row that would leave the database inconsistent. A row whose
+// foreign key points at a row that does not exist, as reported by PRAGMA
+// foreign_key_check, names the Parent table. A row that breaks an integrity
+// rule gives the Reason instead.
+type Violation struct {
+	Table  string `json:"table"`
+	RowID  int64  `json:"row_id"`
+	Parent string `json:"parent,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Result reports a bulk import. When Violations is not empty the import was
+// rolled back, and Notes and Connections count the rows that were checked.
+type Result struct {
+	Notes       int64       `json:"notes"`
+	Connections int64       `json:"connections"`
+	Violations  []Violation `json:"violations"`
+	Duration    string      `json:"duration"`
+}
+
+// Imported reports whether the bundle was committed
+func (r *Result) Imported() bool {
+	return len(r.Violations) == 0
+}
+
+// Importer loads graph bundles into a database
+type Importer struct {
+	db            *sql.DB
+	logger        *slog.Logger
+	edgeMode      connection.EdgeMode
+	validateURLs  bool
+	uniqueContent bool
+}
+
+// Option configures an Importer
+type Option func(*Importer)
+
+// WithLogger sets the logger used to report each import
+func WithLogger(logger *slog.Logger) Option {
+	return func(i *Importer) {
+		i.logger = logging.OrDiscard(logger)
+	}
+}
+
+// WithEdgeMode sets whether a bundle may hold several connections of the same
+// type between two notes, as the connection storage's option of the same
+// name does. The default is connection.EdgeModeUnique.
+func WithEdgeMode(mode connection.EdgeMode) Option {
+	return func(i *Importer) {
+		i.edgeMode = mode
+	}
+}
+
+// WithURLValidation rejects link and image notes whose content is not an
+// absolute http or https URL, as the note storage's option of the same name
+// does
+func WithURLValidation(enabled bool) Option {
+	return func(i *Importer) {
+		i.validateURLs = enabled
+	}
+}
+
+// WithUniqueContent rejects notes whose content matches another note's up to
+// whitespace, as the note storage's option of the same name does
+func WithUniqueContent(enabled bool) Option {
+	return func(i *Importer) {
+		i.uniqueContent = enabled
+	}
+}
+
+// New returns an Importer that writes to db
+func New(db *sql.DB, opts ...Option) *Importer {
+	i := &Importer{db: db, logger: logging.Discard(), edgeMode: connection.EdgeModeUnique}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+// BulkImport inserts every note and connection of bundle with its ID. Note
+// types, connection types and strengths are validated first. Connection
+// counts and content hashes are derived by the database as usual rather
+// than read from the bundle. An ID already in use fails the import. Rows
+// that break a foreign key or an integrity rule are reported in the result
+// and nothing is imported.
+//
+// The rows are inserted on one connection with foreign keys off. SQLite
+// ignores that pragma inside a transaction, so it is set before the
+// transaction begins and restored after it ends; a connection that cannot be
+// restored is discarded rather than returned to the pool.
+func (i *Importer) BulkImport(ctx context.Context, bundle *graph.ExportBundle) (*Result, error) {
+	if bundle == nil {
+		return nil, fmt.Errorf("bundle is required")
+	}
+	if err := validate(bundle); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	conn, err := i.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	var foreignKeys bool
+	if err := conn.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&foreignKeys); err != nil {
+		return nil, fmt.Errorf("failed to read foreign key setting: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF"); err != nil {
+		return nil, fmt.Errorf("failed to disable foreign keys: %w", err)
+	}
+	defer func() {
+		if !foreignKeys {
+			return
+		}
+		// A context that ended must not keep the connection from being restored
+		if _, err := conn.ExecContext(context.Background(), "PRAGMA foreign_keys = ON"); err != nil {
+			i.logger.Error("failed to re-enable foreign keys, discarding connection", "error", err)
+			_ = conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+		}
+	}()
+
+	result, err := i.load(ctx, conn, bundle)
+	if err != nil {
+		return nil, err
+	}
+	result.Duration = time.Since(start).String()
+
+	i.logger.Info("bulk imported bundle", "notes", result.Notes, "connections", result.Connections,
+		"violations", len(result.Violations), "imported", result.Imported(), "duration", result.Duration)
+	return result, nil
+}
+
+// validate rejects a bundle the application would never have stored
+func validate(bundle *graph.ExportBundle) error {
+	for _, n := range bundle.Notes {
+		if n.ID <= 0 {
+			return fmt.Errorf("note IDs must be positive, got: %d", n.ID)
+		}
+		if !note.IsValidNoteType(n.Type) {
+			return fmt.Errorf("note %d: invalid note type: %s", n.ID, n.Type)
+		}
+	}
+	for _, c := range bundle.Connections {
+		if c.ID <= 0 {
+			return fmt.Errorf("connection IDs must be positive, got: %d", c.ID)
+		}
+		if !connection.IsValidConnectionType(c.Type) {
+			return fmt.Errorf("connection %d: invalid connection type: %s", c.ID, c.Type)
+		}
+		if err := connection.ValidateStrength(c.Strength); err != nil {
+			return fmt.Errorf("connection %d: %w", c.ID, err)
+		}
+	}
+	return nil
+}
+
+// load inserts the bundle in a transaction on conn and commits it when the
+// foreign key and integrity checks pass
+func (i *Importer) load(ctx context.Context, conn *sql.Conn, bundle *graph.ExportBundle) (*Result, error) {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result := &Result{Violations: []Violation{}}
+
+	insertNote, err := tx.PrepareContext(ctx, `
+		INSERT INTO notes (id, title, content, content_hash, type, tags, metadata, source, knowledge_base_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare note insert: %w", err)
+	}
+	defer insertNote.Close()
+
+	for _, n := range bundle.Notes {
+		tags := n.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		tagsJSON, err := json.Marshal(tags)
+		if err != nil {
+			return nil, fmt.Errorf("note %d: failed to marshal tags: %w", n.ID, err)
+		}
+		metadataJSON, err := json.Marshal(n.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("note %d: failed to marshal metadata: %w", n.ID, err)
+		}
+
+		_, err = insertNote.ExecContext(ctx, n.ID, n.Title, n.Content, note.ContentHash(n.Content), n.Type,
+			string(tagsJSON), string(metadataJSON), n.Source, n.KnowledgeBaseID, timestamp(n.CreatedAt), timestamp(n.UpdatedAt))
+		if err != nil {
+			return nil, fmt.Errorf("failed to import note %d: %w", n.ID, err)
+		}
+		result.Notes++
+	}
+
+	insertConnection, err := tx.PrepareContext(ctx, `
+		INSERT INTO connections (id, from_note_id, to_note_id, type, description, strength, metadata, source, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare connection insert: %w", err)
+	}
+	defer insertConnection.Close()
+
+	for _, c := range bundle.Connections {
+		metadata := c.Metadata
+		if metadata == nil {
+			metadata = map[string]interface{}{}
+		}
+		metadataJSON, err := json.Marshal(metadata)
+		if err != nil {
+			return nil, fmt.Errorf("connection %d: failed to marshal metadata: %w", c.ID, err)
+		}
+
+		_, err = insertConnection.ExecContext(ctx, c.ID, c.FromNoteID, c.ToNoteID, c.Type, c.Description, c.Strength,
+			string(metadataJSON), c.Source, timestamp(c.CreatedAt), timestamp(c.UpdatedAt))
+		// The unique edge mode's index refuses a parallel connection before
+		// the duplicate check below can see it, so it is reported here
+		if err != nil && isUniquePairError(err) {
+			violation, err := parallelConnection(ctx, tx, c)
+			if err != nil {
+				return nil, err
+			}
+			result.Violations = append(result.Violations, violation)
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to import connection %d: %w", c.ID, err)
+		}
+		result.Connections++
+	}
+
+	foreignKeys, err := foreignKeyViolations(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := i.ruleViolations(ctx, tx, bundle)
+	if err != nil {
+		return nil, err
+	}
+	// Foreign key violations come first, then the rules
+	violations := append(foreignKeys, result.Violations...)
+	result.Violations = append(violations, rules...)
+	if !result.Imported() {
+		return result, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return result, nil
+}
+
+// foreignKeyViolations runs PRAGMA foreign_key_check over the whole database
+func foreignKeyViolations(ctx context.Context, tx *sql.Tx) ([]Violation, error) {
+	rows, err := tx.QueryContext(ctx, "PRAGMA foreign_key_check")
+	if err != nil {
+		return nil, fmt.Errorf("failed to check foreign keys: %w", err)
+	}
+	defer rows.Close()
+
+	violations := []Violation{}
+	for rows.Next() {
+		var v Violation
+		var rowID sql.NullInt64
+		var fkIndex int64
+		if err := rows.Scan(&v.Table, &rowID, &v.Parent, &fkIndex); err != nil {
+			return nil, fmt.Errorf("failed to scan foreign key violation: %w", err)
+		}
+		v.RowID = rowID.Int64
+		violations = append(violations, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return violations, nil
+}
+
+// ruleViolations checks the bundle against the rules the storages apply to
+// every create. tx already holds the bundle's rows, so duplicates are found
+// both within the bundle and against the notes and connections stored before.
+func (i *Importer) ruleViolations(ctx context.Context, tx *sql.Tx, bundle *graph.ExportBundle) ([]Violation, error) {
+	violations := []Violation{}
+
+	if i.validateURLs {
+		for _, n := range bundle.Notes {
+			if err := note.ValidateContent(n.Type, n.Content); err != nil {
+				violations = append(violations, Violation{Table: "notes", RowID: n.ID, Reason: err.Error()})
+			}
+		}
+	}
+
+	for _, c := range bundle.Connections {
+		if c.FromNoteID == c.ToNoteID && !connection.AllowsSelfLoop(c.Type) {
+			violations = append(violations, Violation{Table: "connections", RowID: c.ID,
+				Reason: fmt.Sprintf("self-connections are not allowed for %s connections", c.Type)})
+		}
+		if err := connection.ValidateDescription(c.Description); err != nil {
+			violations = append(violations, Violation{Table: "connections", RowID: c.ID, Reason: err.Error()})
+		}
+	}
+
+	missing, err := missingKnowledgeBases(ctx, tx, bundle)
+	if err != nil {
+		return nil, err
+	}
+	violations = append(violations, missing...)
+
+	if i.uniqueContent {
+		duplicates, err := duplicateContent(ctx, tx, bundle)
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, duplicates...)
+	}
+
+	if i.edgeMode != connection.EdgeModeMulti {
+		duplicates, err := duplicateConnections(ctx, tx, bundle)
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, duplicates...)
+	}
+
+	return violations, nil
+}
+
+// missingKnowledgeBases reports each bundle note whose knowledge base does
+// not exist. notes.knowledge_base_id has no foreign key, so PRAGMA
+// foreign_key_check cannot see these.
+func missingKnowledgeBases(ctx context.Context, tx *sql.Tx, bundle *graph.ExportBundle) ([]Violation, error) {
+	ids := make(map[int64]bool, len(bundle.Notes))
+	for _, n := range bundle.Notes {
+		ids[n.ID] = true
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, knowledge_base_id FROM notes
+		WHERE knowledge_base_id IS NOT NULL
+			AND NOT EXISTS (SELECT 1 FROM knowledge_base WHERE knowledge_base.id = notes.knowledge_base_id)
+		ORDER BY id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check knowledge base references: %w", err)
+	}
+	defer rows.Close()
+
+	violations := []Violation{}
+	for rows.Next() {
+		var id, kbID int64
+		if err := rows.Scan(&id, &kbID); err != nil {
+			return nil, fmt.Errorf("failed to scan knowledge base reference: %w", err)
+		}
+		// Notes stored before the import are not the bundle's to fix
+		if ids[id] {
+			violations = append(violations, Violation{Table: "notes", RowID: id,
+				Reason: fmt.Errorf("%w: %d", knowledgebase.ErrNotFound, kbID).Error()})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+	return violations, nil
+}
+
+// duplicateContent reports each bundle note whose content hash another note
+// shares. Blank content is never a duplicate.
+func duplicateContent(ctx context.Context, tx *sql.Tx, bundle *graph.ExportBundle) ([]Violation, error) {
+	ids := make(map[int64]bool, len(bundle.Notes))
+	for _, n := range bundle.Notes {
+		ids[n.ID] = true
+	}
+
+	groups, err := duplicateGroups(ctx, tx, `
+		SELECT json_group_array(id) FROM notes
+		WHERE content_hash IS NOT NULL AND content_hash != ?
+		GROUP BY content_hash
+		HAVING COUNT(*) > 1
+	`, note.ContentHash(""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for duplicate content: %w", err)
+	}
+
+	violations := []Violation{}
+	for _, group := range groups {
+		kept := keptRow(group, ids)
+		for _, id := range group {
+			if id != kept && ids[id] {
+				violations = append(violations, Violation{Table: "notes", RowID: id,
+					Reason: fmt.Errorf("%w: note %d", note.ErrDuplicateContent, kept).Error()})
+			}
+		}
+	}
+	return violations, nil
+}
+
+// duplicateConnections reports each bundle connection that repeats the notes,
+// direction and type of another connection. The exact reverse of a
+// symmetric connection is no duplicate: create_connection stores it on
+// purpose with auto_symmetric, so an exported pair must import again.
+func duplicateConnections(ctx context.Context, tx *sql.Tx, bundle *graph.ExportBundle) ([]Violation, error) {
+	ids := make(map[int64]bool, len(bundle.Connections))
+	for _, c := range bundle.Connections {
+		ids[c.ID] = true
+	}
+
+	groups, err := duplicateGroups(ctx, tx, `
+		SELECT json_group_array(id) FROM connections
+		GROUP BY from_note_id, to_note_id, type
+		HAVING COUNT(*) > 1
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for duplicate connections: %w", err)
+	}
+
+	violations := []Violation{}
+	for _, group := range groups {
+		kept := keptRow(group, ids)
+		for _, id := range group {
+			if id != kept && ids[id] {
+				violations = append(violations, Violation{Table: "connections", RowID: id,
+					Reason: fmt.Sprintf("connection already exists between these notes with this type: connection %d", kept)})
+			}
+		}
+	}
+	return violations, nil
+}
+
+// duplicateGroups runs query, which returns one JSON array of row IDs per
+// group of duplicates, and returns each group in ID order
+func duplicateGroups(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) ([][]int64, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups [][]int64
+	for rows.Next() {
+		var idsJSON string
+		if err := rows.Scan(&idsJSON); err != nil {
+			return nil, err
+		}
+		var group []int64
+		if err := json.Unmarshal([]byte(idsJSON), &group); err != nil {
+			return nil, err
+		}
+		slices.Sort(group)
+		groups = append(groups, group)
+	}
+	return groups, rows.Err()
+}
+
+// keptRow returns the row of a duplicate group that the others duplicate: the
+// oldest row stored before the import, or the bundle's first row when the
+// whole group comes from the bundle. group is in ID order.
+func keptRow(group []int64, bundleIDs map[int64]bool) int64 {
+	for _, id := range group {
+		if !bundleIDs[id] {
+			return id
+		}
+	}
+	return group[0]
+}
+
+// isUniquePairError reports whether err is the unique edge mode's index
+// refusing a second connection of one type between the same notes
+func isUniquePairError(err error) bool {
+	return strings.Contains(err.Error(), "UNIQUE constraint failed: connections.from_note_id")
+}
+
+// parallelConnection reports c, which the unique connection index refused,
+// as a duplicate of the connection already holding its notes and type
+func parallelConnection(ctx context.Context, tx *sql.Tx, c connection.Connection) (Violation, error) {
+	var kept int64
+	err := tx.QueryRowContext(ctx, "SELECT id FROM connections WHERE from_note_id = ? AND to_note_id = ? AND type = ?",
+		c.FromNoteID, c.ToNoteID, c.Type).Scan(&kept)
+	if err != nil {
+		return Violation{}, fmt.Errorf("failed to find the connection %d duplicates: %w", c.ID, err)
+	}
+	return Violation{Table: "connections", RowID: c.ID,
+		Reason: fmt.Sprintf("connection already exists between these notes with this type: connection %d", kept)}, nil
+}
+
+// timestamp formats t as the schema stores timestamps, using now for a
+// bundle that left it out
+func timestamp(t time.Time) string {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	return t.UTC().Format(sqlitedb.TimestampLayout)
+}
@@ -0,0 +1,459 @@
+package bulkimport_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/bulkimport"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	connstorage "github.com/red1r3ct/knowledge-graph-mcp/internal/connection/sqlite"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/graph"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/migrations"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	notestorage "github.com/red1r3ct/knowledge-graph-mcp/internal/note/sqlite"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/sqlitedb"
+)
+
+// newTestDB returns a fully migrated, empty database
+func newTestDB(t *testing.T, opts ...migrations.Option) *sql.DB {
+	t.Helper()
+
+	dbPath := filepath.Join(t.TempDir(), "bulkimport.db")
+	require.NoError(t, migrations.NewMigrationRunner(dbPath, opts...).RunMigrations())
+
+	db, err := sqlitedb.Open(dbPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// largeBundle returns notes notes, each connected to the next perNote notes
+func largeBundle(notes, perNote int) *graph.ExportBundle {
+	bundle := &graph.ExportBundle{}
+	for i := 1; i <= notes; i++ {
+		bundle.Notes = append(bundle.Notes, note.Note{
+			ID:      int64(i),
+			Title:   fmt.Sprintf("Note %d", i),
+			Content: fmt.Sprintf("content of note %d", i),
+			Type:    "text",
+			Tags:    []string{"bulk"},
+		})
+	}
+	id := int64(1)
+	for i := 1; i <= notes; i++ {
+		for k := 1; k <= perNote; k++ {
+			bundle.Connections = append(bundle.Connections, connection.Connection{
+				ID:         id,
+				FromNoteID: int64(i),
+				ToNoteID:   int64((i+k-1)%notes + 1),
+				Type:       "relates_to",
+				Strength:   5,
+			})
+			id++
+		}
+	}
+	return bundle
+}
+
+// withKnowledgeBase puts the note noteID of bundle in the knowledge base kbID
+func withKnowledgeBase(bundle *graph.ExportBundle, noteID, kbID int64) *graph.ExportBundle {
+	for i := range bundle.Notes {
+		if bundle.Notes[i].ID == noteID {
+			bundle.Notes[i].KnowledgeBaseID = &kbID
+		}
+	}
+	return bundle
+}
+
+// countRows returns the number of rows in table
+func countRows(t *testing.T, db *sql.DB, table string) int64 {
+	t.Helper()
+	var n int64
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM "+table).Scan(&n))
+	return n
+}
+
+func TestBulkImport(t *testing.T) {
+	dangling := largeBundle(3, 1)
+	dangling.Connections = append(dangling.Connections,
+		connection.Connection{ID: 10, FromNoteID: 1, ToNoteID: 99, Type: "relates_to", Strength: 5},
+		connection.Connection{ID: 11, FromNoteID: 98, ToNoteID: 2, Type: "relates_to", Strength: 5},
+	)
+
+	badNoteType := largeBundle(2, 1)
+	badNoteType.Notes[1].Type = "nope"
+
+	badStrength := largeBundle(2, 1)
+	badStrength.Connections[0].Strength = 11
+
+	tests := []struct {
+		name            string
+		bundle          *graph.ExportBundle
+		wantErr         string
+		wantNotes       int64
+		wantConnections int64
+		wantViolations  int
+		wantImported    bool
+	}{
+		{
+			name:            "large bundle",
+			bundle:          largeBundle(2000, 3),
+			wantNotes:       2000,
+			wantConnections: 6000,
+			wantImported:    true,
+		},
+		{
+			name:            "dangling connections are reported and rolled back",
+			bundle:          dangling,
+			wantNotes:       3,
+			wantConnections: 5,
+			wantViolations:  2,
+		},
+		{
+			name:    "invalid note type",
+			bundle:  badNoteType,
+			wantErr: "note 2: invalid note type: nope",
+		},
+		{
+			name:    "invalid strength",
+			bundle:  badStrength,
+			wantErr: "connection 1:",
+		},
+		{
+			name:    "nil bundle",
+			wantErr: "bundle is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+			importer := bulkimport.New(db)
+
+			result, err := importer.BulkImport(context.Background(), tt.bundle)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Zero(t, countRows(t, db, "notes"))
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantNotes, result.Notes)
+			assert.Equal(t, tt.wantConnections, result.Connections)
+			assert.Len(t, result.Violations, tt.wantViolations)
+			assert.Equal(t, tt.wantImported, result.Imported())
+
+			if !tt.wantImported {
+				for _, v := range result.Violations {
+					assert.Equal(t, "connections", v.Table)
+					assert.Equal(t, "notes", v.Parent)
+				}
+				assert.Zero(t, countRows(t, db, "notes"))
+				assert.Zero(t, countRows(t, db, "connections"))
+				return
+			}
+
+			assert.Equal(t, tt.wantNotes, countRows(t, db, "notes"))
+			assert.Equal(t, tt.wantConnections, countRows(t, db, "connections"))
+
+			// The count triggers still ran for every connection
+			var outgoing, incoming int64
+			require.NoError(t, db.QueryRow("SELECT outgoing_count, incoming_count FROM notes WHERE id = 1").Scan(&outgoing, &incoming))
+			assert.Equal(t, int64(3), outgoing)
+			assert.Equal(t, int64(3), incoming)
+
+			// The imported notes are searchable
+			var matches int64
+			require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM notes_fts WHERE notes_fts MATCH 'content'").Scan(&matches))
+			assert.Equal(t, tt.wantNotes, matches)
+		})
+	}
+}
+
+func TestBulkImportRestoresForeignKeys(t *testing.T) {
+	db := newTestDB(t)
+	// One connection makes every query after the import reuse it
+	db.SetMaxOpenConns(1)
+
+	var before bool
+	require.NoError(t, db.QueryRow("PRAGMA foreign_keys").Scan(&before))
+	require.True(t, before, "the driver enables foreign keys")
+
+	importer := bulkimport.New(db)
+	_, err := importer.BulkImport(context.Background(), largeBundle(10, 2))
+	require.NoError(t, err)
+
+	var after bool
+	require.NoError(t, db.QueryRow("PRAGMA foreign_keys").Scan(&after))
+	assert.True(t, after)
+
+	// A connection that breaks a foreign key is refused again after the import
+	_, err = db.Exec("INSERT INTO connections (from_note_id, to_note_id, type, strength) VALUES (1, 999, 'relates_to', 5)")
+	assert.Error(t, err)
+
+	// IDs already in use fail the import as a whole
+	_, err = importer.BulkImport(context.Background(), largeBundle(10, 2))
+	require.Error(t, err)
+	assert.Equal(t, int64(10), countRows(t, db, "notes"))
+}
+
+func TestBulkImportIntegrityRules(t *testing.T) {
+	longDescription := strings.Repeat("x", connection.MaxDescriptionLength+1)
+	maxDescription := strings.Repeat("x", connection.MaxDescriptionLength)
+
+	// notes returns text notes with the given IDs and distinct content
+	notes := func(ids ...int64) []note.Note {
+		var items []note.Note
+		for _, id := range ids {
+			items = append(items, note.Note{ID: id, Title: fmt.Sprintf("Note %d", id), Content: fmt.Sprintf("content %d", id), Type: "text"})
+		}
+		return items
+	}
+	conn := func(id, from, to int64, connType string) connection.Connection {
+		return connection.Connection{ID: id, FromNoteID: from, ToNoteID: to, Type: connType, Strength: 5}
+	}
+
+	tests := []struct {
+		name           string
+		opts           []bulkimport.Option
+		edgeMode       connection.EdgeMode // Edge mode the database is migrated with
+		existing       string              // SQL run before the import
+		bundle         *graph.ExportBundle
+		wantViolations []bulkimport.Violation
+	}{
+		{
+			name: "self-loop of a type that allows it",
+			bundle: &graph.ExportBundle{
+				Notes:       notes(1),
+				Connections: []connection.Connection{conn(1, 1, 1, "relates_to")},
+			},
+		},
+		{
+			name: "self-loop of a type that does not allow it",
+			bundle: &graph.ExportBundle{
+				Notes:       notes(1),
+				Connections: []connection.Connection{conn(1, 1, 1, "supports")},
+			},
+			wantViolations: []bulkimport.Violation{
+				{Table: "connections", RowID: 1, Reason: "self-connections are not allowed for supports connections"},
+			},
+		},
+		{
+			name: "description at the limit",
+			bundle: &graph.ExportBundle{
+				Notes:       notes(1, 2),
+				Connections: []connection.Connection{{ID: 1, FromNoteID: 1, ToNoteID: 2, Type: "supports", Strength: 5, Description: &maxDescription}},
+			},
+		},
+		{
+			name: "description over the limit",
+			bundle: &graph.ExportBundle{
+				Notes:       notes(1, 2),
+				Connections: []connection.Connection{{ID: 1, FromNoteID: 1, ToNoteID: 2, Type: "supports", Strength: 5, Description: &longDescription}},
+			},
+			wantViolations: []bulkimport.Violation{
+				{Table: "connections", RowID: 1, Reason: "description must be 500 characters or less"},
+			},
+		},
+		{
+			name: "parallel connections in unique mode",
+			bundle: &graph.ExportBundle{
+				Notes:       notes(1, 2),
+				Connections: []connection.Connection{conn(1, 1, 2, "supports"), conn(2, 1, 2, "supports"), conn(3, 1, 2, "influences")},
+			},
+			wantViolations: []bulkimport.Violation{
+				{Table: "connections", RowID: 2, Reason: "connection already exists between these notes with this type: connection 1"},
+			},
+		},
+		{
+			name: "exact reverse of a symmetric connection in unique mode",
+			bundle: &graph.ExportBundle{
+				Notes:       notes(1, 2),
+				Connections: []connection.Connection{conn(1, 1, 2, "contradicts"), conn(2, 2, 1, "contradicts")},
+			},
+		},
+		{
+			name: "reverse of a directed connection in unique mode",
+			bundle: &graph.ExportBundle{
+				Notes:       notes(1, 2),
+				Connections: []connection.Connection{conn(1, 1, 2, "supports"), conn(2, 2, 1, "supports")},
+			},
+		},
+		{
+			name: "duplicate of a stored connection in unique mode",
+			existing: `INSERT INTO notes (id, title, content, type) VALUES (1, 'Stored', 'stored', 'text');
+				INSERT INTO connections (id, from_note_id, to_note_id, type, strength) VALUES (50, 1, 1, 'similar_to', 5)`,
+			bundle: &graph.ExportBundle{
+				Notes:       notes(2),
+				Connections: []connection.Connection{conn(1, 1, 2, "similar_to"), conn(2, 2, 1, "similar_to"), conn(3, 1, 1, "similar_to")},
+			},
+			wantViolations: []bulkimport.Violation{
+				{Table: "connections", RowID: 3, Reason: "connection already exists between these notes with this type: connection 50"},
+			},
+		},
+		{
+			name:     "parallel and reverse connections in multi mode",
+			opts:     []bulkimport.Option{bulkimport.WithEdgeMode(connection.EdgeModeMulti)},
+			edgeMode: connection.EdgeModeMulti,
+			bundle: &graph.ExportBundle{
+				Notes:       notes(1, 2),
+				Connections: []connection.Connection{conn(1, 1, 2, "supports"), conn(2, 1, 2, "supports"), conn(3, 2, 1, "contradicts"), conn(4, 1, 2, "contradicts")},
+			},
+		},
+		{
+			name: "symmetric connection repeated in one direction in unique mode",
+			bundle: &graph.ExportBundle{
+				Notes:       notes(1, 2),
+				Connections: []connection.Connection{conn(1, 1, 2, "relates_to"), conn(2, 2, 1, "relates_to"), conn(3, 2, 1, "relates_to")},
+			},
+			wantViolations: []bulkimport.Violation{
+				{Table: "connections", RowID: 3, Reason: "connection already exists between these notes with this type: connection 2"},
+			},
+		},
+		{
+			name:     "existing knowledge base",
+			existing: `INSERT INTO knowledge_base (id, name) VALUES (7, 'Work')`,
+			bundle:   withKnowledgeBase(largeBundle(3, 1), 2, 7),
+		},
+		{
+			name:   "missing knowledge base",
+			bundle: withKnowledgeBase(largeBundle(3, 1), 2, 7),
+			wantViolations: []bulkimport.Violation{
+				{Table: "notes", RowID: 2, Reason: "knowledge base not found: 7"},
+			},
+		},
+		{
+			name: "link without a URL and URL validation off",
+			bundle: &graph.ExportBundle{
+				Notes: []note.Note{{ID: 1, Title: "Reading", Content: "Reading", Type: "link"}},
+			},
+		},
+		{
+			name: "link without a URL and URL validation on",
+			opts: []bulkimport.Option{bulkimport.WithURLValidation(true)},
+			bundle: &graph.ExportBundle{
+				Notes: []note.Note{
+					{ID: 1, Title: "Reading", Content: "Reading", Type: "link"},
+					{ID: 2, Title: "Site", Content: "https://example.com", Type: "link"},
+				},
+			},
+			wantViolations: []bulkimport.Violation{
+				{Table: "notes", RowID: 1, Reason: "link note content: content must be an absolute http or https URL: scheme must be http or https"},
+			},
+		},
+		{
+			name: "duplicate content and unique content off",
+			bundle: &graph.ExportBundle{
+				Notes: []note.Note{{ID: 1, Title: "A", Content: "same", Type: "text"}, {ID: 2, Title: "B", Content: "same", Type: "text"}},
+			},
+		},
+		{
+			name:     "duplicate content and unique content on",
+			opts:     []bulkimport.Option{bulkimport.WithUniqueContent(true)},
+			existing: `INSERT INTO notes (id, title, content, content_hash, type) VALUES (50, 'Stored', 'stored body', '` + note.ContentHash("stored body") + `', 'text')`,
+			bundle: &graph.ExportBundle{
+				Notes: []note.Note{
+					{ID: 1, Title: "A", Content: "same", Type: "text"},
+					{ID: 2, Title: "B", Content: " same\n", Type: "text"},
+					{ID: 3, Title: "C", Content: "stored  body", Type: "text"},
+					{ID: 4, Title: "D", Content: "", Type: "text"},
+					{ID: 5, Title: "E", Content: " ", Type: "text"},
+				},
+			},
+			wantViolations: []bulkimport.Violation{
+				{Table: "notes", RowID: 3, Reason: "a note with the same content already exists: note 50"},
+				{Table: "notes", RowID: 2, Reason: "a note with the same content already exists: note 1"},
+			},
+		},
+		{
+			name: "violations of several rules are all reported",
+			opts: []bulkimport.Option{bulkimport.WithURLValidation(true)},
+			bundle: &graph.ExportBundle{
+				Notes:       []note.Note{{ID: 1, Title: "Picture", Content: "picture", Type: "image"}},
+				Connections: []connection.Connection{conn(1, 1, 9, "relates_to"), conn(2, 1, 1, "depends_on")},
+			},
+			wantViolations: []bulkimport.Violation{
+				{Table: "connections", RowID: 1, Parent: "notes"},
+				{Table: "notes", RowID: 1, Reason: "image note content: content must be an absolute http or https URL: scheme must be http or https"},
+				{Table: "connections", RowID: 2, Reason: "self-connections are not allowed for depends_on connections"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var migrationOpts []migrations.Option
+			if tt.edgeMode != "" {
+				migrationOpts = append(migrationOpts, migrations.WithEdgeMode(tt.edgeMode))
+			}
+			db := newTestDB(t, migrationOpts...)
+			if tt.existing != "" {
+				_, err := db.Exec(tt.existing)
+				require.NoError(t, err)
+			}
+			notesBefore := countRows(t, db, "notes")
+			connectionsBefore := countRows(t, db, "connections")
+
+			result, err := bulkimport.New(db, tt.opts...).BulkImport(context.Background(), tt.bundle)
+			require.NoError(t, err)
+
+			if len(tt.wantViolations) > 0 {
+				assert.ElementsMatch(t, tt.wantViolations, result.Violations)
+				assert.False(t, result.Imported())
+				assert.Equal(t, notesBefore, countRows(t, db, "notes"), "a rejected bundle must not import notes")
+				assert.Equal(t, connectionsBefore, countRows(t, db, "connections"), "a rejected bundle must not import connections")
+				return
+			}
+			assert.Empty(t, result.Violations)
+			assert.True(t, result.Imported())
+			assert.Equal(t, notesBefore+int64(len(tt.bundle.Notes)), countRows(t, db, "notes"))
+			assert.Equal(t, connectionsBefore+int64(len(tt.bundle.Connections)), countRows(t, db, "connections"))
+		})
+	}
+}
+
+func TestBulkImportRoundTripsAutoSymmetricPairs(t *testing.T) {
+	ctx := context.Background()
+
+	// Build a graph through the storages, as the MCP tools do
+	sourcePath := filepath.Join(t.TempDir(), "source.db")
+	require.NoError(t, migrations.NewMigrationRunner(sourcePath).RunMigrations())
+	notes, err := notestorage.NewStorage(sourcePath)
+	require.NoError(t, err)
+	t.Cleanup(func() { notes.Close() })
+	connections, err := connstorage.NewStorage(sourcePath)
+	require.NoError(t, err)
+	t.Cleanup(func() { connections.Close() })
+
+	a, err := notes.Create(ctx, note.CreateNoteRequest{Title: "A", Content: "first", Type: "text"})
+	require.NoError(t, err)
+	b, err := notes.Create(ctx, note.CreateNoteRequest{Title: "B", Content: "second", Type: "text"})
+	require.NoError(t, err)
+	_, err = connections.Create(ctx, connection.CreateConnectionRequest{
+		FromNoteID: a.ID, ToNoteID: b.ID, Type: "relates_to", Strength: 5, AutoSymmetric: true,
+	})
+	require.NoError(t, err)
+
+	// Export it as list_notes and list_connections return it
+	listedNotes, err := notes.List(ctx, note.ListNotesRequest{Limit: 100})
+	require.NoError(t, err)
+	listedConnections, err := connections.List(ctx, connection.ListConnectionsRequest{Limit: 100})
+	require.NoError(t, err)
+	require.Len(t, listedConnections.Items, 2, "auto_symmetric stores both directions")
+	bundle := &graph.ExportBundle{Notes: listedNotes.Items, Connections: listedConnections.Items}
+
+	db := newTestDB(t)
+	result, err := bulkimport.New(db).BulkImport(ctx, bundle)
+	require.NoError(t, err)
+	assert.Empty(t, result.Violations)
+	assert.True(t, result.Imported())
+	assert.Equal(t, int64(2), countRows(t, db, "connections"))
+}
@@ -0,0 +1,97 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/bulkimport"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/graph"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewBulkImportHandler creates a new handler for importing a graph bundle
+func NewBulkImportHandler(importer *bulkimport.Importer) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse bundle
+		bundle, err := parseBundle(arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := importer.BulkImport(ctx, bundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import bundle: %w", err)
+		}
+
+		jsonData, err := mcpx.MarshalJSON(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("%s\n\n%s", formatResult(result), string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
+
+// parseBundle reads the bundle argument given either as a JSON object or as
+// a string holding one
+func parseBundle(arguments map[string]interface{}) (*graph.ExportBundle, error) {
+	raw, ok := arguments["bundle"]
+	if !ok || raw == nil {
+		return nil, fmt.Errorf("bundle is required")
+	}
+
+	var data []byte
+	switch v := raw.(type) {
+	case string:
+		data = []byte(v)
+	case map[string]interface{}:
+		var err error
+		data, err = json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bundle: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("bundle must be a JSON object or a string holding one")
+	}
+
+	var bundle graph.ExportBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("invalid bundle: %w", err)
+	}
+	return &bundle, nil
+}
+
+// formatResult renders the human-readable part of the result
+func formatResult(result *bulkimport.Result) string {
+	if result.Imported() {
+		return fmt.Sprintf("Imported %d notes and %d connections in %s", result.Notes, result.Connections, result.Duration)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Import rolled back: %d violations in %d notes and %d connections", len(result.Violations), result.Notes, result.Connections)
+	for _, v := range result.Violations {
+		if v.Parent != "" {
+			fmt.Fprintf(&b, "\n- %s row %d references a missing row in %s", v.Table, v.RowID, v.Parent)
+			continue
+		}
+		fmt.Fprintf(&b, "\n- %s row %d: %s", v.Table, v.RowID, v.Reason)
+	}
+	return b.String()
+}
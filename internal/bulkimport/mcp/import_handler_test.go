@@ -0,0 +1,118 @@
+package mcp_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/bulkimport"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/bulkimport/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/migrations"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/sqlitedb"
+)
+
+func TestBulkImportHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        interface{}
+		wantErr     string
+		wantContent []string
+	}{
+		{
+			name: "bundle object",
+			args: map[string]interface{}{
+				"bundle": map[string]interface{}{
+					"notes": []interface{}{
+						map[string]interface{}{"id": 1, "title": "One", "content": "first", "type": "text"},
+						map[string]interface{}{"id": 2, "title": "Two", "content": "second", "type": "text"},
+					},
+					"connections": []interface{}{
+						map[string]interface{}{"id": 1, "from_note_id": 1, "to_note_id": 2, "type": "relates_to", "strength": 5},
+					},
+				},
+			},
+			wantContent: []string{"Imported 2 notes and 1 connections", `"violations": []`},
+		},
+		{
+			name: "dangling connection in a string",
+			args: map[string]interface{}{
+				"bundle": `{"notes": [{"id": 1, "title": "One", "content": "first", "type": "text"}],
+					"connections": [{"id": 4, "from_note_id": 1, "to_note_id": 7, "type": "relates_to", "strength": 5}]}`,
+			},
+			wantContent: []string{
+				"Import rolled back: 1 violations in 1 notes and 1 connections",
+				"- connections row 4 references a missing row in notes",
+			},
+		},
+		{
+			name: "rule violation",
+			args: map[string]interface{}{
+				"bundle": `{"notes": [{"id": 1, "title": "One", "content": "first", "type": "text"}],
+					"connections": [{"id": 2, "from_note_id": 1, "to_note_id": 1, "type": "supports", "strength": 5}]}`,
+			},
+			wantContent: []string{
+				"Import rolled back: 1 violations in 1 notes and 1 connections",
+				"- connections row 2: self-connections are not allowed for supports connections",
+				`"reason": "self-connections are not allowed for supports connections"`,
+			},
+		},
+		{
+			name:    "missing bundle",
+			args:    map[string]interface{}{},
+			wantErr: "bundle is required",
+		},
+		{
+			name:    "invalid bundle type",
+			args:    map[string]interface{}{"bundle": 3},
+			wantErr: "bundle must be a JSON object or a string holding one",
+		},
+		{
+			name:    "invalid bundle JSON",
+			args:    map[string]interface{}{"bundle": "{"},
+			wantErr: "invalid bundle",
+		},
+		{
+			name: "invalid connection type",
+			args: map[string]interface{}{
+				"bundle": `{"connections": [{"id": 1, "from_note_id": 1, "to_note_id": 2, "type": "", "strength": 5}]}`,
+			},
+			wantErr: "failed to import bundle: connection 1: invalid connection type",
+		},
+		{
+			name:    "invalid arguments",
+			args:    "not a map",
+			wantErr: "invalid arguments format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbPath := filepath.Join(t.TempDir(), "bulkimport.db")
+			require.NoError(t, migrations.NewMigrationRunner(dbPath).RunMigrations())
+			db, err := sqlitedb.Open(dbPath)
+			require.NoError(t, err)
+			defer db.Close()
+
+			handler := mcp.NewBulkImportHandler(bulkimport.New(db))
+			req := gomcp.CallToolRequest{}
+			req.Params.Arguments = tt.args
+
+			result, err := handler(context.Background(), req)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			text := result.Content[0].(gomcp.TextContent).Text
+			for _, want := range tt.wantContent {
+				assert.Contains(t, text, want)
+			}
+		})
+	}
+}
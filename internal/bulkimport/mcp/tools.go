@@ -0,0 +1,46 @@
+package mcp
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/bulkimport"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// RegisterTools registers the bulk import MCP tools with the server
+func RegisterTools(s *server.MCPServer, importer *bulkimport.Importer, middleware ...mcpx.Middleware) error {
+	tools := []struct {
+		name        string
+		description string
+		handler     server.ToolHandlerFunc
+		schema      mcp.ToolInputSchema
+	}{
+		{
+			name:        "bulk_import_graph",
+			description: "Import a snapshot of the graph in one transaction, keeping its note and connection IDs. Foreign keys and the rules of create_note and create_connection (self-connections, description length, the edge mode, URL validation and unique content) are checked once the whole bundle is loaded; if any row breaks one, nothing is imported and the violations are reported. Fails if an ID is already in use",
+			handler:     NewBulkImportHandler(importer),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"bundle": map[string]interface{}{
+						"type":        "object",
+						"description": "Snapshot as {\"notes\": [...], \"connections\": [...]}, using the items list_notes and list_connections return. May be given as a JSON string",
+					},
+				},
+				Required: []string{"bundle"},
+			},
+		},
+	}
+
+	for _, tool := range tools {
+		t := mcp.Tool{
+			Name:        tool.name,
+			Description: tool.description,
+			InputSchema: tool.schema,
+		}
+		s.AddTool(t, mcpx.Wrap(tool.name, tool.handler, middleware...))
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package connection
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// DefaultConnectionStrength is the built-in strength for connections created without one
+const DefaultConnectionStrength = 5
+
+// customTypePattern restricts custom connection type names to snake_case identifiers
+var customTypePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// defaultStrength is the active default strength, set with Configure
+var defaultStrength = DefaultConnectionStrength
+
+// Config holds operator settings for connections, applied once at startup
+type Config struct {
+	// DefaultStrength is used when a connection is created without a strength.
+	// Zero selects DefaultConnectionStrength.
+	DefaultStrength int
+	// AllowedTypes restricts the valid connection types to this list. Empty
+	// selects all built-in types. Names that are not built-in are registered
+	// as directional custom types.
+	AllowedTypes []string
+	// ExtraTypes extends the allowed types with additional directional custom types
+	ExtraTypes []string
+	// SelfLoopTypes lists the types that may connect a note to itself. Nil
+	// keeps the built-in policy, under which custom types forbid self-loops;
+	// an empty non-nil slice forbids them for every type.
+	SelfLoopTypes []string
+}
+
+// Configure replaces the active connection type set and default strength.
+// It is not safe for concurrent use and must be called before tools are
+// registered, since tool schemas embed the allowed types. Configure(Config{})
+// restores the built-in defaults.
+func Configure(cfg Config) error {
+	strength := cfg.DefaultStrength
+	if strength == 0 {
+		strength = DefaultConnectionStrength
+	}
+	if err := ValidateStrength(strength); err != nil {
+		return fmt.Errorf("default %w", err)
+	}
+
+	allowed := cfg.AllowedTypes
+	if len(allowed) == 0 {
+		allowed = make([]string, 0, len(builtinConnectionTypes))
+		for _, info := range builtinConnectionTypes {
+			allowed = append(allowed, info.Type)
+		}
+	}
+
+	registry := make([]ConnectionTypeInfo, 0, len(allowed)+len(cfg.ExtraTypes))
+	seen := make(map[string]bool)
+	for _, t := range append(append([]string{}, allowed...), cfg.ExtraTypes...) {
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+
+		info, err := resolveConnectionType(t)
+		if err != nil {
+			return err
+		}
+		registry = append(registry, info)
+	}
+
+	if cfg.SelfLoopTypes != nil {
+		allowSelfLoop := make(map[string]bool)
+		for _, t := range cfg.SelfLoopTypes {
+			if !seen[t] {
+				return fmt.Errorf("self-loop type %q is not an allowed connection type", t)
+			}
+			allowSelfLoop[t] = true
+		}
+		for i := range registry {
+			registry[i].AllowSelfLoop = allowSelfLoop[registry[i].Type]
+		}
+	}
+
+	connectionTypeRegistry = registry
+	defaultStrength = strength
+	return nil
+}
+
+// DefaultStrength returns the strength used for connections created without one
+func DefaultStrength() int {
+	return defaultStrength
+}
+
+// resolveConnectionType returns the built-in semantics of the given type, or
+// describes it as a directional custom type
+func resolveConnectionType(connectionType string) (ConnectionTypeInfo, error) {
+	for _, info := range builtinConnectionTypes {
+		if info.Type == connectionType {
+			return info, nil
+		}
+	}
+
+	if !customTypePattern.MatchString(connectionType) {
+		return ConnectionTypeInfo{}, fmt.Errorf("invalid custom connection type %q: must be lowercase snake_case", connectionType)
+	}
+
+	return ConnectionTypeInfo{
+		Type:        connectionType,
+		Description: "Custom connection type configured by the server operator",
+		Symmetric:   false,
+	}, nil
+}
@@ -0,0 +1,103 @@
+package connection_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+func TestConfigure(t *testing.T) {
+	t.Cleanup(func() {
+		require.NoError(t, connection.Configure(connection.Config{}))
+	})
+
+	tests := []struct {
+		name      string
+		cfg       connection.Config
+		wantErr   bool
+		wantTypes []string
+		strength  int
+	}{
+		{
+			name:      "defaults",
+			cfg:       connection.Config{},
+			wantTypes: []string{"relates_to", "references", "supports", "contradicts", "influences", "depends_on", "similar_to", "part_of", "cites", "follows", "precedes"},
+			strength:  connection.DefaultConnectionStrength,
+		},
+		{
+			name:      "restrict and extend types",
+			cfg:       connection.Config{DefaultStrength: 7, AllowedTypes: []string{"supports", "contradicts"}, ExtraTypes: []string{"cause_of", "supports"}},
+			wantTypes: []string{"supports", "contradicts", "cause_of"},
+			strength:  7,
+		},
+		{
+			name:    "invalid default strength",
+			cfg:     connection.Config{DefaultStrength: 11},
+			wantErr: true,
+		},
+		{
+			name:    "invalid custom type name",
+			cfg:     connection.Config{ExtraTypes: []string{"Cause Of"}},
+			wantErr: true,
+		},
+		{
+			name:    "self-loop type that is not allowed",
+			cfg:     connection.Config{AllowedTypes: []string{"supports"}, SelfLoopTypes: []string{"relates_to"}},
+			wantErr: true,
+		},
+	}
+
+	require.NoError(t, connection.Configure(connection.Config{}))
+	builtinTypes := connection.ValidConnectionTypes()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, connection.Configure(connection.Config{}))
+
+			err := connection.Configure(tt.cfg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				// Failed configuration leaves the previous settings untouched
+				assert.Equal(t, connection.DefaultConnectionStrength, connection.DefaultStrength())
+				assert.Equal(t, builtinTypes, connection.ValidConnectionTypes())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTypes, connection.ValidConnectionTypes())
+			assert.Equal(t, tt.strength, connection.DefaultStrength())
+		})
+	}
+
+	t.Run("custom types are directional and validated", func(t *testing.T) {
+		require.NoError(t, connection.Configure(connection.Config{ExtraTypes: []string{"cause_of"}}))
+
+		assert.True(t, connection.IsValidConnectionType("cause_of"))
+		assert.True(t, connection.IsValidConnectionType("relates_to"))
+		assert.False(t, connection.IsSymmetricConnectionType("cause_of"))
+		assert.True(t, connection.IsSymmetricConnectionType("relates_to"))
+
+		require.NoError(t, connection.Configure(connection.Config{AllowedTypes: []string{"cause_of"}}))
+		assert.False(t, connection.IsValidConnectionType("relates_to"))
+	})
+
+	t.Run("self-loop policy", func(t *testing.T) {
+		require.NoError(t, connection.Configure(connection.Config{ExtraTypes: []string{"cause_of"}}))
+		assert.True(t, connection.AllowsSelfLoop("relates_to"))
+		assert.True(t, connection.AllowsSelfLoop("references"))
+		assert.False(t, connection.AllowsSelfLoop("depends_on"))
+		assert.False(t, connection.AllowsSelfLoop("cause_of"))
+		assert.False(t, connection.AllowsSelfLoop("unknown"))
+
+		require.NoError(t, connection.Configure(connection.Config{ExtraTypes: []string{"cause_of"}, SelfLoopTypes: []string{"cause_of"}}))
+		assert.True(t, connection.AllowsSelfLoop("cause_of"))
+		assert.False(t, connection.AllowsSelfLoop("relates_to"))
+
+		// The allow-list never leaks into the built-in defaults
+		require.NoError(t, connection.Configure(connection.Config{}))
+		assert.True(t, connection.AllowsSelfLoop("relates_to"))
+	})
+}
@@ -0,0 +1,34 @@
+package connection
+
+import (
+	"errors"
+)
+
+var (
+	// ErrNotFound is returned when no connection has the requested ID
+	ErrNotFound = errors.New("connection not found")
+
+	// ErrGraphTooLarge is returned when a graph operation would exceed the configured node or edge ceiling
+	ErrGraphTooLarge = errors.New("graph too large, narrow your query")
+
+	// ErrGraphTimeout is returned when a graph operation exceeds the configured time limit
+	ErrGraphTimeout = errors.New("graph operation timed out, narrow your query")
+
+	// ErrRetypeConflict is returned when retyping would duplicate connections that already exist with the target type
+	ErrRetypeConflict = errors.New("retyping would create duplicate connections")
+
+	// ErrConflict is returned when an update's expected updated_at no longer matches the stored connection
+	ErrConflict = errors.New("connection was modified by someone else, get it again and retry")
+
+	// ErrNoteTitleNotFound is returned when no note has the given title
+	ErrNoteTitleNotFound = errors.New("no note has this title")
+
+	// ErrNotInTrash is returned when restoring a connection that is not soft-deleted
+	ErrNotInTrash = errors.New("connection is not in the trash")
+
+	// ErrAuditDisabled is returned when the audit log is read while auditing is turned off
+	ErrAuditDisabled = errors.New("connection audit log is disabled")
+
+	// ErrAmbiguousTitle is returned when a title matches several notes case-insensitively
+	ErrAmbiguousTitle = errors.New("title matches more than one note, use one of the candidate note IDs")
+)
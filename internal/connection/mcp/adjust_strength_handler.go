@@ -0,0 +1,73 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewAdjustStrengthHandler creates a new handler for strengthening or weakening a connection by a delta
+func NewAdjustStrengthHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse ID
+		idRaw, ok := arguments["id"]
+		if !ok {
+			return nil, fmt.Errorf("id is required")
+		}
+
+		id, err := mcpx.ParseInt64(idRaw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id: %w", err)
+		}
+
+		if id <= 0 {
+			return nil, fmt.Errorf("id must be a positive integer")
+		}
+
+		// Parse delta
+		deltaRaw, ok := arguments["delta"]
+		if !ok {
+			return nil, fmt.Errorf("delta is required")
+		}
+
+		delta, err := mcpx.ParseInt(deltaRaw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid delta: %w", err)
+		}
+
+		if delta == 0 {
+			return nil, fmt.Errorf("delta must not be zero")
+		}
+
+		conn, err := storage.AdjustStrength(ctx, id, delta)
+		if err != nil {
+			return nil, fmt.Errorf("failed to adjust connection strength: %w", err)
+		}
+
+		result := newConnectionResponse(conn)
+
+		jsonData, err := mcpx.MarshalJSON(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Adjusted strength of connection %d by %+d, strength is now %d\n\n%s", conn.ID, delta, conn.Strength, string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
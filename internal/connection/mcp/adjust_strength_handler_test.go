@@ -0,0 +1,151 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+)
+
+func TestAdjustStrengthHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewAdjustStrengthHandler(mockStorage)
+
+	now := time.Now()
+	adjusted := func(strength int) *connection.Connection {
+		return &connection.Connection{ID: 1, FromNoteID: 1, ToNoteID: 2, Type: "supports", Strength: strength, CreatedAt: now, UpdatedAt: now}
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "reinforce",
+			args: map[string]interface{}{
+				"id":    float64(1),
+				"delta": float64(2),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					AdjustStrength(gomock.Any(), int64(1), 2).
+					Return(adjusted(7), nil)
+			},
+			wantErr:     false,
+			wantContent: "Adjusted strength of connection 1 by +2, strength is now 7",
+		},
+		{
+			name: "weaken",
+			args: map[string]interface{}{
+				"id":    float64(1),
+				"delta": float64(-3),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					AdjustStrength(gomock.Any(), int64(1), -3).
+					Return(adjusted(1), nil)
+			},
+			wantErr:     false,
+			wantContent: `"strength": 1`,
+		},
+		{
+			name: "missing id",
+			args: map[string]interface{}{
+				"delta": float64(1),
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "id is required",
+		},
+		{
+			name: "non-positive id",
+			args: map[string]interface{}{
+				"id":    float64(0),
+				"delta": float64(1),
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "id must be a positive integer",
+		},
+		{
+			name: "missing delta",
+			args: map[string]interface{}{
+				"id": float64(1),
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "delta is required",
+		},
+		{
+			name: "invalid delta",
+			args: map[string]interface{}{
+				"id":    float64(1),
+				"delta": "more",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid delta",
+		},
+		{
+			name: "zero delta",
+			args: map[string]interface{}{
+				"id":    float64(1),
+				"delta": float64(0),
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "delta must not be zero",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{
+				"id":    float64(99),
+				"delta": float64(1),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					AdjustStrength(gomock.Any(), int64(99), 1).
+					Return(nil, errors.New("connection not found: 99"))
+			},
+			wantErr:     true,
+			wantContent: "failed to adjust connection strength: connection not found: 99",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
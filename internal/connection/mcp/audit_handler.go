@@ -0,0 +1,67 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewAuditHandler creates a new handler for reading a connection's change history
+func NewAuditHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse connection_id
+		idRaw, ok := arguments["connection_id"]
+		if !ok {
+			return nil, fmt.Errorf("connection_id is required")
+		}
+
+		id, err := mcpx.ParseInt64(idRaw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid connection_id: %w", err)
+		}
+
+		if id <= 0 {
+			return nil, fmt.Errorf("connection_id must be a positive integer")
+		}
+
+		entries, err := storage.GetConnectionAudit(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get connection audit: %w", err)
+		}
+
+		if len(entries) == 0 {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.TextContent{
+						Type: "text",
+						Text: fmt.Sprintf("No audit entries found for connection %d", id),
+					},
+				},
+			}, nil
+		}
+
+		jsonData, err := mcpx.MarshalJSON(entries)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Found %d audit entries for connection %d:\n\n%s", len(entries), id, string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
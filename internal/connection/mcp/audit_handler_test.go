@@ -0,0 +1,106 @@
+package mcp_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+)
+
+func TestAuditHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewAuditHandler(mockStorage)
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "history found",
+			args: map[string]interface{}{"connection_id": float64(7)},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetConnectionAudit(gomock.Any(), int64(7)).
+					Return([]connection.AuditEntry{
+						{ID: 1, ConnectionID: 7, Action: connection.AuditActionCreate, Changes: map[string]interface{}{"type": "supports"}, CreatedAt: now},
+						{ID: 2, ConnectionID: 7, Action: connection.AuditActionUpdate, Changes: map[string]interface{}{"strength": map[string]interface{}{"old": 5, "new": 8}}, CreatedAt: now},
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Found 2 audit entries for connection 7",
+		},
+		{
+			name: "no history",
+			args: map[string]interface{}{"connection_id": float64(8)},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetConnectionAudit(gomock.Any(), int64(8)).
+					Return([]connection.AuditEntry{}, nil)
+			},
+			wantErr:     false,
+			wantContent: "No audit entries found for connection 8",
+		},
+		{
+			name:        "missing connection_id",
+			args:        map[string]interface{}{},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "connection_id is required",
+		},
+		{
+			name:        "non-positive connection_id",
+			args:        map[string]interface{}{"connection_id": float64(0)},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "connection_id must be a positive integer",
+		},
+		{
+			name: "audit disabled",
+			args: map[string]interface{}{"connection_id": float64(7)},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetConnectionAudit(gomock.Any(), int64(7)).
+					Return(nil, connection.ErrAuditDisabled)
+			},
+			wantErr:     true,
+			wantContent: "connection audit log is disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// maxBatchNoteIDs caps the number of notes accepted by batch tools
+const maxBatchNoteIDs = 500
+
+// NewConnectionsForNotesHandler creates a new handler for getting connections of several notes at once
+func NewConnectionsForNotesHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse note_ids
+		noteIDs, err := parseNoteIDs(arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		filter := connection.NoteConnectionsFilter{}
+
+		// Parse optional type filter
+		filter.Type, err = parseConnectionType(arguments, "type")
+		if err != nil {
+			return nil, err
+		}
+
+		// Parse optional strength filter
+		if strengthRaw, ok := arguments["strength"]; ok {
+			strength, err := mcpx.ParseInt(strengthRaw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid strength: %w", err)
+			}
+			if err := connection.ValidateStrength(strength); err != nil {
+				return nil, err
+			}
+			filter.Strength = &strength
+		}
+
+		response, err := storage.GetConnectionsForNotes(ctx, noteIDs, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get connections for notes: %w", err)
+		}
+
+		result := map[string]interface{}{
+			"notes": response,
+		}
+
+		jsonData, err := mcpx.MarshalJSON(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Found connections for %d notes:\n\n%s", len(response), string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
+
+// parseNoteIDs parses the required note_ids array argument
+func parseNoteIDs(arguments map[string]interface{}) ([]int64, error) {
+	noteIDsRaw, ok := arguments["note_ids"].([]interface{})
+	if !ok || len(noteIDsRaw) == 0 {
+		return nil, fmt.Errorf("note_ids is required")
+	}
+	if len(noteIDsRaw) > maxBatchNoteIDs {
+		return nil, fmt.Errorf("note_ids must contain at most %d IDs, got: %d", maxBatchNoteIDs, len(noteIDsRaw))
+	}
+
+	noteIDs := make([]int64, 0, len(noteIDsRaw))
+	for _, raw := range noteIDsRaw {
+		noteID, err := mcpx.ParseID(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid note_ids: %w", err)
+		}
+		noteIDs = append(noteIDs, noteID)
+	}
+
+	return noteIDs, nil
+}
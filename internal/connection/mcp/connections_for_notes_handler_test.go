@@ -0,0 +1,136 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+)
+
+func TestConnectionsForNotesHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewConnectionsForNotesHandler(mockStorage)
+
+	connType := "supports"
+	response := map[int64]*connection.NoteConnectionsResponse{
+		1: {NoteID: 1, Outgoing: []connection.Connection{{ID: 10, FromNoteID: 1, ToNoteID: 2, Type: "supports", Strength: 5}}, Incoming: []connection.Connection{}, TotalCount: 1},
+		2: {NoteID: 2, Outgoing: []connection.Connection{}, Incoming: []connection.Connection{{ID: 10, FromNoteID: 1, ToNoteID: 2, Type: "supports", Strength: 5}}, TotalCount: 1},
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "successful batch",
+			args: map[string]interface{}{
+				"note_ids": []interface{}{float64(1), "2"},
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetConnectionsForNotes(gomock.Any(), []int64{1, 2}, connection.NoteConnectionsFilter{}).
+					Return(response, nil)
+			},
+			wantErr:     false,
+			wantContent: "Found connections for 2 notes:",
+		},
+		{
+			name: "successful batch with type filter",
+			args: map[string]interface{}{
+				"note_ids": []interface{}{int64(1), int64(2)},
+				"type":     "supports",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetConnectionsForNotes(gomock.Any(), []int64{1, 2}, connection.NoteConnectionsFilter{Type: &connType}).
+					Return(response, nil)
+			},
+			wantErr:     false,
+			wantContent: `"note_id": 2`,
+		},
+		{
+			name:        "missing note_ids",
+			args:        map[string]interface{}{},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "note_ids is required",
+		},
+		{
+			name: "invalid note id",
+			args: map[string]interface{}{
+				"note_ids": []interface{}{"abc"},
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid note_ids",
+		},
+		{
+			name: "non-positive note id",
+			args: map[string]interface{}{
+				"note_ids": []interface{}{float64(0)},
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid note_ids: IDs must be positive, got: 0",
+		},
+		{
+			name: "invalid type",
+			args: map[string]interface{}{
+				"note_ids": []interface{}{float64(1)},
+				"type":     "invalid_type",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid connection type",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{
+				"note_ids": []interface{}{float64(1)},
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetConnectionsForNotes(gomock.Any(), []int64{1}, connection.NoteConnectionsFilter{}).
+					Return(nil, errors.New("storage error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to get connections for notes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+)
+
+func TestContradictionsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewContradictionsHandler(mockStorage)
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "contradictions found",
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindContradictions(gomock.Any()).
+					Return([]connection.ContradictionPair{
+						{NoteAID: 1, NoteBID: 2, SupportsConnectionIDs: []int64{10}, ContradictsConnectionIDs: []int64{11}},
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Found 1 note pairs linked by both supports and contradicts:",
+		},
+		{
+			name: "no contradictions",
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindContradictions(gomock.Any()).
+					Return([]connection.ContradictionPair{}, nil)
+			},
+			wantErr:     false,
+			wantContent: "No contradictions found",
+		},
+		{
+			name: "storage error",
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindContradictions(gomock.Any()).
+					Return(nil, errors.New("storage error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to find contradictions",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: map[string]interface{}{},
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
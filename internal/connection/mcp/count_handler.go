@@ -0,0 +1,34 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewCountHandler creates a new handler for counting the connections list_connections would return
+func NewCountHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		var listReq connection.ListConnectionsRequest
+		if err := parseListFilters(arguments, &listReq); err != nil {
+			return nil, err
+		}
+
+		total, err := storage.Count(ctx, listReq)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count connections: %w", err)
+		}
+
+		return mcpx.CountResult("connections", total)
+	}
+}
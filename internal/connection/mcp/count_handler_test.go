@@ -0,0 +1,116 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+)
+
+func TestCountHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewCountHandler(mockStorage)
+
+	fromNoteID := int64(1)
+	connType := "supports"
+	strength := 8
+	source := "agent"
+
+	tests := []struct {
+		name        string
+		args        interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "count all connections",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Count(gomock.Any(), connection.ListConnectionsRequest{}).
+					Return(int64(120), nil)
+			},
+			wantContent: "Found 120 connections\n\n{\n  \"total\": 120\n}",
+		},
+		{
+			name: "count with filters",
+			args: map[string]interface{}{
+				"from_note_id": float64(1),
+				"type":         "supports",
+				"types":        []interface{}{"relates_to"},
+				"strength":     float64(8),
+				"source":       "agent",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Count(gomock.Any(), connection.ListConnectionsRequest{
+						FromNoteID: &fromNoteID,
+						Type:       &connType,
+						Types:      []string{"relates_to"},
+						Strength:   &strength,
+						Source:     &source,
+					}).
+					Return(int64(4), nil)
+			},
+			wantContent: "Found 4 connections",
+		},
+		{
+			name:      "invalid type",
+			args:      map[string]interface{}{"type": "unknown"},
+			mockSetup: func() {},
+			wantErr:   true,
+		},
+		{
+			name:      "invalid strength",
+			args:      map[string]interface{}{"strength": float64(11)},
+			mockSetup: func() {},
+			wantErr:   true,
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Count(gomock.Any(), connection.ListConnectionsRequest{}).
+					Return(int64(0), errors.New("database error"))
+			},
+			wantErr: true,
+		},
+		{
+			name:      "invalid arguments",
+			args:      "not a map",
+			mockSetup: func() {},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{}
+			req.Params.Arguments = tt.args
+
+			result, err := handler(context.Background(), req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			text := result.Content[0].(gomcp.TextContent).Text
+			assert.Contains(t, text, tt.wantContent)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewCreateByTitleHandler creates a new handler for creating connections between notes identified by title
+func NewCreateByTitleHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse from_title
+		fromTitle, ok := arguments["from_title"].(string)
+		if !ok || strings.TrimSpace(fromTitle) == "" {
+			return nil, fmt.Errorf("from_title is required")
+		}
+
+		// Parse to_title
+		toTitle, ok := arguments["to_title"].(string)
+		if !ok || strings.TrimSpace(toTitle) == "" {
+			return nil, fmt.Errorf("to_title is required")
+		}
+
+		details, err := parseConnectionDetails(arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		createReq := connection.CreateByTitleRequest{
+			FromTitle:     fromTitle,
+			ToTitle:       toTitle,
+			Type:          details.Type,
+			Description:   details.Description,
+			Strength:      details.Strength,
+			Metadata:      details.Metadata,
+			Source:        details.Source,
+			AutoSymmetric: details.AutoSymmetric,
+		}
+
+		conn, err := storage.CreateByTitle(ctx, createReq)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create connection: %w", err)
+		}
+
+		result := newConnectionResponse(conn)
+
+		jsonData, err := mcpx.MarshalJSON(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Successfully created connection with ID: %d from note %d to note %d%s\n\n%s",
+						conn.ID, conn.FromNoteID, conn.ToNoteID, creationNotes(ctx, storage, conn, createReq.AutoSymmetric), string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
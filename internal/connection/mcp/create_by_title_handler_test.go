@@ -0,0 +1,186 @@
+package mcp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+)
+
+func TestCreateByTitleHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewCreateByTitleHandler(mockStorage)
+
+	now := time.Now()
+	desc := "Both cover the basics"
+	source := "research-agent"
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "successful creation",
+			args: map[string]interface{}{
+				"from_title":  "Go Basics",
+				"to_title":    "Go Concurrency",
+				"type":        "supports",
+				"description": desc,
+				"strength":    8,
+				"source":      source,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					CreateByTitle(gomock.Any(), connection.CreateByTitleRequest{
+						FromTitle:   "Go Basics",
+						ToTitle:     "Go Concurrency",
+						Type:        "supports",
+						Description: &desc,
+						Strength:    8,
+						Source:      &source,
+					}).
+					Return(&connection.Connection{
+						ID: 3, FromNoteID: 10, ToNoteID: 11, Type: "supports", Strength: 8,
+						Description: &desc, Source: &source, CreatedAt: now, UpdatedAt: now,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully created connection with ID: 3 from note 10 to note 11",
+		},
+		{
+			name: "default strength",
+			args: map[string]interface{}{
+				"from_title": "Go Basics",
+				"to_title":   "Go Concurrency",
+				"type":       "references",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					CreateByTitle(gomock.Any(), connection.CreateByTitleRequest{
+						FromTitle: "Go Basics",
+						ToTitle:   "Go Concurrency",
+						Type:      "references",
+						Strength:  connection.DefaultStrength(),
+					}).
+					Return(&connection.Connection{
+						ID: 4, FromNoteID: 10, ToNoteID: 11, Type: "references", Strength: connection.DefaultStrength(),
+						CreatedAt: now, UpdatedAt: now,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully created connection with ID: 4",
+		},
+		{
+			name: "auto_symmetric and redundant inverse",
+			args: map[string]interface{}{
+				"from_title":     "Go Basics",
+				"to_title":       "Go Concurrency",
+				"type":           "precedes",
+				"auto_symmetric": true,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					CreateByTitle(gomock.Any(), connection.CreateByTitleRequest{
+						FromTitle:     "Go Basics",
+						ToTitle:       "Go Concurrency",
+						Type:          "precedes",
+						Strength:      connection.DefaultStrength(),
+						AutoSymmetric: true,
+					}).
+					Return(&connection.Connection{
+						ID: 5, FromNoteID: 10, ToNoteID: 11, Type: "precedes", Strength: connection.DefaultStrength(),
+						CreatedAt: now, UpdatedAt: now,
+					}, nil)
+				mockStorage.EXPECT().
+					FindRedundantInverses(gomock.Any(), int64(10), int64(11), "precedes").
+					Return([]connection.Connection{{ID: 2, FromNoteID: 11, ToNoteID: 10, Type: "follows"}}, nil)
+			},
+			wantErr:     false,
+			wantContent: "from note 10 to note 11\nWarning: redundant inverse: connection 2 already says note 11 follows note 10\n\n",
+		},
+		{
+			name: "missing from_title",
+			args: map[string]interface{}{
+				"to_title": "Go Concurrency",
+				"type":     "supports",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "from_title is required",
+		},
+		{
+			name: "blank to_title",
+			args: map[string]interface{}{
+				"from_title": "Go Basics",
+				"to_title":   "  ",
+				"type":       "supports",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "to_title is required",
+		},
+		{
+			name: "invalid type",
+			args: map[string]interface{}{
+				"from_title": "Go Basics",
+				"to_title":   "Go Concurrency",
+				"type":       "invalid_type",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid connection type",
+		},
+		{
+			name: "ambiguous title",
+			args: map[string]interface{}{
+				"from_title": "go basics",
+				"to_title":   "Go Concurrency",
+				"type":       "supports",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					CreateByTitle(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("from_title: %w: %q matches notes [3 4]", connection.ErrAmbiguousTitle, "go basics"))
+			},
+			wantErr:     true,
+			wantContent: "matches notes [3 4]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
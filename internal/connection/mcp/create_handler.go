@@ -2,14 +2,14 @@ package mcp
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"strconv"
+	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
 )
 
 // NewCreateHandler creates a new handler for creating connections
@@ -25,7 +25,7 @@ func NewCreateHandler(storage connection.Storage) server.ToolHandlerFunc {
 		if !ok {
 			return nil, fmt.Errorf("from_note_id is required")
 		}
-		fromNoteID, err := parseInt64(fromNoteIDRaw)
+		fromNoteID, err := mcpx.ParseInt64(fromNoteIDRaw)
 		if err != nil {
 			return nil, fmt.Errorf("invalid from_note_id: %w", err)
 		}
@@ -35,61 +35,33 @@ func NewCreateHandler(storage connection.Storage) server.ToolHandlerFunc {
 		if !ok {
 			return nil, fmt.Errorf("to_note_id is required")
 		}
-		toNoteID, err := parseInt64(toNoteIDRaw)
+		toNoteID, err := mcpx.ParseInt64(toNoteIDRaw)
 		if err != nil {
 			return nil, fmt.Errorf("invalid to_note_id: %w", err)
 		}
 
-		// Validate that from_note_id != to_note_id
-		if fromNoteID == toNoteID {
-			return nil, fmt.Errorf("from_note_id and to_note_id cannot be the same")
-		}
-
-		// Parse type
-		connectionType, ok := arguments["type"].(string)
-		if !ok || connectionType == "" {
-			return nil, fmt.Errorf("type is required")
+		createReq, err := parseConnectionDetails(arguments)
+		if err != nil {
+			return nil, err
 		}
+		createReq.FromNoteID = fromNoteID
+		createReq.ToNoteID = toNoteID
 
-		// Validate connection type
-		if !connection.IsValidConnectionType(connectionType) {
-			return nil, fmt.Errorf("invalid connection type: %s. Valid types are: %v", connectionType, connection.ValidConnectionTypes())
+		// Parse optional idempotency_key
+		createReq.IdempotencyKey, err = mcpx.ParseIdempotencyKey(arguments)
+		if err != nil {
+			return nil, err
 		}
 
-		// Parse strength (required, default to 5 if not provided)
-		strength := 5
-		if strengthRaw, ok := arguments["strength"]; ok {
-			strengthInt, err := parseInt(strengthRaw)
-			if err != nil {
-				return nil, fmt.Errorf("invalid strength: %w", err)
+		// Parse optional auto_inverse
+		if autoInverseRaw, ok := arguments["auto_inverse"]; ok {
+			autoInverse, ok := autoInverseRaw.(bool)
+			if !ok {
+				return nil, fmt.Errorf("auto_inverse must be a boolean")
+			}
+			if autoInverse {
+				return createWithInverse(ctx, storage, createReq)
 			}
-			strength = strengthInt
-		}
-
-		// Validate strength range
-		if strength < 1 || strength > 10 {
-			return nil, fmt.Errorf("strength must be between 1 and 10, got: %d", strength)
-		}
-
-		// Parse optional description
-		var description *string
-		if desc, ok := arguments["description"].(string); ok && desc != "" {
-			description = &desc
-		}
-
-		// Parse optional metadata
-		var metadata map[string]interface{}
-		if metadataRaw, ok := arguments["metadata"].(map[string]interface{}); ok {
-			metadata = metadataRaw
-		}
-
-		createReq := connection.CreateConnectionRequest{
-			FromNoteID:  fromNoteID,
-			ToNoteID:    toNoteID,
-			Type:        connectionType,
-			Description: description,
-			Strength:    strength,
-			Metadata:    metadata,
 		}
 
 		conn, err := storage.Create(ctx, createReq)
@@ -97,19 +69,9 @@ func NewCreateHandler(storage connection.Storage) server.ToolHandlerFunc {
 			return nil, fmt.Errorf("failed to create connection: %w", err)
 		}
 
-		result := map[string]interface{}{
-			"id":           conn.ID,
-			"from_note_id": conn.FromNoteID,
-			"to_note_id":   conn.ToNoteID,
-			"type":         conn.Type,
-			"description":  conn.Description,
-			"strength":     conn.Strength,
-			"metadata":     conn.Metadata,
-			"created_at":   conn.CreatedAt,
-			"updated_at":   conn.UpdatedAt,
-		}
+		result := newConnectionResponse(conn)
 
-		jsonData, err := json.MarshalIndent(result, "", "  ")
+		jsonData, err := mcpx.MarshalJSON(result)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal result: %w", err)
 		}
@@ -118,42 +80,191 @@ func NewCreateHandler(storage connection.Storage) server.ToolHandlerFunc {
 			Content: []mcp.Content{
 				mcp.TextContent{
 					Type: "text",
-					Text: fmt.Sprintf("Successfully created connection with ID: %d\n\n%s", conn.ID, string(jsonData)),
+					Text: fmt.Sprintf("Successfully created connection with ID: %d%s\n\n%s", conn.ID, creationNotes(ctx, storage, conn, createReq.AutoSymmetric), string(jsonData)),
 				},
 			},
 		}, nil
 	}
 }
 
-// parseInt64 parses various types to int64
-func parseInt64(value interface{}) (int64, error) {
-	switch v := value.(type) {
-	case int64:
-		return v, nil
-	case int:
-		return int64(v), nil
-	case float64:
-		return int64(v), nil
-	case string:
-		return strconv.ParseInt(v, 10, 64)
-	default:
-		return 0, fmt.Errorf("cannot convert %T to int64", value)
+// parseConnectionDetails parses the type, strength, description, metadata and
+// source arguments shared by the connection create tools
+func parseConnectionDetails(arguments map[string]interface{}) (connection.CreateConnectionRequest, error) {
+	// Parse type
+	rawType, _ := arguments["type"].(string)
+	connectionType := connection.NormalizeConnectionType(rawType)
+	if connectionType == "" {
+		return connection.CreateConnectionRequest{}, fmt.Errorf("type is required")
+	}
+
+	// Validate connection type
+	if !connection.IsValidConnectionType(connectionType) {
+		return connection.CreateConnectionRequest{}, fmt.Errorf("invalid connection type: %s. Valid types are: %v", rawType, connection.ValidConnectionTypes())
+	}
+
+	// Parse strength (required, default to the configured default if not provided)
+	strength := connection.DefaultStrength()
+	if strengthRaw, ok := arguments["strength"]; ok {
+		strengthInt, err := mcpx.ParseInt(strengthRaw)
+		if err != nil {
+			return connection.CreateConnectionRequest{}, fmt.Errorf("invalid strength: %w", err)
+		}
+		strength = strengthInt
+	}
+
+	// Validate strength range
+	if err := connection.ValidateStrength(strength); err != nil {
+		return connection.CreateConnectionRequest{}, err
+	}
+
+	// Parse optional description
+	var description *string
+	if desc, ok := arguments["description"].(string); ok && desc != "" {
+		description = &desc
+	}
+
+	// Parse optional metadata
+	var metadata map[string]interface{}
+	if metadataRaw, ok := arguments["metadata"].(map[string]interface{}); ok {
+		metadata = metadataRaw
+	}
+
+	// Parse optional source
+	var source *string
+	if src, ok := arguments["source"].(string); ok && src != "" {
+		source = &src
+	}
+
+	// Parse optional auto_symmetric
+	autoSymmetric := false
+	if autoSymmetricRaw, ok := arguments["auto_symmetric"]; ok {
+		autoSymmetric, ok = autoSymmetricRaw.(bool)
+		if !ok {
+			return connection.CreateConnectionRequest{}, fmt.Errorf("auto_symmetric must be a boolean")
+		}
+	}
+
+	return connection.CreateConnectionRequest{
+		Type:          connectionType,
+		Description:   description,
+		Strength:      strength,
+		Metadata:      metadata,
+		Source:        source,
+		AutoSymmetric: autoSymmetric,
+	}, nil
+}
+
+// createWithInverse creates the connection and its inverse, and reports both
+func createWithInverse(ctx context.Context, storage connection.Storage, createReq connection.CreateConnectionRequest) (*mcp.CallToolResult, error) {
+	conn, inverse, err := storage.CreateWithInverse(ctx, createReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection: %w", err)
+	}
+
+	summary := fmt.Sprintf("Successfully created connection with ID: %d", conn.ID)
+	result := map[string]interface{}{
+		"connection": newConnectionResponse(conn),
+		"inverse":    nil,
+	}
+	if inverse != nil {
+		summary += fmt.Sprintf("\nInverse connection with ID: %d from note %d to note %d (%s)", inverse.ID, inverse.FromNoteID, inverse.ToNoteID, inverse.Type)
+		result["inverse"] = newConnectionResponse(inverse)
+	} else {
+		summary += fmt.Sprintf("\n%s has no inverse type, so no inverse connection was created", conn.Type)
+	}
+	summary += reverseNote(conn, createReq.AutoSymmetric)
+
+	jsonData, err := mcpx.MarshalJSON(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal result: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.TextContent{
+				Type: "text",
+				Text: fmt.Sprintf("%s\n\n%s", summary, string(jsonData)),
+			},
+		},
+	}, nil
+}
+
+// reverseNote reports the reverse connection stored for a symmetric type with auto_symmetric
+func reverseNote(conn *connection.Connection, autoSymmetric bool) string {
+	if !autoSymmetric || conn.FromNoteID == conn.ToNoteID ||
+		connection.ConnectionType(conn.Type).Directionality() != connection.DirectionalitySymmetric {
+		return ""
 	}
+	return fmt.Sprintf("\nAlso created the reverse %s connection from note %d to note %d", conn.Type, conn.ToNoteID, conn.FromNoteID)
 }
 
-// parseInt parses various types to int
-func parseInt(value interface{}) (int, error) {
-	switch v := value.(type) {
-	case int:
-		return v, nil
-	case int64:
-		return int(v), nil
-	case float64:
-		return int(v), nil
-	case string:
-		i, err := strconv.Atoi(v)
-		return i, err
-	default:
-		return 0, fmt.Errorf("cannot convert %T to int", value)
-	}
-}
\ No newline at end of file
+// creationNotes returns the lines added to the summary of a created
+// connection: whether its reverse was stored too, and a warning for each
+// existing connection that already states it with the inverse type. The
+// connection exists either way, so a failed lookup becomes a warning as well.
+func creationNotes(ctx context.Context, storage connection.Storage, conn *connection.Connection, autoSymmetric bool) string {
+	var notes strings.Builder
+	notes.WriteString(reverseNote(conn, autoSymmetric))
+
+	if _, ok := connection.ConnectionType(conn.Type).Inverse(); ok {
+		inverses, err := storage.FindRedundantInverses(ctx, conn.FromNoteID, conn.ToNoteID, conn.Type)
+		if err != nil {
+			fmt.Fprintf(&notes, "\nWarning: could not check for inverse connections: %v", err)
+		}
+		for _, inverse := range inverses {
+			fmt.Fprintf(&notes, "\nWarning: redundant inverse: connection %d already says note %d %s note %d",
+				inverse.ID, inverse.FromNoteID, inverse.Type, inverse.ToNoteID)
+		}
+	}
+
+	return notes.String()
+}
+
+// parseConnectionTypes reads the optional array argument name as a list of
+// valid connection types, normalized like parseConnectionType, returning an
+// empty list when it is missing
+func parseConnectionTypes(arguments map[string]interface{}, name string) ([]string, error) {
+	raw, ok := arguments[name]
+	if !ok {
+		return []string{}, nil
+	}
+
+	list, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be an array of connection types", name)
+	}
+
+	types := make([]string, 0, len(list))
+	for i, item := range list {
+		rawType, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s[%d] must be a string", name, i)
+		}
+		connType := connection.NormalizeConnectionType(rawType)
+		if !connection.IsValidConnectionType(connType) {
+			return nil, fmt.Errorf("invalid connection type in %s: %s. Valid types are: %v", name, rawType, connection.ValidConnectionTypes())
+		}
+		types = append(types, connType)
+	}
+	return types, nil
+}
+
+// parseConnectionType reads the optional string argument name as a connection
+// type, ignoring case and surrounding spaces. It returns nil when the argument
+// is missing or blank.
+func parseConnectionType(arguments map[string]interface{}, name string) (*string, error) {
+	rawType, ok := arguments[name].(string)
+	if !ok {
+		return nil, nil
+	}
+
+	connectionType := connection.NormalizeConnectionType(rawType)
+	if connectionType == "" {
+		return nil, nil
+	}
+	if !connection.IsValidConnectionType(connectionType) {
+		return nil, fmt.Errorf("invalid connection type: %s. Valid types are: %v", rawType, connection.ValidConnectionTypes())
+	}
+
+	return &connectionType, nil
+}
@@ -98,6 +98,100 @@ func TestCreateHandler(t *testing.T) {
 			wantErr:     false,
 			wantContent: "Successfully created connection with ID: 1",
 		},
+		{
+			name: "mixed-case padded type is normalized",
+			args: map[string]interface{}{
+				"from_note_id": int64(1),
+				"to_note_id":   int64(2),
+				"type":         " Relates_To ",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Create(gomock.Any(), connection.CreateConnectionRequest{
+						FromNoteID: 1,
+						ToNoteID:   2,
+						Type:       "relates_to",
+						Strength:   5,
+					}).
+					Return(&connection.Connection{
+						ID:         1,
+						FromNoteID: 1,
+						ToNoteID:   2,
+						Type:       "relates_to",
+						Strength:   5,
+						CreatedAt:  now,
+						UpdatedAt:  now,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: `"type": "relates_to"`,
+		},
+		{
+			name: "successful creation with source",
+			args: map[string]interface{}{
+				"from_note_id": int64(1),
+				"to_note_id":   int64(2),
+				"type":         "relates_to",
+				"source":       "research-agent",
+			},
+			mockSetup: func() {
+				source := "research-agent"
+				mockStorage.EXPECT().
+					Create(gomock.Any(), connection.CreateConnectionRequest{
+						FromNoteID: 1,
+						ToNoteID:   2,
+						Type:       "relates_to",
+						Strength:   5,
+						Source:     &source,
+					}).
+					Return(&connection.Connection{
+						ID:         3,
+						FromNoteID: 1,
+						ToNoteID:   2,
+						Type:       "relates_to",
+						Strength:   5,
+						Source:     &source,
+						CreatedAt:  now,
+						UpdatedAt:  now,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: `"source": "research-agent"`,
+		},
+		{
+			name: "idempotency key is passed to storage",
+			args: map[string]interface{}{
+				"from_note_id":    int64(1),
+				"to_note_id":      int64(2),
+				"type":            "relates_to",
+				"idempotency_key": "retry-1",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Create(gomock.Any(), connection.CreateConnectionRequest{
+						FromNoteID:     1,
+						ToNoteID:       2,
+						Type:           "relates_to",
+						Strength:       5,
+						IdempotencyKey: "retry-1",
+					}).
+					Return(&connection.Connection{ID: 4, FromNoteID: 1, ToNoteID: 2, Type: "relates_to", Strength: 5, CreatedAt: now, UpdatedAt: now}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully created connection with ID: 4",
+		},
+		{
+			name: "invalid idempotency key type",
+			args: map[string]interface{}{
+				"from_note_id":    int64(1),
+				"to_note_id":      int64(2),
+				"type":            "relates_to",
+				"idempotency_key": float64(7),
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "idempotency_key must be a string",
+		},
 		{
 			name: "missing from_note_id",
 			args: map[string]interface{}{
@@ -129,15 +223,53 @@ func TestCreateHandler(t *testing.T) {
 			wantContent: "type is required",
 		},
 		{
-			name: "same from and to note",
+			name: "blank type",
+			args: map[string]interface{}{
+				"from_note_id": int64(1),
+				"to_note_id":   int64(2),
+				"type":         "   ",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "type is required",
+		},
+		{
+			name: "self-connection with a type that allows it",
 			args: map[string]interface{}{
 				"from_note_id": int64(1),
 				"to_note_id":   int64(1),
 				"type":         "relates_to",
 			},
-			mockSetup:   func() {},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(&connection.Connection{
+						ID:         4,
+						FromNoteID: 1,
+						ToNoteID:   1,
+						Type:       "relates_to",
+						Strength:   5,
+						CreatedAt:  now,
+						UpdatedAt:  now,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: `"to_note_id": 1`,
+		},
+		{
+			name: "self-connection rejected by storage",
+			args: map[string]interface{}{
+				"from_note_id": int64(1),
+				"to_note_id":   int64(1),
+				"type":         "depends_on",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("self-connections are not allowed for depends_on connections"))
+			},
 			wantErr:     true,
-			wantContent: "from_note_id and to_note_id cannot be the same",
+			wantContent: "self-connections are not allowed for depends_on connections",
 		},
 		{
 			name: "invalid connection type",
@@ -196,6 +328,209 @@ func TestCreateHandler(t *testing.T) {
 			wantErr:     true,
 			wantContent: "invalid to_note_id",
 		},
+		{
+			name: "auto_symmetric with a symmetric type",
+			args: map[string]interface{}{
+				"from_note_id":   int64(1),
+				"to_note_id":     int64(2),
+				"type":           "similar_to",
+				"auto_symmetric": true,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Create(gomock.Any(), connection.CreateConnectionRequest{
+						FromNoteID:    1,
+						ToNoteID:      2,
+						Type:          "similar_to",
+						Strength:      connection.DefaultStrength(),
+						AutoSymmetric: true,
+					}).
+					Return(&connection.Connection{
+						ID: 5, FromNoteID: 1, ToNoteID: 2, Type: "similar_to", Strength: connection.DefaultStrength(),
+						CreatedAt: now, UpdatedAt: now,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully created connection with ID: 5\nAlso created the reverse similar_to connection from note 2 to note 1\n\n",
+		},
+		{
+			name: "auto_symmetric with a directed type",
+			args: map[string]interface{}{
+				"from_note_id":   int64(1),
+				"to_note_id":     int64(2),
+				"type":           "cites",
+				"auto_symmetric": true,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(&connection.Connection{
+						ID: 6, FromNoteID: 1, ToNoteID: 2, Type: "cites", Strength: connection.DefaultStrength(),
+						CreatedAt: now, UpdatedAt: now,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully created connection with ID: 6\n\n",
+		},
+		{
+			name: "invalid auto_symmetric type",
+			args: map[string]interface{}{
+				"from_note_id":   int64(1),
+				"to_note_id":     int64(2),
+				"type":           "similar_to",
+				"auto_symmetric": "yes",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "auto_symmetric must be a boolean",
+		},
+		{
+			name: "redundant inverse warning",
+			args: map[string]interface{}{
+				"from_note_id": int64(1),
+				"to_note_id":   int64(2),
+				"type":         "precedes",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(&connection.Connection{
+						ID: 7, FromNoteID: 1, ToNoteID: 2, Type: "precedes", Strength: connection.DefaultStrength(),
+						CreatedAt: now, UpdatedAt: now,
+					}, nil)
+				mockStorage.EXPECT().
+					FindRedundantInverses(gomock.Any(), int64(1), int64(2), "precedes").
+					Return([]connection.Connection{{ID: 3, FromNoteID: 2, ToNoteID: 1, Type: "follows"}}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully created connection with ID: 7\nWarning: redundant inverse: connection 3 already says note 2 follows note 1\n\n",
+		},
+		{
+			name: "inverse type without redundant connections",
+			args: map[string]interface{}{
+				"from_note_id": int64(1),
+				"to_note_id":   int64(2),
+				"type":         "follows",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(&connection.Connection{
+						ID: 8, FromNoteID: 1, ToNoteID: 2, Type: "follows", Strength: connection.DefaultStrength(),
+						CreatedAt: now, UpdatedAt: now,
+					}, nil)
+				mockStorage.EXPECT().
+					FindRedundantInverses(gomock.Any(), int64(1), int64(2), "follows").
+					Return([]connection.Connection{}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully created connection with ID: 8\n\n",
+		},
+		{
+			name: "failed inverse lookup still reports the connection",
+			args: map[string]interface{}{
+				"from_note_id": int64(1),
+				"to_note_id":   int64(2),
+				"type":         "precedes",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(&connection.Connection{
+						ID: 9, FromNoteID: 1, ToNoteID: 2, Type: "precedes", Strength: connection.DefaultStrength(),
+						CreatedAt: now, UpdatedAt: now,
+					}, nil)
+				mockStorage.EXPECT().
+					FindRedundantInverses(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error"))
+			},
+			wantErr:     false,
+			wantContent: "Successfully created connection with ID: 9\nWarning: could not check for inverse connections: database error",
+		},
+		{
+			name: "auto_inverse creates the inverse",
+			args: map[string]interface{}{
+				"from_note_id": int64(1),
+				"to_note_id":   int64(2),
+				"type":         "precedes",
+				"auto_inverse": true,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					CreateWithInverse(gomock.Any(), connection.CreateConnectionRequest{
+						FromNoteID: 1,
+						ToNoteID:   2,
+						Type:       "precedes",
+						Strength:   connection.DefaultStrength(),
+					}).
+					Return(
+						&connection.Connection{ID: 10, FromNoteID: 1, ToNoteID: 2, Type: "precedes", CreatedAt: now, UpdatedAt: now},
+						&connection.Connection{ID: 11, FromNoteID: 2, ToNoteID: 1, Type: "follows", CreatedAt: now, UpdatedAt: now},
+						nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully created connection with ID: 10\nInverse connection with ID: 11 from note 2 to note 1 (follows)\n\n",
+		},
+		{
+			name: "auto_inverse with a type without an inverse",
+			args: map[string]interface{}{
+				"from_note_id": int64(1),
+				"to_note_id":   int64(2),
+				"type":         "part_of",
+				"auto_inverse": true,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					CreateWithInverse(gomock.Any(), gomock.Any()).
+					Return(&connection.Connection{ID: 12, FromNoteID: 1, ToNoteID: 2, Type: "part_of", CreatedAt: now, UpdatedAt: now}, nil, nil)
+			},
+			wantErr:     false,
+			wantContent: "part_of has no inverse type, so no inverse connection was created\n\n{\n  \"connection\": {",
+		},
+		{
+			name: "auto_inverse false creates one connection",
+			args: map[string]interface{}{
+				"from_note_id": int64(1),
+				"to_note_id":   int64(2),
+				"type":         "part_of",
+				"auto_inverse": false,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(&connection.Connection{ID: 13, FromNoteID: 1, ToNoteID: 2, Type: "part_of", CreatedAt: now, UpdatedAt: now}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully created connection with ID: 13\n\n",
+		},
+		{
+			name: "invalid auto_inverse type",
+			args: map[string]interface{}{
+				"from_note_id": int64(1),
+				"to_note_id":   int64(2),
+				"type":         "precedes",
+				"auto_inverse": "yes",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "auto_inverse must be a boolean",
+		},
+		{
+			name: "auto_inverse storage error",
+			args: map[string]interface{}{
+				"from_note_id": int64(1),
+				"to_note_id":   int64(2),
+				"type":         "precedes",
+				"auto_inverse": true,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					CreateWithInverse(gomock.Any(), gomock.Any()).
+					Return(nil, nil, errors.New("storage error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to create connection",
+		},
 		{
 			name: "storage error",
 			args: map[string]interface{}{
@@ -235,4 +570,40 @@ func TestCreateHandler(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestCreateHandler_ConfiguredDefaults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	assert.NoError(t, connection.Configure(connection.Config{DefaultStrength: 8, ExtraTypes: []string{"cause_of"}}))
+	t.Cleanup(func() {
+		assert.NoError(t, connection.Configure(connection.Config{}))
+	})
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewCreateHandler(mockStorage)
+
+	mockStorage.EXPECT().
+		Create(gomock.Any(), connection.CreateConnectionRequest{
+			FromNoteID: 1,
+			ToNoteID:   2,
+			Type:       "cause_of",
+			Strength:   8,
+		}).
+		Return(&connection.Connection{ID: 1, FromNoteID: 1, ToNoteID: 2, Type: "cause_of", Strength: 8}, nil)
+
+	req := gomcp.CallToolRequest{
+		Params: gomcp.CallToolParams{
+			Arguments: map[string]interface{}{
+				"from_note_id": int64(1),
+				"to_note_id":   int64(2),
+				"type":         "cause_of",
+			},
+		},
+	}
+
+	result, err := handler(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, "Successfully created connection with ID: 1")
+}
@@ -0,0 +1,62 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewDeleteBetweenHandler creates a new handler for deleting all connections between two notes
+func NewDeleteBetweenHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse from_note_id
+		fromNoteIDRaw, ok := arguments["from_note_id"]
+		if !ok {
+			return nil, fmt.Errorf("from_note_id is required")
+		}
+		fromNoteID, err := mcpx.ParseInt64(fromNoteIDRaw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from_note_id: %w", err)
+		}
+
+		// Parse to_note_id
+		toNoteIDRaw, ok := arguments["to_note_id"]
+		if !ok {
+			return nil, fmt.Errorf("to_note_id is required")
+		}
+		toNoteID, err := mcpx.ParseInt64(toNoteIDRaw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to_note_id: %w", err)
+		}
+
+		// Parse optional type filter
+		connType, err := parseConnectionType(arguments, "type")
+		if err != nil {
+			return nil, err
+		}
+
+		deleted, err := storage.DeleteBetween(ctx, fromNoteID, toNoteID, connType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete connections: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Successfully deleted %d connections between notes %d and %d", deleted, fromNoteID, toNoteID),
+				},
+			},
+		}, nil
+	}
+}
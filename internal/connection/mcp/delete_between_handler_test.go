@@ -0,0 +1,142 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+)
+
+func TestDeleteBetweenHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewDeleteBetweenHandler(mockStorage)
+
+	connType := "supports"
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "delete all types",
+			args: map[string]interface{}{
+				"from_note_id": int64(1),
+				"to_note_id":   int64(2),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					DeleteBetween(gomock.Any(), int64(1), int64(2), (*string)(nil)).
+					Return(int64(3), nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully deleted 3 connections between notes 1 and 2",
+		},
+		{
+			name: "delete single type",
+			args: map[string]interface{}{
+				"from_note_id": float64(1),
+				"to_note_id":   "2",
+				"type":         "supports",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					DeleteBetween(gomock.Any(), int64(1), int64(2), &connType).
+					Return(int64(1), nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully deleted 1 connections",
+		},
+		{
+			name: "nothing to delete",
+			args: map[string]interface{}{
+				"from_note_id": int64(1),
+				"to_note_id":   int64(2),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					DeleteBetween(gomock.Any(), int64(1), int64(2), (*string)(nil)).
+					Return(int64(0), nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully deleted 0 connections",
+		},
+		{
+			name: "missing from_note_id",
+			args: map[string]interface{}{
+				"to_note_id": int64(2),
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "from_note_id is required",
+		},
+		{
+			name: "missing to_note_id",
+			args: map[string]interface{}{
+				"from_note_id": int64(1),
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "to_note_id is required",
+		},
+		{
+			name: "invalid type",
+			args: map[string]interface{}{
+				"from_note_id": int64(1),
+				"to_note_id":   int64(2),
+				"type":         "invalid_type",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid connection type",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{
+				"from_note_id": int64(1),
+				"to_note_id":   int64(2),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					DeleteBetween(gomock.Any(), int64(1), int64(2), (*string)(nil)).
+					Return(int64(0), errors.New("storage error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to delete connections",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
 )
 
 // NewDeleteHandler creates a new handler for deleting connections
@@ -24,7 +25,7 @@ func NewDeleteHandler(storage connection.Storage) server.ToolHandlerFunc {
 			return nil, fmt.Errorf("id is required")
 		}
 
-		id, err := parseInt64(idRaw)
+		id, err := mcpx.ParseInt64(idRaw)
 		if err != nil {
 			return nil, fmt.Errorf("invalid id: %w", err)
 		}
@@ -33,6 +34,30 @@ func NewDeleteHandler(storage connection.Storage) server.ToolHandlerFunc {
 			return nil, fmt.Errorf("id must be a positive integer")
 		}
 
+		// Parse optional soft
+		soft := false
+		if softRaw, ok := arguments["soft"]; ok {
+			soft, ok = softRaw.(bool)
+			if !ok {
+				return nil, fmt.Errorf("soft must be a boolean")
+			}
+		}
+
+		if soft {
+			if err := storage.SoftDelete(ctx, id); err != nil {
+				return nil, fmt.Errorf("failed to delete connection: %w", err)
+			}
+
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.TextContent{
+						Type: "text",
+						Text: fmt.Sprintf("Successfully moved connection with ID: %d to the trash. Use restore_connection to bring it back", id),
+					},
+				},
+			}, nil
+		}
+
 		err = storage.Delete(ctx, id)
 		if err != nil {
 			return nil, fmt.Errorf("failed to delete connection: %w", err)
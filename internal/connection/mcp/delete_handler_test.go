@@ -66,6 +66,58 @@ func TestDeleteHandler(t *testing.T) {
 			wantErr:     false,
 			wantContent: "Successfully deleted connection with ID: 1",
 		},
+		{
+			name: "soft delete",
+			args: map[string]interface{}{
+				"id":   int64(1),
+				"soft": true,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					SoftDelete(gomock.Any(), int64(1)).
+					Return(nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully moved connection with ID: 1 to the trash",
+		},
+		{
+			name: "soft false deletes permanently",
+			args: map[string]interface{}{
+				"id":   int64(1),
+				"soft": false,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Delete(gomock.Any(), int64(1)).
+					Return(nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully deleted connection with ID: 1",
+		},
+		{
+			name: "invalid soft",
+			args: map[string]interface{}{
+				"id":   int64(1),
+				"soft": "yes",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "soft must be a boolean",
+		},
+		{
+			name: "soft delete storage error",
+			args: map[string]interface{}{
+				"id":   int64(1),
+				"soft": true,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					SoftDelete(gomock.Any(), int64(1)).
+					Return(errors.New("connection not found: 1"))
+			},
+			wantErr:     true,
+			wantContent: "failed to delete connection",
+		},
 		{
 			name: "missing id",
 			args: map[string]interface{}{},
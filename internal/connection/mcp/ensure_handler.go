@@ -0,0 +1,101 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewCreateEnsuringNotesHandler creates a new handler for connecting two notes
+// by title, creating either note when it does not exist yet
+func NewCreateEnsuringNotesHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		from, err := parseEnsureNote(arguments, "from")
+		if err != nil {
+			return nil, err
+		}
+		to, err := parseEnsureNote(arguments, "to")
+		if err != nil {
+			return nil, err
+		}
+
+		details, err := parseConnectionDetails(arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		createReq := connection.EnsureRequest{
+			From:          from,
+			To:            to,
+			Type:          details.Type,
+			Description:   details.Description,
+			Strength:      details.Strength,
+			Metadata:      details.Metadata,
+			Source:        details.Source,
+			AutoSymmetric: details.AutoSymmetric,
+		}
+
+		conn, err := storage.CreateConnectionEnsuringNotes(ctx, createReq)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create connection: %w", err)
+		}
+
+		result := newConnectionResponse(conn)
+
+		jsonData, err := mcpx.MarshalJSON(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Successfully created connection with ID: %d from note %d to note %d%s\n\n%s",
+						conn.ID, conn.FromNoteID, conn.ToNoteID, creationNotes(ctx, storage, conn, createReq.AutoSymmetric), string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
+
+// parseEnsureNote reads the <side>_title argument and the optional
+// <side>_content and <side>_type used when the note has to be created
+func parseEnsureNote(arguments map[string]interface{}, side string) (connection.EnsureNoteRequest, error) {
+	// Parse title
+	title, ok := arguments[side+"_title"].(string)
+	if !ok || strings.TrimSpace(title) == "" {
+		return connection.EnsureNoteRequest{}, fmt.Errorf("%s_title is required", side)
+	}
+
+	// Parse optional content
+	var content string
+	if raw, ok := arguments[side+"_content"]; ok {
+		content, ok = raw.(string)
+		if !ok {
+			return connection.EnsureNoteRequest{}, fmt.Errorf("%s_content must be a string", side)
+		}
+	}
+
+	// Parse optional type
+	var noteType string
+	if raw, ok := arguments[side+"_type"]; ok {
+		noteType, ok = raw.(string)
+		if !ok {
+			return connection.EnsureNoteRequest{}, fmt.Errorf("%s_type must be a string", side)
+		}
+	}
+
+	return connection.EnsureNoteRequest{Title: title, Content: content, Type: noteType}, nil
+}
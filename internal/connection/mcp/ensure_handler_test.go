@@ -0,0 +1,157 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+)
+
+func TestCreateEnsuringNotesHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewCreateEnsuringNotesHandler(mockStorage)
+
+	now := time.Now()
+	source := "capture-agent"
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "titles only",
+			args: map[string]interface{}{
+				"from_title": "Go Basics",
+				"to_title":   "New Idea",
+				"type":       "references",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					CreateConnectionEnsuringNotes(gomock.Any(), connection.EnsureRequest{
+						From:     connection.EnsureNoteRequest{Title: "Go Basics"},
+						To:       connection.EnsureNoteRequest{Title: "New Idea"},
+						Type:     "references",
+						Strength: connection.DefaultStrength(),
+					}).
+					Return(&connection.Connection{
+						ID: 3, FromNoteID: 10, ToNoteID: 12, Type: "references", Strength: connection.DefaultStrength(),
+						CreatedAt: now, UpdatedAt: now,
+					}, nil)
+			},
+			wantContent: "Successfully created connection with ID: 3 from note 10 to note 12",
+		},
+		{
+			name: "content and type for new notes",
+			args: map[string]interface{}{
+				"from_title":   "New Idea",
+				"from_content": "An idea worth keeping",
+				"from_type":    "markdown",
+				"to_title":     "Go Basics",
+				"to_content":   "",
+				"type":         "references",
+				"strength":     7,
+				"source":       source,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					CreateConnectionEnsuringNotes(gomock.Any(), connection.EnsureRequest{
+						From:     connection.EnsureNoteRequest{Title: "New Idea", Content: "An idea worth keeping", Type: "markdown"},
+						To:       connection.EnsureNoteRequest{Title: "Go Basics"},
+						Type:     "references",
+						Strength: 7,
+						Source:   &source,
+					}).
+					Return(&connection.Connection{
+						ID: 4, FromNoteID: 12, ToNoteID: 10, Type: "references", Strength: 7,
+						Source: &source, CreatedAt: now, UpdatedAt: now,
+					}, nil)
+			},
+			wantContent: `"source": "capture-agent"`,
+		},
+		{
+			name: "missing to_title",
+			args: map[string]interface{}{
+				"from_title": "Go Basics",
+				"type":       "references",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "to_title is required",
+		},
+		{
+			name: "non-string from_content",
+			args: map[string]interface{}{
+				"from_title":   "Go Basics",
+				"from_content": 5,
+				"to_title":     "New Idea",
+				"type":         "references",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "from_content must be a string",
+		},
+		{
+			name: "invalid connection type",
+			args: map[string]interface{}{
+				"from_title": "Go Basics",
+				"to_title":   "New Idea",
+				"type":       "invalid_type",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid connection type",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{
+				"from_title": "Go Basics",
+				"to_title":   "New Idea",
+				"type":       "references",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					CreateConnectionEnsuringNotes(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("to_title: invalid note type: diagram"))
+			},
+			wantErr:     true,
+			wantContent: "failed to create connection: to_title: invalid note type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewExportCSVHandler creates a new handler for exporting connections as CSV
+func NewExportCSVHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		exportReq := connection.ExportRequest{
+			IncludeTitles: true,
+		}
+
+		// Parse optional include_titles
+		if includeTitlesRaw, ok := arguments["include_titles"]; ok {
+			exportReq.IncludeTitles, ok = includeTitlesRaw.(bool)
+			if !ok {
+				return nil, fmt.Errorf("include_titles must be a boolean")
+			}
+		}
+
+		if err := parseExportFilters(arguments, &exportReq); err != nil {
+			return nil, err
+		}
+
+		// Parse optional compress
+		compress, err := mcpx.ParseCompress(arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		csvData, err := storage.ExportConnectionsCSV(ctx, exportReq)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export connections: %w", err)
+		}
+
+		return mcpx.ExportResult("Exported connections as CSV", csvData, compress)
+	}
+}
+
+// parseExportFilters parses the optional type and source filters shared by the export tools
+func parseExportFilters(arguments map[string]interface{}, exportReq *connection.ExportRequest) error {
+	// Parse optional type filter
+	var err error
+	exportReq.Type, err = parseConnectionType(arguments, "type")
+	if err != nil {
+		return err
+	}
+
+	// Parse optional source filter
+	if source, ok := arguments["source"].(string); ok && source != "" {
+		exportReq.Source = &source
+	}
+
+	return nil
+}
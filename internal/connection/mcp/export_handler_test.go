@@ -0,0 +1,133 @@
+package mcp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+func TestExportCSVHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewExportCSVHandler(mockStorage)
+
+	csvData := "id,from_note_id,to_note_id,from_title,to_title,type,strength,description,created_at\n" +
+		"1,1,2,A,B,supports,5,\"one, two\",2024-01-01T00:00:00Z\n"
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "titles included by default",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ExportConnectionsCSV(gomock.Any(), connection.ExportRequest{IncludeTitles: true}).
+					Return(csvData, nil)
+			},
+			wantErr:     false,
+			wantContent: csvData,
+		},
+		{
+			name: "without titles and with filters",
+			args: map[string]interface{}{
+				"include_titles": false,
+				"type":           "supports",
+				"source":         "importer",
+			},
+			mockSetup: func() {
+				connType, source := "supports", "importer"
+				mockStorage.EXPECT().
+					ExportConnectionsCSV(gomock.Any(), connection.ExportRequest{Type: &connType, Source: &source}).
+					Return("id,from_note_id,to_note_id,type,strength,description,created_at\n", nil)
+			},
+			wantErr:     false,
+			wantContent: "Exported connections as CSV:\n\nid,from_note_id,to_note_id,type",
+		},
+		{
+			name: "invalid include_titles type",
+			args: map[string]interface{}{
+				"include_titles": "no",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "include_titles must be a boolean",
+		},
+		{
+			name: "invalid type",
+			args: map[string]interface{}{
+				"type": "invalid_type",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid connection type",
+		},
+		{
+			name: "compressed",
+			args: map[string]interface{}{"compress": true},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ExportConnectionsCSV(gomock.Any(), connection.ExportRequest{IncludeTitles: true}).
+					Return(csvData, nil)
+			},
+			wantErr:     false,
+			wantContent: fmt.Sprintf("Exported connections as CSV, gzip-compressed from %d bytes and base64-encoded:\n\n%s", len(csvData), mcpx.CompressedPrefix),
+		},
+		{
+			name:        "invalid compress type",
+			args:        map[string]interface{}{"compress": 1.0},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "compress must be a boolean",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ExportConnectionsCSV(gomock.Any(), gomock.Any()).
+					Return("", fmt.Errorf("database error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to export connections",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
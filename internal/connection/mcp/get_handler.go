@@ -2,13 +2,13 @@ package mcp
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
 )
 
 // NewGetHandler creates a new handler for getting connections by ID
@@ -25,7 +25,7 @@ func NewGetHandler(storage connection.Storage) server.ToolHandlerFunc {
 			return nil, fmt.Errorf("id is required")
 		}
 
-		id, err := parseInt64(idRaw)
+		id, err := mcpx.ParseInt64(idRaw)
 		if err != nil {
 			return nil, fmt.Errorf("invalid id: %w", err)
 		}
@@ -39,19 +39,9 @@ func NewGetHandler(storage connection.Storage) server.ToolHandlerFunc {
 			return nil, fmt.Errorf("failed to get connection: %w", err)
 		}
 
-		result := map[string]interface{}{
-			"id":           conn.ID,
-			"from_note_id": conn.FromNoteID,
-			"to_note_id":   conn.ToNoteID,
-			"type":         conn.Type,
-			"description":  conn.Description,
-			"strength":     conn.Strength,
-			"metadata":     conn.Metadata,
-			"created_at":   conn.CreatedAt,
-			"updated_at":   conn.UpdatedAt,
-		}
+		result := newConnectionResponse(conn)
 
-		jsonData, err := json.MarshalIndent(result, "", "  ")
+		jsonData, err := mcpx.MarshalJSON(result)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal result: %w", err)
 		}
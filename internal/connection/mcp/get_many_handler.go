@@ -0,0 +1,81 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// maxGetConnectionIDs caps how many connections one get_connections call may fetch
+const maxGetConnectionIDs = 500
+
+// NewGetManyHandler creates a new handler for getting several connections by ID in one call
+func NewGetManyHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse ids, dropping repeats
+		idsRaw, ok := arguments["ids"].([]interface{})
+		if !ok || len(idsRaw) == 0 {
+			return nil, fmt.Errorf("ids is required")
+		}
+		if len(idsRaw) > maxGetConnectionIDs {
+			return nil, fmt.Errorf("ids must contain at most %d IDs, got: %d", maxGetConnectionIDs, len(idsRaw))
+		}
+		ids := make([]int64, 0, len(idsRaw))
+		seen := make(map[int64]bool, len(idsRaw))
+		for _, raw := range idsRaw {
+			id, err := mcpx.ParseID(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ids: %w", err)
+			}
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+
+		conns, err := storage.GetMany(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get connections: %w", err)
+		}
+
+		found := make(map[int64]bool, len(conns))
+		for _, conn := range conns {
+			found[conn.ID] = true
+		}
+		missing := []int64{}
+		for _, id := range ids {
+			if !found[id] {
+				missing = append(missing, id)
+			}
+		}
+
+		result := map[string]interface{}{
+			"items":       newConnectionResponses(conns),
+			"missing_ids": missing,
+		}
+
+		jsonData, err := mcpx.MarshalJSON(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Found %d of %d connections:\n\n%s", len(conns), len(ids), string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
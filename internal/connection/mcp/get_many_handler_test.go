@@ -0,0 +1,136 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+)
+
+func TestGetManyHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewGetManyHandler(mockStorage)
+	now := time.Now()
+
+	tooMany := make([]interface{}, 501)
+	for i := range tooMany {
+		tooMany[i] = float64(i + 1)
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "mix of existing and missing IDs",
+			args: map[string]interface{}{"ids": []interface{}{float64(7), float64(404), "2", float64(7)}},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetMany(gomock.Any(), []int64{7, 404, 2}).
+					Return([]connection.Connection{
+						{ID: 7, FromNoteID: 1, ToNoteID: 2, Type: "supports", Strength: 5, CreatedAt: now, UpdatedAt: now},
+						{ID: 2, FromNoteID: 2, ToNoteID: 3, Type: "cites", Strength: 3, CreatedAt: now, UpdatedAt: now},
+					}, nil)
+			},
+			wantContent: `"missing_ids": [
+    404
+  ]`,
+		},
+		{
+			name: "summary counts found connections",
+			args: map[string]interface{}{"ids": []interface{}{float64(7), float64(8)}},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetMany(gomock.Any(), []int64{7, 8}).
+					Return([]connection.Connection{{ID: 8, FromNoteID: 1, ToNoteID: 3, Type: "references", Strength: 2}}, nil)
+			},
+			wantContent: "Found 1 of 2 connections",
+		},
+		{
+			name: "nothing found",
+			args: map[string]interface{}{"ids": []interface{}{float64(9)}},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetMany(gomock.Any(), []int64{9}).
+					Return([]connection.Connection{}, nil)
+			},
+			wantContent: `"items": []`,
+		},
+		{
+			name:        "missing ids",
+			args:        map[string]interface{}{},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "ids is required",
+		},
+		{
+			name:        "non-positive id",
+			args:        map[string]interface{}{"ids": []interface{}{float64(0)}},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid ids: IDs must be positive, got: 0",
+		},
+		{
+			name:        "unparseable id",
+			args:        map[string]interface{}{"ids": []interface{}{"seven"}},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid ids",
+		},
+		{
+			name:        "too many ids",
+			args:        map[string]interface{}{"ids": tooMany},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "ids must contain at most 500 IDs",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{"ids": []interface{}{float64(1)}},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetMany(gomock.Any(), []int64{1}).
+					Return(nil, errors.New("database locked"))
+			},
+			wantErr:     true,
+			wantContent: "failed to get connections",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
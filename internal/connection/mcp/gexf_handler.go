@@ -0,0 +1,40 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewExportGEXFHandler creates a new handler for exporting the graph as GEXF
+func NewExportGEXFHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		var exportReq connection.ExportRequest
+		if err := parseExportFilters(arguments, &exportReq); err != nil {
+			return nil, err
+		}
+
+		// Parse optional compress
+		compress, err := mcpx.ParseCompress(arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		gexfData, err := storage.ExportGEXF(ctx, exportReq)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export graph: %w", err)
+		}
+
+		return mcpx.ExportResult("Exported graph as GEXF", gexfData, compress)
+	}
+}
@@ -0,0 +1,141 @@
+package mcp_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+func TestExportGEXFHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewExportGEXFHandler(mockStorage)
+
+	gexfData := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<gexf xmlns="http://gexf.net/1.3" version="1.3"></gexf>` + "\n"
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "whole graph",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ExportGEXF(gomock.Any(), connection.ExportRequest{}).
+					Return(gexfData, nil)
+			},
+			wantErr:     false,
+			wantContent: "Exported graph as GEXF:\n\n" + gexfData,
+		},
+		{
+			name: "with filters",
+			args: map[string]interface{}{
+				"type":   "supports",
+				"source": "importer",
+			},
+			mockSetup: func() {
+				connType, source := "supports", "importer"
+				mockStorage.EXPECT().
+					ExportGEXF(gomock.Any(), connection.ExportRequest{Type: &connType, Source: &source}).
+					Return(gexfData, nil)
+			},
+			wantErr:     false,
+			wantContent: `<gexf xmlns="http://gexf.net/1.3"`,
+		},
+		{
+			name: "invalid type",
+			args: map[string]interface{}{
+				"type": "invalid_type",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid connection type",
+		},
+		{
+			name: "compressed",
+			args: map[string]interface{}{"compress": true},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ExportGEXF(gomock.Any(), connection.ExportRequest{}).
+					Return(gexfData, nil)
+			},
+			wantErr:     false,
+			wantContent: fmt.Sprintf("Exported graph as GEXF, gzip-compressed from %d bytes and base64-encoded:\n\n%s", len(gexfData), mcpx.CompressedPrefix),
+		},
+		{
+			name:        "invalid compress type",
+			args:        map[string]interface{}{"compress": "yes"},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "compress must be a boolean",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ExportGEXF(gomock.Any(), gomock.Any()).
+					Return("", fmt.Errorf("database error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to export graph",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+
+	t.Run("compressed export decompresses to the plain one", func(t *testing.T) {
+		mockStorage.EXPECT().
+			ExportGEXF(gomock.Any(), connection.ExportRequest{}).
+			Return(gexfData, nil)
+
+		req := gomcp.CallToolRequest{Params: gomcp.CallToolParams{Arguments: map[string]interface{}{"compress": true}}}
+		result, err := handler(context.Background(), req)
+		require.NoError(t, err)
+
+		_, body, found := strings.Cut(result.Content[0].(gomcp.TextContent).Text, "\n\n")
+		require.True(t, found)
+		got, err := mcpx.DecompressText(body)
+		require.NoError(t, err)
+		assert.Equal(t, gexfData, got)
+	})
+
+}
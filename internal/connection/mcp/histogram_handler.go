@@ -0,0 +1,89 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewStrengthHistogramHandler creates a new handler for counting connections by strength bucket
+func NewStrengthHistogramHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		buckets, err := parseStrengthBuckets(arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		histogram, err := storage.GetStrengthHistogram(ctx, buckets)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get strength histogram: %w", err)
+		}
+
+		var total int64
+		for _, b := range histogram {
+			total += b.Count
+		}
+
+		jsonData, err := mcpx.MarshalJSON(histogram)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Strength histogram of %d connections in %d buckets:\n\n%s", total, len(histogram), string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
+
+// parseStrengthBuckets builds the buckets from either a bucket count or a
+// list of boundaries, or returns nil for the default buckets
+func parseStrengthBuckets(arguments map[string]interface{}) ([]connection.Bucket, error) {
+	bucketsRaw, hasBuckets := arguments["buckets"]
+	boundariesRaw, hasBoundaries := arguments["boundaries"]
+	if hasBuckets && hasBoundaries {
+		return nil, fmt.Errorf("buckets and boundaries cannot be combined")
+	}
+
+	// Parse optional buckets
+	if hasBuckets {
+		count, err := mcpx.ParseInt(bucketsRaw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid buckets: %w", err)
+		}
+		return connection.EvenStrengthBuckets(count)
+	}
+
+	// Parse optional boundaries
+	if hasBoundaries {
+		list, ok := boundariesRaw.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("boundaries must be an array of strengths")
+		}
+		boundaries := make([]int, 0, len(list))
+		for _, raw := range list {
+			boundary, err := mcpx.ParseInt(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid boundaries: %w", err)
+			}
+			boundaries = append(boundaries, boundary)
+		}
+		return connection.StrengthBucketsFromBoundaries(boundaries)
+	}
+
+	return nil, nil
+}
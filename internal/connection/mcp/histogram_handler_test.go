@@ -0,0 +1,152 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+)
+
+func TestStrengthHistogramHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewStrengthHistogramHandler(mockStorage)
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "default buckets",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetStrengthHistogram(gomock.Any(), gomock.Nil()).
+					Return([]connection.Bucket{
+						{Label: "weak", Min: 1, Max: 3, Count: 2},
+						{Label: "medium", Min: 4, Max: 7, Count: 5},
+						{Label: "strong", Min: 8, Max: 10, Count: 1},
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Strength histogram of 8 connections in 3 buckets",
+		},
+		{
+			name: "bucket count",
+			args: map[string]interface{}{"buckets": float64(2)},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetStrengthHistogram(gomock.Any(), []connection.Bucket{
+						{Label: "1-5", Min: 1, Max: 5},
+						{Label: "6-10", Min: 6, Max: 10},
+					}).
+					Return([]connection.Bucket{
+						{Label: "1-5", Min: 1, Max: 5, Count: 4},
+						{Label: "6-10", Min: 6, Max: 10, Count: 3},
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: `"label": "6-10"`,
+		},
+		{
+			name: "boundaries",
+			args: map[string]interface{}{"boundaries": []interface{}{float64(4), float64(8)}},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetStrengthHistogram(gomock.Any(), []connection.Bucket{
+						{Label: "1-4", Min: 1, Max: 4},
+						{Label: "5-8", Min: 5, Max: 8},
+						{Label: "9-10", Min: 9, Max: 10},
+					}).
+					Return([]connection.Bucket{
+						{Label: "1-4", Min: 1, Max: 4},
+						{Label: "5-8", Min: 5, Max: 8},
+						{Label: "9-10", Min: 9, Max: 10},
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Strength histogram of 0 connections in 3 buckets",
+		},
+		{
+			name:        "buckets and boundaries together",
+			args:        map[string]interface{}{"buckets": float64(2), "boundaries": []interface{}{float64(5)}},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "buckets and boundaries cannot be combined",
+		},
+		{
+			name:        "too many buckets",
+			args:        map[string]interface{}{"buckets": float64(20)},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "bucket count must be between 1 and 10",
+		},
+		{
+			name:        "invalid buckets",
+			args:        map[string]interface{}{"buckets": true},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid buckets",
+		},
+		{
+			name:        "boundaries not an array",
+			args:        map[string]interface{}{"boundaries": "3,7"},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "boundaries must be an array of strengths",
+		},
+		{
+			name:        "boundaries out of order",
+			args:        map[string]interface{}{"boundaries": []interface{}{float64(7), float64(3)}},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "boundaries must increase",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetStrengthHistogram(gomock.Any(), gomock.Nil()).
+					Return(nil, errors.New("database is closed"))
+			},
+			wantErr:     true,
+			wantContent: "failed to get strength histogram",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
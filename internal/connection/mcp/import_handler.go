@@ -0,0 +1,47 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewImportCSVHandler creates a new handler for importing connections from CSV
+func NewImportCSVHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse csv
+		csvData, ok := arguments["csv"].(string)
+		if !ok || csvData == "" {
+			return nil, fmt.Errorf("csv is required")
+		}
+
+		result, err := storage.ImportConnectionsCSV(ctx, csvData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import connections: %w", err)
+		}
+
+		jsonData, err := mcpx.MarshalJSON(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Imported %d connections, %d rows failed:\n\n%s", result.Created, len(result.Errors), string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
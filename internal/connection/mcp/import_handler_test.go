@@ -0,0 +1,119 @@
+package mcp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+)
+
+func TestImportCSVHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewImportCSVHandler(mockStorage)
+
+	csvData := "from_note_id,to_note_id,type,strength\n1,2,supports,5\n1,1,supports,5\n"
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "import with row errors",
+			args: map[string]interface{}{
+				"csv": csvData,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ImportConnectionsCSV(gomock.Any(), csvData).
+					Return(&connection.ImportResult{
+						Created:    1,
+						CreatedIDs: []int64{7},
+						Errors:     []connection.ImportRowError{{Row: 3, Error: "self-connections are not allowed"}},
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Imported 1 connections, 1 rows failed",
+		},
+		{
+			name: "row errors are listed",
+			args: map[string]interface{}{
+				"csv": csvData,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ImportConnectionsCSV(gomock.Any(), csvData).
+					Return(&connection.ImportResult{
+						CreatedIDs: []int64{},
+						Errors:     []connection.ImportRowError{{Row: 2, Error: "invalid connection type: x"}},
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: `"error": "invalid connection type: x"`,
+		},
+		{
+			name:        "missing csv",
+			args:        map[string]interface{}{},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "csv is required",
+		},
+		{
+			name: "csv is not a string",
+			args: map[string]interface{}{
+				"csv": 42,
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "csv is required",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{
+				"csv": "from,to\n",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ImportConnectionsCSV(gomock.Any(), "from,to\n").
+					Return(nil, fmt.Errorf("csv header is missing the type column"))
+			},
+			wantErr:     true,
+			wantContent: "failed to import connections: csv header is missing the type column",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewLatestConnectionsHandler creates a new handler for getting the most recent connection of several notes
+func NewLatestConnectionsHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse note_ids
+		noteIDs, err := parseNoteIDs(arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		latest, err := storage.GetLatestConnectionPerNote(ctx, noteIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest connections: %w", err)
+		}
+
+		connections := make(map[int64]ConnectionResponse, len(latest))
+		for noteID, conn := range latest {
+			connections[noteID] = newConnectionResponse(conn)
+		}
+
+		jsonData, err := mcpx.MarshalJSON(map[string]interface{}{
+			"connections": connections,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Found the latest connection for %d of %d notes:\n\n%s", len(latest), len(noteIDs), string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
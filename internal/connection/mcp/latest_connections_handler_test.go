@@ -0,0 +1,122 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+)
+
+func TestLatestConnectionsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewLatestConnectionsHandler(mockStorage)
+
+	latest := &connection.Connection{ID: 10, FromNoteID: 1, ToNoteID: 2, Type: "supports", Strength: 5}
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "successful lookup",
+			args: map[string]interface{}{
+				"note_ids": []interface{}{float64(1), "2"},
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetLatestConnectionPerNote(gomock.Any(), []int64{1, 2}).
+					Return(map[int64]*connection.Connection{1: latest, 2: latest}, nil)
+			},
+			wantContent: "Found the latest connection for 2 of 2 notes:",
+		},
+		{
+			name: "keyed by note ID",
+			args: map[string]interface{}{
+				"note_ids": []interface{}{float64(2)},
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetLatestConnectionPerNote(gomock.Any(), []int64{2}).
+					Return(map[int64]*connection.Connection{2: latest}, nil)
+			},
+			wantContent: "\"2\": {\n      \"id\": 10,",
+		},
+		{
+			name: "notes without connections",
+			args: map[string]interface{}{
+				"note_ids": []interface{}{float64(3)},
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetLatestConnectionPerNote(gomock.Any(), []int64{3}).
+					Return(map[int64]*connection.Connection{}, nil)
+			},
+			wantContent: "Found the latest connection for 0 of 1 notes:\n\n{\n  \"connections\": {}\n}",
+		},
+		{
+			name:        "missing note_ids",
+			args:        map[string]interface{}{},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "note_ids is required",
+		},
+		{
+			name: "invalid note id",
+			args: map[string]interface{}{
+				"note_ids": []interface{}{"abc"},
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid note_ids",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{
+				"note_ids": []interface{}{float64(1)},
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetLatestConnectionPerNote(gomock.Any(), []int64{1}).
+					Return(nil, errors.New("storage error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to get latest connections",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
@@ -2,17 +2,21 @@ package mcp
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
 )
 
-// NewListHandler creates a new handler for listing connections with filtering
-func NewListHandler(storage connection.Storage) server.ToolHandlerFunc {
+// defaultHalfLifeDays is the strength decay half-life used when strength_decay is set without half_life_days
+const defaultHalfLifeDays = 30.0
+
+// NewListHandler creates a new handler for listing connections with filtering, with page sizes bounded by limits
+func NewListHandler(storage connection.Storage, limits mcpx.ListLimits) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		arguments, ok := req.Params.Arguments.(map[string]interface{})
 		if !ok {
@@ -20,27 +24,21 @@ func NewListHandler(storage connection.Storage) server.ToolHandlerFunc {
 		}
 
 		listReq := connection.ListConnectionsRequest{
-			Limit:    100, // Default limit
-			Offset:   0,   // Default offset
+			Offset:   0, // Default offset
 			OrderBy:  "id",
 			OrderDir: "asc",
 		}
 
 		// Parse optional limit
-		if limitRaw, ok := arguments["limit"]; ok {
-			limit, err := parseInt(limitRaw)
-			if err != nil {
-				return nil, fmt.Errorf("invalid limit: %w", err)
-			}
-			if limit < 1 || limit > 1000 {
-				return nil, fmt.Errorf("limit must be between 1 and 1000, got: %d", limit)
-			}
-			listReq.Limit = limit
+		limit, err := limits.ParseLimit(arguments)
+		if err != nil {
+			return nil, err
 		}
+		listReq.Limit = limit
 
 		// Parse optional offset
 		if offsetRaw, ok := arguments["offset"]; ok {
-			offset, err := parseInt(offsetRaw)
+			offset, err := mcpx.ParseInt(offsetRaw)
 			if err != nil {
 				return nil, fmt.Errorf("invalid offset: %w", err)
 			}
@@ -50,57 +48,21 @@ func NewListHandler(storage connection.Storage) server.ToolHandlerFunc {
 			listReq.Offset = offset
 		}
 
-		// Parse optional from_note_id filter
-		if fromNoteIDRaw, ok := arguments["from_note_id"]; ok {
-			fromNoteID, err := parseInt64(fromNoteIDRaw)
-			if err != nil {
-				return nil, fmt.Errorf("invalid from_note_id: %w", err)
-			}
-			listReq.FromNoteID = &fromNoteID
-		}
-
-		// Parse optional to_note_id filter
-		if toNoteIDRaw, ok := arguments["to_note_id"]; ok {
-			toNoteID, err := parseInt64(toNoteIDRaw)
-			if err != nil {
-				return nil, fmt.Errorf("invalid to_note_id: %w", err)
-			}
-			listReq.ToNoteID = &toNoteID
-		}
-
-		// Parse optional type filter
-		if connectionType, ok := arguments["type"].(string); ok && connectionType != "" {
-			// Validate connection type
-			if !connection.IsValidConnectionType(connectionType) {
-				return nil, fmt.Errorf("invalid connection type: %s. Valid types are: %v", connectionType, connection.ValidConnectionTypes())
-			}
-			listReq.Type = &connectionType
-		}
-
-		// Parse optional strength filter
-		if strengthRaw, ok := arguments["strength"]; ok {
-			strength, err := parseInt(strengthRaw)
-			if err != nil {
-				return nil, fmt.Errorf("invalid strength: %w", err)
-			}
-			if strength < 1 || strength > 10 {
-				return nil, fmt.Errorf("strength must be between 1 and 10, got: %d", strength)
-			}
-			listReq.Strength = &strength
+		if err := parseListFilters(arguments, &listReq); err != nil {
+			return nil, err
 		}
 
 		// Parse optional order_by
 		if orderBy, ok := arguments["order_by"].(string); ok && orderBy != "" {
-			validOrderBy := []string{"id", "created_at", "updated_at", "strength", "type"}
 			isValid := false
-			for _, valid := range validOrderBy {
+			for _, valid := range connection.ValidOrderByFields() {
 				if orderBy == valid {
 					isValid = true
 					break
 				}
 			}
 			if !isValid {
-				return nil, fmt.Errorf("invalid order_by: %s. Valid values are: %v", orderBy, validOrderBy)
+				return nil, fmt.Errorf("invalid order_by: %s. Valid values are: %v", orderBy, connection.ValidOrderByFields())
 			}
 			listReq.OrderBy = orderBy
 		}
@@ -113,19 +75,45 @@ func NewListHandler(storage connection.Storage) server.ToolHandlerFunc {
 			listReq.OrderDir = orderDir
 		}
 
+		// Parse optional strength_decay and half_life_days
+		strengthDecay := false
+		if strengthDecayRaw, ok := arguments["strength_decay"]; ok {
+			strengthDecay, ok = strengthDecayRaw.(bool)
+			if !ok {
+				return nil, fmt.Errorf("strength_decay must be a boolean")
+			}
+		}
+		if halfLifeRaw, ok := arguments["half_life_days"]; ok {
+			if !strengthDecay {
+				return nil, fmt.Errorf("half_life_days requires strength_decay to be true")
+			}
+			halfLife, err := mcpx.ParseFloat64(halfLifeRaw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid half_life_days: %w", err)
+			}
+			if !(halfLife > 0) {
+				return nil, fmt.Errorf("half_life_days must be positive, got: %v", halfLife)
+			}
+			listReq.StrengthDecay = &connection.StrengthDecay{HalfLifeDays: halfLife}
+		} else if strengthDecay {
+			listReq.StrengthDecay = &connection.StrengthDecay{HalfLifeDays: defaultHalfLifeDays}
+		}
+
 		response, err := storage.List(ctx, listReq)
 		if err != nil {
 			return nil, fmt.Errorf("failed to list connections: %w", err)
 		}
 
-		result := map[string]interface{}{
-			"items": response.Items,
-			"total": response.Total,
-			"limit": listReq.Limit,
-			"offset": listReq.Offset,
+		result := ListConnectionsResponse{
+			Items:   newConnectionResponses(response.Items),
+			Limit:   listReq.Limit,
+			Offset:  listReq.Offset,
+			Total:   response.Total,
+			Count:   len(response.Items),
+			HasMore: int64(listReq.Offset+len(response.Items)) < response.Total,
 		}
 
-		jsonData, err := json.MarshalIndent(result, "", "  ")
+		jsonData, err := mcpx.MarshalJSON(result)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal result: %w", err)
 		}
@@ -144,4 +132,65 @@ func NewListHandler(storage connection.Storage) server.ToolHandlerFunc {
 			},
 		}, nil
 	}
-}
\ No newline at end of file
+}
+
+// parseListFilters reads the filter arguments shared by list_connections and
+// count_connections into listReq
+func parseListFilters(arguments map[string]interface{}, listReq *connection.ListConnectionsRequest) error {
+	// Parse optional from_note_id filter
+	if fromNoteIDRaw, ok := arguments["from_note_id"]; ok {
+		fromNoteID, err := mcpx.ParseInt64(fromNoteIDRaw)
+		if err != nil {
+			return fmt.Errorf("invalid from_note_id: %w", err)
+		}
+		listReq.FromNoteID = &fromNoteID
+	}
+
+	// Parse optional to_note_id filter
+	if toNoteIDRaw, ok := arguments["to_note_id"]; ok {
+		toNoteID, err := mcpx.ParseInt64(toNoteIDRaw)
+		if err != nil {
+			return fmt.Errorf("invalid to_note_id: %w", err)
+		}
+		listReq.ToNoteID = &toNoteID
+	}
+
+	// Parse optional type filter
+	var err error
+	listReq.Type, err = parseConnectionType(arguments, "type")
+	if err != nil {
+		return err
+	}
+
+	// Parse optional types filter
+	if _, ok := arguments["types"]; ok {
+		listReq.Types, err = parseConnectionTypes(arguments, "types")
+		if err != nil {
+			return err
+		}
+	}
+
+	// Parse optional strength filter
+	if strengthRaw, ok := arguments["strength"]; ok {
+		strength, err := mcpx.ParseInt(strengthRaw)
+		if err != nil {
+			return fmt.Errorf("invalid strength: %w", err)
+		}
+		if err := connection.ValidateStrength(strength); err != nil {
+			return err
+		}
+		listReq.Strength = &strength
+	}
+
+	// Parse optional source filter
+	if source, ok := arguments["source"].(string); ok && source != "" {
+		listReq.Source = &source
+	}
+
+	// Parse optional description_search filter
+	if search, ok := arguments["description_search"].(string); ok && strings.TrimSpace(search) != "" {
+		listReq.DescriptionSearch = &search
+	}
+
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
 )
 
 func TestListHandler(t *testing.T) {
@@ -20,7 +21,7 @@ func TestListHandler(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockStorage := mock.NewMockStorage(ctrl)
-	handler := mcp.NewListHandler(mockStorage)
+	handler := mcp.NewListHandler(mockStorage, mcpx.DefaultListLimits())
 
 	now := time.Now()
 	desc := "Test connection description"
@@ -103,6 +104,28 @@ func TestListHandler(t *testing.T) {
 			wantErr:     false,
 			wantContent: "Found 1 connections (showing 11-11 of 100 total)",
 		},
+		{
+			name: "last page has no more",
+			args: map[string]interface{}{
+				"limit":  10,
+				"offset": 90,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					List(gomock.Any(), connection.ListConnectionsRequest{
+						Limit:    10,
+						Offset:   90,
+						OrderBy:  "id",
+						OrderDir: "asc",
+					}).
+					Return(&connection.ListConnectionsResponse{
+						Items: make([]connection.Connection, 10),
+						Total: 100,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: `"has_more": false`,
+		},
 		{
 			name: "successful list with filters",
 			args: map[string]interface{}{
@@ -142,6 +165,144 @@ func TestListHandler(t *testing.T) {
 			wantErr:     false,
 			wantContent: "Found 1 connections",
 		},
+		{
+			name: "successful list with several types",
+			args: map[string]interface{}{
+				"type":  "supports",
+				"types": []interface{}{"references", "supports"},
+			},
+			mockSetup: func() {
+				connType := "supports"
+				mockStorage.EXPECT().
+					List(gomock.Any(), connection.ListConnectionsRequest{
+						Limit:    100,
+						Offset:   0,
+						Type:     &connType,
+						Types:    []string{"references", "supports"},
+						OrderBy:  "id",
+						OrderDir: "asc",
+					}).
+					Return(&connection.ListConnectionsResponse{
+						Items: []connection.Connection{},
+						Total: 0,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Found 0 connections",
+		},
+		{
+			name: "mixed-case padded types are normalized",
+			args: map[string]interface{}{
+				"type":  " Supports",
+				"types": []interface{}{"References ", "CITES"},
+			},
+			mockSetup: func() {
+				connType := "supports"
+				mockStorage.EXPECT().
+					List(gomock.Any(), connection.ListConnectionsRequest{
+						Limit:    100,
+						Offset:   0,
+						Type:     &connType,
+						Types:    []string{"references", "cites"},
+						OrderBy:  "id",
+						OrderDir: "asc",
+					}).
+					Return(&connection.ListConnectionsResponse{
+						Items: []connection.Connection{},
+						Total: 0,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Found 0 connections",
+		},
+		{
+			name: "blank type filter is ignored",
+			args: map[string]interface{}{
+				"type": "  ",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					List(gomock.Any(), connection.ListConnectionsRequest{
+						Limit:    100,
+						Offset:   0,
+						OrderBy:  "id",
+						OrderDir: "asc",
+					}).
+					Return(&connection.ListConnectionsResponse{
+						Items: []connection.Connection{},
+						Total: 0,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Found 0 connections",
+		},
+		{
+			name: "successful list with source filter",
+			args: map[string]interface{}{
+				"source": "research-agent",
+			},
+			mockSetup: func() {
+				source := "research-agent"
+				mockStorage.EXPECT().
+					List(gomock.Any(), connection.ListConnectionsRequest{
+						Limit:    100,
+						Offset:   0,
+						Source:   &source,
+						OrderBy:  "id",
+						OrderDir: "asc",
+					}).
+					Return(&connection.ListConnectionsResponse{
+						Items: []connection.Connection{},
+						Total: 0,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Found 0 connections",
+		},
+		{
+			name: "successful list with description search",
+			args: map[string]interface{}{
+				"description_search": "rationale",
+			},
+			mockSetup: func() {
+				search := "rationale"
+				mockStorage.EXPECT().
+					List(gomock.Any(), connection.ListConnectionsRequest{
+						Limit:             100,
+						Offset:            0,
+						DescriptionSearch: &search,
+						OrderBy:           "id",
+						OrderDir:          "asc",
+					}).
+					Return(&connection.ListConnectionsResponse{
+						Items: []connection.Connection{},
+						Total: 0,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Found 0 connections",
+		},
+		{
+			name: "blank description search is ignored",
+			args: map[string]interface{}{
+				"description_search": "   ",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					List(gomock.Any(), connection.ListConnectionsRequest{
+						Limit:    100,
+						Offset:   0,
+						OrderBy:  "id",
+						OrderDir: "asc",
+					}).
+					Return(&connection.ListConnectionsResponse{
+						Items: []connection.Connection{},
+						Total: 0,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Found 0 connections",
+		},
 		{
 			name: "successful list with ordering",
 			args: map[string]interface{}{
@@ -236,6 +397,24 @@ func TestListHandler(t *testing.T) {
 			wantErr:     true,
 			wantContent: "invalid connection type",
 		},
+		{
+			name: "types not an array",
+			args: map[string]interface{}{
+				"types": "supports",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "types must be an array of connection types",
+		},
+		{
+			name: "invalid connection type in types",
+			args: map[string]interface{}{
+				"types": []interface{}{"supports", "invalid_type"},
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid connection type in types: invalid_type",
+		},
 		{
 			name: "invalid strength - too low",
 			args: map[string]interface{}{
@@ -272,6 +451,91 @@ func TestListHandler(t *testing.T) {
 			wantErr:     true,
 			wantContent: "invalid order_dir",
 		},
+		{
+			name: "strength decay with default half-life",
+			args: map[string]interface{}{
+				"strength_decay": true,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					List(gomock.Any(), connection.ListConnectionsRequest{
+						Limit:         100,
+						Offset:        0,
+						OrderBy:       "id",
+						OrderDir:      "asc",
+						StrengthDecay: &connection.StrengthDecay{HalfLifeDays: 30},
+					}).
+					Return(&connection.ListConnectionsResponse{Items: []connection.Connection{}, Total: 0}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Found 0 connections",
+		},
+		{
+			name: "strength decay with custom half-life",
+			args: map[string]interface{}{
+				"strength_decay": true,
+				"half_life_days": 7.5,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					List(gomock.Any(), connection.ListConnectionsRequest{
+						Limit:         100,
+						Offset:        0,
+						OrderBy:       "id",
+						OrderDir:      "asc",
+						StrengthDecay: &connection.StrengthDecay{HalfLifeDays: 7.5},
+					}).
+					Return(&connection.ListConnectionsResponse{Items: []connection.Connection{}, Total: 0}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Found 0 connections",
+		},
+		{
+			name: "strength decay disabled",
+			args: map[string]interface{}{
+				"strength_decay": false,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					List(gomock.Any(), connection.ListConnectionsRequest{
+						Limit:    100,
+						Offset:   0,
+						OrderBy:  "id",
+						OrderDir: "asc",
+					}).
+					Return(&connection.ListConnectionsResponse{Items: []connection.Connection{}, Total: 0}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Found 0 connections",
+		},
+		{
+			name: "invalid strength_decay type",
+			args: map[string]interface{}{
+				"strength_decay": "yes",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "strength_decay must be a boolean",
+		},
+		{
+			name: "half_life_days without strength_decay",
+			args: map[string]interface{}{
+				"half_life_days": 7,
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "half_life_days requires strength_decay",
+		},
+		{
+			name: "non-positive half_life_days",
+			args: map[string]interface{}{
+				"strength_decay": true,
+				"half_life_days": 0,
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "half_life_days must be positive",
+		},
 		{
 			name: "storage error",
 			args: map[string]interface{}{},
@@ -307,4 +571,69 @@ func TestListHandler(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestListHandler_CustomLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewListHandler(mockStorage, mcpx.ListLimits{Default: 5, Max: 20})
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "configured default",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					List(gomock.Any(), connection.ListConnectionsRequest{Limit: 5, OrderBy: "id", OrderDir: "asc"}).
+					Return(&connection.ListConnectionsResponse{Items: []connection.Connection{}}, nil)
+			},
+			wantContent: `"limit": 5`,
+		},
+		{
+			name: "configured maximum",
+			args: map[string]interface{}{"limit": float64(20)},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					List(gomock.Any(), connection.ListConnectionsRequest{Limit: 20, OrderBy: "id", OrderDir: "asc"}).
+					Return(&connection.ListConnectionsResponse{Items: []connection.Connection{}}, nil)
+			},
+			wantContent: `"limit": 20`,
+		},
+		{
+			name:        "above the configured maximum",
+			args:        map[string]interface{}{"limit": float64(21)},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "limit must be between 1 and 20, got: 21",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
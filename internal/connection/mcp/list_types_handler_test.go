@@ -0,0 +1,56 @@
+package mcp_test
+
+import (
+	"context"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+)
+
+func TestListTypesHandler(t *testing.T) {
+	handler := mcp.NewListTypesHandler()
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		wantContent []string
+	}{
+		{
+			name: "lists every connection type",
+			args: map[string]interface{}{},
+			wantContent: append(
+				[]string{"Found 11 connection types:", `"symmetric": true`, `"description":`},
+				connection.ValidConnectionTypes()...,
+			),
+		},
+		{
+			name:        "ignores arguments",
+			args:        map[string]interface{}{"unused": "value"},
+			wantContent: []string{"Found 11 connection types:"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, result)
+
+			text := result.Content[0].(gomcp.TextContent).Text
+			for _, want := range tt.wantContent {
+				assert.Contains(t, text, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewAdjacencyMatrixHandler creates a new handler for exporting the adjacency matrix of a note set
+func NewAdjacencyMatrixHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse note_ids
+		noteIDs, err := parseNoteIDs(arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		// Parse optional symmetric
+		symmetric := false
+		if symmetricRaw, ok := arguments["symmetric"]; ok {
+			symmetric, ok = symmetricRaw.(bool)
+			if !ok {
+				return nil, fmt.Errorf("symmetric must be a boolean")
+			}
+		}
+
+		// Parse optional compress
+		compress, err := mcpx.ParseCompress(arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		matrix, err := storage.ExportAdjacencyMatrix(ctx, noteIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export adjacency matrix: %w", err)
+		}
+		if symmetric {
+			matrix.Symmetrize()
+		}
+
+		jsonData, err := mcpx.MarshalJSON(matrix)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		n := len(matrix.NoteIDs)
+		return mcpx.ExportResult(fmt.Sprintf("Exported %dx%d adjacency matrix (%d missing notes)", n, n, len(matrix.MissingIDs)), string(jsonData), compress)
+	}
+}
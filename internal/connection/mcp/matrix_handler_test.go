@@ -0,0 +1,159 @@
+package mcp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+)
+
+func TestAdjacencyMatrixHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewAdjacencyMatrixHandler(mockStorage)
+
+	// newMatrix returns a fresh matrix for each call, since Symmetrize edits it in place
+	newMatrix := func() *connection.Matrix {
+		return &connection.Matrix{
+			NoteIDs:    []int64{1, 2},
+			Labels:     []string{"A", "B"},
+			Values:     [][]int{{0, 5}, {0, 0}},
+			MissingIDs: []int64{3},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "directed by default",
+			args: map[string]interface{}{
+				"note_ids": []interface{}{float64(1), float64(2), float64(3)},
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ExportAdjacencyMatrix(gomock.Any(), []int64{1, 2, 3}).
+					Return(newMatrix(), nil)
+			},
+			wantErr:     false,
+			wantContent: "Exported 2x2 adjacency matrix (1 missing notes)",
+		},
+		{
+			name: "directed values",
+			args: map[string]interface{}{
+				"note_ids": []interface{}{float64(1), float64(2)},
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ExportAdjacencyMatrix(gomock.Any(), []int64{1, 2}).
+					Return(newMatrix(), nil)
+			},
+			wantErr:     false,
+			wantContent: "[\n      0,\n      0\n    ]",
+		},
+		{
+			name: "symmetric",
+			args: map[string]interface{}{
+				"note_ids":  []interface{}{float64(1), float64(2)},
+				"symmetric": true,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ExportAdjacencyMatrix(gomock.Any(), []int64{1, 2}).
+					Return(newMatrix(), nil)
+			},
+			wantErr:     false,
+			wantContent: "[\n      5,\n      0\n    ]",
+		},
+		{
+			name:        "missing note_ids",
+			args:        map[string]interface{}{},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "note_ids is required",
+		},
+		{
+			name: "invalid symmetric type",
+			args: map[string]interface{}{
+				"note_ids":  []interface{}{float64(1)},
+				"symmetric": "yes",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "symmetric must be a boolean",
+		},
+		{
+			name: "compressed",
+			args: map[string]interface{}{
+				"note_ids": []interface{}{float64(1), float64(2)},
+				"compress": true,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ExportAdjacencyMatrix(gomock.Any(), []int64{1, 2}).
+					Return(newMatrix(), nil)
+			},
+			wantErr:     false,
+			wantContent: "Exported 2x2 adjacency matrix (1 missing notes), gzip-compressed from",
+		},
+		{
+			name: "invalid compress type",
+			args: map[string]interface{}{
+				"note_ids": []interface{}{float64(1)},
+				"compress": "true",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "compress must be a boolean",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{
+				"note_ids": []interface{}{float64(1)},
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ExportAdjacencyMatrix(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("database error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to export adjacency matrix",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
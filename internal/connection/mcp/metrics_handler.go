@@ -0,0 +1,36 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewGraphMetricsHandler creates a new handler for summarizing the structure of the graph
+func NewGraphMetricsHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		metrics, err := storage.GetGraphMetrics(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get graph metrics: %w", err)
+		}
+
+		jsonData, err := mcpx.MarshalJSON(metrics)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Graph has %d notes and %d connections in %d connected components:\n\n%s", metrics.Nodes, metrics.Edges, metrics.ConnectedComponents, string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
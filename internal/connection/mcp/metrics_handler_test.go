@@ -0,0 +1,84 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+)
+
+func TestGraphMetricsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewGraphMetricsHandler(mockStorage)
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "metrics",
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetGraphMetrics(gomock.Any()).
+					Return(&connection.GraphMetrics{Nodes: 3, Edges: 2, Density: 1.0 / 3, AverageDegree: 4.0 / 3, ConnectedComponents: 1}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Graph has 3 notes and 2 connections in 1 connected components:",
+		},
+		{
+			name: "empty graph",
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetGraphMetrics(gomock.Any()).
+					Return(&connection.GraphMetrics{}, nil)
+			},
+			wantErr:     false,
+			wantContent: `"density": 0`,
+		},
+		{
+			name: "storage error",
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetGraphMetrics(gomock.Any()).
+					Return(nil, errors.New("storage error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to get graph metrics",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: map[string]interface{}{},
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewNormalizeStrengthsHandler creates a new handler for rescaling connection strengths onto the full 1-10 range
+func NewNormalizeStrengthsHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse optional dry_run
+		dryRun := false
+		if dryRunRaw, ok := arguments["dry_run"]; ok {
+			dryRun, ok = dryRunRaw.(bool)
+			if !ok {
+				return nil, fmt.Errorf("dry_run must be a boolean")
+			}
+		}
+
+		if !dryRun {
+			updated, err := storage.NormalizeStrengths(ctx, connection.NormalizeStrengthsOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to normalize connection strengths: %w", err)
+			}
+
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.TextContent{
+						Type: "text",
+						Text: fmt.Sprintf("Successfully normalized the strength of %d connections", updated),
+					},
+				},
+			}, nil
+		}
+
+		mapping, err := storage.PlanStrengthNormalization(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to plan strength normalization: %w", err)
+		}
+
+		var wouldChange int64
+		for _, m := range mapping {
+			if m.From != m.To {
+				wouldChange += m.Connections
+			}
+		}
+
+		result := map[string]interface{}{
+			"mapping":      mapping,
+			"would_change": wouldChange,
+		}
+
+		jsonData, err := mcpx.MarshalJSON(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Dry run: normalization would change the strength of %d connections:\n\n%s", wouldChange, string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
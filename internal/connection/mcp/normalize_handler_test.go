@@ -0,0 +1,116 @@
+package mcp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+)
+
+func TestNormalizeStrengthsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewNormalizeStrengthsHandler(mockStorage)
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "normalize strengths",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					NormalizeStrengths(gomock.Any(), connection.NormalizeStrengthsOptions{}).
+					Return(int64(12), nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully normalized the strength of 12 connections",
+		},
+		{
+			name: "dry run returns mapping",
+			args: map[string]interface{}{
+				"dry_run": true,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					PlanStrengthNormalization(gomock.Any()).
+					Return([]connection.StrengthMapping{
+						{From: 4, To: 1, Connections: 3},
+						{From: 5, To: 6, Connections: 7},
+						{From: 6, To: 10, Connections: 2},
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "would change the strength of 12 connections",
+		},
+		{
+			name: "dry run false normalizes",
+			args: map[string]interface{}{
+				"dry_run": false,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					NormalizeStrengths(gomock.Any(), connection.NormalizeStrengthsOptions{}).
+					Return(int64(0), nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully normalized the strength of 0 connections",
+		},
+		{
+			name: "invalid dry_run type",
+			args: map[string]interface{}{
+				"dry_run": "yes",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "dry_run must be a boolean",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					NormalizeStrengths(gomock.Any(), connection.NormalizeStrengthsOptions{}).
+					Return(int64(0), fmt.Errorf("database error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to normalize connection strengths",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
@@ -2,17 +2,17 @@ package mcp
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
 )
 
 // NewNoteConnectionsHandler creates a new handler for getting all connections of a note
-func NewNoteConnectionsHandler(storage connection.Storage) server.ToolHandlerFunc {
+func NewNoteConnectionsHandler(storage connection.Storage, limits mcpx.ListLimits) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		arguments, ok := req.Params.Arguments.(map[string]interface{})
 		if !ok {
@@ -25,57 +25,43 @@ func NewNoteConnectionsHandler(storage connection.Storage) server.ToolHandlerFun
 			return nil, fmt.Errorf("note_id is required")
 		}
 
-		noteID, err := parseInt64(noteIDRaw)
+		noteID, err := mcpx.ParseID(noteIDRaw)
 		if err != nil {
 			return nil, fmt.Errorf("invalid note_id: %w", err)
 		}
 
-		if noteID <= 0 {
-			return nil, fmt.Errorf("note_id must be a positive integer")
-		}
-
 		noteConnReq := connection.NoteConnectionsRequest{
 			NoteID: noteID,
-			Limit:  100, // Default limit
-			Offset: 0,   // Default offset
+			Offset: 0, // Default offset
 		}
 
 		// Parse optional type filter
-		if connectionType, ok := arguments["type"].(string); ok && connectionType != "" {
-			// Validate connection type
-			if !connection.IsValidConnectionType(connectionType) {
-				return nil, fmt.Errorf("invalid connection type: %s. Valid types are: %v", connectionType, connection.ValidConnectionTypes())
-			}
-			noteConnReq.Type = &connectionType
+		noteConnReq.Type, err = parseConnectionType(arguments, "type")
+		if err != nil {
+			return nil, err
 		}
 
 		// Parse optional strength filter
 		if strengthRaw, ok := arguments["strength"]; ok {
-			strength, err := parseInt(strengthRaw)
+			strength, err := mcpx.ParseInt(strengthRaw)
 			if err != nil {
 				return nil, fmt.Errorf("invalid strength: %w", err)
 			}
-			if strength < 1 || strength > 10 {
-				return nil, fmt.Errorf("strength must be between 1 and 10, got: %d", strength)
+			if err := connection.ValidateStrength(strength); err != nil {
+				return nil, err
 			}
 			noteConnReq.Strength = &strength
 		}
 
 		// Parse optional limit
-		if limitRaw, ok := arguments["limit"]; ok {
-			limit, err := parseInt(limitRaw)
-			if err != nil {
-				return nil, fmt.Errorf("invalid limit: %w", err)
-			}
-			if limit < 1 || limit > 1000 {
-				return nil, fmt.Errorf("limit must be between 1 and 1000, got: %d", limit)
-			}
-			noteConnReq.Limit = limit
+		noteConnReq.Limit, err = limits.ParseLimit(arguments)
+		if err != nil {
+			return nil, err
 		}
 
 		// Parse optional offset
 		if offsetRaw, ok := arguments["offset"]; ok {
-			offset, err := parseInt(offsetRaw)
+			offset, err := mcpx.ParseInt(offsetRaw)
 			if err != nil {
 				return nil, fmt.Errorf("invalid offset: %w", err)
 			}
@@ -85,6 +71,12 @@ func NewNoteConnectionsHandler(storage connection.Storage) server.ToolHandlerFun
 			noteConnReq.Offset = offset
 		}
 
+		// Parse optional direction
+		noteConnReq.Direction, err = parseDirection(arguments, connection.DirectionBoth)
+		if err != nil {
+			return nil, err
+		}
+
 		response, err := storage.GetNoteConnections(ctx, noteConnReq)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get note connections: %w", err)
@@ -98,7 +90,7 @@ func NewNoteConnectionsHandler(storage connection.Storage) server.ToolHandlerFun
 			"types_count":  response.TypesCount,
 		}
 
-		jsonData, err := json.MarshalIndent(result, "", "  ")
+		jsonData, err := mcpx.MarshalJSON(result)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal result: %w", err)
 		}
@@ -13,6 +13,7 @@ import (
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
 )
 
 func TestNoteConnectionsHandler(t *testing.T) {
@@ -20,7 +21,7 @@ func TestNoteConnectionsHandler(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockStorage := mock.NewMockStorage(ctrl)
-	handler := mcp.NewNoteConnectionsHandler(mockStorage)
+	handler := mcp.NewNoteConnectionsHandler(mockStorage, mcpx.DefaultListLimits())
 
 	now := time.Now()
 	desc := "Test connection description"
@@ -40,9 +41,10 @@ func TestNoteConnectionsHandler(t *testing.T) {
 			mockSetup: func() {
 				mockStorage.EXPECT().
 					GetNoteConnections(gomock.Any(), connection.NoteConnectionsRequest{
-						NoteID: 1,
-						Limit:  100,
-						Offset: 0,
+						NoteID:    1,
+						Limit:     100,
+						Offset:    0,
+						Direction: connection.DirectionBoth,
 					}).
 					Return(&connection.NoteConnectionsResponse{
 						NoteID: 1,
@@ -103,11 +105,12 @@ func TestNoteConnectionsHandler(t *testing.T) {
 				strength := 5
 				mockStorage.EXPECT().
 					GetNoteConnections(gomock.Any(), connection.NoteConnectionsRequest{
-						NoteID:   1,
-						Type:     &connectionType,
-						Strength: &strength,
-						Limit:    50,
-						Offset:   10,
+						NoteID:    1,
+						Type:      &connectionType,
+						Strength:  &strength,
+						Limit:     50,
+						Offset:    10,
+						Direction: connection.DirectionBoth,
 					}).
 					Return(&connection.NoteConnectionsResponse{
 						NoteID:   1,
@@ -128,9 +131,10 @@ func TestNoteConnectionsHandler(t *testing.T) {
 			mockSetup: func() {
 				mockStorage.EXPECT().
 					GetNoteConnections(gomock.Any(), connection.NoteConnectionsRequest{
-						NoteID: 1,
-						Limit:  100,
-						Offset: 0,
+						NoteID:    1,
+						Limit:     100,
+						Offset:    0,
+						Direction: connection.DirectionBoth,
 					}).
 					Return(&connection.NoteConnectionsResponse{
 						NoteID:     1,
@@ -166,7 +170,7 @@ func TestNoteConnectionsHandler(t *testing.T) {
 			},
 			mockSetup:   func() {},
 			wantErr:     true,
-			wantContent: "note_id must be a positive integer",
+			wantContent: "invalid note_id: IDs must be positive, got: 0",
 		},
 		{
 			name: "negative note_id",
@@ -175,7 +179,7 @@ func TestNoteConnectionsHandler(t *testing.T) {
 			},
 			mockSetup:   func() {},
 			wantErr:     true,
-			wantContent: "note_id must be a positive integer",
+			wantContent: "invalid note_id: IDs must be positive, got: -1",
 		},
 		{
 			name: "invalid connection type",
@@ -280,6 +284,34 @@ func TestNoteConnectionsHandler(t *testing.T) {
 			wantErr:     true,
 			wantContent: "failed to get note connections",
 		},
+		{
+			name: "outgoing only",
+			args: map[string]interface{}{
+				"note_id":   float64(1),
+				"direction": "outgoing",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetNoteConnections(gomock.Any(), connection.NoteConnectionsRequest{
+						NoteID:    1,
+						Limit:     100,
+						Direction: connection.DirectionOutgoing,
+					}).
+					Return(&connection.NoteConnectionsResponse{NoteID: 1, TypesCount: map[string]int64{}}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Found connections for note 1",
+		},
+		{
+			name: "invalid direction",
+			args: map[string]interface{}{
+				"note_id":   float64(1),
+				"direction": "up",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid direction: up",
+		},
 		{
 			name: "note not found",
 			args: map[string]interface{}{
@@ -288,9 +320,10 @@ func TestNoteConnectionsHandler(t *testing.T) {
 			mockSetup: func() {
 				mockStorage.EXPECT().
 					GetNoteConnections(gomock.Any(), connection.NoteConnectionsRequest{
-						NoteID: 999,
-						Limit:  100,
-						Offset: 0,
+						NoteID:    999,
+						Limit:     100,
+						Offset:    0,
+						Direction: connection.DirectionBoth,
 					}).
 					Return(nil, errors.New("note not found"))
 			},
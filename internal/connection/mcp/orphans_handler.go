@@ -0,0 +1,67 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewOrphansHandler creates a new handler for finding notes without connections
+func NewOrphansHandler(storage connection.Storage, limits mcpx.ListLimits) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		limit, err := limits.ParseLimit(arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		offset := 0
+		if offsetRaw, ok := arguments["offset"]; ok {
+			parsed, err := mcpx.ParseInt(offsetRaw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid offset: %w", err)
+			}
+			if parsed < 0 {
+				return nil, fmt.Errorf("offset must be non-negative, got: %d", parsed)
+			}
+			offset = parsed
+		}
+
+		response, err := storage.FindOrphanNotes(ctx, limit, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find orphan notes: %w", err)
+		}
+
+		result := map[string]interface{}{
+			"note_ids": response.NoteIDs,
+			"limit":    limit,
+			"offset":   offset,
+			"total":    response.Total,
+			"count":    len(response.NoteIDs),
+			"has_more": int64(offset+len(response.NoteIDs)) < response.Total,
+		}
+
+		jsonData, err := mcpx.MarshalJSON(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Found %d orphan notes (total: %d):\n\n%s", len(response.NoteIDs), response.Total, string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,110 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+func TestOrphansHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewOrphansHandler(mockStorage, mcpx.DefaultListLimits())
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "successful with defaults",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindOrphanNotes(gomock.Any(), 100, 0).
+					Return(&connection.OrphanNotesResponse{NoteIDs: []int64{4, 7}, Total: 2}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Found 2 orphan notes (total: 2)",
+		},
+		{
+			name: "successful with pagination",
+			args: map[string]interface{}{
+				"limit":  float64(1),
+				"offset": float64(0),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindOrphanNotes(gomock.Any(), 1, 0).
+					Return(&connection.OrphanNotesResponse{NoteIDs: []int64{4}, Total: 2}, nil)
+			},
+			wantErr:     false,
+			wantContent: `"has_more": true`,
+		},
+		{
+			name: "invalid limit",
+			args: map[string]interface{}{
+				"limit": 0,
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "limit must be between 1 and 1000",
+		},
+		{
+			name: "invalid offset",
+			args: map[string]interface{}{
+				"offset": -1,
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "offset must be non-negative",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindOrphanNotes(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("storage error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to find orphan notes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewPageRankHandler creates a new handler for computing strength-weighted PageRank
+func NewPageRankHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		opts := connection.PageRankOptions{
+			DampingFactor: 0.85,
+			MaxIterations: 100,
+			Limit:         20,
+		}
+
+		// Parse optional damping_factor
+		if dampingRaw, ok := arguments["damping_factor"]; ok {
+			damping, err := mcpx.ParseFloat64(dampingRaw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid damping_factor: %w", err)
+			}
+			if damping <= 0 || damping >= 1 {
+				return nil, fmt.Errorf("damping_factor must be between 0 and 1 (exclusive), got: %v", damping)
+			}
+			opts.DampingFactor = damping
+		}
+
+		// Parse optional max_iterations
+		if iterationsRaw, ok := arguments["max_iterations"]; ok {
+			iterations, err := mcpx.ParseInt(iterationsRaw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid max_iterations: %w", err)
+			}
+			if iterations < 1 || iterations > 1000 {
+				return nil, fmt.Errorf("max_iterations must be between 1 and 1000, got: %d", iterations)
+			}
+			opts.MaxIterations = iterations
+		}
+
+		// Parse optional limit
+		if limitRaw, ok := arguments["limit"]; ok {
+			limit, err := mcpx.ParseInt(limitRaw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid limit: %w", err)
+			}
+			if limit < 1 || limit > 1000 {
+				return nil, fmt.Errorf("limit must be between 1 and 1000, got: %d", limit)
+			}
+			opts.Limit = limit
+		}
+
+		ranks, err := storage.ComputePageRank(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute pagerank: %w", err)
+		}
+
+		result := map[string]interface{}{
+			"ranks":          ranks,
+			"damping_factor": opts.DampingFactor,
+			"max_iterations": opts.MaxIterations,
+		}
+
+		jsonData, err := mcpx.MarshalJSON(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Ranked %d notes by PageRank:\n\n%s", len(ranks), string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,132 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+)
+
+func TestPageRankHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewPageRankHandler(mockStorage)
+
+	ranks := []connection.NoteRank{
+		{NoteID: 3, Rank: 0.5},
+		{NoteID: 1, Rank: 0.3},
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "successful with defaults",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ComputePageRank(gomock.Any(), connection.PageRankOptions{
+						DampingFactor: 0.85,
+						MaxIterations: 100,
+						Limit:         20,
+					}).
+					Return(ranks, nil)
+			},
+			wantErr:     false,
+			wantContent: "Ranked 2 notes by PageRank:",
+		},
+		{
+			name: "successful with custom options",
+			args: map[string]interface{}{
+				"damping_factor": 0.5,
+				"max_iterations": float64(10),
+				"limit":          "5",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ComputePageRank(gomock.Any(), connection.PageRankOptions{
+						DampingFactor: 0.5,
+						MaxIterations: 10,
+						Limit:         5,
+					}).
+					Return(ranks, nil)
+			},
+			wantErr:     false,
+			wantContent: `"note_id": 3`,
+		},
+		{
+			name: "invalid damping factor",
+			args: map[string]interface{}{
+				"damping_factor": 1.0,
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "damping_factor must be between 0 and 1",
+		},
+		{
+			name: "invalid max iterations",
+			args: map[string]interface{}{
+				"max_iterations": 0,
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "max_iterations must be between 1 and 1000",
+		},
+		{
+			name: "invalid limit type",
+			args: map[string]interface{}{
+				"limit": []string{"bad"},
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid limit",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ComputePageRank(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("storage error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to compute pagerank",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewPruneHandler creates a new handler for deleting weak connections. It only
+// previews the deletion unless dry_run is explicitly false.
+func NewPruneHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse max_strength
+		maxStrengthRaw, ok := arguments["max_strength"]
+		if !ok {
+			return nil, fmt.Errorf("max_strength is required")
+		}
+		maxStrength, err := mcpx.ParseInt(maxStrengthRaw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max_strength: %w", err)
+		}
+
+		// Parse optional dry_run
+		dryRun := true
+		if dryRunRaw, ok := arguments["dry_run"]; ok {
+			dryRun, ok = dryRunRaw.(bool)
+			if !ok {
+				return nil, fmt.Errorf("dry_run must be a boolean")
+			}
+		}
+
+		count, err := storage.PruneConnections(ctx, maxStrength, dryRun)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prune connections: %w", err)
+		}
+
+		text := fmt.Sprintf("Successfully deleted %d connections with strength at most %d", count, maxStrength)
+		if dryRun {
+			text = fmt.Sprintf("Dry run: pruning would delete %d connections with strength at most %d. Set dry_run to false to delete them", count, maxStrength)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: text,
+				},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,135 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+)
+
+func TestPruneHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewPruneHandler(mockStorage)
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "dry run by default",
+			args: map[string]interface{}{
+				"max_strength": float64(3),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					PruneConnections(gomock.Any(), 3, true).
+					Return(int64(4), nil)
+			},
+			wantErr:     false,
+			wantContent: "Dry run: pruning would delete 4 connections with strength at most 3",
+		},
+		{
+			name: "explicit dry run",
+			args: map[string]interface{}{
+				"max_strength": "2",
+				"dry_run":      true,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					PruneConnections(gomock.Any(), 2, true).
+					Return(int64(0), nil)
+			},
+			wantErr:     false,
+			wantContent: "Dry run: pruning would delete 0 connections",
+		},
+		{
+			name: "delete",
+			args: map[string]interface{}{
+				"max_strength": 3,
+				"dry_run":      false,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					PruneConnections(gomock.Any(), 3, false).
+					Return(int64(4), nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully deleted 4 connections with strength at most 3",
+		},
+		{
+			name:        "missing max_strength",
+			args:        map[string]interface{}{},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "max_strength is required",
+		},
+		{
+			name: "invalid max_strength",
+			args: map[string]interface{}{
+				"max_strength": "weak",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid max_strength",
+		},
+		{
+			name: "invalid dry_run",
+			args: map[string]interface{}{
+				"max_strength": 3,
+				"dry_run":      "no",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "dry_run must be a boolean",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{
+				"max_strength": 3,
+				"dry_run":      false,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					PruneConnections(gomock.Any(), 3, false).
+					Return(int64(0), errors.New("storage error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to prune connections",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewReciprocalHandler creates a new handler for finding notes connected in both directions
+func NewReciprocalHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		pairs, err := storage.FindReciprocalConnections(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find reciprocal connections: %w", err)
+		}
+
+		if len(pairs) == 0 {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.TextContent{
+						Type: "text",
+						Text: "No reciprocal connections found",
+					},
+				},
+			}, nil
+		}
+
+		result := map[string]interface{}{
+			"pairs": pairs,
+			"count": len(pairs),
+		}
+
+		jsonData, err := mcpx.MarshalJSON(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Found %d note pairs connected in both directions:\n\n%s", len(pairs), string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,100 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+)
+
+func TestReciprocalHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewReciprocalHandler(mockStorage)
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		wantErr     bool
+		wantContent []string
+	}{
+		{
+			name: "reciprocal connections found",
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindReciprocalConnections(gomock.Any()).
+					Return([]connection.ReciprocalPair{
+						{
+							NoteAID: 1,
+							NoteBID: 2,
+							AToB:    []connection.ConnectionRef{{ID: 10, Type: "supports"}},
+							BToA:    []connection.ConnectionRef{{ID: 11, Type: "references"}},
+						},
+					}, nil)
+			},
+			wantErr: false,
+			wantContent: []string{
+				"Found 1 note pairs connected in both directions:",
+				`"a_to_b": [`,
+				`"type": "references"`,
+				`"count": 1`,
+			},
+		},
+		{
+			name: "no reciprocal connections",
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindReciprocalConnections(gomock.Any()).
+					Return([]connection.ReciprocalPair{}, nil)
+			},
+			wantErr:     false,
+			wantContent: []string{"No reciprocal connections found"},
+		},
+		{
+			name: "storage error",
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindReciprocalConnections(gomock.Any()).
+					Return(nil, errors.New("storage error"))
+			},
+			wantErr:     true,
+			wantContent: []string{"failed to find reciprocal connections"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: map[string]interface{}{},
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				for _, want := range tt.wantContent {
+					assert.Contains(t, err.Error(), want)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				for _, want := range tt.wantContent {
+					assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, want)
+				}
+			}
+		})
+	}
+}
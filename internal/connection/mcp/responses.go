@@ -0,0 +1,86 @@
+package mcp
+
+import (
+	"time"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+// ConnectionResponse is the JSON shape of a connection in tool results.
+// Optional fields are always present (as null) so clients see a stable set of keys.
+type ConnectionResponse struct {
+	ID          int64                  `json:"id"`
+	FromNoteID  int64                  `json:"from_note_id"`
+	ToNoteID    int64                  `json:"to_note_id"`
+	Type        string                 `json:"type"`
+	Description *string                `json:"description"`
+	Strength    int                    `json:"strength"`
+	Metadata    map[string]interface{} `json:"metadata"`
+	Source      *string                `json:"source"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+// ListConnectionsResponse is the JSON shape of the list_connections tool result
+type ListConnectionsResponse struct {
+	Items   []ConnectionResponse `json:"items"`
+	Limit   int                  `json:"limit"`
+	Offset  int                  `json:"offset"`
+	Total   int64                `json:"total"`
+	Count   int                  `json:"count"`
+	HasMore bool                 `json:"has_more"`
+}
+
+// DeletedConnectionResponse is the JSON shape of a connection in the trash
+type DeletedConnectionResponse struct {
+	ConnectionResponse
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// ListDeletedConnectionsResponse is the JSON shape of the
+// list_deleted_connections tool result
+type ListDeletedConnectionsResponse struct {
+	Items   []DeletedConnectionResponse `json:"items"`
+	Limit   int                         `json:"limit"`
+	Offset  int                         `json:"offset"`
+	Total   int64                       `json:"total"`
+	Count   int                         `json:"count"`
+	HasMore bool                        `json:"has_more"`
+}
+
+// newConnectionResponse converts a domain connection to its response shape
+func newConnectionResponse(conn *connection.Connection) ConnectionResponse {
+	return ConnectionResponse{
+		ID:          conn.ID,
+		FromNoteID:  conn.FromNoteID,
+		ToNoteID:    conn.ToNoteID,
+		Type:        conn.Type,
+		Description: conn.Description,
+		Strength:    conn.Strength,
+		Metadata:    conn.Metadata,
+		Source:      conn.Source,
+		CreatedAt:   conn.CreatedAt,
+		UpdatedAt:   conn.UpdatedAt,
+	}
+}
+
+// newConnectionResponses converts domain connections to their response shape
+func newConnectionResponses(conns []connection.Connection) []ConnectionResponse {
+	items := make([]ConnectionResponse, len(conns))
+	for i := range conns {
+		items[i] = newConnectionResponse(&conns[i])
+	}
+	return items
+}
+
+// newDeletedConnectionResponses converts trashed connections to their response shape
+func newDeletedConnectionResponses(conns []connection.DeletedConnection) []DeletedConnectionResponse {
+	items := make([]DeletedConnectionResponse, len(conns))
+	for i := range conns {
+		items[i] = DeletedConnectionResponse{
+			ConnectionResponse: newConnectionResponse(&conns[i].Connection),
+			DeletedAt:          conns[i].DeletedAt,
+		}
+	}
+	return items
+}
@@ -0,0 +1,137 @@
+package mcp_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+var connectionResponseKeys = []string{
+	"id", "from_note_id", "to_note_id", "type", "description",
+	"strength", "metadata", "source", "created_at", "updated_at",
+}
+
+// resultJSON extracts the JSON document that follows the summary line of a tool result
+func resultJSON(t *testing.T, result *gomcp.CallToolResult) []byte {
+	t.Helper()
+	text := result.Content[0].(gomcp.TextContent).Text
+	idx := strings.Index(text, "\n\n")
+	require.NotEqual(t, -1, idx, "result has no JSON payload")
+	return []byte(text[idx+2:])
+}
+
+// decodeStrict decodes data into v, failing on fields the struct does not declare
+func decodeStrict(t *testing.T, data []byte, v interface{}) {
+	t.Helper()
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	require.NoError(t, dec.Decode(v))
+}
+
+func keys(t *testing.T, data []byte) []string {
+	t.Helper()
+	var m map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &m))
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
+
+func TestResponses_Shape(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	now := time.Now().UTC().Truncate(time.Second)
+	// Optional fields are unset to verify they are still reported
+	conn := &connection.Connection{
+		ID:         1,
+		FromNoteID: 2,
+		ToNoteID:   3,
+		Type:       "supports",
+		Strength:   6,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	t.Run("get", func(t *testing.T) {
+		mockStorage.EXPECT().Get(gomock.Any(), int64(1)).Return(conn, nil)
+
+		result, err := mcp.NewGetHandler(mockStorage)(context.Background(), gomcp.CallToolRequest{
+			Params: gomcp.CallToolParams{Arguments: map[string]interface{}{"id": 1}},
+		})
+		require.NoError(t, err)
+
+		data := resultJSON(t, result)
+		assert.ElementsMatch(t, connectionResponseKeys, keys(t, data))
+
+		var resp mcp.ConnectionResponse
+		decodeStrict(t, data, &resp)
+		assert.Equal(t, conn.ID, resp.ID)
+		assert.Equal(t, conn.Type, resp.Type)
+		assert.Equal(t, conn.Strength, resp.Strength)
+		assert.True(t, now.Equal(resp.CreatedAt))
+	})
+
+	t.Run("create", func(t *testing.T) {
+		mockStorage.EXPECT().Create(gomock.Any(), gomock.Any()).Return(conn, nil)
+
+		result, err := mcp.NewCreateHandler(mockStorage)(context.Background(), gomcp.CallToolRequest{
+			Params: gomcp.CallToolParams{Arguments: map[string]interface{}{
+				"from_note_id": 2,
+				"to_note_id":   3,
+				"type":         "supports",
+			}},
+		})
+		require.NoError(t, err)
+
+		data := resultJSON(t, result)
+		assert.ElementsMatch(t, connectionResponseKeys, keys(t, data))
+
+		var resp mcp.ConnectionResponse
+		decodeStrict(t, data, &resp)
+		assert.Equal(t, conn.ID, resp.ID)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		mockStorage.EXPECT().List(gomock.Any(), gomock.Any()).Return(&connection.ListConnectionsResponse{
+			Items: []connection.Connection{*conn},
+			Total: 1,
+		}, nil)
+
+		result, err := mcp.NewListHandler(mockStorage, mcpx.DefaultListLimits())(context.Background(), gomcp.CallToolRequest{
+			Params: gomcp.CallToolParams{Arguments: map[string]interface{}{}},
+		})
+		require.NoError(t, err)
+
+		data := resultJSON(t, result)
+		assert.ElementsMatch(t, []string{"items", "limit", "offset", "total", "count", "has_more"}, keys(t, data))
+
+		var resp mcp.ListConnectionsResponse
+		decodeStrict(t, data, &resp)
+		require.Len(t, resp.Items, 1)
+		assert.Equal(t, int64(1), resp.Total)
+		assert.Equal(t, 100, resp.Limit)
+
+		var raw struct {
+			Items []json.RawMessage `json:"items"`
+		}
+		require.NoError(t, json.Unmarshal(data, &raw))
+		assert.ElementsMatch(t, connectionResponseKeys, keys(t, raw.Items[0]))
+	})
+}
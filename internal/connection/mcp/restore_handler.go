@@ -0,0 +1,115 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewRestoreHandler creates a new handler for restoring connections from the trash
+func NewRestoreHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse ID
+		idRaw, ok := arguments["id"]
+		if !ok {
+			return nil, fmt.Errorf("id is required")
+		}
+
+		id, err := mcpx.ParseInt64(idRaw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id: %w", err)
+		}
+
+		if id <= 0 {
+			return nil, fmt.Errorf("id must be a positive integer")
+		}
+
+		conn, err := storage.Restore(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore connection: %w", err)
+		}
+
+		jsonData, err := mcpx.MarshalJSON(newConnectionResponse(conn))
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Successfully restored connection with ID: %d\n\n%s", conn.ID, string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
+
+// NewListDeletedHandler creates a new handler for listing the connections in the trash
+func NewListDeletedHandler(storage connection.Storage, limits mcpx.ListLimits) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		offset := 0
+
+		// Parse optional limit
+		limit, err := limits.ParseLimit(arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		// Parse optional offset
+		if offsetRaw, ok := arguments["offset"]; ok {
+			parsed, err := mcpx.ParseInt(offsetRaw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid offset: %w", err)
+			}
+			if parsed < 0 {
+				return nil, fmt.Errorf("offset must be non-negative, got: %d", parsed)
+			}
+			offset = parsed
+		}
+
+		response, err := storage.ListDeletedConnections(ctx, limit, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list deleted connections: %w", err)
+		}
+
+		items := newDeletedConnectionResponses(response.Items)
+		result := ListDeletedConnectionsResponse{
+			Items:   items,
+			Limit:   limit,
+			Offset:  offset,
+			Total:   response.Total,
+			Count:   len(items),
+			HasMore: int64(offset+len(items)) < response.Total,
+		}
+
+		jsonData, err := mcpx.MarshalJSON(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Found %d connections in the trash (showing %d)\n\n%s", response.Total, len(items), string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,197 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+func TestRestoreHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewRestoreHandler(mockStorage)
+
+	restored := &connection.Connection{ID: 4, FromNoteID: 1, ToNoteID: 2, Type: "supports", Strength: 6}
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "successful restore",
+			args: map[string]interface{}{"id": float64(4)},
+			mockSetup: func() {
+				mockStorage.EXPECT().Restore(gomock.Any(), int64(4)).Return(restored, nil)
+			},
+			wantContent: "Successfully restored connection with ID: 4",
+		},
+		{
+			name:        "missing id",
+			args:        map[string]interface{}{},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "id is required",
+		},
+		{
+			name:        "zero id",
+			args:        map[string]interface{}{"id": int64(0)},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "id must be a positive integer",
+		},
+		{
+			name: "not in trash",
+			args: map[string]interface{}{"id": int64(9)},
+			mockSetup: func() {
+				mockStorage.EXPECT().Restore(gomock.Any(), int64(9)).
+					Return(nil, fmt.Errorf("%w: 9", connection.ErrNotInTrash))
+			},
+			wantErr:     true,
+			wantContent: "connection is not in the trash: 9",
+		},
+		{
+			name: "blocked by re-created connection",
+			args: map[string]interface{}{"id": int64(4)},
+			mockSetup: func() {
+				mockStorage.EXPECT().Restore(gomock.Any(), int64(4)).
+					Return(nil, errors.New("cannot restore connection 4: connection already exists between these notes with this type"))
+			},
+			wantErr:     true,
+			wantContent: "failed to restore connection: cannot restore connection 4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
+
+func TestListDeletedHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewListDeletedHandler(mockStorage, mcpx.DefaultListLimits())
+
+	deletedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	response := &connection.ListDeletedConnectionsResponse{
+		Items: []connection.DeletedConnection{
+			{Connection: connection.Connection{ID: 4, FromNoteID: 1, ToNoteID: 2, Type: "supports", Strength: 6}, DeletedAt: deletedAt},
+		},
+		Total: 3,
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "defaults",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().ListDeletedConnections(gomock.Any(), 100, 0).Return(response, nil)
+			},
+			wantContent: `"deleted_at": "2025-03-01T12:00:00Z"`,
+		},
+		{
+			name: "limit and offset",
+			args: map[string]interface{}{"limit": float64(1), "offset": float64(1)},
+			mockSetup: func() {
+				mockStorage.EXPECT().ListDeletedConnections(gomock.Any(), 1, 1).Return(response, nil)
+			},
+			wantContent: `"has_more": true`,
+		},
+		{
+			name: "summary",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().ListDeletedConnections(gomock.Any(), 100, 0).Return(response, nil)
+			},
+			wantContent: "Found 3 connections in the trash (showing 1)",
+		},
+		{
+			name:        "limit too large",
+			args:        map[string]interface{}{"limit": float64(1001)},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "limit must be between 1 and 1000",
+		},
+		{
+			name:        "negative offset",
+			args:        map[string]interface{}{"offset": float64(-1)},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "offset must be non-negative",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().ListDeletedConnections(gomock.Any(), 100, 0).Return(nil, errors.New("database error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to list deleted connections",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+// NewRetypeHandler creates a new handler for changing the type of all connections of one type
+func NewRetypeHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse from_type
+		rawFromType, _ := arguments["from_type"].(string)
+		fromType := connection.NormalizeConnectionType(rawFromType)
+		if fromType == "" {
+			return nil, fmt.Errorf("from_type is required")
+		}
+		if !connection.IsValidConnectionType(fromType) {
+			return nil, fmt.Errorf("invalid from_type: %s. Valid types are: %v", rawFromType, connection.ValidConnectionTypes())
+		}
+
+		// Parse to_type
+		rawToType, _ := arguments["to_type"].(string)
+		toType := connection.NormalizeConnectionType(rawToType)
+		if toType == "" {
+			return nil, fmt.Errorf("to_type is required")
+		}
+		if !connection.IsValidConnectionType(toType) {
+			return nil, fmt.Errorf("invalid to_type: %s. Valid types are: %v", rawToType, connection.ValidConnectionTypes())
+		}
+
+		if fromType == toType {
+			return nil, fmt.Errorf("from_type and to_type cannot be the same")
+		}
+
+		updated, err := storage.RetypeConnections(ctx, fromType, toType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retype connections: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Successfully retyped %d connections from %s to %s", updated, fromType, toType),
+				},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,155 @@
+package mcp_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+)
+
+func TestRetypeHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewRetypeHandler(mockStorage)
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "retype connections",
+			args: map[string]interface{}{
+				"from_type": "supports",
+				"to_type":   "references",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					RetypeConnections(gomock.Any(), "supports", "references").
+					Return(int64(4), nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully retyped 4 connections from supports to references",
+		},
+		{
+			name: "types ignore case and surrounding spaces",
+			args: map[string]interface{}{
+				"from_type": " Supports ",
+				"to_type":   "REFERENCES",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					RetypeConnections(gomock.Any(), "supports", "references").
+					Return(int64(4), nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully retyped 4 connections from supports to references",
+		},
+		{
+			name: "missing from_type",
+			args: map[string]interface{}{
+				"to_type": "references",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "from_type is required",
+		},
+		{
+			name: "missing to_type",
+			args: map[string]interface{}{
+				"from_type": "supports",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "to_type is required",
+		},
+		{
+			name: "invalid from_type",
+			args: map[string]interface{}{
+				"from_type": "invalid_type",
+				"to_type":   "references",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid from_type",
+		},
+		{
+			name: "invalid to_type",
+			args: map[string]interface{}{
+				"from_type": "supports",
+				"to_type":   "invalid_type",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid to_type",
+		},
+		{
+			name: "same type",
+			args: map[string]interface{}{
+				"from_type": "supports",
+				"to_type":   "supports",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "cannot be the same",
+		},
+		{
+			name: "same type in different casing",
+			args: map[string]interface{}{
+				"from_type": "supports",
+				"to_type":   "Supports ",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "cannot be the same",
+		},
+		{
+			name: "collision",
+			args: map[string]interface{}{
+				"from_type": "supports",
+				"to_type":   "references",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					RetypeConnections(gomock.Any(), "supports", "references").
+					Return(int64(0), fmt.Errorf("%w: 1 supports connections would duplicate existing references connections", connection.ErrRetypeConflict))
+			},
+			wantErr:     true,
+			wantContent: "would create duplicate connections",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+const (
+	defaultPathDepth = 4
+	maxPathDepth     = 10
+)
+
+// NewStrongestPathHandler creates a new handler for finding the strongest path between two notes
+func NewStrongestPathHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse from_note_id
+		fromNoteIDRaw, ok := arguments["from_note_id"]
+		if !ok {
+			return nil, fmt.Errorf("from_note_id is required")
+		}
+		fromNoteID, err := mcpx.ParseInt64(fromNoteIDRaw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from_note_id: %w", err)
+		}
+
+		// Parse to_note_id
+		toNoteIDRaw, ok := arguments["to_note_id"]
+		if !ok {
+			return nil, fmt.Errorf("to_note_id is required")
+		}
+		toNoteID, err := mcpx.ParseInt64(toNoteIDRaw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to_note_id: %w", err)
+		}
+
+		opts := connection.PathOptions{MaxDepth: defaultPathDepth}
+
+		// Parse optional max_depth
+		if maxDepthRaw, ok := arguments["max_depth"]; ok {
+			maxDepth, err := mcpx.ParseInt(maxDepthRaw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid max_depth: %w", err)
+			}
+			if maxDepth < 1 || maxDepth > maxPathDepth {
+				return nil, fmt.Errorf("max_depth must be between 1 and %d, got: %d", maxPathDepth, maxDepth)
+			}
+			opts.MaxDepth = maxDepth
+		}
+
+		// Parse optional exclude_types
+		opts.ExcludeTypes, err = parseConnectionTypes(arguments, "exclude_types")
+		if err != nil {
+			return nil, err
+		}
+
+		// Parse optional direction; paths follow connections the way they point by default
+		opts.Direction, err = parseDirection(arguments, connection.DirectionOutgoing)
+		if err != nil {
+			return nil, err
+		}
+
+		path, err := storage.FindStrongestPath(ctx, fromNoteID, toNoteID, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find path: %w", err)
+		}
+
+		if path == nil || len(path.Path) == 0 {
+			text := fmt.Sprintf("No %s path from note %d to note %d within %d connections", opts.Direction, fromNoteID, toNoteID, opts.MaxDepth)
+			if path != nil && path.Truncated {
+				text = fmt.Sprintf("No %s path from note %d to note %d found before the search reached the server's traversal limits", opts.Direction, fromNoteID, toNoteID)
+			}
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.TextContent{
+						Type: "text",
+						Text: text,
+					},
+				},
+			}, nil
+		}
+
+		result := map[string]interface{}{
+			"path":          path,
+			"max_depth":     opts.MaxDepth,
+			"exclude_types": opts.ExcludeTypes,
+			"direction":     opts.Direction,
+		}
+
+		jsonData, err := mcpx.MarshalJSON(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		summary := fmt.Sprintf("Found a path of %d connections from note %d to note %d, weakest strength %d", path.Length, fromNoteID, toNoteID, path.Strength)
+		if path.Truncated {
+			summary += " (truncated: the search reached the server's traversal limits, so a stronger path may exist)"
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("%s:\n\n%s", summary, string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
+
+// parseDirection reads the optional direction argument, returning def when it is missing
+func parseDirection(arguments map[string]interface{}, def connection.Direction) (connection.Direction, error) {
+	raw, ok := arguments["direction"]
+	if !ok {
+		return def, nil
+	}
+
+	direction, ok := raw.(string)
+	if !ok || !connection.IsValidDirection(direction) {
+		return "", fmt.Errorf("invalid direction: %v. Valid directions are: %v", raw, connection.ValidDirections())
+	}
+	return connection.Direction(direction), nil
+}
@@ -0,0 +1,250 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+)
+
+func TestStrongestPathHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewStrongestPathHandler(mockStorage)
+
+	path := &connection.ConnectionPath{
+		FromNoteID: 1,
+		ToNoteID:   3,
+		Path: []connection.Connection{
+			{ID: 10, FromNoteID: 1, ToNoteID: 2, Type: "supports", Strength: 9},
+			{ID: 11, FromNoteID: 2, ToNoteID: 3, Type: "supports", Strength: 7},
+		},
+		Length:   2,
+		Strength: 7,
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "path with defaults",
+			args: map[string]interface{}{
+				"from_note_id": float64(1),
+				"to_note_id":   float64(3),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindStrongestPath(gomock.Any(), int64(1), int64(3), connection.PathOptions{MaxDepth: 4, ExcludeTypes: []string{}, Direction: connection.DirectionOutgoing}).
+					Return(path, nil)
+			},
+			wantContent: "Found a path of 2 connections from note 1 to note 3, weakest strength 7",
+		},
+		{
+			name: "excluded types and max depth",
+			args: map[string]interface{}{
+				"from_note_id":  float64(1),
+				"to_note_id":    float64(3),
+				"max_depth":     float64(2),
+				"exclude_types": []interface{}{"contradicts", "relates_to"},
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindStrongestPath(gomock.Any(), int64(1), int64(3), connection.PathOptions{MaxDepth: 2, ExcludeTypes: []string{"contradicts", "relates_to"}, Direction: connection.DirectionOutgoing}).
+					Return(path, nil)
+			},
+			wantContent: `"exclude_types": [
+    "contradicts",
+    "relates_to"
+  ]`,
+		},
+		{
+			name: "no path",
+			args: map[string]interface{}{
+				"from_note_id":  float64(1),
+				"to_note_id":    float64(3),
+				"exclude_types": []interface{}{"supports"},
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindStrongestPath(gomock.Any(), int64(1), int64(3), connection.PathOptions{MaxDepth: 4, ExcludeTypes: []string{"supports"}, Direction: connection.DirectionOutgoing}).
+					Return(nil, nil)
+			},
+			wantContent: "No outgoing path from note 1 to note 3 within 4 connections",
+		},
+		{
+			name: "search truncated without a path",
+			args: map[string]interface{}{
+				"from_note_id": float64(1),
+				"to_note_id":   float64(3),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindStrongestPath(gomock.Any(), int64(1), int64(3), connection.PathOptions{MaxDepth: 4, ExcludeTypes: []string{}, Direction: connection.DirectionOutgoing}).
+					Return(&connection.ConnectionPath{FromNoteID: 1, ToNoteID: 3, Path: []connection.Connection{}, Truncated: true}, nil)
+			},
+			wantContent: "No outgoing path from note 1 to note 3 found before the search reached the server's traversal limits",
+		},
+		{
+			name: "truncated path",
+			args: map[string]interface{}{
+				"from_note_id": float64(1),
+				"to_note_id":   float64(3),
+			},
+			mockSetup: func() {
+				truncated := *path
+				truncated.Truncated = true
+				mockStorage.EXPECT().
+					FindStrongestPath(gomock.Any(), int64(1), int64(3), connection.PathOptions{MaxDepth: 4, ExcludeTypes: []string{}, Direction: connection.DirectionOutgoing}).
+					Return(&truncated, nil)
+			},
+			wantContent: "weakest strength 7 (truncated: the search reached the server's traversal limits, so a stronger path may exist)",
+		},
+		{
+			name: "undirected",
+			args: map[string]interface{}{
+				"from_note_id": float64(3),
+				"to_note_id":   float64(1),
+				"direction":    "both",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindStrongestPath(gomock.Any(), int64(3), int64(1), connection.PathOptions{MaxDepth: 4, ExcludeTypes: []string{}, Direction: connection.DirectionBoth}).
+					Return(path, nil)
+			},
+			wantContent: `"direction": "both"`,
+		},
+		{
+			name: "incoming",
+			args: map[string]interface{}{
+				"from_note_id": float64(3),
+				"to_note_id":   float64(1),
+				"direction":    "incoming",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindStrongestPath(gomock.Any(), int64(3), int64(1), connection.PathOptions{MaxDepth: 4, ExcludeTypes: []string{}, Direction: connection.DirectionIncoming}).
+					Return(nil, nil)
+			},
+			wantContent: "No incoming path from note 3 to note 1 within 4 connections",
+		},
+		{
+			name: "invalid direction",
+			args: map[string]interface{}{
+				"from_note_id": float64(1),
+				"to_note_id":   float64(3),
+				"direction":    "sideways",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid direction: sideways. Valid directions are: [outgoing incoming both]",
+		},
+		{
+			name:        "missing from_note_id",
+			args:        map[string]interface{}{"to_note_id": float64(3)},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "from_note_id is required",
+		},
+		{
+			name:        "missing to_note_id",
+			args:        map[string]interface{}{"from_note_id": float64(1)},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "to_note_id is required",
+		},
+		{
+			name: "max_depth too large",
+			args: map[string]interface{}{
+				"from_note_id": float64(1),
+				"to_note_id":   float64(3),
+				"max_depth":    float64(11),
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "max_depth must be between 1 and 10, got: 11",
+		},
+		{
+			name: "exclude_types not an array",
+			args: map[string]interface{}{
+				"from_note_id":  float64(1),
+				"to_note_id":    float64(3),
+				"exclude_types": "contradicts",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "exclude_types must be an array of connection types",
+		},
+		{
+			name: "exclude_types with a non-string",
+			args: map[string]interface{}{
+				"from_note_id":  float64(1),
+				"to_note_id":    float64(3),
+				"exclude_types": []interface{}{"supports", float64(2)},
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "exclude_types[1] must be a string",
+		},
+		{
+			name: "exclude_types with an unknown type",
+			args: map[string]interface{}{
+				"from_note_id":  float64(1),
+				"to_note_id":    float64(3),
+				"exclude_types": []interface{}{"disagrees"},
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid connection type in exclude_types: disagrees",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{
+				"from_note_id": float64(1),
+				"to_note_id":   float64(3),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindStrongestPath(gomock.Any(), int64(1), int64(3), gomock.Any()).
+					Return(nil, errors.New("graph too large, narrow your query"))
+			},
+			wantErr:     true,
+			wantContent: "failed to find path: graph too large",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
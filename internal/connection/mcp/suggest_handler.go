@@ -0,0 +1,70 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewSuggestHandler creates a new handler for suggesting notes to connect to a note
+func NewSuggestHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse note_id
+		noteIDRaw, ok := arguments["note_id"]
+		if !ok {
+			return nil, fmt.Errorf("note_id is required")
+		}
+
+		noteID, err := mcpx.ParseID(noteIDRaw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid note_id: %w", err)
+		}
+
+		// Parse optional limit
+		limit := 10
+		if limitRaw, ok := arguments["limit"]; ok {
+			parsed, err := mcpx.ParseInt(limitRaw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid limit: %w", err)
+			}
+			if parsed < 1 || parsed > 50 {
+				return nil, fmt.Errorf("limit must be between 1 and 50, got: %d", parsed)
+			}
+			limit = parsed
+		}
+
+		suggestions, err := storage.SuggestConnections(ctx, noteID, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to suggest connections: %w", err)
+		}
+
+		result := map[string]interface{}{
+			"note_id": noteID,
+			"items":   suggestions,
+		}
+
+		jsonData, err := mcpx.MarshalJSON(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Found %d suggested connections for note %d:\n\n%s", len(suggestions), noteID, string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
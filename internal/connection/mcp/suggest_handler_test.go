@@ -0,0 +1,136 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+)
+
+func TestSuggestHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewSuggestHandler(mockStorage)
+
+	suggestions := []connection.Suggestion{
+		{NoteID: 2, Title: "Paxos vs Raft", Score: 1.67, SharedTags: []string{"consensus", "distributed"}, Reason: "shares 2 of 3 tags (consensus, distributed); similar title or content (100% of best match)"},
+		{NoteID: 5, Title: "Goroutines", Score: 0.33, SharedTags: []string{"go"}, Reason: "shares 1 of 3 tags (go)"},
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "default limit",
+			args: map[string]interface{}{
+				"note_id": float64(1),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					SuggestConnections(gomock.Any(), int64(1), 10).
+					Return(suggestions, nil)
+			},
+			wantContent: "Found 2 suggested connections for note 1",
+		},
+		{
+			name: "suggestions include reasons",
+			args: map[string]interface{}{
+				"note_id": "1",
+				"limit":   float64(5),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					SuggestConnections(gomock.Any(), int64(1), 5).
+					Return(suggestions, nil)
+			},
+			wantContent: `"reason": "shares 1 of 3 tags (go)"`,
+		},
+		{
+			name: "no suggestions",
+			args: map[string]interface{}{
+				"note_id": float64(1),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					SuggestConnections(gomock.Any(), int64(1), 10).
+					Return([]connection.Suggestion{}, nil)
+			},
+			wantContent: `"items": []`,
+		},
+		{
+			name:        "missing note_id",
+			args:        map[string]interface{}{},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "note_id is required",
+		},
+		{
+			name: "non-positive note_id",
+			args: map[string]interface{}{
+				"note_id": float64(0),
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid note_id: IDs must be positive, got: 0",
+		},
+		{
+			name: "limit out of range",
+			args: map[string]interface{}{
+				"note_id": float64(1),
+				"limit":   float64(51),
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "limit must be between 1 and 50",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{
+				"note_id": float64(1),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					SuggestConnections(gomock.Any(), int64(1), 10).
+					Return(nil, errors.New("note not found: 1"))
+			},
+			wantErr:     true,
+			wantContent: "failed to suggest connections",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
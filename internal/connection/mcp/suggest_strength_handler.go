@@ -0,0 +1,64 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewSuggestStrengthHandler creates a new handler for suggesting the strength
+// of a connection between two notes
+func NewSuggestStrengthHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse from_note_id
+		fromNoteIDRaw, ok := arguments["from_note_id"]
+		if !ok {
+			return nil, fmt.Errorf("from_note_id is required")
+		}
+
+		fromNoteID, err := mcpx.ParseID(fromNoteIDRaw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from_note_id: %w", err)
+		}
+
+		// Parse to_note_id
+		toNoteIDRaw, ok := arguments["to_note_id"]
+		if !ok {
+			return nil, fmt.Errorf("to_note_id is required")
+		}
+
+		toNoteID, err := mcpx.ParseID(toNoteIDRaw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to_note_id: %w", err)
+		}
+
+		suggestion, err := storage.SuggestStrength(ctx, fromNoteID, toNoteID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to suggest strength: %w", err)
+		}
+
+		jsonData, err := mcpx.MarshalJSON(suggestion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal suggestion: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Suggested strength %d for connecting note %d to note %d\n\n%s", suggestion.Strength, fromNoteID, toNoteID, string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
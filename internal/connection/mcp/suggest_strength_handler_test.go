@@ -0,0 +1,136 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+)
+
+func TestSuggestStrengthHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewSuggestStrengthHandler(mockStorage)
+
+	suggestion := &connection.StrengthSuggestion{
+		FromNoteID:        1,
+		ToNoteID:          2,
+		Strength:          7,
+		Score:             0.67,
+		TagOverlap:        0.5,
+		NeighborOverlap:   1,
+		ContentSimilarity: 0.5,
+		SharedTags:        []string{"go"},
+		SharedNeighborIDs: []int64{3},
+		Rationale:         "Strength 7 (score 0.67): shares 1 of 2 tags (go); shares 1 of 1 connected notes; 50% similar title and content words",
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "suggested strength",
+			args: map[string]interface{}{
+				"from_note_id": float64(1),
+				"to_note_id":   float64(2),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					SuggestStrength(gomock.Any(), int64(1), int64(2)).
+					Return(suggestion, nil)
+			},
+			wantContent: "Suggested strength 7 for connecting note 1 to note 2",
+		},
+		{
+			name: "includes rationale",
+			args: map[string]interface{}{
+				"from_note_id": "1",
+				"to_note_id":   "2",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					SuggestStrength(gomock.Any(), int64(1), int64(2)).
+					Return(suggestion, nil)
+			},
+			wantContent: `"rationale": "Strength 7 (score 0.67): shares 1 of 2 tags (go)`,
+		},
+		{
+			name: "missing from_note_id",
+			args: map[string]interface{}{
+				"to_note_id": float64(2),
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "from_note_id is required",
+		},
+		{
+			name: "missing to_note_id",
+			args: map[string]interface{}{
+				"from_note_id": float64(1),
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "to_note_id is required",
+		},
+		{
+			name: "non-positive to_note_id",
+			args: map[string]interface{}{
+				"from_note_id": float64(1),
+				"to_note_id":   float64(-2),
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid to_note_id: IDs must be positive, got: -2",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{
+				"from_note_id": float64(1),
+				"to_note_id":   float64(9),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					SuggestStrength(gomock.Any(), int64(1), int64(9)).
+					Return(nil, errors.New("note not found: 9"))
+			},
+			wantErr:     true,
+			wantContent: "failed to suggest strength: note not found: 9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
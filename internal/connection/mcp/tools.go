@@ -1,14 +1,18 @@
 package mcp
 
 import (
+	"fmt"
+
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
 )
 
 // RegisterTools registers all connection MCP tools with the server
-func RegisterTools(s *server.MCPServer, storage connection.Storage) error {
+func RegisterTools(s *server.MCPServer, storage connection.Storage, limits mcpx.ListLimits, middleware ...mcpx.Middleware) error {
 	tools := []struct {
 		name        string
 		description string
@@ -22,6 +26,7 @@ func RegisterTools(s *server.MCPServer, storage connection.Storage) error {
 			schema: mcp.ToolInputSchema{
 				Type: "object",
 				Properties: map[string]interface{}{
+					"idempotency_key": mcpx.IdempotencyKeyProperty("connection"),
 					"from_note_id": map[string]interface{}{
 						"type":        "integer",
 						"description": "ID of the source note",
@@ -32,8 +37,7 @@ func RegisterTools(s *server.MCPServer, storage connection.Storage) error {
 					},
 					"type": map[string]interface{}{
 						"type":        "string",
-						"description": "Type of connection (e.g., relates_to, references, supports, etc.)",
-						"enum":        connection.ValidConnectionTypes(),
+						"description": "Type of connection from list_connection_types (e.g., relates_to, references, supports, etc.), case-insensitive",
 					},
 					"description": map[string]interface{}{
 						"type":        "string",
@@ -41,18 +45,138 @@ func RegisterTools(s *server.MCPServer, storage connection.Storage) error {
 					},
 					"strength": map[string]interface{}{
 						"type":        "integer",
-						"description": "Strength of the connection (1-10, default: 5)",
-						"minimum":     1,
-						"maximum":     10,
+						"description": fmt.Sprintf("Strength of the connection (%d-%d, default: %d)", connection.MinStrength, connection.MaxStrength, connection.DefaultStrength()),
+						"minimum":     connection.MinStrength,
+						"maximum":     connection.MaxStrength,
 					},
 					"metadata": map[string]interface{}{
 						"type":        "object",
 						"description": "Optional metadata for the connection",
 					},
+					"source": map[string]interface{}{
+						"type":        "string",
+						"description": "Optional name of the agent or tool creating the connection, for attribution",
+					},
+					"auto_symmetric": map[string]interface{}{
+						"type":        "boolean",
+						"description": "For symmetric types, also store the reverse connection (default: false)",
+					},
+					"auto_inverse": map[string]interface{}{
+						"type":        "boolean",
+						"description": "For types with an inverse, such as precedes and follows, also create the inverse connection from the target to the source (default: false)",
+					},
 				},
 				Required: []string{"from_note_id", "to_note_id", "type"},
 			},
 		},
+		{
+			name:        "create_connection_by_title",
+			description: "Create a new connection between two notes identified by title instead of ID. Titles match exactly, then ignoring case; if several notes match, the error lists the candidate note IDs to use with create_connection",
+			handler:     NewCreateByTitleHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"from_title": map[string]interface{}{
+						"type":        "string",
+						"description": "Title of the source note",
+					},
+					"to_title": map[string]interface{}{
+						"type":        "string",
+						"description": "Title of the target note",
+					},
+					"type": map[string]interface{}{
+						"type":        "string",
+						"description": "Type of connection from list_connection_types (e.g., relates_to, references, supports, etc.), case-insensitive",
+					},
+					"description": map[string]interface{}{
+						"type":        "string",
+						"description": "Optional description of the connection",
+					},
+					"strength": map[string]interface{}{
+						"type":        "integer",
+						"description": fmt.Sprintf("Strength of the connection (%d-%d, default: %d)", connection.MinStrength, connection.MaxStrength, connection.DefaultStrength()),
+						"minimum":     connection.MinStrength,
+						"maximum":     connection.MaxStrength,
+					},
+					"metadata": map[string]interface{}{
+						"type":        "object",
+						"description": "Optional metadata for the connection",
+					},
+					"source": map[string]interface{}{
+						"type":        "string",
+						"description": "Optional name of the agent or tool creating the connection, for attribution",
+					},
+					"auto_symmetric": map[string]interface{}{
+						"type":        "boolean",
+						"description": "For symmetric types, also store the reverse connection (default: false)",
+					},
+				},
+				Required: []string{"from_title", "to_title", "type"},
+			},
+		},
+		{
+			name:        "create_connection_ensuring_notes",
+			description: "Connect two notes by title, creating either note first if no note has that title, e.g. to capture a new idea and relate it in one call. Titles match exactly, then ignoring case, so an existing note is never duplicated; if several notes match, the error lists the candidate note IDs. The notes and the connection are created together or not at all",
+			handler:     NewCreateEnsuringNotesHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"from_title": map[string]interface{}{
+						"type":        "string",
+						"description": "Title of the source note",
+					},
+					"from_content": map[string]interface{}{
+						"type":        "string",
+						"description": "Content for the source note if it has to be created (default: the title)",
+					},
+					"from_type": map[string]interface{}{
+						"type":        "string",
+						"description": "Type for the source note if it has to be created (default: text)",
+						"enum":        note.ValidNoteTypes(),
+					},
+					"to_title": map[string]interface{}{
+						"type":        "string",
+						"description": "Title of the target note",
+					},
+					"to_content": map[string]interface{}{
+						"type":        "string",
+						"description": "Content for the target note if it has to be created (default: the title)",
+					},
+					"to_type": map[string]interface{}{
+						"type":        "string",
+						"description": "Type for the target note if it has to be created (default: text)",
+						"enum":        note.ValidNoteTypes(),
+					},
+					"type": map[string]interface{}{
+						"type":        "string",
+						"description": "Type of connection from list_connection_types (e.g., relates_to, references, supports, etc.), case-insensitive",
+					},
+					"description": map[string]interface{}{
+						"type":        "string",
+						"description": "Optional description of the connection",
+					},
+					"strength": map[string]interface{}{
+						"type":        "integer",
+						"description": fmt.Sprintf("Strength of the connection (%d-%d, default: %d)", connection.MinStrength, connection.MaxStrength, connection.DefaultStrength()),
+						"minimum":     connection.MinStrength,
+						"maximum":     connection.MaxStrength,
+					},
+					"metadata": map[string]interface{}{
+						"type":        "object",
+						"description": "Optional metadata for the connection",
+					},
+					"source": map[string]interface{}{
+						"type":        "string",
+						"description": "Optional name of the agent or tool creating the connection and any notes, for attribution",
+					},
+					"auto_symmetric": map[string]interface{}{
+						"type":        "boolean",
+						"description": "For symmetric types, also store the reverse connection (default: false)",
+					},
+				},
+				Required: []string{"from_title", "to_title", "type"},
+			},
+		},
 		{
 			name:        "get_connection",
 			description: "Get a connection by ID",
@@ -68,6 +192,41 @@ func RegisterTools(s *server.MCPServer, storage connection.Storage) error {
 				Required: []string{"id"},
 			},
 		},
+		{
+			name:        "get_connections",
+			description: "Get several connections by ID in one call, e.g. to load the edges of a path with full detail. Connections come back in the requested order and unknown IDs are listed under missing_ids",
+			handler:     NewGetManyHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"ids": map[string]interface{}{
+						"type":        "array",
+						"description": "IDs of the connections to get",
+						"items": map[string]interface{}{
+							"type": "integer",
+						},
+						"minItems": 1,
+						"maxItems": maxGetConnectionIDs,
+					},
+				},
+				Required: []string{"ids"},
+			},
+		},
+		{
+			name:        "get_connection_audit",
+			description: "Get the change history of a connection: when it was created, updated and deleted, with the changed fields. Works for deleted connections; requires the server to run with -connection-audit",
+			handler:     NewAuditHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"connection_id": map[string]interface{}{
+						"type":        "integer",
+						"description": "ID of the connection, which may already be deleted",
+					},
+				},
+				Required: []string{"connection_id"},
+			},
+		},
 		{
 			name:        "update_connection",
 			description: "Update an existing connection",
@@ -81,8 +240,7 @@ func RegisterTools(s *server.MCPServer, storage connection.Storage) error {
 					},
 					"type": map[string]interface{}{
 						"type":        "string",
-						"description": "Updated type of connection",
-						"enum":        connection.ValidConnectionTypes(),
+						"description": "Updated type of connection from list_connection_types, case-insensitive",
 					},
 					"description": map[string]interface{}{
 						"type":        "string",
@@ -90,18 +248,56 @@ func RegisterTools(s *server.MCPServer, storage connection.Storage) error {
 					},
 					"strength": map[string]interface{}{
 						"type":        "integer",
-						"description": "Updated strength of the connection (1-10)",
-						"minimum":     1,
-						"maximum":     10,
+						"description": fmt.Sprintf("Updated strength of the connection (%d-%d)", connection.MinStrength, connection.MaxStrength),
+						"minimum":     connection.MinStrength,
+						"maximum":     connection.MaxStrength,
 					},
 					"metadata": map[string]interface{}{
 						"type":        "object",
 						"description": "Updated metadata for the connection",
 					},
+					"expected_updated_at": map[string]interface{}{
+						"type":        "string",
+						"description": "updated_at of the connection as last read (RFC 3339). The update fails without changes if the connection has been modified since",
+					},
 				},
 				Required: []string{"id"},
 			},
 		},
+		{
+			name:        "touch_connection",
+			description: "Mark a connection as recently revisited by refreshing its updated_at, without changing its data, so it sorts as recent when list_connections orders by updated_at",
+			handler:     NewTouchHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"id": map[string]interface{}{
+						"type":        "integer",
+						"description": "Unique identifier of the connection to touch",
+					},
+				},
+				Required: []string{"id"},
+			},
+		},
+		{
+			name:        "adjust_connection_strength",
+			description: "Reinforce or weaken a connection by adding delta to its strength, clamped to 1-10. Safe to call concurrently, unlike reading the strength and writing it back with update_connection",
+			handler:     NewAdjustStrengthHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"id": map[string]interface{}{
+						"type":        "integer",
+						"description": "Unique identifier of the connection",
+					},
+					"delta": map[string]interface{}{
+						"type":        "integer",
+						"description": fmt.Sprintf("Amount to add to the strength; negative to weaken. The result stays between %d and %d", connection.MinStrength, connection.MaxStrength),
+					},
+				},
+				Required: []string{"id", "delta"},
+			},
+		},
 		{
 			name:        "delete_connection",
 			description: "Delete a connection by ID",
@@ -113,64 +309,144 @@ func RegisterTools(s *server.MCPServer, storage connection.Storage) error {
 						"type":        "integer",
 						"description": "Unique identifier of the connection to delete",
 					},
+					"soft": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Move the connection to the trash instead of deleting it permanently, so restore_connection can bring it back (default: false)",
+					},
 				},
 				Required: []string{"id"},
 			},
 		},
 		{
-			name:        "list_connections",
-			description: "List connections with optional filtering and pagination",
-			handler:     NewListHandler(storage),
+			name:        "restore_connection",
+			description: "Restore a soft-deleted connection from the trash with its original ID",
+			handler:     NewRestoreHandler(storage),
 			schema: mcp.ToolInputSchema{
 				Type: "object",
 				Properties: map[string]interface{}{
-					"limit": map[string]interface{}{
+					"id": map[string]interface{}{
 						"type":        "integer",
-						"description": "Maximum number of connections to return (default: 100)",
-						"minimum":     1,
-						"maximum":     1000,
+						"description": "Unique identifier of the connection to restore",
 					},
+				},
+				Required: []string{"id"},
+			},
+		},
+		{
+			name:        "list_deleted_connections",
+			description: "List the soft-deleted connections in the trash, most recently deleted first",
+			handler:     NewListDeletedHandler(storage, limits),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"limit": limits.LimitProperty("connections"),
 					"offset": map[string]interface{}{
 						"type":        "integer",
 						"description": "Number of connections to skip (default: 0)",
 						"minimum":     0,
 					},
+				},
+			},
+		},
+		{
+			name:        "delete_connections_between",
+			description: "Delete all connections between two notes in either direction, optionally of a single type",
+			handler:     NewDeleteBetweenHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
 					"from_note_id": map[string]interface{}{
 						"type":        "integer",
-						"description": "Filter by source note ID",
+						"description": "ID of the first note",
 					},
 					"to_note_id": map[string]interface{}{
 						"type":        "integer",
-						"description": "Filter by target note ID",
+						"description": "ID of the second note",
 					},
 					"type": map[string]interface{}{
 						"type":        "string",
-						"description": "Filter by connection type",
-						"enum":        connection.ValidConnectionTypes(),
+						"description": "Only delete connections of this type, case-insensitive",
 					},
-					"strength": map[string]interface{}{
+				},
+				Required: []string{"from_note_id", "to_note_id"},
+			},
+		},
+		{
+			name:        "prune_weak_connections",
+			description: "Delete every connection with strength at or below max_strength, e.g. to clear out speculative links. Only previews how many would be deleted unless dry_run is false",
+			handler:     NewPruneHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"max_strength": map[string]interface{}{
 						"type":        "integer",
-						"description": "Filter by connection strength",
-						"minimum":     1,
-						"maximum":     10,
+						"description": "Delete connections with this strength or weaker",
+						"minimum":     connection.MinStrength,
+						"maximum":     connection.MaxStrength,
+					},
+					"dry_run": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Only count the connections that would be deleted (default: true)",
 					},
-					"order_by": map[string]interface{}{
+				},
+				Required: []string{"max_strength"},
+			},
+		},
+		{
+			name:        "retype_connections",
+			description: "Change the type of every connection of one type, e.g. when reclassifying edges. Fails without changes if any connection would duplicate one that already has the target type",
+			handler:     NewRetypeHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"from_type": map[string]interface{}{
 						"type":        "string",
-						"description": "Field to order by (default: id)",
-						"enum":        []string{"id", "created_at", "updated_at", "strength", "type"},
+						"description": "Current type of the connections to change, case-insensitive",
 					},
-					"order_dir": map[string]interface{}{
+					"to_type": map[string]interface{}{
 						"type":        "string",
-						"description": "Order direction (default: asc)",
-						"enum":        []string{"asc", "desc"},
+						"description": "New type for the connections, case-insensitive",
 					},
 				},
+				Required: []string{"from_type", "to_type"},
+			},
+		},
+		{
+			name:        "normalize_connection_strengths",
+			description: "Rescale all connection strengths so the weakest becomes 1 and the strongest 10, e.g. after importing data clustered around one value. Use dry_run to preview the mapping",
+			handler:     NewNormalizeStrengthsHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"dry_run": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Return the proposed strength mapping without changing anything (default: false)",
+					},
+				},
+			},
+		},
+		{
+			name:        "list_connections",
+			description: "List connections with optional filtering and pagination",
+			handler:     NewListHandler(storage, limits),
+			schema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: listConnectionsProperties(limits),
+			},
+		},
+		{
+			name:        "count_connections",
+			description: "Count the connections matching the list_connections filters without fetching them, e.g. to size a query before paging through it",
+			handler:     NewCountHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: mcpx.CountProperties(listConnectionsProperties(limits), "strength_decay", "half_life_days"),
 			},
 		},
 		{
 			name:        "get_note_connections",
-			description: "Get all connections for a specific note (incoming and outgoing)",
-			handler:     NewNoteConnectionsHandler(storage),
+			description: "Get all connections for a specific note (incoming and outgoing, or one direction)",
+			handler:     NewNoteConnectionsHandler(storage, limits),
 			schema: mcp.ToolInputSchema{
 				Type: "object",
 				Properties: map[string]interface{}{
@@ -180,30 +456,345 @@ func RegisterTools(s *server.MCPServer, storage connection.Storage) error {
 					},
 					"type": map[string]interface{}{
 						"type":        "string",
-						"description": "Filter by connection type",
-						"enum":        connection.ValidConnectionTypes(),
+						"description": "Filter by connection type, case-insensitive",
 					},
 					"strength": map[string]interface{}{
 						"type":        "integer",
 						"description": "Filter by connection strength",
+						"minimum":     connection.MinStrength,
+						"maximum":     connection.MaxStrength,
+					},
+					"limit": limits.LimitProperty("connections"),
+					"offset": map[string]interface{}{
+						"type":        "integer",
+						"description": "Number of connections to skip (default: 0)",
+						"minimum":     0,
+					},
+					"direction": map[string]interface{}{
+						"type":        "string",
+						"description": "Return only outgoing connections, only incoming ones, or both (default: both)",
+						"enum":        connection.ValidDirections(),
+					},
+				},
+				Required: []string{"note_id"},
+			},
+		},
+		{
+			name:        "get_connections_for_notes",
+			description: "Get incoming and outgoing connections for several notes in one call, grouped by note ID",
+			handler:     NewConnectionsForNotesHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"note_ids": map[string]interface{}{
+						"type":        "array",
+						"description": "IDs of the notes to get connections for",
+						"items": map[string]interface{}{
+							"type": "integer",
+						},
+						"minItems": 1,
+						"maxItems": 500,
+					},
+					"type": map[string]interface{}{
+						"type":        "string",
+						"description": "Filter by connection type, case-insensitive",
+					},
+					"strength": map[string]interface{}{
+						"type":        "integer",
+						"description": "Filter by connection strength",
+						"minimum":     connection.MinStrength,
+						"maximum":     connection.MaxStrength,
+					},
+				},
+				Required: []string{"note_ids"},
+			},
+		},
+		{
+			name:        "get_latest_connections",
+			description: "Get the most recently created connection of each note, in either direction, keyed by note ID. Notes without connections are left out.",
+			handler:     NewLatestConnectionsHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"note_ids": map[string]interface{}{
+						"type":        "array",
+						"description": "IDs of the notes to get the latest connection for",
+						"items": map[string]interface{}{
+							"type": "integer",
+						},
+						"minItems": 1,
+						"maxItems": maxBatchNoteIDs,
+					},
+				},
+				Required: []string{"note_ids"},
+			},
+		},
+		{
+			name:        "compute_pagerank",
+			description: "Rank notes by centrality using PageRank weighted by connection strength",
+			handler:     NewPageRankHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"damping_factor": map[string]interface{}{
+						"type":             "number",
+						"description":      "Probability of following a connection instead of jumping to a random note (default: 0.85)",
+						"exclusiveMinimum": 0,
+						"exclusiveMaximum": 1,
+					},
+					"max_iterations": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum number of iterations before stopping (default: 100)",
 						"minimum":     1,
-						"maximum":     10,
+						"maximum":     1000,
 					},
 					"limit": map[string]interface{}{
 						"type":        "integer",
-						"description": "Maximum number of connections to return (default: 100)",
+						"description": "Maximum number of ranked notes to return (default: 20)",
 						"minimum":     1,
 						"maximum":     1000,
 					},
+				},
+			},
+		},
+		{
+			name:        "find_strongest_path",
+			description: "Find how one note leads to another: the path of connections whose weakest connection is strongest, preferring fewer connections. By default connections are followed in their direction, and exclude_types skips types such as contradicts",
+			handler:     NewStrongestPathHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"from_note_id": map[string]interface{}{
+						"type":        "integer",
+						"description": "ID of the note the path starts from",
+					},
+					"to_note_id": map[string]interface{}{
+						"type":        "integer",
+						"description": "ID of the note the path ends at",
+					},
+					"max_depth": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum number of connections in the path (default: 4)",
+						"minimum":     1,
+						"maximum":     10,
+					},
+					"exclude_types": map[string]interface{}{
+						"type":        "array",
+						"description": "Connection types the path must not use, case-insensitive",
+						"items": map[string]interface{}{
+							"type": "string",
+						},
+					},
+					"direction": map[string]interface{}{
+						"type":        "string",
+						"description": "Follow connections the way they point (outgoing), backwards from their target (incoming), or either way as if undirected (both) (default: outgoing)",
+						"enum":        connection.ValidDirections(),
+					},
+				},
+				Required: []string{"from_note_id", "to_note_id"},
+			},
+		},
+		{
+			name:        "get_graph_metrics",
+			description: "Summarize the structure of the graph: the number of notes and connections, density, average degree and the number of connected components, ignoring connection direction",
+			handler:     NewGraphMetricsHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: map[string]interface{}{},
+			},
+		},
+		{
+			name:        "find_contradictions",
+			description: "Find note pairs linked by both a supports and a contradicts connection, which usually indicates a modeling error or a disputed relationship",
+			handler:     NewContradictionsHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: map[string]interface{}{},
+			},
+		},
+		{
+			name:        "find_reciprocal_connections",
+			description: "Find note pairs connected in both directions (A to B and B to A) with any types, which can reveal an implicitly symmetric relationship or redundant connections",
+			handler:     NewReciprocalHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: map[string]interface{}{},
+			},
+		},
+		{
+			name:        "find_orphan_notes",
+			description: "Find notes that have no incoming or outgoing connections",
+			handler:     NewOrphansHandler(storage, limits),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"limit": limits.LimitProperty("note IDs"),
 					"offset": map[string]interface{}{
 						"type":        "integer",
-						"description": "Number of connections to skip (default: 0)",
+						"description": "Number of note IDs to skip (default: 0)",
 						"minimum":     0,
 					},
 				},
+			},
+		},
+		{
+			name:        "suggest_connections",
+			description: "Suggest notes worth connecting to a note, ranked by shared tags and title or content similarity. Notes already connected to it are left out. Each suggestion has a score and a reason",
+			handler:     NewSuggestHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"note_id": map[string]interface{}{
+						"type":        "integer",
+						"description": "ID of the note to suggest connections for",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum number of suggestions (default: 10)",
+						"minimum":     1,
+						"maximum":     50,
+					},
+				},
 				Required: []string{"note_id"},
 			},
 		},
+		{
+			name:        "suggest_connection_strength",
+			description: "Suggest a strength (1-10) for connecting two notes from their shared tags, the notes both are connected to and the similarity of their titles and content. Returns each signal and a short rationale",
+			handler:     NewSuggestStrengthHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"from_note_id": map[string]interface{}{
+						"type":        "integer",
+						"description": "ID of the note the connection would start at",
+					},
+					"to_note_id": map[string]interface{}{
+						"type":        "integer",
+						"description": "ID of the note the connection would point to",
+					},
+				},
+				Required: []string{"from_note_id", "to_note_id"},
+			},
+		},
+		{
+			name:        "export_connections_csv",
+			description: "Export connections as CSV for analysis in a spreadsheet. Columns: id, from_note_id, to_note_id, from_title, to_title, type, strength, description, created_at",
+			handler:     NewExportCSVHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"compress": mcpx.CompressProperty(),
+					"include_titles": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Include the from_title and to_title columns (default: true)",
+					},
+					"type": map[string]interface{}{
+						"type":        "string",
+						"description": "Only export connections of this type, case-insensitive",
+					},
+					"source": map[string]interface{}{
+						"type":        "string",
+						"description": "Only export connections created by this source",
+					},
+				},
+			},
+		},
+		{
+			name:        "get_strength_histogram",
+			description: "Count connections by strength range for an overview of how strongly notes are linked. By default the ranges are weak (1-3), medium (4-7) and strong (8-10); pass buckets for evenly sized ranges or boundaries for custom ones",
+			handler:     NewStrengthHistogramHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"buckets": map[string]interface{}{
+						"type":        "integer",
+						"description": "Split the strengths 1-10 into this many evenly sized ranges",
+						"minimum":     1,
+						"maximum":     10,
+					},
+					"boundaries": map[string]interface{}{
+						"type":        "array",
+						"description": "Increasing strengths that end each range but the last, e.g. [3, 7] for 1-3, 4-7 and 8-10. Cannot be combined with buckets",
+						"items": map[string]interface{}{
+							"type":    "integer",
+							"minimum": 1,
+							"maximum": 9,
+						},
+						"minItems": 1,
+						"maxItems": 9,
+					},
+				},
+			},
+		},
+		{
+			name:        "export_adjacency_matrix",
+			description: "Export the adjacency matrix of a set of notes for numerical analysis. values[i][j] is the strength of the connection from note_ids[i] to note_ids[j], or 0; labels holds the note titles. The matrix is directed, except that symmetric connection types fill both cells. Unknown note IDs are listed in missing_ids and left out",
+			handler:     NewAdjacencyMatrixHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"compress": mcpx.CompressProperty(),
+					"note_ids": map[string]interface{}{
+						"type":        "array",
+						"description": "IDs of the notes, in row and column order",
+						"items": map[string]interface{}{
+							"type": "integer",
+						},
+						"minItems": 1,
+						"maxItems": 500,
+					},
+					"symmetric": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Combine both directions, so each pair gets the stronger of its two values (default: false)",
+					},
+				},
+				Required: []string{"note_ids"},
+			},
+		},
+		{
+			name:        "export_graph_gexf",
+			description: "Export the graph as GEXF XML for import into Gephi. Nodes are notes labelled by title with type and tags attributes; edges are connections weighted by strength with a type attribute. Symmetric types are undirected. With a filter only the matching connections and the notes they join are exported",
+			handler:     NewExportGEXFHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"compress": mcpx.CompressProperty(),
+					"type": map[string]interface{}{
+						"type":        "string",
+						"description": "Only export connections of this type, case-insensitive",
+					},
+					"source": map[string]interface{}{
+						"type":        "string",
+						"description": "Only export connections created by this source",
+					},
+				},
+			},
+		},
+		{
+			name:        "import_connections_csv",
+			description: "Create connections from CSV with a from_note_id, to_note_id, type, strength and description header. Common aliases such as from, to, relationship and weight are accepted and other columns are ignored, so export_connections_csv output can be imported as is. Types are case-insensitive. Invalid rows are skipped and reported by row number",
+			handler:     NewImportCSVHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"csv": map[string]interface{}{
+						"type":        "string",
+						"description": "CSV text with a header row (at most 10000 data rows)",
+					},
+				},
+				Required: []string{"csv"},
+			},
+		},
+		{
+			name:        "list_connection_types",
+			description: "List all connection types with their meaning and whether they are symmetric or directional. Tools accept types in any casing and with surrounding spaces, and store them lowercase.",
+			handler:     NewListTypesHandler(),
+			schema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: map[string]interface{}{},
+			},
+		},
 	}
 
 	for _, tool := range tools {
@@ -212,8 +803,73 @@ func RegisterTools(s *server.MCPServer, storage connection.Storage) error {
 			Description: tool.description,
 			InputSchema: tool.schema,
 		}
-		s.AddTool(t, tool.handler)
+		s.AddTool(t, mcpx.Wrap(tool.name, tool.handler, middleware...))
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// listConnectionsProperties is the list_connections schema, whose filters
+// count_connections shares
+func listConnectionsProperties(limits mcpx.ListLimits) map[string]interface{} {
+	return map[string]interface{}{
+		"limit": limits.LimitProperty("connections"),
+		"offset": map[string]interface{}{
+			"type":        "integer",
+			"description": "Number of connections to skip (default: 0)",
+			"minimum":     0,
+		},
+		"from_note_id": map[string]interface{}{
+			"type":        "integer",
+			"description": "Filter by source note ID",
+		},
+		"to_note_id": map[string]interface{}{
+			"type":        "integer",
+			"description": "Filter by target note ID",
+		},
+		"type": map[string]interface{}{
+			"type":        "string",
+			"description": "Filter by connection type, case-insensitive",
+		},
+		"types": map[string]interface{}{
+			"type":        "array",
+			"description": "Filter by any of these connection types, case-insensitive, combined with type when both are given",
+			"items": map[string]interface{}{
+				"type": "string",
+			},
+		},
+		"strength": map[string]interface{}{
+			"type":        "integer",
+			"description": "Filter by connection strength",
+			"minimum":     connection.MinStrength,
+			"maximum":     connection.MaxStrength,
+		},
+		"order_by": map[string]interface{}{
+			"type":        "string",
+			"description": "Field to order by (default: id)",
+			"enum":        connection.ValidOrderByFields(),
+		},
+		"order_dir": map[string]interface{}{
+			"type":        "string",
+			"description": "Order direction (default: asc)",
+			"enum":        []string{"asc", "desc"},
+		},
+		"source": map[string]interface{}{
+			"type":        "string",
+			"description": "Filter by the source that created the connections",
+		},
+		"description_search": map[string]interface{}{
+			"type":        "string",
+			"description": "Only return connections whose description contains every word, matched as word prefixes ignoring case and accents",
+		},
+		"strength_decay": map[string]interface{}{
+			"type":        "boolean",
+			"description": "Order by strength decayed with age, strength * exp(-ln(2) / half_life_days * age_days), so recent links rank above stale strong ones. Overrides order_by",
+		},
+		"half_life_days": map[string]interface{}{
+			"type":             "number",
+			"description":      "Days after which a connection's weight halves when strength_decay is set (default: 30)",
+			"exclusiveMinimum": 0,
+		},
+	}
+}
@@ -0,0 +1,60 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewTouchHandler creates a new handler for marking connections as recently revisited
+func NewTouchHandler(storage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse ID
+		idRaw, ok := arguments["id"]
+		if !ok {
+			return nil, fmt.Errorf("id is required")
+		}
+
+		id, err := mcpx.ParseInt64(idRaw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id: %w", err)
+		}
+
+		if id <= 0 {
+			return nil, fmt.Errorf("id must be a positive integer")
+		}
+
+		if err := storage.Touch(ctx, id); err != nil {
+			return nil, fmt.Errorf("failed to touch connection: %w", err)
+		}
+
+		conn, err := storage.Get(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get connection: %w", err)
+		}
+
+		jsonData, err := mcpx.MarshalJSON(newConnectionResponse(conn))
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Successfully touched connection with ID: %d\n\n%s", id, string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,135 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+)
+
+func TestTouchHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewTouchHandler(mockStorage)
+
+	touched := &connection.Connection{
+		ID: 1, FromNoteID: 2, ToNoteID: 3, Type: "supports", Strength: 7,
+		CreatedAt: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "successful touch",
+			args: map[string]interface{}{
+				"id": float64(1),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().Touch(gomock.Any(), int64(1)).Return(nil)
+				mockStorage.EXPECT().Get(gomock.Any(), int64(1)).Return(touched, nil)
+			},
+			wantContent: "Successfully touched connection with ID: 1",
+		},
+		{
+			name: "returns the refreshed connection",
+			args: map[string]interface{}{
+				"id": "1",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().Touch(gomock.Any(), int64(1)).Return(nil)
+				mockStorage.EXPECT().Get(gomock.Any(), int64(1)).Return(touched, nil)
+			},
+			wantContent: `"updated_at": "2024-06-01T12:00:00Z"`,
+		},
+		{
+			name:        "missing id",
+			args:        map[string]interface{}{},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "id is required",
+		},
+		{
+			name: "invalid id",
+			args: map[string]interface{}{
+				"id": "abc",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid id",
+		},
+		{
+			name: "non-positive id",
+			args: map[string]interface{}{
+				"id": float64(0),
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "id must be a positive integer",
+		},
+		{
+			name: "connection not found",
+			args: map[string]interface{}{
+				"id": float64(99),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Touch(gomock.Any(), int64(99)).
+					Return(fmt.Errorf("%w: 99", connection.ErrNotFound))
+			},
+			wantErr:     true,
+			wantContent: "failed to touch connection",
+		},
+		{
+			name: "get error",
+			args: map[string]interface{}{
+				"id": float64(1),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().Touch(gomock.Any(), int64(1)).Return(nil)
+				mockStorage.EXPECT().Get(gomock.Any(), int64(1)).Return(nil, errors.New("storage error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to get connection",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
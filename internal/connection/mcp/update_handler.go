@@ -2,13 +2,14 @@ package mcp
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
 )
 
 // NewUpdateHandler creates a new handler for updating connections
@@ -25,7 +26,7 @@ func NewUpdateHandler(storage connection.Storage) server.ToolHandlerFunc {
 			return nil, fmt.Errorf("id is required")
 		}
 
-		id, err := parseInt64(idRaw)
+		id, err := mcpx.ParseInt64(idRaw)
 		if err != nil {
 			return nil, fmt.Errorf("invalid id: %w", err)
 		}
@@ -37,12 +38,9 @@ func NewUpdateHandler(storage connection.Storage) server.ToolHandlerFunc {
 		updateReq := connection.UpdateConnectionRequest{}
 
 		// Parse optional type
-		if connectionType, ok := arguments["type"].(string); ok && connectionType != "" {
-			// Validate connection type
-			if !connection.IsValidConnectionType(connectionType) {
-				return nil, fmt.Errorf("invalid connection type: %s. Valid types are: %v", connectionType, connection.ValidConnectionTypes())
-			}
-			updateReq.Type = &connectionType
+		updateReq.Type, err = parseConnectionType(arguments, "type")
+		if err != nil {
+			return nil, err
 		}
 
 		// Parse optional description
@@ -52,13 +50,13 @@ func NewUpdateHandler(storage connection.Storage) server.ToolHandlerFunc {
 
 		// Parse optional strength
 		if strengthRaw, ok := arguments["strength"]; ok {
-			strength, err := parseInt(strengthRaw)
+			strength, err := mcpx.ParseInt(strengthRaw)
 			if err != nil {
 				return nil, fmt.Errorf("invalid strength: %w", err)
 			}
 			// Validate strength range
-			if strength < 1 || strength > 10 {
-				return nil, fmt.Errorf("strength must be between 1 and 10, got: %d", strength)
+			if err := connection.ValidateStrength(strength); err != nil {
+				return nil, err
 			}
 			updateReq.Strength = &strength
 		}
@@ -68,24 +66,23 @@ func NewUpdateHandler(storage connection.Storage) server.ToolHandlerFunc {
 			updateReq.Metadata = metadataRaw
 		}
 
+		// Parse optional expected_updated_at
+		if expectedRaw, ok := arguments["expected_updated_at"].(string); ok && expectedRaw != "" {
+			expected, err := time.Parse(time.RFC3339, expectedRaw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid expected_updated_at, use the RFC 3339 updated_at from get_connection: %w", err)
+			}
+			updateReq.ExpectedUpdatedAt = &expected
+		}
+
 		conn, err := storage.Update(ctx, id, updateReq)
 		if err != nil {
 			return nil, fmt.Errorf("failed to update connection: %w", err)
 		}
 
-		result := map[string]interface{}{
-			"id":           conn.ID,
-			"from_note_id": conn.FromNoteID,
-			"to_note_id":   conn.ToNoteID,
-			"type":         conn.Type,
-			"description":  conn.Description,
-			"strength":     conn.Strength,
-			"metadata":     conn.Metadata,
-			"created_at":   conn.CreatedAt,
-			"updated_at":   conn.UpdatedAt,
-		}
+		result := newConnectionResponse(conn)
 
-		jsonData, err := json.MarshalIndent(result, "", "  ")
+		jsonData, err := mcpx.MarshalJSON(result)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal result: %w", err)
 		}
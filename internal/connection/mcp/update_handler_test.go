@@ -3,6 +3,7 @@ package mcp_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -92,6 +93,31 @@ func TestUpdateHandler(t *testing.T) {
 			wantErr:     false,
 			wantContent: "Successfully updated connection with ID: 1",
 		},
+		{
+			name: "mixed-case padded type is normalized",
+			args: map[string]interface{}{
+				"id":   int64(1),
+				"type": "REFERENCES\t",
+			},
+			mockSetup: func() {
+				refType := "references"
+				mockStorage.EXPECT().
+					Update(gomock.Any(), int64(1), connection.UpdateConnectionRequest{
+						Type: &refType,
+					}).
+					Return(&connection.Connection{
+						ID:         1,
+						FromNoteID: 1,
+						ToNoteID:   2,
+						Type:       "references",
+						Strength:   5,
+						CreatedAt:  now,
+						UpdatedAt:  now,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully updated connection with ID: 1",
+		},
 		{
 			name: "successful update strength only",
 			args: map[string]interface{}{
@@ -143,6 +169,58 @@ func TestUpdateHandler(t *testing.T) {
 			wantErr:     false,
 			wantContent: "Successfully updated connection with ID: 1",
 		},
+		{
+			name: "update with expected_updated_at",
+			args: map[string]interface{}{
+				"id":                  int64(1),
+				"strength":            8,
+				"expected_updated_at": "2026-10-14T10:00:00Z",
+			},
+			mockSetup: func() {
+				expected := time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)
+				mockStorage.EXPECT().
+					Update(gomock.Any(), int64(1), connection.UpdateConnectionRequest{
+						Strength:          &newStrength,
+						ExpectedUpdatedAt: &expected,
+					}).
+					Return(&connection.Connection{
+						ID:         1,
+						FromNoteID: 1,
+						ToNoteID:   2,
+						Type:       "supports",
+						Strength:   8,
+						CreatedAt:  now,
+						UpdatedAt:  now,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully updated connection with ID: 1",
+		},
+		{
+			name: "stale expected_updated_at",
+			args: map[string]interface{}{
+				"id":                  int64(1),
+				"strength":            8,
+				"expected_updated_at": "2026-10-14T10:00:00Z",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Update(gomock.Any(), int64(1), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: connection 1 was updated at 2026-10-14T10:05:00Z, expected 2026-10-14T10:00:00Z", connection.ErrConflict))
+			},
+			wantErr:     true,
+			wantContent: "modified by someone else",
+		},
+		{
+			name: "invalid expected_updated_at",
+			args: map[string]interface{}{
+				"id":                  int64(1),
+				"expected_updated_at": "2026-10-14",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid expected_updated_at",
+		},
 		{
 			name: "missing id",
 			args: map[string]interface{}{
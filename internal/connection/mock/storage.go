@@ -35,6 +35,51 @@ func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
 	return m.recorder
 }
 
+// AdjustStrength mocks base method.
+func (m *MockStorage) AdjustStrength(ctx context.Context, id int64, delta int) (*connection.Connection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustStrength", ctx, id, delta)
+	ret0, _ := ret[0].(*connection.Connection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustStrength indicates an expected call of AdjustStrength.
+func (mr *MockStorageMockRecorder) AdjustStrength(ctx, id, delta interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustStrength", reflect.TypeOf((*MockStorage)(nil).AdjustStrength), ctx, id, delta)
+}
+
+// ComputePageRank mocks base method.
+func (m *MockStorage) ComputePageRank(ctx context.Context, opts connection.PageRankOptions) ([]connection.NoteRank, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ComputePageRank", ctx, opts)
+	ret0, _ := ret[0].([]connection.NoteRank)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ComputePageRank indicates an expected call of ComputePageRank.
+func (mr *MockStorageMockRecorder) ComputePageRank(ctx, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ComputePageRank", reflect.TypeOf((*MockStorage)(nil).ComputePageRank), ctx, opts)
+}
+
+// Count mocks base method.
+func (m *MockStorage) Count(ctx context.Context, req connection.ListConnectionsRequest) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, req)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockStorageMockRecorder) Count(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockStorage)(nil).Count), ctx, req)
+}
+
 // Create mocks base method.
 func (m *MockStorage) Create(ctx context.Context, req connection.CreateConnectionRequest) (*connection.Connection, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +95,52 @@ func (mr *MockStorageMockRecorder) Create(ctx, req interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStorage)(nil).Create), ctx, req)
 }
 
+// CreateByTitle mocks base method.
+func (m *MockStorage) CreateByTitle(ctx context.Context, req connection.CreateByTitleRequest) (*connection.Connection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateByTitle", ctx, req)
+	ret0, _ := ret[0].(*connection.Connection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateByTitle indicates an expected call of CreateByTitle.
+func (mr *MockStorageMockRecorder) CreateByTitle(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateByTitle", reflect.TypeOf((*MockStorage)(nil).CreateByTitle), ctx, req)
+}
+
+// CreateConnectionEnsuringNotes mocks base method.
+func (m *MockStorage) CreateConnectionEnsuringNotes(ctx context.Context, req connection.EnsureRequest) (*connection.Connection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateConnectionEnsuringNotes", ctx, req)
+	ret0, _ := ret[0].(*connection.Connection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateConnectionEnsuringNotes indicates an expected call of CreateConnectionEnsuringNotes.
+func (mr *MockStorageMockRecorder) CreateConnectionEnsuringNotes(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateConnectionEnsuringNotes", reflect.TypeOf((*MockStorage)(nil).CreateConnectionEnsuringNotes), ctx, req)
+}
+
+// CreateWithInverse mocks base method.
+func (m *MockStorage) CreateWithInverse(ctx context.Context, req connection.CreateConnectionRequest) (*connection.Connection, *connection.Connection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithInverse", ctx, req)
+	ret0, _ := ret[0].(*connection.Connection)
+	ret1, _ := ret[1].(*connection.Connection)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateWithInverse indicates an expected call of CreateWithInverse.
+func (mr *MockStorageMockRecorder) CreateWithInverse(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithInverse", reflect.TypeOf((*MockStorage)(nil).CreateWithInverse), ctx, req)
+}
+
 // Delete mocks base method.
 func (m *MockStorage) Delete(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
@@ -64,19 +155,154 @@ func (mr *MockStorageMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStorage)(nil).Delete), ctx, id)
 }
 
+// DeleteBetween mocks base method.
+func (m *MockStorage) DeleteBetween(ctx context.Context, fromNoteID, toNoteID int64, connType *string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBetween", ctx, fromNoteID, toNoteID, connType)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBetween indicates an expected call of DeleteBetween.
+func (mr *MockStorageMockRecorder) DeleteBetween(ctx, fromNoteID, toNoteID, connType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBetween", reflect.TypeOf((*MockStorage)(nil).DeleteBetween), ctx, fromNoteID, toNoteID, connType)
+}
+
+// ExportAdjacencyMatrix mocks base method.
+func (m *MockStorage) ExportAdjacencyMatrix(ctx context.Context, noteIDs []int64) (*connection.Matrix, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportAdjacencyMatrix", ctx, noteIDs)
+	ret0, _ := ret[0].(*connection.Matrix)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportAdjacencyMatrix indicates an expected call of ExportAdjacencyMatrix.
+func (mr *MockStorageMockRecorder) ExportAdjacencyMatrix(ctx, noteIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportAdjacencyMatrix", reflect.TypeOf((*MockStorage)(nil).ExportAdjacencyMatrix), ctx, noteIDs)
+}
+
+// ExportConnectionsCSV mocks base method.
+func (m *MockStorage) ExportConnectionsCSV(ctx context.Context, req connection.ExportRequest) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportConnectionsCSV", ctx, req)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportConnectionsCSV indicates an expected call of ExportConnectionsCSV.
+func (mr *MockStorageMockRecorder) ExportConnectionsCSV(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportConnectionsCSV", reflect.TypeOf((*MockStorage)(nil).ExportConnectionsCSV), ctx, req)
+}
+
+// ExportGEXF mocks base method.
+func (m *MockStorage) ExportGEXF(ctx context.Context, req connection.ExportRequest) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportGEXF", ctx, req)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportGEXF indicates an expected call of ExportGEXF.
+func (mr *MockStorageMockRecorder) ExportGEXF(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportGEXF", reflect.TypeOf((*MockStorage)(nil).ExportGEXF), ctx, req)
+}
+
 // FindConnectionPaths mocks base method.
-func (m *MockStorage) FindConnectionPaths(ctx context.Context, fromNoteID, toNoteID int64, maxDepth int) ([]connection.ConnectionPath, error) {
+func (m *MockStorage) FindConnectionPaths(ctx context.Context, fromNoteID, toNoteID int64, opts connection.PathOptions) ([]connection.ConnectionPath, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindConnectionPaths", ctx, fromNoteID, toNoteID, maxDepth)
+	ret := m.ctrl.Call(m, "FindConnectionPaths", ctx, fromNoteID, toNoteID, opts)
 	ret0, _ := ret[0].([]connection.ConnectionPath)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindConnectionPaths indicates an expected call of FindConnectionPaths.
-func (mr *MockStorageMockRecorder) FindConnectionPaths(ctx, fromNoteID, toNoteID, maxDepth interface{}) *gomock.Call {
+func (mr *MockStorageMockRecorder) FindConnectionPaths(ctx, fromNoteID, toNoteID, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindConnectionPaths", reflect.TypeOf((*MockStorage)(nil).FindConnectionPaths), ctx, fromNoteID, toNoteID, opts)
+}
+
+// FindContradictions mocks base method.
+func (m *MockStorage) FindContradictions(ctx context.Context) ([]connection.ContradictionPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindContradictions", ctx)
+	ret0, _ := ret[0].([]connection.ContradictionPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindContradictions indicates an expected call of FindContradictions.
+func (mr *MockStorageMockRecorder) FindContradictions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindContradictions", reflect.TypeOf((*MockStorage)(nil).FindContradictions), ctx)
+}
+
+// FindOrphanNotes mocks base method.
+func (m *MockStorage) FindOrphanNotes(ctx context.Context, limit, offset int) (*connection.OrphanNotesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrphanNotes", ctx, limit, offset)
+	ret0, _ := ret[0].(*connection.OrphanNotesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrphanNotes indicates an expected call of FindOrphanNotes.
+func (mr *MockStorageMockRecorder) FindOrphanNotes(ctx, limit, offset interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindConnectionPaths", reflect.TypeOf((*MockStorage)(nil).FindConnectionPaths), ctx, fromNoteID, toNoteID, maxDepth)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrphanNotes", reflect.TypeOf((*MockStorage)(nil).FindOrphanNotes), ctx, limit, offset)
+}
+
+// FindReciprocalConnections mocks base method.
+func (m *MockStorage) FindReciprocalConnections(ctx context.Context) ([]connection.ReciprocalPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindReciprocalConnections", ctx)
+	ret0, _ := ret[0].([]connection.ReciprocalPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindReciprocalConnections indicates an expected call of FindReciprocalConnections.
+func (mr *MockStorageMockRecorder) FindReciprocalConnections(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindReciprocalConnections", reflect.TypeOf((*MockStorage)(nil).FindReciprocalConnections), ctx)
+}
+
+// FindRedundantInverses mocks base method.
+func (m *MockStorage) FindRedundantInverses(ctx context.Context, fromNoteID, toNoteID int64, connectionType string) ([]connection.Connection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRedundantInverses", ctx, fromNoteID, toNoteID, connectionType)
+	ret0, _ := ret[0].([]connection.Connection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRedundantInverses indicates an expected call of FindRedundantInverses.
+func (mr *MockStorageMockRecorder) FindRedundantInverses(ctx, fromNoteID, toNoteID, connectionType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRedundantInverses", reflect.TypeOf((*MockStorage)(nil).FindRedundantInverses), ctx, fromNoteID, toNoteID, connectionType)
+}
+
+// FindStrongestPath mocks base method.
+func (m *MockStorage) FindStrongestPath(ctx context.Context, fromNoteID, toNoteID int64, opts connection.PathOptions) (*connection.ConnectionPath, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStrongestPath", ctx, fromNoteID, toNoteID, opts)
+	ret0, _ := ret[0].(*connection.ConnectionPath)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindStrongestPath indicates an expected call of FindStrongestPath.
+func (mr *MockStorageMockRecorder) FindStrongestPath(ctx, fromNoteID, toNoteID, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStrongestPath", reflect.TypeOf((*MockStorage)(nil).FindStrongestPath), ctx, fromNoteID, toNoteID, opts)
 }
 
 // Get mocks base method.
@@ -109,19 +335,34 @@ func (mr *MockStorageMockRecorder) GetBidirectionalConnections(ctx, noteID inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBidirectionalConnections", reflect.TypeOf((*MockStorage)(nil).GetBidirectionalConnections), ctx, noteID)
 }
 
+// GetConnectionAudit mocks base method.
+func (m *MockStorage) GetConnectionAudit(ctx context.Context, connectionID int64) ([]connection.AuditEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConnectionAudit", ctx, connectionID)
+	ret0, _ := ret[0].([]connection.AuditEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConnectionAudit indicates an expected call of GetConnectionAudit.
+func (mr *MockStorageMockRecorder) GetConnectionAudit(ctx, connectionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnectionAudit", reflect.TypeOf((*MockStorage)(nil).GetConnectionAudit), ctx, connectionID)
+}
+
 // GetConnectionStats mocks base method.
-func (m *MockStorage) GetConnectionStats(ctx context.Context) (*connection.ConnectionStats, error) {
+func (m *MockStorage) GetConnectionStats(ctx context.Context, opts connection.StatsOptions) (*connection.ConnectionStats, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetConnectionStats", ctx)
+	ret := m.ctrl.Call(m, "GetConnectionStats", ctx, opts)
 	ret0, _ := ret[0].(*connection.ConnectionStats)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetConnectionStats indicates an expected call of GetConnectionStats.
-func (mr *MockStorageMockRecorder) GetConnectionStats(ctx interface{}) *gomock.Call {
+func (mr *MockStorageMockRecorder) GetConnectionStats(ctx, opts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnectionStats", reflect.TypeOf((*MockStorage)(nil).GetConnectionStats), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnectionStats", reflect.TypeOf((*MockStorage)(nil).GetConnectionStats), ctx, opts)
 }
 
 // GetConnectionsByType mocks base method.
@@ -139,6 +380,66 @@ func (mr *MockStorageMockRecorder) GetConnectionsByType(ctx, connectionType, req
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnectionsByType", reflect.TypeOf((*MockStorage)(nil).GetConnectionsByType), ctx, connectionType, req)
 }
 
+// GetConnectionsForNotes mocks base method.
+func (m *MockStorage) GetConnectionsForNotes(ctx context.Context, noteIDs []int64, filter connection.NoteConnectionsFilter) (map[int64]*connection.NoteConnectionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConnectionsForNotes", ctx, noteIDs, filter)
+	ret0, _ := ret[0].(map[int64]*connection.NoteConnectionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConnectionsForNotes indicates an expected call of GetConnectionsForNotes.
+func (mr *MockStorageMockRecorder) GetConnectionsForNotes(ctx, noteIDs, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnectionsForNotes", reflect.TypeOf((*MockStorage)(nil).GetConnectionsForNotes), ctx, noteIDs, filter)
+}
+
+// GetGraphMetrics mocks base method.
+func (m *MockStorage) GetGraphMetrics(ctx context.Context) (*connection.GraphMetrics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGraphMetrics", ctx)
+	ret0, _ := ret[0].(*connection.GraphMetrics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGraphMetrics indicates an expected call of GetGraphMetrics.
+func (mr *MockStorageMockRecorder) GetGraphMetrics(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGraphMetrics", reflect.TypeOf((*MockStorage)(nil).GetGraphMetrics), ctx)
+}
+
+// GetLatestConnectionPerNote mocks base method.
+func (m *MockStorage) GetLatestConnectionPerNote(ctx context.Context, noteIDs []int64) (map[int64]*connection.Connection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestConnectionPerNote", ctx, noteIDs)
+	ret0, _ := ret[0].(map[int64]*connection.Connection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestConnectionPerNote indicates an expected call of GetLatestConnectionPerNote.
+func (mr *MockStorageMockRecorder) GetLatestConnectionPerNote(ctx, noteIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestConnectionPerNote", reflect.TypeOf((*MockStorage)(nil).GetLatestConnectionPerNote), ctx, noteIDs)
+}
+
+// GetMany mocks base method.
+func (m *MockStorage) GetMany(ctx context.Context, ids []int64) ([]connection.Connection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMany", ctx, ids)
+	ret0, _ := ret[0].([]connection.Connection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMany indicates an expected call of GetMany.
+func (mr *MockStorageMockRecorder) GetMany(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockStorage)(nil).GetMany), ctx, ids)
+}
+
 // GetNoteConnections mocks base method.
 func (m *MockStorage) GetNoteConnections(ctx context.Context, req connection.NoteConnectionsRequest) (*connection.NoteConnectionsResponse, error) {
 	m.ctrl.T.Helper()
@@ -154,6 +455,36 @@ func (mr *MockStorageMockRecorder) GetNoteConnections(ctx, req interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNoteConnections", reflect.TypeOf((*MockStorage)(nil).GetNoteConnections), ctx, req)
 }
 
+// GetStrengthHistogram mocks base method.
+func (m *MockStorage) GetStrengthHistogram(ctx context.Context, buckets []connection.Bucket) ([]connection.Bucket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStrengthHistogram", ctx, buckets)
+	ret0, _ := ret[0].([]connection.Bucket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStrengthHistogram indicates an expected call of GetStrengthHistogram.
+func (mr *MockStorageMockRecorder) GetStrengthHistogram(ctx, buckets interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStrengthHistogram", reflect.TypeOf((*MockStorage)(nil).GetStrengthHistogram), ctx, buckets)
+}
+
+// ImportConnectionsCSV mocks base method.
+func (m *MockStorage) ImportConnectionsCSV(ctx context.Context, data string) (*connection.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportConnectionsCSV", ctx, data)
+	ret0, _ := ret[0].(*connection.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportConnectionsCSV indicates an expected call of ImportConnectionsCSV.
+func (mr *MockStorageMockRecorder) ImportConnectionsCSV(ctx, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportConnectionsCSV", reflect.TypeOf((*MockStorage)(nil).ImportConnectionsCSV), ctx, data)
+}
+
 // List mocks base method.
 func (m *MockStorage) List(ctx context.Context, req connection.ListConnectionsRequest) (*connection.ListConnectionsResponse, error) {
 	m.ctrl.T.Helper()
@@ -169,6 +500,154 @@ func (mr *MockStorageMockRecorder) List(ctx, req interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockStorage)(nil).List), ctx, req)
 }
 
+// ListDeletedConnections mocks base method.
+func (m *MockStorage) ListDeletedConnections(ctx context.Context, limit, offset int) (*connection.ListDeletedConnectionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedConnections", ctx, limit, offset)
+	ret0, _ := ret[0].(*connection.ListDeletedConnectionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletedConnections indicates an expected call of ListDeletedConnections.
+func (mr *MockStorageMockRecorder) ListDeletedConnections(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedConnections", reflect.TypeOf((*MockStorage)(nil).ListDeletedConnections), ctx, limit, offset)
+}
+
+// NormalizeStrengths mocks base method.
+func (m *MockStorage) NormalizeStrengths(ctx context.Context, opts connection.NormalizeStrengthsOptions) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NormalizeStrengths", ctx, opts)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NormalizeStrengths indicates an expected call of NormalizeStrengths.
+func (mr *MockStorageMockRecorder) NormalizeStrengths(ctx, opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NormalizeStrengths", reflect.TypeOf((*MockStorage)(nil).NormalizeStrengths), ctx, opts)
+}
+
+// PlanStrengthNormalization mocks base method.
+func (m *MockStorage) PlanStrengthNormalization(ctx context.Context) ([]connection.StrengthMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlanStrengthNormalization", ctx)
+	ret0, _ := ret[0].([]connection.StrengthMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlanStrengthNormalization indicates an expected call of PlanStrengthNormalization.
+func (mr *MockStorageMockRecorder) PlanStrengthNormalization(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanStrengthNormalization", reflect.TypeOf((*MockStorage)(nil).PlanStrengthNormalization), ctx)
+}
+
+// PruneConnections mocks base method.
+func (m *MockStorage) PruneConnections(ctx context.Context, maxStrength int, dryRun bool) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneConnections", ctx, maxStrength, dryRun)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneConnections indicates an expected call of PruneConnections.
+func (mr *MockStorageMockRecorder) PruneConnections(ctx, maxStrength, dryRun interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneConnections", reflect.TypeOf((*MockStorage)(nil).PruneConnections), ctx, maxStrength, dryRun)
+}
+
+// Restore mocks base method.
+func (m *MockStorage) Restore(ctx context.Context, id int64) (*connection.Connection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(*connection.Connection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockStorageMockRecorder) Restore(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockStorage)(nil).Restore), ctx, id)
+}
+
+// RetypeConnections mocks base method.
+func (m *MockStorage) RetypeConnections(ctx context.Context, fromType, toType string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetypeConnections", ctx, fromType, toType)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetypeConnections indicates an expected call of RetypeConnections.
+func (mr *MockStorageMockRecorder) RetypeConnections(ctx, fromType, toType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetypeConnections", reflect.TypeOf((*MockStorage)(nil).RetypeConnections), ctx, fromType, toType)
+}
+
+// SoftDelete mocks base method.
+func (m *MockStorage) SoftDelete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDelete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SoftDelete indicates an expected call of SoftDelete.
+func (mr *MockStorageMockRecorder) SoftDelete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDelete", reflect.TypeOf((*MockStorage)(nil).SoftDelete), ctx, id)
+}
+
+// SuggestConnections mocks base method.
+func (m *MockStorage) SuggestConnections(ctx context.Context, noteID int64, limit int) ([]connection.Suggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestConnections", ctx, noteID, limit)
+	ret0, _ := ret[0].([]connection.Suggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestConnections indicates an expected call of SuggestConnections.
+func (mr *MockStorageMockRecorder) SuggestConnections(ctx, noteID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestConnections", reflect.TypeOf((*MockStorage)(nil).SuggestConnections), ctx, noteID, limit)
+}
+
+// SuggestStrength mocks base method.
+func (m *MockStorage) SuggestStrength(ctx context.Context, fromNoteID, toNoteID int64) (*connection.StrengthSuggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuggestStrength", ctx, fromNoteID, toNoteID)
+	ret0, _ := ret[0].(*connection.StrengthSuggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SuggestStrength indicates an expected call of SuggestStrength.
+func (mr *MockStorageMockRecorder) SuggestStrength(ctx, fromNoteID, toNoteID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuggestStrength", reflect.TypeOf((*MockStorage)(nil).SuggestStrength), ctx, fromNoteID, toNoteID)
+}
+
+// Touch mocks base method.
+func (m *MockStorage) Touch(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockStorageMockRecorder) Touch(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockStorage)(nil).Touch), ctx, id)
+}
+
 // Update mocks base method.
 func (m *MockStorage) Update(ctx context.Context, id int64, req connection.UpdateConnectionRequest) (*connection.Connection, error) {
 	m.ctrl.T.Helper()
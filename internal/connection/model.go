@@ -1,9 +1,21 @@
 package connection
 
 import (
+	"fmt"
+	"math"
+	"strings"
 	"time"
 )
 
+const (
+	// DefaultMaxGraphNodes is the default node ceiling for whole-graph operations
+	DefaultMaxGraphNodes = 100000
+	// DefaultMaxGraphEdges is the default edge ceiling for whole-graph operations
+	DefaultMaxGraphEdges = 1000000
+	// DefaultGraphTimeout is the default time limit for whole-graph operations
+	DefaultGraphTimeout = 30 * time.Second
+)
+
 // Connection represents the domain model for a connection entity
 type Connection struct {
 	ID          int64                  `json:"id"`
@@ -13,6 +25,7 @@ type Connection struct {
 	Description *string                `json:"description,omitempty"`
 	Strength    int                    `json:"strength"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Source      *string                `json:"source,omitempty"`
 	CreatedAt   time.Time              `json:"created_at"`
 	UpdatedAt   time.Time              `json:"updated_at"`
 }
@@ -36,29 +49,24 @@ const (
 
 // ValidConnectionTypes returns a slice of all valid connection types
 func ValidConnectionTypes() []string {
-	return []string{
-		string(ConnectionTypeRelatesTo),
-		string(ConnectionTypeReferences),
-		string(ConnectionTypeSupports),
-		string(ConnectionTypeContradicts),
-		string(ConnectionTypeInfluences),
-		string(ConnectionTypeDependsOn),
-		string(ConnectionTypeSimilarTo),
-		string(ConnectionTypePartOf),
-		string(ConnectionTypeCites),
-		string(ConnectionTypeFollows),
-		string(ConnectionTypePrecedes),
+	types := make([]string, 0, len(connectionTypeRegistry))
+	for _, info := range connectionTypeRegistry {
+		types = append(types, info.Type)
 	}
+	return types
 }
 
 // IsValidConnectionType checks if the given type is a valid connection type
 func IsValidConnectionType(connectionType string) bool {
-	for _, validType := range ValidConnectionTypes() {
-		if validType == connectionType {
-			return true
-		}
-	}
-	return false
+	_, ok := GetConnectionTypeInfo(connectionType)
+	return ok
+}
+
+// NormalizeConnectionType trims and lowercases a connection type from client
+// input. Every valid type is lowercase, so the result validates whenever the
+// input names a type in any casing.
+func NormalizeConnectionType(connectionType string) string {
+	return strings.ToLower(strings.TrimSpace(connectionType))
 }
 
 // CreateConnectionRequest represents the DTO for creating a connection
@@ -69,6 +77,48 @@ type CreateConnectionRequest struct {
 	Description *string                `json:"description,omitempty"`
 	Strength    int                    `json:"strength"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Source      *string                `json:"source,omitempty"` // Who or what created the record, e.g. an agent name
+	// AutoSymmetric also stores the reverse connection when Type is symmetric
+	AutoSymmetric bool `json:"auto_symmetric,omitempty"`
+	// IdempotencyKey, when set, makes Create and CreateWithInverse return the
+	// connection an earlier call with the same key made instead of creating another
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+}
+
+// CreateByTitleRequest represents the DTO for creating a connection between notes identified by title
+type CreateByTitleRequest struct {
+	FromTitle   string                 `json:"from_title"`
+	ToTitle     string                 `json:"to_title"`
+	Type        string                 `json:"type"`
+	Description *string                `json:"description,omitempty"`
+	Strength    int                    `json:"strength"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Source      *string                `json:"source,omitempty"`
+	// AutoSymmetric also stores the reverse connection when Type is symmetric
+	AutoSymmetric bool `json:"auto_symmetric,omitempty"`
+}
+
+// EnsureNoteRequest names a note to connect by title. Content and Type are
+// only used when no note has the title and one is created; Content then
+// defaults to the title and Type to text.
+type EnsureNoteRequest struct {
+	Title   string `json:"title"`
+	Content string `json:"content,omitempty"`
+	Type    string `json:"type,omitempty"`
+}
+
+// EnsureRequest represents the DTO for connecting two notes by title, creating
+// either note when it does not exist yet
+type EnsureRequest struct {
+	From        EnsureNoteRequest      `json:"from"`
+	To          EnsureNoteRequest      `json:"to"`
+	Type        string                 `json:"type"`
+	Description *string                `json:"description,omitempty"`
+	Strength    int                    `json:"strength"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Source      *string                `json:"source,omitempty"`
+	// AutoSymmetric also stores the reverse connection when Type is symmetric
+	AutoSymmetric bool `json:"auto_symmetric,omitempty"`
 }
 
 // UpdateConnectionRequest represents the DTO for updating a connection
@@ -77,6 +127,10 @@ type UpdateConnectionRequest struct {
 	Description *string                `json:"description,omitempty"`
 	Strength    *int                   `json:"strength,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+
+	// ExpectedUpdatedAt makes the update fail with ErrConflict unless the
+	// connection's updated_at still equals it, to the second
+	ExpectedUpdatedAt *time.Time `json:"expected_updated_at,omitempty"`
 }
 
 // ListConnectionsRequest represents the DTO for listing connections
@@ -86,9 +140,36 @@ type ListConnectionsRequest struct {
 	FromNoteID *int64  `json:"from_note_id,omitempty"`
 	ToNoteID   *int64  `json:"to_note_id,omitempty"`
 	Type       *string `json:"type,omitempty"`
-	Strength   *int    `json:"strength,omitempty"`
-	OrderBy    string  `json:"order_by,omitempty"`
-	OrderDir   string  `json:"order_dir,omitempty"`
+	// Types keeps connections of any of these types. When Type is also set,
+	// connections of Type are kept too.
+	Types    []string `json:"types,omitempty"`
+	Strength *int     `json:"strength,omitempty"`
+	Source   *string  `json:"source,omitempty"`
+	// DescriptionSearch, when set, keeps connections whose description
+	// contains every word, each matched as a word prefix
+	DescriptionSearch *string `json:"description_search,omitempty"`
+	OrderBy           string  `json:"order_by,omitempty"`  // One of ValidOrderByFields; empty means newest first
+	OrderDir          string  `json:"order_dir,omitempty"` // asc (default) or desc
+	// StrengthDecay, when set, orders by decayed strength instead of OrderBy
+	StrengthDecay *StrengthDecay `json:"strength_decay,omitempty"`
+}
+
+// ValidOrderByFields returns the fields connections can be ordered by
+func ValidOrderByFields() []string {
+	return []string{"id", "created_at", "updated_at", "strength", "type"}
+}
+
+// StrengthDecay configures recency weighting for listed connections.
+// The effective strength is strength * exp(-lambda * age_days), where
+// lambda = ln(2) / HalfLifeDays and age_days is the time since created_at.
+// A connection loses half of its weight every HalfLifeDays.
+type StrengthDecay struct {
+	HalfLifeDays float64 `json:"half_life_days"`
+}
+
+// Lambda returns the decay rate per day
+func (d StrengthDecay) Lambda() float64 {
+	return math.Ln2 / d.HalfLifeDays
 }
 
 // ListConnectionsResponse represents the DTO for listing response
@@ -97,6 +178,18 @@ type ListConnectionsResponse struct {
 	Total int64        `json:"total"`
 }
 
+// DeletedConnection is a soft-deleted connection in the trash
+type DeletedConnection struct {
+	Connection
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// ListDeletedConnectionsResponse represents a page of the trash
+type ListDeletedConnectionsResponse struct {
+	Items []DeletedConnection `json:"items"`
+	Total int64               `json:"total"`
+}
+
 // NoteConnectionsRequest represents the DTO for getting all connections for a specific note
 type NoteConnectionsRequest struct {
 	NoteID   int64   `json:"note_id"`
@@ -104,6 +197,8 @@ type NoteConnectionsRequest struct {
 	Strength *int    `json:"strength,omitempty"`
 	Limit    int     `json:"limit,omitempty"`
 	Offset   int     `json:"offset,omitempty"`
+	// Direction picks outgoing, incoming or both kinds of connections; empty means DirectionBoth
+	Direction Direction `json:"direction,omitempty"`
 }
 
 // NoteConnectionsResponse represents all connections for a specific note
@@ -115,13 +210,55 @@ type NoteConnectionsResponse struct {
 	TypesCount map[string]int64   `json:"types_count"` // Count by connection type
 }
 
+// NoteConnectionsFilter represents optional filters applied when fetching connections for several notes
+type NoteConnectionsFilter struct {
+	Type     *string `json:"type,omitempty"`
+	Strength *int    `json:"strength,omitempty"`
+}
+
+// Direction selects which way a traversal follows connections from a note
+type Direction string
+
+const (
+	// DirectionOutgoing follows connections from their from note to their to note
+	DirectionOutgoing Direction = "outgoing"
+	// DirectionIncoming follows connections backwards, from their to note to their from note
+	DirectionIncoming Direction = "incoming"
+	// DirectionBoth follows connections either way, treating the graph as undirected
+	DirectionBoth Direction = "both"
+)
+
+// ValidDirections returns all valid traversal directions
+func ValidDirections() []string {
+	return []string{string(DirectionOutgoing), string(DirectionIncoming), string(DirectionBoth)}
+}
+
+// IsValidDirection checks if the given direction is a valid traversal direction
+func IsValidDirection(direction string) bool {
+	switch Direction(direction) {
+	case DirectionOutgoing, DirectionIncoming, DirectionBoth:
+		return true
+	}
+	return false
+}
+
+// PathOptions controls path finding between two notes
+type PathOptions struct {
+	MaxDepth     int       `json:"max_depth"`               // Maximum number of connections in a path
+	ExcludeTypes []string  `json:"exclude_types,omitempty"` // Connection types a path must not use
+	Direction    Direction `json:"direction,omitempty"`     // Which way connections are followed; empty means DirectionOutgoing
+}
+
 // ConnectionPath represents a path between two notes through connections
 type ConnectionPath struct {
 	FromNoteID int64        `json:"from_note_id"`
 	ToNoteID   int64        `json:"to_note_id"`
 	Path       []Connection `json:"path"`     // Ordered list of connections forming the path
 	Length     int          `json:"length"`   // Number of connections in the path
-	Strength   int          `json:"strength"` // Combined strength of the path
+	Strength   int          `json:"strength"` // Combined strength of the path; for FindStrongestPath, the strength of its weakest connection
+	// Truncated reports that the search stopped at the traversal limits, so a
+	// stronger path may exist, or, with an empty Path, that one may exist at all
+	Truncated bool `json:"truncated,omitempty"`
 }
 
 // ConnectionStats represents statistics about connections
@@ -130,6 +267,16 @@ type ConnectionStats struct {
 	ConnectionsByType    map[string]int64 `json:"connections_by_type"`
 	ConnectionsByStrength map[int]int64   `json:"connections_by_strength"`
 	MostConnectedNotes   []NoteConnection `json:"most_connected_notes"`
+	// ArchivedConnections is the number of connections in the trash, reported
+	// whether or not they are included in the other counts
+	ArchivedConnections int64 `json:"archived_connections"`
+}
+
+// StatsOptions configures which connections GetConnectionStats counts
+type StatsOptions struct {
+	// IncludeArchived counts soft-deleted connections in the trash as if they
+	// had not been deleted. By default only live connections count.
+	IncludeArchived bool `json:"include_archived,omitempty"`
 }
 
 // NoteConnection represents a note with its connection count
@@ -138,4 +285,344 @@ type NoteConnection struct {
 	IncomingCount   int64 `json:"incoming_count"`
 	OutgoingCount   int64 `json:"outgoing_count"`
 	TotalCount      int64 `json:"total_count"`
-}
\ No newline at end of file
+}
+
+// PageRankOptions configures the strength-weighted PageRank computation
+type PageRankOptions struct {
+	DampingFactor float64 `json:"damping_factor,omitempty"` // Probability of following an edge (default: 0.85)
+	MaxIterations int     `json:"max_iterations,omitempty"` // Iteration cap (default: 100)
+	Tolerance     float64 `json:"tolerance,omitempty"`      // L1 convergence threshold (default: 1e-6)
+	Limit         int     `json:"limit,omitempty"`          // Maximum number of ranked notes to return (0 = all)
+}
+
+// NoteRank represents a note with its PageRank score
+type NoteRank struct {
+	NoteID int64   `json:"note_id"`
+	Rank   float64 `json:"rank"`
+}
+
+// GraphMetrics summarizes the structure of the whole graph. Density is the
+// share of the n*(n-1) possible directed connections between distinct notes
+// that exist, and AverageDegree counts both incoming and outgoing connections.
+// Components are weakly connected, so an isolated note is a component of its own.
+type GraphMetrics struct {
+	Nodes               int64   `json:"nodes"`
+	Edges               int64   `json:"edges"`
+	Density             float64 `json:"density"`
+	AverageDegree       float64 `json:"average_degree"`
+	ConnectedComponents int64   `json:"connected_components"`
+}
+
+// NormalizeStrengthsOptions configures NormalizeStrengths
+type NormalizeStrengthsOptions struct {
+	DryRun bool `json:"dry_run,omitempty"` // Count the connections that would change without writing
+}
+
+// StrengthMapping describes how one existing strength value is rescaled by strength normalization
+type StrengthMapping struct {
+	From        int   `json:"from"`
+	To          int   `json:"to"`
+	Connections int64 `json:"connections"`
+}
+
+// ContradictionPair represents two notes linked by both supports and contradicts connections
+type ContradictionPair struct {
+	NoteAID                  int64   `json:"note_a_id"`
+	NoteBID                  int64   `json:"note_b_id"`
+	SupportsConnectionIDs    []int64 `json:"supports_connection_ids"`
+	ContradictsConnectionIDs []int64 `json:"contradicts_connection_ids"`
+}
+
+// AuditAction is the kind of change recorded in the connection audit log
+type AuditAction string
+
+const (
+	AuditActionCreate AuditAction = "create"
+	AuditActionUpdate AuditAction = "update"
+	AuditActionDelete AuditAction = "delete"
+)
+
+// AuditEntry records one change to a connection. Create and delete entries
+// hold every field of the connection; update entries hold the old and new
+// value of each changed field.
+type AuditEntry struct {
+	ID           int64                  `json:"id"`
+	ConnectionID int64                  `json:"connection_id"`
+	Action       AuditAction            `json:"action"`
+	Changes      map[string]interface{} `json:"changes"`
+	CreatedAt    time.Time              `json:"created_at"`
+}
+
+// ReciprocalPair represents two notes connected in both directions, of any type.
+// NoteAID is the lower note ID.
+type ReciprocalPair struct {
+	NoteAID int64           `json:"note_a_id"`
+	NoteBID int64           `json:"note_b_id"`
+	AToB    []ConnectionRef `json:"a_to_b"`
+	BToA    []ConnectionRef `json:"b_to_a"`
+}
+
+// ConnectionRef identifies a connection and its type
+type ConnectionRef struct {
+	ID   int64  `json:"id"`
+	Type string `json:"type"`
+}
+
+// OrphanNotesResponse represents a page of notes that have no connections
+type OrphanNotesResponse struct {
+	NoteIDs []int64 `json:"note_ids"`
+	Total   int64   `json:"total"`
+}
+
+// Suggestion is a note worth connecting to a given note. Score is the tag
+// overlap (0-1) plus the content similarity (0-1); Reason explains both.
+type Suggestion struct {
+	NoteID     int64    `json:"note_id"`
+	Title      string   `json:"title"`
+	Score      float64  `json:"score"`
+	SharedTags []string `json:"shared_tags"`
+	Reason     string   `json:"reason"`
+}
+
+// StrengthWeights sets how much each signal counts towards a suggested
+// strength. Only their ratios matter; a zero weight ignores that signal.
+type StrengthWeights struct {
+	Tags      float64 `json:"tags"`      // Share of the two notes' tags they have in common
+	Neighbors float64 `json:"neighbors"` // Share of the notes connected to either that are connected to both
+	Content   float64 `json:"content"`   // Similarity of their title and content words
+}
+
+// DefaultStrengthWeights returns the weights used when none are configured,
+// which count every signal equally
+func DefaultStrengthWeights() StrengthWeights {
+	return StrengthWeights{Tags: 1, Neighbors: 1, Content: 1}
+}
+
+// Validate reports negative weights and weights that are all zero
+func (w StrengthWeights) Validate() error {
+	if w.Tags < 0 || w.Neighbors < 0 || w.Content < 0 {
+		return fmt.Errorf("strength weights must not be negative, got: tags=%g, neighbors=%g, content=%g", w.Tags, w.Neighbors, w.Content)
+	}
+	if w.Tags+w.Neighbors+w.Content == 0 {
+		return fmt.Errorf("at least one strength weight must be positive")
+	}
+	return nil
+}
+
+// StrengthSuggestion is a proposed strength for connecting two notes. Each
+// signal is between 0 and 1, and Score is their weighted average over the
+// signals that either note has evidence for, mapped linearly onto
+// MinStrength-MaxStrength to give Strength.
+type StrengthSuggestion struct {
+	FromNoteID        int64    `json:"from_note_id"`
+	ToNoteID          int64    `json:"to_note_id"`
+	Strength          int      `json:"strength"`
+	Score             float64  `json:"score"`
+	TagOverlap        float64  `json:"tag_overlap"`
+	NeighborOverlap   float64  `json:"neighbor_overlap"`
+	ContentSimilarity float64  `json:"content_similarity"`
+	SharedTags        []string `json:"shared_tags"`
+	SharedNeighborIDs []int64  `json:"shared_neighbor_ids"`
+	Rationale         string   `json:"rationale"`
+}
+
+// ExportRequest configures a CSV or GEXF export of connections
+type ExportRequest struct {
+	Type   *string `json:"type,omitempty"`
+	Source *string `json:"source,omitempty"`
+	// IncludeTitles adds from_title and to_title columns with the note titles.
+	// It only applies to CSV; GEXF nodes are always labelled with titles.
+	IncludeTitles bool `json:"include_titles,omitempty"`
+}
+
+// ImportResult reports the outcome of a CSV import of connections
+type ImportResult struct {
+	Created    int64            `json:"created"`
+	CreatedIDs []int64          `json:"created_ids"`
+	Errors     []ImportRowError `json:"errors"`
+}
+
+// ImportRowError describes a CSV row that could not be imported. Row is the
+// spreadsheet row number, counting the header as row 1.
+type ImportRowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+// Matrix is an adjacency matrix over an ordered set of notes. Values[i][j] is
+// the strength of the connection from NoteIDs[i] to NoteIDs[j], or 0 when
+// there is none. Labels holds the note titles in the same order.
+type Matrix struct {
+	NoteIDs []int64  `json:"note_ids"`
+	Labels  []string `json:"labels"`
+	Values  [][]int  `json:"values"`
+	// MissingIDs lists requested IDs with no note; they have no row or column
+	MissingIDs []int64 `json:"missing_ids"`
+}
+
+// Symmetrize makes the matrix undirected by setting both cells of every pair
+// to the stronger of its two directions
+func (m *Matrix) Symmetrize() {
+	for i := range m.Values {
+		for j := i + 1; j < len(m.Values); j++ {
+			v := max(m.Values[i][j], m.Values[j][i])
+			m.Values[i][j], m.Values[j][i] = v, v
+		}
+	}
+}
+
+// GraphLimits bounds the cost of expensive graph operations such as PageRank.
+// A zero field disables that limit.
+type GraphLimits struct {
+	MaxNodes int           `json:"max_nodes,omitempty"`
+	MaxEdges int           `json:"max_edges,omitempty"`
+	Timeout  time.Duration `json:"timeout,omitempty"`
+}
+
+// DefaultGraphLimits returns the limits applied when none are configured
+func DefaultGraphLimits() GraphLimits {
+	return GraphLimits{
+		MaxNodes: DefaultMaxGraphNodes,
+		MaxEdges: DefaultMaxGraphEdges,
+		Timeout:  DefaultGraphTimeout,
+	}
+}
+
+// EdgeMode decides whether two notes may share several connections of the
+// same type
+type EdgeMode string
+
+const (
+	// EdgeModeUnique allows one connection per note pair and type, counting
+	// both directions for symmetric types
+	EdgeModeUnique EdgeMode = "unique"
+	// EdgeModeMulti allows parallel connections of the same type, for example
+	// one per source citing the same link
+	EdgeModeMulti EdgeMode = "multi"
+)
+
+// ParseEdgeMode parses an edge mode name; an empty name is EdgeModeUnique
+func ParseEdgeMode(name string) (EdgeMode, error) {
+	switch EdgeMode(strings.ToLower(strings.TrimSpace(name))) {
+	case "", EdgeModeUnique:
+		return EdgeModeUnique, nil
+	case EdgeModeMulti:
+		return EdgeModeMulti, nil
+	default:
+		return "", fmt.Errorf("invalid edge mode %q, must be unique or multi", name)
+	}
+}
+
+// MinStrength and MaxStrength bound every connection strength, inclusive.
+// The CHECK constraint on connections.strength, last defined in migration
+// 000004, enforces the same range.
+const (
+	MinStrength = 1
+	MaxStrength = 10
+)
+
+// ValidateStrength reports a strength outside MinStrength to MaxStrength. The
+// storage returns the same error when the database rejects a strength.
+func ValidateStrength(strength int) error {
+	if strength < MinStrength || strength > MaxStrength {
+		return fmt.Errorf("strength must be between %d and %d, got: %d", MinStrength, MaxStrength, strength)
+	}
+	return nil
+}
+
+// MaxDescriptionLength is the longest connection description, in bytes
+const MaxDescriptionLength = 500
+
+// ValidateDescription reports a description longer than MaxDescriptionLength.
+// A nil description is valid.
+func ValidateDescription(description *string) error {
+	if description != nil && len(*description) > MaxDescriptionLength {
+		return fmt.Errorf("description must be %d characters or less", MaxDescriptionLength)
+	}
+	return nil
+}
+
+// Bucket is a range of connection strengths, inclusive at both ends, and the
+// number of connections whose strength falls in it
+type Bucket struct {
+	Label string `json:"label"`
+	Min   int    `json:"min"`
+	Max   int    `json:"max"`
+	Count int64  `json:"count"`
+}
+
+// DefaultStrengthBuckets returns the weak (1-3), medium (4-7) and strong
+// (8-10) buckets
+func DefaultStrengthBuckets() []Bucket {
+	return []Bucket{
+		{Label: "weak", Min: 1, Max: 3},
+		{Label: "medium", Min: 4, Max: 7},
+		{Label: "strong", Min: 8, Max: 10},
+	}
+}
+
+// EvenStrengthBuckets splits the strengths 1-10 into count buckets whose
+// widths differ by at most one: 3 buckets are 1-3, 4-6 and 7-10
+func EvenStrengthBuckets(count int) ([]Bucket, error) {
+	span := MaxStrength - MinStrength + 1
+	if count < 1 || count > span {
+		return nil, fmt.Errorf("bucket count must be between 1 and %d, got: %d", span, count)
+	}
+
+	buckets := make([]Bucket, count)
+	for i := range buckets {
+		buckets[i] = newStrengthBucket(MinStrength+i*span/count, MinStrength+(i+1)*span/count-1)
+	}
+	return buckets, nil
+}
+
+// StrengthBucketsFromBoundaries builds buckets ending at each boundary, plus
+// a last bucket up to 10. Boundaries must increase and lie within 1-9, so
+// [3, 7] gives 1-3, 4-7 and 8-10.
+func StrengthBucketsFromBoundaries(boundaries []int) ([]Bucket, error) {
+	if len(boundaries) == 0 {
+		return nil, fmt.Errorf("at least one boundary is required")
+	}
+
+	buckets := make([]Bucket, 0, len(boundaries)+1)
+	low := MinStrength
+	for _, boundary := range boundaries {
+		if boundary < low || boundary >= MaxStrength {
+			return nil, fmt.Errorf("boundaries must increase within %d-%d, got: %v", MinStrength, MaxStrength-1, boundaries)
+		}
+		buckets = append(buckets, newStrengthBucket(low, boundary))
+		low = boundary + 1
+	}
+	return append(buckets, newStrengthBucket(low, MaxStrength)), nil
+}
+
+// ValidateStrengthBuckets checks that every bucket is a non-empty range
+// within 1-10 and that no two buckets overlap
+func ValidateStrengthBuckets(buckets []Bucket) error {
+	if len(buckets) == 0 {
+		return fmt.Errorf("at least one bucket is required")
+	}
+
+	taken := make(map[int]string)
+	for _, b := range buckets {
+		if b.Min < MinStrength || b.Max > MaxStrength || b.Min > b.Max {
+			return fmt.Errorf("bucket %s must cover a range within %d-%d, got: %d-%d", b.Label, MinStrength, MaxStrength, b.Min, b.Max)
+		}
+		for strength := b.Min; strength <= b.Max; strength++ {
+			if other, ok := taken[strength]; ok {
+				return fmt.Errorf("buckets %s and %s both cover strength %d", other, b.Label, strength)
+			}
+			taken[strength] = b.Label
+		}
+	}
+	return nil
+}
+
+// newStrengthBucket returns an empty bucket labelled with its range
+func newStrengthBucket(low, high int) Bucket {
+	label := fmt.Sprintf("%d-%d", low, high)
+	if low == high {
+		label = fmt.Sprint(low)
+	}
+	return Bucket{Label: label, Min: low, Max: high}
+}
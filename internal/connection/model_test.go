@@ -0,0 +1,256 @@
+package connection_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+func TestParseEdgeMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    connection.EdgeMode
+		wantErr bool
+	}{
+		{name: "empty defaults to unique", input: "", want: connection.EdgeModeUnique},
+		{name: "unique", input: "unique", want: connection.EdgeModeUnique},
+		{name: "multi ignoring case and spaces", input: " Multi ", want: connection.EdgeModeMulti},
+		{name: "unknown", input: "parallel", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := connection.ParseEdgeMode(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "must be unique or multi")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNormalizeConnectionType(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		want      string
+		wantValid bool
+	}{
+		{name: "canonical", input: "relates_to", want: "relates_to", wantValid: true},
+		{name: "mixed case", input: "Relates_To", want: "relates_to", wantValid: true},
+		{name: "padded", input: " relates_to ", want: "relates_to", wantValid: true},
+		{name: "upper case with tabs", input: "\tSUPPORTS\n", want: "supports", wantValid: true},
+		{name: "blank", input: "   ", want: ""},
+		{name: "unknown stays invalid", input: " Agrees_With ", want: "agrees_with"},
+		{name: "inner spaces are kept", input: "relates to", want: "relates to"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := connection.NormalizeConnectionType(tt.input)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantValid, connection.IsValidConnectionType(got))
+		})
+	}
+}
+
+func TestMatrixSymmetrize(t *testing.T) {
+	tests := []struct {
+		name   string
+		values [][]int
+		want   [][]int
+	}{
+		{name: "empty", values: [][]int{}, want: [][]int{}},
+		{name: "one direction is mirrored", values: [][]int{{0, 4}, {0, 0}}, want: [][]int{{0, 4}, {4, 0}}},
+		{name: "stronger direction wins", values: [][]int{{0, 4}, {9, 0}}, want: [][]int{{0, 9}, {9, 0}}},
+		{
+			name:   "diagonal is kept",
+			values: [][]int{{2, 0, 5}, {0, 0, 0}, {1, 3, 0}},
+			want:   [][]int{{2, 0, 5}, {0, 0, 3}, {5, 3, 0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := connection.Matrix{Values: tt.values}
+			m.Symmetrize()
+			assert.Equal(t, tt.want, m.Values)
+		})
+	}
+}
+
+func TestEvenStrengthBuckets(t *testing.T) {
+	tests := []struct {
+		name    string
+		count   int
+		want    []connection.Bucket
+		wantErr bool
+	}{
+		{name: "one bucket", count: 1, want: []connection.Bucket{{Label: "1-10", Min: 1, Max: 10}}},
+		{name: "even split", count: 2, want: []connection.Bucket{{Label: "1-5", Min: 1, Max: 5}, {Label: "6-10", Min: 6, Max: 10}}},
+		{
+			name:  "uneven split",
+			count: 3,
+			want:  []connection.Bucket{{Label: "1-3", Min: 1, Max: 3}, {Label: "4-6", Min: 4, Max: 6}, {Label: "7-10", Min: 7, Max: 10}},
+		},
+		{
+			name:  "four buckets",
+			count: 4,
+			want: []connection.Bucket{
+				{Label: "1-2", Min: 1, Max: 2}, {Label: "3-5", Min: 3, Max: 5},
+				{Label: "6-7", Min: 6, Max: 7}, {Label: "8-10", Min: 8, Max: 10},
+			},
+		},
+		{name: "zero", count: 0, wantErr: true},
+		{name: "more buckets than strengths", count: 11, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := connection.EvenStrengthBuckets(tt.count)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "bucket count must be between 1 and 10")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("ten buckets hold one strength each", func(t *testing.T) {
+		got, err := connection.EvenStrengthBuckets(10)
+		require.NoError(t, err)
+		require.Len(t, got, 10)
+		for i, b := range got {
+			assert.Equal(t, connection.Bucket{Label: fmt.Sprint(i + 1), Min: i + 1, Max: i + 1}, b)
+		}
+	})
+}
+
+func TestStrengthBucketsFromBoundaries(t *testing.T) {
+	tests := []struct {
+		name       string
+		boundaries []int
+		want       []connection.Bucket
+		wantErr    string
+	}{
+		{
+			name:       "matches the default ranges",
+			boundaries: []int{3, 7},
+			want:       []connection.Bucket{{Label: "1-3", Min: 1, Max: 3}, {Label: "4-7", Min: 4, Max: 7}, {Label: "8-10", Min: 8, Max: 10}},
+		},
+		{
+			name:       "single strength buckets",
+			boundaries: []int{1, 9},
+			want:       []connection.Bucket{{Label: "1", Min: 1, Max: 1}, {Label: "2-9", Min: 2, Max: 9}, {Label: "10", Min: 10, Max: 10}},
+		},
+		{name: "none", boundaries: nil, wantErr: "at least one boundary is required"},
+		{name: "not increasing", boundaries: []int{5, 5}, wantErr: "boundaries must increase"},
+		{name: "decreasing", boundaries: []int{7, 3}, wantErr: "boundaries must increase"},
+		{name: "too high", boundaries: []int{10}, wantErr: "boundaries must increase within 1-9"},
+		{name: "too low", boundaries: []int{0}, wantErr: "boundaries must increase within 1-9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := connection.StrengthBucketsFromBoundaries(tt.boundaries)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, connection.ValidateStrengthBuckets(got))
+		})
+	}
+}
+
+func TestValidateStrengthBuckets(t *testing.T) {
+	tests := []struct {
+		name    string
+		buckets []connection.Bucket
+		wantErr string
+	}{
+		{name: "defaults", buckets: connection.DefaultStrengthBuckets()},
+		{name: "gaps are allowed", buckets: []connection.Bucket{{Label: "low", Min: 1, Max: 2}, {Label: "top", Min: 10, Max: 10}}},
+		{name: "empty", buckets: nil, wantErr: "at least one bucket is required"},
+		{name: "reversed range", buckets: []connection.Bucket{{Label: "odd", Min: 5, Max: 4}}, wantErr: "bucket odd must cover a range within 1-10"},
+		{name: "out of range", buckets: []connection.Bucket{{Label: "big", Min: 8, Max: 11}}, wantErr: "got: 8-11"},
+		{
+			name:    "overlap",
+			buckets: []connection.Bucket{{Label: "weak", Min: 1, Max: 4}, {Label: "medium", Min: 4, Max: 7}},
+			wantErr: "buckets weak and medium both cover strength 4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := connection.ValidateStrengthBuckets(tt.buckets)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateStrength(t *testing.T) {
+	tests := []struct {
+		name     string
+		strength int
+		wantErr  string
+	}{
+		{name: "minimum", strength: connection.MinStrength},
+		{name: "maximum", strength: connection.MaxStrength},
+		{name: "below the minimum", strength: connection.MinStrength - 1, wantErr: "strength must be between 1 and 10, got: 0"},
+		{name: "above the maximum", strength: connection.MaxStrength + 1, wantErr: "strength must be between 1 and 10, got: 11"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := connection.ValidateStrength(tt.strength)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestStrengthWeightsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights connection.StrengthWeights
+		wantErr string
+	}{
+		{name: "defaults", weights: connection.DefaultStrengthWeights()},
+		{name: "one signal", weights: connection.StrengthWeights{Content: 0.5}},
+		{name: "negative", weights: connection.StrengthWeights{Tags: 1, Neighbors: -1}, wantErr: "must not be negative"},
+		{name: "all zero", weights: connection.StrengthWeights{}, wantErr: "at least one strength weight must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.weights.Validate()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
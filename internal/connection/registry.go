@@ -0,0 +1,141 @@
+package connection
+
+// ConnectionTypeInfo describes the semantics of a connection type
+type ConnectionTypeInfo struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Symmetric   bool   `json:"symmetric"`
+	// AllowSelfLoop permits connections from a note to itself
+	AllowSelfLoop bool `json:"allow_self_loop"`
+	// Inverse names the type that states the same relationship with the notes
+	// swapped, so A precedes B says the same as B follows A
+	Inverse string `json:"inverse,omitempty"`
+}
+
+// Directionality describes whether a connection type reads the same both ways
+type Directionality string
+
+const (
+	// DirectionalitySymmetric types imply the reverse connection, e.g. A similar_to B
+	DirectionalitySymmetric Directionality = "symmetric"
+	// DirectionalityDirected types only hold from the source to the target, e.g. A cites B
+	DirectionalityDirected Directionality = "directed"
+)
+
+// builtinConnectionTypes holds the semantics of the connection types shipped with the server
+var builtinConnectionTypes = []ConnectionTypeInfo{
+	{
+		Type:          string(ConnectionTypeRelatesTo),
+		Description:   "General relationship between two notes when no more specific type applies",
+		Symmetric:     true,
+		AllowSelfLoop: true,
+	},
+	{
+		Type:          string(ConnectionTypeReferences),
+		Description:   "Source note mentions or points to the target note",
+		Symmetric:     false,
+		AllowSelfLoop: true,
+	},
+	{
+		Type:        string(ConnectionTypeSupports),
+		Description: "Source note provides evidence or arguments in favor of the target note",
+		Symmetric:   false,
+	},
+	{
+		Type:        string(ConnectionTypeContradicts),
+		Description: "The two notes make claims that conflict with each other",
+		Symmetric:   true,
+	},
+	{
+		Type:        string(ConnectionTypeInfluences),
+		Description: "Source note shaped or affected the ideas in the target note",
+		Symmetric:   false,
+	},
+	{
+		Type:        string(ConnectionTypeDependsOn),
+		Description: "Source note requires the target note to be understood or to hold",
+		Symmetric:   false,
+	},
+	{
+		Type:          string(ConnectionTypeSimilarTo),
+		Description:   "The two notes cover closely related or overlapping ideas",
+		Symmetric:     true,
+		AllowSelfLoop: true,
+	},
+	{
+		Type:        string(ConnectionTypePartOf),
+		Description: "Source note is a component or subtopic of the target note",
+		Symmetric:   false,
+	},
+	{
+		Type:        string(ConnectionTypeCites),
+		Description: "Source note formally cites the target note as a source",
+		Symmetric:   false,
+	},
+	{
+		Type:        string(ConnectionTypeFollows),
+		Description: "Source note comes after the target note in a sequence",
+		Symmetric:   false,
+		Inverse:     string(ConnectionTypePrecedes),
+	},
+	{
+		Type:        string(ConnectionTypePrecedes),
+		Description: "Source note comes before the target note in a sequence",
+		Symmetric:   false,
+		Inverse:     string(ConnectionTypeFollows),
+	},
+}
+
+// connectionTypeRegistry holds the active set of connection types. It defaults to
+// the built-in types and can be replaced at startup with Configure. The order of
+// entries defines the order returned by ValidConnectionTypes.
+var connectionTypeRegistry = builtinConnectionTypes
+
+// ConnectionTypes returns the semantics of all valid connection types
+func ConnectionTypes() []ConnectionTypeInfo {
+	types := make([]ConnectionTypeInfo, len(connectionTypeRegistry))
+	copy(types, connectionTypeRegistry)
+	return types
+}
+
+// GetConnectionTypeInfo returns the semantics of the given connection type
+func GetConnectionTypeInfo(connectionType string) (ConnectionTypeInfo, bool) {
+	for _, info := range connectionTypeRegistry {
+		if info.Type == connectionType {
+			return info, true
+		}
+	}
+	return ConnectionTypeInfo{}, false
+}
+
+// IsSymmetricConnectionType checks if A->B of the given type implies B->A
+func IsSymmetricConnectionType(connectionType string) bool {
+	info, ok := GetConnectionTypeInfo(connectionType)
+	return ok && info.Symmetric
+}
+
+// AllowsSelfLoop checks if a note may be connected to itself with the given type
+func AllowsSelfLoop(connectionType string) bool {
+	info, ok := GetConnectionTypeInfo(connectionType)
+	return ok && info.AllowSelfLoop
+}
+
+// Directionality reports whether the type is symmetric or directed. Unknown
+// types are directed, like custom types.
+func (t ConnectionType) Directionality() Directionality {
+	if IsSymmetricConnectionType(string(t)) {
+		return DirectionalitySymmetric
+	}
+	return DirectionalityDirected
+}
+
+// Inverse returns the type that states the same relationship with the notes
+// swapped. It reports false when the type has no inverse or the inverse is not
+// an allowed type.
+func (t ConnectionType) Inverse() (ConnectionType, bool) {
+	info, ok := GetConnectionTypeInfo(string(t))
+	if !ok || info.Inverse == "" || !IsValidConnectionType(info.Inverse) {
+		return "", false
+	}
+	return ConnectionType(info.Inverse), true
+}
@@ -0,0 +1,68 @@
+package connection_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+func TestConnectionTypeDirectionality(t *testing.T) {
+	tests := []struct {
+		connType connection.ConnectionType
+		want     connection.Directionality
+	}{
+		{connection.ConnectionTypeRelatesTo, connection.DirectionalitySymmetric},
+		{connection.ConnectionTypeContradicts, connection.DirectionalitySymmetric},
+		{connection.ConnectionTypeSimilarTo, connection.DirectionalitySymmetric},
+		{connection.ConnectionTypePrecedes, connection.DirectionalityDirected},
+		{connection.ConnectionTypeFollows, connection.DirectionalityDirected},
+		{connection.ConnectionTypeDependsOn, connection.DirectionalityDirected},
+		{connection.ConnectionTypePartOf, connection.DirectionalityDirected},
+		{connection.ConnectionTypeCites, connection.DirectionalityDirected},
+		{"unknown_type", connection.DirectionalityDirected},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.connType), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.connType.Directionality())
+		})
+	}
+}
+
+func TestConnectionTypeInverse(t *testing.T) {
+	t.Cleanup(func() {
+		require.NoError(t, connection.Configure(connection.Config{}))
+	})
+
+	tests := []struct {
+		name     string
+		cfg      connection.Config
+		connType connection.ConnectionType
+		want     connection.ConnectionType
+		wantOK   bool
+	}{
+		{name: "precedes", connType: connection.ConnectionTypePrecedes, want: connection.ConnectionTypeFollows, wantOK: true},
+		{name: "follows", connType: connection.ConnectionTypeFollows, want: connection.ConnectionTypePrecedes, wantOK: true},
+		{name: "directed type without an inverse", connType: connection.ConnectionTypePartOf},
+		{name: "symmetric type", connType: connection.ConnectionTypeSimilarTo},
+		{name: "unknown type", connType: "unknown_type"},
+		{
+			name:     "inverse not allowed",
+			cfg:      connection.Config{AllowedTypes: []string{"precedes", "cites"}},
+			connType: connection.ConnectionTypePrecedes,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, connection.Configure(tt.cfg))
+
+			got, ok := tt.connType.Inverse()
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+// FindContradictions finds note pairs linked by both a supports and a contradicts
+// connection, in either direction. Each pair is reported once with the lower
+// note ID as NoteAID. The self-join covers the whole graph, so the graph is
+// checked against the storage's GraphLimits first.
+func (s *Storage) FindContradictions(ctx context.Context) (_ []connection.ContradictionPair, err error) {
+	ctx, cancel, err := s.guardGraph(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	defer func() {
+		err = s.graphError(ctx, err)
+	}()
+
+	query := `
+		SELECT s.id, s.from_note_id, s.to_note_id, c.id
+		FROM connections s
+		JOIN connections c
+			ON (s.from_note_id = c.from_note_id AND s.to_note_id = c.to_note_id)
+			OR (s.from_note_id = c.to_note_id AND s.to_note_id = c.from_note_id)
+		WHERE s.type = ? AND c.type = ?
+		ORDER BY s.id, c.id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, string(connection.ConnectionTypeSupports), string(connection.ConnectionTypeContradicts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find contradictions: %w", err)
+	}
+	defer rows.Close()
+
+	type notePair struct{ a, b int64 }
+	pairs := []connection.ContradictionPair{}
+	index := make(map[notePair]int)
+	for rows.Next() {
+		var supportsID, fromNoteID, toNoteID, contradictsID int64
+		if err := rows.Scan(&supportsID, &fromNoteID, &toNoteID, &contradictsID); err != nil {
+			return nil, fmt.Errorf("failed to scan contradiction: %w", err)
+		}
+
+		key := notePair{a: fromNoteID, b: toNoteID}
+		if key.a > key.b {
+			key.a, key.b = key.b, key.a
+		}
+
+		i, ok := index[key]
+		if !ok {
+			i = len(pairs)
+			index[key] = i
+			pairs = append(pairs, connection.ContradictionPair{NoteAID: key.a, NoteBID: key.b})
+		}
+		pairs[i].SupportsConnectionIDs = appendUnique(pairs[i].SupportsConnectionIDs, supportsID)
+		pairs[i].ContradictsConnectionIDs = appendUnique(pairs[i].ContradictsConnectionIDs, contradictsID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return pairs, nil
+}
+
+// FindReciprocalConnections finds note pairs connected in both directions,
+// A->B and B->A, with any types. Each pair is reported once with the lower
+// note ID as NoteAID and lists every connection in each direction.
+// Self-connections are not reciprocal and are skipped. Like FindContradictions,
+// it is bounded by the storage's GraphLimits.
+func (s *Storage) FindReciprocalConnections(ctx context.Context) (_ []connection.ReciprocalPair, err error) {
+	ctx, cancel, err := s.guardGraph(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	defer func() {
+		err = s.graphError(ctx, err)
+	}()
+
+	query := `
+		SELECT f.from_note_id, f.to_note_id, f.id, f.type, b.id, b.type
+		FROM connections f
+		JOIN connections b
+			ON b.from_note_id = f.to_note_id AND b.to_note_id = f.from_note_id
+		WHERE f.from_note_id < f.to_note_id
+		ORDER BY f.from_note_id, f.to_note_id, f.id, b.id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reciprocal connections: %w", err)
+	}
+	defer rows.Close()
+
+	type notePair struct{ a, b int64 }
+	pairs := []connection.ReciprocalPair{}
+	index := make(map[notePair]int)
+	for rows.Next() {
+		var key notePair
+		var forward, backward connection.ConnectionRef
+		if err := rows.Scan(&key.a, &key.b, &forward.ID, &forward.Type, &backward.ID, &backward.Type); err != nil {
+			return nil, fmt.Errorf("failed to scan reciprocal connection: %w", err)
+		}
+
+		i, ok := index[key]
+		if !ok {
+			i = len(pairs)
+			index[key] = i
+			pairs = append(pairs, connection.ReciprocalPair{NoteAID: key.a, NoteBID: key.b})
+		}
+		pairs[i].AToB = appendUniqueRef(pairs[i].AToB, forward)
+		pairs[i].BToA = appendUniqueRef(pairs[i].BToA, backward)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return pairs, nil
+}
+
+// FindOrphanNotes finds notes that have no incoming or outgoing connections,
+// ordered by note ID
+func (s *Storage) FindOrphanNotes(ctx context.Context, limit, offset int) (*connection.OrphanNotesResponse, error) {
+	const orphanClause = `
+		FROM notes n
+		WHERE NOT EXISTS (
+			SELECT 1 FROM connections c
+			WHERE c.from_note_id = n.id OR c.to_note_id = n.id
+		)
+	`
+
+	var total int64
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) "+orphanClause).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count orphan notes: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, "SELECT n.id "+orphanClause+" ORDER BY n.id LIMIT ? OFFSET ?", limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find orphan notes: %w", err)
+	}
+	defer rows.Close()
+
+	noteIDs := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan orphan note: %w", err)
+		}
+		noteIDs = append(noteIDs, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return &connection.OrphanNotesResponse{
+		NoteIDs: noteIDs,
+		Total:   total,
+	}, nil
+}
+
+// appendUnique appends id to ids unless it is already present
+func appendUnique(ids []int64, id int64) []int64 {
+	for _, existing := range ids {
+		if existing == id {
+			return ids
+		}
+	}
+	return append(ids, id)
+}
+
+// appendUniqueRef appends ref to refs unless a connection with its ID is already present
+func appendUniqueRef(refs []connection.ConnectionRef, ref connection.ConnectionRef) []connection.ConnectionRef {
+	for _, existing := range refs {
+		if existing.ID == ref.ID {
+			return refs
+		}
+	}
+	return append(refs, ref)
+}
@@ -0,0 +1,134 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+func TestStorage_FindContradictions(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+	t.Run("no contradictions", func(t *testing.T) {
+		pairs, err := storage.FindContradictions(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, pairs)
+	})
+
+	create := func(from, to int64, connType string) int64 {
+		conn, err := storage.Create(ctx, connection.CreateConnectionRequest{
+			FromNoteID: from, ToNoteID: to, Type: connType, Strength: 5,
+		})
+		require.NoError(t, err)
+		return conn.ID
+	}
+
+	// note1/note2: supports in one direction, contradicts in the other
+	supports12 := create(note1ID, note2ID, "supports")
+	contradicts21 := create(note2ID, note1ID, "contradicts")
+	supports21 := create(note2ID, note1ID, "supports")
+	// note1/note3: only supports, no conflict
+	create(note1ID, note3ID, "supports")
+	// note2/note3: same direction conflict
+	supports23 := create(note2ID, note3ID, "supports")
+	contradicts23 := create(note2ID, note3ID, "contradicts")
+
+	pairs, err := storage.FindContradictions(ctx)
+	require.NoError(t, err)
+	require.Len(t, pairs, 2)
+
+	byPair := make(map[[2]int64]connection.ContradictionPair)
+	for _, p := range pairs {
+		assert.Less(t, p.NoteAID, p.NoteBID)
+		byPair[[2]int64{p.NoteAID, p.NoteBID}] = p
+	}
+
+	p12 := byPair[[2]int64{note1ID, note2ID}]
+	assert.ElementsMatch(t, []int64{supports12, supports21}, p12.SupportsConnectionIDs)
+	assert.ElementsMatch(t, []int64{contradicts21}, p12.ContradictsConnectionIDs)
+
+	p23 := byPair[[2]int64{note2ID, note3ID}]
+	assert.ElementsMatch(t, []int64{supports23}, p23.SupportsConnectionIDs)
+	assert.ElementsMatch(t, []int64{contradicts23}, p23.ContradictsConnectionIDs)
+}
+
+func TestStorage_FindReciprocalConnections(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+	create := func(from, to int64, connType string) connection.ConnectionRef {
+		conn, err := storage.Create(ctx, connection.CreateConnectionRequest{
+			FromNoteID: from, ToNoteID: to, Type: connType, Strength: 5,
+		})
+		require.NoError(t, err)
+		return connection.ConnectionRef{ID: conn.ID, Type: connType}
+	}
+
+	t.Run("no reciprocal connections", func(t *testing.T) {
+		create(note1ID, note3ID, "supports")
+		// A self-connection points both ways but is not a reciprocal pair
+		create(note1ID, note1ID, "references")
+
+		pairs, err := storage.FindReciprocalConnections(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, pairs)
+	})
+
+	// note1/note2: two types one way, one type back
+	supports12 := create(note1ID, note2ID, "supports")
+	cites12 := create(note1ID, note2ID, "cites")
+	references21 := create(note2ID, note1ID, "references")
+	// note2/note3: created from the higher ID first
+	dependsOn32 := create(note3ID, note2ID, "depends_on")
+	partOf23 := create(note2ID, note3ID, "part_of")
+
+	pairs, err := storage.FindReciprocalConnections(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []connection.ReciprocalPair{
+		{
+			NoteAID: note1ID,
+			NoteBID: note2ID,
+			AToB:    []connection.ConnectionRef{supports12, cites12},
+			BToA:    []connection.ConnectionRef{references21},
+		},
+		{
+			NoteAID: note2ID,
+			NoteBID: note3ID,
+			AToB:    []connection.ConnectionRef{partOf23},
+			BToA:    []connection.ConnectionRef{dependsOn32},
+		},
+	}, pairs)
+}
+
+func TestStorage_FindOrphanNotes(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+	resp, err := storage.FindOrphanNotes(ctx, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{note1ID, note2ID, note3ID}, resp.NoteIDs)
+	assert.Equal(t, int64(3), resp.Total)
+
+	_, err = storage.Create(ctx, connection.CreateConnectionRequest{
+		FromNoteID: note1ID, ToNoteID: note2ID, Type: "references", Strength: 5,
+	})
+	require.NoError(t, err)
+
+	resp, err = storage.FindOrphanNotes(ctx, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{note3ID}, resp.NoteIDs)
+	assert.Equal(t, int64(1), resp.Total)
+
+	resp, err = storage.FindOrphanNotes(ctx, 10, 1)
+	require.NoError(t, err)
+	assert.Empty(t, resp.NoteIDs)
+	assert.Equal(t, int64(1), resp.Total)
+}
@@ -0,0 +1,128 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+// auditChange is the old and new value of a field in an update entry
+type auditChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// GetConnectionAudit returns the audit entries of a connection, oldest first.
+// Entries outlive the connection, so the history of a deleted connection can
+// still be read. It returns connection.ErrAuditDisabled unless the storage was
+// created WithAudit.
+func (s *Storage) GetConnectionAudit(ctx context.Context, connectionID int64) ([]connection.AuditEntry, error) {
+	if !s.audit {
+		return nil, connection.ErrAuditDisabled
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, connection_id, action, changes, created_at
+		FROM connection_audit
+		WHERE connection_id = ?
+		ORDER BY id
+	`, connectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection audit: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []connection.AuditEntry{}
+	for rows.Next() {
+		var entry connection.AuditEntry
+		var changesJSON string
+		if err := rows.Scan(&entry.ID, &entry.ConnectionID, &entry.Action, &changesJSON, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		if err := json.Unmarshal([]byte(changesJSON), &entry.Changes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal audit changes: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return entries, nil
+}
+
+// recordAudit writes an audit entry through db, which should be the
+// transaction making the change
+func recordAudit(ctx context.Context, db execer, connectionID int64, action connection.AuditAction, changes map[string]interface{}) error {
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit changes: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx,
+		"INSERT INTO connection_audit (connection_id, action, changes) VALUES (?, ?, ?)",
+		connectionID, string(action), string(changesJSON),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record connection audit: %w", err)
+	}
+	return nil
+}
+
+// auditSnapshot returns the audited fields of conn
+func auditSnapshot(conn *connection.Connection) map[string]interface{} {
+	var description, source interface{}
+	if conn.Description != nil {
+		description = *conn.Description
+	}
+	if conn.Source != nil {
+		source = *conn.Source
+	}
+
+	return map[string]interface{}{
+		"from_note_id": conn.FromNoteID,
+		"to_note_id":   conn.ToNoteID,
+		"type":         conn.Type,
+		"strength":     conn.Strength,
+		"description":  description,
+		"metadata":     conn.Metadata,
+		"source":       source,
+	}
+}
+
+// auditChanges returns the old and new value of every audited field that
+// differs between before and after
+func auditChanges(before, after *connection.Connection) map[string]interface{} {
+	old, updated := auditSnapshot(before), auditSnapshot(after)
+
+	changes := make(map[string]interface{})
+	for field, value := range updated {
+		if !reflect.DeepEqual(old[field], value) {
+			changes[field] = auditChange{Old: old[field], New: value}
+		}
+	}
+	return changes
+}
+
+// queryIDs runs a query returning a single ID column through db
+func queryIDs(ctx context.Context, db execer, query string, args ...interface{}) ([]int64, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
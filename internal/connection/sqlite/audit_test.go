@@ -0,0 +1,197 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+func TestStorage_GetConnectionAudit(t *testing.T) {
+	ctx := context.Background()
+
+	// actions returns the recorded actions of a connection in order
+	actions := func(t *testing.T, entries []connection.AuditEntry) []connection.AuditAction {
+		t.Helper()
+		got := make([]connection.AuditAction, len(entries))
+		for i, entry := range entries {
+			got[i] = entry.Action
+		}
+		return got
+	}
+
+	tests := []struct {
+		name        string
+		change      func(t *testing.T, storage *Storage, id, note1ID, note2ID int64)
+		wantActions []connection.AuditAction
+		wantLast    map[string]interface{}
+	}{
+		{
+			name:        "create records every field",
+			change:      func(*testing.T, *Storage, int64, int64, int64) {},
+			wantActions: []connection.AuditAction{connection.AuditActionCreate},
+			wantLast: map[string]interface{}{
+				"type":        "supports",
+				"strength":    float64(5),
+				"description": "initial",
+				"metadata":    map[string]interface{}{"origin": "test"},
+				"source":      nil,
+			},
+		},
+		{
+			name: "update records only changed fields",
+			change: func(t *testing.T, storage *Storage, id, _, _ int64) {
+				_, err := storage.Update(ctx, id, connection.UpdateConnectionRequest{Strength: intPtr(8), Description: strPtr("initial")})
+				require.NoError(t, err)
+			},
+			wantActions: []connection.AuditAction{connection.AuditActionCreate, connection.AuditActionUpdate},
+			wantLast: map[string]interface{}{
+				"strength": map[string]interface{}{"old": float64(5), "new": float64(8)},
+			},
+		},
+		{
+			name: "update without changes is not recorded",
+			change: func(t *testing.T, storage *Storage, id, _, _ int64) {
+				_, err := storage.Update(ctx, id, connection.UpdateConnectionRequest{Type: strPtr("supports")})
+				require.NoError(t, err)
+			},
+			wantActions: []connection.AuditAction{connection.AuditActionCreate},
+		},
+		{
+			name: "touch is not recorded",
+			change: func(t *testing.T, storage *Storage, id, _, _ int64) {
+				require.NoError(t, storage.Touch(ctx, id))
+			},
+			wantActions: []connection.AuditAction{connection.AuditActionCreate},
+		},
+		{
+			name: "delete keeps the history",
+			change: func(t *testing.T, storage *Storage, id, _, _ int64) {
+				require.NoError(t, storage.Delete(ctx, id))
+			},
+			wantActions: []connection.AuditAction{connection.AuditActionCreate, connection.AuditActionDelete},
+			wantLast: map[string]interface{}{
+				"type":     "supports",
+				"strength": float64(5),
+			},
+		},
+		{
+			name: "delete between",
+			change: func(t *testing.T, storage *Storage, _, note1ID, note2ID int64) {
+				deleted, err := storage.DeleteBetween(ctx, note2ID, note1ID, nil)
+				require.NoError(t, err)
+				require.Equal(t, int64(1), deleted)
+			},
+			wantActions: []connection.AuditAction{connection.AuditActionCreate, connection.AuditActionDelete},
+		},
+		{
+			name: "retype",
+			change: func(t *testing.T, storage *Storage, _, _, _ int64) {
+				_, err := storage.RetypeConnections(ctx, "supports", "cites")
+				require.NoError(t, err)
+			},
+			wantActions: []connection.AuditAction{connection.AuditActionCreate, connection.AuditActionUpdate},
+			wantLast: map[string]interface{}{
+				"type": map[string]interface{}{"old": "supports", "new": "cites"},
+			},
+		},
+		{
+			name: "strength normalization",
+			change: func(t *testing.T, storage *Storage, _, note1ID, _ int64) {
+				// A second strength gives min-max normalization a range to rescale
+				_, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: note1ID, ToNoteID: note1ID, Type: "references", Strength: 7})
+				require.NoError(t, err)
+				_, err = storage.NormalizeStrengths(ctx, connection.NormalizeStrengthsOptions{})
+				require.NoError(t, err)
+			},
+			wantActions: []connection.AuditAction{connection.AuditActionCreate, connection.AuditActionUpdate},
+			wantLast: map[string]interface{}{
+				"strength": map[string]interface{}{"old": float64(5), "new": float64(1)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newTestStorage(t, WithAudit(true))
+			note1ID, note2ID, _ := createTestNotes(t, storage.db)
+
+			conn, err := storage.Create(ctx, connection.CreateConnectionRequest{
+				FromNoteID:  note1ID,
+				ToNoteID:    note2ID,
+				Type:        "supports",
+				Strength:    5,
+				Description: strPtr("initial"),
+				Metadata:    map[string]interface{}{"origin": "test"},
+			})
+			require.NoError(t, err)
+
+			tt.change(t, storage, conn.ID, note1ID, note2ID)
+
+			entries, err := storage.GetConnectionAudit(ctx, conn.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantActions, actions(t, entries))
+			for _, entry := range entries {
+				assert.Equal(t, conn.ID, entry.ConnectionID)
+				assert.False(t, entry.CreatedAt.IsZero())
+			}
+
+			last := entries[len(entries)-1].Changes
+			if entries[len(entries)-1].Action == connection.AuditActionUpdate {
+				assert.Equal(t, tt.wantLast, last)
+				return
+			}
+			for field, want := range tt.wantLast {
+				assert.Equal(t, want, last[field], field)
+			}
+		})
+	}
+
+	t.Run("failed change records nothing", func(t *testing.T) {
+		storage := newTestStorage(t, WithAudit(true))
+		note1ID, note2ID, _ := createTestNotes(t, storage.db)
+
+		_, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: note1ID, ToNoteID: 99999, Type: "supports", Strength: 5})
+		require.Error(t, err)
+
+		var count int
+		require.NoError(t, storage.db.QueryRow("SELECT COUNT(*) FROM connection_audit").Scan(&count))
+		assert.Zero(t, count)
+
+		conn, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 5})
+		require.NoError(t, err)
+		_, err = storage.Update(ctx, conn.ID, connection.UpdateConnectionRequest{Strength: intPtr(11)})
+		require.Error(t, err)
+
+		entries, err := storage.GetConnectionAudit(ctx, conn.ID)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("unknown connection has no history", func(t *testing.T) {
+		storage := newTestStorage(t, WithAudit(true))
+
+		entries, err := storage.GetConnectionAudit(ctx, 12345)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		storage := newTestStorage(t)
+		note1ID, note2ID, _ := createTestNotes(t, storage.db)
+
+		conn, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 5})
+		require.NoError(t, err)
+		require.NoError(t, storage.Delete(ctx, conn.ID))
+
+		_, err = storage.GetConnectionAudit(ctx, conn.ID)
+		assert.ErrorIs(t, err, connection.ErrAuditDisabled)
+
+		var count int
+		require.NoError(t, storage.db.QueryRow("SELECT COUNT(*) FROM connection_audit").Scan(&count))
+		assert.Zero(t, count)
+	})
+}
@@ -0,0 +1,108 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+// CreateByTitle resolves both titles to note IDs and creates the connection in
+// the same transaction. A title matches exactly first; otherwise it matches
+// ignoring case and surrounding whitespace, which fails with ErrAmbiguousTitle
+// when several notes qualify.
+func (s *Storage) CreateByTitle(ctx context.Context, req connection.CreateByTitleRequest) (*connection.Connection, error) {
+	var result *connection.Connection
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.createByTitle(ctx, req)
+		return err
+	})
+	return result, err
+}
+
+// createByTitle makes a single attempt at CreateByTitle
+func (s *Storage) createByTitle(ctx context.Context, req connection.CreateByTitleRequest) (*connection.Connection, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	fromNoteID, err := resolveNoteTitle(ctx, tx, req.FromTitle)
+	if err != nil {
+		return nil, fmt.Errorf("from_title: %w", err)
+	}
+	toNoteID, err := resolveNoteTitle(ctx, tx, req.ToTitle)
+	if err != nil {
+		return nil, fmt.Errorf("to_title: %w", err)
+	}
+
+	id, err := s.insertConnection(ctx, tx, connection.CreateConnectionRequest{
+		FromNoteID:    fromNoteID,
+		ToNoteID:      toNoteID,
+		Type:          req.Type,
+		Description:   req.Description,
+		Strength:      req.Strength,
+		Metadata:      req.Metadata,
+		Source:        req.Source,
+		AutoSymmetric: req.AutoSymmetric,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.Get(ctx, id)
+}
+
+// resolveNoteTitle returns the ID of the note with the given title
+func resolveNoteTitle(ctx context.Context, tx *sql.Tx, title string) (int64, error) {
+	if strings.TrimSpace(title) == "" {
+		return 0, fmt.Errorf("title is required")
+	}
+
+	// Titles are unique, so an exact match is never ambiguous
+	var id int64
+	err := tx.QueryRowContext(ctx, "SELECT id FROM notes WHERE title = ?", title).Scan(&id)
+	if err == nil {
+		return id, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("failed to look up note title: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx,
+		"SELECT id FROM notes WHERE lower(trim(title)) = lower(?) ORDER BY id",
+		strings.TrimSpace(title))
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up note title: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []int64
+	for rows.Next() {
+		if err := rows.Scan(&id); err != nil {
+			return 0, fmt.Errorf("failed to scan note ID: %w", err)
+		}
+		candidates = append(candidates, id)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	switch len(candidates) {
+	case 0:
+		return 0, fmt.Errorf("%w: %q", connection.ErrNoteTitleNotFound, title)
+	case 1:
+		return candidates[0], nil
+	default:
+		return 0, fmt.Errorf("%w: %q matches notes %v", connection.ErrAmbiguousTitle, title, candidates)
+	}
+}
@@ -0,0 +1,105 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+func TestStorage_CreateByTitle(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, _ := createTestNotes(t, storage.db)
+
+	// Two notes that differ only by case
+	var dupIDs []int64
+	for _, title := range []string{"Go Basics", "go basics"} {
+		result, err := storage.db.Exec(
+			"INSERT INTO notes (title, content, type, tags, metadata) VALUES (?, ?, ?, ?, ?)",
+			title, "Content", "text", "[]", "{}",
+		)
+		require.NoError(t, err)
+		id, err := result.LastInsertId()
+		require.NoError(t, err)
+		dupIDs = append(dupIDs, id)
+	}
+
+	tests := []struct {
+		name         string
+		req          connection.CreateByTitleRequest
+		wantFrom     int64
+		wantTo       int64
+		wantErrIs    error
+		wantErrMatch string
+	}{
+		{
+			name:     "exact titles",
+			req:      connection.CreateByTitleRequest{FromTitle: "Test Note 1", ToTitle: "Test Note 2", Type: "supports", Strength: 5},
+			wantFrom: note1ID,
+			wantTo:   note2ID,
+		},
+		{
+			name:     "case-insensitive fallback",
+			req:      connection.CreateByTitleRequest{FromTitle: " test note 2 ", ToTitle: "TEST NOTE 1", Type: "references", Strength: 5},
+			wantFrom: note2ID,
+			wantTo:   note1ID,
+		},
+		{
+			name:     "exact match wins over case variants",
+			req:      connection.CreateByTitleRequest{FromTitle: "go basics", ToTitle: "Test Note 1", Type: "references", Strength: 5},
+			wantFrom: dupIDs[1],
+			wantTo:   note1ID,
+		},
+		{
+			name:         "ambiguous title lists candidates",
+			req:          connection.CreateByTitleRequest{FromTitle: "GO BASICS", ToTitle: "Test Note 1", Type: "supports", Strength: 5},
+			wantErrIs:    connection.ErrAmbiguousTitle,
+			wantErrMatch: "from_title",
+		},
+		{
+			name:         "missing title",
+			req:          connection.CreateByTitleRequest{FromTitle: "Test Note 1", ToTitle: "Nowhere", Type: "supports", Strength: 5},
+			wantErrIs:    connection.ErrNoteTitleNotFound,
+			wantErrMatch: `to_title: no note has this title: "Nowhere"`,
+		},
+		{
+			name:         "same note on both ends",
+			req:          connection.CreateByTitleRequest{FromTitle: "Test Note 1", ToTitle: "test note 1", Type: "supports", Strength: 5},
+			wantErrMatch: "self-connections are not allowed",
+		},
+		{
+			name:         "invalid strength",
+			req:          connection.CreateByTitleRequest{FromTitle: "Test Note 1", ToTitle: "Test Note 3", Type: "supports", Strength: 0},
+			wantErrMatch: "strength must be between 1 and 10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, err := storage.CreateByTitle(ctx, tt.req)
+			if tt.wantErrIs != nil || tt.wantErrMatch != "" {
+				require.Error(t, err)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
+				assert.Contains(t, err.Error(), tt.wantErrMatch)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFrom, conn.FromNoteID)
+			assert.Equal(t, tt.wantTo, conn.ToNoteID)
+			assert.Equal(t, tt.req.Type, conn.Type)
+		})
+	}
+
+	t.Run("ambiguity error names the candidate IDs", func(t *testing.T) {
+		_, err := storage.CreateByTitle(ctx, connection.CreateByTitleRequest{FromTitle: "Test Note 1", ToTitle: "Go basics", Type: "supports", Strength: 5})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), fmt.Sprintf("matches notes %v", dupIDs))
+	})
+}
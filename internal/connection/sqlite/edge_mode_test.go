@@ -0,0 +1,135 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+func TestStorage_EdgeMode(t *testing.T) {
+	ctx := context.Background()
+
+	// Every case starts with one supports connection from note 1 to note 2
+	tests := []struct {
+		name       string
+		change     func(storage *Storage, existingID, note1ID, note2ID, note3ID int64) error
+		wantUnique string
+	}{
+		{
+			name: "parallel connection of the same type",
+			change: func(storage *Storage, _, note1ID, note2ID, _ int64) error {
+				_, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 3})
+				return err
+			},
+			wantUnique: "connection already exists between these notes with this type",
+		},
+		{
+			name: "reverse connection of a symmetric type",
+			change: func(storage *Storage, _, note1ID, note2ID, _ int64) error {
+				_, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: note1ID, ToNoteID: note2ID, Type: "similar_to", Strength: 3})
+				if err != nil {
+					return err
+				}
+				_, err = storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: note2ID, ToNoteID: note1ID, Type: "similar_to", Strength: 3})
+				return err
+			},
+			wantUnique: "similar_to is symmetric",
+		},
+		{
+			name: "update onto an existing type",
+			change: func(storage *Storage, _, note1ID, note2ID, _ int64) error {
+				other, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: note1ID, ToNoteID: note2ID, Type: "cites", Strength: 3})
+				if err != nil {
+					return err
+				}
+				_, err = storage.Update(ctx, other.ID, connection.UpdateConnectionRequest{Type: strPtr("supports")})
+				return err
+			},
+			wantUnique: "connection already exists between these notes with this type",
+		},
+		{
+			name: "retype onto an existing type",
+			change: func(storage *Storage, _, note1ID, note2ID, _ int64) error {
+				_, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: note1ID, ToNoteID: note2ID, Type: "cites", Strength: 3})
+				if err != nil {
+					return err
+				}
+				_, err = storage.RetypeConnections(ctx, "cites", "supports")
+				return err
+			},
+			wantUnique: "would duplicate existing supports connections",
+		},
+		{
+			name: "import of a duplicate",
+			change: func(storage *Storage, _, note1ID, note2ID, _ int64) error {
+				result, err := storage.ImportConnectionsCSV(ctx, fmt.Sprintf("from_note_id,to_note_id,type\n%d,%d,supports\n", note1ID, note2ID))
+				if err != nil {
+					return err
+				}
+				if len(result.Errors) > 0 {
+					return errors.New(result.Errors[0].Error)
+				}
+				return nil
+			},
+			wantUnique: "connection already exists between these notes with this type",
+		},
+		{
+			name: "connection to another note",
+			change: func(storage *Storage, _, note1ID, _, note3ID int64) error {
+				_, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: note1ID, ToNoteID: note3ID, Type: "supports", Strength: 3})
+				return err
+			},
+		},
+		{
+			name: "update keeping its own type",
+			change: func(storage *Storage, existingID, _, _, _ int64) error {
+				_, err := storage.Update(ctx, existingID, connection.UpdateConnectionRequest{Type: strPtr("supports"), Strength: intPtr(9)})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, mode := range []connection.EdgeMode{connection.EdgeModeUnique, connection.EdgeModeMulti} {
+			t.Run(tt.name+"/"+string(mode), func(t *testing.T) {
+				storage := newTestStorage(t, WithEdgeMode(mode))
+				note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+				existing, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 5})
+				require.NoError(t, err)
+
+				err = tt.change(storage, existing.ID, note1ID, note2ID, note3ID)
+				if mode == connection.EdgeModeUnique && tt.wantUnique != "" {
+					require.Error(t, err)
+					assert.Contains(t, err.Error(), tt.wantUnique)
+					return
+				}
+				require.NoError(t, err)
+			})
+		}
+	}
+
+	t.Run("multi mode lists parallel connections", func(t *testing.T) {
+		storage := newTestStorage(t, WithEdgeMode(connection.EdgeModeMulti))
+		note1ID, note2ID, _ := createTestNotes(t, storage.db)
+
+		for _, source := range []string{"paper-a", "paper-b"} {
+			_, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 5, Source: strPtr(source)})
+			require.NoError(t, err)
+		}
+
+		resp, err := storage.GetNoteConnections(ctx, connection.NoteConnectionsRequest{NoteID: note1ID, Limit: 10})
+		require.NoError(t, err)
+		assert.Len(t, resp.Outgoing, 2)
+
+		deleted, err := storage.DeleteBetween(ctx, note1ID, note2ID, strPtr("supports"))
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+	})
+}
@@ -0,0 +1,114 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// CreateConnectionEnsuringNotes connects the notes with the given titles,
+// first creating either note when no note has its title. Titles resolve as
+// in CreateByTitle, so a title that matches one note exactly or ignoring case
+// never creates a second note, and an ambiguous title fails rather than
+// guessing. The notes and the connection are created in one transaction.
+func (s *Storage) CreateConnectionEnsuringNotes(ctx context.Context, req connection.EnsureRequest) (*connection.Connection, error) {
+	var result *connection.Connection
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.createConnectionEnsuringNotes(ctx, req)
+		return err
+	})
+	return result, err
+}
+
+// createConnectionEnsuringNotes makes a single attempt at CreateConnectionEnsuringNotes
+func (s *Storage) createConnectionEnsuringNotes(ctx context.Context, req connection.EnsureRequest) (*connection.Connection, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// The to note is resolved after the from note is created, so the same
+	// new title on both ends names one note
+	fromNoteID, fromCreated, err := s.ensureNote(ctx, tx, req.From, req.Source)
+	if err != nil {
+		return nil, fmt.Errorf("from_title: %w", err)
+	}
+	toNoteID, toCreated, err := s.ensureNote(ctx, tx, req.To, req.Source)
+	if err != nil {
+		return nil, fmt.Errorf("to_title: %w", err)
+	}
+
+	id, err := s.insertConnection(ctx, tx, connection.CreateConnectionRequest{
+		FromNoteID:    fromNoteID,
+		ToNoteID:      toNoteID,
+		Type:          req.Type,
+		Description:   req.Description,
+		Strength:      req.Strength,
+		Metadata:      req.Metadata,
+		Source:        req.Source,
+		AutoSymmetric: req.AutoSymmetric,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if fromCreated || toCreated {
+		s.logger.Info("created notes for connection", "connection_id", id,
+			"from_note_id", fromNoteID, "from_created", fromCreated,
+			"to_note_id", toNoteID, "to_created", toCreated)
+	}
+	return s.Get(ctx, id)
+}
+
+// NoteInserter creates a note inside a transaction of the connection
+// storage. The note SQLite storage implements it.
+type NoteInserter interface {
+	InsertNote(ctx context.Context, tx *sql.Tx, req note.CreateNoteRequest) (int64, error)
+}
+
+// ensureNote returns the ID of the note titled req.Title, creating it with
+// source when no note has the title, and reports whether it was created
+func (s *Storage) ensureNote(ctx context.Context, tx *sql.Tx, req connection.EnsureNoteRequest, source *string) (int64, bool, error) {
+	id, err := resolveNoteTitle(ctx, tx, req.Title)
+	if err == nil {
+		return id, false, nil
+	}
+	if !errors.Is(err, connection.ErrNoteTitleNotFound) {
+		return 0, false, err
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if s.notes == nil {
+		return 0, false, fmt.Errorf("cannot create note %q: no note storage configured", title)
+	}
+	content := req.Content
+	if content == "" {
+		content = title
+	}
+	noteType := req.Type
+	if noteType == "" {
+		noteType = string(note.NoteTypeText)
+	}
+
+	id, err = s.notes.InsertNote(ctx, tx, note.CreateNoteRequest{
+		Title:   title,
+		Content: content,
+		Type:    noteType,
+		Source:  source,
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	return id, true, nil
+}
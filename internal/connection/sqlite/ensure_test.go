@@ -0,0 +1,280 @@
+package sqlite
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/migrations"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	notestorage "github.com/red1r3ct/knowledge-graph-mcp/internal/note/sqlite"
+)
+
+func TestStorage_CreateConnectionEnsuringNotes(t *testing.T) {
+	ctx := context.Background()
+	source := "capture-agent"
+
+	tests := []struct {
+		name         string
+		req          connection.EnsureRequest
+		noteOpts     []notestorage.Option
+		wantFrom     string // Title of the from note, or empty to skip the check
+		wantTo       string
+		wantCreated  int
+		wantErrIs    error
+		wantErrMatch string
+	}{
+		{
+			name: "both exist",
+			req: connection.EnsureRequest{
+				From: connection.EnsureNoteRequest{Title: "Test Note 1"},
+				To:   connection.EnsureNoteRequest{Title: "Test Note 2"},
+				Type: "supports", Strength: 5,
+			},
+			wantFrom: "Test Note 1",
+			wantTo:   "Test Note 2",
+		},
+		{
+			name: "one must be created",
+			req: connection.EnsureRequest{
+				From: connection.EnsureNoteRequest{Title: "Test Note 1"},
+				To:   connection.EnsureNoteRequest{Title: "New Idea", Content: "An idea worth keeping", Type: "markdown"},
+				Type: "supports", Strength: 5, Source: &source,
+			},
+			wantFrom:    "Test Note 1",
+			wantTo:      "New Idea",
+			wantCreated: 1,
+		},
+		{
+			name: "both must be created",
+			req: connection.EnsureRequest{
+				From: connection.EnsureNoteRequest{Title: "First Idea"},
+				To:   connection.EnsureNoteRequest{Title: "Second Idea"},
+				Type: "relates_to", Strength: 5,
+			},
+			wantFrom:    "First Idea",
+			wantTo:      "Second Idea",
+			wantCreated: 2,
+		},
+		{
+			name: "case-insensitive match does not create a note",
+			req: connection.EnsureRequest{
+				From: connection.EnsureNoteRequest{Title: " test note 3 "},
+				To:   connection.EnsureNoteRequest{Title: "TEST NOTE 1"},
+				Type: "references", Strength: 5,
+			},
+			wantFrom: "Test Note 3",
+			wantTo:   "Test Note 1",
+		},
+		{
+			name: "same new title on both ends creates one note",
+			req: connection.EnsureRequest{
+				From: connection.EnsureNoteRequest{Title: "Lonely Idea"},
+				To:   connection.EnsureNoteRequest{Title: "lonely idea"},
+				Type: "relates_to", Strength: 5,
+			},
+			wantFrom:    "Lonely Idea",
+			wantTo:      "Lonely Idea",
+			wantCreated: 1,
+		},
+		{
+			name: "ambiguous title creates nothing",
+			req: connection.EnsureRequest{
+				From: connection.EnsureNoteRequest{Title: "Brand New"},
+				To:   connection.EnsureNoteRequest{Title: "GO BASICS"},
+				Type: "supports", Strength: 5,
+			},
+			wantErrIs:    connection.ErrAmbiguousTitle,
+			wantErrMatch: "to_title",
+		},
+		{
+			name: "invalid note type",
+			req: connection.EnsureRequest{
+				From: connection.EnsureNoteRequest{Title: "Brand New", Type: "diagram"},
+				To:   connection.EnsureNoteRequest{Title: "Test Note 1"},
+				Type: "supports", Strength: 5,
+			},
+			wantErrMatch: "from_title: invalid note type: diagram",
+		},
+		{
+			name: "link note without a URL with URL validation",
+			req: connection.EnsureRequest{
+				From: connection.EnsureNoteRequest{Title: "Test Note 1"},
+				To:   connection.EnsureNoteRequest{Title: "Reading List", Type: "link"},
+				Type: "references", Strength: 5,
+			},
+			noteOpts:     []notestorage.Option{notestorage.WithURLValidation(true)},
+			wantErrMatch: "to_title: link note content",
+		},
+		{
+			name: "link note with a URL with URL validation",
+			req: connection.EnsureRequest{
+				From: connection.EnsureNoteRequest{Title: "Test Note 1"},
+				To:   connection.EnsureNoteRequest{Title: "Reading List", Content: "https://example.com/reading", Type: "link"},
+				Type: "references", Strength: 5,
+			},
+			noteOpts:    []notestorage.Option{notestorage.WithURLValidation(true)},
+			wantFrom:    "Test Note 1",
+			wantTo:      "Reading List",
+			wantCreated: 1,
+		},
+		{
+			name: "duplicate content with unique content",
+			req: connection.EnsureRequest{
+				From: connection.EnsureNoteRequest{Title: "Brand New", Content: "Content"},
+				To:   connection.EnsureNoteRequest{Title: "Test Note 1"},
+				Type: "supports", Strength: 5,
+			},
+			noteOpts:     []notestorage.Option{notestorage.WithUniqueContent(true)},
+			wantErrIs:    note.ErrDuplicateContent,
+			wantErrMatch: "from_title",
+		},
+		{
+			name: "invalid connection rolls back created notes",
+			req: connection.EnsureRequest{
+				From: connection.EnsureNoteRequest{Title: "Brand New"},
+				To:   connection.EnsureNoteRequest{Title: "Test Note 1"},
+				Type: "supports", Strength: 0,
+			},
+			wantErrMatch: "strength must be between 1 and 10",
+		},
+		{
+			name: "blank title",
+			req: connection.EnsureRequest{
+				From: connection.EnsureNoteRequest{Title: "  "},
+				To:   connection.EnsureNoteRequest{Title: "Test Note 1"},
+				Type: "supports", Strength: 5,
+			},
+			wantErrMatch: "from_title: title is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newEnsureTestStorage(t, tt.noteOpts...)
+			createTestNotes(t, storage.db)
+			for _, title := range []string{"Go Basics", "go basics"} {
+				_, err := storage.db.Exec(
+					"INSERT INTO notes (title, content, type, tags, metadata) VALUES (?, ?, ?, ?, ?)",
+					title, "Content", "text", "[]", "{}",
+				)
+				require.NoError(t, err)
+			}
+			notesBefore := countRows(t, storage, "notes")
+
+			conn, err := storage.CreateConnectionEnsuringNotes(ctx, tt.req)
+
+			if tt.wantErrMatch != "" {
+				require.Error(t, err)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
+				assert.Contains(t, err.Error(), tt.wantErrMatch)
+				assert.Equal(t, notesBefore, countRows(t, storage, "notes"))
+				assert.Equal(t, int64(0), countRows(t, storage, "connections"))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.req.Type, conn.Type)
+			assert.Equal(t, tt.wantFrom, noteTitle(t, storage, conn.FromNoteID))
+			assert.Equal(t, tt.wantTo, noteTitle(t, storage, conn.ToNoteID))
+			assert.Equal(t, notesBefore+int64(tt.wantCreated), countRows(t, storage, "notes"))
+		})
+	}
+
+	t.Run("created note uses the given content, type and source", func(t *testing.T) {
+		storage := newEnsureTestStorage(t)
+		createTestNotes(t, storage.db)
+
+		conn, err := storage.CreateConnectionEnsuringNotes(ctx, connection.EnsureRequest{
+			From:     connection.EnsureNoteRequest{Title: " New Idea ", Content: "An idea worth keeping", Type: "markdown"},
+			To:       connection.EnsureNoteRequest{Title: "Default Idea"},
+			Type:     "supports",
+			Strength: 5,
+			Source:   &source,
+		})
+		require.NoError(t, err)
+
+		var title, content, noteType, noteSource string
+		var contentHash *string
+		err = storage.db.QueryRow("SELECT title, content, type, source, content_hash FROM notes WHERE id = ?", conn.FromNoteID).
+			Scan(&title, &content, &noteType, &noteSource, &contentHash)
+		require.NoError(t, err)
+		assert.Equal(t, "New Idea", title)
+		assert.Equal(t, "An idea worth keeping", content)
+		assert.Equal(t, "markdown", noteType)
+		assert.Equal(t, source, noteSource)
+		assert.NotNil(t, contentHash)
+
+		// Content defaults to the title and type to text
+		err = storage.db.QueryRow("SELECT content, type FROM notes WHERE id = ?", conn.ToNoteID).Scan(&content, &noteType)
+		require.NoError(t, err)
+		assert.Equal(t, "Default Idea", content)
+		assert.Equal(t, "text", noteType)
+	})
+
+	t.Run("without a note storage only existing notes connect", func(t *testing.T) {
+		storage := newTestStorage(t)
+		createTestNotes(t, storage.db)
+
+		_, err := storage.CreateConnectionEnsuringNotes(ctx, connection.EnsureRequest{
+			From: connection.EnsureNoteRequest{Title: "Test Note 1"},
+			To:   connection.EnsureNoteRequest{Title: "Test Note 2"},
+			Type: "supports", Strength: 5,
+		})
+		require.NoError(t, err)
+
+		_, err = storage.CreateConnectionEnsuringNotes(ctx, connection.EnsureRequest{
+			From: connection.EnsureNoteRequest{Title: "Test Note 1"},
+			To:   connection.EnsureNoteRequest{Title: "Brand New"},
+			Type: "relates_to", Strength: 5,
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no note storage configured")
+	})
+}
+
+// newEnsureTestStorage returns a connection storage whose ensured notes are
+// created by a note storage with noteOpts on the same database
+func newEnsureTestStorage(t *testing.T, noteOpts ...notestorage.Option) *Storage {
+	t.Helper()
+
+	tempFile, err := os.CreateTemp("", "test-*.db")
+	require.NoError(t, err)
+	tempFile.Close()
+	t.Cleanup(func() { os.Remove(tempFile.Name()) })
+
+	notes, err := notestorage.NewStorage(tempFile.Name(), noteOpts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { notes.Close() })
+
+	storage, err := NewStorage(tempFile.Name(), WithNoteInserter(notes))
+	require.NoError(t, err)
+	t.Cleanup(func() { storage.Close() })
+
+	err = migrations.NewMigrationRunner(tempFile.Name(), migrations.WithEdgeMode(storage.edgeMode)).RunMigrations()
+	require.NoError(t, err)
+
+	return storage
+}
+
+// noteTitle returns the title of the note with the given ID
+func noteTitle(t *testing.T, storage *Storage, id int64) string {
+	t.Helper()
+	var title string
+	require.NoError(t, storage.db.QueryRow("SELECT title FROM notes WHERE id = ?", id).Scan(&title))
+	return title
+}
+
+// countRows returns the number of rows in table
+func countRows(t *testing.T, storage *Storage, table string) int64 {
+	t.Helper()
+	var count int64
+	require.NoError(t, storage.db.QueryRow("SELECT COUNT(*) FROM "+table).Scan(&count))
+	return count
+}
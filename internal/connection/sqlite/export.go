@@ -0,0 +1,128 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+// ExportConnectionsCSV exports connections as CSV with a header row, ordered by ID.
+// Note titles are joined in only when req.IncludeTitles is set. Quoting of
+// descriptions containing commas, quotes or newlines is left to encoding/csv.
+func (s *Storage) ExportConnectionsCSV(ctx context.Context, req connection.ExportRequest) (out string, err error) {
+	ctx, cancel, err := s.guardGraph(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer cancel()
+	defer func() {
+		err = s.graphError(ctx, err)
+	}()
+
+	var whereClauses []string
+	var args []interface{}
+
+	if req.Type != nil {
+		whereClauses = append(whereClauses, "c.type = ?")
+		args = append(args, *req.Type)
+	}
+
+	if req.Source != nil {
+		whereClauses = append(whereClauses, "c.source = ?")
+		args = append(args, *req.Source)
+	}
+
+	whereClause := ""
+	if len(whereClauses) > 0 {
+		whereClause = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	titleColumns := ""
+	titleJoins := ""
+	if req.IncludeTitles {
+		titleColumns = ", f.title, t.title"
+		titleJoins = "JOIN notes f ON f.id = c.from_note_id JOIN notes t ON t.id = c.to_note_id"
+	}
+
+	query := fmt.Sprintf(`
+		SELECT c.id, c.from_note_id, c.to_note_id, c.type, c.strength, c.description, c.created_at%s
+		FROM connections c
+		%s
+		%s
+		ORDER BY c.id
+	`, titleColumns, titleJoins, whereClause)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to query connections: %w", err)
+	}
+	defer rows.Close()
+
+	var buf strings.Builder
+	w := csv.NewWriter(&buf)
+
+	header := []string{"id", "from_note_id", "to_note_id"}
+	if req.IncludeTitles {
+		header = append(header, "from_title", "to_title")
+	}
+	header = append(header, "type", "strength", "description", "created_at")
+	if err := w.Write(header); err != nil {
+		return "", fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	exported := 0
+	for rows.Next() {
+		var id, fromNoteID, toNoteID int64
+		var connType string
+		var strength int
+		var description sql.NullString
+		var createdAt time.Time
+		var fromTitle, toTitle string
+
+		dest := []interface{}{&id, &fromNoteID, &toNoteID, &connType, &strength, &description, &createdAt}
+		if req.IncludeTitles {
+			dest = append(dest, &fromTitle, &toTitle)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return "", fmt.Errorf("failed to scan connection: %w", err)
+		}
+
+		record := []string{
+			strconv.FormatInt(id, 10),
+			strconv.FormatInt(fromNoteID, 10),
+			strconv.FormatInt(toNoteID, 10),
+		}
+		if req.IncludeTitles {
+			record = append(record, fromTitle, toTitle)
+		}
+		record = append(record,
+			connType,
+			strconv.Itoa(strength),
+			description.String,
+			createdAt.UTC().Format(time.RFC3339),
+		)
+		if err := w.Write(record); err != nil {
+			return "", fmt.Errorf("failed to write csv row: %w", err)
+		}
+		exported++
+	}
+
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", fmt.Errorf("failed to write csv: %w", err)
+	}
+
+	s.logger.Info("exported connections to csv", "connections", exported, "include_titles", req.IncludeTitles)
+
+	return buf.String(), nil
+}
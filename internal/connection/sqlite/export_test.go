@@ -0,0 +1,112 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/csv"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+func TestStorage_ExportConnectionsCSV(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, _ := createTestNotes(t, storage.db)
+
+	result, err := storage.db.Exec(
+		"INSERT INTO notes (title, content, type, tags, metadata) VALUES (?, ?, ?, ?, ?)",
+		`Cats, "dogs"`, "Content", "text", "[]", "{}",
+	)
+	require.NoError(t, err)
+	quotedNoteID, err := result.LastInsertId()
+	require.NoError(t, err)
+
+	plain, err := storage.Create(ctx, connection.CreateConnectionRequest{
+		FromNoteID: note1ID, ToNoteID: note2ID, Type: "references", Strength: 5,
+	})
+	require.NoError(t, err)
+	tricky, err := storage.Create(ctx, connection.CreateConnectionRequest{
+		FromNoteID: note2ID, ToNoteID: quotedNoteID, Type: "supports", Strength: 8,
+		Description: strPtr("line one, with a comma\nline \"two\""), Source: strPtr("importer"),
+	})
+	require.NoError(t, err)
+
+	id := func(v int64) string { return strconv.FormatInt(v, 10) }
+	createdAt := func(c *connection.Connection) string { return c.CreatedAt.UTC().Format(time.RFC3339) }
+
+	tests := []struct {
+		name string
+		req  connection.ExportRequest
+		want [][]string
+	}{
+		{
+			name: "without titles",
+			req:  connection.ExportRequest{},
+			want: [][]string{
+				{"id", "from_note_id", "to_note_id", "type", "strength", "description", "created_at"},
+				{id(plain.ID), id(note1ID), id(note2ID), "references", "5", "", createdAt(plain)},
+				{id(tricky.ID), id(note2ID), id(quotedNoteID), "supports", "8", "line one, with a comma\nline \"two\"", createdAt(tricky)},
+			},
+		},
+		{
+			name: "with titles",
+			req:  connection.ExportRequest{IncludeTitles: true},
+			want: [][]string{
+				{"id", "from_note_id", "to_note_id", "from_title", "to_title", "type", "strength", "description", "created_at"},
+				{id(plain.ID), id(note1ID), id(note2ID), "Test Note 1", "Test Note 2", "references", "5", "", createdAt(plain)},
+				{id(tricky.ID), id(note2ID), id(quotedNoteID), "Test Note 2", `Cats, "dogs"`, "supports", "8", "line one, with a comma\nline \"two\"", createdAt(tricky)},
+			},
+		},
+		{
+			name: "filtered by source",
+			req:  connection.ExportRequest{Source: strPtr("importer")},
+			want: [][]string{
+				{"id", "from_note_id", "to_note_id", "type", "strength", "description", "created_at"},
+				{id(tricky.ID), id(note2ID), id(quotedNoteID), "supports", "8", "line one, with a comma\nline \"two\"", createdAt(tricky)},
+			},
+		},
+		{
+			name: "no matches keeps the header",
+			req:  connection.ExportRequest{Type: strPtr("contradicts")},
+			want: [][]string{
+				{"id", "from_note_id", "to_note_id", "type", "strength", "description", "created_at"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := storage.ExportConnectionsCSV(ctx, tt.req)
+			require.NoError(t, err)
+
+			records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, records)
+		})
+	}
+
+	t.Run("quotes fields with commas, quotes and newlines", func(t *testing.T) {
+		out, err := storage.ExportConnectionsCSV(ctx, connection.ExportRequest{IncludeTitles: true})
+		require.NoError(t, err)
+		assert.Contains(t, out, `"Cats, ""dogs"""`)
+		assert.Contains(t, out, "\"line one, with a comma\nline \"\"two\"\"\"")
+	})
+
+	t.Run("rejects graphs over the limit", func(t *testing.T) {
+		limited := newTestStorage(t, WithGraphLimits(connection.GraphLimits{MaxEdges: 1}))
+		a, b, c := createTestNotes(t, limited.db)
+		for _, to := range []int64{b, c} {
+			_, err := limited.Create(ctx, connection.CreateConnectionRequest{FromNoteID: a, ToNoteID: to, Type: "references", Strength: 5})
+			require.NoError(t, err)
+		}
+
+		_, err := limited.ExportConnectionsCSV(ctx, connection.ExportRequest{})
+		assert.ErrorIs(t, err, connection.ErrGraphTooLarge)
+	})
+}
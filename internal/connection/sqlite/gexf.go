@@ -0,0 +1,234 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+// gexfDocument and the types below mirror the parts of the GEXF 1.3 schema
+// that the export uses. encoding/xml escapes every label and attribute value.
+type gexfDocument struct {
+	XMLName xml.Name  `xml:"gexf"`
+	XMLNS   string    `xml:"xmlns,attr"`
+	Version string    `xml:"version,attr"`
+	Meta    gexfMeta  `xml:"meta"`
+	Graph   gexfGraph `xml:"graph"`
+}
+
+type gexfMeta struct {
+	Creator     string `xml:"creator"`
+	Description string `xml:"description"`
+}
+
+type gexfGraph struct {
+	Mode            string           `xml:"mode,attr"`
+	DefaultEdgeType string           `xml:"defaultedgetype,attr"`
+	Attributes      []gexfAttributes `xml:"attributes"`
+	Nodes           []gexfNode       `xml:"nodes>node"`
+	Edges           []gexfEdge       `xml:"edges>edge"`
+}
+
+type gexfAttributes struct {
+	Class      string          `xml:"class,attr"`
+	Attributes []gexfAttribute `xml:"attribute"`
+}
+
+type gexfAttribute struct {
+	ID    string `xml:"id,attr"`
+	Title string `xml:"title,attr"`
+	Type  string `xml:"type,attr"`
+}
+
+type gexfNode struct {
+	ID        string         `xml:"id,attr"`
+	Label     string         `xml:"label,attr"`
+	AttValues []gexfAttValue `xml:"attvalues>attvalue"`
+}
+
+type gexfEdge struct {
+	ID        string         `xml:"id,attr"`
+	Source    string         `xml:"source,attr"`
+	Target    string         `xml:"target,attr"`
+	Type      string         `xml:"type,attr,omitempty"`
+	Label     string         `xml:"label,attr"`
+	Weight    int            `xml:"weight,attr"`
+	AttValues []gexfAttValue `xml:"attvalues>attvalue"`
+}
+
+type gexfAttValue struct {
+	For   string `xml:"for,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// ExportGEXF exports the graph as a GEXF 1.3 document for Gephi. Nodes are
+// notes labelled with their titles, with type and tags attributes; edges are
+// connections weighted by strength, with type and description attributes.
+// Symmetric connection types become undirected edges.
+//
+// With a type or source filter only the matching connections and the notes
+// they join are exported; otherwise every note is, including unconnected
+// ones. Connections to missing notes are skipped, since Gephi rejects edges
+// without both nodes. req.IncludeTitles is ignored because labels are always
+// included.
+func (s *Storage) ExportGEXF(ctx context.Context, req connection.ExportRequest) (out string, err error) {
+	ctx, cancel, err := s.guardGraph(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer cancel()
+	defer func() {
+		err = s.graphError(ctx, err)
+	}()
+
+	var whereClauses []string
+	var args []interface{}
+
+	if req.Type != nil {
+		whereClauses = append(whereClauses, "c.type = ?")
+		args = append(args, *req.Type)
+	}
+
+	if req.Source != nil {
+		whereClauses = append(whereClauses, "c.source = ?")
+		args = append(args, *req.Source)
+	}
+
+	whereClause := ""
+	if len(whereClauses) > 0 {
+		whereClause = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+
+	edgeQuery := `
+		SELECT c.id, c.from_note_id, c.to_note_id, c.type, c.strength, c.description
+		FROM connections c
+		JOIN notes f ON f.id = c.from_note_id
+		JOIN notes t ON t.id = c.to_note_id
+		` + whereClause + `
+		ORDER BY c.id
+	`
+
+	edges, endpoints, err := s.gexfEdges(ctx, edgeQuery, args)
+	if err != nil {
+		return "", err
+	}
+
+	nodeQuery := "SELECT id, title, type, tags FROM notes ORDER BY id"
+	filtered := len(whereClauses) > 0
+
+	rows, err := s.db.QueryContext(ctx, nodeQuery)
+	if err != nil {
+		return "", fmt.Errorf("failed to query notes: %w", err)
+	}
+	defer rows.Close()
+
+	nodes := []gexfNode{}
+	for rows.Next() {
+		var id int64
+		var title, noteType string
+		var tagsJSON sql.NullString
+		if err := rows.Scan(&id, &title, &noteType, &tagsJSON); err != nil {
+			return "", fmt.Errorf("failed to scan note: %w", err)
+		}
+		if filtered && !endpoints[id] {
+			continue
+		}
+		nodes = append(nodes, gexfNode{
+			ID:    strconv.FormatInt(id, 10),
+			Label: title,
+			AttValues: []gexfAttValue{
+				{For: "type", Value: noteType},
+				{For: "tags", Value: strings.Join(noteTags(tagsJSON), ",")},
+			},
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	doc := gexfDocument{
+		XMLNS:   "http://gexf.net/1.3",
+		Version: "1.3",
+		Meta: gexfMeta{
+			Creator:     "knowledge-graph-mcp",
+			Description: "Notes and their connections",
+		},
+		Graph: gexfGraph{
+			Mode:            "static",
+			DefaultEdgeType: "directed",
+			Attributes: []gexfAttributes{
+				{Class: "node", Attributes: []gexfAttribute{
+					{ID: "type", Title: "type", Type: "string"},
+					{ID: "tags", Title: "tags", Type: "string"},
+				}},
+				{Class: "edge", Attributes: []gexfAttribute{
+					{ID: "type", Title: "type", Type: "string"},
+					{ID: "description", Title: "description", Type: "string"},
+				}},
+			},
+			Nodes: nodes,
+			Edges: edges,
+		},
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode gexf: %w", err)
+	}
+
+	s.logger.Info("exported graph to gexf", "notes", len(nodes), "connections", len(edges))
+
+	return xml.Header + string(data) + "\n", nil
+}
+
+// gexfEdges runs query and returns its connections as GEXF edges, along with
+// the IDs of the notes they join
+func (s *Storage) gexfEdges(ctx context.Context, query string, args []interface{}) ([]gexfEdge, map[int64]bool, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query connections: %w", err)
+	}
+	defer rows.Close()
+
+	edges := []gexfEdge{}
+	endpoints := make(map[int64]bool)
+	for rows.Next() {
+		var id, fromNoteID, toNoteID int64
+		var connType string
+		var strength int
+		var description sql.NullString
+		if err := rows.Scan(&id, &fromNoteID, &toNoteID, &connType, &strength, &description); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan connection: %w", err)
+		}
+
+		edge := gexfEdge{
+			ID:     strconv.FormatInt(id, 10),
+			Source: strconv.FormatInt(fromNoteID, 10),
+			Target: strconv.FormatInt(toNoteID, 10),
+			Label:  connType,
+			Weight: strength,
+			AttValues: []gexfAttValue{
+				{For: "type", Value: connType},
+				{For: "description", Value: description.String},
+			},
+		}
+		if connection.IsSymmetricConnectionType(connType) {
+			edge.Type = "undirected"
+		}
+		edges = append(edges, edge)
+		endpoints[fromNoteID] = true
+		endpoints[toNoteID] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return edges, endpoints, nil
+}
@@ -0,0 +1,104 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/xml"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+func TestStorage_ExportGEXF(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	notes := []struct {
+		title    string
+		noteType string
+		tags     string
+	}{
+		{"Cats & <Dogs>", "text", `["pets","animals"]`},
+		{`The "quoted" note`, "markdown", `["pets"]`},
+		{"Loner", "text", "[]"},
+		{"Deleted later", "text", "[]"},
+	}
+	var ids []int64
+	for _, n := range notes {
+		result, err := storage.db.Exec(
+			"INSERT INTO notes (title, content, type, tags, metadata) VALUES (?, ?, ?, ?, ?)",
+			n.title, "Content", n.noteType, n.tags, "{}",
+		)
+		require.NoError(t, err)
+		id, err := result.LastInsertId()
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	_, err := storage.Create(ctx, connection.CreateConnectionRequest{
+		FromNoteID: ids[0], ToNoteID: ids[1], Type: "supports", Strength: 8,
+		Description: strPtr("cats 'support' dogs & more"), Source: strPtr("importer"),
+	})
+	require.NoError(t, err)
+	_, err = storage.Create(ctx, connection.CreateConnectionRequest{
+		FromNoteID: ids[1], ToNoteID: ids[0], Type: "relates_to", Strength: 3,
+	})
+	require.NoError(t, err)
+	_, err = storage.Create(ctx, connection.CreateConnectionRequest{
+		FromNoteID: ids[0], ToNoteID: ids[3], Type: "references", Strength: 5,
+	})
+	require.NoError(t, err)
+	_, err = storage.db.Exec("DELETE FROM notes WHERE id = ?", ids[3])
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		req    connection.ExportRequest
+		golden string
+	}{
+		{
+			name:   "whole graph",
+			req:    connection.ExportRequest{},
+			golden: "export.gexf",
+		},
+		{
+			name:   "filtered by source",
+			req:    connection.ExportRequest{Source: strPtr("importer")},
+			golden: "export_filtered.gexf",
+		},
+		{
+			name:   "no matches",
+			req:    connection.ExportRequest{Type: strPtr("contradicts")},
+			golden: "export_empty.gexf",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := storage.ExportGEXF(ctx, tt.req)
+			require.NoError(t, err)
+
+			path := filepath.Join("testdata", tt.golden)
+			if *update {
+				require.NoError(t, os.WriteFile(path, []byte(got), 0o644))
+			}
+			want, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, string(want), got)
+
+			// The document must parse back with the original titles intact
+			var doc gexfDocument
+			require.NoError(t, xml.Unmarshal([]byte(got), &doc))
+			for _, node := range doc.Graph.Nodes {
+				assert.Contains(t, []string{notes[0].title, notes[1].title, notes[2].title}, node.Label)
+			}
+		})
+	}
+}
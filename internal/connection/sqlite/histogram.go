@@ -0,0 +1,52 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+// GetStrengthHistogram counts the connections in each strength bucket. The
+// buckets are returned in the given order with Count filled in; buckets must
+// not overlap but need not cover every strength. Without buckets the default
+// weak, medium and strong buckets are used.
+func (s *Storage) GetStrengthHistogram(ctx context.Context, buckets []connection.Bucket) ([]connection.Bucket, error) {
+	if len(buckets) == 0 {
+		buckets = connection.DefaultStrengthBuckets()
+	}
+	if err := connection.ValidateStrengthBuckets(buckets); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, "SELECT strength, COUNT(*) FROM connections GROUP BY strength")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connections by strength: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[int]int64)
+	for rows.Next() {
+		var strength int
+		var count int64
+		if err := rows.Scan(&strength, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan strength count: %w", err)
+		}
+		counts[strength] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	histogram := make([]connection.Bucket, len(buckets))
+	for i, b := range buckets {
+		b.Count = 0
+		for strength := b.Min; strength <= b.Max; strength++ {
+			b.Count += counts[strength]
+		}
+		histogram[i] = b
+	}
+
+	return histogram, nil
+}
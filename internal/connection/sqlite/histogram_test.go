@@ -0,0 +1,96 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+func TestStorage_GetStrengthHistogram(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+	t.Run("empty graph", func(t *testing.T) {
+		histogram, err := storage.GetStrengthHistogram(ctx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, connection.DefaultStrengthBuckets(), histogram)
+	})
+
+	for _, c := range []struct {
+		from, to int64
+		connType string
+		strength int
+	}{
+		{note1ID, note2ID, "references", 2},
+		{note1ID, note3ID, "references", 3},
+		{note2ID, note3ID, "references", 5},
+		{note2ID, note1ID, "supports", 8},
+		{note3ID, note1ID, "supports", 10},
+		{note3ID, note2ID, "supports", 10},
+	} {
+		_, err := storage.Create(ctx, connection.CreateConnectionRequest{
+			FromNoteID: c.from, ToNoteID: c.to, Type: c.connType, Strength: c.strength,
+		})
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name    string
+		buckets []connection.Bucket
+		want    []connection.Bucket
+		wantErr string
+	}{
+		{
+			name: "default buckets",
+			want: []connection.Bucket{
+				{Label: "weak", Min: 1, Max: 3, Count: 2},
+				{Label: "medium", Min: 4, Max: 7, Count: 1},
+				{Label: "strong", Min: 8, Max: 10, Count: 3},
+			},
+		},
+		{
+			name:    "given order is kept and stale counts are ignored",
+			buckets: []connection.Bucket{{Label: "top", Min: 10, Max: 10, Count: 99}, {Label: "bottom", Min: 1, Max: 2}},
+			want:    []connection.Bucket{{Label: "top", Min: 10, Max: 10, Count: 2}, {Label: "bottom", Min: 1, Max: 2, Count: 1}},
+		},
+		{
+			name:    "overlapping buckets",
+			buckets: []connection.Bucket{{Label: "a", Min: 1, Max: 5}, {Label: "b", Min: 5, Max: 10}},
+			wantErr: "both cover strength 5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			histogram, err := storage.GetStrengthHistogram(ctx, tt.buckets)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, histogram)
+		})
+	}
+
+	t.Run("even buckets cover every connection", func(t *testing.T) {
+		buckets, err := connection.EvenStrengthBuckets(5)
+		require.NoError(t, err)
+
+		histogram, err := storage.GetStrengthHistogram(ctx, buckets)
+		require.NoError(t, err)
+
+		var total int64
+		for _, b := range histogram {
+			total += b.Count
+		}
+		assert.Equal(t, int64(6), total)
+		assert.Equal(t, int64(1), histogram[0].Count, "strengths 1-2")
+		assert.Equal(t, int64(2), histogram[4].Count, "strengths 9-10")
+	})
+}
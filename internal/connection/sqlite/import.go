@@ -0,0 +1,211 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+// maxImportRows caps the number of data rows in one CSV import
+const maxImportRows = 10000
+
+// importColumnAliases maps normalized header names to import columns. Columns
+// not listed here, such as the id, title and created_at columns written by
+// ExportConnectionsCSV, are ignored.
+var importColumnAliases = map[string]string{
+	"from_note_id":    "from_note_id",
+	"from":            "from_note_id",
+	"from_id":         "from_note_id",
+	"from_note":       "from_note_id",
+	"source_note_id":  "from_note_id",
+	"to_note_id":      "to_note_id",
+	"to":              "to_note_id",
+	"to_id":           "to_note_id",
+	"to_note":         "to_note_id",
+	"target_note_id":  "to_note_id",
+	"target":          "to_note_id",
+	"type":            "type",
+	"connection_type": "type",
+	"relation":        "type",
+	"relationship":    "type",
+	"strength":        "strength",
+	"weight":          "strength",
+	"description":     "description",
+	"desc":            "description",
+	"notes":           "description",
+}
+
+// ImportConnectionsCSV creates connections from CSV data with a header row.
+// The from_note_id, to_note_id and type columns are required; strength
+// defaults to the configured default and description may be omitted. Rows
+// that fail to parse or validate are skipped and reported in the result, the
+// rest are created in a single transaction.
+func (s *Storage) ImportConnectionsCSV(ctx context.Context, data string) (*connection.ImportResult, error) {
+	var result *connection.ImportResult
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.importConnectionsCSV(ctx, data)
+		return err
+	})
+	return result, err
+}
+
+// importConnectionsCSV makes a single attempt at ImportConnectionsCSV
+func (s *Storage) importConnectionsCSV(ctx context.Context, data string) (*connection.ImportResult, error) {
+	r := csv.NewReader(strings.NewReader(data))
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("csv is empty, expected a header row")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	columns, err := importColumns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	type importRow struct {
+		row int
+		req connection.CreateConnectionRequest
+	}
+
+	result := &connection.ImportResult{CreatedIDs: []int64{}, Errors: []connection.ImportRowError{}}
+	var rows []importRow
+	for row := 2; ; row++ {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+		if len(rows)+len(result.Errors) >= maxImportRows {
+			return nil, fmt.Errorf("csv has more than %d rows", maxImportRows)
+		}
+
+		req, err := parseImportRecord(record, columns)
+		if err != nil {
+			result.Errors = append(result.Errors, connection.ImportRowError{Row: row, Error: err.Error()})
+			continue
+		}
+		rows = append(rows, importRow{row: row, req: req})
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// A failed insert only aborts its own statement, so later rows still apply
+	for _, row := range rows {
+		id, err := s.insertConnection(ctx, tx, row.req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("failed to import connections: %w", err)
+			}
+			result.Errors = append(result.Errors, connection.ImportRowError{Row: row.row, Error: err.Error()})
+			continue
+		}
+		result.CreatedIDs = append(result.CreatedIDs, id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	result.Created = int64(len(result.CreatedIDs))
+
+	// Parse errors were collected first, so report everything in row order
+	sort.Slice(result.Errors, func(i, j int) bool { return result.Errors[i].Row < result.Errors[j].Row })
+
+	s.logger.Info("imported connections from csv", "created", result.Created, "failed", len(result.Errors))
+
+	return result, nil
+}
+
+// importColumns maps each import column to its index in the header row
+func importColumns(header []string) (map[string]int, error) {
+	columns := make(map[string]int)
+	for i, name := range header {
+		if i == 0 {
+			// Spreadsheet exports often start with a byte order mark
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		name = strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+
+		column, ok := importColumnAliases[name]
+		if !ok {
+			continue
+		}
+		if _, dup := columns[column]; dup {
+			return nil, fmt.Errorf("csv header maps more than one column to %s", column)
+		}
+		columns[column] = i
+	}
+
+	for _, required := range []string{"from_note_id", "to_note_id", "type"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("csv header is missing the %s column", required)
+		}
+	}
+
+	return columns, nil
+}
+
+// parseImportRecord turns one CSV record into a create request
+func parseImportRecord(record []string, columns map[string]int) (connection.CreateConnectionRequest, error) {
+	field := func(column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return record[i]
+	}
+
+	req := connection.CreateConnectionRequest{
+		Type:     connection.NormalizeConnectionType(field("type")),
+		Strength: connection.DefaultStrength(),
+	}
+
+	var err error
+	if req.FromNoteID, err = strconv.ParseInt(strings.TrimSpace(field("from_note_id")), 10, 64); err != nil {
+		return req, fmt.Errorf("invalid from_note_id %q", field("from_note_id"))
+	}
+	if req.ToNoteID, err = strconv.ParseInt(strings.TrimSpace(field("to_note_id")), 10, 64); err != nil {
+		return req, fmt.Errorf("invalid to_note_id %q", field("to_note_id"))
+	}
+
+	if req.Type == "" {
+		return req, fmt.Errorf("type is required")
+	}
+	if !connection.IsValidConnectionType(req.Type) {
+		return req, fmt.Errorf("invalid connection type: %s", req.Type)
+	}
+
+	if raw := strings.TrimSpace(field("strength")); raw != "" {
+		if req.Strength, err = strconv.Atoi(raw); err != nil {
+			return req, fmt.Errorf("invalid strength %q", raw)
+		}
+		if err := connection.ValidateStrength(req.Strength); err != nil {
+			return req, err
+		}
+	}
+
+	if description := field("description"); description != "" {
+		req.Description = &description
+	}
+
+	return req, nil
+}
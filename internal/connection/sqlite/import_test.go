@@ -0,0 +1,207 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+func TestStorage_ImportConnectionsCSV(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		data        func(note1ID, note2ID, note3ID int64) string
+		wantCreated int64
+		wantErrors  []connection.ImportRowError
+		wantTypes   []string
+		wantErr     string
+	}{
+		{
+			name: "all rows valid",
+			data: func(n1, n2, n3 int64) string {
+				return fmt.Sprintf("from_note_id,to_note_id,type,strength,description\n"+
+					"%d,%d,supports,8,\"first, with a comma\"\n"+
+					"%d,%d,references,,\n", n1, n2, n2, n3)
+			},
+			wantCreated: 2,
+			wantErrors:  []connection.ImportRowError{},
+		},
+		{
+			name: "friendly headers and extra columns",
+			data: func(n1, n2, _ int64) string {
+				return fmt.Sprintf("\ufeffFrom, To ,Relationship,Weight,Notes,Ignored\n"+
+					"%d,%d,supports,3,\"multi\nline\",x\n", n1, n2)
+			},
+			wantCreated: 1,
+			wantErrors:  []connection.ImportRowError{},
+		},
+		{
+			name: "mixed-case and padded types are normalized",
+			data: func(n1, n2, n3 int64) string {
+				return fmt.Sprintf("from_note_id,to_note_id,type\n"+
+					"%d,%d,Supports\n"+
+					"%d,%d, REFERENCES \n", n1, n2, n2, n3)
+			},
+			wantCreated: 2,
+			wantErrors:  []connection.ImportRowError{},
+			wantTypes:   []string{"supports", "references"},
+		},
+		{
+			name: "invalid rows are reported and skipped",
+			data: func(n1, n2, n3 int64) string {
+				return fmt.Sprintf("from_note_id,to_note_id,type,strength\n"+
+					"%d,%d,supports,5\n"+
+					"abc,%d,supports,5\n"+
+					"%d,%d,not_a_type,5\n"+
+					"%d,%d,supports,11\n"+
+					"%d,%d,supports,5\n"+
+					"%d,%d,supports,5\n"+
+					"%d,9999,supports,5\n"+
+					"%d,%d,references\n",
+					n1, n2, n2, n1, n3, n2, n3, n1, n2, n1, n1, n1, n1, n3)
+			},
+			wantCreated: 2,
+			wantErrors: []connection.ImportRowError{
+				{Row: 3, Error: `invalid from_note_id "abc"`},
+				{Row: 4, Error: "invalid connection type: not_a_type"},
+				{Row: 5, Error: "strength must be between 1 and 10, got: 11"},
+				{Row: 6, Error: "connection already exists between these notes with this type"},
+				{Row: 7, Error: "self-connections are not allowed for supports connections"},
+				{Row: 8, Error: "invalid note ID: one or both notes do not exist"},
+			},
+		},
+		{
+			name: "header only",
+			data: func(_, _, _ int64) string {
+				return "from_note_id,to_note_id,type\n"
+			},
+			wantCreated: 0,
+			wantErrors:  []connection.ImportRowError{},
+		},
+		{
+			name: "missing required column",
+			data: func(_, _, _ int64) string {
+				return "from_note_id,to_note_id,strength\n1,2,5\n"
+			},
+			wantErr: "missing the type column",
+		},
+		{
+			name: "ambiguous header",
+			data: func(_, _, _ int64) string {
+				return "from,source_note_id,to,type\n1,1,2,supports\n"
+			},
+			wantErr: "more than one column to from_note_id",
+		},
+		{
+			name: "empty input",
+			data: func(_, _, _ int64) string {
+				return ""
+			},
+			wantErr: "csv is empty",
+		},
+		{
+			name: "malformed csv",
+			data: func(_, _, _ int64) string {
+				return "from_note_id,to_note_id,type\n1,2,\"supports\n"
+			},
+			wantErr: "failed to read csv",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newTestStorage(t)
+			note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+			result, err := storage.ImportConnectionsCSV(ctx, tt.data(note1ID, note2ID, note3ID))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantCreated, result.Created)
+			assert.Len(t, result.CreatedIDs, int(tt.wantCreated))
+			assert.Equal(t, tt.wantErrors, result.Errors)
+
+			list, err := storage.List(ctx, connection.ListConnectionsRequest{Limit: 100})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCreated, list.Total)
+			if tt.wantTypes != nil {
+				var types []string
+				for _, conn := range list.Items {
+					types = append(types, conn.Type)
+				}
+				assert.ElementsMatch(t, tt.wantTypes, types)
+			}
+		})
+	}
+
+	t.Run("parsed fields and defaults", func(t *testing.T) {
+		storage := newTestStorage(t)
+		note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+		result, err := storage.ImportConnectionsCSV(ctx, fmt.Sprintf(
+			"from,to,type,weight,desc\n%d,%d,supports,9,\"a, \"\"quoted\"\" description\"\n%d,%d,references,,\n",
+			note1ID, note2ID, note2ID, note3ID))
+		require.NoError(t, err)
+		require.Len(t, result.CreatedIDs, 2)
+
+		first, err := storage.Get(ctx, result.CreatedIDs[0])
+		require.NoError(t, err)
+		assert.Equal(t, "supports", first.Type)
+		assert.Equal(t, 9, first.Strength)
+		require.NotNil(t, first.Description)
+		assert.Equal(t, `a, "quoted" description`, *first.Description)
+
+		second, err := storage.Get(ctx, result.CreatedIDs[1])
+		require.NoError(t, err)
+		assert.Equal(t, connection.DefaultStrength(), second.Strength)
+		assert.Nil(t, second.Description)
+	})
+
+	t.Run("round trip with export", func(t *testing.T) {
+		source := newTestStorage(t)
+		note1ID, note2ID, note3ID := createTestNotes(t, source.db)
+		for _, req := range []connection.CreateConnectionRequest{
+			{FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 7, Description: strPtr("line one\nline, two")},
+			{FromNoteID: note2ID, ToNoteID: note3ID, Type: "references", Strength: 2},
+		} {
+			_, err := source.Create(ctx, req)
+			require.NoError(t, err)
+		}
+
+		exported, err := source.ExportConnectionsCSV(ctx, connection.ExportRequest{IncludeTitles: true})
+		require.NoError(t, err)
+
+		target := newTestStorage(t)
+		createTestNotes(t, target.db)
+		result, err := target.ImportConnectionsCSV(ctx, exported)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), result.Created)
+		assert.Empty(t, result.Errors)
+
+		reexported, err := target.ExportConnectionsCSV(ctx, connection.ExportRequest{IncludeTitles: true})
+		require.NoError(t, err)
+
+		// created_at is set on insert, so compare every other column
+		withoutCreatedAt := func(data string) [][]string {
+			records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+			require.NoError(t, err)
+			for i := range records {
+				records[i] = records[i][:len(records[i])-1]
+			}
+			return records
+		}
+		assert.Equal(t, withoutCreatedAt(exported), withoutCreatedAt(reexported))
+	})
+}
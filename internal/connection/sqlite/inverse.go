@@ -0,0 +1,125 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/sqlitedb"
+)
+
+// CreateWithInverse creates a connection and, when its type has an inverse,
+// the inverse connection with the notes swapped, so A precedes B also stores
+// B follows A. Both are created in one transaction. An inverse that already
+// exists is returned instead of being created again. The inverse is nil when
+// the type has no inverse or it is not an allowed type. Repeating an
+// idempotency key returns the connection it created and its current inverse.
+func (s *Storage) CreateWithInverse(ctx context.Context, req connection.CreateConnectionRequest) (*connection.Connection, *connection.Connection, error) {
+	var conn, inverse *connection.Connection
+	err := s.withRetry(ctx, func() error {
+		var err error
+		conn, inverse, err = s.createWithInverse(ctx, req)
+		return err
+	})
+	return conn, inverse, err
+}
+
+// createWithInverse makes a single attempt at CreateWithInverse
+func (s *Storage) createWithInverse(ctx context.Context, req connection.CreateConnectionRequest) (*connection.Connection, *connection.Connection, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var inverseID int64
+	inverseType, hasInverse := connection.ConnectionType(req.Type).Inverse()
+	id, replayed, err := sqlitedb.CreateOnce(ctx, tx, sqlitedb.IdempotencyConnections, req.IdempotencyKey, s.idempotencyWindow, func() (int64, error) {
+		id, err := s.insertConnection(ctx, tx, req)
+		if err != nil {
+			return 0, err
+		}
+
+		if hasInverse {
+			existing, err := findRedundantInverses(ctx, tx, req.FromNoteID, req.ToNoteID, inverseType)
+			if err != nil {
+				return 0, err
+			}
+			if len(existing) > 0 {
+				inverseID = existing[0].ID
+			} else {
+				inverseReq := req
+				inverseReq.FromNoteID, inverseReq.ToNoteID = req.ToNoteID, req.FromNoteID
+				inverseReq.Type = string(inverseType)
+				inverseID, err = s.insertConnection(ctx, tx, inverseReq)
+				if err != nil {
+					return 0, fmt.Errorf("failed to create inverse connection: %w", err)
+				}
+			}
+		}
+		return id, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	conn, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if replayed {
+		s.logger.Info("returned connection for repeated idempotency key", "connection_id", id)
+		// The connection the key created decides the inverse, not req
+		existing, err := s.FindRedundantInverses(ctx, conn.FromNoteID, conn.ToNoteID, conn.Type)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(existing) == 0 {
+			return conn, nil, nil
+		}
+		return conn, &existing[0], nil
+	}
+	if !hasInverse {
+		return conn, nil, nil
+	}
+
+	inverse, err := s.Get(ctx, inverseID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return conn, inverse, nil
+}
+
+// FindRedundantInverses returns the connections that already state what a
+// connectionType connection from fromNoteID to toNoteID would, using the
+// inverse type in the other direction. For A precedes B that is any B follows
+// A. Types without an inverse never have redundant inverses.
+func (s *Storage) FindRedundantInverses(ctx context.Context, fromNoteID, toNoteID int64, connectionType string) ([]connection.Connection, error) {
+	inverse, ok := connection.ConnectionType(connectionType).Inverse()
+	if !ok {
+		return []connection.Connection{}, nil
+	}
+
+	return findRedundantInverses(ctx, s.db, fromNoteID, toNoteID, inverse)
+}
+
+// findRedundantInverses returns the inverseType connections from toNoteID to
+// fromNoteID through db, which may be a transaction
+func findRedundantInverses(ctx context.Context, db execer, fromNoteID, toNoteID int64, inverseType connection.ConnectionType) ([]connection.Connection, error) {
+	query := "SELECT " + connectionColumns + " FROM connections WHERE from_note_id = ? AND to_note_id = ? AND type = ? ORDER BY id"
+
+	connections, err := queryConnectionsWith(ctx, db, query, toNoteID, fromNoteID, string(inverseType))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find inverse connections: %w", err)
+	}
+	if connections == nil {
+		connections = []connection.Connection{}
+	}
+
+	return connections, nil
+}
@@ -0,0 +1,215 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+func TestStorage_FindRedundantInverses(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	a, b, c := createTestNotes(t, storage.db)
+
+	// b follows a states the same as a precedes b
+	follows, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: b, ToNoteID: a, Type: "follows", Strength: 5})
+	require.NoError(t, err)
+	precedes, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: b, ToNoteID: c, Type: "precedes", Strength: 5})
+	require.NoError(t, err)
+	_, err = storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: a, ToNoteID: c, Type: "follows", Strength: 5})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		from     int64
+		to       int64
+		connType string
+		wantIDs  []int64
+	}{
+		{name: "precedes against an existing follows", from: a, to: b, connType: "precedes", wantIDs: []int64{follows.ID}},
+		{name: "follows against an existing precedes", from: c, to: b, connType: "follows", wantIDs: []int64{precedes.ID}},
+		{name: "same direction is not an inverse", from: b, to: a, connType: "precedes", wantIDs: []int64{}},
+		{name: "same type in reverse is not an inverse", from: a, to: b, connType: "follows", wantIDs: []int64{}},
+		{name: "unrelated pair", from: b, to: c, connType: "follows", wantIDs: []int64{}},
+		{name: "type without an inverse", from: a, to: b, connType: "supports", wantIDs: []int64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inverses, err := storage.FindRedundantInverses(ctx, tt.from, tt.to, tt.connType)
+			require.NoError(t, err)
+
+			ids := []int64{}
+			for _, inverse := range inverses {
+				ids = append(ids, inverse.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestStorage_CreateAutoSymmetric(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		edgeMode    connection.EdgeMode
+		connType    string
+		self        bool
+		wantReverse bool
+	}{
+		{name: "symmetric type", edgeMode: connection.EdgeModeUnique, connType: "similar_to", wantReverse: true},
+		{name: "symmetric type in multi mode", edgeMode: connection.EdgeModeMulti, connType: "contradicts", wantReverse: true},
+		{name: "directed type", edgeMode: connection.EdgeModeUnique, connType: "depends_on"},
+		{name: "self-loop has no reverse", edgeMode: connection.EdgeModeUnique, connType: "relates_to", self: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newTestStorage(t, WithEdgeMode(tt.edgeMode))
+			from, to, _ := createTestNotes(t, storage.db)
+			if tt.self {
+				to = from
+			}
+
+			conn, err := storage.Create(ctx, connection.CreateConnectionRequest{
+				FromNoteID: from, ToNoteID: to, Type: tt.connType, Strength: 6,
+				Description: strPtr("shared"), AutoSymmetric: true,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, from, conn.FromNoteID)
+
+			reverse, err := storage.queryConnections(ctx,
+				"SELECT "+connectionColumns+" FROM connections WHERE from_note_id = ? AND to_note_id = ? AND id != ?", to, from, conn.ID)
+			require.NoError(t, err)
+			if !tt.wantReverse {
+				assert.Empty(t, reverse)
+				return
+			}
+			require.Len(t, reverse, 1)
+			assert.Equal(t, tt.connType, reverse[0].Type)
+			assert.Equal(t, 6, reverse[0].Strength)
+			assert.Equal(t, strPtr("shared"), reverse[0].Description)
+		})
+	}
+
+	t.Run("existing reverse still rejects the pair", func(t *testing.T) {
+		storage := newTestStorage(t)
+		from, to, _ := createTestNotes(t, storage.db)
+
+		_, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: to, ToNoteID: from, Type: "similar_to", Strength: 5})
+		require.NoError(t, err)
+
+		_, err = storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: from, ToNoteID: to, Type: "similar_to", Strength: 5, AutoSymmetric: true})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "similar_to is symmetric")
+	})
+}
+
+func TestStorage_CreateWithInverse(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		// existing is the type of a connection created before the call, from
+		// the target to the source when existingReversed is set
+		existing         string
+		existingReversed bool
+		connType         string
+		wantInverse      string
+		wantReused       bool
+		wantErr          string
+		wantTotal        int64
+	}{
+		{name: "precedes creates follows", connType: "precedes", wantInverse: "follows", wantTotal: 2},
+		{name: "follows creates precedes", connType: "follows", wantInverse: "precedes", wantTotal: 2},
+		{name: "type without an inverse", connType: "part_of", wantTotal: 1},
+		{name: "symmetric type", connType: "similar_to", wantTotal: 1},
+		{
+			name:             "existing inverse is reused",
+			existing:         "follows",
+			existingReversed: true,
+			connType:         "precedes",
+			wantInverse:      "follows",
+			wantReused:       true,
+			wantTotal:        2,
+		},
+		{
+			name:      "duplicate connection creates nothing",
+			existing:  "precedes",
+			connType:  "precedes",
+			wantErr:   "connection already exists",
+			wantTotal: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newTestStorage(t)
+			note1ID, note2ID, _ := createTestNotes(t, storage.db)
+
+			var existingID int64
+			if tt.existing != "" {
+				from, to := note1ID, note2ID
+				if tt.existingReversed {
+					from, to = to, from
+				}
+				c, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: from, ToNoteID: to, Type: tt.existing, Strength: 3})
+				require.NoError(t, err)
+				existingID = c.ID
+			}
+
+			conn, inverse, err := storage.CreateWithInverse(ctx, connection.CreateConnectionRequest{
+				FromNoteID: note1ID, ToNoteID: note2ID, Type: tt.connType, Strength: 7, Source: strPtr("agent"),
+			})
+
+			var total int64
+			require.NoError(t, storage.db.QueryRow("SELECT COUNT(*) FROM connections").Scan(&total))
+			assert.Equal(t, tt.wantTotal, total)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.connType, conn.Type)
+			assert.Equal(t, note1ID, conn.FromNoteID)
+
+			if tt.wantInverse == "" {
+				assert.Nil(t, inverse)
+				return
+			}
+			require.NotNil(t, inverse)
+			assert.Equal(t, tt.wantInverse, inverse.Type)
+			assert.Equal(t, note2ID, inverse.FromNoteID)
+			assert.Equal(t, note1ID, inverse.ToNoteID)
+			if tt.wantReused {
+				assert.Equal(t, existingID, inverse.ID)
+				assert.Equal(t, 3, inverse.Strength)
+			} else {
+				assert.Equal(t, 7, inverse.Strength)
+				assert.Equal(t, strPtr("agent"), inverse.Source)
+			}
+		})
+	}
+
+	t.Run("inverse removed by configuration", func(t *testing.T) {
+		require.NoError(t, connection.Configure(connection.Config{AllowedTypes: []string{"precedes"}}))
+		t.Cleanup(func() {
+			require.NoError(t, connection.Configure(connection.Config{}))
+		})
+
+		storage := newTestStorage(t)
+		note1ID, note2ID, _ := createTestNotes(t, storage.db)
+
+		conn, inverse, err := storage.CreateWithInverse(ctx, connection.CreateConnectionRequest{FromNoteID: note1ID, ToNoteID: note2ID, Type: "precedes", Strength: 5})
+		require.NoError(t, err)
+		assert.NotNil(t, conn)
+		assert.Nil(t, inverse)
+	})
+}
@@ -0,0 +1,79 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+// GetLatestConnectionPerNote returns the most recently created connection of
+// each of the given notes, in either direction, keyed by note ID. Ties on
+// created_at go to the higher connection ID. Notes without connections, and
+// IDs with no note, are left out of the map.
+func (s *Storage) GetLatestConnectionPerNote(ctx context.Context, noteIDs []int64) (map[int64]*connection.Connection, error) {
+	result := make(map[int64]*connection.Connection, len(noteIDs))
+	if len(noteIDs) == 0 {
+		return result, nil
+	}
+
+	requested := make(map[int64]bool, len(noteIDs))
+	placeholders := make([]string, len(noteIDs))
+	idArgs := make([]interface{}, len(noteIDs))
+	for i, id := range noteIDs {
+		requested[id] = true
+		placeholders[i] = "?"
+		idArgs[i] = id
+	}
+	inList := strings.Join(placeholders, ", ")
+
+	// Each connection appears once per requested end, and a self-loop once,
+	// so every note's connections share a partition
+	query := fmt.Sprintf(`
+		WITH ends AS (
+			SELECT from_note_id AS note_id, %[1]s FROM connections
+			WHERE from_note_id IN (%[2]s)
+			UNION ALL
+			SELECT to_note_id AS note_id, %[1]s FROM connections
+			WHERE to_note_id IN (%[2]s) AND to_note_id != from_note_id
+		), ranked AS (
+			SELECT %[1]s, ROW_NUMBER() OVER (
+				PARTITION BY note_id ORDER BY created_at DESC, id DESC
+			) AS position
+			FROM ends
+		)
+		SELECT DISTINCT %[1]s FROM ranked WHERE position = 1
+	`, connectionColumns, inList)
+	args := append(append([]interface{}{}, idArgs...), idArgs...)
+
+	connections, err := s.queryConnections(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest connections: %w", err)
+	}
+
+	// A connection that is latest for one note may also touch another
+	// requested note whose latest connection is newer, so keep the newest
+	for i := range connections {
+		conn := &connections[i]
+		for _, noteID := range []int64{conn.FromNoteID, conn.ToNoteID} {
+			if !requested[noteID] {
+				continue
+			}
+			if current, ok := result[noteID]; !ok || newerConnection(conn, current) {
+				result[noteID] = conn
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// newerConnection reports whether a was created after b, breaking ties by ID
+// the way GetLatestConnectionPerNote orders them
+func newerConnection(a, b *connection.Connection) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.ID > b.ID
+}
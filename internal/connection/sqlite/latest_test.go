@@ -0,0 +1,80 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+func TestStorage_GetLatestConnectionPerNote(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	a, b, c := createTestNotes(t, storage.db)
+
+	res, err := storage.db.Exec("INSERT INTO notes (title, content, type, tags, metadata) VALUES ('Lonely', 'No connections', 'text', '[]', '{}')")
+	require.NoError(t, err)
+	lonely, err := res.LastInsertId()
+	require.NoError(t, err)
+
+	create := func(from, to int64, connType, createdAt string) int64 {
+		conn, err := storage.Create(ctx, connection.CreateConnectionRequest{
+			FromNoteID: from, ToNoteID: to, Type: connType, Strength: 5,
+		})
+		require.NoError(t, err)
+		_, err = storage.db.Exec("UPDATE connections SET created_at = ? WHERE id = ?", createdAt, conn.ID)
+		require.NoError(t, err)
+		return conn.ID
+	}
+	aToB := create(a, b, "supports", "2024-01-01 10:00:00")
+	cToB := create(c, b, "references", "2024-01-03 10:00:00")
+	aToC := create(a, c, "relates_to", "2024-01-02 10:00:00")
+	// Same second as aToC, so the higher ID wins for a
+	aToA := create(a, a, "references", "2024-01-02 10:00:00")
+
+	tests := []struct {
+		name    string
+		noteIDs []int64
+		want    map[int64]int64
+	}{
+		{name: "no notes", noteIDs: nil, want: map[int64]int64{}},
+		{name: "outgoing and self-loop tie broken by ID", noteIDs: []int64{a}, want: map[int64]int64{a: aToA}},
+		{name: "incoming", noteIDs: []int64{b}, want: map[int64]int64{b: cToB}},
+		{name: "either direction", noteIDs: []int64{c}, want: map[int64]int64{c: cToB}},
+		{
+			name:    "several notes sharing connections",
+			noteIDs: []int64{a, b, c},
+			want:    map[int64]int64{a: aToA, b: cToB, c: cToB},
+		},
+		{name: "notes without connections are omitted", noteIDs: []int64{lonely, 999}, want: map[int64]int64{}},
+		{name: "duplicate IDs", noteIDs: []int64{b, b}, want: map[int64]int64{b: cToB}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			latest, err := storage.GetLatestConnectionPerNote(ctx, tt.noteIDs)
+			require.NoError(t, err)
+
+			got := make(map[int64]int64, len(latest))
+			for noteID, conn := range latest {
+				got[noteID] = conn.ID
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("trashed connections are skipped", func(t *testing.T) {
+		require.NoError(t, storage.SoftDelete(ctx, cToB))
+
+		latest, err := storage.GetLatestConnectionPerNote(ctx, []int64{b, c})
+		require.NoError(t, err)
+		require.Contains(t, latest, b)
+		assert.Equal(t, aToB, latest[b].ID)
+		assert.Equal(t, "supports", latest[b].Type)
+		require.Contains(t, latest, c)
+		assert.Equal(t, aToC, latest[c].ID)
+	})
+}
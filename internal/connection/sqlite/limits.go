@@ -0,0 +1,53 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+// guardGraph checks the graph against the configured node and edge ceilings
+// and derives a context bounded by the configured time limit. Whole-graph
+// operations must call it before loading anything into memory and use the
+// returned context for all further work.
+func (s *Storage) guardGraph(ctx context.Context) (context.Context, context.CancelFunc, error) {
+	if s.limits.MaxNodes > 0 || s.limits.MaxEdges > 0 {
+		var nodes, edges int
+		err := s.db.QueryRowContext(ctx,
+			"SELECT (SELECT COUNT(*) FROM notes), (SELECT COUNT(*) FROM connections)").Scan(&nodes, &edges)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to measure graph: %w", err)
+		}
+
+		if s.limits.MaxNodes > 0 && nodes > s.limits.MaxNodes {
+			s.logger.Warn("graph operation rejected", "notes", nodes, "max_notes", s.limits.MaxNodes)
+			return nil, nil, fmt.Errorf("%w: %d notes exceeds the limit of %d", connection.ErrGraphTooLarge, nodes, s.limits.MaxNodes)
+		}
+		if s.limits.MaxEdges > 0 && edges > s.limits.MaxEdges {
+			s.logger.Warn("graph operation rejected", "connections", edges, "max_connections", s.limits.MaxEdges)
+			return nil, nil, fmt.Errorf("%w: %d connections exceeds the limit of %d", connection.ErrGraphTooLarge, edges, s.limits.MaxEdges)
+		}
+	}
+
+	if s.limits.Timeout > 0 {
+		ctx, cancel := context.WithTimeout(ctx, s.limits.Timeout)
+		return ctx, cancel, nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return ctx, cancel, nil
+}
+
+// graphError reports err as ErrGraphTimeout when the guarded context's deadline
+// has passed, since drivers surface an interrupted query in different ways
+func (s *Storage) graphError(ctx context.Context, err error) error {
+	if err == nil || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return err
+	}
+	s.logger.Warn("graph operation timed out", "timeout", s.limits.Timeout)
+	if s.limits.Timeout > 0 {
+		return fmt.Errorf("%w: exceeded the limit of %s", connection.ErrGraphTimeout, s.limits.Timeout)
+	}
+	return fmt.Errorf("%w: %v", connection.ErrGraphTimeout, err)
+}
@@ -0,0 +1,181 @@
+package sqlite
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/migrations"
+)
+
+func TestStorage_GraphLimits(t *testing.T) {
+	tempFile, err := os.CreateTemp("", "test-*.db")
+	require.NoError(t, err)
+	tempFile.Close()
+	t.Cleanup(func() { os.Remove(tempFile.Name()) })
+	require.NoError(t, migrations.NewMigrationRunner(tempFile.Name()).RunMigrations())
+
+	seed, err := NewStorage(tempFile.Name())
+	require.NoError(t, err)
+	note1ID, note2ID, note3ID := createTestNotes(t, seed.db)
+	for _, req := range []connection.CreateConnectionRequest{
+		{FromNoteID: note1ID, ToNoteID: note2ID, Type: "references", Strength: 5},
+		{FromNoteID: note2ID, ToNoteID: note3ID, Type: "references", Strength: 5},
+	} {
+		_, err := seed.Create(context.Background(), req)
+		require.NoError(t, err)
+	}
+	require.NoError(t, seed.Close())
+
+	tests := []struct {
+		name    string
+		limits  connection.GraphLimits
+		wantErr error
+	}{
+		{
+			name:   "within limits",
+			limits: connection.GraphLimits{MaxNodes: 3, MaxEdges: 2, Timeout: time.Minute},
+		},
+		{
+			name:   "limits disabled",
+			limits: connection.GraphLimits{},
+		},
+		{
+			name:    "node ceiling",
+			limits:  connection.GraphLimits{MaxNodes: 2},
+			wantErr: connection.ErrGraphTooLarge,
+		},
+		{
+			name:    "edge ceiling",
+			limits:  connection.GraphLimits{MaxEdges: 1},
+			wantErr: connection.ErrGraphTooLarge,
+		},
+		{
+			name:    "deadline",
+			limits:  connection.GraphLimits{Timeout: time.Nanosecond},
+			wantErr: connection.ErrGraphTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage, err := NewStorage(tempFile.Name(), WithGraphLimits(tt.limits))
+			require.NoError(t, err)
+			defer storage.Close()
+
+			ranks, err := storage.ComputePageRank(context.Background(), connection.PageRankOptions{})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, ranks, 3)
+		})
+	}
+}
+
+func TestStorage_GraphLimitsGuardWholeGraphOperations(t *testing.T) {
+	ctx := context.Background()
+
+	operations := []struct {
+		name string
+		run  func(s *Storage, from, to int64) error
+	}{
+		{
+			name: "pagerank",
+			run: func(s *Storage, _, _ int64) error {
+				_, err := s.ComputePageRank(ctx, connection.PageRankOptions{})
+				return err
+			},
+		},
+		{
+			name: "graph metrics",
+			run: func(s *Storage, _, _ int64) error {
+				_, err := s.GetGraphMetrics(ctx)
+				return err
+			},
+		},
+		{
+			name: "strongest path",
+			run: func(s *Storage, from, to int64) error {
+				_, err := s.FindStrongestPath(ctx, from, to, connection.PathOptions{MaxDepth: 3})
+				return err
+			},
+		},
+		{
+			name: "connection paths",
+			run: func(s *Storage, from, to int64) error {
+				_, err := s.FindConnectionPaths(ctx, from, to, connection.PathOptions{})
+				return err
+			},
+		},
+		{
+			name: "contradictions",
+			run: func(s *Storage, _, _ int64) error {
+				_, err := s.FindContradictions(ctx)
+				return err
+			},
+		},
+		{
+			name: "reciprocal connections",
+			run: func(s *Storage, _, _ int64) error {
+				_, err := s.FindReciprocalConnections(ctx)
+				return err
+			},
+		},
+		{
+			name: "csv export",
+			run: func(s *Storage, _, _ int64) error {
+				_, err := s.ExportConnectionsCSV(ctx, connection.ExportRequest{})
+				return err
+			},
+		},
+		{
+			name: "gexf export",
+			run: func(s *Storage, _, _ int64) error {
+				_, err := s.ExportGEXF(ctx, connection.ExportRequest{})
+				return err
+			},
+		},
+	}
+
+	limits := []struct {
+		name    string
+		limits  connection.GraphLimits
+		wantErr error
+	}{
+		{name: "within limits", limits: connection.GraphLimits{MaxNodes: 3, MaxEdges: 2, Timeout: time.Minute}},
+		{name: "node ceiling", limits: connection.GraphLimits{MaxNodes: 2}, wantErr: connection.ErrGraphTooLarge},
+		{name: "edge ceiling", limits: connection.GraphLimits{MaxEdges: 1}, wantErr: connection.ErrGraphTooLarge},
+		{name: "deadline", limits: connection.GraphLimits{Timeout: time.Nanosecond}, wantErr: connection.ErrGraphTimeout},
+	}
+
+	for _, op := range operations {
+		for _, tt := range limits {
+			t.Run(op.name+"/"+tt.name, func(t *testing.T) {
+				storage := newTestStorage(t, WithGraphLimits(tt.limits))
+				note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+				for _, req := range []connection.CreateConnectionRequest{
+					{FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 5},
+					{FromNoteID: note2ID, ToNoteID: note1ID, Type: "contradicts", Strength: 5},
+				} {
+					_, err := storage.Create(ctx, req)
+					require.NoError(t, err)
+				}
+
+				err := op.run(storage, note1ID, note3ID)
+				if tt.wantErr != nil {
+					assert.ErrorIs(t, err, tt.wantErr)
+					return
+				}
+				assert.NoError(t, err)
+			})
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+// ExportAdjacencyMatrix builds the adjacency matrix of the given notes. Rows
+// and columns follow the order of noteIDs, with duplicates dropped. IDs with no
+// note are reported in MissingIDs instead of getting an empty row.
+//
+// The matrix is directed: a connection from A to B fills row A, column B. A
+// symmetric connection type implies the reverse connection, so it fills both
+// cells. When several connections join the same pair in one direction, the
+// strongest wins. Connections to notes outside the set are ignored.
+func (s *Storage) ExportAdjacencyMatrix(ctx context.Context, noteIDs []int64) (*connection.Matrix, error) {
+	matrix := &connection.Matrix{
+		NoteIDs:    []int64{},
+		Labels:     []string{},
+		Values:     [][]int{},
+		MissingIDs: []int64{},
+	}
+	if len(noteIDs) == 0 {
+		return matrix, nil
+	}
+
+	seen := make(map[int64]bool, len(noteIDs))
+	placeholders := []string{}
+	idArgs := []interface{}{}
+	for _, id := range noteIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		placeholders = append(placeholders, "?")
+		idArgs = append(idArgs, id)
+	}
+	inList := strings.Join(placeholders, ", ")
+
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf("SELECT id, title FROM notes WHERE id IN (%s)", inList), idArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notes: %w", err)
+	}
+	defer rows.Close()
+
+	titles := make(map[int64]string, len(idArgs))
+	for rows.Next() {
+		var id int64
+		var title string
+		if err := rows.Scan(&id, &title); err != nil {
+			return nil, fmt.Errorf("failed to scan note: %w", err)
+		}
+		titles[id] = title
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	index := make(map[int64]int, len(titles))
+	for _, arg := range idArgs {
+		id := arg.(int64)
+		title, ok := titles[id]
+		if !ok {
+			matrix.MissingIDs = append(matrix.MissingIDs, id)
+			continue
+		}
+		index[id] = len(matrix.NoteIDs)
+		matrix.NoteIDs = append(matrix.NoteIDs, id)
+		matrix.Labels = append(matrix.Labels, title)
+	}
+	for range matrix.NoteIDs {
+		matrix.Values = append(matrix.Values, make([]int, len(matrix.NoteIDs)))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT from_note_id, to_note_id, type, strength
+		FROM connections
+		WHERE from_note_id IN (%s) AND to_note_id IN (%s)
+	`, inList, inList)
+	args := append(append([]interface{}{}, idArgs...), idArgs...)
+
+	connRows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query connections: %w", err)
+	}
+	defer connRows.Close()
+
+	connections := 0
+	for connRows.Next() {
+		var fromNoteID, toNoteID int64
+		var connType string
+		var strength int
+		if err := connRows.Scan(&fromNoteID, &toNoteID, &connType, &strength); err != nil {
+			return nil, fmt.Errorf("failed to scan connection: %w", err)
+		}
+
+		// Both ends are in the set, but one may be a missing note
+		from, okFrom := index[fromNoteID]
+		to, okTo := index[toNoteID]
+		if !okFrom || !okTo {
+			continue
+		}
+		connections++
+
+		matrix.Values[from][to] = max(matrix.Values[from][to], strength)
+		if connection.IsSymmetricConnectionType(connType) {
+			matrix.Values[to][from] = max(matrix.Values[to][from], strength)
+		}
+	}
+	if err := connRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	s.logger.Info("exported adjacency matrix", "notes", len(matrix.NoteIDs), "connections", connections, "missing", len(matrix.MissingIDs))
+
+	return matrix, nil
+}
@@ -0,0 +1,107 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+func TestStorage_ExportAdjacencyMatrix(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	a, b, c := createTestNotes(t, storage.db)
+
+	connections := []connection.CreateConnectionRequest{
+		{FromNoteID: a, ToNoteID: b, Type: "references", Strength: 4},
+		{FromNoteID: a, ToNoteID: b, Type: "supports", Strength: 7},
+		{FromNoteID: b, ToNoteID: c, Type: "relates_to", Strength: 3},
+		{FromNoteID: c, ToNoteID: a, Type: "references", Strength: 2},
+	}
+	for _, req := range connections {
+		_, err := storage.Create(ctx, req)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name        string
+		noteIDs     []int64
+		wantIDs     []int64
+		wantLabels  []string
+		wantValues  [][]int
+		wantMissing []int64
+	}{
+		{
+			name:        "no notes",
+			noteIDs:     nil,
+			wantIDs:     []int64{},
+			wantLabels:  []string{},
+			wantValues:  [][]int{},
+			wantMissing: []int64{},
+		},
+		{
+			name:       "whole set is directed with symmetric types in both cells",
+			noteIDs:    []int64{a, b, c},
+			wantIDs:    []int64{a, b, c},
+			wantLabels: []string{"Test Note 1", "Test Note 2", "Test Note 3"},
+			wantValues: [][]int{
+				{0, 7, 0},
+				{0, 0, 3},
+				{2, 3, 0},
+			},
+			wantMissing: []int64{},
+		},
+		{
+			name:       "follows input order",
+			noteIDs:    []int64{c, a},
+			wantIDs:    []int64{c, a},
+			wantLabels: []string{"Test Note 3", "Test Note 1"},
+			wantValues: [][]int{
+				{0, 2},
+				{0, 0},
+			},
+			wantMissing: []int64{},
+		},
+		{
+			name:        "unknown and repeated IDs",
+			noteIDs:     []int64{b, 999, b, c},
+			wantIDs:     []int64{b, c},
+			wantLabels:  []string{"Test Note 2", "Test Note 3"},
+			wantValues:  [][]int{{0, 3}, {3, 0}},
+			wantMissing: []int64{999},
+		},
+		{
+			name:        "only unknown IDs",
+			noteIDs:     []int64{998, 999},
+			wantIDs:     []int64{},
+			wantLabels:  []string{},
+			wantValues:  [][]int{},
+			wantMissing: []int64{998, 999},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matrix, err := storage.ExportAdjacencyMatrix(ctx, tt.noteIDs)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantIDs, matrix.NoteIDs)
+			assert.Equal(t, tt.wantLabels, matrix.Labels)
+			assert.Equal(t, tt.wantValues, matrix.Values)
+			assert.Equal(t, tt.wantMissing, matrix.MissingIDs)
+		})
+	}
+
+	t.Run("connections to deleted notes are ignored", func(t *testing.T) {
+		_, err := storage.db.Exec("DELETE FROM notes WHERE id = ?", c)
+		require.NoError(t, err)
+
+		matrix, err := storage.ExportAdjacencyMatrix(ctx, []int64{a, b, c})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{a, b}, matrix.NoteIDs)
+		assert.Equal(t, [][]int{{0, 7}, {0, 0}}, matrix.Values)
+		assert.Equal(t, []int64{c}, matrix.MissingIDs)
+	})
+}
@@ -0,0 +1,127 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+// GetGraphMetrics counts the notes, connections and weakly connected
+// components of the graph and derives its density and average degree. An
+// empty graph has zero of everything, so nothing is divided by zero.
+func (s *Storage) GetGraphMetrics(ctx context.Context) (_ *connection.GraphMetrics, err error) {
+	ctx, cancel, err := s.guardGraph(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	defer func() {
+		err = s.graphError(ctx, err)
+	}()
+
+	noteIDs, err := s.loadNoteIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	components := newComponents(noteIDs)
+
+	rows, err := s.db.QueryContext(ctx, "SELECT from_note_id, to_note_id FROM connections")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load connections: %w", err)
+	}
+	defer rows.Close()
+
+	var edges int64
+	for rows.Next() {
+		var fromID, toID int64
+		if err := rows.Scan(&fromID, &toID); err != nil {
+			return nil, fmt.Errorf("failed to scan connection: %w", err)
+		}
+		edges++
+		components.union(fromID, toID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating connections: %w", err)
+	}
+
+	metrics := &connection.GraphMetrics{
+		Nodes:               int64(len(noteIDs)),
+		Edges:               edges,
+		ConnectedComponents: components.count,
+	}
+	if metrics.Nodes > 0 {
+		metrics.AverageDegree = 2 * float64(edges) / float64(metrics.Nodes)
+	}
+	if metrics.Nodes > 1 {
+		metrics.Density = float64(edges) / float64(metrics.Nodes*(metrics.Nodes-1))
+	}
+
+	return metrics, nil
+}
+
+// loadNoteIDs returns the IDs of all notes in ascending order
+func (s *Storage) loadNoteIDs(ctx context.Context) ([]int64, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT id FROM notes ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load notes: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan note: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating notes: %w", err)
+	}
+
+	return ids, nil
+}
+
+// components tracks the weakly connected components of a set of notes with a
+// union-find over their IDs. Every note starts as a component of its own.
+type components struct {
+	parent map[int64]int64
+	count  int64
+}
+
+// newComponents starts one component per note
+func newComponents(noteIDs []int64) *components {
+	c := &components{parent: make(map[int64]int64, len(noteIDs)), count: int64(len(noteIDs))}
+	for _, id := range noteIDs {
+		c.parent[id] = id
+	}
+	return c
+}
+
+// find returns the representative of the note's component, halving the path
+// on the way
+func (c *components) find(id int64) int64 {
+	for c.parent[id] != id {
+		c.parent[id] = c.parent[c.parent[id]]
+		id = c.parent[id]
+	}
+	return id
+}
+
+// union merges the components of two notes. Notes that are not tracked are
+// ignored.
+func (c *components) union(a, b int64) {
+	if _, ok := c.parent[a]; !ok {
+		return
+	}
+	if _, ok := c.parent[b]; !ok {
+		return
+	}
+	rootA, rootB := c.find(a), c.find(b)
+	if rootA == rootB {
+		return
+	}
+	c.parent[rootA] = rootB
+	c.count--
+}
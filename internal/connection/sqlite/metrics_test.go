@@ -0,0 +1,81 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+func TestStorage_GetGraphMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	type edge struct{ from, to int }
+
+	tests := []struct {
+		name    string
+		noNotes bool
+		edges   []edge
+		want    connection.GraphMetrics
+	}{
+		{
+			name:    "empty graph",
+			noNotes: true,
+			want:    connection.GraphMetrics{},
+		},
+		{
+			name: "notes without connections",
+			want: connection.GraphMetrics{Nodes: 3, ConnectedComponents: 3},
+		},
+		{
+			name:  "one connection leaves a note isolated",
+			edges: []edge{{0, 1}},
+			want:  connection.GraphMetrics{Nodes: 3, Edges: 1, Density: 1.0 / 6, AverageDegree: 2.0 / 3, ConnectedComponents: 2},
+		},
+		{
+			name:  "direction does not split a component",
+			edges: []edge{{0, 1}, {2, 1}},
+			want:  connection.GraphMetrics{Nodes: 3, Edges: 2, Density: 2.0 / 6, AverageDegree: 4.0 / 3, ConnectedComponents: 1},
+		},
+		{
+			name:  "self-connection joins nothing",
+			edges: []edge{{0, 0}},
+			want:  connection.GraphMetrics{Nodes: 3, Edges: 1, Density: 1.0 / 6, AverageDegree: 2.0 / 3, ConnectedComponents: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newTestStorage(t)
+
+			if !tt.noNotes {
+				note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+				ids := []int64{note1ID, note2ID, note3ID}
+				for _, e := range tt.edges {
+					_, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: ids[e.from], ToNoteID: ids[e.to], Type: "references", Strength: 5})
+					require.NoError(t, err)
+				}
+			}
+
+			metrics, err := storage.GetGraphMetrics(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Nodes, metrics.Nodes)
+			assert.Equal(t, tt.want.Edges, metrics.Edges)
+			assert.InDelta(t, tt.want.Density, metrics.Density, 1e-9)
+			assert.InDelta(t, tt.want.AverageDegree, metrics.AverageDegree, 1e-9)
+			assert.Equal(t, tt.want.ConnectedComponents, metrics.ConnectedComponents)
+		})
+	}
+
+	t.Run("graph limits apply", func(t *testing.T) {
+		storage := newTestStorage(t, WithGraphLimits(connection.GraphLimits{MaxNodes: 2}))
+		createTestNotes(t, storage.db)
+
+		_, err := storage.GetGraphMetrics(ctx)
+		assert.True(t, errors.Is(err, connection.ErrGraphTooLarge))
+	})
+}
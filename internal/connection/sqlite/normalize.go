@@ -0,0 +1,179 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+// NormalizeStrengths rescales every connection strength with min-max
+// normalization so the lowest strength in use becomes 1 and the highest
+// becomes 10. The mapping is computed and applied in one transaction. It
+// returns the number of connections whose strength changed, or would change
+// when opts.DryRun is set.
+func (s *Storage) NormalizeStrengths(ctx context.Context, opts connection.NormalizeStrengthsOptions) (int64, error) {
+	var result int64
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.normalizeStrengths(ctx, opts)
+		return err
+	})
+	return result, err
+}
+
+// normalizeStrengths makes a single attempt at NormalizeStrengths
+func (s *Storage) normalizeStrengths(ctx context.Context, opts connection.NormalizeStrengthsOptions) (int64, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	mapping, err := strengthMapping(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	var cases []string
+	var args []interface{}
+	var changing []string
+	var changingArgs []interface{}
+	var expected int64
+	for _, m := range mapping {
+		if m.From == m.To {
+			continue
+		}
+		cases = append(cases, "WHEN ? THEN ?")
+		args = append(args, m.From, m.To)
+		changing = append(changing, "?")
+		changingArgs = append(changingArgs, m.From)
+		expected += m.Connections
+	}
+
+	if opts.DryRun || len(cases) == 0 {
+		return expected, nil
+	}
+
+	// A single CASE update keeps a rescaled value from being remapped again
+	// by a later entry, e.g. 4 -> 5 followed by 5 -> 7
+	query := fmt.Sprintf(
+		"UPDATE connections SET strength = CASE strength %s END, updated_at = CURRENT_TIMESTAMP WHERE strength IN (%s)",
+		strings.Join(cases, " "), strings.Join(changing, ", "),
+	)
+
+	type rescaled struct {
+		id       int64
+		strength int
+	}
+	var audited []rescaled
+	if s.audit {
+		rows, err := tx.QueryContext(ctx,
+			fmt.Sprintf("SELECT id, strength FROM connections WHERE strength IN (%s) ORDER BY id", strings.Join(changing, ", ")),
+			changingArgs...)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read connections to normalize: %w", err)
+		}
+		for rows.Next() {
+			var r rescaled
+			if err := rows.Scan(&r.id, &r.strength); err != nil {
+				rows.Close()
+				return 0, fmt.Errorf("failed to scan connection: %w", err)
+			}
+			audited = append(audited, r)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return 0, fmt.Errorf("error iterating rows: %w", err)
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, query, append(args, changingArgs...)...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to normalize strengths: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if len(audited) > 0 {
+		target := make(map[int]int, len(mapping))
+		for _, m := range mapping {
+			target[m.From] = m.To
+		}
+		for _, r := range audited {
+			changes := map[string]interface{}{"strength": auditChange{Old: r.strength, New: target[r.strength]}}
+			if err := recordAudit(ctx, tx, r.id, connection.AuditActionUpdate, changes); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Info("normalized connection strengths", "updated", rowsAffected)
+	return rowsAffected, nil
+}
+
+// PlanStrengthNormalization returns the mapping NormalizeStrengths would apply,
+// one entry per strength value currently in use, ordered by strength
+func (s *Storage) PlanStrengthNormalization(ctx context.Context) ([]connection.StrengthMapping, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	return strengthMapping(ctx, tx)
+}
+
+// strengthMapping reads the strength distribution and maps each value onto
+// the full range. A distribution with a single value is left unchanged since
+// there is no spread to rescale.
+func strengthMapping(ctx context.Context, tx *sql.Tx) ([]connection.StrengthMapping, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT strength, COUNT(*) FROM connections GROUP BY strength ORDER BY strength")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read strength distribution: %w", err)
+	}
+	defer rows.Close()
+
+	mapping := []connection.StrengthMapping{}
+	for rows.Next() {
+		var m connection.StrengthMapping
+		if err := rows.Scan(&m.From, &m.Connections); err != nil {
+			return nil, fmt.Errorf("failed to scan strength distribution: %w", err)
+		}
+		mapping = append(mapping, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate strength distribution: %w", err)
+	}
+
+	if len(mapping) == 0 {
+		return mapping, nil
+	}
+
+	low, high := mapping[0].From, mapping[len(mapping)-1].From
+	for i := range mapping {
+		mapping[i].To = rescaleStrength(mapping[i].From, low, high)
+	}
+	return mapping, nil
+}
+
+// rescaleStrength maps value from [low, high] onto [connection.MinStrength, connection.MaxStrength],
+// rounding half up
+func rescaleStrength(value, low, high int) int {
+	if high == low {
+		return value
+	}
+	span := connection.MaxStrength - connection.MinStrength
+	num := (value - low) * span
+	den := high - low
+	return connection.MinStrength + (2*num+den)/(2*den)
+}
@@ -0,0 +1,118 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+func TestStorage_NormalizeStrengths(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+	t.Run("empty graph", func(t *testing.T) {
+		mapping, err := storage.PlanStrengthNormalization(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, mapping)
+
+		changed, err := storage.NormalizeStrengths(ctx, connection.NormalizeStrengthsOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), changed)
+	})
+
+	create := func(from, to int64, connType string, strength int) int64 {
+		conn, err := storage.Create(ctx, connection.CreateConnectionRequest{
+			FromNoteID: from, ToNoteID: to, Type: connType, Strength: strength,
+		})
+		require.NoError(t, err)
+		return conn.ID
+	}
+
+	// Imported data clustered around 5
+	low := create(note1ID, note2ID, "references", 4)
+	mid := create(note1ID, note3ID, "references", 5)
+	mid2 := create(note2ID, note3ID, "references", 5)
+	high := create(note2ID, note1ID, "supports", 6)
+
+	wantMapping := []connection.StrengthMapping{
+		{From: 4, To: 1, Connections: 1},
+		{From: 5, To: 6, Connections: 2},
+		{From: 6, To: 10, Connections: 1},
+	}
+
+	t.Run("plan", func(t *testing.T) {
+		mapping, err := storage.PlanStrengthNormalization(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, wantMapping, mapping)
+	})
+
+	t.Run("dry run writes nothing", func(t *testing.T) {
+		changed, err := storage.NormalizeStrengths(ctx, connection.NormalizeStrengthsOptions{DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), changed)
+
+		conn, err := storage.Get(ctx, low)
+		require.NoError(t, err)
+		assert.Equal(t, 4, conn.Strength)
+	})
+
+	t.Run("normalize", func(t *testing.T) {
+		changed, err := storage.NormalizeStrengths(ctx, connection.NormalizeStrengthsOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), changed)
+
+		for id, want := range map[int64]int{low: 1, mid: 6, mid2: 6, high: 10} {
+			conn, err := storage.Get(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, want, conn.Strength, "connection %d", id)
+		}
+	})
+
+	t.Run("already normalized", func(t *testing.T) {
+		changed, err := storage.NormalizeStrengths(ctx, connection.NormalizeStrengthsOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), changed)
+	})
+}
+
+func TestStorage_NormalizeStrengths_SingleValue(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, _ := createTestNotes(t, storage.db)
+
+	_, err := storage.Create(ctx, connection.CreateConnectionRequest{
+		FromNoteID: note1ID, ToNoteID: note2ID, Type: "references", Strength: 5,
+	})
+	require.NoError(t, err)
+
+	mapping, err := storage.PlanStrengthNormalization(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []connection.StrengthMapping{{From: 5, To: 5, Connections: 1}}, mapping)
+
+	changed, err := storage.NormalizeStrengths(ctx, connection.NormalizeStrengthsOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), changed)
+}
+
+func TestRescaleStrength(t *testing.T) {
+	tests := []struct {
+		value, low, high, want int
+	}{
+		{value: 1, low: 1, high: 10, want: 1},
+		{value: 10, low: 1, high: 10, want: 10},
+		{value: 4, low: 4, high: 7, want: 1},
+		{value: 5, low: 4, high: 7, want: 4},
+		{value: 6, low: 4, high: 7, want: 7},
+		{value: 7, low: 4, high: 7, want: 10},
+		{value: 3, low: 3, high: 3, want: 3},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, rescaleStrength(tt.value, tt.low, tt.high), "rescale %d in [%d, %d]", tt.value, tt.low, tt.high)
+	}
+}
@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+func TestStorage_ComputePageRank(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+	t.Run("empty graph ranks all notes equally", func(t *testing.T) {
+		ranks, err := storage.ComputePageRank(ctx, connection.PageRankOptions{})
+		require.NoError(t, err)
+		require.Len(t, ranks, 3)
+		for _, r := range ranks {
+			assert.InDelta(t, 1.0/3.0, r.Rank, 1e-9)
+		}
+	})
+
+	// note1 and note2 both point at note3; note1 -> note3 is the strongest edge
+	connections := []connection.CreateConnectionRequest{
+		{FromNoteID: note1ID, ToNoteID: note3ID, Type: "references", Strength: 10},
+		{FromNoteID: note2ID, ToNoteID: note3ID, Type: "references", Strength: 5},
+		{FromNoteID: note1ID, ToNoteID: note2ID, Type: "references", Strength: 1},
+	}
+	for _, req := range connections {
+		_, err := storage.Create(ctx, req)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name     string
+		opts     connection.PageRankOptions
+		wantErr  bool
+		validate func(t *testing.T, ranks []connection.NoteRank)
+	}{
+		{
+			name: "default options",
+			opts: connection.PageRankOptions{},
+			validate: func(t *testing.T, ranks []connection.NoteRank) {
+				require.Len(t, ranks, 3)
+				assert.Equal(t, note3ID, ranks[0].NoteID)
+				assert.Equal(t, note2ID, ranks[1].NoteID)
+				assert.Equal(t, note1ID, ranks[2].NoteID)
+
+				var sum float64
+				for _, r := range ranks {
+					sum += r.Rank
+				}
+				assert.InDelta(t, 1.0, sum, 1e-6)
+			},
+		},
+		{
+			name: "limit results",
+			opts: connection.PageRankOptions{Limit: 1},
+			validate: func(t *testing.T, ranks []connection.NoteRank) {
+				require.Len(t, ranks, 1)
+				assert.Equal(t, note3ID, ranks[0].NoteID)
+			},
+		},
+		{
+			name: "single iteration",
+			opts: connection.PageRankOptions{MaxIterations: 1},
+			validate: func(t *testing.T, ranks []connection.NoteRank) {
+				require.Len(t, ranks, 3)
+				assert.Equal(t, note3ID, ranks[0].NoteID)
+			},
+		},
+		{
+			name:    "invalid damping factor",
+			opts:    connection.PageRankOptions{DampingFactor: 1.5},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranks, err := storage.ComputePageRank(ctx, tt.opts)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.validate != nil {
+				tt.validate(t, ranks)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+// pathEdge is an outgoing connection in the in-memory adjacency list used for path finding
+type pathEdge struct {
+	id       int64
+	to       int64
+	strength int
+}
+
+// FindStrongestPath finds the path of at most opts.MaxDepth connections from
+// one note to another whose weakest connection is strongest. Among paths with
+// the same weakest connection the one with the fewest connections wins, then
+// the one with the highest total strength. Connections are followed in
+// opts.Direction, and connections of the excluded types are skipped. It
+// returns nil when no path exists.
+//
+// Like the other whole-graph operations, it is bounded by the storage's
+// GraphLimits. The searches are also bounded by its TraversalLimits: the
+// depth is capped, and once the notes visited across all searches or the time
+// reach their limit the best path found so far is returned with Truncated
+// set. When none was found the path is empty rather than nil.
+func (s *Storage) FindStrongestPath(ctx context.Context, fromNoteID, toNoteID int64, opts connection.PathOptions) (_ *connection.ConnectionPath, err error) {
+	if opts.MaxDepth < 1 {
+		return nil, fmt.Errorf("max depth must be at least 1, got: %d", opts.MaxDepth)
+	}
+	if fromNoteID == toNoteID {
+		return nil, fmt.Errorf("from and to notes must be different")
+	}
+	direction, err := pathDirection(opts.Direction)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel, err := s.guardGraph(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	defer func() {
+		err = s.graphError(ctx, err)
+	}()
+
+	query := "SELECT id, from_note_id, to_note_id, strength FROM connections"
+	clause, args := excludeTypesClause(opts.ExcludeTypes)
+	if clause != "" {
+		query += " WHERE " + clause
+	}
+	query += " ORDER BY id"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load connections: %w", err)
+	}
+	defer rows.Close()
+
+	adjacency := make(map[int64][]pathEdge)
+	seenStrengths := make(map[int]bool)
+	var strengths []int
+	for rows.Next() {
+		var from int64
+		var edge pathEdge
+		if err := rows.Scan(&edge.id, &from, &edge.to, &edge.strength); err != nil {
+			return nil, fmt.Errorf("failed to scan connection: %w", err)
+		}
+		to := edge.to
+		if direction != connection.DirectionIncoming {
+			adjacency[from] = append(adjacency[from], edge)
+		}
+		// Walking a connection backwards leaves from its to note
+		if direction != connection.DirectionOutgoing {
+			edge.to = from
+			adjacency[to] = append(adjacency[to], edge)
+		}
+		if !seenStrengths[edge.strength] {
+			seenStrengths[edge.strength] = true
+			strengths = append(strengths, edge.strength)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating connections: %w", err)
+	}
+
+	// The first threshold that connects the notes is the strongest weakest
+	// connection any path can have
+	traversal := s.traversal.Start()
+	maxDepth := traversal.Depth(opts.MaxDepth)
+	sort.Sort(sort.Reverse(sort.IntSlice(strengths)))
+	for _, threshold := range strengths {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		ids := shortestPathAbove(adjacency, fromNoteID, toNoteID, maxDepth, threshold, traversal)
+		if ids == nil {
+			continue
+		}
+
+		path, err := s.loadPath(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		return &connection.ConnectionPath{
+			FromNoteID: fromNoteID,
+			ToNoteID:   toNoteID,
+			Path:       path,
+			Length:     len(path),
+			Strength:   threshold,
+			Truncated:  traversal.Truncated(),
+		}, nil
+	}
+
+	if traversal.Truncated() {
+		s.logger.Warn("path search truncated", "from_note_id", fromNoteID, "to_note_id", toNoteID, "visited", traversal.Visited())
+		return &connection.ConnectionPath{
+			FromNoteID: fromNoteID,
+			ToNoteID:   toNoteID,
+			Path:       []connection.Connection{},
+			Truncated:  true,
+		}, nil
+	}
+	return nil, nil
+}
+
+// shortestPathAbove returns the IDs of the connections on the shortest path
+// from one note to another that only uses connections of at least threshold
+// strength, or nil if there is none within maxDepth. Among the shortest paths
+// it picks the one with the highest total strength. Notes traversal does not
+// let it visit are left unexplored.
+func shortestPathAbove(adjacency map[int64][]pathEdge, fromNoteID, toNoteID int64, maxDepth, threshold int, traversal *connection.Traversal) []int64 {
+	type visit struct {
+		depth int
+		total int
+		from  int64 // Previous note on the best path
+		edge  int64 // Connection from the previous note
+	}
+
+	visits := map[int64]*visit{fromNoteID: {}}
+	layer := []int64{fromNoteID}
+	for depth := 1; depth <= maxDepth && len(layer) > 0; depth++ {
+		var next []int64
+		for _, noteID := range layer {
+			current := visits[noteID]
+			for _, edge := range adjacency[noteID] {
+				if edge.strength < threshold {
+					continue
+				}
+				total := current.total + edge.strength
+				reached, ok := visits[edge.to]
+				if !ok {
+					if !traversal.Visit() {
+						continue
+					}
+					visits[edge.to] = &visit{depth: depth, total: total, from: noteID, edge: edge.id}
+					next = append(next, edge.to)
+				} else if reached.depth == depth && total > reached.total {
+					reached.total, reached.from, reached.edge = total, noteID, edge.id
+				}
+			}
+		}
+
+		if target, ok := visits[toNoteID]; ok {
+			ids := make([]int64, target.depth)
+			for noteID := toNoteID; noteID != fromNoteID; noteID = visits[noteID].from {
+				ids[visits[noteID].depth-1] = visits[noteID].edge
+			}
+			return ids
+		}
+		layer = next
+	}
+
+	return nil
+}
+
+// loadPath reads the connections with the given IDs, in that order
+func (s *Storage) loadPath(ctx context.Context, ids []int64) ([]connection.Connection, error) {
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	query := "SELECT " + connectionColumns + " FROM connections WHERE id IN (" + strings.Join(placeholders, ", ") + ")"
+	connections, err := s.queryConnections(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load path connections: %w", err)
+	}
+
+	byID := make(map[int64]connection.Connection, len(connections))
+	for _, conn := range connections {
+		byID[conn.ID] = conn
+	}
+
+	path := make([]connection.Connection, len(ids))
+	for i, id := range ids {
+		path[i] = byID[id]
+	}
+	return path, nil
+}
+
+// pathDirection checks the direction of a path search, which defaults to
+// following connections the way they point
+func pathDirection(direction connection.Direction) (connection.Direction, error) {
+	if direction == "" {
+		return connection.DirectionOutgoing, nil
+	}
+	if !connection.IsValidDirection(string(direction)) {
+		return "", fmt.Errorf("invalid direction: %s", direction)
+	}
+	return direction, nil
+}
+
+// excludeTypesClause builds a condition that skips connections of the given
+// types. Blank types are ignored, and no types give an empty clause.
+func excludeTypesClause(excludeTypes []string) (string, []interface{}) {
+	var placeholders []string
+	var args []interface{}
+	for _, connType := range excludeTypes {
+		connType = strings.TrimSpace(connType)
+		if connType == "" {
+			continue
+		}
+		placeholders = append(placeholders, "?")
+		args = append(args, connType)
+	}
+	if len(placeholders) == 0 {
+		return "", nil
+	}
+	return "type NOT IN (" + strings.Join(placeholders, ", ") + ")", args
+}
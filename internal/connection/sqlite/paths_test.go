@@ -0,0 +1,291 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+func TestStorage_FindStrongestPath(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	ids := map[string]int64{}
+	for _, title := range []string{"A", "B", "C", "D", "E"} {
+		result, err := storage.db.Exec(
+			"INSERT INTO notes (title, content, type, tags, metadata) VALUES (?, ?, ?, ?, ?)",
+			title, "Content", "text", "[]", "{}",
+		)
+		require.NoError(t, err)
+		ids[title], err = result.LastInsertId()
+		require.NoError(t, err)
+	}
+
+	// A reaches D directly through a contradiction, through B by supports,
+	// and through C or E by weaker relates_to connections
+	connIDs := map[string]int64{}
+	for _, c := range []struct {
+		from, to, connType string
+		strength           int
+	}{
+		{"A", "D", "contradicts", 10},
+		{"A", "B", "supports", 9},
+		{"B", "D", "supports", 9},
+		{"A", "C", "relates_to", 6},
+		{"C", "D", "relates_to", 6},
+		{"A", "E", "relates_to", 6},
+		{"E", "D", "relates_to", 8},
+	} {
+		conn, err := storage.Create(ctx, connection.CreateConnectionRequest{
+			FromNoteID: ids[c.from], ToNoteID: ids[c.to], Type: c.connType, Strength: c.strength,
+		})
+		require.NoError(t, err)
+		connIDs[c.from+c.to] = conn.ID
+	}
+
+	tests := []struct {
+		name         string
+		from, to     string
+		maxDepth     int
+		excludeTypes []string
+		direction    connection.Direction
+		wantPath     []string
+		wantStrength int
+		wantErr      string
+	}{
+		{
+			name:         "strongest connection wins",
+			from:         "A",
+			to:           "D",
+			maxDepth:     3,
+			wantPath:     []string{"AD"},
+			wantStrength: 10,
+		},
+		{
+			name:         "excluding contradicts follows supports",
+			from:         "A",
+			to:           "D",
+			maxDepth:     3,
+			excludeTypes: []string{"contradicts"},
+			wantPath:     []string{"AB", "BD"},
+			wantStrength: 9,
+		},
+		{
+			name:         "ties on the weakest connection prefer the higher total",
+			from:         "A",
+			to:           "D",
+			maxDepth:     3,
+			excludeTypes: []string{"contradicts", "supports"},
+			wantPath:     []string{"AE", "ED"},
+			wantStrength: 6,
+		},
+		{
+			name:         "blank excluded types are ignored",
+			from:         "A",
+			to:           "D",
+			maxDepth:     3,
+			excludeTypes: []string{" "},
+			wantPath:     []string{"AD"},
+			wantStrength: 10,
+		},
+		{
+			name:         "every route excluded",
+			from:         "A",
+			to:           "D",
+			maxDepth:     3,
+			excludeTypes: []string{"contradicts", "supports", "relates_to"},
+		},
+		{
+			name:         "too deep once the direct connection is excluded",
+			from:         "A",
+			to:           "D",
+			maxDepth:     1,
+			excludeTypes: []string{"contradicts"},
+		},
+		{
+			name:     "connections are followed in their direction",
+			from:     "D",
+			to:       "A",
+			maxDepth: 3,
+		},
+		{
+			name:      "outgoing is the same as the default",
+			from:      "D",
+			to:        "A",
+			maxDepth:  3,
+			direction: connection.DirectionOutgoing,
+		},
+		{
+			name:         "incoming walks connections backwards",
+			from:         "D",
+			to:           "A",
+			maxDepth:     3,
+			excludeTypes: []string{"contradicts"},
+			direction:    connection.DirectionIncoming,
+			wantPath:     []string{"BD", "AB"},
+			wantStrength: 9,
+		},
+		{
+			name:      "incoming does not follow connections forwards",
+			from:      "A",
+			to:        "D",
+			maxDepth:  3,
+			direction: connection.DirectionIncoming,
+		},
+		{
+			name:         "both mixes directions",
+			from:         "B",
+			to:           "E",
+			maxDepth:     3,
+			direction:    connection.DirectionBoth,
+			wantPath:     []string{"BD", "ED"},
+			wantStrength: 8,
+		},
+		{
+			name:         "both walks a connection backwards",
+			from:         "D",
+			to:           "B",
+			maxDepth:     3,
+			direction:    connection.DirectionBoth,
+			wantPath:     []string{"BD"},
+			wantStrength: 9,
+		},
+		{
+			name:      "invalid direction",
+			from:      "A",
+			to:        "D",
+			maxDepth:  3,
+			direction: "sideways",
+			wantErr:   "invalid direction: sideways",
+		},
+		{
+			name:     "invalid max depth",
+			from:     "A",
+			to:       "D",
+			maxDepth: 0,
+			wantErr:  "max depth must be at least 1, got: 0",
+		},
+		{
+			name:     "same note",
+			from:     "A",
+			to:       "A",
+			maxDepth: 3,
+			wantErr:  "from and to notes must be different",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := storage.FindStrongestPath(ctx, ids[tt.from], ids[tt.to], connection.PathOptions{
+				MaxDepth:     tt.maxDepth,
+				ExcludeTypes: tt.excludeTypes,
+				Direction:    tt.direction,
+			})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			if tt.wantPath == nil {
+				assert.Nil(t, path)
+				return
+			}
+			require.NotNil(t, path)
+
+			var gotIDs []int64
+			for _, conn := range path.Path {
+				gotIDs = append(gotIDs, conn.ID)
+			}
+			var wantIDs []int64
+			for _, name := range tt.wantPath {
+				wantIDs = append(wantIDs, connIDs[name])
+			}
+			assert.Equal(t, wantIDs, gotIDs)
+			assert.Equal(t, ids[tt.from], path.FromNoteID)
+			assert.Equal(t, ids[tt.to], path.ToNoteID)
+			assert.Equal(t, len(tt.wantPath), path.Length)
+			assert.Equal(t, tt.wantStrength, path.Strength)
+		})
+	}
+
+	t.Run("graph limits apply", func(t *testing.T) {
+		limited := newTestStorage(t, WithGraphLimits(connection.GraphLimits{MaxEdges: 1}))
+		note1ID, note2ID, note3ID := createTestNotes(t, limited.db)
+		for _, req := range []connection.CreateConnectionRequest{
+			{FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 5},
+			{FromNoteID: note2ID, ToNoteID: note3ID, Type: "supports", Strength: 5},
+		} {
+			_, err := limited.Create(ctx, req)
+			require.NoError(t, err)
+		}
+
+		_, err := limited.FindStrongestPath(ctx, note1ID, note3ID, connection.PathOptions{MaxDepth: 3})
+		assert.ErrorIs(t, err, connection.ErrGraphTooLarge)
+	})
+
+	t.Run("traversal limits truncate the search", func(t *testing.T) {
+		// A hub with 200 spokes, and the target behind the last spoke
+		star := newTestStorage(t)
+		hub, target, spokes := createStar(t, star, 200)
+		_, err := star.db.Exec("INSERT INTO connections (from_note_id, to_note_id, type, strength, metadata) VALUES (?, ?, 'relates_to', 5, '{}')", spokes[len(spokes)-1], target)
+		require.NoError(t, err)
+
+		path, err := star.FindStrongestPath(ctx, hub, target, connection.PathOptions{MaxDepth: 3})
+		require.NoError(t, err)
+		require.NotNil(t, path)
+		assert.Equal(t, 2, path.Length)
+		assert.False(t, path.Truncated)
+
+		for _, tt := range []struct {
+			name   string
+			limits connection.TraversalLimits
+		}{
+			{name: "node cap", limits: connection.TraversalLimits{MaxNodes: 50}},
+			{name: "depth cap", limits: connection.TraversalLimits{MaxDepth: 1}},
+		} {
+			t.Run(tt.name, func(t *testing.T) {
+				star.traversal = tt.limits
+				t.Cleanup(func() { star.traversal = connection.DefaultTraversalLimits() })
+
+				path, err := star.FindStrongestPath(ctx, hub, target, connection.PathOptions{MaxDepth: 3})
+				require.NoError(t, err)
+				require.NotNil(t, path)
+				assert.True(t, path.Truncated)
+				assert.Empty(t, path.Path)
+			})
+		}
+	})
+}
+
+// createStar creates a hub note connected to spokes new notes and an
+// unconnected target note, returning the hub, target and spoke IDs
+func createStar(t *testing.T, storage *Storage, spokes int) (int64, int64, []int64) {
+	t.Helper()
+
+	insertNote := func(title string) int64 {
+		result, err := storage.db.Exec(
+			"INSERT INTO notes (title, content, type, tags, metadata) VALUES (?, ?, ?, ?, ?)",
+			title, "Content of "+title, "text", "[]", "{}",
+		)
+		require.NoError(t, err)
+		id, err := result.LastInsertId()
+		require.NoError(t, err)
+		return id
+	}
+
+	hub := insertNote("Hub")
+	target := insertNote("Target")
+	ids := make([]int64, spokes)
+	for i := range ids {
+		ids[i] = insertNote(fmt.Sprintf("Spoke %d", i+1))
+		_, err := storage.db.Exec("INSERT INTO connections (from_note_id, to_note_id, type, strength, metadata) VALUES (?, ?, 'relates_to', 5, '{}')", hub, ids[i])
+		require.NoError(t, err)
+	}
+	return hub, target, ids
+}
@@ -0,0 +1,73 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+// PruneConnections deletes every connection whose strength is at most
+// maxStrength, in one transaction, and returns how many were deleted. With
+// dryRun nothing is deleted and the count is of the connections that would be.
+func (s *Storage) PruneConnections(ctx context.Context, maxStrength int, dryRun bool) (int64, error) {
+	if err := connection.ValidateStrength(maxStrength); err != nil {
+		return 0, err
+	}
+
+	if dryRun {
+		var count int64
+		if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM connections WHERE strength <= ?", maxStrength).Scan(&count); err != nil {
+			return 0, fmt.Errorf("failed to count connections: %w", err)
+		}
+		return count, nil
+	}
+
+	var result int64
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.pruneConnections(ctx, maxStrength)
+		return err
+	})
+	return result, err
+}
+
+// pruneConnections makes a single attempt at deleting for PruneConnections
+func (s *Storage) pruneConnections(ctx context.Context, maxStrength int) (int64, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var deleted []connection.Connection
+	if s.audit {
+		deleted, err = queryConnectionsWith(ctx, tx, "SELECT "+connectionColumns+" FROM connections WHERE strength <= ?", maxStrength)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read connections to delete: %w", err)
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM connections WHERE strength <= ?", maxStrength)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete connections: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	for i := range deleted {
+		if err := recordAudit(ctx, tx, deleted[i].ID, connection.AuditActionDelete, auditSnapshot(&deleted[i])); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Info("pruned weak connections", "max_strength", maxStrength, "deleted", rowsAffected)
+	return rowsAffected, nil
+}
@@ -0,0 +1,107 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+func TestStorage_PruneConnections(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		maxStrength   int
+		dryRun        bool
+		wantCount     int64
+		wantRemaining int64
+		wantErr       bool
+	}{
+		{
+			name:          "preview leaves every connection",
+			maxStrength:   3,
+			dryRun:        true,
+			wantCount:     2,
+			wantRemaining: 4,
+		},
+		{
+			name:          "delete at or below the threshold",
+			maxStrength:   3,
+			wantCount:     2,
+			wantRemaining: 2,
+		},
+		{
+			name:          "delete nothing below the weakest",
+			maxStrength:   1,
+			wantCount:     0,
+			wantRemaining: 4,
+		},
+		{
+			name:          "delete everything at the maximum",
+			maxStrength:   connection.MaxStrength,
+			wantCount:     4,
+			wantRemaining: 0,
+		},
+		{
+			name:        "reject a threshold above the maximum",
+			maxStrength: connection.MaxStrength + 1,
+			dryRun:      true,
+			wantErr:     true,
+		},
+		{
+			name:        "reject a threshold below the minimum",
+			maxStrength: connection.MinStrength - 1,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newTestStorage(t, WithAudit(true))
+			note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+			var weakIDs []int64
+			for _, req := range []connection.CreateConnectionRequest{
+				{FromNoteID: note1ID, ToNoteID: note2ID, Type: "relates_to", Strength: 2},
+				{FromNoteID: note2ID, ToNoteID: note3ID, Type: "supports", Strength: 3},
+				{FromNoteID: note1ID, ToNoteID: note3ID, Type: "references", Strength: 4},
+				{FromNoteID: note3ID, ToNoteID: note1ID, Type: "supports", Strength: 9},
+			} {
+				conn, err := storage.Create(ctx, req)
+				require.NoError(t, err)
+				if conn.Strength <= tt.maxStrength {
+					weakIDs = append(weakIDs, conn.ID)
+				}
+			}
+
+			count, err := storage.PruneConnections(ctx, tt.maxStrength, tt.dryRun)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCount, count)
+
+			var remaining int64
+			require.NoError(t, storage.db.QueryRow("SELECT COUNT(*) FROM connections").Scan(&remaining))
+			assert.Equal(t, tt.wantRemaining, remaining)
+
+			// Only real deletes leave a delete entry in the audit log
+			for _, id := range weakIDs {
+				entries, err := storage.GetConnectionAudit(ctx, id)
+				require.NoError(t, err)
+				last := entries[len(entries)-1]
+				if tt.dryRun {
+					assert.Equal(t, connection.AuditActionCreate, last.Action)
+				} else {
+					assert.Equal(t, connection.AuditActionDelete, last.Action)
+				}
+			}
+		})
+	}
+}
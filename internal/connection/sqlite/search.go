@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// descriptionSearchClause returns the condition and arguments that keep
+// connections whose description matches search. Databases migrated before
+// connections_fts was introduced fall back to a substring match on every word.
+func (s *Storage) descriptionSearchClause(ctx context.Context, search string) (string, []interface{}, error) {
+	useFTS, err := s.hasDescriptionFTS(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if useFTS {
+		return "id IN (SELECT rowid FROM connections_fts WHERE connections_fts MATCH ?)", []interface{}{ftsQuery(search)}, nil
+	}
+
+	words := strings.Fields(search)
+	clauses := make([]string, len(words))
+	args := make([]interface{}, len(words))
+	for i, word := range words {
+		clauses[i] = "description LIKE ?"
+		args[i] = "%" + word + "%"
+	}
+	return "(" + strings.Join(clauses, " AND ") + ")", args, nil
+}
+
+// hasDescriptionFTS reports whether the connections_fts table exists
+func (s *Storage) hasDescriptionFTS(ctx context.Context) (bool, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'connections_fts'").Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check full-text search table: %w", err)
+	}
+	return count > 0, nil
+}
+
+// ftsQuery turns free text into an FTS5 query that matches every word as a
+// prefix. Words are quoted so user input cannot inject FTS5 syntax.
+func ftsQuery(search string) string {
+	words := strings.Fields(search)
+	for i, word := range words {
+		words[i] = `"` + strings.ReplaceAll(word, `"`, `""`) + `"*`
+	}
+	return strings.Join(words, " ")
+}
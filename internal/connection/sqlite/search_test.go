@@ -0,0 +1,122 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+func TestStorage_ListDescriptionSearch(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+	create := func(from, to int64, connType string, description *string) int64 {
+		conn, err := storage.Create(ctx, connection.CreateConnectionRequest{
+			FromNoteID: from, ToNoteID: to, Type: connType, Strength: 5, Description: description,
+		})
+		require.NoError(t, err)
+		return conn.ID
+	}
+	benchmark := create(note1ID, note2ID, "supports", strPtr("Benchmarks confirm the caching rationale"))
+	cafe := create(note1ID, note3ID, "supports", strPtr("Both notes came out of the Café meeting"))
+	caching := create(note2ID, note3ID, "references", strPtr("Explains the cache eviction policy"))
+	create(note3ID, note1ID, "references", nil)
+
+	tests := []struct {
+		name     string
+		search   string
+		connType *string
+		wantIDs  []int64
+	}{
+		{name: "single word", search: "rationale", wantIDs: []int64{benchmark}},
+		{name: "case insensitive", search: "BENCHMARKS", wantIDs: []int64{benchmark}},
+		{name: "accents folded", search: "cafe", wantIDs: []int64{cafe}},
+		{name: "word prefix", search: "cach", wantIDs: []int64{benchmark, caching}},
+		{name: "every word must match", search: "cache policy", wantIDs: []int64{caching}},
+		{name: "combined with other filters", search: "cach", connType: strPtr("references"), wantIDs: []int64{caching}},
+		{name: "no match", search: "unrelated", wantIDs: []int64{}},
+		{name: "fts syntax is matched literally", search: `rationale OR "cafe`, wantIDs: []int64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := storage.List(ctx, connection.ListConnectionsRequest{
+				Limit: 10, DescriptionSearch: &tt.search, Type: tt.connType, OrderBy: "id", OrderDir: "asc",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantIDs, connectionIDs(response.Items))
+			assert.Equal(t, int64(len(tt.wantIDs)), response.Total)
+		})
+	}
+
+	search := func(t *testing.T, term string) []int64 {
+		t.Helper()
+		response, err := storage.List(ctx, connection.ListConnectionsRequest{
+			Limit: 10, DescriptionSearch: &term, OrderBy: "id", OrderDir: "asc",
+		})
+		require.NoError(t, err)
+		return connectionIDs(response.Items)
+	}
+
+	t.Run("updated description is reindexed", func(t *testing.T) {
+		_, err := storage.Update(ctx, caching, connection.UpdateConnectionRequest{Description: strPtr("Explains the retry backoff")})
+		require.NoError(t, err)
+
+		assert.Equal(t, []int64{caching}, search(t, "backoff"))
+		assert.Equal(t, []int64{benchmark}, search(t, "cach"))
+	})
+
+	t.Run("trashed and deleted connections are not found", func(t *testing.T) {
+		require.NoError(t, storage.SoftDelete(ctx, cafe))
+		assert.Empty(t, search(t, "cafe"))
+
+		_, err := storage.Restore(ctx, cafe)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{cafe}, search(t, "cafe"))
+
+		require.NoError(t, storage.Delete(ctx, cafe))
+		assert.Empty(t, search(t, "cafe"))
+	})
+
+	t.Run("falls back to substring match without the fts table", func(t *testing.T) {
+		_, err := storage.db.Exec(`
+			DROP TRIGGER connections_fts_insert;
+			DROP TRIGGER connections_fts_update;
+			DROP TRIGGER connections_fts_delete;
+			DROP TABLE connections_fts`)
+		require.NoError(t, err)
+
+		assert.Equal(t, []int64{benchmark}, search(t, "confirm rationale"))
+	})
+}
+
+func TestFTSQuery(t *testing.T) {
+	tests := []struct {
+		search string
+		want   string
+	}{
+		{search: "cache", want: `"cache"*`},
+		{search: "  cache   policy ", want: `"cache"* "policy"*`},
+		{search: `say "hi"`, want: `"say"* """hi"""*`},
+		{search: "a OR b", want: `"a"* "OR"* "b"*`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.search, func(t *testing.T) {
+			assert.Equal(t, tt.want, ftsQuery(tt.search))
+		})
+	}
+}
+
+func connectionIDs(conns []connection.Connection) []int64 {
+	ids := []int64{}
+	for _, conn := range conns {
+		ids = append(ids, conn.ID)
+	}
+	return ids
+}
@@ -5,22 +5,118 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"strings"
-
-	_ "github.com/ncruces/go-sqlite3/driver"
-	_ "github.com/ncruces/go-sqlite3/embed"
+	"time"
 
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/logging"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/sqlitedb"
 )
 
 // Storage implements the connection.Storage interface using SQLite
 type Storage struct {
-	db *sql.DB
+	db                *sql.DB
+	limits            connection.GraphLimits
+	traversal         connection.TraversalLimits
+	logger            *slog.Logger
+	audit             bool
+	edgeMode          connection.EdgeMode
+	retry             sqlitedb.RetryPolicy
+	weights           connection.StrengthWeights
+	idempotencyWindow time.Duration
+	notes             NoteInserter
+}
+
+// Option configures a Storage
+type Option func(*Storage)
+
+// WithGraphLimits sets the node, edge and time limits for expensive graph operations
+func WithGraphLimits(limits connection.GraphLimits) Option {
+	return func(s *Storage) {
+		s.limits = limits
+	}
+}
+
+// WithTraversalLimits sets the node, depth and time limits for walks from a note
+func WithTraversalLimits(limits connection.TraversalLimits) Option {
+	return func(s *Storage) {
+		s.traversal = limits
+	}
+}
+
+// WithLogger sets the logger used for database lifecycle, maintenance and graph limit events
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Storage) {
+		s.logger = logging.OrDiscard(logger)
+	}
+}
+
+// WithAudit records every create, update and delete in the connection_audit
+// table, in the same transaction as the change. It is off by default.
+func WithAudit(enabled bool) Option {
+	return func(s *Storage) {
+		s.audit = enabled
+	}
+}
+
+// WithEdgeMode sets whether two notes may share several connections of the
+// same type. The default is connection.EdgeModeUnique.
+func WithEdgeMode(mode connection.EdgeMode) Option {
+	return func(s *Storage) {
+		s.edgeMode = mode
+	}
+}
+
+// WithStrengthWeights sets how SuggestStrength weighs shared tags, shared
+// neighbors and content similarity. The default is
+// connection.DefaultStrengthWeights.
+func WithStrengthWeights(weights connection.StrengthWeights) Option {
+	return func(s *Storage) {
+		s.weights = weights
+	}
+}
+
+// WithIdempotencyWindow sets how long Create and CreateWithInverse remember an
+// idempotency key. A key older than window is forgotten and creates a new
+// connection; 0 keeps keys forever. The default is
+// sqlitedb.DefaultIdempotencyWindow.
+func WithIdempotencyWindow(window time.Duration) Option {
+	return func(s *Storage) {
+		s.idempotencyWindow = window
+	}
+}
+
+// WithNoteInserter sets the note storage that creates the notes of
+// CreateConnectionEnsuringNotes, so they get the same normalization and
+// validation as notes created with create_note. Without it,
+// CreateConnectionEnsuringNotes fails when a note must be created.
+func WithNoteInserter(notes NoteInserter) Option {
+	return func(s *Storage) {
+		s.notes = notes
+	}
+}
+
+// WithRetry sets how writes are retried when another connection holds the
+// database lock. The default is sqlitedb.DefaultRetryPolicy.
+func WithRetry(policy sqlitedb.RetryPolicy) Option {
+	return func(s *Storage) {
+		s.retry = policy
+	}
+}
+
+// withRetry runs fn under the retry policy and logs writes that stay busy
+func (s *Storage) withRetry(ctx context.Context, fn func() error) error {
+	err := sqlitedb.Retry(ctx, s.retry, fn)
+	if sqlitedb.IsBusy(err) {
+		s.logger.Warn("database still locked after retries", "storage", "connection", "retries", s.retry.MaxRetries, "error", err)
+	}
+	return err
 }
 
 // NewStorage creates a new SQLite storage instance
-func NewStorage(dbPath string) (*Storage, error) {
-	db, err := sql.Open("sqlite3", dbPath)
+func NewStorage(dbPath string, opts ...Option) (*Storage, error) {
+	db, err := sqlitedb.Open(dbPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -29,7 +125,13 @@ func NewStorage(dbPath string) (*Storage, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &Storage{db: db}, nil
+	s := &Storage{db: db, limits: connection.DefaultGraphLimits(), traversal: connection.DefaultTraversalLimits(), logger: logging.Discard(), edgeMode: connection.EdgeModeUnique, retry: sqlitedb.DefaultRetryPolicy(), weights: connection.DefaultStrengthWeights(), idempotencyWindow: sqlitedb.DefaultIdempotencyWindow}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.logger.Debug("opened database", "storage", "connection", "dsn", dbPath)
+	return s, nil
 }
 
 // Close closes the database connection
@@ -40,29 +142,108 @@ func (s *Storage) Close() error {
 	return nil
 }
 
-// Create creates a new connection
+// Create creates a new connection. With an idempotency key that an earlier
+// create used within the idempotency window, the connection that call created
+// is returned and req is otherwise ignored.
 func (s *Storage) Create(ctx context.Context, req connection.CreateConnectionRequest) (*connection.Connection, error) {
+	var result *connection.Connection
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.create(ctx, req)
+		return err
+	})
+	return result, err
+}
+
+// create makes a single attempt at Create
+func (s *Storage) create(ctx context.Context, req connection.CreateConnectionRequest) (*connection.Connection, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	id, replayed, err := sqlitedb.CreateOnce(ctx, tx, sqlitedb.IdempotencyConnections, req.IdempotencyKey, s.idempotencyWindow, func() (int64, error) {
+		return s.insertConnection(ctx, tx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if replayed {
+		s.logger.Info("returned connection for repeated idempotency key", "connection_id", id)
+	}
+	return s.Get(ctx, id)
+}
+
+// execer is the subset of *sql.DB and *sql.Tx used to read and write connections
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// connectionColumns lists the columns scanned into a connection.Connection
+const connectionColumns = "id, from_note_id, to_note_id, type, description, strength, metadata, source, created_at, updated_at"
+
+// insertConnection validates req and inserts it, returning the new connection ID.
+// With req.AutoSymmetric the reverse of a symmetric connection is inserted too.
+func (s *Storage) insertConnection(ctx context.Context, db execer, req connection.CreateConnectionRequest) (int64, error) {
 	// Validate connection type
 	if !connection.IsValidConnectionType(req.Type) {
-		return nil, fmt.Errorf("invalid connection type: %s", req.Type)
+		return 0, fmt.Errorf("invalid connection type: %s", req.Type)
 	}
 
 	// Validate strength
-	if req.Strength < 1 || req.Strength > 10 {
-		return nil, fmt.Errorf("strength must be between 1 and 10, got: %d", req.Strength)
+	if err := connection.ValidateStrength(req.Strength); err != nil {
+		return 0, err
 	}
 
 	// Validate description length
-	if req.Description != nil && len(*req.Description) > 500 {
-		return nil, fmt.Errorf("description must be 500 characters or less")
+	if err := connection.ValidateDescription(req.Description); err != nil {
+		return 0, err
+	}
+
+	// Validate self-connections against the type's policy
+	if req.FromNoteID == req.ToNoteID && !connection.AllowsSelfLoop(req.Type) {
+		return 0, selfLoopError(req.Type)
+	}
+
+	if err := s.checkDuplicate(ctx, db, 0, req.FromNoteID, req.ToNoteID, req.Type, false); err != nil {
+		return 0, err
 	}
 
+	id, err := s.insertRow(ctx, db, req)
+	if err != nil {
+		return 0, err
+	}
+
+	// The duplicate check above already covered the reverse direction of a
+	// symmetric type, so the reverse row needs no check of its own
+	if req.AutoSymmetric && req.FromNoteID != req.ToNoteID &&
+		connection.ConnectionType(req.Type).Directionality() == connection.DirectionalitySymmetric {
+		reverse := req
+		reverse.FromNoteID, reverse.ToNoteID = req.ToNoteID, req.FromNoteID
+		if _, err := s.insertRow(ctx, db, reverse); err != nil {
+			return 0, fmt.Errorf("failed to create reverse connection: %w", err)
+		}
+	}
+
+	return id, nil
+}
+
+// insertRow inserts an already validated connection and audits it
+func (s *Storage) insertRow(ctx context.Context, db execer, req connection.CreateConnectionRequest) (int64, error) {
 	// Serialize metadata
 	var metadataJSON string
 	if req.Metadata != nil {
 		metadataBytes, err := json.Marshal(req.Metadata)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+			return 0, fmt.Errorf("failed to marshal metadata: %w", err)
 		}
 		metadataJSON = string(metadataBytes)
 	} else {
@@ -70,48 +251,98 @@ func (s *Storage) Create(ctx context.Context, req connection.CreateConnectionReq
 	}
 
 	query := `
-		INSERT INTO connections (from_note_id, to_note_id, type, description, strength, metadata)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO connections (from_note_id, to_note_id, type, description, strength, metadata, source)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
 	`
 
-	result, err := s.db.ExecContext(ctx, query, req.FromNoteID, req.ToNoteID, req.Type, req.Description, req.Strength, metadataJSON)
+	result, err := db.ExecContext(ctx, query, req.FromNoteID, req.ToNoteID, req.Type, req.Description, req.Strength, metadataJSON, req.Source)
 	if err != nil {
 		// Check for foreign key constraint violations
 		if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
-			return nil, fmt.Errorf("invalid note ID: one or both notes do not exist")
+			return 0, fmt.Errorf("invalid note ID: one or both notes do not exist")
 		}
-		// Check for unique constraint violations
-		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
-			return nil, fmt.Errorf("connection already exists between these notes with this type")
+		if isStrengthCheckError(err) {
+			return 0, connection.ValidateStrength(req.Strength)
 		}
-		// Check for self-connection prevention
-		if strings.Contains(err.Error(), "Self-connections are not allowed") {
-			return nil, fmt.Errorf("self-connections are not allowed")
-		}
-		return nil, fmt.Errorf("failed to create connection: %w", err)
+		return 0, fmt.Errorf("failed to create connection: %w", err)
 	}
 
 	id, err := result.LastInsertId()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get last insert ID: %w", err)
+		return 0, fmt.Errorf("failed to get last insert ID: %w", err)
 	}
 
-	return s.Get(ctx, id)
+	if s.audit {
+		created, err := getConnection(ctx, db, id)
+		if err != nil {
+			return 0, err
+		}
+		if err := recordAudit(ctx, db, id, connection.AuditActionCreate, auditSnapshot(created)); err != nil {
+			return 0, err
+		}
+	}
+
+	return id, nil
+}
+
+// checkDuplicate rejects a connection of connectionType between the two notes
+// when the unique edge mode already has one. Symmetric types are stored once
+// per note pair, so the reverse direction counts too unless allowReverse is
+// set, as when restoring one half of a pair stored with AutoSymmetric.
+// excludeID skips the connection being updated; pass 0 when inserting.
+func (s *Storage) checkDuplicate(ctx context.Context, db execer, excludeID, fromNoteID, toNoteID int64, connectionType string, allowReverse bool) error {
+	if s.edgeMode == connection.EdgeModeMulti {
+		return nil
+	}
+
+	query := "SELECT COUNT(*) FROM connections WHERE from_note_id = ? AND to_note_id = ? AND type = ? AND id != ?"
+
+	var count int64
+	if err := db.QueryRowContext(ctx, query, fromNoteID, toNoteID, connectionType, excludeID).Scan(&count); err != nil {
+		return fmt.Errorf("failed to check for duplicate connection: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("connection already exists between these notes with this type")
+	}
+
+	if connection.IsSymmetricConnectionType(connectionType) && !allowReverse {
+		if err := db.QueryRowContext(ctx, query, toNoteID, fromNoteID, connectionType, excludeID).Scan(&count); err != nil {
+			return fmt.Errorf("failed to check reverse connection: %w", err)
+		}
+		if count > 0 {
+			return fmt.Errorf("connection already exists between these notes with this type (%s is symmetric)", connectionType)
+		}
+	}
+	return nil
+}
+
+// selfLoopError reports that connectionType does not allow self-connections
+func selfLoopError(connectionType string) error {
+	return fmt.Errorf("self-connections are not allowed for %s connections", connectionType)
+}
+
+// isStrengthCheckError reports whether err is the CHECK constraint on
+// connections.strength rejecting a value. Writers validate strengths first,
+// so this only fires when the schema and connection.MinStrength or
+// connection.MaxStrength disagree.
+func isStrengthCheckError(err error) bool {
+	return strings.Contains(err.Error(), "CHECK constraint failed: strength")
 }
 
 // Get retrieves a connection by ID
 func (s *Storage) Get(ctx context.Context, id int64) (*connection.Connection, error) {
-	query := `
-		SELECT id, from_note_id, to_note_id, type, description, strength, metadata, created_at, updated_at
-		FROM connections
-		WHERE id = ?
-	`
+	return getConnection(ctx, s.db, id)
+}
+
+// getConnection retrieves a connection by ID through db, which may be a transaction
+func getConnection(ctx context.Context, db execer, id int64) (*connection.Connection, error) {
+	query := "SELECT " + connectionColumns + " FROM connections WHERE id = ?"
 
 	var conn connection.Connection
-	var description sql.NullString
+	var description, source sql.NullString
 	var metadataJSON string
 
-	err := s.db.QueryRowContext(ctx, query, id).Scan(
+	err := db.QueryRowContext(ctx, query, id).Scan(
 		&conn.ID,
 		&conn.FromNoteID,
 		&conn.ToNoteID,
@@ -119,12 +350,13 @@ func (s *Storage) Get(ctx context.Context, id int64) (*connection.Connection, er
 		&description,
 		&conn.Strength,
 		&metadataJSON,
+		&source,
 		&conn.CreatedAt,
 		&conn.UpdatedAt,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("connection not found: %d", id)
+			return nil, fmt.Errorf("%w: %d", connection.ErrNotFound, id)
 		}
 		return nil, fmt.Errorf("failed to get connection: %w", err)
 	}
@@ -132,6 +364,9 @@ func (s *Storage) Get(ctx context.Context, id int64) (*connection.Connection, er
 	if description.Valid {
 		conn.Description = &description.String
 	}
+	if source.Valid {
+		conn.Source = &source.String
+	}
 
 	if err := json.Unmarshal([]byte(metadataJSON), &conn.Metadata); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
@@ -140,8 +375,61 @@ func (s *Storage) Get(ctx context.Context, id int64) (*connection.Connection, er
 	return &conn, nil
 }
 
+// GetMany retrieves the connections with the given IDs in one query.
+// Connections are returned in the order of ids, once each; unknown IDs are
+// left out.
+func (s *Storage) GetMany(ctx context.Context, ids []int64) ([]connection.Connection, error) {
+	if len(ids) == 0 {
+		return []connection.Connection{}, nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	query := "SELECT " + connectionColumns + " FROM connections WHERE id IN (" + strings.Join(placeholders, ", ") + ")"
+	conns, err := s.queryConnections(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connections: %w", err)
+	}
+
+	byID := make(map[int64]connection.Connection, len(conns))
+	for _, conn := range conns {
+		byID[conn.ID] = conn
+	}
+
+	items := make([]connection.Connection, 0, len(conns))
+	for _, id := range ids {
+		if conn, ok := byID[id]; ok {
+			items = append(items, conn)
+			delete(byID, id)
+		}
+	}
+	return items, nil
+}
+
 // Update updates an existing connection
 func (s *Storage) Update(ctx context.Context, id int64, req connection.UpdateConnectionRequest) (*connection.Connection, error) {
+	var result *connection.Connection
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.update(ctx, id, req)
+		return err
+	})
+	return result, err
+}
+
+// update makes a single attempt at Update
+func (s *Storage) update(ctx context.Context, id int64, req connection.UpdateConnectionRequest) (*connection.Connection, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	// Build dynamic update query
 	var setClauses []string
 	var args []interface{}
@@ -150,21 +438,35 @@ func (s *Storage) Update(ctx context.Context, id int64, req connection.UpdateCon
 		if !connection.IsValidConnectionType(*req.Type) {
 			return nil, fmt.Errorf("invalid connection type: %s", *req.Type)
 		}
+		// A missing connection is reported by the update itself
+		var fromNoteID, toNoteID int64
+		err := tx.QueryRowContext(ctx, "SELECT from_note_id, to_note_id FROM connections WHERE id = ?", id).Scan(&fromNoteID, &toNoteID)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, fmt.Errorf("failed to read connection notes: %w", err)
+		}
+		if err == nil {
+			if fromNoteID == toNoteID && !connection.AllowsSelfLoop(*req.Type) {
+				return nil, selfLoopError(*req.Type)
+			}
+			if err := s.checkDuplicate(ctx, tx, id, fromNoteID, toNoteID, *req.Type, false); err != nil {
+				return nil, err
+			}
+		}
 		setClauses = append(setClauses, "type = ?")
 		args = append(args, *req.Type)
 	}
 
 	if req.Description != nil {
-		if len(*req.Description) > 500 {
-			return nil, fmt.Errorf("description must be 500 characters or less")
+		if err := connection.ValidateDescription(req.Description); err != nil {
+			return nil, err
 		}
 		setClauses = append(setClauses, "description = ?")
 		args = append(args, *req.Description)
 	}
 
 	if req.Strength != nil {
-		if *req.Strength < 1 || *req.Strength > 10 {
-			return nil, fmt.Errorf("strength must be between 1 and 10, got: %d", *req.Strength)
+		if err := connection.ValidateStrength(*req.Strength); err != nil {
+			return nil, err
 		}
 		setClauses = append(setClauses, "strength = ?")
 		args = append(args, *req.Strength)
@@ -183,19 +485,35 @@ func (s *Storage) Update(ctx context.Context, id int64, req connection.UpdateCon
 		return s.Get(ctx, id)
 	}
 
+	// Keep the previous values so the audit entry records only what changed
+	var before *connection.Connection
+	if s.audit {
+		if before, err = getConnection(ctx, tx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	// Optimistic concurrency: only update the version the caller last read
+	versionClause := ""
+	if req.ExpectedUpdatedAt != nil {
+		versionClause = " AND datetime(updated_at) = ?"
+	}
+
 	query := fmt.Sprintf(`
 		UPDATE connections
 		SET %s, updated_at = CURRENT_TIMESTAMP
-		WHERE id = ?
-	`, strings.Join(setClauses, ", "))
+		WHERE id = ?%s
+	`, strings.Join(setClauses, ", "), versionClause)
 
 	args = append(args, id)
+	if req.ExpectedUpdatedAt != nil {
+		args = append(args, req.ExpectedUpdatedAt.UTC().Format(sqlitedb.TimestampLayout))
+	}
 
-	result, err := s.db.ExecContext(ctx, query, args...)
+	result, err := tx.ExecContext(ctx, query, args...)
 	if err != nil {
-		// Check for unique constraint violations
-		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
-			return nil, fmt.Errorf("connection already exists between these notes with this type")
+		if req.Strength != nil && isStrengthCheckError(err) {
+			return nil, connection.ValidateStrength(*req.Strength)
 		}
 		return nil, fmt.Errorf("failed to update connection: %w", err)
 	}
@@ -206,7 +524,32 @@ func (s *Storage) Update(ctx context.Context, id int64, req connection.UpdateCon
 	}
 
 	if rowsAffected == 0 {
-		return nil, fmt.Errorf("connection not found: %d", id)
+		tx.Rollback()
+		if req.ExpectedUpdatedAt != nil {
+			current, err := s.Get(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: connection %d was updated at %s, expected %s", connection.ErrConflict, id,
+				current.UpdatedAt.UTC().Format(time.RFC3339), req.ExpectedUpdatedAt.UTC().Format(time.RFC3339))
+		}
+		return nil, fmt.Errorf("%w: %d", connection.ErrNotFound, id)
+	}
+
+	if s.audit {
+		after, err := getConnection(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+		if changes := auditChanges(before, after); len(changes) > 0 {
+			if err := recordAudit(ctx, tx, id, connection.AuditActionUpdate, changes); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return s.Get(ctx, id)
@@ -214,9 +557,29 @@ func (s *Storage) Update(ctx context.Context, id int64, req connection.UpdateCon
 
 // Delete deletes a connection by ID
 func (s *Storage) Delete(ctx context.Context, id int64) error {
+	return s.withRetry(ctx, func() error {
+		return s.delete(ctx, id)
+	})
+}
+
+// delete makes a single attempt at Delete
+func (s *Storage) delete(ctx context.Context, id int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var deleted *connection.Connection
+	if s.audit {
+		if deleted, err = getConnection(ctx, tx, id); err != nil {
+			return err
+		}
+	}
+
 	query := "DELETE FROM connections WHERE id = ?"
 
-	result, err := s.db.ExecContext(ctx, query, id)
+	result, err := tx.ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete connection: %w", err)
 	}
@@ -227,63 +590,229 @@ func (s *Storage) Delete(ctx context.Context, id int64) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("connection not found: %d", id)
+		return fmt.Errorf("%w: %d", connection.ErrNotFound, id)
+	}
+
+	if deleted != nil {
+		if err := recordAudit(ctx, tx, id, connection.AuditActionDelete, auditSnapshot(deleted)); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return nil
 }
 
-// List lists connections with pagination and filtering
-func (s *Storage) List(ctx context.Context, req connection.ListConnectionsRequest) (*connection.ListConnectionsResponse, error) {
-	// Build query
-	var whereClauses []string
-	var args []interface{}
+// DeleteBetween deletes all connections between two notes in either direction,
+// optionally restricted to a single type. It returns the number of removed
+// connections and is idempotent: no matching connections is not an error.
+func (s *Storage) DeleteBetween(ctx context.Context, fromNoteID, toNoteID int64, connType *string) (int64, error) {
+	var result int64
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.deleteBetween(ctx, fromNoteID, toNoteID, connType)
+		return err
+	})
+	return result, err
+}
 
-	if req.FromNoteID != nil {
-		whereClauses = append(whereClauses, "from_note_id = ?")
-		args = append(args, *req.FromNoteID)
+// deleteBetween makes a single attempt at DeleteBetween
+func (s *Storage) deleteBetween(ctx context.Context, fromNoteID, toNoteID int64, connType *string) (int64, error) {
+	where := "((from_note_id = ? AND to_note_id = ?) OR (from_note_id = ? AND to_note_id = ?))"
+	args := []interface{}{fromNoteID, toNoteID, toNoteID, fromNoteID}
+
+	if connType != nil {
+		if !connection.IsValidConnectionType(*connType) {
+			return 0, fmt.Errorf("invalid connection type: %s", *connType)
+		}
+		where += " AND type = ?"
+		args = append(args, *connType)
 	}
 
-	if req.ToNoteID != nil {
-		whereClauses = append(whereClauses, "to_note_id = ?")
-		args = append(args, *req.ToNoteID)
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	if req.Type != nil {
-		whereClauses = append(whereClauses, "type = ?")
-		args = append(args, *req.Type)
+	var deleted []connection.Connection
+	if s.audit {
+		deleted, err = queryConnectionsWith(ctx, tx, "SELECT "+connectionColumns+" FROM connections WHERE "+where, args...)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read connections to delete: %w", err)
+		}
 	}
 
-	if req.Strength != nil {
-		whereClauses = append(whereClauses, "strength = ?")
-		args = append(args, *req.Strength)
+	result, err := tx.ExecContext(ctx, "DELETE FROM connections WHERE "+where, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete connections: %w", err)
 	}
 
-	whereClause := ""
-	if len(whereClauses) > 0 {
-		whereClause = "WHERE " + strings.Join(whereClauses, " AND ")
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	for i := range deleted {
+		if err := recordAudit(ctx, tx, deleted[i].ID, connection.AuditActionDelete, auditSnapshot(&deleted[i])); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return rowsAffected, nil
+}
+
+// RetypeConnections changes the type of every connection of fromType to toType.
+// In the unique edge mode nothing is updated if any connection would collide
+// with one that already has toType; the error wraps connection.ErrRetypeConflict.
+func (s *Storage) RetypeConnections(ctx context.Context, fromType, toType string) (int64, error) {
+	var result int64
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.retypeConnections(ctx, fromType, toType)
+		return err
+	})
+	return result, err
+}
+
+// retypeConnections makes a single attempt at RetypeConnections
+func (s *Storage) retypeConnections(ctx context.Context, fromType, toType string) (int64, error) {
+	if !connection.IsValidConnectionType(fromType) {
+		return 0, fmt.Errorf("invalid connection type: %s", fromType)
+	}
+	if !connection.IsValidConnectionType(toType) {
+		return 0, fmt.Errorf("invalid connection type: %s", toType)
+	}
+	if fromType == toType {
+		return 0, fmt.Errorf("from_type and to_type cannot be the same")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// A connection collides with an existing toType edge between the same
+	// notes; symmetric types also count the reverse direction, including
+	// pairs of fromType edges that would become each other's reverse
+	conflictQuery := `
+		SELECT COUNT(*) FROM connections c
+		WHERE c.type = ? AND EXISTS (
+			SELECT 1 FROM connections o
+			WHERE o.type = ? AND o.from_note_id = c.from_note_id AND o.to_note_id = c.to_note_id
+		)
+	`
+	if connection.IsSymmetricConnectionType(toType) {
+		conflictQuery = `
+			SELECT COUNT(*) FROM connections c
+			WHERE c.type = ? AND EXISTS (
+				SELECT 1 FROM connections o
+				WHERE (o.type = ? AND ((o.from_note_id = c.from_note_id AND o.to_note_id = c.to_note_id)
+						OR (o.from_note_id = c.to_note_id AND o.to_note_id = c.from_note_id)))
+					OR (o.type = c.type AND o.from_note_id = c.to_note_id AND o.to_note_id = c.from_note_id
+						AND o.id < c.id)
+			)
+		`
+	}
+
+	if s.edgeMode != connection.EdgeModeMulti {
+		var conflicts int64
+		if err := tx.QueryRowContext(ctx, conflictQuery, fromType, toType).Scan(&conflicts); err != nil {
+			return 0, fmt.Errorf("failed to check for conflicting connections: %w", err)
+		}
+		if conflicts > 0 {
+			return 0, fmt.Errorf("%w: %d %s connections would duplicate existing %s connections, delete them first", connection.ErrRetypeConflict, conflicts, fromType, toType)
+		}
+	}
+
+	if !connection.AllowsSelfLoop(toType) {
+		var selfLoops int64
+		err := tx.QueryRowContext(ctx,
+			"SELECT COUNT(*) FROM connections WHERE type = ? AND from_note_id = to_note_id", fromType,
+		).Scan(&selfLoops)
+		if err != nil {
+			return 0, fmt.Errorf("failed to check for self-connections: %w", err)
+		}
+		if selfLoops > 0 {
+			return 0, fmt.Errorf("%d %s connections are self-connections, which %s connections do not allow", selfLoops, fromType, toType)
+		}
+	}
+
+	var retyped []int64
+	if s.audit {
+		if retyped, err = queryIDs(ctx, tx, "SELECT id FROM connections WHERE type = ? ORDER BY id", fromType); err != nil {
+			return 0, fmt.Errorf("failed to read connections to retype: %w", err)
+		}
+	}
+
+	result, err := tx.ExecContext(ctx,
+		"UPDATE connections SET type = ?, updated_at = CURRENT_TIMESTAMP WHERE type = ?",
+		toType, fromType,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retype connections: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	for _, id := range retyped {
+		changes := map[string]interface{}{"type": auditChange{Old: fromType, New: toType}}
+		if err := recordAudit(ctx, tx, id, connection.AuditActionUpdate, changes); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Info("retyped connections", "from_type", fromType, "to_type", toType, "updated", rowsAffected)
+	return rowsAffected, nil
+}
+
+// List lists connections with pagination and filtering
+func (s *Storage) List(ctx context.Context, req connection.ListConnectionsRequest) (*connection.ListConnectionsResponse, error) {
+	if req.StrengthDecay != nil && !(req.StrengthDecay.HalfLifeDays > 0) {
+		return nil, fmt.Errorf("strength decay half-life must be positive, got: %v", req.StrengthDecay.HalfLifeDays)
+	}
+
+	whereClause, args, err := s.listFilter(ctx, req)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get total count
-	countQuery := "SELECT COUNT(*) FROM connections " + whereClause
-	var total int64
-	if err := s.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
-		return nil, fmt.Errorf("failed to count connections: %w", err)
+	total, err := s.countConnections(ctx, whereClause, args)
+	if err != nil {
+		return nil, err
 	}
 
 	// Build order clause
-	orderClause := "ORDER BY created_at DESC"
-	if req.OrderBy != "" {
-		direction := "ASC"
-		if req.OrderDir == "desc" {
-			direction = "DESC"
-		}
-		orderClause = fmt.Sprintf("ORDER BY %s %s", req.OrderBy, direction)
+	orderClause, err := buildOrderClause(req.OrderBy, req.OrderDir)
+	if err != nil {
+		return nil, err
+	}
+	if req.StrengthDecay != nil {
+		// Effective strength decays with age; ties fall back to the newest first.
+		// Clamp the age so clock skew can never boost a connection above its strength.
+		orderClause = "ORDER BY strength * exp(-? * max(julianday('now') - julianday(created_at), 0)) DESC, created_at DESC, id ASC"
+		args = append(args, req.StrengthDecay.Lambda())
 	}
 
 	// Get items
 	query := fmt.Sprintf(`
-		SELECT id, from_note_id, to_note_id, type, description, strength, metadata, created_at, updated_at
+		SELECT id, from_note_id, to_note_id, type, description, strength, metadata, source, created_at, updated_at
 		FROM connections
 		%s
 		%s
@@ -301,7 +830,7 @@ func (s *Storage) List(ctx context.Context, req connection.ListConnectionsReques
 	var items []connection.Connection
 	for rows.Next() {
 		var conn connection.Connection
-		var description sql.NullString
+		var description, source sql.NullString
 		var metadataJSON string
 
 		if err := rows.Scan(
@@ -312,6 +841,7 @@ func (s *Storage) List(ctx context.Context, req connection.ListConnectionsReques
 			&description,
 			&conn.Strength,
 			&metadataJSON,
+			&source,
 			&conn.CreatedAt,
 			&conn.UpdatedAt,
 		); err != nil {
@@ -321,6 +851,9 @@ func (s *Storage) List(ctx context.Context, req connection.ListConnectionsReques
 		if description.Valid {
 			conn.Description = &description.String
 		}
+		if source.Valid {
+			conn.Source = &source.String
+		}
 
 		if err := json.Unmarshal([]byte(metadataJSON), &conn.Metadata); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
@@ -339,8 +872,83 @@ func (s *Storage) List(ctx context.Context, req connection.ListConnectionsReques
 	}, nil
 }
 
+// Count returns the number of connections List would find for req, ignoring
+// its pagination and ordering
+func (s *Storage) Count(ctx context.Context, req connection.ListConnectionsRequest) (int64, error) {
+	whereClause, args, err := s.listFilter(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	return s.countConnections(ctx, whereClause, args)
+}
+
+// listFilter builds the WHERE clause and its arguments for the request
+// filters of List and Count
+func (s *Storage) listFilter(ctx context.Context, req connection.ListConnectionsRequest) (string, []interface{}, error) {
+	// Build query
+	var whereClauses []string
+	var args []interface{}
+
+	if req.FromNoteID != nil {
+		whereClauses = append(whereClauses, "from_note_id = ?")
+		args = append(args, *req.FromNoteID)
+	}
+
+	if req.ToNoteID != nil {
+		whereClauses = append(whereClauses, "to_note_id = ?")
+		args = append(args, *req.ToNoteID)
+	}
+
+	if clause, clauseArgs := typesClause(req); clause != "" {
+		whereClauses = append(whereClauses, clause)
+		args = append(args, clauseArgs...)
+	}
+
+	if req.Strength != nil {
+		whereClauses = append(whereClauses, "strength = ?")
+		args = append(args, *req.Strength)
+	}
+
+	if req.Source != nil {
+		whereClauses = append(whereClauses, "source = ?")
+		args = append(args, *req.Source)
+	}
+
+	if req.DescriptionSearch != nil && strings.TrimSpace(*req.DescriptionSearch) != "" {
+		clause, clauseArgs, err := s.descriptionSearchClause(ctx, *req.DescriptionSearch)
+		if err != nil {
+			return "", nil, err
+		}
+		whereClauses = append(whereClauses, clause)
+		args = append(args, clauseArgs...)
+	}
+
+	whereClause := ""
+	if len(whereClauses) > 0 {
+		whereClause = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+	return whereClause, args, nil
+}
+
+// countConnections counts the connections matching whereClause
+func (s *Storage) countConnections(ctx context.Context, whereClause string, args []interface{}) (int64, error) {
+	var total int64
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM connections "+whereClause, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count connections: %w", err)
+	}
+	return total, nil
+}
+
 // GetNoteConnections retrieves all connections for a specific note
 func (s *Storage) GetNoteConnections(ctx context.Context, req connection.NoteConnectionsRequest) (*connection.NoteConnectionsResponse, error) {
+	direction := req.Direction
+	if direction == "" {
+		direction = connection.DirectionBoth
+	}
+	if !connection.IsValidDirection(string(direction)) {
+		return nil, fmt.Errorf("invalid direction: %s", direction)
+	}
+
 	var whereClauses []string
 
 	// Base conditions for outgoing and incoming connections
@@ -370,22 +978,27 @@ func (s *Storage) GetNoteConnections(ctx context.Context, req connection.NoteCon
 
 	// Get outgoing connections
 	outgoingQuery := fmt.Sprintf(`
-		SELECT id, from_note_id, to_note_id, type, description, strength, metadata, created_at, updated_at
+		SELECT id, from_note_id, to_note_id, type, description, strength, metadata, source, created_at, updated_at
 		FROM connections
 		WHERE %s
 		ORDER BY created_at DESC
 		LIMIT ? OFFSET ?
 	`, outgoingWhere)
 
+	var outgoing, incoming []connection.Connection
+	var err error
+
 	outgoingArgs = append(outgoingArgs, req.Limit, req.Offset)
-	outgoing, err := s.queryConnections(ctx, outgoingQuery, outgoingArgs...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get outgoing connections: %w", err)
+	if direction != connection.DirectionIncoming {
+		outgoing, err = s.queryConnections(ctx, outgoingQuery, outgoingArgs...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get outgoing connections: %w", err)
+		}
 	}
 
 	// Get incoming connections
 	incomingQuery := fmt.Sprintf(`
-		SELECT id, from_note_id, to_note_id, type, description, strength, metadata, created_at, updated_at
+		SELECT id, from_note_id, to_note_id, type, description, strength, metadata, source, created_at, updated_at
 		FROM connections
 		WHERE %s
 		ORDER BY created_at DESC
@@ -393,9 +1006,11 @@ func (s *Storage) GetNoteConnections(ctx context.Context, req connection.NoteCon
 	`, incomingWhere)
 
 	incomingArgs = append(incomingArgs, req.Limit, req.Offset)
-	incoming, err := s.queryConnections(ctx, incomingQuery, incomingArgs...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get incoming connections: %w", err)
+	if direction != connection.DirectionOutgoing {
+		incoming, err = s.queryConnections(ctx, incomingQuery, incomingArgs...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get incoming connections: %w", err)
+		}
 	}
 
 	// Get total count and type statistics
@@ -418,6 +1033,69 @@ func (s *Storage) GetNoteConnections(ctx context.Context, req connection.NoteCon
 	}, nil
 }
 
+// GetConnectionsForNotes retrieves connections for several notes in one query.
+// Every requested note gets an entry in the result, even when it has no connections.
+func (s *Storage) GetConnectionsForNotes(ctx context.Context, noteIDs []int64, filter connection.NoteConnectionsFilter) (map[int64]*connection.NoteConnectionsResponse, error) {
+	result := make(map[int64]*connection.NoteConnectionsResponse, len(noteIDs))
+	if len(noteIDs) == 0 {
+		return result, nil
+	}
+
+	placeholders := make([]string, len(noteIDs))
+	idArgs := make([]interface{}, len(noteIDs))
+	for i, id := range noteIDs {
+		placeholders[i] = "?"
+		idArgs[i] = id
+		result[id] = &connection.NoteConnectionsResponse{
+			NoteID:     id,
+			Outgoing:   []connection.Connection{},
+			Incoming:   []connection.Connection{},
+			TypesCount: make(map[string]int64),
+		}
+	}
+	inList := strings.Join(placeholders, ", ")
+
+	whereClause := fmt.Sprintf("(from_note_id IN (%s) OR to_note_id IN (%s))", inList, inList)
+	args := append(append([]interface{}{}, idArgs...), idArgs...)
+
+	if filter.Type != nil {
+		whereClause += " AND type = ?"
+		args = append(args, *filter.Type)
+	}
+
+	if filter.Strength != nil {
+		whereClause += " AND strength = ?"
+		args = append(args, *filter.Strength)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, from_note_id, to_note_id, type, description, strength, metadata, source, created_at, updated_at
+		FROM connections
+		WHERE %s
+		ORDER BY created_at DESC
+	`, whereClause)
+
+	connections, err := s.queryConnections(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connections for notes: %w", err)
+	}
+
+	for _, conn := range connections {
+		if resp, ok := result[conn.FromNoteID]; ok {
+			resp.Outgoing = append(resp.Outgoing, conn)
+			resp.TotalCount++
+			resp.TypesCount[conn.Type]++
+		}
+		if resp, ok := result[conn.ToNoteID]; ok {
+			resp.Incoming = append(resp.Incoming, conn)
+			resp.TotalCount++
+			resp.TypesCount[conn.Type]++
+		}
+	}
+
+	return result, nil
+}
+
 // GetConnectionsByType retrieves connections filtered by type
 func (s *Storage) GetConnectionsByType(ctx context.Context, connectionType string, req connection.ListConnectionsRequest) (*connection.ListConnectionsResponse, error) {
 	// Add type filter to the request
@@ -436,17 +1114,34 @@ func (s *Storage) GetBidirectionalConnections(ctx context.Context, noteID int64)
 }
 
 // GetConnectionStats retrieves statistics about connections
-func (s *Storage) GetConnectionStats(ctx context.Context) (*connection.ConnectionStats, error) {
+func (s *Storage) GetConnectionStats(ctx context.Context, opts connection.StatsOptions) (*connection.ConnectionStats, error) {
+	// Trashed connections are counted by reading the trash alongside the table
+	source := "connections"
+	if opts.IncludeArchived {
+		source = `(
+			SELECT from_note_id, to_note_id, type, strength FROM connections
+			UNION ALL
+			SELECT from_note_id, to_note_id, type, strength FROM connection_trash
+		)`
+	}
+
 	// Get total connections
 	var totalConnections int64
-	err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM connections").Scan(&totalConnections)
+	err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+source).Scan(&totalConnections)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get total connections: %w", err)
 	}
 
+	// Get archived connections
+	var archivedConnections int64
+	err = s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM connection_trash").Scan(&archivedConnections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archived connections: %w", err)
+	}
+
 	// Get connections by type
 	connectionsByType := make(map[string]int64)
-	typeRows, err := s.db.QueryContext(ctx, "SELECT type, COUNT(*) FROM connections GROUP BY type")
+	typeRows, err := s.db.QueryContext(ctx, "SELECT type, COUNT(*) FROM "+source+" GROUP BY type")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get connections by type: %w", err)
 	}
@@ -463,7 +1158,7 @@ func (s *Storage) GetConnectionStats(ctx context.Context) (*connection.Connectio
 
 	// Get connections by strength
 	connectionsByStrength := make(map[int]int64)
-	strengthRows, err := s.db.QueryContext(ctx, "SELECT strength, COUNT(*) FROM connections GROUP BY strength")
+	strengthRows, err := s.db.QueryContext(ctx, "SELECT strength, COUNT(*) FROM "+source+" GROUP BY strength")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get connections by strength: %w", err)
 	}
@@ -480,7 +1175,7 @@ func (s *Storage) GetConnectionStats(ctx context.Context) (*connection.Connectio
 
 	// Get most connected notes
 	mostConnectedNotes := []connection.NoteConnection{}
-	noteRows, err := s.db.QueryContext(ctx, `
+	noteRows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
 		SELECT 
 			note_id,
 			SUM(incoming_count) as incoming_count,
@@ -488,17 +1183,17 @@ func (s *Storage) GetConnectionStats(ctx context.Context) (*connection.Connectio
 			SUM(incoming_count + outgoing_count) as total_count
 		FROM (
 			SELECT from_note_id as note_id, COUNT(*) as outgoing_count, 0 as incoming_count
-			FROM connections 
+			FROM %[1]s 
 			GROUP BY from_note_id
 			UNION ALL
 			SELECT to_note_id as note_id, 0 as outgoing_count, COUNT(*) as incoming_count
-			FROM connections 
+			FROM %[1]s 
 			GROUP BY to_note_id
 		) 
 		GROUP BY note_id 
 		ORDER BY total_count DESC 
 		LIMIT 10
-	`)
+	`, source))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get most connected notes: %w", err)
 	}
@@ -517,21 +1212,56 @@ func (s *Storage) GetConnectionStats(ctx context.Context) (*connection.Connectio
 		ConnectionsByType:     connectionsByType,
 		ConnectionsByStrength: connectionsByStrength,
 		MostConnectedNotes:    mostConnectedNotes,
+		ArchivedConnections:   archivedConnections,
 	}, nil
 }
 
-// FindConnectionPaths finds paths between two notes (basic implementation)
-func (s *Storage) FindConnectionPaths(ctx context.Context, fromNoteID, toNoteID int64, maxDepth int) ([]connection.ConnectionPath, error) {
+// FindConnectionPaths finds paths between two notes (basic implementation).
+// Connections are followed in opts.Direction, and connections of the excluded
+// types are skipped. Path searches are bounded by the storage's GraphLimits.
+func (s *Storage) FindConnectionPaths(ctx context.Context, fromNoteID, toNoteID int64, opts connection.PathOptions) (_ []connection.ConnectionPath, err error) {
 	// This is a basic implementation that finds direct connections
 	// A more sophisticated implementation would use graph traversal algorithms
-	
+	direction, err := pathDirection(opts.Direction)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel, err := s.guardGraph(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	defer func() {
+		err = s.graphError(ctx, err)
+	}()
+
+	var endpoints string
+	var args []interface{}
+	switch direction {
+	case connection.DirectionOutgoing:
+		endpoints = "from_note_id = ? AND to_note_id = ?"
+		args = []interface{}{fromNoteID, toNoteID}
+	case connection.DirectionIncoming:
+		endpoints = "from_note_id = ? AND to_note_id = ?"
+		args = []interface{}{toNoteID, fromNoteID}
+	case connection.DirectionBoth:
+		endpoints = "((from_note_id = ? AND to_note_id = ?) OR (from_note_id = ? AND to_note_id = ?))"
+		args = []interface{}{fromNoteID, toNoteID, toNoteID, fromNoteID}
+	}
+
 	query := `
-		SELECT id, from_note_id, to_note_id, type, description, strength, metadata, created_at, updated_at
+		SELECT id, from_note_id, to_note_id, type, description, strength, metadata, source, created_at, updated_at
 		FROM connections
-		WHERE from_note_id = ? AND to_note_id = ?
-	`
+		WHERE ` + endpoints
+
+	if clause, typeArgs := excludeTypesClause(opts.ExcludeTypes); clause != "" {
+		query += " AND " + clause
+		args = append(args, typeArgs...)
+	}
+	query += " ORDER BY id"
 
-	connections, err := s.queryConnections(ctx, query, fromNoteID, toNoteID)
+	connections, err := s.queryConnections(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find direct connections: %w", err)
 	}
@@ -553,7 +1283,12 @@ func (s *Storage) FindConnectionPaths(ctx context.Context, fromNoteID, toNoteID
 
 // queryConnections is a helper method to query connections and scan results
 func (s *Storage) queryConnections(ctx context.Context, query string, args ...interface{}) ([]connection.Connection, error) {
-	rows, err := s.db.QueryContext(ctx, query, args...)
+	return queryConnectionsWith(ctx, s.db, query, args...)
+}
+
+// queryConnectionsWith queries connections through db, which may be a transaction
+func queryConnectionsWith(ctx context.Context, db execer, query string, args ...interface{}) ([]connection.Connection, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -562,7 +1297,7 @@ func (s *Storage) queryConnections(ctx context.Context, query string, args ...in
 	var connections []connection.Connection
 	for rows.Next() {
 		var conn connection.Connection
-		var description sql.NullString
+		var description, source sql.NullString
 		var metadataJSON string
 
 		if err := rows.Scan(
@@ -573,6 +1308,7 @@ func (s *Storage) queryConnections(ctx context.Context, query string, args ...in
 			&description,
 			&conn.Strength,
 			&metadataJSON,
+			&source,
 			&conn.CreatedAt,
 			&conn.UpdatedAt,
 		); err != nil {
@@ -582,6 +1318,9 @@ func (s *Storage) queryConnections(ctx context.Context, query string, args ...in
 		if description.Valid {
 			conn.Description = &description.String
 		}
+		if source.Valid {
+			conn.Source = &source.String
+		}
 
 		if err := json.Unmarshal([]byte(metadataJSON), &conn.Metadata); err != nil {
 			return nil, err
@@ -591,4 +1330,60 @@ func (s *Storage) queryConnections(ctx context.Context, query string, args ...in
 	}
 
 	return connections, rows.Err()
-}
\ No newline at end of file
+}
+// orderByColumns whitelists the sortable columns so user input never reaches the SQL text
+var orderByColumns = map[string]string{
+	"id":         "id",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+	"strength":   "strength",
+	"type":       "type",
+}
+
+// typesClause builds the type filter for List from Type and Types, keeping
+// connections of any type in either
+func typesClause(req connection.ListConnectionsRequest) (string, []interface{}) {
+	types := req.Types
+	if req.Type != nil {
+		types = append([]string{*req.Type}, types...)
+	}
+
+	var placeholders []string
+	var args []interface{}
+	seen := make(map[string]bool, len(types))
+	for _, connType := range types {
+		if seen[connType] {
+			continue
+		}
+		seen[connType] = true
+		placeholders = append(placeholders, "?")
+		args = append(args, connType)
+	}
+	if len(placeholders) == 0 {
+		return "", nil
+	}
+	return "type IN (" + strings.Join(placeholders, ", ") + ")", args
+}
+
+// buildOrderClause builds the ORDER BY clause for List. Without orderBy the
+// newest connections come first; with it the default direction is ascending.
+func buildOrderClause(orderBy, orderDir string) (string, error) {
+	if orderBy == "" {
+		return "ORDER BY created_at DESC", nil
+	}
+	column, ok := orderByColumns[orderBy]
+	if !ok {
+		return "", fmt.Errorf("invalid order_by: %s. Valid values are: %v", orderBy, connection.ValidOrderByFields())
+	}
+
+	direction := "ASC"
+	switch strings.ToLower(orderDir) {
+	case "", "asc":
+	case "desc":
+		direction = "DESC"
+	default:
+		return "", fmt.Errorf("invalid order_dir: %s. Valid values are: asc, desc", orderDir)
+	}
+
+	return fmt.Sprintf("ORDER BY %s %s", column, direction), nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -134,11 +135,11 @@ func TestStorage(t *testing.T) {
 				wantErr: true,
 			},
 			{
-				name: "create self-connection",
+				name: "create self-connection with a type that forbids it",
 				req: connection.CreateConnectionRequest{
 					FromNoteID: note1ID,
 					ToNoteID:   note1ID,
-					Type:       "relates_to",
+					Type:       "depends_on",
 					Strength:   5,
 				},
 				wantErr: true,
@@ -532,6 +533,48 @@ func TestStorage(t *testing.T) {
 				wantTotalCount: 1,
 				wantErr:        false,
 			},
+			{
+				name: "outgoing only",
+				req: connection.NoteConnectionsRequest{
+					NoteID:    note1ID,
+					Limit:     10,
+					Direction: connection.DirectionOutgoing,
+				},
+				wantOutgoing:   2,
+				wantIncoming:   0,
+				wantTotalCount: 2,
+			},
+			{
+				name: "incoming only",
+				req: connection.NoteConnectionsRequest{
+					NoteID:    note1ID,
+					Limit:     10,
+					Direction: connection.DirectionIncoming,
+				},
+				wantOutgoing:   0,
+				wantIncoming:   2,
+				wantTotalCount: 2,
+			},
+			{
+				name: "both directions",
+				req: connection.NoteConnectionsRequest{
+					NoteID:    note1ID,
+					Limit:     10,
+					Direction: connection.DirectionBoth,
+				},
+				wantOutgoing:   2,
+				wantIncoming:   2,
+				wantTotalCount: 4,
+			},
+			{
+				name: "invalid direction",
+				req: connection.NoteConnectionsRequest{
+					NoteID:    note1ID,
+					Limit:     10,
+					Direction: "sideways",
+				},
+				wantErr: true,
+			},
 		}
 
 		for _, tt := range tests {
@@ -640,7 +683,7 @@ func TestStorage(t *testing.T) {
 		// Create bidirectional connections
 		connections := []connection.CreateConnectionRequest{
 			{FromNoteID: note1ID, ToNoteID: note2ID, Type: "relates_to", Strength: 5},
-			{FromNoteID: note2ID, ToNoteID: note1ID, Type: "relates_to", Strength: 5},
+			{FromNoteID: note2ID, ToNoteID: note1ID, Type: "supports", Strength: 5},
 			{FromNoteID: note1ID, ToNoteID: note3ID, Type: "references", Strength: 7},
 		}
 
@@ -716,7 +759,7 @@ func TestStorage(t *testing.T) {
 
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
-				stats, err := storage.GetConnectionStats(ctx)
+				stats, err := storage.GetConnectionStats(ctx, connection.StatsOptions{})
 				require.NoError(t, err)
 				if tt.validate != nil {
 					tt.validate(t, stats)
@@ -747,6 +790,8 @@ func TestStorage(t *testing.T) {
 			fromNoteID   int64
 			toNoteID     int64
 			maxDepth     int
+			excludeTypes []string
+			direction    connection.Direction
 			wantPaths    int
 			wantErr      bool
 			validate     func(t *testing.T, paths []connection.ConnectionPath)
@@ -768,6 +813,75 @@ func TestStorage(t *testing.T) {
 					}
 				},
 			},
+			{
+				name:         "exclude a type",
+				fromNoteID:   note1ID,
+				toNoteID:     note2ID,
+				maxDepth:     1,
+				excludeTypes: []string{"relates_to"},
+				wantPaths:    1, // Only the supports connection is left
+				validate: func(t *testing.T, paths []connection.ConnectionPath) {
+					assert.Equal(t, "supports", paths[0].Path[0].Type)
+					assert.Equal(t, 8, paths[0].Strength)
+				},
+			},
+			{
+				name:         "exclude every type between the notes",
+				fromNoteID:   note1ID,
+				toNoteID:     note2ID,
+				maxDepth:     1,
+				excludeTypes: []string{"relates_to", "supports"},
+				wantPaths:    0,
+			},
+			{
+				name:       "outgoing ignores connections into the first note",
+				fromNoteID: note2ID,
+				toNoteID:   note1ID,
+				maxDepth:   1,
+				direction:  connection.DirectionOutgoing,
+				wantPaths:  0,
+			},
+			{
+				name:       "incoming finds connections into the first note",
+				fromNoteID: note2ID,
+				toNoteID:   note1ID,
+				maxDepth:   1,
+				direction:  connection.DirectionIncoming,
+				wantPaths:  2,
+				validate: func(t *testing.T, paths []connection.ConnectionPath) {
+					for _, path := range paths {
+						assert.Equal(t, note1ID, path.Path[0].FromNoteID)
+						assert.Equal(t, note2ID, path.Path[0].ToNoteID)
+					}
+				},
+			},
+			{
+				name:       "incoming ignores connections out of the first note",
+				fromNoteID: note1ID,
+				toNoteID:   note2ID,
+				maxDepth:   1,
+				direction:  connection.DirectionIncoming,
+				wantPaths:  0,
+			},
+			{
+				name:         "both directions",
+				fromNoteID:   note3ID,
+				toNoteID:     note2ID,
+				maxDepth:     1,
+				direction:    connection.DirectionBoth,
+				wantPaths:    1,
+				validate: func(t *testing.T, paths []connection.ConnectionPath) {
+					assert.Equal(t, "references", paths[0].Path[0].Type)
+				},
+			},
+			{
+				name:       "invalid direction",
+				fromNoteID: note1ID,
+				toNoteID:   note2ID,
+				maxDepth:   1,
+				direction:  "sideways",
+				wantErr:    true,
+			},
 			{
 				name:       "find no direct connection",
 				fromNoteID: note1ID,
@@ -794,7 +908,11 @@ func TestStorage(t *testing.T) {
 
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
-				paths, err := storage.FindConnectionPaths(ctx, tt.fromNoteID, tt.toNoteID, tt.maxDepth)
+				paths, err := storage.FindConnectionPaths(ctx, tt.fromNoteID, tt.toNoteID, connection.PathOptions{
+					MaxDepth:     tt.maxDepth,
+					ExcludeTypes: tt.excludeTypes,
+					Direction:    tt.direction,
+				})
 				if tt.wantErr {
 					assert.Error(t, err)
 					return
@@ -895,19 +1013,6 @@ func runTestMigrations(db *sql.DB) error {
 		return fmt.Errorf("failed to create update trigger: %w", err)
 	}
 
-	_, err = db.Exec(`
-		CREATE TRIGGER IF NOT EXISTS prevent_self_connection
-		BEFORE INSERT ON connections
-		FOR EACH ROW
-		WHEN NEW.from_note_id = NEW.to_note_id
-		BEGIN
-			SELECT RAISE(ABORT, 'Self-connections are not allowed');
-		END
-	`)
-	if err != nil {
-		return fmt.Errorf("failed to create self-connection prevention trigger: %w", err)
-	}
-
 	return nil
 }
 
@@ -945,4 +1050,932 @@ func strPtr(s string) *string {
 
 func intPtr(i int) *int {
 	return &i
-}
\ No newline at end of file
+}
+func TestStorage_SymmetricTypes(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, _ := createTestNotes(t, storage.db)
+
+	tests := []struct {
+		name     string
+		connType string
+		wantErr  bool
+	}{
+		{name: "reverse relates_to is rejected", connType: "relates_to", wantErr: true},
+		{name: "reverse similar_to is rejected", connType: "similar_to", wantErr: true},
+		{name: "reverse contradicts is rejected", connType: "contradicts", wantErr: true},
+		{name: "reverse supports is allowed", connType: "supports", wantErr: false},
+		{name: "reverse depends_on is allowed", connType: "depends_on", wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := storage.Create(ctx, connection.CreateConnectionRequest{
+				FromNoteID: note1ID, ToNoteID: note2ID, Type: tt.connType, Strength: 5,
+			})
+			require.NoError(t, err)
+
+			_, err = storage.Create(ctx, connection.CreateConnectionRequest{
+				FromNoteID: note2ID, ToNoteID: note1ID, Type: tt.connType, Strength: 5,
+			})
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "symmetric")
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+// newTestStorage creates a storage instance backed by a fresh migrated database
+func newTestStorage(t *testing.T, opts ...Option) *Storage {
+	t.Helper()
+
+	tempFile, err := os.CreateTemp("", "test-*.db")
+	require.NoError(t, err)
+	tempFile.Close()
+	t.Cleanup(func() { os.Remove(tempFile.Name()) })
+
+	storage, err := NewStorage(tempFile.Name(), opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { storage.Close() })
+
+	err = migrations.NewMigrationRunner(tempFile.Name(), migrations.WithEdgeMode(storage.edgeMode)).RunMigrations()
+	require.NoError(t, err)
+
+	return storage
+}
+
+func TestStorage_DeleteBetween(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+	setup := func(t *testing.T) {
+		_, err := storage.db.Exec("DELETE FROM connections")
+		require.NoError(t, err)
+
+		connections := []connection.CreateConnectionRequest{
+			{FromNoteID: note1ID, ToNoteID: note2ID, Type: "relates_to", Strength: 5},
+			{FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 6},
+			{FromNoteID: note2ID, ToNoteID: note1ID, Type: "supports", Strength: 7},
+			{FromNoteID: note1ID, ToNoteID: note3ID, Type: "references", Strength: 5},
+		}
+		for _, req := range connections {
+			_, err := storage.Create(ctx, req)
+			require.NoError(t, err)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		fromNoteID    int64
+		toNoteID      int64
+		connType      *string
+		wantDeleted   int64
+		wantRemaining int64
+		wantErr       bool
+	}{
+		{
+			name:          "delete all connections in both directions",
+			fromNoteID:    note1ID,
+			toNoteID:      note2ID,
+			wantDeleted:   3,
+			wantRemaining: 1,
+		},
+		{
+			name:          "delete only one type",
+			fromNoteID:    note2ID,
+			toNoteID:      note1ID,
+			connType:      strPtr("supports"),
+			wantDeleted:   2,
+			wantRemaining: 2,
+		},
+		{
+			name:          "no connections is idempotent",
+			fromNoteID:    note2ID,
+			toNoteID:      note3ID,
+			wantDeleted:   0,
+			wantRemaining: 4,
+		},
+		{
+			name:       "invalid type",
+			fromNoteID: note1ID,
+			toNoteID:   note2ID,
+			connType:   strPtr("invalid_type"),
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup(t)
+
+			deleted, err := storage.DeleteBetween(ctx, tt.fromNoteID, tt.toNoteID, tt.connType)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDeleted, deleted)
+
+			var remaining int64
+			require.NoError(t, storage.db.QueryRow("SELECT COUNT(*) FROM connections").Scan(&remaining))
+			assert.Equal(t, tt.wantRemaining, remaining)
+		})
+	}
+}
+
+func TestStorage_RetypeConnections(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+	setup := func(t *testing.T, connections []connection.CreateConnectionRequest) {
+		_, err := storage.db.Exec("DELETE FROM connections")
+		require.NoError(t, err)
+
+		for _, req := range connections {
+			_, err := storage.Create(ctx, req)
+			require.NoError(t, err)
+		}
+	}
+
+	countByType := func(t *testing.T, connType string) int64 {
+		var count int64
+		require.NoError(t, storage.db.QueryRow("SELECT COUNT(*) FROM connections WHERE type = ?", connType).Scan(&count))
+		return count
+	}
+
+	tests := []struct {
+		name        string
+		connections []connection.CreateConnectionRequest
+		fromType    string
+		toType      string
+		wantUpdated int64
+		wantErr     error
+		wantFrom    int64
+		wantTo      int64
+	}{
+		{
+			name: "retype all connections of a type",
+			connections: []connection.CreateConnectionRequest{
+				{FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 5},
+				{FromNoteID: note2ID, ToNoteID: note3ID, Type: "supports", Strength: 6},
+				{FromNoteID: note1ID, ToNoteID: note3ID, Type: "relates_to", Strength: 5},
+			},
+			fromType:    "supports",
+			toType:      "references",
+			wantUpdated: 2,
+			wantFrom:    0,
+			wantTo:      2,
+		},
+		{
+			name: "no connections of the type",
+			connections: []connection.CreateConnectionRequest{
+				{FromNoteID: note1ID, ToNoteID: note2ID, Type: "relates_to", Strength: 5},
+			},
+			fromType:    "supports",
+			toType:      "references",
+			wantUpdated: 0,
+			wantFrom:    0,
+			wantTo:      0,
+		},
+		{
+			name: "collision with existing connection leaves everything unchanged",
+			connections: []connection.CreateConnectionRequest{
+				{FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 5},
+				{FromNoteID: note2ID, ToNoteID: note3ID, Type: "supports", Strength: 6},
+				{FromNoteID: note1ID, ToNoteID: note2ID, Type: "references", Strength: 7},
+			},
+			fromType: "supports",
+			toType:   "references",
+			wantErr:  connection.ErrRetypeConflict,
+			wantFrom: 2,
+			wantTo:   1,
+		},
+		{
+			name: "collision with reverse connection of a symmetric type",
+			connections: []connection.CreateConnectionRequest{
+				{FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 5},
+				{FromNoteID: note2ID, ToNoteID: note1ID, Type: "relates_to", Strength: 5},
+			},
+			fromType: "supports",
+			toType:   "relates_to",
+			wantErr:  connection.ErrRetypeConflict,
+			wantFrom: 1,
+			wantTo:   1,
+		},
+		{
+			name: "opposite connections collide when the target is symmetric",
+			connections: []connection.CreateConnectionRequest{
+				{FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 5},
+				{FromNoteID: note2ID, ToNoteID: note1ID, Type: "supports", Strength: 5},
+			},
+			fromType: "supports",
+			toType:   "similar_to",
+			wantErr:  connection.ErrRetypeConflict,
+			wantFrom: 2,
+			wantTo:   0,
+		},
+		{
+			name: "reverse connection is fine for a directional type",
+			connections: []connection.CreateConnectionRequest{
+				{FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 5},
+				{FromNoteID: note2ID, ToNoteID: note1ID, Type: "references", Strength: 5},
+			},
+			fromType:    "supports",
+			toType:      "references",
+			wantUpdated: 1,
+			wantFrom:    0,
+			wantTo:      2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup(t, tt.connections)
+
+			updated, err := storage.RetypeConnections(ctx, tt.fromType, tt.toType)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantUpdated, updated)
+			}
+
+			assert.Equal(t, tt.wantFrom, countByType(t, tt.fromType))
+			assert.Equal(t, tt.wantTo, countByType(t, tt.toType))
+		})
+	}
+
+	t.Run("invalid types", func(t *testing.T) {
+		_, err := storage.RetypeConnections(ctx, "invalid_type", "references")
+		assert.Error(t, err)
+
+		_, err = storage.RetypeConnections(ctx, "supports", "invalid_type")
+		assert.Error(t, err)
+
+		_, err = storage.RetypeConnections(ctx, "supports", "supports")
+		assert.Error(t, err)
+	})
+}
+
+func TestStorage_Source(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+	created, err := storage.Create(ctx, connection.CreateConnectionRequest{
+		FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 5, Source: strPtr("research-agent"),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, created.Source)
+	assert.Equal(t, "research-agent", *created.Source)
+
+	unattributed, err := storage.Create(ctx, connection.CreateConnectionRequest{
+		FromNoteID: note2ID, ToNoteID: note3ID, Type: "supports", Strength: 5,
+	})
+	require.NoError(t, err)
+	assert.Nil(t, unattributed.Source)
+
+	resp, err := storage.List(ctx, connection.ListConnectionsRequest{Limit: 10, Source: strPtr("research-agent")})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.Total)
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, created.ID, resp.Items[0].ID)
+
+	noteConns, err := storage.GetNoteConnections(ctx, connection.NoteConnectionsRequest{NoteID: note1ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, noteConns.Outgoing, 1)
+	assert.Equal(t, "research-agent", *noteConns.Outgoing[0].Source)
+}
+
+func TestStorage_UpdateExpectedUpdatedAt(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, _ := createTestNotes(t, storage.db)
+
+	created, err := storage.Create(ctx, connection.CreateConnectionRequest{
+		FromNoteID: note1ID, ToNoteID: note2ID, Type: "references", Strength: 5,
+	})
+	require.NoError(t, err)
+
+	read, err := storage.Get(ctx, created.ID)
+	require.NoError(t, err)
+	version := read.UpdatedAt
+	stale := version.Add(-time.Minute)
+
+	first, second := 8, 2
+	updated, err := storage.Update(ctx, created.ID, connection.UpdateConnectionRequest{
+		Strength:          &first,
+		ExpectedUpdatedAt: &version,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 8, updated.Strength)
+
+	// A second writer holding an older version is rejected
+	_, err = storage.Update(ctx, created.ID, connection.UpdateConnectionRequest{
+		Strength:          &second,
+		ExpectedUpdatedAt: &stale,
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, connection.ErrConflict)
+
+	current, err := storage.Get(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 8, current.Strength)
+
+	_, err = storage.Update(ctx, created.ID+100, connection.UpdateConnectionRequest{
+		Strength:          &second,
+		ExpectedUpdatedAt: &version,
+	})
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, connection.ErrConflict)
+}
+
+func TestStorage_GetConnectionsForNotes(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+	connections := []connection.CreateConnectionRequest{
+		{FromNoteID: note1ID, ToNoteID: note2ID, Type: "relates_to", Strength: 5},
+		{FromNoteID: note2ID, ToNoteID: note3ID, Type: "supports", Strength: 8},
+		{FromNoteID: note3ID, ToNoteID: note1ID, Type: "references", Strength: 8},
+	}
+	for _, req := range connections {
+		_, err := storage.Create(ctx, req)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name     string
+		noteIDs  []int64
+		filter   connection.NoteConnectionsFilter
+		validate func(t *testing.T, result map[int64]*connection.NoteConnectionsResponse)
+	}{
+		{
+			name:    "group connections by note",
+			noteIDs: []int64{note1ID, note2ID},
+			validate: func(t *testing.T, result map[int64]*connection.NoteConnectionsResponse) {
+				require.Len(t, result, 2)
+				assert.Len(t, result[note1ID].Outgoing, 1)
+				assert.Len(t, result[note1ID].Incoming, 1)
+				assert.Equal(t, int64(2), result[note1ID].TotalCount)
+				assert.Len(t, result[note2ID].Outgoing, 1)
+				assert.Len(t, result[note2ID].Incoming, 1)
+				assert.Equal(t, int64(1), result[note2ID].TypesCount["supports"])
+			},
+		},
+		{
+			name:    "filter by strength",
+			noteIDs: []int64{note1ID, note2ID, note3ID},
+			filter:  connection.NoteConnectionsFilter{Strength: intPtr(8)},
+			validate: func(t *testing.T, result map[int64]*connection.NoteConnectionsResponse) {
+				require.Len(t, result, 3)
+				assert.Equal(t, int64(1), result[note1ID].TotalCount)
+				assert.Equal(t, int64(1), result[note2ID].TotalCount)
+				assert.Equal(t, int64(2), result[note3ID].TotalCount)
+			},
+		},
+		{
+			name:    "note without connections gets empty entry",
+			noteIDs: []int64{99999},
+			filter:  connection.NoteConnectionsFilter{Type: strPtr("relates_to")},
+			validate: func(t *testing.T, result map[int64]*connection.NoteConnectionsResponse) {
+				require.Contains(t, result, int64(99999))
+				assert.Empty(t, result[99999].Outgoing)
+				assert.Empty(t, result[99999].Incoming)
+				assert.Equal(t, int64(0), result[99999].TotalCount)
+			},
+		},
+		{
+			name:    "empty input",
+			noteIDs: nil,
+			validate: func(t *testing.T, result map[int64]*connection.NoteConnectionsResponse) {
+				assert.Empty(t, result)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := storage.GetConnectionsForNotes(ctx, tt.noteIDs, tt.filter)
+			require.NoError(t, err)
+			tt.validate(t, result)
+		})
+	}
+}
+
+func TestStorage_GetMany(t *testing.T) {
+	ctx := context.Background()
+	storage := newTestStorage(t)
+	note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+	first, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 5})
+	require.NoError(t, err)
+	second, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: note2ID, ToNoteID: note3ID, Type: "cites", Strength: 3, Description: strPtr("detail")})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		ids     []int64
+		wantIDs []int64
+	}{
+		{name: "requested order", ids: []int64{second.ID, first.ID}, wantIDs: []int64{second.ID, first.ID}},
+		{name: "mix of existing and missing", ids: []int64{9999, first.ID}, wantIDs: []int64{first.ID}},
+		{name: "repeated ID returned once", ids: []int64{first.ID, first.ID}, wantIDs: []int64{first.ID}},
+		{name: "no IDs", ids: nil, wantIDs: []int64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conns, err := storage.GetMany(ctx, tt.ids)
+			require.NoError(t, err)
+
+			ids := make([]int64, len(conns))
+			for i, conn := range conns {
+				ids[i] = conn.ID
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+
+	t.Run("full detail", func(t *testing.T) {
+		conns, err := storage.GetMany(ctx, []int64{second.ID})
+		require.NoError(t, err)
+		require.Len(t, conns, 1)
+		assert.Equal(t, *second, conns[0])
+	})
+}
+
+func TestStorage_CustomTypes(t *testing.T) {
+	require.NoError(t, connection.Configure(connection.Config{ExtraTypes: []string{"cause_of"}}))
+	t.Cleanup(func() {
+		require.NoError(t, connection.Configure(connection.Config{}))
+	})
+
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, _ := createTestNotes(t, storage.db)
+
+	conn, err := storage.Create(ctx, connection.CreateConnectionRequest{
+		FromNoteID: note1ID,
+		ToNoteID:   note2ID,
+		Type:       "cause_of",
+		Strength:   5,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "cause_of", conn.Type)
+
+	_, err = storage.Create(ctx, connection.CreateConnectionRequest{
+		FromNoteID: note1ID,
+		ToNoteID:   note2ID,
+		Type:       "effect_of",
+		Strength:   5,
+	})
+	assert.Error(t, err)
+}
+
+func TestStorage_SelfLoops(t *testing.T) {
+	ctx := context.Background()
+	t.Cleanup(func() {
+		require.NoError(t, connection.Configure(connection.Config{}))
+	})
+
+	tests := []struct {
+		name         string
+		cfg          connection.Config
+		connType     string
+		wantErrMatch string
+	}{
+		{name: "symmetric type allows self-loops", connType: "relates_to"},
+		{name: "annotation type allows self-loops", connType: "references"},
+		{name: "depends_on forbids self-loops", connType: "depends_on", wantErrMatch: "self-connections are not allowed for depends_on connections"},
+		{name: "custom type forbids self-loops by default", cfg: connection.Config{ExtraTypes: []string{"defines"}}, connType: "defines", wantErrMatch: "not allowed"},
+		{name: "allow-list enables a custom type", cfg: connection.Config{ExtraTypes: []string{"defines"}, SelfLoopTypes: []string{"defines"}}, connType: "defines"},
+		{name: "allow-list replaces the built-in policy", cfg: connection.Config{SelfLoopTypes: []string{"depends_on"}}, connType: "relates_to", wantErrMatch: "not allowed"},
+		{name: "empty allow-list forbids every type", cfg: connection.Config{SelfLoopTypes: []string{}}, connType: "references", wantErrMatch: "not allowed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, connection.Configure(tt.cfg))
+			storage := newTestStorage(t)
+			note1ID, _, _ := createTestNotes(t, storage.db)
+
+			conn, err := storage.Create(ctx, connection.CreateConnectionRequest{
+				FromNoteID: note1ID,
+				ToNoteID:   note1ID,
+				Type:       tt.connType,
+				Strength:   5,
+			})
+			if tt.wantErrMatch != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrMatch)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, note1ID, conn.FromNoteID)
+			assert.Equal(t, note1ID, conn.ToNoteID)
+		})
+	}
+
+	t.Run("changing type keeps the policy", func(t *testing.T) {
+		require.NoError(t, connection.Configure(connection.Config{}))
+		storage := newTestStorage(t)
+		note1ID, note2ID, _ := createTestNotes(t, storage.db)
+
+		loop, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: note1ID, ToNoteID: note1ID, Type: "relates_to", Strength: 5})
+		require.NoError(t, err)
+		edge, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: note1ID, ToNoteID: note2ID, Type: "relates_to", Strength: 5})
+		require.NoError(t, err)
+
+		_, err = storage.Update(ctx, loop.ID, connection.UpdateConnectionRequest{Type: strPtr("depends_on")})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "self-connections are not allowed for depends_on connections")
+
+		updated, err := storage.Update(ctx, loop.ID, connection.UpdateConnectionRequest{Type: strPtr("similar_to")})
+		require.NoError(t, err)
+		assert.Equal(t, "similar_to", updated.Type)
+
+		updated, err = storage.Update(ctx, edge.ID, connection.UpdateConnectionRequest{Type: strPtr("depends_on")})
+		require.NoError(t, err)
+		assert.Equal(t, "depends_on", updated.Type)
+
+		_, err = storage.RetypeConnections(ctx, "similar_to", "part_of")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "1 similar_to connections are self-connections")
+
+		updatedCount, err := storage.RetypeConnections(ctx, "similar_to", "references")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), updatedCount)
+	})
+}
+
+func TestStorage_ListStrengthDecay(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+	// Connections of different strength and age
+	fixtures := []struct {
+		from, to int64
+		strength int
+		ageDays  int
+	}{
+		{note1ID, note2ID, 4, 0},
+		{note2ID, note3ID, 10, 30},
+		{note1ID, note3ID, 9, 365},
+	}
+	ids := make([]int64, len(fixtures))
+	for i, f := range fixtures {
+		created, err := storage.Create(ctx, connection.CreateConnectionRequest{
+			FromNoteID: f.from, ToNoteID: f.to, Type: "references", Strength: f.strength,
+		})
+		require.NoError(t, err)
+		_, err = storage.db.ExecContext(ctx,
+			"UPDATE connections SET created_at = datetime('now', ?) WHERE id = ?",
+			fmt.Sprintf("-%d days", f.ageDays), created.ID)
+		require.NoError(t, err)
+		ids[i] = created.ID
+	}
+
+	tests := []struct {
+		name    string
+		req     connection.ListConnectionsRequest
+		wantIDs []int64
+		wantErr bool
+	}{
+		{
+			name:    "short half-life favours recent connections",
+			req:     connection.ListConnectionsRequest{Limit: 10, StrengthDecay: &connection.StrengthDecay{HalfLifeDays: 7}},
+			wantIDs: []int64{ids[0], ids[1], ids[2]},
+		},
+		{
+			name:    "medium half-life",
+			req:     connection.ListConnectionsRequest{Limit: 10, StrengthDecay: &connection.StrengthDecay{HalfLifeDays: 30}},
+			wantIDs: []int64{ids[1], ids[0], ids[2]},
+		},
+		{
+			name:    "long half-life is close to plain strength",
+			req:     connection.ListConnectionsRequest{Limit: 10, StrengthDecay: &connection.StrengthDecay{HalfLifeDays: 3650}},
+			wantIDs: []int64{ids[1], ids[2], ids[0]},
+		},
+		{
+			name:    "decay overrides order_by and keeps pagination",
+			req:     connection.ListConnectionsRequest{Limit: 1, Offset: 1, OrderBy: "id", OrderDir: "asc", StrengthDecay: &connection.StrengthDecay{HalfLifeDays: 7}},
+			wantIDs: []int64{ids[1]},
+		},
+		{
+			name:    "filters still apply",
+			req:     connection.ListConnectionsRequest{Limit: 10, FromNoteID: &note1ID, StrengthDecay: &connection.StrengthDecay{HalfLifeDays: 30}},
+			wantIDs: []int64{ids[0], ids[2]},
+		},
+		{
+			name:    "zero half-life",
+			req:     connection.ListConnectionsRequest{Limit: 10, StrengthDecay: &connection.StrengthDecay{HalfLifeDays: 0}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := storage.List(ctx, tt.req)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var gotIDs []int64
+			for _, conn := range resp.Items {
+				gotIDs = append(gotIDs, conn.ID)
+			}
+			assert.Equal(t, tt.wantIDs, gotIDs)
+		})
+	}
+}
+
+func TestStorage_ListOrdering(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+	// Insert with explicit timestamps so created_at ordering is deterministic
+	for i, c := range []struct {
+		from, to int64
+		connType string
+		strength int
+	}{
+		{note1ID, note2ID, "supports", 3},
+		{note2ID, note3ID, "references", 9},
+		{note1ID, note3ID, "influences", 6},
+	} {
+		_, err := storage.db.ExecContext(ctx,
+			"INSERT INTO connections (from_note_id, to_note_id, type, strength, metadata, created_at, updated_at) VALUES (?, ?, ?, ?, '{}', ?, ?)",
+			c.from, c.to, c.connType, c.strength, fmt.Sprintf("2024-01-0%d 00:00:00", i+1), fmt.Sprintf("2024-01-0%d 00:00:00", i+1))
+		require.NoError(t, err)
+	}
+
+	types := func(resp *connection.ListConnectionsResponse) []string {
+		var result []string
+		for _, conn := range resp.Items {
+			result = append(result, conn.Type)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name      string
+		req       connection.ListConnectionsRequest
+		wantErr   string
+		wantTypes []string
+	}{
+		{
+			name:      "default is newest first",
+			req:       connection.ListConnectionsRequest{Limit: 10},
+			wantTypes: []string{"influences", "references", "supports"},
+		},
+		{
+			name:      "strength ascending by default",
+			req:       connection.ListConnectionsRequest{Limit: 10, OrderBy: "strength"},
+			wantTypes: []string{"supports", "influences", "references"},
+		},
+		{
+			name:      "type descending",
+			req:       connection.ListConnectionsRequest{Limit: 10, OrderBy: "type", OrderDir: "DESC"},
+			wantTypes: []string{"supports", "references", "influences"},
+		},
+		{
+			name:    "injected order_by",
+			req:     connection.ListConnectionsRequest{Limit: 10, OrderBy: "id; DROP TABLE connections; --"},
+			wantErr: "invalid order_by: id; DROP TABLE connections; --",
+		},
+		{
+			name:    "expression in order_by",
+			req:     connection.ListConnectionsRequest{Limit: 10, OrderBy: "CASE WHEN 1=1 THEN id END"},
+			wantErr: "invalid order_by",
+		},
+		{
+			name:    "injected order_dir",
+			req:     connection.ListConnectionsRequest{Limit: 10, OrderBy: "id", OrderDir: "asc; DROP TABLE connections; --"},
+			wantErr: "invalid order_dir: asc; DROP TABLE connections; --",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := storage.List(ctx, tt.req)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTypes, types(resp))
+		})
+	}
+
+	var count int
+	require.NoError(t, storage.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM connections").Scan(&count))
+	assert.Equal(t, 3, count)
+}
+
+func TestStorage_ListTypes(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+	create := func(from, to int64, connType string) int64 {
+		conn, err := storage.Create(ctx, connection.CreateConnectionRequest{
+			FromNoteID: from, ToNoteID: to, Type: connType, Strength: 5,
+		})
+		require.NoError(t, err)
+		return conn.ID
+	}
+	supports := create(note1ID, note2ID, "supports")
+	references := create(note1ID, note3ID, "references")
+	contradicts := create(note2ID, note3ID, "contradicts")
+
+	tests := []struct {
+		name     string
+		connType *string
+		types    []string
+		wantIDs  []int64
+	}{
+		{name: "no filter", wantIDs: []int64{supports, references, contradicts}},
+		{name: "single type", types: []string{"supports"}, wantIDs: []int64{supports}},
+		{name: "several types", types: []string{"supports", "references"}, wantIDs: []int64{supports, references}},
+		{name: "combined with type", connType: strPtr("contradicts"), types: []string{"supports"}, wantIDs: []int64{supports, contradicts}},
+		{name: "type repeated in types", connType: strPtr("supports"), types: []string{"supports"}, wantIDs: []int64{supports}},
+		{name: "empty types keeps type", connType: strPtr("references"), types: []string{}, wantIDs: []int64{references}},
+		{name: "no match", types: []string{"relates_to", "similar_to"}, wantIDs: []int64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := storage.List(ctx, connection.ListConnectionsRequest{
+				Limit: 10, Type: tt.connType, Types: tt.types, OrderBy: "id", OrderDir: "asc",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantIDs, connectionIDs(response.Items))
+			assert.Equal(t, int64(len(tt.wantIDs)), response.Total)
+		})
+	}
+}
+
+func TestStorage_Count(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+	for _, req := range []connection.CreateConnectionRequest{
+		{FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 8, Source: strPtr("agent")},
+		{FromNoteID: note1ID, ToNoteID: note3ID, Type: "references", Strength: 5, Description: strPtr("Cites the original paper")},
+		{FromNoteID: note2ID, ToNoteID: note3ID, Type: "contradicts", Strength: 5, Source: strPtr("agent")},
+	} {
+		_, err := storage.Create(ctx, req)
+		require.NoError(t, err)
+	}
+
+	fromNote1 := note1ID
+	toNote3 := note3ID
+	strength := 5
+
+	tests := []struct {
+		name string
+		req  connection.ListConnectionsRequest
+		want int64
+	}{
+		{name: "no filter", req: connection.ListConnectionsRequest{}, want: 3},
+		{name: "from note", req: connection.ListConnectionsRequest{FromNoteID: &fromNote1}, want: 2},
+		{name: "to note", req: connection.ListConnectionsRequest{ToNoteID: &toNote3}, want: 2},
+		{name: "type", req: connection.ListConnectionsRequest{Type: strPtr("supports")}, want: 1},
+		{name: "types", req: connection.ListConnectionsRequest{Types: []string{"supports", "contradicts"}}, want: 2},
+		{name: "strength", req: connection.ListConnectionsRequest{Strength: &strength}, want: 2},
+		{name: "source", req: connection.ListConnectionsRequest{Source: strPtr("agent")}, want: 2},
+		{name: "description search", req: connection.ListConnectionsRequest{DescriptionSearch: strPtr("paper")}, want: 1},
+		{name: "combined", req: connection.ListConnectionsRequest{FromNoteID: &fromNote1, Source: strPtr("agent")}, want: 1},
+		{name: "no match", req: connection.ListConnectionsRequest{Type: strPtr("similar_to")}, want: 0},
+		// Paging does not change the total
+		{name: "limit and offset ignored", req: connection.ListConnectionsRequest{Limit: 1, Offset: 2}, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, err := storage.Count(ctx, tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, count)
+
+			listReq := tt.req
+			listReq.Limit = 1
+			resp, err := storage.List(ctx, listReq)
+			require.NoError(t, err)
+			assert.Equal(t, resp.Total, count)
+		})
+	}
+}
+
+func TestStorage_CreateIdempotencyKey(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+	first, err := storage.Create(ctx, connection.CreateConnectionRequest{
+		FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 5, IdempotencyKey: "retry-1",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		req     connection.CreateConnectionRequest
+		wantID  func(id int64) bool
+		wantErr string
+	}{
+		{
+			name:   "repeated key returns the first connection",
+			req:    connection.CreateConnectionRequest{FromNoteID: note2ID, ToNoteID: note3ID, Type: "references", Strength: 9, IdempotencyKey: "retry-1"},
+			wantID: func(id int64) bool { return id == first.ID },
+		},
+		{
+			name:   "another key creates a connection",
+			req:    connection.CreateConnectionRequest{FromNoteID: note2ID, ToNoteID: note3ID, Type: "references", Strength: 9, IdempotencyKey: "retry-2"},
+			wantID: func(id int64) bool { return id != first.ID },
+		},
+		{
+			name:    "a new key does not skip the duplicate check",
+			req:     connection.CreateConnectionRequest{FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 5, IdempotencyKey: "retry-3"},
+			wantErr: "already exists",
+		},
+		{
+			name:    "blank key",
+			req:     connection.CreateConnectionRequest{FromNoteID: note3ID, ToNoteID: note1ID, Type: "supports", Strength: 5, IdempotencyKey: " "},
+			wantErr: "idempotency key must not be blank",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := storage.Create(ctx, tt.req)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.wantID(got.ID), "unexpected ID %d", got.ID)
+		})
+	}
+
+	conn, err := storage.Get(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 5, conn.Strength)
+
+	t.Run("with inverse", func(t *testing.T) {
+		req := connection.CreateConnectionRequest{FromNoteID: note3ID, ToNoteID: note1ID, Type: "precedes", Strength: 5, IdempotencyKey: "inverse-1"}
+		conn, inverse, err := storage.CreateWithInverse(ctx, req)
+		require.NoError(t, err)
+		require.NotNil(t, inverse)
+
+		again, againInverse, err := storage.CreateWithInverse(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, conn.ID, again.ID)
+		require.NotNil(t, againInverse)
+		assert.Equal(t, inverse.ID, againInverse.ID)
+
+		// Create and CreateWithInverse share the connection keys
+		plain, err := storage.Create(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, conn.ID, plain.ID)
+	})
+}
+
+func TestStorage_NotFound(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	strength := 5
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{name: "get", call: func() error { _, err := storage.Get(ctx, 99999); return err }},
+		{name: "update", call: func() error {
+			_, err := storage.Update(ctx, 99999, connection.UpdateConnectionRequest{Strength: &strength})
+			return err
+		}},
+		{name: "delete", call: func() error { return storage.Delete(ctx, 99999) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			assert.ErrorIs(t, err, connection.ErrNotFound)
+			assert.EqualError(t, err, "connection not found: 99999")
+		})
+	}
+}
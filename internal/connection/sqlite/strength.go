@@ -0,0 +1,73 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+// AdjustStrength adds delta to a connection's strength, clamped to 1-10, and
+// returns the connection. A negative delta weakens it. The new strength is
+// computed by the UPDATE itself, so concurrent adjustments never lose one
+// another the way a read-modify-write through Update can. A delta that the
+// clamp cancels out leaves the connection, and its updated_at, unchanged.
+func (s *Storage) AdjustStrength(ctx context.Context, id int64, delta int) (*connection.Connection, error) {
+	var result *connection.Connection
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.adjustStrength(ctx, id, delta)
+		return err
+	})
+	return result, err
+}
+
+// adjustStrength makes a single attempt at AdjustStrength
+func (s *Storage) adjustStrength(ctx context.Context, id int64, delta int) (*connection.Connection, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// The transaction keeps the audited old value consistent with the update
+	var before *connection.Connection
+	if s.audit {
+		if before, err = getConnection(ctx, tx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE connections
+		SET strength = MIN(?, MAX(?, strength + ?)), updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND strength != MIN(?, MAX(?, strength + ?))
+	`, connection.MaxStrength, connection.MinStrength, delta, id, connection.MaxStrength, connection.MinStrength, delta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to adjust connection strength: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	// Reports a missing connection, or returns one already at the clamp
+	after, err := getConnection(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.audit && rowsAffected > 0 {
+		changes := map[string]interface{}{"strength": auditChange{Old: before.Strength, New: after.Strength}}
+		if err := recordAudit(ctx, tx, id, connection.AuditActionUpdate, changes); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return after, nil
+}
@@ -0,0 +1,179 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+func TestStorage_AdjustStrength(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		strength     int
+		delta        int
+		wantStrength int
+	}{
+		{name: "reinforce", strength: 5, delta: 2, wantStrength: 7},
+		{name: "weaken", strength: 5, delta: -3, wantStrength: 2},
+		{name: "up to the maximum", strength: 8, delta: 2, wantStrength: 10},
+		{name: "clamped at the maximum", strength: 8, delta: 5, wantStrength: 10},
+		{name: "already at the maximum", strength: 10, delta: 1, wantStrength: 10},
+		{name: "down to the minimum", strength: 3, delta: -2, wantStrength: 1},
+		{name: "clamped at the minimum", strength: 3, delta: -50, wantStrength: 1},
+		{name: "already at the minimum", strength: 1, delta: -1, wantStrength: 1},
+		{name: "leaving the minimum", strength: 1, delta: 1, wantStrength: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newTestStorage(t)
+			note1ID, note2ID, _ := createTestNotes(t, storage.db)
+
+			conn, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: tt.strength})
+			require.NoError(t, err)
+
+			adjusted, err := storage.AdjustStrength(ctx, conn.ID, tt.delta)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStrength, adjusted.Strength)
+
+			stored, err := storage.Get(ctx, conn.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStrength, stored.Strength)
+		})
+	}
+
+	t.Run("unknown connection", func(t *testing.T) {
+		storage := newTestStorage(t)
+
+		_, err := storage.AdjustStrength(ctx, 12345, 1)
+		assert.ErrorContains(t, err, "connection not found: 12345")
+	})
+
+	t.Run("concurrent adjustments are not lost", func(t *testing.T) {
+		storage := newTestStorage(t)
+		note1ID, note2ID, _ := createTestNotes(t, storage.db)
+
+		conn, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 5})
+		require.NoError(t, err)
+
+		// Five reinforcements and four weakenings can never reach a clamp from 5
+		var wg sync.WaitGroup
+		errs := make(chan error, 9)
+		for _, delta := range []int{1, -1, 1, -1, 1, -1, 1, -1, 1} {
+			wg.Add(1)
+			go func(delta int) {
+				defer wg.Done()
+				_, err := storage.AdjustStrength(ctx, conn.ID, delta)
+				errs <- err
+			}(delta)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		stored, err := storage.Get(ctx, conn.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 6, stored.Strength)
+	})
+
+	t.Run("audit records only real changes", func(t *testing.T) {
+		storage := newTestStorage(t, WithAudit(true))
+		note1ID, note2ID, _ := createTestNotes(t, storage.db)
+
+		conn, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 8})
+		require.NoError(t, err)
+
+		_, err = storage.AdjustStrength(ctx, conn.ID, 5)
+		require.NoError(t, err)
+		_, err = storage.AdjustStrength(ctx, conn.ID, 1)
+		require.NoError(t, err)
+
+		entries, err := storage.GetConnectionAudit(ctx, conn.ID)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, connection.AuditActionUpdate, entries[1].Action)
+		assert.Equal(t, map[string]interface{}{"strength": map[string]interface{}{"old": float64(8), "new": float64(10)}}, entries[1].Changes)
+	})
+}
+
+func TestStorage_StrengthBounds(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("schema enforces the model bounds", func(t *testing.T) {
+		storage := newTestStorage(t)
+
+		var schema string
+		require.NoError(t, storage.db.QueryRowContext(ctx, "SELECT sql FROM sqlite_master WHERE type = 'table' AND name = 'connections'").Scan(&schema))
+		assert.Contains(t, schema, fmt.Sprintf("CHECK (strength >= %d AND strength <= %d)", connection.MinStrength, connection.MaxStrength))
+	})
+
+	// Every layer that rejects a strength reports it the same way
+	tests := []struct {
+		name     string
+		strength int
+		reject   func(t *testing.T, storage *Storage, from, to int64, strength int) string
+	}{
+		{
+			name:     "create above the maximum",
+			strength: connection.MaxStrength + 1,
+			reject: func(t *testing.T, storage *Storage, from, to int64, strength int) string {
+				_, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: from, ToNoteID: to, Type: "supports", Strength: strength})
+				require.Error(t, err)
+				return err.Error()
+			},
+		},
+		{
+			name:     "update below the minimum",
+			strength: connection.MinStrength - 1,
+			reject: func(t *testing.T, storage *Storage, from, to int64, strength int) string {
+				conn, err := storage.Create(ctx, connection.CreateConnectionRequest{FromNoteID: from, ToNoteID: to, Type: "supports", Strength: 5})
+				require.NoError(t, err)
+				_, err = storage.Update(ctx, conn.ID, connection.UpdateConnectionRequest{Strength: &strength})
+				require.Error(t, err)
+				return err.Error()
+			},
+		},
+		{
+			name:     "csv import row",
+			strength: connection.MaxStrength + 2,
+			reject: func(t *testing.T, storage *Storage, from, to int64, strength int) string {
+				result, err := storage.ImportConnectionsCSV(ctx, fmt.Sprintf("from_note_id,to_note_id,type,strength\n%d,%d,supports,%d\n", from, to, strength))
+				require.NoError(t, err)
+				require.Len(t, result.Errors, 1)
+				return result.Errors[0].Error
+			},
+		},
+		{
+			name:     "database check constraint",
+			strength: connection.MaxStrength + 1,
+			reject: func(t *testing.T, storage *Storage, from, to int64, strength int) string {
+				// insertRow skips validation, so the CHECK constraint rejects the row
+				_, err := storage.insertRow(ctx, storage.db, connection.CreateConnectionRequest{FromNoteID: from, ToNoteID: to, Type: "supports", Strength: strength})
+				require.Error(t, err)
+				return err.Error()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newTestStorage(t)
+			note1ID, note2ID, _ := createTestNotes(t, storage.db)
+
+			got := tt.reject(t, storage, note1ID, note2ID, tt.strength)
+			want := fmt.Sprintf("strength must be between %d and %d, got: %d", connection.MinStrength, connection.MaxStrength, tt.strength)
+			assert.Equal(t, want, got)
+			assert.Equal(t, connection.ValidateStrength(tt.strength).Error(), got)
+		})
+	}
+}
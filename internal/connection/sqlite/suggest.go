@@ -0,0 +1,270 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// suggestionKeywords is the number of words of a note searched for in other notes
+const suggestionKeywords = 12
+
+// unconnectedClause keeps candidate notes n that share no connection, in
+// either direction, with the note bound to both placeholders
+const unconnectedClause = `
+	AND NOT EXISTS (
+		SELECT 1 FROM connections c
+		WHERE (c.from_note_id = ? AND c.to_note_id = n.id)
+			OR (c.to_note_id = ? AND c.from_note_id = n.id)
+	)`
+
+// stopWords are common English words left out of similarity searches
+var stopWords = map[string]bool{
+	"about": true, "after": true, "also": true, "and": true, "are": true, "because": true,
+	"been": true, "before": true, "but": true, "can": true, "could": true, "does": true,
+	"for": true, "from": true, "had": true, "has": true, "have": true, "how": true,
+	"into": true, "its": true, "just": true, "more": true, "most": true, "not": true,
+	"only": true, "other": true, "our": true, "should": true, "some": true, "such": true,
+	"than": true, "that": true, "the": true, "their": true, "them": true, "then": true,
+	"there": true, "these": true, "they": true, "this": true, "those": true, "too": true,
+	"use": true, "used": true, "very": true, "was": true, "were": true, "what": true,
+	"when": true, "where": true, "which": true, "while": true, "who": true, "why": true,
+	"will": true, "with": true, "would": true, "you": true, "your": true,
+}
+
+// suggestionCandidate accumulates the evidence for one suggested note
+type suggestionCandidate struct {
+	title      string
+	sharedTags []string
+	similarity float64
+}
+
+// SuggestConnections recommends notes to connect to noteID, best first. A
+// note scores the share of noteID's tags it carries plus its full-text
+// similarity to noteID's most frequent title and content words, relative to
+// the most similar note. Notes already connected to noteID in either
+// direction are never suggested.
+func (s *Storage) SuggestConnections(ctx context.Context, noteID int64, limit int) ([]connection.Suggestion, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive, got: %d", limit)
+	}
+
+	var title, content string
+	var tagsJSON sql.NullString
+	err := s.db.QueryRowContext(ctx, "SELECT title, content, tags FROM notes WHERE id = ?", noteID).Scan(&title, &content, &tagsJSON)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %d", note.ErrNotFound, noteID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get note: %w", err)
+	}
+
+	candidates := make(map[int64]*suggestionCandidate)
+	candidate := func(id int64, title string) *suggestionCandidate {
+		c, ok := candidates[id]
+		if !ok {
+			c = &suggestionCandidate{title: title}
+			candidates[id] = c
+		}
+		return c
+	}
+
+	tags := noteTags(tagsJSON)
+	if len(tags) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(tags)), ", ")
+		query := `
+			SELECT DISTINCT n.id, n.title, t.value
+			FROM notes n, json_each(n.tags) t
+			WHERE json_valid(n.tags) AND t.type = 'text'
+				AND t.value IN (` + placeholders + `)
+				AND n.id != ?` + unconnectedClause + `
+			ORDER BY n.id, t.value`
+
+		args := make([]interface{}, 0, len(tags)+3)
+		for _, tag := range tags {
+			args = append(args, tag)
+		}
+		args = append(args, noteID, noteID, noteID)
+
+		rows, err := s.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find notes with shared tags: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var id int64
+			var noteTitle, tag string
+			if err := rows.Scan(&id, &noteTitle, &tag); err != nil {
+				return nil, fmt.Errorf("failed to scan shared tag: %w", err)
+			}
+			c := candidate(id, noteTitle)
+			c.sharedTags = append(c.sharedTags, tag)
+		}
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("error iterating rows: %w", err)
+		}
+	}
+
+	if match := similarityQuery(title, content); match != "" {
+		query := `
+			SELECT n.id, n.title, notes_fts.rank
+			FROM notes_fts
+			JOIN notes n ON n.id = notes_fts.rowid
+			WHERE notes_fts MATCH ?
+				AND n.id != ?` + unconnectedClause + `
+			ORDER BY notes_fts.rank, n.id
+			LIMIT ?`
+
+		// Fetch more than limit so tag overlap can still reorder the best matches
+		rows, err := s.db.QueryContext(ctx, query, match, noteID, noteID, noteID, limit*4)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find notes with similar content: %w", err)
+		}
+		defer rows.Close()
+
+		// bm25 ranks are negative and the first row is the best match
+		best := 0.0
+		for rows.Next() {
+			var id int64
+			var noteTitle string
+			var rank float64
+			if err := rows.Scan(&id, &noteTitle, &rank); err != nil {
+				return nil, fmt.Errorf("failed to scan similar note: %w", err)
+			}
+			if best == 0 {
+				best = rank
+			}
+			similarity := 1.0
+			if best != 0 {
+				similarity = rank / best
+			}
+			candidate(id, noteTitle).similarity = similarity
+		}
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("error iterating rows: %w", err)
+		}
+	}
+
+	suggestions := make([]connection.Suggestion, 0, len(candidates))
+	for id, c := range candidates {
+		tagScore := 0.0
+		if len(tags) > 0 {
+			tagScore = float64(len(c.sharedTags)) / float64(len(tags))
+		}
+		sharedTags := c.sharedTags
+		if sharedTags == nil {
+			sharedTags = []string{}
+		}
+		suggestions = append(suggestions, connection.Suggestion{
+			NoteID:     id,
+			Title:      c.title,
+			Score:      tagScore + c.similarity,
+			SharedTags: sharedTags,
+			Reason:     suggestionReason(len(tags), c),
+		})
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].NoteID < suggestions[j].NoteID
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	return suggestions, nil
+}
+
+// noteTags decodes a note's tags column, dropping blanks and duplicates.
+// Missing or malformed JSON has no tags.
+func noteTags(tagsJSON sql.NullString) []string {
+	if !tagsJSON.Valid {
+		return nil
+	}
+	var raw []string
+	if err := json.Unmarshal([]byte(tagsJSON.String), &raw); err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(raw))
+	tags := make([]string, 0, len(raw))
+	for _, tag := range raw {
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// similarityQuery builds an FTS5 query matching any of the most frequent
+// words of a note, as counted by keywordCounts. It returns "" when no word is
+// left.
+func similarityQuery(title, content string) string {
+	counts := keywordCounts(title, content)
+
+	words := make([]string, 0, len(counts))
+	for word := range counts {
+		words = append(words, word)
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if counts[words[i]] != counts[words[j]] {
+			return counts[words[i]] > counts[words[j]]
+		}
+		return words[i] < words[j]
+	})
+	if len(words) > suggestionKeywords {
+		words = words[:suggestionKeywords]
+	}
+
+	// Words hold only letters and digits, so quoting them is enough to keep
+	// FTS5 from reading them as operators such as OR or NEAR
+	for i, word := range words {
+		words[i] = `"` + word + `"`
+	}
+	return strings.Join(words, " OR ")
+}
+
+// keywordCounts counts the words of a note's title and content, lowercased.
+// Title words count twice. Words shorter than three letters and stop words
+// are skipped.
+func keywordCounts(title, content string) map[string]int {
+	counts := make(map[string]int)
+	addWords := func(text string, weight int) {
+		words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		for _, word := range words {
+			if len([]rune(word)) < 3 || stopWords[word] {
+				continue
+			}
+			counts[word] += weight
+		}
+	}
+	addWords(title, 2)
+	addWords(content, 1)
+	return counts
+}
+
+// suggestionReason describes why a candidate was suggested
+func suggestionReason(noteTagCount int, c *suggestionCandidate) string {
+	var reasons []string
+	if len(c.sharedTags) > 0 {
+		reasons = append(reasons, fmt.Sprintf("shares %d of %d tags (%s)", len(c.sharedTags), noteTagCount, strings.Join(c.sharedTags, ", ")))
+	}
+	if c.similarity > 0 {
+		reasons = append(reasons, fmt.Sprintf("similar title or content (%.0f%% of best match)", c.similarity*100))
+	}
+	return strings.Join(reasons, "; ")
+}
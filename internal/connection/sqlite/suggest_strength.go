@@ -0,0 +1,197 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// SuggestStrength proposes a strength for connecting fromNoteID and toNoteID
+// from three signals, each between 0 and 1:
+//   - tag overlap: the Jaccard index of the two notes' tags
+//   - neighbor overlap: the Jaccard index of the notes each is connected to in
+//     either direction, leaving out the two notes themselves. Notes connected
+//     to both are the co-citations of the pair and the middle of the two-step
+//     paths between them.
+//   - content similarity: the cosine similarity of their word counts, counted
+//     as SuggestConnections does
+//
+// The score is the average of the signals weighted by the storage's
+// StrengthWeights. A signal neither note has evidence for, such as tags when
+// neither note is tagged, is left out instead of counting as no overlap. The
+// score maps linearly onto MinStrength-MaxStrength. With no signal left the
+// default strength is suggested. Existing connections between the two notes
+// are not taken into account.
+func (s *Storage) SuggestStrength(ctx context.Context, fromNoteID, toNoteID int64) (*connection.StrengthSuggestion, error) {
+	if fromNoteID == toNoteID {
+		return nil, fmt.Errorf("from and to note must differ")
+	}
+
+	from, err := s.strengthNote(ctx, fromNoteID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.strengthNote(ctx, toNoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	fromNeighbors, err := s.neighborIDs(ctx, fromNoteID, toNoteID)
+	if err != nil {
+		return nil, err
+	}
+	toNeighbors, err := s.neighborIDs(ctx, toNoteID, fromNoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestion := &connection.StrengthSuggestion{
+		FromNoteID:        fromNoteID,
+		ToNoteID:          toNoteID,
+		SharedTags:        []string{},
+		SharedNeighborIDs: []int64{},
+	}
+
+	var weighted, totalWeight float64
+	var reasons []string
+
+	// Tags
+	toTags := make(map[string]bool, len(to.tags))
+	for _, tag := range to.tags {
+		toTags[tag] = true
+	}
+	for _, tag := range from.tags {
+		if toTags[tag] {
+			suggestion.SharedTags = append(suggestion.SharedTags, tag)
+		}
+	}
+	if tagUnion := len(from.tags) + len(to.tags) - len(suggestion.SharedTags); tagUnion > 0 {
+		suggestion.TagOverlap = float64(len(suggestion.SharedTags)) / float64(tagUnion)
+		if s.weights.Tags > 0 {
+			weighted += s.weights.Tags * suggestion.TagOverlap
+			totalWeight += s.weights.Tags
+			if len(suggestion.SharedTags) > 0 {
+				reasons = append(reasons, fmt.Sprintf("shares %d of %d tags (%s)", len(suggestion.SharedTags), tagUnion, strings.Join(suggestion.SharedTags, ", ")))
+			} else {
+				reasons = append(reasons, fmt.Sprintf("shares none of %d tags", tagUnion))
+			}
+		}
+	}
+
+	// Neighbors
+	for id := range fromNeighbors {
+		if toNeighbors[id] {
+			suggestion.SharedNeighborIDs = append(suggestion.SharedNeighborIDs, id)
+		}
+	}
+	sort.Slice(suggestion.SharedNeighborIDs, func(i, j int) bool {
+		return suggestion.SharedNeighborIDs[i] < suggestion.SharedNeighborIDs[j]
+	})
+	if neighborUnion := len(fromNeighbors) + len(toNeighbors) - len(suggestion.SharedNeighborIDs); neighborUnion > 0 {
+		suggestion.NeighborOverlap = float64(len(suggestion.SharedNeighborIDs)) / float64(neighborUnion)
+		if s.weights.Neighbors > 0 {
+			weighted += s.weights.Neighbors * suggestion.NeighborOverlap
+			totalWeight += s.weights.Neighbors
+			if len(suggestion.SharedNeighborIDs) > 0 {
+				reasons = append(reasons, fmt.Sprintf("shares %d of %d connected notes", len(suggestion.SharedNeighborIDs), neighborUnion))
+			} else {
+				reasons = append(reasons, fmt.Sprintf("shares none of %d connected notes", neighborUnion))
+			}
+		}
+	}
+
+	// Content
+	if len(from.words) > 0 || len(to.words) > 0 {
+		suggestion.ContentSimilarity = cosineSimilarity(from.words, to.words)
+		if s.weights.Content > 0 {
+			weighted += s.weights.Content * suggestion.ContentSimilarity
+			totalWeight += s.weights.Content
+			reasons = append(reasons, fmt.Sprintf("%.0f%% similar title and content words", suggestion.ContentSimilarity*100))
+		}
+	}
+
+	if totalWeight == 0 {
+		suggestion.Strength = connection.DefaultStrength()
+		suggestion.Rationale = fmt.Sprintf("No tags, connections or words to compare, so the default strength %d is suggested", suggestion.Strength)
+		return suggestion, nil
+	}
+
+	suggestion.Score = weighted / totalWeight
+	suggestion.Strength = connection.MinStrength + int(math.Round(suggestion.Score*float64(connection.MaxStrength-connection.MinStrength)))
+	suggestion.Rationale = fmt.Sprintf("Strength %d (score %.2f): %s", suggestion.Strength, suggestion.Score, strings.Join(reasons, "; "))
+	return suggestion, nil
+}
+
+// strengthNote holds what SuggestStrength compares of a note
+type strengthNote struct {
+	tags  []string
+	words map[string]int
+}
+
+// strengthNote loads the tags and word counts of a note
+func (s *Storage) strengthNote(ctx context.Context, noteID int64) (*strengthNote, error) {
+	var title, content string
+	var tagsJSON sql.NullString
+	err := s.db.QueryRowContext(ctx, "SELECT title, content, tags FROM notes WHERE id = ?", noteID).Scan(&title, &content, &tagsJSON)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %d", note.ErrNotFound, noteID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get note: %w", err)
+	}
+
+	return &strengthNote{tags: noteTags(tagsJSON), words: keywordCounts(title, content)}, nil
+}
+
+// neighborIDs returns the notes connected to noteID in either direction,
+// except excludeID
+func (s *Storage) neighborIDs(ctx context.Context, noteID, excludeID int64) (map[int64]bool, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT to_note_id FROM connections WHERE from_note_id = ?
+		UNION
+		SELECT from_note_id FROM connections WHERE to_note_id = ?`,
+		noteID, noteID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connected notes: %w", err)
+	}
+	defer rows.Close()
+
+	neighbors := make(map[int64]bool)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan connected note: %w", err)
+		}
+		if id != noteID && id != excludeID {
+			neighbors[id] = true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return neighbors, nil
+}
+
+// cosineSimilarity compares two word count vectors, 0 when either is empty
+func cosineSimilarity(a, b map[string]int) float64 {
+	var dot, normA, normB float64
+	for word, count := range a {
+		normA += float64(count * count)
+		dot += float64(count * b[word])
+	}
+	for _, count := range b {
+		normB += float64(count * count)
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(normA*normB)
+}
@@ -0,0 +1,166 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+func TestStorage_SuggestStrength(t *testing.T) {
+	ctx := context.Background()
+
+	type notes struct {
+		raft, raftCopy, sourdough, hub, quiet, terse, goRaft int64
+	}
+
+	// seed creates the same notes in every storage so the cases only differ
+	// in weights and the pair compared
+	seed := func(t *testing.T, storage *Storage) notes {
+		insert := func(title, content, tags string) int64 {
+			result, err := storage.db.Exec(
+				"INSERT INTO notes (title, content, type, tags, metadata) VALUES (?, ?, 'text', ?, '{}')",
+				title, content, tags,
+			)
+			require.NoError(t, err)
+			id, err := result.LastInsertId()
+			require.NoError(t, err)
+			return id
+		}
+
+		n := notes{
+			raft:      insert("Raft consensus", "Raft leader election", `["distributed", "consensus", "go"]`),
+			raftCopy:  insert("Consensus in Raft", "Raft leader election", `["distributed", "consensus", "go"]`),
+			sourdough: insert("Sourdough", "Flour water salt", `["baking"]`),
+			hub:       insert("Hub", "Index of everything", `[]`),
+			quiet:     insert("Hi", "ok", `[]`),
+			terse:     insert("Ab", "", `[]`),
+			goRaft:    insert("Consensus, Raft", "Raft leader election", `["go"]`),
+		}
+
+		for _, pair := range [][2]int64{{n.raft, n.hub}, {n.hub, n.raftCopy}} {
+			_, err := storage.Create(ctx, connection.CreateConnectionRequest{
+				FromNoteID: pair[0], ToNoteID: pair[1], Type: "references", Strength: 5,
+			})
+			require.NoError(t, err)
+		}
+		return n
+	}
+
+	tests := []struct {
+		name          string
+		weights       *connection.StrengthWeights
+		pair          func(n notes) (int64, int64)
+		wantStrength  int
+		wantNeighbors func(n notes) []int64
+		wantTags      []string
+		wantRationale []string
+		wantErr       string
+		wantErrIs     error
+	}{
+		{
+			name:          "identical notes sharing a neighbor",
+			pair:          func(n notes) (int64, int64) { return n.raft, n.raftCopy },
+			wantStrength:  10,
+			wantNeighbors: func(n notes) []int64 { return []int64{n.hub} },
+			wantTags:      []string{"distributed", "consensus", "go"},
+			wantRationale: []string{"shares 3 of 3 tags (distributed, consensus, go)", "shares 1 of 1 connected notes", "100% similar title and content words"},
+		},
+		{
+			name:          "nothing in common",
+			pair:          func(n notes) (int64, int64) { return n.raft, n.sourdough },
+			wantStrength:  1,
+			wantTags:      []string{},
+			wantRationale: []string{"shares none of 4 tags", "shares none of 1 connected notes", "0% similar title and content words"},
+		},
+		{
+			name:          "signals are averaged",
+			pair:          func(n notes) (int64, int64) { return n.raft, n.goRaft },
+			wantStrength:  5,
+			wantTags:      []string{"go"},
+			wantRationale: []string{"shares 1 of 3 tags (go)", "shares none of 1 connected notes", "100% similar title and content words"},
+		},
+		{
+			name:          "only tags weighted",
+			weights:       &connection.StrengthWeights{Tags: 1},
+			pair:          func(n notes) (int64, int64) { return n.raft, n.goRaft },
+			wantStrength:  4,
+			wantTags:      []string{"go"},
+			wantRationale: []string{"Strength 4 (score 0.33): shares 1 of 3 tags (go)"},
+		},
+		{
+			name:          "content weighted heavily",
+			weights:       &connection.StrengthWeights{Tags: 1, Neighbors: 1, Content: 7},
+			pair:          func(n notes) (int64, int64) { return n.raft, n.goRaft },
+			wantStrength:  8,
+			wantTags:      []string{"go"},
+			wantRationale: []string{"score 0.81"},
+		},
+		{
+			name:          "no evidence uses the default strength",
+			pair:          func(n notes) (int64, int64) { return n.quiet, n.terse },
+			wantStrength:  connection.DefaultStrength(),
+			wantTags:      []string{},
+			wantRationale: []string{"default strength"},
+		},
+		{
+			name:          "no evidence for the weighted signals",
+			weights:       &connection.StrengthWeights{Neighbors: 1},
+			pair:          func(n notes) (int64, int64) { return n.raft, n.goRaft },
+			wantStrength:  1,
+			wantTags:      []string{"go"},
+			wantRationale: []string{"shares none of 1 connected notes"},
+		},
+		{
+			name:    "same note",
+			pair:    func(n notes) (int64, int64) { return n.raft, n.raft },
+			wantErr: "from and to note must differ",
+		},
+		{
+			name:      "missing note",
+			pair:      func(n notes) (int64, int64) { return n.raft, 9999 },
+			wantErr:   "note not found: 9999",
+			wantErrIs: note.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []Option
+			if tt.weights != nil {
+				opts = append(opts, WithStrengthWeights(*tt.weights))
+			}
+			storage := newTestStorage(t, opts...)
+			n := seed(t, storage)
+
+			from, to := tt.pair(n)
+			got, err := storage.SuggestStrength(ctx, from, to)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, from, got.FromNoteID)
+			assert.Equal(t, to, got.ToNoteID)
+			assert.Equal(t, tt.wantStrength, got.Strength)
+			assert.Equal(t, tt.wantTags, got.SharedTags)
+			wantNeighbors := []int64{}
+			if tt.wantNeighbors != nil {
+				wantNeighbors = tt.wantNeighbors(n)
+			}
+			assert.Equal(t, wantNeighbors, got.SharedNeighborIDs)
+			for _, want := range tt.wantRationale {
+				assert.Contains(t, got.Rationale, want)
+			}
+		})
+	}
+}
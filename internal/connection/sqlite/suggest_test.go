@@ -0,0 +1,141 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+func TestStorage_SuggestConnections(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	insert := func(title, content, tags string) int64 {
+		result, err := storage.db.Exec(
+			"INSERT INTO notes (title, content, type, tags, metadata) VALUES (?, ?, 'text', ?, '{}')",
+			title, content, tags,
+		)
+		require.NoError(t, err)
+		id, err := result.LastInsertId()
+		require.NoError(t, err)
+		return id
+	}
+
+	source := insert("Raft consensus", "Raft elects a leader and replicates a log across servers.", `["distributed", "consensus", "go"]`)
+	sameTopic := insert("Paxos vs Raft", "Both reach consensus; Raft is easier to follow than Paxos.", `["distributed", "consensus"]`)
+	tagOnly := insert("Goroutines", "Lightweight threads scheduled by the runtime.", `["go"]`)
+	textOnly := insert("Leader election", "A leader is elected when the log of servers diverges.", `[]`)
+	connected := insert("Raft log replication", "The leader replicates its log to followers.", `["distributed", "consensus"]`)
+	unrelated := insert("Sourdough", "Flour, water and salt.", `["baking"]`)
+	badTags := insert("Raft paper notes", "Notes on the Raft paper.", `not json`)
+	lonely := insert("Untagged", "Zzz.", `[]`)
+
+	_, err := storage.Create(ctx, connection.CreateConnectionRequest{
+		FromNoteID: connected, ToNoteID: source, Type: "references", Strength: 5,
+	})
+	require.NoError(t, err)
+
+	suggestions, err := storage.SuggestConnections(ctx, source, 10)
+	require.NoError(t, err)
+
+	byID := make(map[int64]connection.Suggestion)
+	var ids []int64
+	for _, s := range suggestions {
+		byID[s.NoteID] = s
+		ids = append(ids, s.NoteID)
+	}
+
+	t.Run("excludes the note, connected and unrelated notes", func(t *testing.T) {
+		assert.NotContains(t, ids, source)
+		assert.NotContains(t, ids, connected, "connected in the other direction")
+		assert.NotContains(t, ids, unrelated)
+		assert.NotContains(t, ids, lonely)
+	})
+
+	t.Run("tags and content together rank first", func(t *testing.T) {
+		require.NotEmpty(t, suggestions)
+		assert.Equal(t, sameTopic, suggestions[0].NoteID)
+		assert.Equal(t, []string{"consensus", "distributed"}, suggestions[0].SharedTags)
+		assert.Contains(t, suggestions[0].Reason, "shares 2 of 3 tags (consensus, distributed)")
+		assert.Contains(t, suggestions[0].Reason, "similar title or content")
+	})
+
+	t.Run("ordered by score", func(t *testing.T) {
+		for i := 1; i < len(suggestions); i++ {
+			assert.GreaterOrEqual(t, suggestions[i-1].Score, suggestions[i].Score)
+		}
+	})
+
+	tests := []struct {
+		name           string
+		noteID         int64
+		wantSharedTags []string
+		wantReason     string
+		wantNoReason   string
+	}{
+		{name: "shared tag only", noteID: tagOnly, wantSharedTags: []string{"go"}, wantReason: "shares 1 of 3 tags (go)", wantNoReason: "similar"},
+		{name: "similar content only", noteID: textOnly, wantSharedTags: []string{}, wantReason: "similar title or content", wantNoReason: "shares"},
+		{name: "malformed tags still match content", noteID: badTags, wantSharedTags: []string{}, wantReason: "similar title or content", wantNoReason: "shares"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, ok := byID[tt.noteID]
+			require.True(t, ok, "note %d not suggested", tt.noteID)
+			assert.Equal(t, tt.wantSharedTags, s.SharedTags)
+			assert.Contains(t, s.Reason, tt.wantReason)
+			assert.NotContains(t, s.Reason, tt.wantNoReason)
+			assert.Greater(t, s.Score, 0.0)
+			assert.LessOrEqual(t, s.Score, 2.0)
+		})
+	}
+
+	t.Run("limit", func(t *testing.T) {
+		limited, err := storage.SuggestConnections(ctx, source, 1)
+		require.NoError(t, err)
+		require.Len(t, limited, 1)
+		assert.Equal(t, sameTopic, limited[0].NoteID)
+	})
+
+	t.Run("nothing in common", func(t *testing.T) {
+		none, err := storage.SuggestConnections(ctx, lonely, 10)
+		require.NoError(t, err)
+		assert.Empty(t, none)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := storage.SuggestConnections(ctx, 9999, 10)
+		assert.ErrorIs(t, err, note.ErrNotFound)
+		assert.ErrorContains(t, err, "note not found: 9999")
+
+		_, err = storage.SuggestConnections(ctx, source, 0)
+		assert.ErrorContains(t, err, "limit must be positive")
+	})
+}
+
+func TestSimilarityQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		title   string
+		content string
+		want    string
+	}{
+		{name: "empty", want: ""},
+		{name: "only stop words and short words", title: "The", content: "and if it is", want: ""},
+		{name: "title words count twice", title: "Graph", content: "nodes nodes graph", want: `"graph" OR "nodes"`},
+		{name: "ties sorted by word", content: "zeta alpha", want: `"alpha" OR "zeta"`},
+		{name: "punctuation and operators are plain words", content: `"NEAR" OR* foo-bar`, want: `"bar" OR "foo" OR "near"`},
+		{name: "keeps the most frequent words", content: "a1a b2b c3c d4d e5e f6f g7g h8h i9i j0j k1k l2l m3m m3m", want: `"m3m" OR "a1a" OR "b2b" OR "c3c" OR "d4d" OR "e5e" OR "f6f" OR "g7g" OR "h8h" OR "i9i" OR "j0j" OR "k1k"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, similarityQuery(tt.title, tt.content))
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+// Touch marks a connection as revisited by refreshing its updated_at, so
+// listings ordered by updated_at bring it forward. Nothing else about the
+// connection changes and no audit entry is recorded. Strength decay ages
+// connections from created_at and is not affected.
+func (s *Storage) Touch(ctx context.Context, id int64) error {
+	return s.withRetry(ctx, func() error {
+		return s.touch(ctx, id)
+	})
+}
+
+// touch makes a single attempt at Touch
+func (s *Storage) touch(ctx context.Context, id int64) error {
+	// The assignment is a no-op; update_connections_updated_at then sets the
+	// timestamp, which keeps the clock in one place
+	result, err := s.db.ExecContext(ctx, "UPDATE connections SET updated_at = updated_at WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to touch connection: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: %d", connection.ErrNotFound, id)
+	}
+
+	return nil
+}
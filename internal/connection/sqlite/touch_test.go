@@ -0,0 +1,89 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+func TestStorage_Touch(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+	// pinUpdatedAt moves updated_at into the past. update_connections_updated_at
+	// would overwrite it, so the trigger is recreated afterwards from its own SQL.
+	pinUpdatedAt := func(t *testing.T, id int64, updatedAt string) {
+		t.Helper()
+		var triggerSQL string
+		require.NoError(t, storage.db.QueryRow("SELECT sql FROM sqlite_master WHERE type = 'trigger' AND name = 'update_connections_updated_at'").Scan(&triggerSQL))
+		_, err := storage.db.Exec("DROP TRIGGER update_connections_updated_at")
+		require.NoError(t, err)
+		_, err = storage.db.Exec("UPDATE connections SET updated_at = ? WHERE id = ?", updatedAt, id)
+		require.NoError(t, err)
+		_, err = storage.db.Exec(triggerSQL)
+		require.NoError(t, err)
+	}
+
+	touched, err := storage.Create(ctx, connection.CreateConnectionRequest{
+		FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 7,
+		Description: strPtr("Backs up the claim"), Metadata: map[string]interface{}{"origin": "test"}, Source: strPtr("agent"),
+	})
+	require.NoError(t, err)
+	other, err := storage.Create(ctx, connection.CreateConnectionRequest{
+		FromNoteID: note2ID, ToNoteID: note3ID, Type: "references", Strength: 3,
+	})
+	require.NoError(t, err)
+	trashed, err := storage.Create(ctx, connection.CreateConnectionRequest{
+		FromNoteID: note1ID, ToNoteID: note3ID, Type: "relates_to", Strength: 5,
+	})
+	require.NoError(t, err)
+	require.NoError(t, storage.SoftDelete(ctx, trashed.ID))
+
+	pinned := "2024-01-01 10:00:00"
+	pinUpdatedAt(t, touched.ID, pinned)
+	pinUpdatedAt(t, other.ID, pinned)
+
+	before, err := storage.Get(ctx, touched.ID)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), before.UpdatedAt.UTC())
+
+	t.Run("advances updated_at and nothing else", func(t *testing.T) {
+		require.NoError(t, storage.Touch(ctx, touched.ID))
+
+		after, err := storage.Get(ctx, touched.ID)
+		require.NoError(t, err)
+		assert.True(t, after.UpdatedAt.After(before.UpdatedAt), "updated_at %v should be after %v", after.UpdatedAt, before.UpdatedAt)
+		assert.WithinDuration(t, time.Now(), after.UpdatedAt, time.Minute)
+
+		after.UpdatedAt = before.UpdatedAt
+		assert.Equal(t, before, after)
+	})
+
+	t.Run("other connections keep their updated_at", func(t *testing.T) {
+		got, err := storage.Get(ctx, other.ID)
+		require.NoError(t, err)
+		assert.Equal(t, before.UpdatedAt, got.UpdatedAt)
+	})
+
+	tests := []struct {
+		name string
+		id   int64
+	}{
+		{name: "unknown connection", id: other.ID + 100},
+		{name: "trashed connection", id: trashed.ID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := storage.Touch(ctx, tt.id)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, connection.ErrNotFound)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+// SoftDelete moves a connection into the trash. It disappears from every
+// query, count and duplicate check as if deleted, but Restore can bring it
+// back with its original ID. Deleting either note removes it from the trash
+// for good.
+func (s *Storage) SoftDelete(ctx context.Context, id int64) error {
+	return s.withRetry(ctx, func() error {
+		return s.softDelete(ctx, id)
+	})
+}
+
+// softDelete makes a single attempt at SoftDelete
+func (s *Storage) softDelete(ctx context.Context, id int64) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	deleted, err := getConnection(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO connection_trash ("+connectionColumns+") SELECT "+connectionColumns+" FROM connections WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to move connection to trash: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM connections WHERE id = ?", id); err != nil {
+		return fmt.Errorf("failed to delete connection: %w", err)
+	}
+
+	if s.audit {
+		if err := recordAudit(ctx, tx, id, connection.AuditActionDelete, auditSnapshot(deleted)); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Info("moved connection to trash", "id", id)
+	return nil
+}
+
+// Restore moves a connection out of the trash with its original ID and
+// fields. In the unique edge mode it fails if an equivalent connection was
+// created after the soft delete; delete that one first or switch to multi.
+func (s *Storage) Restore(ctx context.Context, id int64) (*connection.Connection, error) {
+	var result *connection.Connection
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.restore(ctx, id)
+		return err
+	})
+	return result, err
+}
+
+// restore makes a single attempt at Restore
+func (s *Storage) restore(ctx context.Context, id int64) (*connection.Connection, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var fromNoteID, toNoteID int64
+	var connType string
+	err = tx.QueryRowContext(ctx, "SELECT from_note_id, to_note_id, type FROM connection_trash WHERE id = ?", id).
+		Scan(&fromNoteID, &toNoteID, &connType)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %d", connection.ErrNotInTrash, id)
+		}
+		return nil, fmt.Errorf("failed to get deleted connection: %w", err)
+	}
+
+	// The type may have been removed from the configuration since the delete
+	if !connection.IsValidConnectionType(connType) {
+		return nil, fmt.Errorf("invalid connection type: %s", connType)
+	}
+	// The live reverse of a symmetric connection is the other half of an
+	// AutoSymmetric pair, so only the same direction blocks the restore
+	if err := s.checkDuplicate(ctx, tx, 0, fromNoteID, toNoteID, connType, true); err != nil {
+		return nil, fmt.Errorf("cannot restore connection %d: %w", id, err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO connections ("+connectionColumns+") SELECT "+connectionColumns+" FROM connection_trash WHERE id = ?", id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore connection: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM connection_trash WHERE id = ?", id); err != nil {
+		return nil, fmt.Errorf("failed to remove connection from trash: %w", err)
+	}
+
+	restored, err := getConnection(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.audit {
+		if err := recordAudit(ctx, tx, id, connection.AuditActionCreate, auditSnapshot(restored)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Info("restored connection from trash", "id", id)
+	return restored, nil
+}
+
+// ListDeletedConnections lists the connections in the trash, most recently
+// deleted first
+func (s *Storage) ListDeletedConnections(ctx context.Context, limit, offset int) (*connection.ListDeletedConnectionsResponse, error) {
+	var total int64
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM connection_trash").Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count deleted connections: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+connectionColumns+`, deleted_at
+		FROM connection_trash
+		ORDER BY deleted_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted connections: %w", err)
+	}
+	defer rows.Close()
+
+	items := []connection.DeletedConnection{}
+	for rows.Next() {
+		var item connection.DeletedConnection
+		var description, source sql.NullString
+		var metadataJSON sql.NullString
+
+		if err := rows.Scan(
+			&item.ID,
+			&item.FromNoteID,
+			&item.ToNoteID,
+			&item.Type,
+			&description,
+			&item.Strength,
+			&metadataJSON,
+			&source,
+			&item.CreatedAt,
+			&item.UpdatedAt,
+			&item.DeletedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan deleted connection: %w", err)
+		}
+
+		if description.Valid {
+			item.Description = &description.String
+		}
+		if source.Valid {
+			item.Source = &source.String
+		}
+		if metadataJSON.Valid && metadataJSON.String != "" && metadataJSON.String != "null" {
+			if err := json.Unmarshal([]byte(metadataJSON.String), &item.Metadata); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return &connection.ListDeletedConnectionsResponse{Items: items, Total: total}, nil
+}
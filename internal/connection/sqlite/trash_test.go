@@ -0,0 +1,323 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+func TestStorage_SoftDelete(t *testing.T) {
+	storage := newTestStorage(t, WithAudit(true))
+	ctx := context.Background()
+	note1ID, note2ID, _ := createTestNotes(t, storage.db)
+
+	conn, err := storage.Create(ctx, connection.CreateConnectionRequest{
+		FromNoteID:  note1ID,
+		ToNoteID:    note2ID,
+		Type:        "references",
+		Strength:    7,
+		Description: strPtr("cited in the intro"),
+		Metadata:    map[string]interface{}{"page": float64(3)},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, storage.SoftDelete(ctx, conn.ID))
+
+	_, err = storage.Get(ctx, conn.ID)
+	assert.ErrorContains(t, err, "connection not found")
+
+	list, err := storage.List(ctx, connection.ListConnectionsRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, list.Items)
+	assert.Zero(t, list.Total)
+
+	var outgoing, incoming int
+	require.NoError(t, storage.db.QueryRow("SELECT outgoing_count FROM notes WHERE id = ?", note1ID).Scan(&outgoing))
+	require.NoError(t, storage.db.QueryRow("SELECT incoming_count FROM notes WHERE id = ?", note2ID).Scan(&incoming))
+	assert.Zero(t, outgoing)
+	assert.Zero(t, incoming)
+
+	deleted, err := storage.ListDeletedConnections(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, deleted.Items, 1)
+	assert.Equal(t, int64(1), deleted.Total)
+	assert.Equal(t, *conn, deleted.Items[0].Connection)
+	assert.False(t, deleted.Items[0].DeletedAt.IsZero())
+
+	entries, err := storage.GetConnectionAudit(ctx, conn.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, entries)
+	assert.Equal(t, connection.AuditActionDelete, entries[len(entries)-1].Action)
+
+	t.Run("already deleted", func(t *testing.T) {
+		err := storage.SoftDelete(ctx, conn.ID)
+		assert.ErrorContains(t, err, "connection not found")
+	})
+}
+
+func TestStorage_Restore(t *testing.T) {
+	tests := []struct {
+		name     string
+		edgeMode connection.EdgeMode
+		setup    func(t *testing.T, storage *Storage, conn *connection.Connection)
+		wantErr  string
+	}{
+		{
+			name: "restores the original connection",
+		},
+		{
+			name: "re-created connection blocks restore in unique mode",
+			setup: func(t *testing.T, storage *Storage, conn *connection.Connection) {
+				_, err := storage.Create(context.Background(), connection.CreateConnectionRequest{
+					FromNoteID: conn.FromNoteID, ToNoteID: conn.ToNoteID, Type: conn.Type, Strength: 2,
+				})
+				require.NoError(t, err)
+			},
+			wantErr: "connection already exists",
+		},
+		{
+			name:     "re-created connection is allowed in multi mode",
+			edgeMode: connection.EdgeModeMulti,
+			setup: func(t *testing.T, storage *Storage, conn *connection.Connection) {
+				_, err := storage.Create(context.Background(), connection.CreateConnectionRequest{
+					FromNoteID: conn.FromNoteID, ToNoteID: conn.ToNoteID, Type: conn.Type, Strength: 2,
+				})
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []Option
+			if tt.edgeMode != "" {
+				opts = append(opts, WithEdgeMode(tt.edgeMode))
+			}
+			storage := newTestStorage(t, opts...)
+			ctx := context.Background()
+			note1ID, note2ID, _ := createTestNotes(t, storage.db)
+
+			conn, err := storage.Create(ctx, connection.CreateConnectionRequest{
+				FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 6, Source: strPtr("review"),
+			})
+			require.NoError(t, err)
+			require.NoError(t, storage.SoftDelete(ctx, conn.ID))
+
+			if tt.setup != nil {
+				tt.setup(t, storage, conn)
+			}
+
+			restored, err := storage.Restore(ctx, conn.ID)
+			if tt.wantErr != "" {
+				assert.NotErrorIs(t, err, connection.ErrNotInTrash)
+				assert.ErrorContains(t, err, tt.wantErr)
+
+				// A failed restore leaves the connection in the trash
+				deleted, err := storage.ListDeletedConnections(ctx, 10, 0)
+				require.NoError(t, err)
+				assert.Equal(t, int64(1), deleted.Total)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, conn, restored)
+
+			got, err := storage.Get(ctx, conn.ID)
+			require.NoError(t, err)
+			assert.Equal(t, conn, got)
+
+			deleted, err := storage.ListDeletedConnections(ctx, 10, 0)
+			require.NoError(t, err)
+			assert.Empty(t, deleted.Items)
+		})
+	}
+
+	t.Run("one half of an auto_symmetric pair restores", func(t *testing.T) {
+		storage := newTestStorage(t)
+		ctx := context.Background()
+		note1ID, note2ID, _ := createTestNotes(t, storage.db)
+
+		_, err := storage.Create(ctx, connection.CreateConnectionRequest{
+			FromNoteID: note1ID, ToNoteID: note2ID, Type: "relates_to", Strength: 6, AutoSymmetric: true,
+		})
+		require.NoError(t, err)
+		reverse, err := storage.List(ctx, connection.ListConnectionsRequest{FromNoteID: &note2ID, Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, reverse.Items, 1)
+		require.NoError(t, storage.SoftDelete(ctx, reverse.Items[0].ID))
+
+		restored, err := storage.Restore(ctx, reverse.Items[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, note2ID, restored.FromNoteID)
+		assert.Equal(t, note1ID, restored.ToNoteID)
+		assert.Equal(t, int64(2), countRows(t, storage, "connections"))
+	})
+
+	t.Run("deleting a note empties its connections from the trash", func(t *testing.T) {
+		storage := newTestStorage(t)
+		ctx := context.Background()
+		note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+		kept, err := storage.Create(ctx, connection.CreateConnectionRequest{
+			FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 6,
+		})
+		require.NoError(t, err)
+		removed, err := storage.Create(ctx, connection.CreateConnectionRequest{
+			FromNoteID: note1ID, ToNoteID: note3ID, Type: "supports", Strength: 6,
+		})
+		require.NoError(t, err)
+		require.NoError(t, storage.SoftDelete(ctx, kept.ID))
+		require.NoError(t, storage.SoftDelete(ctx, removed.ID))
+
+		_, err = storage.db.Exec("DELETE FROM notes WHERE id = ?", note3ID)
+		require.NoError(t, err)
+
+		_, err = storage.Restore(ctx, removed.ID)
+		assert.ErrorIs(t, err, connection.ErrNotInTrash)
+		_, err = storage.Restore(ctx, kept.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("not in trash", func(t *testing.T) {
+		storage := newTestStorage(t)
+		ctx := context.Background()
+		note1ID, note2ID, _ := createTestNotes(t, storage.db)
+
+		conn, err := storage.Create(ctx, connection.CreateConnectionRequest{
+			FromNoteID: note1ID, ToNoteID: note2ID, Type: "supports", Strength: 6,
+		})
+		require.NoError(t, err)
+
+		for _, id := range []int64{conn.ID, 999} {
+			_, err := storage.Restore(ctx, id)
+			assert.ErrorIs(t, err, connection.ErrNotInTrash)
+		}
+	})
+}
+
+func TestStorage_ListDeletedConnections(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+	var ids []int64
+	for _, to := range []int64{note2ID, note3ID, note1ID} {
+		conn, err := storage.Create(ctx, connection.CreateConnectionRequest{
+			FromNoteID: note1ID, ToNoteID: to, Type: "references", Strength: 5,
+		})
+		require.NoError(t, err)
+		ids = append(ids, conn.ID)
+	}
+	// The deletes share a deleted_at second, so the higher ID sorts first
+	for _, id := range ids {
+		require.NoError(t, storage.SoftDelete(ctx, id))
+	}
+
+	// A permanent delete leaves nothing in the trash
+	hardDeleted, err := storage.Create(ctx, connection.CreateConnectionRequest{
+		FromNoteID: note2ID, ToNoteID: note3ID, Type: "references", Strength: 5,
+	})
+	require.NoError(t, err)
+	require.NoError(t, storage.Delete(ctx, hardDeleted.ID))
+
+	tests := []struct {
+		name    string
+		limit   int
+		offset  int
+		wantIDs []int64
+	}{
+		{name: "all", limit: 10, wantIDs: []int64{ids[2], ids[1], ids[0]}},
+		{name: "limit", limit: 2, wantIDs: []int64{ids[2], ids[1]}},
+		{name: "offset", limit: 10, offset: 2, wantIDs: []int64{ids[0]}},
+		{name: "offset past end", limit: 10, offset: 5, wantIDs: []int64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := storage.ListDeletedConnections(ctx, tt.limit, tt.offset)
+			require.NoError(t, err)
+			assert.Equal(t, int64(3), response.Total)
+
+			gotIDs := []int64{}
+			for _, item := range response.Items {
+				gotIDs = append(gotIDs, item.ID)
+			}
+			assert.Equal(t, tt.wantIDs, gotIDs)
+		})
+	}
+}
+
+func TestStorage_GetConnectionStatsArchived(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	note1ID, note2ID, note3ID := createTestNotes(t, storage.db)
+
+	var trashed []int64
+	for _, c := range []struct {
+		from, to int64
+		connType string
+		strength int
+		trash    bool
+	}{
+		{note1ID, note2ID, "supports", 5, false},
+		{note1ID, note3ID, "supports", 5, true},
+		{note2ID, note3ID, "references", 8, true},
+	} {
+		conn, err := storage.Create(ctx, connection.CreateConnectionRequest{
+			FromNoteID: c.from, ToNoteID: c.to, Type: c.connType, Strength: c.strength,
+		})
+		require.NoError(t, err)
+		if c.trash {
+			trashed = append(trashed, conn.ID)
+		}
+	}
+	for _, id := range trashed {
+		require.NoError(t, storage.SoftDelete(ctx, id))
+	}
+
+	tests := []struct {
+		name           string
+		opts           connection.StatsOptions
+		wantTotal      int64
+		wantByType     map[string]int64
+		wantByStrength map[int]int64
+		wantNoteTotals map[int64]int64
+	}{
+		{
+			name:           "archived excluded by default",
+			wantTotal:      1,
+			wantByType:     map[string]int64{"supports": 1},
+			wantByStrength: map[int]int64{5: 1},
+			wantNoteTotals: map[int64]int64{note1ID: 1, note2ID: 1},
+		},
+		{
+			name:           "archived included",
+			opts:           connection.StatsOptions{IncludeArchived: true},
+			wantTotal:      3,
+			wantByType:     map[string]int64{"supports": 2, "references": 1},
+			wantByStrength: map[int]int64{5: 2, 8: 1},
+			wantNoteTotals: map[int64]int64{note1ID: 2, note2ID: 2, note3ID: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := storage.GetConnectionStats(ctx, tt.opts)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantTotal, stats.TotalConnections)
+			assert.Equal(t, int64(2), stats.ArchivedConnections)
+			assert.Equal(t, tt.wantByType, stats.ConnectionsByType)
+			assert.Equal(t, tt.wantByStrength, stats.ConnectionsByStrength)
+
+			noteTotals := map[int64]int64{}
+			for _, n := range stats.MostConnectedNotes {
+				noteTotals[n.NoteID] = n.TotalCount
+			}
+			assert.Equal(t, tt.wantNoteTotals, noteTotals)
+		})
+	}
+}
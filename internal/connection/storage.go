@@ -11,19 +11,46 @@ type Storage interface {
 	// Create creates a new connection
 	Create(ctx context.Context, req CreateConnectionRequest) (*Connection, error)
 	
+	// CreateWithInverse creates a connection and, if its type has an inverse, the inverse connection in one transaction
+	CreateWithInverse(ctx context.Context, req CreateConnectionRequest) (*Connection, *Connection, error)
+
+	// CreateByTitle creates a new connection between the notes with the given titles
+	CreateByTitle(ctx context.Context, req CreateByTitleRequest) (*Connection, error)
+
+	// CreateConnectionEnsuringNotes creates a connection between the notes with the given titles, creating missing notes
+	CreateConnectionEnsuringNotes(ctx context.Context, req EnsureRequest) (*Connection, error)
+	
 	// Get retrieves a connection by ID
 	Get(ctx context.Context, id int64) (*Connection, error)
 	
+	// GetMany retrieves the connections with the given IDs in the requested order, omitting unknown IDs
+	GetMany(ctx context.Context, ids []int64) ([]Connection, error)
+	
 	// Update updates an existing connection
 	Update(ctx context.Context, id int64, req UpdateConnectionRequest) (*Connection, error)
 	
+	// Touch refreshes a connection's updated_at without changing anything else
+	Touch(ctx context.Context, id int64) error
+	
 	// Delete deletes a connection by ID
 	Delete(ctx context.Context, id int64) error
+
+	// SoftDelete moves a connection to the trash, from where Restore can bring it back
+	SoftDelete(ctx context.Context, id int64) error
+
+	// Restore moves a soft-deleted connection back out of the trash
+	Restore(ctx context.Context, id int64) (*Connection, error)
+
+	// ListDeletedConnections lists soft-deleted connections, most recently deleted first
+	ListDeletedConnections(ctx context.Context, limit, offset int) (*ListDeletedConnectionsResponse, error)
 	
 	// List lists connections with pagination and filtering
 	List(ctx context.Context, req ListConnectionsRequest) (*ListConnectionsResponse, error)
+
+	// Count counts the connections List would find, without fetching them
+	Count(ctx context.Context, req ListConnectionsRequest) (int64, error)
 	
-	// GetNoteConnections retrieves all connections for a specific note
+	// GetNoteConnections retrieves the connections of a specific note in req.Direction
 	GetNoteConnections(ctx context.Context, req NoteConnectionsRequest) (*NoteConnectionsResponse, error)
 	
 	// GetConnectionsByType retrieves connections filtered by type
@@ -32,9 +59,81 @@ type Storage interface {
 	// GetBidirectionalConnections retrieves both incoming and outgoing connections for a note
 	GetBidirectionalConnections(ctx context.Context, noteID int64) (*NoteConnectionsResponse, error)
 	
-	// GetConnectionStats retrieves statistics about connections
-	GetConnectionStats(ctx context.Context) (*ConnectionStats, error)
+	// GetConnectionStats retrieves statistics about connections, leaving out
+	// the trash unless opts.IncludeArchived is set
+	GetConnectionStats(ctx context.Context, opts StatsOptions) (*ConnectionStats, error)
+
+	// GetStrengthHistogram counts the connections whose strength falls in each bucket
+	GetStrengthHistogram(ctx context.Context, buckets []Bucket) ([]Bucket, error)
 	
-	// FindConnectionPaths finds paths between two notes (for future graph traversal)
-	FindConnectionPaths(ctx context.Context, fromNoteID, toNoteID int64, maxDepth int) ([]ConnectionPath, error)
-}
\ No newline at end of file
+	// FindRedundantInverses finds connections that state the same relationship with the inverse type and the notes swapped
+	FindRedundantInverses(ctx context.Context, fromNoteID, toNoteID int64, connectionType string) ([]Connection, error)
+
+	// FindConnectionPaths finds paths between two notes (for future graph traversal), following connections in opts.Direction and skipping opts.ExcludeTypes
+	FindConnectionPaths(ctx context.Context, fromNoteID, toNoteID int64, opts PathOptions) ([]ConnectionPath, error)
+
+	// FindStrongestPath finds the path of at most opts.MaxDepth connections from one note to another whose weakest connection is strongest, following connections in opts.Direction and skipping opts.ExcludeTypes
+	FindStrongestPath(ctx context.Context, fromNoteID, toNoteID int64, opts PathOptions) (*ConnectionPath, error)
+
+	// ComputePageRank ranks notes by strength-weighted PageRank centrality
+	ComputePageRank(ctx context.Context, opts PageRankOptions) ([]NoteRank, error)
+
+	// GetGraphMetrics counts notes, connections and connected components and
+	// derives the graph's density and average degree
+	GetGraphMetrics(ctx context.Context) (*GraphMetrics, error)
+
+	// DeleteBetween deletes all connections between two notes in either direction
+	DeleteBetween(ctx context.Context, fromNoteID, toNoteID int64, connType *string) (int64, error)
+
+	// PruneConnections deletes all connections with strength at or below
+	// maxStrength, or only counts them when dryRun is set
+	PruneConnections(ctx context.Context, maxStrength int, dryRun bool) (int64, error)
+
+	// RetypeConnections changes the type of every connection of fromType to toType
+	RetypeConnections(ctx context.Context, fromType, toType string) (int64, error)
+
+	// AdjustStrength atomically adds delta to a connection's strength, clamped to 1-10
+	AdjustStrength(ctx context.Context, id int64, delta int) (*Connection, error)
+
+	// NormalizeStrengths rescales all connection strengths onto the full 1-10 range
+	NormalizeStrengths(ctx context.Context, opts NormalizeStrengthsOptions) (int64, error)
+
+	// PlanStrengthNormalization returns the mapping NormalizeStrengths would apply
+	PlanStrengthNormalization(ctx context.Context) ([]StrengthMapping, error)
+
+	// GetConnectionsForNotes retrieves connections for several notes in one query, grouped by note ID
+	GetConnectionsForNotes(ctx context.Context, noteIDs []int64, filter NoteConnectionsFilter) (map[int64]*NoteConnectionsResponse, error)
+
+	// GetLatestConnectionPerNote retrieves each note's most recently created connection, keyed by note ID, omitting notes without connections
+	GetLatestConnectionPerNote(ctx context.Context, noteIDs []int64) (map[int64]*Connection, error)
+
+	// FindContradictions finds note pairs linked by both supports and contradicts connections
+	FindContradictions(ctx context.Context) ([]ContradictionPair, error)
+
+	// FindReciprocalConnections finds note pairs connected in both directions, regardless of type
+	FindReciprocalConnections(ctx context.Context) ([]ReciprocalPair, error)
+
+	// FindOrphanNotes finds notes that have no incoming or outgoing connections
+	FindOrphanNotes(ctx context.Context, limit, offset int) (*OrphanNotesResponse, error)
+
+	// SuggestConnections recommends unconnected notes by shared tags and content similarity, best first
+	SuggestConnections(ctx context.Context, noteID int64, limit int) ([]Suggestion, error)
+
+	// SuggestStrength proposes a strength for connecting two notes from their shared tags, neighbors and content
+	SuggestStrength(ctx context.Context, fromNoteID, toNoteID int64) (*StrengthSuggestion, error)
+
+	// ExportConnectionsCSV exports connections as CSV, one row per connection ordered by ID
+	ExportConnectionsCSV(ctx context.Context, req ExportRequest) (string, error)
+
+	// ExportAdjacencyMatrix builds the directed strength matrix of the given notes, in input order
+	ExportAdjacencyMatrix(ctx context.Context, noteIDs []int64) (*Matrix, error)
+
+	// ExportGEXF exports notes and connections as a GEXF document for Gephi
+	ExportGEXF(ctx context.Context, req ExportRequest) (string, error)
+
+	// ImportConnectionsCSV creates connections from CSV rows in one transaction, skipping and reporting invalid rows
+	ImportConnectionsCSV(ctx context.Context, data string) (*ImportResult, error)
+
+	// GetConnectionAudit returns the recorded changes to a connection, oldest first
+	GetConnectionAudit(ctx context.Context, connectionID int64) ([]AuditEntry, error)
+}
@@ -0,0 +1,87 @@
+package connection
+
+import "time"
+
+const (
+	// DefaultMaxTraversalNodes is the default number of notes one traversal may visit
+	DefaultMaxTraversalNodes = 10000
+	// DefaultMaxTraversalDepth is the default number of connections a traversal may walk away from its start
+	DefaultMaxTraversalDepth = 10
+	// DefaultTraversalTimeout is the default time limit for one traversal
+	DefaultTraversalTimeout = 5 * time.Second
+)
+
+// TraversalLimits bounds walks that spread out from a note, such as path
+// finding and note contexts. Unlike GraphLimits, reaching a limit does not
+// fail the operation: the walk stops and its result is marked truncated. A
+// zero field disables that limit.
+type TraversalLimits struct {
+	MaxNodes    int           `json:"max_nodes,omitempty"`
+	MaxDepth    int           `json:"max_depth,omitempty"`
+	MaxDuration time.Duration `json:"max_duration,omitempty"`
+}
+
+// DefaultTraversalLimits returns the limits applied when none are configured
+func DefaultTraversalLimits() TraversalLimits {
+	return TraversalLimits{
+		MaxNodes:    DefaultMaxTraversalNodes,
+		MaxDepth:    DefaultMaxTraversalDepth,
+		MaxDuration: DefaultTraversalTimeout,
+	}
+}
+
+// Traversal tracks one walk against its limits. It is not safe for
+// concurrent use.
+type Traversal struct {
+	limits    TraversalLimits
+	deadline  time.Time
+	visited   int
+	truncated bool
+}
+
+// Start begins a traversal bounded by l; its time limit runs from now
+func (l TraversalLimits) Start() *Traversal {
+	t := &Traversal{limits: l}
+	if l.MaxDuration > 0 {
+		t.deadline = time.Now().Add(l.MaxDuration)
+	}
+	return t
+}
+
+// Depth returns depth capped at the depth limit, marking the traversal
+// truncated when the cap lowers it
+func (t *Traversal) Depth(depth int) int {
+	if t.limits.MaxDepth > 0 && depth > t.limits.MaxDepth {
+		t.truncated = true
+		return t.limits.MaxDepth
+	}
+	return depth
+}
+
+// Visit counts a newly reached note and reports whether the walk may keep
+// it. Once the node or time limit is reached it reports false and the
+// traversal is truncated.
+func (t *Traversal) Visit() bool {
+	if t.exhausted() {
+		t.truncated = true
+		return false
+	}
+	t.visited++
+	return true
+}
+
+// exhausted reports whether the node or time limit has been reached
+func (t *Traversal) exhausted() bool {
+	return t.limits.MaxNodes > 0 && t.visited >= t.limits.MaxNodes ||
+		!t.deadline.IsZero() && !time.Now().Before(t.deadline)
+}
+
+// Visited returns the number of notes the walk has kept
+func (t *Traversal) Visited() int {
+	return t.visited
+}
+
+// Truncated reports whether a limit cut the walk short
+func (t *Traversal) Truncated() bool {
+	return t.truncated
+}
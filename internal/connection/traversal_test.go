@@ -0,0 +1,81 @@
+package connection_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+)
+
+func TestTraversal(t *testing.T) {
+	tests := []struct {
+		name          string
+		limits        connection.TraversalLimits
+		visits        int
+		depth         int
+		wantVisited   int
+		wantDepth     int
+		wantTruncated bool
+	}{
+		{
+			name:        "within limits",
+			limits:      connection.TraversalLimits{MaxNodes: 5, MaxDepth: 3},
+			visits:      5,
+			depth:       3,
+			wantVisited: 5,
+			wantDepth:   3,
+		},
+		{
+			name:          "node limit",
+			limits:        connection.TraversalLimits{MaxNodes: 5},
+			visits:        8,
+			depth:         3,
+			wantVisited:   5,
+			wantDepth:     3,
+			wantTruncated: true,
+		},
+		{
+			name:          "depth limit",
+			limits:        connection.TraversalLimits{MaxDepth: 2},
+			visits:        1,
+			depth:         4,
+			wantVisited:   1,
+			wantDepth:     2,
+			wantTruncated: true,
+		},
+		{
+			name:        "zero limits are unlimited",
+			visits:      100,
+			depth:       50,
+			wantVisited: 100,
+			wantDepth:   50,
+		},
+		{
+			name:          "time limit",
+			limits:        connection.TraversalLimits{MaxDuration: time.Nanosecond},
+			visits:        3,
+			depth:         1,
+			wantVisited:   0,
+			wantDepth:     1,
+			wantTruncated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			traversal := tt.limits.Start()
+			if tt.limits.MaxDuration > 0 {
+				time.Sleep(time.Millisecond)
+			}
+
+			assert.Equal(t, tt.wantDepth, traversal.Depth(tt.depth))
+			for i := 0; i < tt.visits; i++ {
+				traversal.Visit()
+			}
+			assert.Equal(t, tt.wantVisited, traversal.Visited())
+			assert.Equal(t, tt.wantTruncated, traversal.Truncated())
+		})
+	}
+}
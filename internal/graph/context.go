@@ -0,0 +1,172 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+const (
+	// DefaultContextDepth is how many connections away from the note neighbors are looked for by default
+	DefaultContextDepth = 1
+	// MaxContextDepth caps the depth of a note context
+	MaxContextDepth = 3
+	// DefaultMaxContextNeighbors caps the neighbor notes in a context when no limit is given
+	DefaultMaxContextNeighbors = 50
+	// DefaultMaxContextConnections caps the connections in a context when no limit is given
+	DefaultMaxContextConnections = 200
+
+	// neighborSummaryLength is the length of a neighbor's summary in runes
+	neighborSummaryLength = 200
+)
+
+// NeighborNote is a note near the center of a context, shortened to what is
+// needed to decide whether to read it in full
+type NeighborNote struct {
+	ID       int64    `json:"id"`
+	Title    string   `json:"title"`
+	Type     string   `json:"type"`
+	Tags     []string `json:"tags,omitempty"`
+	Summary  string   `json:"summary"`  // Start of the content with whitespace collapsed, cut at neighborSummaryLength runes
+	Distance int      `json:"distance"` // Number of connections between the neighbor and the center note
+}
+
+// NoteContext is a note together with its neighborhood
+type NoteContext struct {
+	Note        *note.Note              `json:"note"`
+	Depth       int                     `json:"depth"`
+	Connections []connection.Connection `json:"connections"` // Connections walked to reach the neighbors, nearest first
+	Neighbors   []NeighborNote          `json:"neighbors"`   // Nearest first
+	Truncated   bool                    `json:"truncated"`   // Some connections or neighbors were left out to stay within the limits
+}
+
+// ContextLimits bounds the size of a note context. Zero counts use the
+// defaults.
+type ContextLimits struct {
+	MaxNeighbors   int `json:"max_neighbors"`
+	MaxConnections int `json:"max_connections"`
+	// Traversal bounds the walk itself, as configured for the server. As for
+	// every TraversalLimits, a zero field disables that limit.
+	Traversal connection.TraversalLimits `json:"-"`
+}
+
+// GetNoteContext composes the note, connection and batch note storage methods
+// into the note with ID noteID and everything within depth connections of
+// it, following connections either way. The neighborhood is walked one
+// distance at a time, so when a limit is reached the nearest neighbors are
+// the ones kept, and Truncated reports that the rest were left out. A depth
+// beyond the traversal depth limit is lowered to it, and Depth reports the
+// depth walked.
+func GetNoteContext(ctx context.Context, noteStorage note.Storage, connStorage connection.Storage, noteID int64, depth int, limits ContextLimits) (*NoteContext, error) {
+	if depth < 1 || depth > MaxContextDepth {
+		return nil, fmt.Errorf("depth must be between 1 and %d, got: %d", MaxContextDepth, depth)
+	}
+	if limits.MaxNeighbors <= 0 {
+		limits.MaxNeighbors = DefaultMaxContextNeighbors
+	}
+	if limits.MaxConnections <= 0 {
+		limits.MaxConnections = DefaultMaxContextConnections
+	}
+
+	center, err := noteStorage.Get(ctx, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get note: %w", err)
+	}
+
+	traversal := limits.Traversal.Start()
+	depth = traversal.Depth(depth)
+
+	result := &NoteContext{
+		Note:        center,
+		Depth:       depth,
+		Connections: []connection.Connection{},
+		Neighbors:   []NeighborNote{},
+	}
+
+	distances := map[int64]int{noteID: 0}
+	seenConnections := make(map[int64]bool)
+	var neighborIDs []int64
+	frontier := []int64{noteID}
+
+	for distance := 1; distance <= depth && len(frontier) > 0; distance++ {
+		byNote, err := connStorage.GetConnectionsForNotes(ctx, frontier, connection.NoteConnectionsFilter{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get connections: %w", err)
+		}
+
+		var next []int64
+		for _, id := range frontier {
+			conns := byNote[id]
+			if conns == nil {
+				continue
+			}
+			// Walk connections by ID so truncation always keeps the same ones
+			around := append(append([]connection.Connection{}, conns.Outgoing...), conns.Incoming...)
+			sort.Slice(around, func(i, j int) bool { return around[i].ID < around[j].ID })
+			for _, conn := range around {
+				if seenConnections[conn.ID] {
+					continue
+				}
+
+				other := conn.ToNoteID
+				if other == id {
+					other = conn.FromNoteID
+				}
+				_, known := distances[other]
+
+				if len(result.Connections) >= limits.MaxConnections || (!known && len(neighborIDs) >= limits.MaxNeighbors) {
+					result.Truncated = true
+					continue
+				}
+				if !known && !traversal.Visit() {
+					continue
+				}
+
+				seenConnections[conn.ID] = true
+				result.Connections = append(result.Connections, conn)
+				if !known {
+					distances[other] = distance
+					neighborIDs = append(neighborIDs, other)
+					next = append(next, other)
+				}
+			}
+		}
+		frontier = next
+	}
+	result.Truncated = result.Truncated || traversal.Truncated()
+
+	if len(neighborIDs) == 0 {
+		return result, nil
+	}
+
+	neighbors, err := noteStorage.GetMany(ctx, neighborIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get neighbor notes: %w", err)
+	}
+	for _, n := range neighbors {
+		result.Neighbors = append(result.Neighbors, NeighborNote{
+			ID:       n.ID,
+			Title:    n.Title,
+			Type:     n.Type,
+			Tags:     n.Tags,
+			Summary:  summarize(n.Content),
+			Distance: distances[n.ID],
+		})
+	}
+
+	return result, nil
+}
+
+// summarize returns the start of content with whitespace collapsed, cut at
+// neighborSummaryLength runes and marked with "…" when cut
+func summarize(content string) string {
+	runes := []rune(strings.Join(strings.Fields(content), " "))
+	if len(runes) <= neighborSummaryLength {
+		return string(runes)
+	}
+	return strings.TrimRight(string(runes[:neighborSummaryLength]), " ") + "…"
+}
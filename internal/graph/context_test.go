@@ -0,0 +1,250 @@
+package graph_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	connmock "github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+	connstorage "github.com/red1r3ct/knowledge-graph-mcp/internal/connection/sqlite"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/graph"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/migrations"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	notemock "github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+	notestorage "github.com/red1r3ct/knowledge-graph-mcp/internal/note/sqlite"
+)
+
+func TestGetNoteContext(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "context.db")
+	require.NoError(t, migrations.NewMigrationRunner(dbPath).RunMigrations())
+
+	notes, err := notestorage.NewStorage(dbPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { notes.Close() })
+	conns, err := connstorage.NewStorage(dbPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { conns.Close() })
+
+	ids := map[string]int64{}
+	for _, title := range []string{"Center", "Cause", "Effect", "Detail", "Far", "Unrelated"} {
+		content := "About " + strings.ToLower(title)
+		if title == "Effect" {
+			content = strings.Repeat("effect  text ", 40)
+		}
+		n, err := notes.Create(ctx, note.CreateNoteRequest{Title: title, Content: content, Type: "text", Tags: []string{"t"}})
+		require.NoError(t, err)
+		ids[title] = n.ID
+	}
+
+	// Cause -> Center -> Effect -> Detail -> Far, plus Detail -> Center
+	connIDs := map[string]int64{}
+	for _, c := range [][2]string{{"Cause", "Center"}, {"Center", "Effect"}, {"Effect", "Detail"}, {"Detail", "Far"}, {"Detail", "Center"}} {
+		conn, err := conns.Create(ctx, connection.CreateConnectionRequest{FromNoteID: ids[c[0]], ToNoteID: ids[c[1]], Type: "references", Strength: 5})
+		require.NoError(t, err)
+		connIDs[c[0]+">"+c[1]] = conn.ID
+	}
+
+	tests := []struct {
+		name            string
+		noteID          int64
+		depth           int
+		limits          graph.ContextLimits
+		wantNeighbors   map[string]int
+		wantConnections []string
+		wantTruncated   bool
+		wantErr         string
+	}{
+		{
+			name:            "direct neighbors in both directions",
+			noteID:          ids["Center"],
+			depth:           1,
+			wantNeighbors:   map[string]int{"Cause": 1, "Effect": 1, "Detail": 1},
+			wantConnections: []string{"Cause>Center", "Center>Effect", "Detail>Center"},
+		},
+		{
+			name:            "two steps out",
+			noteID:          ids["Center"],
+			depth:           2,
+			wantNeighbors:   map[string]int{"Cause": 1, "Effect": 1, "Detail": 1, "Far": 2},
+			wantConnections: []string{"Cause>Center", "Center>Effect", "Detail>Center", "Detail>Far", "Effect>Detail"},
+		},
+		{
+			name:            "neighbor limit keeps the nearest",
+			noteID:          ids["Center"],
+			depth:           2,
+			limits:          graph.ContextLimits{MaxNeighbors: 3},
+			wantNeighbors:   map[string]int{"Cause": 1, "Effect": 1, "Detail": 1},
+			wantConnections: []string{"Cause>Center", "Center>Effect", "Detail>Center", "Effect>Detail"},
+			wantTruncated:   true,
+		},
+		{
+			name:            "connection limit",
+			noteID:          ids["Center"],
+			depth:           2,
+			limits:          graph.ContextLimits{MaxConnections: 2},
+			wantNeighbors:   map[string]int{"Cause": 1, "Effect": 1},
+			wantConnections: []string{"Cause>Center", "Center>Effect"},
+			wantTruncated:   true,
+		},
+		{
+			name:            "note without connections",
+			noteID:          ids["Unrelated"],
+			depth:           2,
+			wantNeighbors:   map[string]int{},
+			wantConnections: []string{},
+		},
+		{
+			name:    "depth too large",
+			noteID:  ids["Center"],
+			depth:   graph.MaxContextDepth + 1,
+			wantErr: "depth must be between 1 and 3, got: 4",
+		},
+		{
+			name:    "unknown note",
+			noteID:  99999,
+			depth:   1,
+			wantErr: "failed to get note",
+		},
+	}
+
+	titles := map[int64]string{}
+	for title, id := range ids {
+		titles[id] = title
+	}
+	names := map[int64]string{}
+	for name, id := range connIDs {
+		names[id] = name
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := graph.GetNoteContext(ctx, notes, conns, tt.noteID, tt.depth, tt.limits)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.noteID, result.Note.ID)
+			assert.Equal(t, tt.depth, result.Depth)
+			assert.Equal(t, tt.wantTruncated, result.Truncated)
+
+			gotNeighbors := map[string]int{}
+			for _, n := range result.Neighbors {
+				gotNeighbors[titles[n.ID]] = n.Distance
+			}
+			assert.Equal(t, tt.wantNeighbors, gotNeighbors)
+
+			gotConnections := []string{}
+			for _, c := range result.Connections {
+				gotConnections = append(gotConnections, names[c.ID])
+			}
+			assert.ElementsMatch(t, tt.wantConnections, gotConnections)
+		})
+	}
+
+	t.Run("neighbors carry a short summary", func(t *testing.T) {
+		result, err := graph.GetNoteContext(ctx, notes, conns, ids["Center"], 1, graph.ContextLimits{})
+		require.NoError(t, err)
+
+		for _, n := range result.Neighbors {
+			switch titles[n.ID] {
+			case "Cause":
+				assert.Equal(t, "About cause", n.Summary)
+				assert.Equal(t, []string{"t"}, n.Tags)
+			case "Effect":
+				assert.True(t, strings.HasPrefix(n.Summary, "effect text effect"))
+				assert.True(t, strings.HasSuffix(n.Summary, "…"))
+				assert.LessOrEqual(t, len([]rune(n.Summary)), 201)
+			}
+		}
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		noteStorage := notemock.NewMockStorage(ctrl)
+		connStorage := connmock.NewMockStorage(ctrl)
+
+		noteStorage.EXPECT().Get(gomock.Any(), int64(1)).Return(&note.Note{ID: 1}, nil)
+		connStorage.EXPECT().
+			GetConnectionsForNotes(gomock.Any(), []int64{1}, connection.NoteConnectionsFilter{}).
+			Return(nil, errors.New("database is locked"))
+
+		_, err := graph.GetNoteContext(ctx, noteStorage, connStorage, 1, 1, graph.ContextLimits{})
+		assert.ErrorContains(t, err, "failed to get connections: database is locked")
+	})
+
+	t.Run("traversal limits cap a star graph", func(t *testing.T) {
+		starPath := filepath.Join(t.TempDir(), "star.db")
+		require.NoError(t, migrations.NewMigrationRunner(starPath).RunMigrations())
+		starNotes, err := notestorage.NewStorage(starPath)
+		require.NoError(t, err)
+		t.Cleanup(func() { starNotes.Close() })
+		starConns, err := connstorage.NewStorage(starPath)
+		require.NoError(t, err)
+		t.Cleanup(func() { starConns.Close() })
+
+		hub, err := starNotes.Create(ctx, note.CreateNoteRequest{Title: "Hub", Content: "The hub", Type: "text"})
+		require.NoError(t, err)
+		for i := 1; i <= 100; i++ {
+			spoke, err := starNotes.Create(ctx, note.CreateNoteRequest{Title: fmt.Sprintf("Spoke %d", i), Content: fmt.Sprintf("Spoke number %d", i), Type: "text"})
+			require.NoError(t, err)
+			_, err = starConns.Create(ctx, connection.CreateConnectionRequest{FromNoteID: hub.ID, ToNoteID: spoke.ID, Type: "references", Strength: 5})
+			require.NoError(t, err)
+		}
+
+		tests := []struct {
+			name          string
+			depth         int
+			limits        graph.ContextLimits
+			wantDepth     int
+			wantNeighbors int
+			wantTruncated bool
+		}{
+			{
+				name:          "within the limits",
+				depth:         2,
+				limits:        graph.ContextLimits{MaxNeighbors: 200, MaxConnections: 200, Traversal: connection.DefaultTraversalLimits()},
+				wantDepth:     2,
+				wantNeighbors: 100,
+			},
+			{
+				name:          "node cap",
+				depth:         2,
+				limits:        graph.ContextLimits{MaxNeighbors: 200, MaxConnections: 200, Traversal: connection.TraversalLimits{MaxNodes: 10}},
+				wantDepth:     2,
+				wantNeighbors: 10,
+				wantTruncated: true,
+			},
+			{
+				name:          "depth cap",
+				depth:         3,
+				limits:        graph.ContextLimits{MaxNeighbors: 200, MaxConnections: 200, Traversal: connection.TraversalLimits{MaxDepth: 1}},
+				wantDepth:     1,
+				wantNeighbors: 100,
+				wantTruncated: true,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				result, err := graph.GetNoteContext(ctx, starNotes, starConns, hub.ID, tt.depth, tt.limits)
+				require.NoError(t, err)
+
+				assert.Equal(t, tt.wantDepth, result.Depth)
+				assert.Len(t, result.Neighbors, tt.wantNeighbors)
+				assert.Len(t, result.Connections, tt.wantNeighbors)
+				assert.Equal(t, tt.wantTruncated, result.Truncated)
+			})
+		}
+	})
+}
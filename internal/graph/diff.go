@@ -0,0 +1,323 @@
+package graph
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// ExportBundle is a snapshot of the graph, the notes and connections as the
+// list tools return them
+type ExportBundle struct {
+	Notes       []note.Note             `json:"notes"`
+	Connections []connection.Connection `json:"connections"`
+}
+
+// NoteChange is a note found in both snapshots with different field values
+type NoteChange struct {
+	Old    note.Note `json:"old"`
+	New    note.Note `json:"new"`
+	Fields []string  `json:"fields"` // JSON names of the fields that differ
+}
+
+// ConnectionChange is a connection found in both snapshots with different field values
+type ConnectionChange struct {
+	Old    connection.Connection `json:"old"`
+	New    connection.Connection `json:"new"`
+	Fields []string              `json:"fields"` // JSON names of the fields that differ
+}
+
+// GraphDiff lists what changed from one snapshot to the next. Added entities
+// are ordered by their new ID, removed and changed ones by their old ID.
+type GraphDiff struct {
+	AddedNotes         []note.Note             `json:"added_notes"`
+	RemovedNotes       []note.Note             `json:"removed_notes"`
+	ChangedNotes       []NoteChange            `json:"changed_notes"`
+	AddedConnections   []connection.Connection `json:"added_connections"`
+	RemovedConnections []connection.Connection `json:"removed_connections"`
+	ChangedConnections []ConnectionChange      `json:"changed_connections"`
+}
+
+// Empty reports whether the snapshots hold the same notes and connections
+func (d *GraphDiff) Empty() bool {
+	return len(d.AddedNotes) == 0 && len(d.RemovedNotes) == 0 && len(d.ChangedNotes) == 0 &&
+		len(d.AddedConnections) == 0 && len(d.RemovedConnections) == 0 && len(d.ChangedConnections) == 0
+}
+
+// DiffBundles compares two snapshots of the graph.
+//
+// Notes are matched by ID first. A note left unmatched on both sides is then
+// matched by content hash, so a note exported from another database, or
+// deleted and recreated, counts as the same note when its content is. The
+// hash is computed from the content when a bundle leaves it out.
+//
+// Connections are matched by ID first, then by endpoints and type, with old
+// note IDs translated through the note matches. Timestamps, connection
+// counts and content hashes are derived values and are not compared.
+func DiffBundles(old, new *ExportBundle) (*GraphDiff, error) {
+	if old == nil || new == nil {
+		return nil, fmt.Errorf("both bundles are required")
+	}
+	if err := checkBundleIDs("old", old); err != nil {
+		return nil, err
+	}
+	if err := checkBundleIDs("new", new); err != nil {
+		return nil, err
+	}
+
+	diff := &GraphDiff{
+		AddedNotes:         []note.Note{},
+		RemovedNotes:       []note.Note{},
+		ChangedNotes:       []NoteChange{},
+		AddedConnections:   []connection.Connection{},
+		RemovedConnections: []connection.Connection{},
+		ChangedConnections: []ConnectionChange{},
+	}
+
+	// Match notes by ID, then the rest by content hash, in bundle order
+	noteMatches := matchByKey(len(old.Notes), len(new.Notes), nil,
+		func(i int) (interface{}, bool) { return old.Notes[i].ID, old.Notes[i].ID != 0 },
+		func(j int) (interface{}, bool) { return new.Notes[j].ID, new.Notes[j].ID != 0 })
+	noteMatches = matchByKey(len(old.Notes), len(new.Notes), noteMatches,
+		func(i int) (interface{}, bool) { return noteHash(old.Notes[i]), true },
+		func(j int) (interface{}, bool) { return noteHash(new.Notes[j]), true })
+
+	newNoteIDs := make(map[int64]int64)
+	matchedNew := make(map[int]bool)
+	for i, n := range old.Notes {
+		j, ok := noteMatches[i]
+		if !ok {
+			diff.RemovedNotes = append(diff.RemovedNotes, n)
+			continue
+		}
+		matchedNew[j] = true
+		newNoteIDs[n.ID] = new.Notes[j].ID
+		if fields := noteFieldChanges(n, new.Notes[j]); len(fields) > 0 {
+			diff.ChangedNotes = append(diff.ChangedNotes, NoteChange{Old: n, New: new.Notes[j], Fields: fields})
+		}
+	}
+	for j, n := range new.Notes {
+		if !matchedNew[j] {
+			diff.AddedNotes = append(diff.AddedNotes, n)
+		}
+	}
+
+	// translate maps an old note ID to the ID of the same note in the new bundle
+	translate := func(id int64) int64 {
+		if newID, ok := newNoteIDs[id]; ok {
+			return newID
+		}
+		return id
+	}
+
+	// Match connections by ID, then the rest by translated endpoints and type
+	type edgeKey struct {
+		from, to int64
+		connType string
+	}
+	connMatches := matchByKey(len(old.Connections), len(new.Connections), nil,
+		func(i int) (interface{}, bool) { return old.Connections[i].ID, old.Connections[i].ID != 0 },
+		func(j int) (interface{}, bool) { return new.Connections[j].ID, new.Connections[j].ID != 0 })
+	connMatches = matchByKey(len(old.Connections), len(new.Connections), connMatches,
+		func(i int) (interface{}, bool) {
+			c := old.Connections[i]
+			return edgeKey{translate(c.FromNoteID), translate(c.ToNoteID), c.Type}, true
+		},
+		func(j int) (interface{}, bool) {
+			c := new.Connections[j]
+			return edgeKey{c.FromNoteID, c.ToNoteID, c.Type}, true
+		})
+
+	matchedNew = make(map[int]bool)
+	for i, c := range old.Connections {
+		j, ok := connMatches[i]
+		if !ok {
+			diff.RemovedConnections = append(diff.RemovedConnections, c)
+			continue
+		}
+		matchedNew[j] = true
+		if fields := connectionFieldChanges(c, new.Connections[j], translate); len(fields) > 0 {
+			diff.ChangedConnections = append(diff.ChangedConnections, ConnectionChange{Old: c, New: new.Connections[j], Fields: fields})
+		}
+	}
+	for j, c := range new.Connections {
+		if !matchedNew[j] {
+			diff.AddedConnections = append(diff.AddedConnections, c)
+		}
+	}
+
+	sort.SliceStable(diff.AddedNotes, func(i, j int) bool { return diff.AddedNotes[i].ID < diff.AddedNotes[j].ID })
+	sort.SliceStable(diff.RemovedNotes, func(i, j int) bool { return diff.RemovedNotes[i].ID < diff.RemovedNotes[j].ID })
+	sort.SliceStable(diff.ChangedNotes, func(i, j int) bool { return diff.ChangedNotes[i].Old.ID < diff.ChangedNotes[j].Old.ID })
+	sort.SliceStable(diff.AddedConnections, func(i, j int) bool { return diff.AddedConnections[i].ID < diff.AddedConnections[j].ID })
+	sort.SliceStable(diff.RemovedConnections, func(i, j int) bool { return diff.RemovedConnections[i].ID < diff.RemovedConnections[j].ID })
+	sort.SliceStable(diff.ChangedConnections, func(i, j int) bool {
+		return diff.ChangedConnections[i].Old.ID < diff.ChangedConnections[j].Old.ID
+	})
+
+	return diff, nil
+}
+
+// checkBundleIDs rejects a bundle that lists a note or connection ID twice,
+// which would make matching by ID ambiguous. Zero IDs are left unchecked,
+// since hand-written bundles may leave them out.
+func checkBundleIDs(name string, bundle *ExportBundle) error {
+	seenNotes := make(map[int64]bool, len(bundle.Notes))
+	for _, n := range bundle.Notes {
+		if n.ID != 0 && seenNotes[n.ID] {
+			return fmt.Errorf("%s bundle has note %d more than once", name, n.ID)
+		}
+		seenNotes[n.ID] = true
+	}
+	seenConns := make(map[int64]bool, len(bundle.Connections))
+	for _, c := range bundle.Connections {
+		if c.ID != 0 && seenConns[c.ID] {
+			return fmt.Errorf("%s bundle has connection %d more than once", name, c.ID)
+		}
+		seenConns[c.ID] = true
+	}
+	return nil
+}
+
+// matchByKey extends matches, which maps old indexes to new ones, by pairing
+// still unmatched old and new items with equal keys. Items whose key function
+// reports false take no part. Equal keys pair up in bundle order.
+func matchByKey(oldLen, newLen int, matches map[int]int, oldKey, newKey func(int) (interface{}, bool)) map[int]int {
+	if matches == nil {
+		matches = make(map[int]int)
+	}
+	taken := make(map[int]bool, len(matches))
+	for _, j := range matches {
+		taken[j] = true
+	}
+
+	candidates := make(map[interface{}][]int)
+	for j := 0; j < newLen; j++ {
+		if taken[j] {
+			continue
+		}
+		if key, ok := newKey(j); ok {
+			candidates[key] = append(candidates[key], j)
+		}
+	}
+
+	for i := 0; i < oldLen; i++ {
+		if _, ok := matches[i]; ok {
+			continue
+		}
+		key, ok := oldKey(i)
+		if !ok || len(candidates[key]) == 0 {
+			continue
+		}
+		matches[i] = candidates[key][0]
+		candidates[key] = candidates[key][1:]
+	}
+	return matches
+}
+
+// noteHash returns the content hash of n, computing it when the bundle left it out
+func noteHash(n note.Note) string {
+	if n.ContentHash != "" {
+		return n.ContentHash
+	}
+	return note.ContentHash(n.Content)
+}
+
+// noteFieldChanges returns the JSON names of the editable fields that differ between a and b
+func noteFieldChanges(a, b note.Note) []string {
+	var fields []string
+	if a.Title != b.Title {
+		fields = append(fields, "title")
+	}
+	if a.Content != b.Content {
+		fields = append(fields, "content")
+	}
+	if a.Type != b.Type {
+		fields = append(fields, "type")
+	}
+	if !equalTags(a.Tags, b.Tags) {
+		fields = append(fields, "tags")
+	}
+	if !equalMetadata(a.Metadata, b.Metadata) {
+		fields = append(fields, "metadata")
+	}
+	if !equalStrings(a.Source, b.Source) {
+		fields = append(fields, "source")
+	}
+	if !equalIDs(a.KnowledgeBaseID, b.KnowledgeBaseID) {
+		fields = append(fields, "knowledge_base_id")
+	}
+	return fields
+}
+
+// connectionFieldChanges returns the JSON names of the editable fields that
+// differ between a and b, comparing a's endpoints as translated to b's note IDs
+func connectionFieldChanges(a, b connection.Connection, translate func(int64) int64) []string {
+	var fields []string
+	if translate(a.FromNoteID) != b.FromNoteID {
+		fields = append(fields, "from_note_id")
+	}
+	if translate(a.ToNoteID) != b.ToNoteID {
+		fields = append(fields, "to_note_id")
+	}
+	if a.Type != b.Type {
+		fields = append(fields, "type")
+	}
+	if !equalStrings(a.Description, b.Description) {
+		fields = append(fields, "description")
+	}
+	if a.Strength != b.Strength {
+		fields = append(fields, "strength")
+	}
+	if !equalMetadata(a.Metadata, b.Metadata) {
+		fields = append(fields, "metadata")
+	}
+	if !equalStrings(a.Source, b.Source) {
+		fields = append(fields, "source")
+	}
+	return fields
+}
+
+// equalTags compares tag lists in order, treating nil and empty as equal
+func equalTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// equalMetadata compares metadata objects, treating nil and empty as equal
+func equalMetadata(a, b map[string]interface{}) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// equalStrings compares optional strings, treating nil and empty as equal
+func equalStrings(a, b *string) bool {
+	var av, bv string
+	if a != nil {
+		av = *a
+	}
+	if b != nil {
+		bv = *b
+	}
+	return av == bv
+}
+
+// equalIDs compares optional IDs
+func equalIDs(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,184 @@
+package graph_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/graph"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+func TestDiffBundles(t *testing.T) {
+	noteA := note.Note{ID: 1, Title: "A", Content: "alpha", Type: "markdown", Tags: []string{"x"}}
+	noteB := note.Note{ID: 2, Title: "B", Content: "beta", Type: "markdown"}
+	conn := connection.Connection{ID: 10, FromNoteID: 1, ToNoteID: 2, Type: "supports", Strength: 5}
+
+	base := &graph.ExportBundle{
+		Notes:       []note.Note{noteA, noteB},
+		Connections: []connection.Connection{conn},
+	}
+
+	tests := []struct {
+		name    string
+		old     *graph.ExportBundle
+		new     *graph.ExportBundle
+		wantErr string
+		check   func(t *testing.T, d *graph.GraphDiff)
+	}{
+		{
+			name: "identical bundles",
+			old:  base,
+			new:  base,
+			check: func(t *testing.T, d *graph.GraphDiff) {
+				assert.True(t, d.Empty())
+			},
+		},
+		{
+			name: "derived fields are ignored",
+			old:  base,
+			new: func() *graph.ExportBundle {
+				a := noteA
+				a.IncomingCount = 3
+				a.ContentHash = note.ContentHash(a.Content)
+				a.Tags = []string{"x"}
+				b := noteB
+				b.Tags = []string{}
+				b.Metadata = map[string]interface{}{}
+				return &graph.ExportBundle{Notes: []note.Note{a, b}, Connections: []connection.Connection{conn}}
+			}(),
+			check: func(t *testing.T, d *graph.GraphDiff) {
+				assert.True(t, d.Empty())
+			},
+		},
+		{
+			name: "added note and connection",
+			old:  base,
+			new: &graph.ExportBundle{
+				Notes: []note.Note{noteA, noteB, {ID: 3, Title: "C", Content: "gamma"}},
+				Connections: []connection.Connection{
+					conn,
+					{ID: 11, FromNoteID: 2, ToNoteID: 3, Type: "cites", Strength: 3},
+				},
+			},
+			check: func(t *testing.T, d *graph.GraphDiff) {
+				require.Len(t, d.AddedNotes, 1)
+				assert.Equal(t, int64(3), d.AddedNotes[0].ID)
+				require.Len(t, d.AddedConnections, 1)
+				assert.Equal(t, int64(11), d.AddedConnections[0].ID)
+				assert.Empty(t, d.RemovedNotes)
+				assert.Empty(t, d.ChangedNotes)
+				assert.Empty(t, d.RemovedConnections)
+				assert.Empty(t, d.ChangedConnections)
+			},
+		},
+		{
+			name: "removed note and connection",
+			old:  base,
+			new:  &graph.ExportBundle{Notes: []note.Note{noteA}},
+			check: func(t *testing.T, d *graph.GraphDiff) {
+				require.Len(t, d.RemovedNotes, 1)
+				assert.Equal(t, int64(2), d.RemovedNotes[0].ID)
+				require.Len(t, d.RemovedConnections, 1)
+				assert.Equal(t, int64(10), d.RemovedConnections[0].ID)
+				assert.Empty(t, d.AddedNotes)
+				assert.Empty(t, d.AddedConnections)
+			},
+		},
+		{
+			name: "changed note and connection",
+			old:  base,
+			new: func() *graph.ExportBundle {
+				a := noteA
+				a.Title = "A2"
+				a.Tags = []string{"x", "y"}
+				c := conn
+				c.Strength = 8
+				c.ToNoteID = 1
+				return &graph.ExportBundle{Notes: []note.Note{a, noteB}, Connections: []connection.Connection{c}}
+			}(),
+			check: func(t *testing.T, d *graph.GraphDiff) {
+				require.Len(t, d.ChangedNotes, 1)
+				assert.Equal(t, "A", d.ChangedNotes[0].Old.Title)
+				assert.Equal(t, "A2", d.ChangedNotes[0].New.Title)
+				assert.Equal(t, []string{"title", "tags"}, d.ChangedNotes[0].Fields)
+				require.Len(t, d.ChangedConnections, 1)
+				assert.Equal(t, []string{"to_note_id", "strength"}, d.ChangedConnections[0].Fields)
+				assert.Empty(t, d.AddedNotes)
+				assert.Empty(t, d.RemovedNotes)
+			},
+		},
+		{
+			name: "notes with new IDs are matched by content hash",
+			old:  base,
+			new: func() *graph.ExportBundle {
+				a := noteA
+				a.ID = 101
+				a.Title = "A renamed"
+				b := noteB
+				b.ID = 102
+				c := conn
+				c.ID = 110
+				c.FromNoteID = 101
+				c.ToNoteID = 102
+				return &graph.ExportBundle{Notes: []note.Note{a, b}, Connections: []connection.Connection{c}}
+			}(),
+			check: func(t *testing.T, d *graph.GraphDiff) {
+				assert.Empty(t, d.AddedNotes)
+				assert.Empty(t, d.RemovedNotes)
+				require.Len(t, d.ChangedNotes, 1)
+				assert.Equal(t, int64(1), d.ChangedNotes[0].Old.ID)
+				assert.Equal(t, int64(101), d.ChangedNotes[0].New.ID)
+				assert.Equal(t, []string{"title"}, d.ChangedNotes[0].Fields)
+				// The connection is matched by its translated endpoints
+				assert.Empty(t, d.AddedConnections)
+				assert.Empty(t, d.RemovedConnections)
+				assert.Empty(t, d.ChangedConnections)
+			},
+		},
+		{
+			name: "same ID wins over content hash",
+			old:  &graph.ExportBundle{Notes: []note.Note{noteA, {ID: 5, Content: "beta"}}},
+			new:  &graph.ExportBundle{Notes: []note.Note{noteA, {ID: 5, Content: "beta2"}, {ID: 6, Content: "beta"}}},
+			check: func(t *testing.T, d *graph.GraphDiff) {
+				require.Len(t, d.ChangedNotes, 1)
+				assert.Equal(t, int64(5), d.ChangedNotes[0].New.ID)
+				assert.Equal(t, []string{"content"}, d.ChangedNotes[0].Fields)
+				require.Len(t, d.AddedNotes, 1)
+				assert.Equal(t, int64(6), d.AddedNotes[0].ID)
+			},
+		},
+		{
+			name:    "nil bundle",
+			old:     base,
+			wantErr: "both bundles are required",
+		},
+		{
+			name:    "duplicate note ID",
+			old:     &graph.ExportBundle{Notes: []note.Note{noteA, noteA}},
+			new:     base,
+			wantErr: "old bundle has note 1 more than once",
+		},
+		{
+			name:    "duplicate connection ID",
+			old:     base,
+			new:     &graph.ExportBundle{Connections: []connection.Connection{conn, conn}},
+			wantErr: "new bundle has connection 10 more than once",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := graph.DiffBundles(tt.old, tt.new)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, d)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/graph"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// maxContextNeighbors caps the max_neighbors argument of get_note_context
+const maxContextNeighbors = 200
+
+// NewNoteContextHandler creates a new handler for getting a note with its
+// neighborhood, walking it within traversal
+func NewNoteContextHandler(noteStorage note.Storage, connStorage connection.Storage, traversal connection.TraversalLimits) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse note_id
+		noteIDRaw, ok := arguments["note_id"]
+		if !ok {
+			return nil, fmt.Errorf("note_id is required")
+		}
+		noteID, err := mcpx.ParseID(noteIDRaw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid note_id: %w", err)
+		}
+
+		// Parse optional depth
+		depth := graph.DefaultContextDepth
+		if depthRaw, ok := arguments["depth"]; ok {
+			value, err := mcpx.ParseInt(depthRaw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid depth: %w", err)
+			}
+			if value < 1 || value > graph.MaxContextDepth {
+				return nil, fmt.Errorf("depth must be between 1 and %d, got: %d", graph.MaxContextDepth, value)
+			}
+			depth = value
+		}
+
+		// Parse optional max_neighbors
+		limits := graph.ContextLimits{Traversal: traversal}
+		if maxRaw, ok := arguments["max_neighbors"]; ok {
+			value, err := mcpx.ParseInt(maxRaw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid max_neighbors: %w", err)
+			}
+			if value < 1 || value > maxContextNeighbors {
+				return nil, fmt.Errorf("max_neighbors must be between 1 and %d, got: %d", maxContextNeighbors, value)
+			}
+			limits.MaxNeighbors = value
+		}
+
+		noteContext, err := graph.GetNoteContext(ctx, noteStorage, connStorage, noteID, depth, limits)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get note context: %w", err)
+		}
+
+		jsonData, err := mcpx.MarshalJSON(noteContext)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		summary := fmt.Sprintf("Note %d %q with %d neighbors and %d connections within %d connections",
+			noteContext.Note.ID, noteContext.Note.Title, len(noteContext.Neighbors), len(noteContext.Connections), noteContext.Depth)
+		if noteContext.Truncated {
+			summary += " (truncated: the neighborhood is larger than the limits, nearest notes kept)"
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("%s\n\n%s", summary, string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
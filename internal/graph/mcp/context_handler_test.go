@@ -0,0 +1,161 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	connmock "github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/graph/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	notemock "github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+)
+
+func TestNoteContextHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	noteStorage := notemock.NewMockStorage(ctrl)
+	connStorage := connmock.NewMockStorage(ctrl)
+	handler := mcp.NewNoteContextHandler(noteStorage, connStorage, connection.DefaultTraversalLimits())
+
+	center := &note.Note{ID: 1, Title: "Center", Content: "Full content of the center note", Type: "text"}
+	neighborhood := func(conns ...connection.Connection) map[int64]*connection.NoteConnectionsResponse {
+		return map[int64]*connection.NoteConnectionsResponse{1: {NoteID: 1, Outgoing: conns}}
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent []string
+	}{
+		{
+			name: "note with a neighbor",
+			args: map[string]interface{}{"note_id": float64(1)},
+			mockSetup: func() {
+				noteStorage.EXPECT().Get(gomock.Any(), int64(1)).Return(center, nil)
+				connStorage.EXPECT().
+					GetConnectionsForNotes(gomock.Any(), []int64{1}, connection.NoteConnectionsFilter{}).
+					Return(neighborhood(connection.Connection{ID: 7, FromNoteID: 1, ToNoteID: 2, Type: "supports", Strength: 5}), nil)
+				noteStorage.EXPECT().
+					GetMany(gomock.Any(), []int64{2}).
+					Return([]note.Note{{ID: 2, Title: "Neighbor", Content: "Neighbor content", Type: "text"}}, nil)
+			},
+			wantContent: []string{
+				`Note 1 "Center" with 1 neighbors and 1 connections within 1 connections`,
+				`"content": "Full content of the center note"`,
+				`"summary": "Neighbor content"`,
+				`"truncated": false`,
+			},
+		},
+		{
+			name: "truncated neighborhood",
+			args: map[string]interface{}{"note_id": float64(1), "max_neighbors": float64(1)},
+			mockSetup: func() {
+				noteStorage.EXPECT().Get(gomock.Any(), int64(1)).Return(center, nil)
+				connStorage.EXPECT().
+					GetConnectionsForNotes(gomock.Any(), []int64{1}, connection.NoteConnectionsFilter{}).
+					Return(neighborhood(
+						connection.Connection{ID: 7, FromNoteID: 1, ToNoteID: 2, Type: "supports", Strength: 5},
+						connection.Connection{ID: 8, FromNoteID: 1, ToNoteID: 3, Type: "supports", Strength: 5},
+					), nil)
+				noteStorage.EXPECT().
+					GetMany(gomock.Any(), []int64{2}).
+					Return([]note.Note{{ID: 2, Title: "Neighbor", Type: "text"}}, nil)
+			},
+			wantContent: []string{
+				"with 1 neighbors and 1 connections within 1 connections (truncated",
+				`"truncated": true`,
+			},
+		},
+		{
+			name: "deeper context",
+			args: map[string]interface{}{"note_id": float64(1), "depth": float64(2)},
+			mockSetup: func() {
+				noteStorage.EXPECT().Get(gomock.Any(), int64(1)).Return(center, nil)
+				connStorage.EXPECT().
+					GetConnectionsForNotes(gomock.Any(), []int64{1}, connection.NoteConnectionsFilter{}).
+					Return(neighborhood(), nil)
+			},
+			wantContent: []string{`"depth": 2`},
+		},
+		{
+			name:        "missing note_id",
+			args:        map[string]interface{}{},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: []string{"note_id is required"},
+		},
+		{
+			name:        "fractional note_id",
+			args:        map[string]interface{}{"note_id": 1.5},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: []string{"invalid note_id: 1.5 is not an integer"},
+		},
+		{
+			name:        "non-positive note_id",
+			args:        map[string]interface{}{"note_id": float64(0)},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: []string{"invalid note_id: IDs must be positive, got: 0"},
+		},
+		{
+			name:        "depth too large",
+			args:        map[string]interface{}{"note_id": float64(1), "depth": float64(4)},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: []string{"depth must be between 1 and 3, got: 4"},
+		},
+		{
+			name:        "max_neighbors too large",
+			args:        map[string]interface{}{"note_id": float64(1), "max_neighbors": float64(201)},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: []string{"max_neighbors must be between 1 and 200, got: 201"},
+		},
+		{
+			name: "unknown note",
+			args: map[string]interface{}{"note_id": float64(99)},
+			mockSetup: func() {
+				noteStorage.EXPECT().Get(gomock.Any(), int64(99)).Return(nil, errors.New("note not found"))
+			},
+			wantErr:     true,
+			wantContent: []string{"failed to get note context: failed to get note: note not found"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				for _, want := range tt.wantContent {
+					assert.Contains(t, err.Error(), want)
+				}
+			} else {
+				assert.NoError(t, err)
+				text := result.Content[0].(gomcp.TextContent).Text
+				for _, want := range tt.wantContent {
+					assert.Contains(t, text, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/graph"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewDiffBundlesHandler creates a new handler for comparing two graph snapshots
+func NewDiffBundlesHandler() server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse old_bundle and new_bundle
+		oldBundle, err := parseBundle(arguments, "old_bundle")
+		if err != nil {
+			return nil, err
+		}
+		newBundle, err := parseBundle(arguments, "new_bundle")
+		if err != nil {
+			return nil, err
+		}
+
+		diff, err := graph.DiffBundles(oldBundle, newBundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to diff bundles: %w", err)
+		}
+
+		jsonData, err := mcpx.MarshalJSON(diff)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("%s\n\n%s", formatDiff(diff), string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
+
+// parseBundle reads a bundle argument given either as a JSON object or as a
+// string holding one
+func parseBundle(arguments map[string]interface{}, name string) (*graph.ExportBundle, error) {
+	raw, ok := arguments[name]
+	if !ok || raw == nil {
+		return nil, fmt.Errorf("%s is required", name)
+	}
+
+	var data []byte
+	switch v := raw.(type) {
+	case string:
+		data = []byte(v)
+	case map[string]interface{}:
+		var err error
+		data, err = json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+	default:
+		return nil, fmt.Errorf("%s must be a JSON object or a string holding one", name)
+	}
+
+	var bundle graph.ExportBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return &bundle, nil
+}
+
+// formatDiff renders the human-readable part of the diff
+func formatDiff(d *graph.GraphDiff) string {
+	if d.Empty() {
+		return "No differences between the bundles"
+	}
+
+	var b strings.Builder
+	b.WriteString("Graph diff:\n")
+	fmt.Fprintf(&b, "- Notes: %d added, %d removed, %d changed\n", len(d.AddedNotes), len(d.RemovedNotes), len(d.ChangedNotes))
+	fmt.Fprintf(&b, "- Connections: %d added, %d removed, %d changed", len(d.AddedConnections), len(d.RemovedConnections), len(d.ChangedConnections))
+	return b.String()
+}
@@ -0,0 +1,102 @@
+package mcp_test
+
+import (
+	"context"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/graph/mcp"
+)
+
+func TestDiffBundlesHandler(t *testing.T) {
+	handler := mcp.NewDiffBundlesHandler()
+
+	oldBundle := map[string]interface{}{
+		"notes": []interface{}{
+			map[string]interface{}{"id": float64(1), "title": "A", "content": "alpha", "type": "markdown"},
+			map[string]interface{}{"id": float64(2), "title": "B", "content": "beta", "type": "markdown"},
+		},
+		"connections": []interface{}{
+			map[string]interface{}{"id": float64(10), "from_note_id": float64(1), "to_note_id": float64(2), "type": "supports", "strength": float64(5)},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		wantErr     bool
+		wantContent []string
+	}{
+		{
+			name: "bundles as objects",
+			args: map[string]interface{}{
+				"old_bundle": oldBundle,
+				"new_bundle": map[string]interface{}{
+					"notes": []interface{}{
+						map[string]interface{}{"id": float64(1), "title": "A2", "content": "alpha", "type": "markdown"},
+						map[string]interface{}{"id": float64(3), "title": "C", "content": "gamma", "type": "markdown"},
+					},
+				},
+			},
+			wantContent: []string{
+				"- Notes: 1 added, 1 removed, 1 changed",
+				"- Connections: 0 added, 1 removed, 0 changed",
+				`"fields": [`,
+				`"title"`,
+			},
+		},
+		{
+			name: "bundles as JSON strings",
+			args: map[string]interface{}{
+				"old_bundle": `{"notes": [{"id": 1, "content": "alpha"}]}`,
+				"new_bundle": `{"notes": [{"id": 1, "content": "alpha"}]}`,
+			},
+			wantContent: []string{"No differences between the bundles"},
+		},
+		{
+			name:    "missing new_bundle",
+			args:    map[string]interface{}{"old_bundle": oldBundle},
+			wantErr: true,
+		},
+		{
+			name:    "bundle of the wrong type",
+			args:    map[string]interface{}{"old_bundle": oldBundle, "new_bundle": float64(1)},
+			wantErr: true,
+		},
+		{
+			name:    "invalid JSON string",
+			args:    map[string]interface{}{"old_bundle": oldBundle, "new_bundle": `{"notes": `},
+			wantErr: true,
+		},
+		{
+			name: "duplicate IDs",
+			args: map[string]interface{}{
+				"old_bundle": oldBundle,
+				"new_bundle": `{"notes": [{"id": 1}, {"id": 1}]}`,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := gomcp.CallToolRequest{}
+			req.Params.Arguments = tt.args
+
+			result, err := handler(context.Background(), req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotNil(t, result)
+			text := result.Content[0].(gomcp.TextContent).Text
+			for _, want := range tt.wantContent {
+				assert.Contains(t, text, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/graph"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// NewSummaryHandler creates a new handler for summarizing the whole graph
+func NewSummaryHandler(kbStorage knowledgebase.Storage, noteStorage note.Storage, connStorage connection.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse optional include_archived
+		var opts connection.StatsOptions
+		if includeRaw, ok := arguments["include_archived"]; ok {
+			opts.IncludeArchived, ok = includeRaw.(bool)
+			if !ok {
+				return nil, fmt.Errorf("include_archived must be a boolean")
+			}
+		}
+
+		summary, err := graph.GetSummary(ctx, kbStorage, noteStorage, connStorage, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get graph summary: %w", err)
+		}
+
+		jsonData, err := mcpx.MarshalJSON(summary)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("%s\n\n%s", formatSummary(summary, opts), string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
+
+// formatSummary renders the human-readable part of the summary
+func formatSummary(s *graph.Summary, opts connection.StatsOptions) string {
+	var b strings.Builder
+	b.WriteString("Graph summary:\n")
+	fmt.Fprintf(&b, "- Knowledge bases: %d\n", s.TotalKnowledgeBases)
+	fmt.Fprintf(&b, "- Notes: %d%s\n", s.TotalNotes, formatCounts(s.NotesByType))
+	fmt.Fprintf(&b, "- Connections: %d%s\n", s.TotalConnections, formatCounts(s.ConnectionsByType))
+	if s.ArchivedConnections > 0 {
+		if opts.IncludeArchived {
+			fmt.Fprintf(&b, "- Archived connections: %d, included above\n", s.ArchivedConnections)
+		} else {
+			fmt.Fprintf(&b, "- Archived connections: %d, not included above\n", s.ArchivedConnections)
+		}
+	}
+	fmt.Fprintf(&b, "- Orphan notes: %d", s.OrphanNotes)
+
+	if len(s.MostConnectedNotes) > 0 {
+		parts := make([]string, len(s.MostConnectedNotes))
+		for i, n := range s.MostConnectedNotes {
+			parts[i] = fmt.Sprintf("#%d (%d)", n.NoteID, n.TotalCount)
+		}
+		fmt.Fprintf(&b, "\n- Most connected notes: %s", strings.Join(parts, ", "))
+	}
+
+	return b.String()
+}
+
+// formatCounts renders a breakdown such as " (markdown: 3, text: 1)" in key order
+func formatCounts(counts map[string]int64) string {
+	if len(counts) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s: %d", k, counts[k])
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
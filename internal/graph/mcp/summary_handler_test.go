@@ -0,0 +1,149 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	connmock "github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/graph/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase"
+	kbmock "github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase/mock"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	notemock "github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+)
+
+func TestSummaryHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	kbStorage := kbmock.NewMockStorage(ctrl)
+	noteStorage := notemock.NewMockStorage(ctrl)
+	connStorage := connmock.NewMockStorage(ctrl)
+	handler := mcp.NewSummaryHandler(kbStorage, noteStorage, connStorage)
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent []string
+	}{
+		{
+			name: "successful summary",
+			mockSetup: func() {
+				kbStorage.EXPECT().List(gomock.Any(), gomock.Any()).Return(&knowledgebase.ListResponse{Total: 1}, nil)
+				noteStorage.EXPECT().
+					List(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, req note.ListNotesRequest) (*note.ListNotesResponse, error) {
+						if req.Type == "" || req.Type == "markdown" {
+							return &note.ListNotesResponse{Total: 3}, nil
+						}
+						return &note.ListNotesResponse{}, nil
+					}).
+					AnyTimes()
+				connStorage.EXPECT().GetConnectionStats(gomock.Any(), connection.StatsOptions{}).Return(&connection.ConnectionStats{
+					TotalConnections:      2,
+					ConnectionsByType:     map[string]int64{"supports": 1, "cites": 1},
+					ConnectionsByStrength: map[int]int64{5: 2},
+					MostConnectedNotes:    []connection.NoteConnection{{NoteID: 1, TotalCount: 2}},
+				}, nil)
+				connStorage.EXPECT().FindOrphanNotes(gomock.Any(), 1, 0).Return(&connection.OrphanNotesResponse{Total: 1}, nil)
+			},
+			wantErr: false,
+			wantContent: []string{
+				"- Knowledge bases: 1",
+				"- Notes: 3 (markdown: 3)",
+				"- Connections: 2 (cites: 1, supports: 1)",
+				"- Orphan notes: 1",
+				"- Most connected notes: #1 (2)",
+				`"total_notes": 3`,
+			},
+		},
+		{
+			name: "archived connections excluded",
+			mockSetup: func() {
+				kbStorage.EXPECT().List(gomock.Any(), gomock.Any()).Return(&knowledgebase.ListResponse{}, nil)
+				noteStorage.EXPECT().List(gomock.Any(), gomock.Any()).Return(&note.ListNotesResponse{}, nil).AnyTimes()
+				connStorage.EXPECT().GetConnectionStats(gomock.Any(), connection.StatsOptions{}).Return(&connection.ConnectionStats{
+					TotalConnections:    2,
+					ArchivedConnections: 3,
+				}, nil)
+				connStorage.EXPECT().FindOrphanNotes(gomock.Any(), 1, 0).Return(&connection.OrphanNotesResponse{}, nil)
+			},
+			wantContent: []string{
+				"- Connections: 2",
+				"- Archived connections: 3, not included above",
+				`"archived_connections": 3`,
+			},
+		},
+		{
+			name: "archived connections included",
+			args: map[string]interface{}{"include_archived": true},
+			mockSetup: func() {
+				kbStorage.EXPECT().List(gomock.Any(), gomock.Any()).Return(&knowledgebase.ListResponse{}, nil)
+				noteStorage.EXPECT().List(gomock.Any(), gomock.Any()).Return(&note.ListNotesResponse{}, nil).AnyTimes()
+				connStorage.EXPECT().GetConnectionStats(gomock.Any(), connection.StatsOptions{IncludeArchived: true}).Return(&connection.ConnectionStats{
+					TotalConnections:    5,
+					ArchivedConnections: 3,
+				}, nil)
+				connStorage.EXPECT().FindOrphanNotes(gomock.Any(), 1, 0).Return(&connection.OrphanNotesResponse{}, nil)
+			},
+			wantContent: []string{
+				"- Connections: 5",
+				"- Archived connections: 3, included above",
+			},
+		},
+		{
+			name:        "invalid include_archived",
+			args:        map[string]interface{}{"include_archived": "yes"},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: []string{"include_archived must be a boolean"},
+		},
+		{
+			name: "storage error",
+			mockSetup: func() {
+				kbStorage.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, errors.New("storage error"))
+			},
+			wantErr:     true,
+			wantContent: []string{"failed to get graph summary"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			args := tt.args
+			if args == nil {
+				args = map[string]interface{}{}
+			}
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				for _, want := range tt.wantContent {
+					assert.Contains(t, err.Error(), want)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				for _, want := range tt.wantContent {
+					assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/graph"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// RegisterTools registers all cross-entity graph MCP tools with the server.
+// traversal bounds the tools that walk out from a note.
+func RegisterTools(s *server.MCPServer, kbStorage knowledgebase.Storage, noteStorage note.Storage, connStorage connection.Storage, traversal connection.TraversalLimits, middleware ...mcpx.Middleware) error {
+	tools := []struct {
+		name        string
+		description string
+		handler     server.ToolHandlerFunc
+		schema      mcp.ToolInputSchema
+	}{
+		{
+			name:        "get_graph_summary",
+			description: "Get an overview of the whole graph in one call: knowledge base, note and connection totals with breakdowns by type and strength, orphan notes and the most connected notes",
+			handler:     NewSummaryHandler(kbStorage, noteStorage, connStorage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"include_archived": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Count soft-deleted connections in the trash towards the connection totals (default: false). The number in the trash is always reported",
+					},
+				},
+			},
+		},
+		{
+			name:        "get_note_context",
+			description: "Get a note with its neighborhood in one call: the full note, the connections around it in either direction, and the title, type, tags and a short summary of every note within depth connections. Use it to build context around a concept; get_notes loads neighbors in full",
+			handler:     NewNoteContextHandler(noteStorage, connStorage, traversal),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"note_id": map[string]interface{}{
+						"type":        "integer",
+						"description": "ID of the note at the center of the context",
+					},
+					"depth": map[string]interface{}{
+						"type":        "integer",
+						"description": fmt.Sprintf("How many connections away to look for neighbors (default: %d)", graph.DefaultContextDepth),
+						"minimum":     1,
+						"maximum":     graph.MaxContextDepth,
+					},
+					"max_neighbors": map[string]interface{}{
+						"type":        "integer",
+						"description": fmt.Sprintf("Maximum number of neighbor notes to return; nearer notes are kept first (default: %d)", graph.DefaultMaxContextNeighbors),
+						"minimum":     1,
+						"maximum":     maxContextNeighbors,
+					},
+				},
+				Required: []string{"note_id"},
+			},
+		},
+		{
+			name:        "diff_graph_bundles",
+			description: "Compare two snapshots of the graph and report the notes and connections added, removed and changed between them. Entities are matched by ID, and notes whose IDs differ are matched by content hash",
+			handler:     NewDiffBundlesHandler(),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"old_bundle": map[string]interface{}{
+						"type":        "object",
+						"description": "Earlier snapshot as {\"notes\": [...], \"connections\": [...]}, using the items list_notes and list_connections return. May be given as a JSON string",
+					},
+					"new_bundle": map[string]interface{}{
+						"type":        "object",
+						"description": "Later snapshot, in the same form as old_bundle",
+					},
+				},
+				Required: []string{"old_bundle", "new_bundle"},
+			},
+		},
+	}
+
+	for _, tool := range tools {
+		t := mcp.Tool{
+			Name:        tool.name,
+			Description: tool.description,
+			InputSchema: tool.schema,
+		}
+		s.AddTool(t, mcpx.Wrap(tool.name, tool.handler, middleware...))
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// Summary aggregates statistics across knowledge bases, notes and connections
+type Summary struct {
+	TotalKnowledgeBases   int64                       `json:"total_knowledge_bases"`
+	TotalNotes            int64                       `json:"total_notes"`
+	NotesByType           map[string]int64            `json:"notes_by_type"`
+	TotalConnections      int64                       `json:"total_connections"`
+	ConnectionsByType     map[string]int64            `json:"connections_by_type"`
+	ConnectionsByStrength map[int]int64               `json:"connections_by_strength"`
+	OrphanNotes           int64                       `json:"orphan_notes"`
+	MostConnectedNotes    []connection.NoteConnection `json:"most_connected_notes"`
+	ArchivedConnections   int64                       `json:"archived_connections"`
+}
+
+// GetSummary composes the storages' own counting methods into a single summary
+// of the whole graph. opts decides whether the connection totals include the
+// trash; orphan notes are always judged by live connections.
+func GetSummary(ctx context.Context, kbStorage knowledgebase.Storage, noteStorage note.Storage, connStorage connection.Storage, opts connection.StatsOptions) (*Summary, error) {
+	kbs, err := kbStorage.List(ctx, knowledgebase.ListRequest{Limit: 1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count knowledge bases: %w", err)
+	}
+
+	notes, err := noteStorage.List(ctx, note.ListNotesRequest{Limit: 1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count notes: %w", err)
+	}
+
+	notesByType := make(map[string]int64)
+	for _, noteType := range note.ValidNoteTypes() {
+		resp, err := noteStorage.List(ctx, note.ListNotesRequest{Limit: 1, Type: noteType})
+		if err != nil {
+			return nil, fmt.Errorf("failed to count %s notes: %w", noteType, err)
+		}
+		if resp.Total > 0 {
+			notesByType[noteType] = resp.Total
+		}
+	}
+
+	stats, err := connStorage.GetConnectionStats(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection stats: %w", err)
+	}
+
+	orphans, err := connStorage.FindOrphanNotes(ctx, 1, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count orphan notes: %w", err)
+	}
+
+	return &Summary{
+		TotalKnowledgeBases:   kbs.Total,
+		TotalNotes:            notes.Total,
+		NotesByType:           notesByType,
+		TotalConnections:      stats.TotalConnections,
+		ConnectionsByType:     stats.ConnectionsByType,
+		ConnectionsByStrength: stats.ConnectionsByStrength,
+		OrphanNotes:           orphans.Total,
+		MostConnectedNotes:    stats.MostConnectedNotes,
+		ArchivedConnections:   stats.ArchivedConnections,
+	}, nil
+}
@@ -0,0 +1,93 @@
+package graph_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	connmock "github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/graph"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase"
+	kbmock "github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase/mock"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	notemock "github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+)
+
+func TestGetSummary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	kbStorage := kbmock.NewMockStorage(ctrl)
+	noteStorage := notemock.NewMockStorage(ctrl)
+	connStorage := connmock.NewMockStorage(ctrl)
+
+	stats := &connection.ConnectionStats{
+		TotalConnections:      3,
+		ConnectionsByType:     map[string]int64{"supports": 2, "cites": 1},
+		ConnectionsByStrength: map[int]int64{5: 3},
+		MostConnectedNotes:    []connection.NoteConnection{{NoteID: 1, OutgoingCount: 3, TotalCount: 3}},
+	}
+
+	t.Run("aggregates all storages", func(t *testing.T) {
+		kbStorage.EXPECT().
+			List(gomock.Any(), knowledgebase.ListRequest{Limit: 1}).
+			Return(&knowledgebase.ListResponse{Total: 2}, nil)
+		noteStorage.EXPECT().
+			List(gomock.Any(), note.ListNotesRequest{Limit: 1}).
+			Return(&note.ListNotesResponse{Total: 5}, nil)
+		noteStorage.EXPECT().
+			List(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req note.ListNotesRequest) (*note.ListNotesResponse, error) {
+				counts := map[string]int64{"markdown": 4, "code": 1}
+				return &note.ListNotesResponse{Total: counts[req.Type]}, nil
+			}).
+			Times(len(note.ValidNoteTypes()))
+		connStorage.EXPECT().GetConnectionStats(gomock.Any(), connection.StatsOptions{}).Return(stats, nil)
+		connStorage.EXPECT().
+			FindOrphanNotes(gomock.Any(), 1, 0).
+			Return(&connection.OrphanNotesResponse{NoteIDs: []int64{5}, Total: 2}, nil)
+
+		summary, err := graph.GetSummary(context.Background(), kbStorage, noteStorage, connStorage, connection.StatsOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(2), summary.TotalKnowledgeBases)
+		assert.Equal(t, int64(5), summary.TotalNotes)
+		assert.Equal(t, map[string]int64{"markdown": 4, "code": 1}, summary.NotesByType)
+		assert.Equal(t, int64(3), summary.TotalConnections)
+		assert.Equal(t, stats.ConnectionsByType, summary.ConnectionsByType)
+		assert.Equal(t, stats.ConnectionsByStrength, summary.ConnectionsByStrength)
+		assert.Equal(t, int64(2), summary.OrphanNotes)
+		assert.Equal(t, stats.MostConnectedNotes, summary.MostConnectedNotes)
+	})
+
+	t.Run("passes the archive option to the connection stats", func(t *testing.T) {
+		opts := connection.StatsOptions{IncludeArchived: true}
+		archived := &connection.ConnectionStats{TotalConnections: 4, ArchivedConnections: 1}
+
+		kbStorage.EXPECT().List(gomock.Any(), gomock.Any()).Return(&knowledgebase.ListResponse{}, nil)
+		noteStorage.EXPECT().List(gomock.Any(), gomock.Any()).Return(&note.ListNotesResponse{}, nil).Times(1 + len(note.ValidNoteTypes()))
+		connStorage.EXPECT().GetConnectionStats(gomock.Any(), opts).Return(archived, nil)
+		connStorage.EXPECT().FindOrphanNotes(gomock.Any(), 1, 0).Return(&connection.OrphanNotesResponse{}, nil)
+
+		summary, err := graph.GetSummary(context.Background(), kbStorage, noteStorage, connStorage, opts)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(4), summary.TotalConnections)
+		assert.Equal(t, int64(1), summary.ArchivedConnections)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		kbStorage.EXPECT().
+			List(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("storage error"))
+
+		_, err := graph.GetSummary(context.Background(), kbStorage, noteStorage, connStorage, connection.StatsOptions{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to count knowledge bases")
+	})
+}
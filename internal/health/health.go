@@ -0,0 +1,165 @@
+// Package health checks whether the server can still do its job, so an
+// orchestrator can restart a wedged instance.
+//
+// A report is unhealthy when requests cannot succeed: the database does not
+// answer or a migration failed halfway. It is degraded when the server works
+// but something is off: migrations are pending or the search index is out of
+// step with the notes.
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Status is the outcome of a check or of a whole report
+type Status string
+
+const (
+	// StatusHealthy means the check passed
+	StatusHealthy Status = "healthy"
+	// StatusDegraded means the server works but needs attention
+	StatusDegraded Status = "degraded"
+	// StatusUnhealthy means requests are expected to fail
+	StatusUnhealthy Status = "unhealthy"
+)
+
+// severity orders statuses so a report takes its worst check's status
+var severity = map[Status]int{
+	StatusHealthy:   0,
+	StatusDegraded:  1,
+	StatusUnhealthy: 2,
+}
+
+// Check is the result of one health check
+type Check struct {
+	Name    string `json:"name"`
+	Status  Status `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// Report is the result of a full health check
+type Report struct {
+	Status    Status    `json:"status"`
+	Checks    []Check   `json:"checks"`
+	CheckedAt time.Time `json:"checked_at"`
+	Duration  string    `json:"duration"`
+}
+
+// Migrations reports the applied and the latest known schema version.
+// *migrations.MigrationRunner implements it.
+type Migrations interface {
+	GetVersion() (uint, bool, error)
+	LatestVersion() (uint, error)
+}
+
+// Checker runs the health checks against a database
+type Checker struct {
+	db         *sql.DB
+	migrations Migrations
+}
+
+// New creates a Checker for db, comparing its schema against migrations
+func New(db *sql.DB, migrations Migrations) *Checker {
+	return &Checker{db: db, migrations: migrations}
+}
+
+// Check runs every check and returns the report. The remaining checks are
+// skipped when the database is unreachable, since they would only repeat
+// that failure.
+func (c *Checker) Check(ctx context.Context) Report {
+	start := time.Now()
+	report := Report{Status: StatusHealthy, CheckedAt: start.UTC()}
+
+	add := func(check Check) {
+		report.Checks = append(report.Checks, check)
+		if severity[check.Status] > severity[report.Status] {
+			report.Status = check.Status
+		}
+	}
+
+	database := c.checkDatabase(ctx)
+	add(database)
+	if database.Status == StatusHealthy {
+		add(c.checkMigrations())
+		add(c.checkSearchIndex(ctx))
+	}
+
+	report.Duration = time.Since(start).String()
+	return report
+}
+
+// checkDatabase verifies the database answers queries
+func (c *Checker) checkDatabase(ctx context.Context) Check {
+	check := Check{Name: "database", Status: StatusHealthy}
+	if err := c.db.PingContext(ctx); err != nil {
+		check.Status = StatusUnhealthy
+		check.Message = fmt.Sprintf("database is unreachable: %v", err)
+		return check
+	}
+
+	// Ping may reuse an idle connection without touching the file
+	var one int
+	if err := c.db.QueryRowContext(ctx, "SELECT 1").Scan(&one); err != nil {
+		check.Status = StatusUnhealthy
+		check.Message = fmt.Sprintf("database does not answer queries: %v", err)
+	}
+	return check
+}
+
+// checkMigrations verifies every migration has been applied and none failed
+func (c *Checker) checkMigrations() Check {
+	check := Check{Name: "migrations", Status: StatusHealthy}
+
+	version, dirty, err := c.migrations.GetVersion()
+	if err != nil {
+		check.Status = StatusUnhealthy
+		check.Message = fmt.Sprintf("failed to read schema version: %v", err)
+		return check
+	}
+	latest, err := c.migrations.LatestVersion()
+	if err != nil {
+		check.Status = StatusUnhealthy
+		check.Message = fmt.Sprintf("failed to read latest migration: %v", err)
+		return check
+	}
+
+	switch {
+	case dirty:
+		check.Status = StatusUnhealthy
+		check.Message = fmt.Sprintf("migration %d failed and left the schema dirty", version)
+	case version < latest:
+		check.Status = StatusDegraded
+		check.Message = fmt.Sprintf("schema is at version %d, %d migrations pending up to %d", version, latest-version, latest)
+	case version > latest:
+		check.Status = StatusDegraded
+		check.Message = fmt.Sprintf("schema version %d is newer than this server's latest migration %d", version, latest)
+	default:
+		check.Message = fmt.Sprintf("schema is at version %d", version)
+	}
+	return check
+}
+
+// checkSearchIndex verifies the full-text index holds one document per note
+func (c *Checker) checkSearchIndex(ctx context.Context) Check {
+	check := Check{Name: "search_index", Status: StatusHealthy}
+
+	var notes, indexed int64
+	err := c.db.QueryRowContext(ctx,
+		"SELECT (SELECT COUNT(*) FROM notes), (SELECT COUNT(*) FROM notes_fts_docsize)").Scan(&notes, &indexed)
+	if err != nil {
+		check.Status = StatusDegraded
+		check.Message = fmt.Sprintf("failed to count indexed notes: %v", err)
+		return check
+	}
+
+	if notes != indexed {
+		check.Status = StatusDegraded
+		check.Message = fmt.Sprintf("search index has %d documents for %d notes, run rebuild_search_index", indexed, notes)
+		return check
+	}
+	check.Message = fmt.Sprintf("%d notes indexed", notes)
+	return check
+}
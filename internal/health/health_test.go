@@ -0,0 +1,168 @@
+package health_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/health"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/migrations"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/sqlitedb"
+)
+
+// fakeMigrations reports fixed schema versions
+type fakeMigrations struct {
+	version   uint
+	dirty     bool
+	latest    uint
+	err       error
+	latestErr error
+}
+
+func (f fakeMigrations) GetVersion() (uint, bool, error) { return f.version, f.dirty, f.err }
+func (f fakeMigrations) LatestVersion() (uint, error)    { return f.latest, f.latestErr }
+
+// newTestDB returns a fully migrated database with two notes
+func newTestDB(t *testing.T) (*sql.DB, *migrations.MigrationRunner) {
+	t.Helper()
+
+	dbPath := filepath.Join(t.TempDir(), "health.db")
+	runner := migrations.NewMigrationRunner(dbPath)
+	require.NoError(t, runner.RunMigrations())
+
+	db, err := sqlitedb.Open(dbPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec(`INSERT INTO notes (title, content, type) VALUES
+		('one', 'first note', 'text'),
+		('two', 'second note', 'text')`)
+	require.NoError(t, err)
+
+	return db, runner
+}
+
+// checkByName returns the named check from a report
+func checkByName(t *testing.T, report health.Report, name string) health.Check {
+	t.Helper()
+	for _, check := range report.Checks {
+		if check.Name == name {
+			return check
+		}
+	}
+	t.Fatalf("report has no %s check", name)
+	return health.Check{}
+}
+
+func TestChecker_Check(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("migrated database is healthy", func(t *testing.T) {
+		db, runner := newTestDB(t)
+
+		report := health.New(db, runner).Check(ctx)
+		assert.Equal(t, health.StatusHealthy, report.Status)
+		require.Len(t, report.Checks, 3)
+		for _, check := range report.Checks {
+			assert.Equal(t, health.StatusHealthy, check.Status, check.Name)
+		}
+		assert.Equal(t, "2 notes indexed", checkByName(t, report, "search_index").Message)
+		assert.False(t, report.CheckedAt.IsZero())
+	})
+
+	tests := []struct {
+		name        string
+		migrations  fakeMigrations
+		wantStatus  health.Status
+		wantMessage string
+	}{
+		{
+			name:        "current schema",
+			migrations:  fakeMigrations{version: 8, latest: 8},
+			wantStatus:  health.StatusHealthy,
+			wantMessage: "schema is at version 8",
+		},
+		{
+			name:        "pending migrations",
+			migrations:  fakeMigrations{version: 6, latest: 8},
+			wantStatus:  health.StatusDegraded,
+			wantMessage: "2 migrations pending",
+		},
+		{
+			name:        "schema newer than the binary",
+			migrations:  fakeMigrations{version: 9, latest: 8},
+			wantStatus:  health.StatusDegraded,
+			wantMessage: "newer than this server's latest migration",
+		},
+		{
+			name:        "dirty schema",
+			migrations:  fakeMigrations{version: 8, dirty: true, latest: 8},
+			wantStatus:  health.StatusUnhealthy,
+			wantMessage: "migration 8 failed",
+		},
+		{
+			name:        "version unreadable",
+			migrations:  fakeMigrations{err: errors.New("locked")},
+			wantStatus:  health.StatusUnhealthy,
+			wantMessage: "failed to read schema version: locked",
+		},
+		{
+			name:        "latest version unreadable",
+			migrations:  fakeMigrations{latestErr: errors.New("no source")},
+			wantStatus:  health.StatusUnhealthy,
+			wantMessage: "failed to read latest migration: no source",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, _ := newTestDB(t)
+
+			report := health.New(db, tt.migrations).Check(ctx)
+			check := checkByName(t, report, "migrations")
+			assert.Equal(t, tt.wantStatus, check.Status)
+			assert.Contains(t, check.Message, tt.wantMessage)
+			assert.Equal(t, tt.wantStatus, report.Status)
+		})
+	}
+
+	t.Run("search index out of step is degraded", func(t *testing.T) {
+		db, runner := newTestDB(t)
+
+		// Drop one note's index entry behind the triggers' back
+		_, err := db.Exec(`INSERT INTO notes_fts (notes_fts, rowid, title, content) VALUES ('delete', 1, 'one', 'first note')`)
+		require.NoError(t, err)
+
+		report := health.New(db, runner).Check(ctx)
+		assert.Equal(t, health.StatusDegraded, report.Status)
+		check := checkByName(t, report, "search_index")
+		assert.Equal(t, health.StatusDegraded, check.Status)
+		assert.Contains(t, check.Message, "1 documents for 2 notes")
+		assert.Contains(t, check.Message, "rebuild_search_index")
+	})
+
+	t.Run("unhealthy wins over degraded", func(t *testing.T) {
+		db, _ := newTestDB(t)
+		_, err := db.Exec(`INSERT INTO notes_fts (notes_fts, rowid, title, content) VALUES ('delete', 1, 'one', 'first note')`)
+		require.NoError(t, err)
+
+		report := health.New(db, fakeMigrations{version: 8, dirty: true, latest: 8}).Check(ctx)
+		assert.Equal(t, health.StatusUnhealthy, report.Status)
+	})
+
+	t.Run("closed database is unhealthy", func(t *testing.T) {
+		db, runner := newTestDB(t)
+		require.NoError(t, db.Close())
+
+		report := health.New(db, runner).Check(ctx)
+		assert.Equal(t, health.StatusUnhealthy, report.Status)
+		require.Len(t, report.Checks, 1)
+		assert.Equal(t, "database", report.Checks[0].Name)
+		assert.Contains(t, report.Checks[0].Message, "database is unreachable")
+	})
+}
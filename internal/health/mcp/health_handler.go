@@ -0,0 +1,47 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/health"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewHealthHandler creates a new handler for checking database, schema and search index health
+func NewHealthHandler(checker *health.Checker) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		report := checker.Check(ctx)
+
+		jsonData, err := mcpx.MarshalJSON(report)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("%s\n\n%s", formatReport(report), string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
+
+// formatReport renders the human-readable part of the report
+func formatReport(report health.Report) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Server is %s", report.Status)
+	for _, check := range report.Checks {
+		fmt.Fprintf(&b, "\n- %s: %s", check.Name, check.Status)
+		if check.Message != "" {
+			fmt.Fprintf(&b, " (%s)", check.Message)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package mcp_test
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/health"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/health/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/migrations"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/sqlitedb"
+)
+
+// pendingMigrations reports a schema one migration behind
+type pendingMigrations struct{}
+
+func (pendingMigrations) GetVersion() (uint, bool, error) { return 7, false, nil }
+func (pendingMigrations) LatestVersion() (uint, error)    { return 8, nil }
+
+func TestHealthHandler(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "health.db")
+	runner := migrations.NewMigrationRunner(dbPath)
+	require.NoError(t, runner.RunMigrations())
+
+	db, err := sqlitedb.Open(dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	tests := []struct {
+		name        string
+		migrations  health.Migrations
+		wantStatus  health.Status
+		wantContent []string
+	}{
+		{
+			name:       "healthy",
+			migrations: runner,
+			wantStatus: health.StatusHealthy,
+			wantContent: []string{
+				"Server is healthy",
+				"- database: healthy",
+				"- migrations: healthy",
+				"- search_index: healthy (0 notes indexed)",
+			},
+		},
+		{
+			name:       "degraded",
+			migrations: pendingMigrations{},
+			wantStatus: health.StatusDegraded,
+			wantContent: []string{
+				"Server is degraded",
+				"- migrations: degraded (schema is at version 7, 1 migrations pending up to 8)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := mcp.NewHealthHandler(health.New(db, tt.migrations))
+			result, err := handler(context.Background(), gomcp.CallToolRequest{})
+			require.NoError(t, err)
+
+			text := result.Content[0].(gomcp.TextContent).Text
+			for _, want := range tt.wantContent {
+				assert.Contains(t, text, want)
+			}
+
+			var report health.Report
+			require.NoError(t, json.Unmarshal([]byte(text[strings.Index(text, "{"):]), &report))
+			assert.Equal(t, tt.wantStatus, report.Status)
+			assert.Len(t, report.Checks, 3)
+		})
+	}
+}
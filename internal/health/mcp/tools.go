@@ -0,0 +1,40 @@
+package mcp
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/health"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// RegisterTools registers the health check MCP tools with the server
+func RegisterTools(s *server.MCPServer, checker *health.Checker, middleware ...mcpx.Middleware) error {
+	tools := []struct {
+		name        string
+		description string
+		handler     server.ToolHandlerFunc
+		schema      mcp.ToolInputSchema
+	}{
+		{
+			name:        "health_check",
+			description: "Check that the database is reachable, all migrations are applied and the search index matches the notes. Reports healthy, degraded (working but needs attention) or unhealthy",
+			handler:     NewHealthHandler(checker),
+			schema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: map[string]interface{}{},
+			},
+		},
+	}
+
+	for _, tool := range tools {
+		t := mcp.Tool{
+			Name:        tool.name,
+			Description: tool.description,
+			InputSchema: tool.schema,
+		}
+		s.AddTool(t, mcpx.Wrap(tool.name, tool.handler, middleware...))
+	}
+
+	return nil
+}
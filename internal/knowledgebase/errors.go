@@ -0,0 +1,10 @@
+package knowledgebase
+
+import (
+	"errors"
+)
+
+var (
+	// ErrNotFound is returned when no knowledge base has the requested ID
+	ErrNotFound = errors.New("knowledge base not found")
+)
@@ -0,0 +1,32 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// NewCountHandler creates a new handler for counting the knowledge base entries list_knowledge_bases would return
+func NewCountHandler(storage knowledgebase.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		var listReq knowledgebase.ListRequest
+		parseListFilters(arguments, &listReq)
+
+		total, err := storage.Count(ctx, listReq)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count knowledge bases: %w", err)
+		}
+
+		return mcpx.CountResult("knowledge bases", total)
+	}
+}
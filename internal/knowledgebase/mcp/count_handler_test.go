@@ -0,0 +1,96 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase/mock"
+)
+
+func TestCountHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewCountHandler(mockStorage)
+
+	tests := []struct {
+		name        string
+		args        interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "count all knowledge bases",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Count(gomock.Any(), knowledgebase.ListRequest{}).
+					Return(int64(7), nil)
+			},
+			wantContent: "Found 7 knowledge bases\n\n{\n  \"total\": 7\n}",
+		},
+		{
+			name: "count with filters",
+			args: map[string]interface{}{
+				"search": "golang",
+				"tags":   []interface{}{"programming"},
+				"source": "agent",
+				"limit":  5,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Count(gomock.Any(), knowledgebase.ListRequest{
+						Search: "golang",
+						Tags:   []string{"programming"},
+						Source: "agent",
+					}).
+					Return(int64(2), nil)
+			},
+			wantContent: "Found 2 knowledge bases",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Count(gomock.Any(), knowledgebase.ListRequest{}).
+					Return(int64(0), errors.New("database error"))
+			},
+			wantErr: true,
+		},
+		{
+			name:      "invalid arguments",
+			args:      "not a map",
+			mockSetup: func() {},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{}
+			req.Params.Arguments = tt.args
+
+			result, err := handler(context.Background(), req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			text := result.Content[0].(gomcp.TextContent).Text
+			assert.Contains(t, text, tt.wantContent)
+		})
+	}
+}
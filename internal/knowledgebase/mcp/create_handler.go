@@ -2,13 +2,13 @@ package mcp
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
 )
 
 // NewCreateHandler creates a new handler for creating knowledge base entries
@@ -38,10 +38,22 @@ func NewCreateHandler(storage knowledgebase.Storage) server.ToolHandlerFunc {
 			}
 		}
 
+		var source *string
+		if src, ok := arguments["source"].(string); ok && src != "" {
+			source = &src
+		}
+
+		idempotencyKey, err := mcpx.ParseIdempotencyKey(arguments)
+		if err != nil {
+			return nil, err
+		}
+
 		createReq := knowledgebase.CreateRequest{
-			Name:        name,
-			Description: &description,
-			Tags:        tags,
+			Name:           name,
+			Description:    &description,
+			Tags:           tags,
+			Source:         source,
+			IdempotencyKey: idempotencyKey,
 		}
 
 		kb, err := storage.Create(ctx, createReq)
@@ -54,11 +66,12 @@ func NewCreateHandler(storage knowledgebase.Storage) server.ToolHandlerFunc {
 			"name":        kb.Name,
 			"description": kb.Description,
 			"tags":        kb.Tags,
+			"source":      kb.Source,
 			"created_at":  kb.CreatedAt,
 			"updated_at":  kb.UpdatedAt,
 		}
 
-		jsonData, err := json.MarshalIndent(result, "", "  ")
+		jsonData, err := mcpx.MarshalJSON(result)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal result: %w", err)
 		}
@@ -58,6 +58,63 @@ func TestCreateHandler(t *testing.T) {
 			wantErr:     false,
 			wantContent: "Successfully created knowledge base entry with ID: 1",
 		},
+		{
+			name: "successful creation with source",
+			args: map[string]interface{}{
+				"name":        "Test KB",
+				"description": "Test Description",
+				"source":      "research-agent",
+			},
+			mockSetup: func() {
+				source := "research-agent"
+				mockStorage.EXPECT().
+					Create(gomock.Any(), knowledgebase.CreateRequest{
+						Name:        "Test KB",
+						Description: &desc,
+						Source:      &source,
+					}).
+					Return(&knowledgebase.KnowledgeBase{
+						ID:          2,
+						Name:        "Test KB",
+						Description: &desc,
+						Tags:        []string{},
+						Source:      &source,
+						CreatedAt:   now,
+						UpdatedAt:   now,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: `"source": "research-agent"`,
+		},
+		{
+			name: "idempotency key is passed to storage",
+			args: map[string]interface{}{
+				"name":            "Test KB",
+				"idempotency_key": "retry-1",
+			},
+			mockSetup: func() {
+				empty := ""
+				mockStorage.EXPECT().
+					Create(gomock.Any(), knowledgebase.CreateRequest{
+						Name:           "Test KB",
+						Description:    &empty,
+						IdempotencyKey: "retry-1",
+					}).
+					Return(&knowledgebase.KnowledgeBase{ID: 3, Name: "Test KB", Tags: []string{}, CreatedAt: now, UpdatedAt: now}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully created knowledge base entry with ID: 3",
+		},
+		{
+			name: "invalid idempotency key type",
+			args: map[string]interface{}{
+				"name":            "Test KB",
+				"idempotency_key": true,
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "idempotency_key must be a string",
+		},
 		{
 			name: "missing name",
 			args: map[string]interface{}{
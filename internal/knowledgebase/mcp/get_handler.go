@@ -2,14 +2,14 @@ package mcp
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"strconv"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
 )
 
 // NewGetHandler creates a new handler for getting a knowledge base entry by ID
@@ -20,22 +20,23 @@ func NewGetHandler(storage knowledgebase.Storage) server.ToolHandlerFunc {
 			return nil, fmt.Errorf("invalid arguments format")
 		}
 
-		idStr, ok := arguments["id"].(string)
-		if !ok || idStr == "" {
+		// Parse ID
+		idRaw, ok := arguments["id"]
+		if !ok {
 			return nil, fmt.Errorf("id is required")
 		}
 
-		id, err := strconv.ParseInt(idStr, 10, 64)
+		id, err := mcpx.ParseInt64(idRaw)
 		if err != nil {
-			return nil, fmt.Errorf("invalid id format: %w", err)
+			return nil, fmt.Errorf("invalid id: %w", err)
 		}
 
-		kb, err := storage.Get(ctx, id)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get knowledge base: %w", err)
+		if id <= 0 {
+			return nil, fmt.Errorf("id must be a positive integer")
 		}
 
-		if kb == nil {
+		kb, err := storage.Get(ctx, id)
+		if errors.Is(err, knowledgebase.ErrNotFound) {
 			return &mcp.CallToolResult{
 				Content: []mcp.Content{
 					mcp.TextContent{
@@ -45,17 +46,21 @@ func NewGetHandler(storage knowledgebase.Storage) server.ToolHandlerFunc {
 				},
 			}, nil
 		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get knowledge base: %w", err)
+		}
 
 		result := map[string]interface{}{
 			"id":          kb.ID,
 			"name":        kb.Name,
 			"description": kb.Description,
 			"tags":        kb.Tags,
+			"source":      kb.Source,
 			"created_at":  kb.CreatedAt,
 			"updated_at":  kb.UpdatedAt,
 		}
 
-		jsonData, err := json.MarshalIndent(result, "", "  ")
+		jsonData, err := mcpx.MarshalJSON(result)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal result: %w", err)
 		}
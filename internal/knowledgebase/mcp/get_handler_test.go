@@ -3,6 +3,7 @@ package mcp_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -52,6 +53,19 @@ func TestGetHandler(t *testing.T) {
 			wantErr:     false,
 			wantContent: `"name": "Test KB"`,
 		},
+		{
+			name: "numeric id",
+			args: map[string]interface{}{
+				"id": float64(124),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Get(gomock.Any(), int64(124)).
+					Return(&knowledgebase.KnowledgeBase{ID: 124, Name: "Numeric KB", CreatedAt: now, UpdatedAt: now}, nil)
+			},
+			wantErr:     false,
+			wantContent: `"name": "Numeric KB"`,
+		},
 		{
 			name: "not found",
 			args: map[string]interface{}{
@@ -60,7 +74,7 @@ func TestGetHandler(t *testing.T) {
 			mockSetup: func() {
 				mockStorage.EXPECT().
 					Get(gomock.Any(), int64(123)).
-					Return(nil, nil)
+					Return(nil, fmt.Errorf("%w: 123", knowledgebase.ErrNotFound))
 			},
 			wantErr:     false,
 			wantContent: "Knowledge base entry with ID 123 not found",
@@ -72,7 +86,16 @@ func TestGetHandler(t *testing.T) {
 			},
 			mockSetup:   func() {},
 			wantErr:     true,
-			wantContent: "invalid id format",
+			wantContent: "invalid id: ",
+		},
+		{
+			name: "non-positive id",
+			args: map[string]interface{}{
+				"id": float64(0),
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "id must be a positive integer",
 		},
 		{
 			name: "storage error",
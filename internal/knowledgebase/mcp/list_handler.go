@@ -2,17 +2,17 @@ package mcp
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
 )
 
-// NewListHandler creates a new handler for listing knowledge base entries
-func NewListHandler(storage knowledgebase.Storage) server.ToolHandlerFunc {
+// NewListHandler creates a new handler for listing knowledge base entries, with page sizes bounded by limits
+func NewListHandler(storage knowledgebase.Storage, limits mcpx.ListLimits) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		arguments, ok := req.Params.Arguments.(map[string]interface{})
 		if !ok {
@@ -21,34 +21,49 @@ func NewListHandler(storage knowledgebase.Storage) server.ToolHandlerFunc {
 
 		listReq := knowledgebase.ListRequest{}
 
-		// Parse limit
-		if limit, ok := arguments["limit"].(float64); ok {
-			listReq.Limit = int(limit)
-		} else {
-			listReq.Limit = 100 // default
+		// Parse optional limit
+		limit, err := limits.ParseLimit(arguments)
+		if err != nil {
+			return nil, err
 		}
+		listReq.Limit = limit
 
 		// Parse offset
-		if offset, ok := arguments["offset"].(float64); ok {
-			listReq.Offset = int(offset)
-		} else {
-			listReq.Offset = 0 // default
+		if offsetRaw, ok := arguments["offset"]; ok {
+			listReq.Offset, err = mcpx.ParseInt(offsetRaw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid offset: %w", err)
+			}
 		}
 
-		// Parse search
-		if search, ok := arguments["search"].(string); ok {
-			listReq.Search = search
-		}
+		parseListFilters(arguments, &listReq)
 
-		// Parse tags
-		if tagsRaw, ok := arguments["tags"].([]interface{}); ok {
-			var tags []string
-			for _, tag := range tagsRaw {
-				if tagStr, ok := tag.(string); ok {
-					tags = append(tags, tagStr)
+		// Parse order_by
+		if orderBy, ok := arguments["order_by"].(string); ok && orderBy != "" {
+			isValid := false
+			for _, valid := range knowledgebase.ValidOrderByFields() {
+				if orderBy == valid {
+					isValid = true
+					break
 				}
 			}
-			listReq.Tags = tags
+			if !isValid {
+				return nil, fmt.Errorf("invalid order_by: %s. Valid values are: %v", orderBy, knowledgebase.ValidOrderByFields())
+			}
+			listReq.OrderBy = orderBy
+		}
+
+		// Parse order_dir
+		if orderDir, ok := arguments["order_dir"].(string); ok && orderDir != "" {
+			if orderDir != "asc" && orderDir != "desc" {
+				return nil, fmt.Errorf("invalid order_dir: %s. Valid values are: asc, desc", orderDir)
+			}
+			listReq.OrderDir = orderDir
+		}
+
+		// Parse include_counts
+		if includeCounts, ok := arguments["include_counts"].(bool); ok {
+			listReq.IncludeCounts = includeCounts
 		}
 
 		response, err := storage.List(ctx, listReq)
@@ -68,19 +83,38 @@ func NewListHandler(storage knowledgebase.Storage) server.ToolHandlerFunc {
 		}
 
 		var results []map[string]interface{}
-		for _, kb := range response.Items {
+		for i, kb := range response.Items {
 			result := map[string]interface{}{
 				"id":          kb.ID,
 				"name":        kb.Name,
 				"description": kb.Description,
 				"tags":        kb.Tags,
+				"source":      kb.Source,
 				"created_at":  kb.CreatedAt,
 				"updated_at":  kb.UpdatedAt,
 			}
+			if listReq.IncludeCounts {
+				var noteCount, connectionCount int64
+				if i < len(response.ItemsWithCounts) {
+					noteCount = response.ItemsWithCounts[i].NoteCount
+					connectionCount = response.ItemsWithCounts[i].ConnectionCount
+				}
+				result["note_count"] = noteCount
+				result["connection_count"] = connectionCount
+			}
 			results = append(results, result)
 		}
 
-		jsonData, err := json.MarshalIndent(results, "", "  ")
+		summary := map[string]interface{}{
+			"items":    results,
+			"limit":    listReq.Limit,
+			"offset":   listReq.Offset,
+			"total":    response.Total,
+			"count":    len(response.Items),
+			"has_more": int64(listReq.Offset+len(response.Items)) < response.Total,
+		}
+
+		jsonData, err := mcpx.MarshalJSON(summary)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal results: %w", err)
 		}
@@ -89,9 +123,34 @@ func NewListHandler(storage knowledgebase.Storage) server.ToolHandlerFunc {
 			Content: []mcp.Content{
 				mcp.TextContent{
 					Type: "text",
-					Text: fmt.Sprintf("Found %d knowledge base entries:\n\n%s", len(response.Items), string(jsonData)),
+					Text: fmt.Sprintf("Found %d knowledge base entries (total: %d):\n\n%s", len(response.Items), response.Total, string(jsonData)),
 				},
 			},
 		}, nil
 	}
 }
+
+// parseListFilters reads the filter arguments shared by list_knowledge_bases
+// and count_knowledge_bases into listReq
+func parseListFilters(arguments map[string]interface{}, listReq *knowledgebase.ListRequest) {
+	// Parse search
+	if search, ok := arguments["search"].(string); ok {
+		listReq.Search = search
+	}
+
+	// Parse tags
+	if tagsRaw, ok := arguments["tags"].([]interface{}); ok {
+		var tags []string
+		for _, tag := range tagsRaw {
+			if tagStr, ok := tag.(string); ok {
+				tags = append(tags, tagStr)
+			}
+		}
+		listReq.Tags = tags
+	}
+
+	// Parse source
+	if source, ok := arguments["source"].(string); ok {
+		listReq.Source = source
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase"
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase/mcp"
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase/mock"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
 )
 
 func TestListHandler(t *testing.T) {
@@ -20,7 +21,7 @@ func TestListHandler(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockStorage := mock.NewMockStorage(ctrl)
-	handler := mcp.NewListHandler(mockStorage)
+	handler := mcp.NewListHandler(mockStorage, mcpx.DefaultListLimits())
 
 	now := time.Now()
 	desc1 := "Description 1"
@@ -97,6 +98,116 @@ func TestListHandler(t *testing.T) {
 			wantErr:     false,
 			wantContent: "Found 1 knowledge base entries",
 		},
+		{
+			name: "list with source filter",
+			args: map[string]interface{}{
+				"source": "research-agent",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					List(gomock.Any(), knowledgebase.ListRequest{
+						Source: "research-agent",
+						Limit:  100,
+						Offset: 0,
+					}).
+					Return(&knowledgebase.ListResponse{
+						Items: []knowledgebase.KnowledgeBase{},
+						Total: 0,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "No knowledge base entries found",
+		},
+		{
+			name: "list with counts",
+			args: map[string]interface{}{
+				"include_counts": true,
+			},
+			mockSetup: func() {
+				kb := knowledgebase.KnowledgeBase{
+					ID:        1,
+					Name:      "Test KB",
+					Tags:      []string{"tag1"},
+					CreatedAt: now,
+					UpdatedAt: now,
+				}
+				mockStorage.EXPECT().
+					List(gomock.Any(), knowledgebase.ListRequest{
+						Limit:         100,
+						Offset:        0,
+						IncludeCounts: true,
+					}).
+					Return(&knowledgebase.ListResponse{
+						Items: []knowledgebase.KnowledgeBase{kb},
+						ItemsWithCounts: []knowledgebase.KnowledgeBaseWithCounts{
+							{KnowledgeBase: kb, NoteCount: 4, ConnectionCount: 7},
+						},
+						Total: 1,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: `"connection_count": 7`,
+		},
+		{
+			name: "more pages available",
+			args: map[string]interface{}{
+				"limit":  float64(1),
+				"offset": float64(1),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					List(gomock.Any(), knowledgebase.ListRequest{
+						Limit:  1,
+						Offset: 1,
+					}).
+					Return(&knowledgebase.ListResponse{
+						Items: []knowledgebase.KnowledgeBase{{ID: 2, Name: "KB 2", CreatedAt: now, UpdatedAt: now}},
+						Total: 3,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: `"has_more": true`,
+		},
+		{
+			name: "list ordered by name",
+			args: map[string]interface{}{
+				"order_by":  "name",
+				"order_dir": "asc",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					List(gomock.Any(), knowledgebase.ListRequest{
+						Limit:    100,
+						Offset:   0,
+						OrderBy:  "name",
+						OrderDir: "asc",
+					}).
+					Return(&knowledgebase.ListResponse{
+						Items: []knowledgebase.KnowledgeBase{{ID: 1, Name: "Alpha", CreatedAt: now, UpdatedAt: now}},
+						Total: 1,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Found 1 knowledge base entries",
+		},
+		{
+			name: "invalid order_by",
+			args: map[string]interface{}{
+				"order_by": "tags",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid order_by",
+		},
+		{
+			name: "invalid order_dir",
+			args: map[string]interface{}{
+				"order_dir": "up",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid order_dir",
+		},
 		{
 			name: "empty list",
 			args: map[string]interface{}{},
@@ -149,4 +260,69 @@ func TestListHandler(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestListHandler_CustomLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewListHandler(mockStorage, mcpx.ListLimits{Default: 5, Max: 20})
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "configured default",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					List(gomock.Any(), knowledgebase.ListRequest{Limit: 5}).
+					Return(&knowledgebase.ListResponse{Items: []knowledgebase.KnowledgeBase{}}, nil)
+			},
+			wantContent: "No knowledge base entries found",
+		},
+		{
+			name: "configured maximum",
+			args: map[string]interface{}{"limit": float64(20)},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					List(gomock.Any(), knowledgebase.ListRequest{Limit: 20}).
+					Return(&knowledgebase.ListResponse{Items: []knowledgebase.KnowledgeBase{}}, nil)
+			},
+			wantContent: "No knowledge base entries found",
+		},
+		{
+			name:        "above the configured maximum",
+			args:        map[string]interface{}{"limit": float64(21)},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "limit must be between 1 and 20, got: 21",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
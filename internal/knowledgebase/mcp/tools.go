@@ -5,10 +5,11 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
 )
 
 // RegisterTools registers all knowledge base MCP tools with the server
-func RegisterTools(s *server.MCPServer, storage knowledgebase.Storage) error {
+func RegisterTools(s *server.MCPServer, storage knowledgebase.Storage, limits mcpx.ListLimits, middleware ...mcpx.Middleware) error {
 	tools := []struct {
 		name        string
 		description string
@@ -22,6 +23,7 @@ func RegisterTools(s *server.MCPServer, storage knowledgebase.Storage) error {
 			schema: mcp.ToolInputSchema{
 				Type: "object",
 				Properties: map[string]interface{}{
+					"idempotency_key": mcpx.IdempotencyKeyProperty("knowledge base"),
 					"name": map[string]interface{}{
 						"type":        "string",
 						"description": "Name of the knowledge base entry",
@@ -37,6 +39,10 @@ func RegisterTools(s *server.MCPServer, storage knowledgebase.Storage) error {
 							"type": "string",
 						},
 					},
+					"source": map[string]interface{}{
+						"type":        "string",
+						"description": "Optional name of the agent or tool creating the knowledge base, for attribution",
+					},
 				},
 				Required: []string{"name"},
 			},
@@ -49,7 +55,7 @@ func RegisterTools(s *server.MCPServer, storage knowledgebase.Storage) error {
 				Type: "object",
 				Properties: map[string]interface{}{
 					"id": map[string]interface{}{
-						"type":        "string",
+						"type":        "integer",
 						"description": "Unique identifier of the knowledge base entry",
 					},
 				},
@@ -64,7 +70,7 @@ func RegisterTools(s *server.MCPServer, storage knowledgebase.Storage) error {
 				Type: "object",
 				Properties: map[string]interface{}{
 					"id": map[string]interface{}{
-						"type":        "string",
+						"type":        "integer",
 						"description": "Unique identifier of the knowledge base entry",
 					},
 					"name": map[string]interface{}{
@@ -94,7 +100,7 @@ func RegisterTools(s *server.MCPServer, storage knowledgebase.Storage) error {
 				Type: "object",
 				Properties: map[string]interface{}{
 					"id": map[string]interface{}{
-						"type":        "string",
+						"type":        "integer",
 						"description": "Unique identifier of the knowledge base entry to delete",
 					},
 				},
@@ -104,33 +110,19 @@ func RegisterTools(s *server.MCPServer, storage knowledgebase.Storage) error {
 		{
 			name:        "list_knowledge_bases",
 			description: "List all knowledge base entries with optional filtering",
-			handler:     NewListHandler(storage),
+			handler:     NewListHandler(storage, limits),
 			schema: mcp.ToolInputSchema{
-				Type: "object",
-				Properties: map[string]interface{}{
-					"limit": map[string]interface{}{
-						"type":        "integer",
-						"description": "Maximum number of entries to return (default: 100)",
-						"minimum":     1,
-						"maximum":     1000,
-					},
-					"offset": map[string]interface{}{
-						"type":        "integer",
-						"description": "Number of entries to skip (default: 0)",
-						"minimum":     0,
-					},
-					"tags": map[string]interface{}{
-						"type":        "array",
-						"description": "Filter by tags (returns entries that have any of the specified tags)",
-						"items": map[string]interface{}{
-							"type": "string",
-						},
-					},
-					"search": map[string]interface{}{
-						"type":        "string",
-						"description": "Search term to filter entries by name or description",
-					},
-				},
+				Type:       "object",
+				Properties: listKnowledgeBasesProperties(limits),
+			},
+		},
+		{
+			name:        "count_knowledge_bases",
+			description: "Count the knowledge base entries matching the list_knowledge_bases filters without fetching them",
+			handler:     NewCountHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: mcpx.CountProperties(listKnowledgeBasesProperties(limits), "include_counts"),
 			},
 		},
 	}
@@ -141,8 +133,50 @@ func RegisterTools(s *server.MCPServer, storage knowledgebase.Storage) error {
 			Description: tool.description,
 			InputSchema: tool.schema,
 		}
-		s.AddTool(t, tool.handler)
+		s.AddTool(t, mcpx.Wrap(tool.name, tool.handler, middleware...))
 	}
 
 	return nil
 }
+
+// listKnowledgeBasesProperties is the list_knowledge_bases schema, whose
+// filters count_knowledge_bases shares
+func listKnowledgeBasesProperties(limits mcpx.ListLimits) map[string]interface{} {
+	return map[string]interface{}{
+		"limit": limits.LimitProperty("entries"),
+		"offset": map[string]interface{}{
+			"type":        "integer",
+			"description": "Number of entries to skip (default: 0)",
+			"minimum":     0,
+		},
+		"tags": map[string]interface{}{
+			"type":        "array",
+			"description": "Filter by tags (returns entries that have any of the specified tags)",
+			"items": map[string]interface{}{
+				"type": "string",
+			},
+		},
+		"search": map[string]interface{}{
+			"type":        "string",
+			"description": "Search term to filter entries by name or description (case- and accent-insensitive, ranked by relevance unless order_by is set)",
+		},
+		"order_by": map[string]interface{}{
+			"type":        "string",
+			"description": "Field to order by (default: created_at)",
+			"enum":        knowledgebase.ValidOrderByFields(),
+		},
+		"order_dir": map[string]interface{}{
+			"type":        "string",
+			"description": "Order direction (default: desc)",
+			"enum":        []string{"asc", "desc"},
+		},
+		"source": map[string]interface{}{
+			"type":        "string",
+			"description": "Filter by the source that created the knowledge bases",
+		},
+		"include_counts": map[string]interface{}{
+			"type":        "boolean",
+			"description": "Include each knowledge base's note_count and connection_count (default: false)",
+		},
+	}
+}
@@ -2,14 +2,14 @@ package mcp
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"strconv"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
 )
 
 // NewUpdateHandler creates a new handler for updating knowledge base entries
@@ -20,14 +20,19 @@ func NewUpdateHandler(storage knowledgebase.Storage) server.ToolHandlerFunc {
 			return nil, fmt.Errorf("invalid arguments format")
 		}
 
-		idStr, ok := arguments["id"].(string)
-		if !ok || idStr == "" {
+		// Parse ID
+		idRaw, ok := arguments["id"]
+		if !ok {
 			return nil, fmt.Errorf("id is required")
 		}
 
-		id, err := strconv.ParseInt(idStr, 10, 64)
+		id, err := mcpx.ParseInt64(idRaw)
 		if err != nil {
-			return nil, fmt.Errorf("invalid id format: %w", err)
+			return nil, fmt.Errorf("invalid id: %w", err)
+		}
+
+		if id <= 0 {
+			return nil, fmt.Errorf("id must be a positive integer")
 		}
 
 		updateReq := knowledgebase.UpdateRequest{}
@@ -51,11 +56,7 @@ func NewUpdateHandler(storage knowledgebase.Storage) server.ToolHandlerFunc {
 		}
 
 		kb, err := storage.Update(ctx, id, updateReq)
-		if err != nil {
-			return nil, fmt.Errorf("failed to update knowledge base: %w", err)
-		}
-
-		if kb == nil {
+		if errors.Is(err, knowledgebase.ErrNotFound) {
 			return &mcp.CallToolResult{
 				Content: []mcp.Content{
 					mcp.TextContent{
@@ -65,17 +66,21 @@ func NewUpdateHandler(storage knowledgebase.Storage) server.ToolHandlerFunc {
 				},
 			}, nil
 		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to update knowledge base: %w", err)
+		}
 
 		result := map[string]interface{}{
 			"id":          kb.ID,
 			"name":        kb.Name,
 			"description": kb.Description,
 			"tags":        kb.Tags,
+			"source":      kb.Source,
 			"created_at":  kb.CreatedAt,
 			"updated_at":  kb.UpdatedAt,
 		}
 
-		jsonData, err := json.MarshalIndent(result, "", "  ")
+		jsonData, err := mcpx.MarshalJSON(result)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal result: %w", err)
 		}
@@ -3,6 +3,7 @@ package mcp_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -56,6 +57,34 @@ func TestUpdateHandler(t *testing.T) {
 			wantErr:     false,
 			wantContent: "Successfully updated knowledge base entry with ID: 123",
 		},
+		{
+			name: "numeric id",
+			args: map[string]interface{}{
+				"id":   float64(124),
+				"name": "Updated KB",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Update(gomock.Any(), int64(124), gomock.Any()).
+					Return(&knowledgebase.KnowledgeBase{ID: 124, Name: updatedName, CreatedAt: now, UpdatedAt: now}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully updated knowledge base entry with ID: 124",
+		},
+		{
+			name: "not found",
+			args: map[string]interface{}{
+				"id":   "999",
+				"name": "Updated KB",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Update(gomock.Any(), int64(999), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: 999", knowledgebase.ErrNotFound))
+			},
+			wantErr:     false,
+			wantContent: "Knowledge base entry with ID 999 not found",
+		},
 		{
 			name: "missing id",
 			args: map[string]interface{}{
@@ -72,7 +101,16 @@ func TestUpdateHandler(t *testing.T) {
 			},
 			mockSetup:   func() {},
 			wantErr:     true,
-			wantContent: "invalid id format",
+			wantContent: "invalid id: ",
+		},
+		{
+			name: "non-positive id",
+			args: map[string]interface{}{
+				"id": float64(0),
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "id must be a positive integer",
 		},
 		{
 			name: "storage error",
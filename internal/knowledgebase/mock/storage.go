@@ -35,6 +35,21 @@ func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
 	return m.recorder
 }
 
+// Count mocks base method.
+func (m *MockStorage) Count(ctx context.Context, req knowledgebase.ListRequest) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, req)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockStorageMockRecorder) Count(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockStorage)(nil).Count), ctx, req)
+}
+
 // Create mocks base method.
 func (m *MockStorage) Create(ctx context.Context, req knowledgebase.CreateRequest) (*knowledgebase.KnowledgeBase, error) {
 	m.ctrl.T.Helper()
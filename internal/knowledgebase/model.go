@@ -10,6 +10,7 @@ type KnowledgeBase struct {
 	Name        string     `json:"name"`
 	Description *string    `json:"description,omitempty"`
 	Tags        []string   `json:"tags,omitempty"`
+	Source      *string    `json:"source,omitempty"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 }
@@ -19,6 +20,11 @@ type CreateRequest struct {
 	Name        string   `json:"name"`
 	Description *string  `json:"description,omitempty"`
 	Tags        []string `json:"tags,omitempty"`
+	Source      *string  `json:"source,omitempty"` // Who or what created the record, e.g. an agent name
+
+	// IdempotencyKey, when set, makes Create return the knowledge base an
+	// earlier Create with the same key made instead of creating another
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
 }
 
 // UpdateRequest represents the DTO for updating a knowledge base
@@ -30,14 +36,33 @@ type UpdateRequest struct {
 
 // ListRequest represents the DTO for listing knowledge bases
 type ListRequest struct {
-	Limit  int      `json:"limit,omitempty"`
-	Offset int      `json:"offset,omitempty"`
-	Search string   `json:"search,omitempty"`
-	Tags   []string `json:"tags,omitempty"`
+	Limit    int      `json:"limit,omitempty"`
+	Offset   int      `json:"offset,omitempty"`
+	Search   string   `json:"search,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Source   string   `json:"source,omitempty"`
+	OrderBy  string   `json:"order_by,omitempty"`  // created_at (default), updated_at or name
+	OrderDir string   `json:"order_dir,omitempty"` // asc or desc (default)
+
+	IncludeCounts bool `json:"include_counts,omitempty"` // Also count each knowledge base's notes and connections
+}
+
+// ValidOrderByFields returns the fields knowledge bases can be ordered by
+func ValidOrderByFields() []string {
+	return []string{"created_at", "updated_at", "name"}
+}
+
+// KnowledgeBaseWithCounts is a knowledge base listed together with the size of its graph.
+// It is only returned by List when IncludeCounts is set.
+type KnowledgeBaseWithCounts struct {
+	KnowledgeBase
+	NoteCount       int64 `json:"note_count"`
+	ConnectionCount int64 `json:"connection_count"`
 }
 
 // ListResponse represents the DTO for listing response
 type ListResponse struct {
-	Items []KnowledgeBase `json:"items"`
-	Total int64           `json:"total"`
+	Items           []KnowledgeBase           `json:"items"`
+	ItemsWithCounts []KnowledgeBaseWithCounts `json:"items_with_counts,omitempty"` // Same items as Items, set when IncludeCounts is requested
+	Total           int64                     `json:"total"`
 }
\ No newline at end of file
@@ -0,0 +1,78 @@
+package sqlite
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/ncruces/go-sqlite3"
+)
+
+// foldFunctionName is the SQL function registered on every connection that
+// normalizes text for case- and accent-insensitive search
+const foldFunctionName = "kb_fold"
+
+// foldReplacements maps lowercase Latin letters with diacritics to their base letters
+var foldReplacements = func() map[rune]string {
+	groups := map[string]string{
+		"àáâãäåāăą":  "a",
+		"çćĉċč":      "c",
+		"ďđð":        "d",
+		"èéêëēĕėęě":  "e",
+		"ĝğġģ":       "g",
+		"ĥħ":         "h",
+		"ìíîïĩīĭįı":  "i",
+		"ĵ":          "j",
+		"ķ":          "k",
+		"ĺļľŀł":      "l",
+		"ñńņňŉ":      "n",
+		"òóôõöøōŏő":  "o",
+		"ŕŗř":        "r",
+		"śŝşš":       "s",
+		"ţťŧ":        "t",
+		"ùúûüũūŭůűų": "u",
+		"ŵ":          "w",
+		"ýÿŷ":        "y",
+		"źżž":        "z",
+		"ß":          "ss",
+		"æ":          "ae",
+		"œ":          "oe",
+		"þ":          "th",
+	}
+	m := make(map[rune]string)
+	for letters, base := range groups {
+		for _, r := range letters {
+			m[r] = base
+		}
+	}
+	return m
+}()
+
+// foldText lowercases s and strips diacritics so that "Café" and "cafe" compare equal.
+// Precomposed Latin letters are mapped to their base letter and combining marks
+// are dropped; other scripts are only lowercased.
+func foldText(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range strings.ToLower(s) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if base, ok := foldReplacements[r]; ok {
+			b.WriteString(base)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// registerFunctions registers the custom SQL functions used by the storage
+func registerFunctions(conn *sqlite3.Conn) error {
+	return conn.CreateFunction(foldFunctionName, 1, sqlite3.DETERMINISTIC|sqlite3.INNOCUOUS, func(ctx sqlite3.Context, arg ...sqlite3.Value) {
+		if arg[0].Type() == sqlite3.NULL {
+			ctx.ResultNull()
+			return
+		}
+		ctx.ResultText(foldText(arg[0].Text()))
+	})
+}
@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase"
+)
+
+func TestFoldText(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Cafe", "cafe"},
+		{"CAFÉ", "cafe"},
+		{"café", "cafe"},
+		{"cafe\u0301", "cafe"}, // decomposed e + combining acute
+		{"Straße", "strasse"},
+		{"Ærøskøbing", "aeroskobing"},
+		{"Łódź", "lodz"},
+		{"Привет", "привет"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, foldText(tt.input))
+		})
+	}
+}
+
+func TestStorage_ListSearchFolding(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	for _, req := range []knowledgebase.CreateRequest{
+		{Name: "Cafe Recipes"},
+		{Name: "Travel", Description: strPtr("Notes from a CAFÉ in Zürich")},
+		{Name: "ÉCOLE Normale"},
+		{Name: "Unrelated"},
+	} {
+		_, err := storage.Create(ctx, req)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name      string
+		search    string
+		wantNames []string
+	}{
+		{"accented query matches plain name", "café", []string{"Cafe Recipes", "Travel"}},
+		{"plain query matches accented description", "cafe", []string{"Cafe Recipes", "Travel"}},
+		{"mixed case", "cAfE", []string{"Cafe Recipes", "Travel"}},
+		{"non-ASCII uppercase", "école", []string{"ÉCOLE Normale"}},
+		{"accent in description only", "zurich", []string{"Travel"}},
+		{"no match", "bistro", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := storage.List(ctx, knowledgebase.ListRequest{Limit: 10, Search: tt.search, OrderBy: "name", OrderDir: "asc"})
+			require.NoError(t, err)
+
+			var names []string
+			for _, kb := range resp.Items {
+				names = append(names, kb.Name)
+			}
+			assert.Equal(t, tt.wantNames, names)
+			assert.Equal(t, int64(len(tt.wantNames)), resp.Total)
+		})
+	}
+}
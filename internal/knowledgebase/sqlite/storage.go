@@ -5,22 +5,80 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"strings"
-
-	_ "github.com/ncruces/go-sqlite3/driver"
-	_ "github.com/ncruces/go-sqlite3/embed"
+	"time"
 
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/logging"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/sqlitedb"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/tags"
 )
 
 // Storage implements the knowledgebase.Storage interface using SQLite
 type Storage struct {
-	db *sql.DB
+	db                *sql.DB
+	logger            *slog.Logger
+	normalizeTags     bool
+	retry             sqlitedb.RetryPolicy
+	idempotencyWindow time.Duration
+}
+
+// Option configures a Storage
+type Option func(*Storage)
+
+// WithLogger sets the logger used for database lifecycle and maintenance events
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Storage) {
+		s.logger = logging.OrDiscard(logger)
+	}
+}
+
+// WithTagNormalization makes Create, Update and List tag filters trim,
+// lowercase and deduplicate tags. Without it tags are stored verbatim.
+func WithTagNormalization(enabled bool) Option {
+	return func(s *Storage) {
+		s.normalizeTags = enabled
+	}
+}
+
+// normalizedTags applies tag normalization when it is enabled
+func (s *Storage) normalizedTags(t []string) []string {
+	if !s.normalizeTags {
+		return t
+	}
+	return tags.Normalize(t)
+}
+
+// WithRetry sets how writes are retried when another connection holds the
+// database lock. The default is sqlitedb.DefaultRetryPolicy.
+func WithRetry(policy sqlitedb.RetryPolicy) Option {
+	return func(s *Storage) {
+		s.retry = policy
+	}
+}
+
+// WithIdempotencyWindow sets how long Create remembers an idempotency key. A
+// key older than window is forgotten and creates a new knowledge base; 0
+// keeps keys forever. The default is sqlitedb.DefaultIdempotencyWindow.
+func WithIdempotencyWindow(window time.Duration) Option {
+	return func(s *Storage) {
+		s.idempotencyWindow = window
+	}
+}
+
+// withRetry runs fn under the retry policy and logs writes that stay busy
+func (s *Storage) withRetry(ctx context.Context, fn func() error) error {
+	err := sqlitedb.Retry(ctx, s.retry, fn)
+	if sqlitedb.IsBusy(err) {
+		s.logger.Warn("database still locked after retries", "storage", "knowledgebase", "retries", s.retry.MaxRetries, "error", err)
+	}
+	return err
 }
 
 // NewStorage creates a new SQLite storage instance
-func NewStorage(dbPath string) (*Storage, error) {
-	db, err := sql.Open("sqlite3", dbPath)
+func NewStorage(dbPath string, opts ...Option) (*Storage, error) {
+	db, err := sqlitedb.Open(dbPath, registerFunctions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -29,7 +87,13 @@ func NewStorage(dbPath string) (*Storage, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &Storage{db: db}, nil
+	s := &Storage{db: db, logger: logging.Discard(), retry: sqlitedb.DefaultRetryPolicy(), idempotencyWindow: sqlitedb.DefaultIdempotencyWindow}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.logger.Debug("opened database", "storage", "knowledgebase", "dsn", dbPath)
+	return s, nil
 }
 
 // Close closes the database connection
@@ -40,41 +104,75 @@ func (s *Storage) Close() error {
 	return nil
 }
 
-// Create creates a new knowledge base
+// Create creates a new knowledge base. With an idempotency key that an earlier
+// Create used within the idempotency window, the knowledge base that call
+// created is returned and req is otherwise ignored.
 func (s *Storage) Create(ctx context.Context, req knowledgebase.CreateRequest) (*knowledgebase.KnowledgeBase, error) {
+	var result *knowledgebase.KnowledgeBase
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.create(ctx, req)
+		return err
+	})
+	return result, err
+}
+
+// create makes a single attempt at Create
+func (s *Storage) create(ctx context.Context, req knowledgebase.CreateRequest) (*knowledgebase.KnowledgeBase, error) {
+	req.Tags = s.normalizedTags(req.Tags)
+
 	tagsJSON, err := json.Marshal(req.Tags)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal tags: %w", err)
 	}
 
 	query := `
-		INSERT INTO knowledge_base (name, description, tags)
-		VALUES (?, ?, ?)
+		INSERT INTO knowledge_base (name, description, tags, source)
+		VALUES (?, ?, ?, ?)
 	`
 
-	result, err := s.db.ExecContext(ctx, query, req.Name, req.Description, string(tagsJSON))
+	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create knowledge base: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	id, err := result.LastInsertId()
+	id, replayed, err := sqlitedb.CreateOnce(ctx, tx, sqlitedb.IdempotencyKnowledgeBases, req.IdempotencyKey, s.idempotencyWindow, func() (int64, error) {
+		result, err := tx.ExecContext(ctx, query, req.Name, req.Description, string(tagsJSON), req.Source)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create knowledge base: %w", err)
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get last insert ID: %w", err)
+		}
+		return id, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get last insert ID: %w", err)
+		return nil, err
 	}
 
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if replayed {
+		s.logger.Info("returned knowledge base for repeated idempotency key", "knowledge_base_id", id)
+	}
 	return s.Get(ctx, id)
 }
 
 // Get retrieves a knowledge base by ID
 func (s *Storage) Get(ctx context.Context, id int64) (*knowledgebase.KnowledgeBase, error) {
 	query := `
-		SELECT id, name, description, tags, created_at, updated_at
+		SELECT id, name, description, tags, source, created_at, updated_at
 		FROM knowledge_base
 		WHERE id = ?
 	`
 
 	var kb knowledgebase.KnowledgeBase
-	var description sql.NullString
+	var description, source sql.NullString
 	var tagsJSON string
 
 	err := s.db.QueryRowContext(ctx, query, id).Scan(
@@ -82,12 +180,13 @@ func (s *Storage) Get(ctx context.Context, id int64) (*knowledgebase.KnowledgeBa
 		&kb.Name,
 		&description,
 		&tagsJSON,
+		&source,
 		&kb.CreatedAt,
 		&kb.UpdatedAt,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("knowledge base not found: %d", id)
+			return nil, fmt.Errorf("%w: %d", knowledgebase.ErrNotFound, id)
 		}
 		return nil, fmt.Errorf("failed to get knowledge base: %w", err)
 	}
@@ -95,6 +194,9 @@ func (s *Storage) Get(ctx context.Context, id int64) (*knowledgebase.KnowledgeBa
 	if description.Valid {
 		kb.Description = &description.String
 	}
+	if source.Valid {
+		kb.Source = &source.String
+	}
 
 	if err := json.Unmarshal([]byte(tagsJSON), &kb.Tags); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal tags: %w", err)
@@ -105,6 +207,19 @@ func (s *Storage) Get(ctx context.Context, id int64) (*knowledgebase.KnowledgeBa
 
 // Update updates an existing knowledge base
 func (s *Storage) Update(ctx context.Context, id int64, req knowledgebase.UpdateRequest) (*knowledgebase.KnowledgeBase, error) {
+	var result *knowledgebase.KnowledgeBase
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.update(ctx, id, req)
+		return err
+	})
+	return result, err
+}
+
+// update makes a single attempt at Update
+func (s *Storage) update(ctx context.Context, id int64, req knowledgebase.UpdateRequest) (*knowledgebase.KnowledgeBase, error) {
+	req.Tags = s.normalizedTags(req.Tags)
+
 	// Build dynamic update query
 	var setClauses []string
 	var args []interface{}
@@ -151,7 +266,7 @@ func (s *Storage) Update(ctx context.Context, id int64, req knowledgebase.Update
 	}
 
 	if rowsAffected == 0 {
-		return nil, fmt.Errorf("knowledge base not found: %d", id)
+		return nil, fmt.Errorf("%w: %d", knowledgebase.ErrNotFound, id)
 	}
 
 	return s.Get(ctx, id)
@@ -159,9 +274,27 @@ func (s *Storage) Update(ctx context.Context, id int64, req knowledgebase.Update
 
 // Delete deletes a knowledge base by ID
 func (s *Storage) Delete(ctx context.Context, id int64) error {
+	return s.withRetry(ctx, func() error {
+		return s.delete(ctx, id)
+	})
+}
+
+// delete makes a single attempt at Delete
+func (s *Storage) delete(ctx context.Context, id int64) error {
+	linked, err := s.hasNoteLinks(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	query := "DELETE FROM knowledge_base WHERE id = ?"
 
-	result, err := s.db.ExecContext(ctx, query, id)
+	result, err := tx.ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete knowledge base: %w", err)
 	}
@@ -172,7 +305,18 @@ func (s *Storage) Delete(ctx context.Context, id int64) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("knowledge base not found: %d", id)
+		return fmt.Errorf("%w: %d", knowledgebase.ErrNotFound, id)
+	}
+
+	// Notes of a deleted knowledge base become unassigned
+	if linked {
+		if _, err := tx.ExecContext(ctx, "UPDATE notes SET knowledge_base_id = NULL WHERE knowledge_base_id = ?", id); err != nil {
+			return fmt.Errorf("failed to unassign notes: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	return nil
@@ -180,43 +324,34 @@ func (s *Storage) Delete(ctx context.Context, id int64) error {
 
 // List lists knowledge bases with pagination and filtering
 func (s *Storage) List(ctx context.Context, req knowledgebase.ListRequest) (*knowledgebase.ListResponse, error) {
-	// Build query
-	var whereClauses []string
-	var args []interface{}
-
-	if req.Search != "" {
-		whereClauses = append(whereClauses, "(name LIKE ? OR description LIKE ?)")
-		searchPattern := "%" + req.Search + "%"
-		args = append(args, searchPattern, searchPattern)
+	orderClause, err := buildOrderClause(req.OrderBy, req.OrderDir)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(req.Tags) > 0 {
-		for _, tag := range req.Tags {
-			whereClauses = append(whereClauses, "tags LIKE ?")
-			args = append(args, "%\""+tag+"\"%")
-		}
+	// Build query
+	fromClause, whereClause, args, ranked, err := s.listFilter(ctx, req)
+	if err != nil {
+		return nil, err
 	}
-
-	whereClause := ""
-	if len(whereClauses) > 0 {
-		whereClause = "WHERE " + strings.Join(whereClauses, " AND ")
+	if ranked && req.OrderBy == "" {
+		orderClause = "ORDER BY fts_rank, id"
 	}
 
 	// Get total count
-	countQuery := "SELECT COUNT(*) FROM knowledge_base " + whereClause
-	var total int64
-	if err := s.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
-		return nil, fmt.Errorf("failed to count knowledge bases: %w", err)
+	total, err := s.countKnowledgeBases(ctx, fromClause, whereClause, args)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get items
 	query := fmt.Sprintf(`
-		SELECT id, name, description, tags, created_at, updated_at
-		FROM knowledge_base
+		SELECT id, name, description, tags, source, created_at, updated_at
+		FROM %s
+		%s
 		%s
-		ORDER BY created_at DESC
 		LIMIT ? OFFSET ?
-	`, whereClause)
+	`, fromClause, whereClause, orderClause)
 
 	args = append(args, req.Limit, req.Offset)
 
@@ -229,7 +364,7 @@ func (s *Storage) List(ctx context.Context, req knowledgebase.ListRequest) (*kno
 	var items []knowledgebase.KnowledgeBase
 	for rows.Next() {
 		var kb knowledgebase.KnowledgeBase
-		var description sql.NullString
+		var description, source sql.NullString
 		var tagsJSON string
 
 		if err := rows.Scan(
@@ -237,6 +372,7 @@ func (s *Storage) List(ctx context.Context, req knowledgebase.ListRequest) (*kno
 			&kb.Name,
 			&description,
 			&tagsJSON,
+			&source,
 			&kb.CreatedAt,
 			&kb.UpdatedAt,
 		); err != nil {
@@ -246,6 +382,9 @@ func (s *Storage) List(ctx context.Context, req knowledgebase.ListRequest) (*kno
 		if description.Valid {
 			kb.Description = &description.String
 		}
+		if source.Valid {
+			kb.Source = &source.String
+		}
 
 		if err := json.Unmarshal([]byte(tagsJSON), &kb.Tags); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal tags: %w", err)
@@ -258,8 +397,215 @@ func (s *Storage) List(ctx context.Context, req knowledgebase.ListRequest) (*kno
 		return nil, fmt.Errorf("error iterating rows: %w", err)
 	}
 
-	return &knowledgebase.ListResponse{
+	response := &knowledgebase.ListResponse{
 		Items: items,
 		Total: total,
-	}, nil
+	}
+
+	if req.IncludeCounts {
+		response.ItemsWithCounts, err = s.withCounts(ctx, items)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+// Count returns the number of knowledge bases List would find for req,
+// ignoring its pagination and ordering
+func (s *Storage) Count(ctx context.Context, req knowledgebase.ListRequest) (int64, error) {
+	fromClause, whereClause, args, _, err := s.listFilter(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	return s.countKnowledgeBases(ctx, fromClause, whereClause, args)
+}
+
+// listFilter builds the FROM and WHERE clauses and their arguments for the
+// request filters of List and Count. ranked reports that the search joined
+// the full-text index, whose fts_rank column can order the results.
+func (s *Storage) listFilter(ctx context.Context, req knowledgebase.ListRequest) (fromClause, whereClause string, args []interface{}, ranked bool, err error) {
+	req.Tags = s.normalizedTags(req.Tags)
+
+	fromClause = "knowledge_base"
+	var whereClauses []string
+
+	if req.Search != "" {
+		useFTS, err := s.hasFTS(ctx)
+		if err != nil {
+			return "", "", nil, false, err
+		}
+
+		if query := ftsQuery(req.Search); useFTS && query != "" {
+			// Ranked full-text search; unicode61 folds case and accents
+			fromClause = `knowledge_base JOIN (
+				SELECT rowid AS fts_id, rank AS fts_rank
+				FROM knowledge_base_fts
+				WHERE knowledge_base_fts MATCH ?
+			) ON fts_id = knowledge_base.id`
+			args = append(args, query)
+			ranked = true
+		} else {
+			// Both sides are folded so matching ignores case and accents
+			whereClauses = append(whereClauses, fmt.Sprintf("(%[1]s(name) LIKE ? OR %[1]s(description) LIKE ?)", foldFunctionName))
+			searchPattern := "%" + foldText(req.Search) + "%"
+			args = append(args, searchPattern, searchPattern)
+		}
+	}
+
+	if len(req.Tags) > 0 {
+		for _, tag := range req.Tags {
+			whereClauses = append(whereClauses, "tags LIKE ?")
+			args = append(args, "%\""+tag+"\"%")
+		}
+	}
+
+	if req.Source != "" {
+		whereClauses = append(whereClauses, "source = ?")
+		args = append(args, req.Source)
+	}
+
+	if len(whereClauses) > 0 {
+		whereClause = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+	return fromClause, whereClause, args, ranked, nil
+}
+
+// countKnowledgeBases counts the knowledge bases matching the clauses
+func (s *Storage) countKnowledgeBases(ctx context.Context, fromClause, whereClause string, args []interface{}) (int64, error) {
+	var total int64
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+fromClause+" "+whereClause, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count knowledge bases: %w", err)
+	}
+	return total, nil
+}
+
+// withCounts pairs each knowledge base with its note and connection counts. A
+// connection counts towards a knowledge base when either end is one of its
+// notes. Schemas without the notes table report zero counts.
+func (s *Storage) withCounts(ctx context.Context, items []knowledgebase.KnowledgeBase) ([]knowledgebase.KnowledgeBaseWithCounts, error) {
+	result := make([]knowledgebase.KnowledgeBaseWithCounts, len(items))
+	for i, kb := range items {
+		result[i].KnowledgeBase = kb
+	}
+	if len(items) == 0 {
+		return result, nil
+	}
+
+	linked, err := s.hasNoteLinks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !linked {
+		return result, nil
+	}
+
+	placeholders := make([]string, len(items))
+	args := make([]interface{}, len(items))
+	index := make(map[int64]int, len(items))
+	for i, kb := range items {
+		placeholders[i] = "?"
+		args[i] = kb.ID
+		index[kb.ID] = i
+	}
+
+	query := fmt.Sprintf(`
+		SELECT kb.id,
+			(SELECT COUNT(*) FROM notes n WHERE n.knowledge_base_id = kb.id),
+			(SELECT COUNT(*) FROM connections c
+				WHERE c.from_note_id IN (SELECT id FROM notes WHERE knowledge_base_id = kb.id)
+					OR c.to_note_id IN (SELECT id FROM notes WHERE knowledge_base_id = kb.id))
+		FROM knowledge_base kb
+		WHERE kb.id IN (%s)
+	`, strings.Join(placeholders, ", "))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count knowledge base contents: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, notes, connections int64
+		if err := rows.Scan(&id, &notes, &connections); err != nil {
+			return nil, fmt.Errorf("failed to scan knowledge base counts: %w", err)
+		}
+		if i, ok := index[id]; ok {
+			result[i].NoteCount = notes
+			result[i].ConnectionCount = connections
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// hasNoteLinks reports whether the database has notes linked through
+// knowledge_base_id and a connections table. Knowledge base storage can be
+// opened on a schema without them, as in the package tests.
+func (s *Storage) hasNoteLinks(ctx context.Context) (bool, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx, `
+		SELECT (SELECT COUNT(*) FROM pragma_table_info('notes') WHERE name = 'knowledge_base_id')
+			* (SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'connections')
+	`).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check note links: %w", err)
+	}
+	return count > 0, nil
+}
+
+// orderByColumns whitelists the sortable columns so user input never reaches the SQL text
+var orderByColumns = map[string]string{
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+	"name":       "name COLLATE NOCASE",
+}
+
+// buildOrderClause builds the ORDER BY clause for List, defaulting to newest first
+func buildOrderClause(orderBy, orderDir string) (string, error) {
+	if orderBy == "" {
+		orderBy = "created_at"
+	}
+	column, ok := orderByColumns[orderBy]
+	if !ok {
+		return "", fmt.Errorf("invalid order_by: %s. Valid values are: %v", orderBy, knowledgebase.ValidOrderByFields())
+	}
+
+	direction := "DESC"
+	switch strings.ToLower(orderDir) {
+	case "", "desc":
+	case "asc":
+		direction = "ASC"
+	default:
+		return "", fmt.Errorf("invalid order_dir: %s. Valid values are: asc, desc", orderDir)
+	}
+
+	return fmt.Sprintf("ORDER BY %s %s, id %s", column, direction, direction), nil
+}
+
+// hasFTS reports whether the knowledge_base_fts table exists. Databases
+// migrated before it was introduced fall back to LIKE search.
+func (s *Storage) hasFTS(ctx context.Context) (bool, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'knowledge_base_fts'").Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check full-text search table: %w", err)
+	}
+	return count > 0, nil
+}
+
+// ftsQuery turns free text into an FTS5 query that matches every word as a
+// prefix. Words are quoted so user input cannot inject FTS5 syntax.
+func ftsQuery(search string) string {
+	words := strings.Fields(search)
+	for i, word := range words {
+		words[i] = `"` + strings.ReplaceAll(word, `"`, `""`) + `"*`
+	}
+	return strings.Join(words, " ")
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/migrations"
 )
 
 func TestStorage(t *testing.T) {
@@ -45,12 +46,14 @@ func TestStorage(t *testing.T) {
 					Name:        "Test Knowledge Base",
 					Description: strPtr("Test description"),
 					Tags:        []string{"tag1", "tag2"},
+					Source:      strPtr("research-agent"),
 				},
 				wantErr: false,
 				validate: func(t *testing.T, kb *knowledgebase.KnowledgeBase) {
 					assert.Equal(t, "Test Knowledge Base", kb.Name)
 					assert.Equal(t, "Test description", *kb.Description)
 					assert.Equal(t, []string{"tag1", "tag2"}, kb.Tags)
+					assert.Equal(t, "research-agent", *kb.Source)
 					assert.False(t, kb.CreatedAt.IsZero())
 					assert.False(t, kb.UpdatedAt.IsZero())
 				},
@@ -64,6 +67,7 @@ func TestStorage(t *testing.T) {
 				validate: func(t *testing.T, kb *knowledgebase.KnowledgeBase) {
 					assert.Equal(t, "Minimal Knowledge Base", kb.Name)
 					assert.Nil(t, kb.Description)
+					assert.Nil(t, kb.Source)
 					assert.Empty(t, kb.Tags)
 				},
 			},
@@ -364,6 +368,7 @@ func runTestMigrations(db *sql.DB) error {
 			name TEXT NOT NULL,
 			description TEXT,
 			tags TEXT,
+			source TEXT,
 			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
 		)
@@ -402,3 +407,409 @@ func runTestMigrations(db *sql.DB) error {
 func strPtr(s string) *string {
 	return &s
 }
+
+// newTestStorage creates a storage instance backed by a fresh migrated database
+func newTestStorage(t *testing.T, opts ...Option) *Storage {
+	t.Helper()
+
+	tempFile, err := os.CreateTemp("", "test-*.db")
+	require.NoError(t, err)
+	tempFile.Close()
+	t.Cleanup(func() { os.Remove(tempFile.Name()) })
+
+	storage, err := NewStorage(tempFile.Name(), opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { storage.Close() })
+
+	err = migrations.NewMigrationRunner(tempFile.Name()).RunMigrations()
+	require.NoError(t, err)
+
+	return storage
+}
+
+func TestStorage_ListOrdering(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	// Insert with explicit timestamps so created_at ordering is deterministic
+	for i, name := range []string{"beta", "Alpha", "gamma"} {
+		_, err := storage.db.ExecContext(ctx,
+			"INSERT INTO knowledge_base (name, tags, created_at, updated_at) VALUES (?, '[]', ?, ?)",
+			name, fmt.Sprintf("2024-01-0%d 00:00:00", i+1), fmt.Sprintf("2024-02-0%d 00:00:00", 3-i))
+		require.NoError(t, err)
+	}
+
+	names := func(resp *knowledgebase.ListResponse) []string {
+		var result []string
+		for _, kb := range resp.Items {
+			result = append(result, kb.Name)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name      string
+		req       knowledgebase.ListRequest
+		wantErr   bool
+		wantNames []string
+	}{
+		{
+			name:      "default is newest first",
+			req:       knowledgebase.ListRequest{Limit: 10},
+			wantNames: []string{"gamma", "Alpha", "beta"},
+		},
+		{
+			name:      "name ascending is case-insensitive",
+			req:       knowledgebase.ListRequest{Limit: 10, OrderBy: "name", OrderDir: "asc"},
+			wantNames: []string{"Alpha", "beta", "gamma"},
+		},
+		{
+			name:      "name descending",
+			req:       knowledgebase.ListRequest{Limit: 10, OrderBy: "name", OrderDir: "desc"},
+			wantNames: []string{"gamma", "beta", "Alpha"},
+		},
+		{
+			name:      "updated_at ascending",
+			req:       knowledgebase.ListRequest{Limit: 10, OrderBy: "updated_at", OrderDir: "asc"},
+			wantNames: []string{"gamma", "Alpha", "beta"},
+		},
+		{
+			name:    "invalid order_by",
+			req:     knowledgebase.ListRequest{Limit: 10, OrderBy: "name; DROP TABLE knowledge_base"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid order_dir",
+			req:     knowledgebase.ListRequest{Limit: 10, OrderDir: "sideways"},
+			wantErr: true,
+		},
+		{
+			name:    "injected order_dir",
+			req:     knowledgebase.ListRequest{Limit: 10, OrderBy: "name", OrderDir: "asc; DROP TABLE knowledge_base; --"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := storage.List(ctx, tt.req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNames, names(resp))
+		})
+	}
+
+	var count int
+	require.NoError(t, storage.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM knowledge_base").Scan(&count))
+	assert.Equal(t, 3, count)
+}
+
+func TestStorage_ListFTS(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	useFTS, err := storage.hasFTS(ctx)
+	require.NoError(t, err)
+	require.True(t, useFTS)
+
+	graphs, err := storage.Create(ctx, knowledgebase.CreateRequest{
+		Name:        "Graph Theory",
+		Description: strPtr("Graphs, graph algorithms and graph databases"),
+	})
+	require.NoError(t, err)
+	_, err = storage.Create(ctx, knowledgebase.CreateRequest{
+		Name:        "Databases",
+		Description: strPtr("Relational systems, with a note on graph stores"),
+	})
+	require.NoError(t, err)
+	_, err = storage.Create(ctx, knowledgebase.CreateRequest{Name: "Cooking"})
+	require.NoError(t, err)
+
+	search := func(t *testing.T, term string) []string {
+		resp, err := storage.List(ctx, knowledgebase.ListRequest{Limit: 10, Search: term})
+		require.NoError(t, err)
+		var names []string
+		for _, kb := range resp.Items {
+			names = append(names, kb.Name)
+		}
+		return names
+	}
+
+	t.Run("ranks by relevance", func(t *testing.T) {
+		assert.Equal(t, []string{"Graph Theory", "Databases"}, search(t, "graph"))
+	})
+
+	t.Run("matches word prefixes", func(t *testing.T) {
+		assert.Equal(t, []string{"Databases"}, search(t, "relat"))
+	})
+
+	t.Run("all words must match", func(t *testing.T) {
+		assert.Equal(t, []string{"Databases"}, search(t, "graph relational"))
+	})
+
+	t.Run("FTS syntax in input is treated literally", func(t *testing.T) {
+		assert.Empty(t, search(t, `graph" OR "cooking`))
+	})
+
+	t.Run("updates are reindexed", func(t *testing.T) {
+		_, err := storage.Update(ctx, graphs.ID, knowledgebase.UpdateRequest{Name: strPtr("Network Science")})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Network Science"}, search(t, "network"))
+		assert.Empty(t, search(t, "theory"))
+	})
+
+	t.Run("deletes are removed from the index", func(t *testing.T) {
+		require.NoError(t, storage.Delete(ctx, graphs.ID))
+		assert.Equal(t, []string{"Databases"}, search(t, "graph"))
+	})
+}
+
+func TestStorage_TagNormalization(t *testing.T) {
+	ctx := context.Background()
+	messy := []string{" Go", "go", "GO ", "", "Machine Learning", "  "}
+
+	t.Run("enabled", func(t *testing.T) {
+		storage := newTestStorage(t, WithTagNormalization(true))
+
+		kb, err := storage.Create(ctx, knowledgebase.CreateRequest{Name: "Messy", Tags: messy})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"go", "machine learning"}, kb.Tags)
+
+		updated, err := storage.Update(ctx, kb.ID, knowledgebase.UpdateRequest{Tags: []string{"Research ", "research", "AI"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"research", "ai"}, updated.Tags)
+
+		// Filters are normalized the same way
+		resp, err := storage.List(ctx, knowledgebase.ListRequest{Limit: 10, Tags: []string{" RESEARCH"}})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), resp.Total)
+	})
+
+	t.Run("disabled keeps tags verbatim", func(t *testing.T) {
+		storage := newTestStorage(t)
+
+		kb, err := storage.Create(ctx, knowledgebase.CreateRequest{Name: "Messy", Tags: messy})
+		require.NoError(t, err)
+		assert.Equal(t, messy, kb.Tags)
+	})
+}
+
+func TestStorage_ListSource(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	for _, req := range []knowledgebase.CreateRequest{
+		{Name: "Research", Source: strPtr("research-agent")},
+		{Name: "Planning", Source: strPtr("planner")},
+		{Name: "Manual"},
+	} {
+		_, err := storage.Create(ctx, req)
+		require.NoError(t, err)
+	}
+
+	resp, err := storage.List(ctx, knowledgebase.ListRequest{Limit: 10, Source: "research-agent"})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, int64(1), resp.Total)
+	assert.Equal(t, "Research", resp.Items[0].Name)
+	assert.Equal(t, "research-agent", *resp.Items[0].Source)
+
+	resp, err = storage.List(ctx, knowledgebase.ListRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), resp.Total)
+
+	resp, err = storage.List(ctx, knowledgebase.ListRequest{Limit: 10, Source: "unknown"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Items)
+}
+
+func TestStorage_Count(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	for _, req := range []knowledgebase.CreateRequest{
+		{Name: "Graph Theory", Description: strPtr("Graphs and graph algorithms"), Tags: []string{"math"}, Source: strPtr("agent")},
+		{Name: "Databases", Description: strPtr("Relational systems and graph stores"), Tags: []string{"programming"}},
+		{Name: "Cooking", Tags: []string{"hobby"}, Source: strPtr("agent")},
+	} {
+		_, err := storage.Create(ctx, req)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name string
+		req  knowledgebase.ListRequest
+		want int64
+	}{
+		{name: "no filter", req: knowledgebase.ListRequest{}, want: 3},
+		{name: "search", req: knowledgebase.ListRequest{Search: "graph"}, want: 2},
+		{name: "tags", req: knowledgebase.ListRequest{Tags: []string{"math"}}, want: 1},
+		{name: "source", req: knowledgebase.ListRequest{Source: "agent"}, want: 2},
+		{name: "combined", req: knowledgebase.ListRequest{Search: "graph", Source: "agent"}, want: 1},
+		{name: "no match", req: knowledgebase.ListRequest{Search: "astronomy"}, want: 0},
+		// Paging does not change the total
+		{name: "limit and offset ignored", req: knowledgebase.ListRequest{Limit: 1, Offset: 2}, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, err := storage.Count(ctx, tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, count)
+
+			listReq := tt.req
+			listReq.Limit = 1
+			resp, err := storage.List(ctx, listReq)
+			require.NoError(t, err)
+			assert.Equal(t, resp.Total, count)
+		})
+	}
+}
+
+func TestStorage_ListIncludeCounts(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	research, err := storage.Create(ctx, knowledgebase.CreateRequest{Name: "Research"})
+	require.NoError(t, err)
+	empty, err := storage.Create(ctx, knowledgebase.CreateRequest{Name: "Empty"})
+	require.NoError(t, err)
+
+	t.Run("counts omitted by default", func(t *testing.T) {
+		resp, err := storage.List(ctx, knowledgebase.ListRequest{Limit: 10})
+		require.NoError(t, err)
+		assert.Len(t, resp.Items, 2)
+		assert.Nil(t, resp.ItemsWithCounts)
+	})
+
+	t.Run("no assigned notes count as zero", func(t *testing.T) {
+		resp, err := storage.List(ctx, knowledgebase.ListRequest{Limit: 10, IncludeCounts: true})
+		require.NoError(t, err)
+		require.Len(t, resp.ItemsWithCounts, 2)
+		for _, item := range resp.ItemsWithCounts {
+			assert.Equal(t, int64(0), item.NoteCount)
+			assert.Equal(t, int64(0), item.ConnectionCount)
+		}
+	})
+
+	var noteIDs []int64
+	for i := 0; i < 3; i++ {
+		result, err := storage.db.Exec("INSERT INTO notes (title, content, type, knowledge_base_id) VALUES (?, ?, 'text', ?)",
+			fmt.Sprintf("Note %d", i), "Content", research.ID)
+		require.NoError(t, err)
+		id, err := result.LastInsertId()
+		require.NoError(t, err)
+		noteIDs = append(noteIDs, id)
+	}
+	for _, pair := range [][2]int64{{noteIDs[0], noteIDs[1]}, {noteIDs[1], noteIDs[2]}} {
+		_, err := storage.db.Exec("INSERT INTO connections (from_note_id, to_note_id, type) VALUES (?, ?, 'references')", pair[0], pair[1])
+		require.NoError(t, err)
+	}
+
+	t.Run("linked notes are counted", func(t *testing.T) {
+		resp, err := storage.List(ctx, knowledgebase.ListRequest{Limit: 10, IncludeCounts: true})
+		require.NoError(t, err)
+		require.Len(t, resp.ItemsWithCounts, 2)
+
+		counts := make(map[int64]knowledgebase.KnowledgeBaseWithCounts)
+		for i, item := range resp.ItemsWithCounts {
+			assert.Equal(t, resp.Items[i].ID, item.ID)
+			counts[item.ID] = item
+		}
+		assert.Equal(t, int64(3), counts[research.ID].NoteCount)
+		assert.Equal(t, int64(2), counts[research.ID].ConnectionCount)
+		assert.Equal(t, int64(0), counts[empty.ID].NoteCount)
+		assert.Equal(t, int64(0), counts[empty.ID].ConnectionCount)
+	})
+
+	t.Run("delete unassigns notes", func(t *testing.T) {
+		require.NoError(t, storage.Delete(ctx, research.ID))
+
+		var assigned int
+		require.NoError(t, storage.db.QueryRow("SELECT COUNT(*) FROM notes WHERE knowledge_base_id IS NOT NULL").Scan(&assigned))
+		assert.Equal(t, 0, assigned)
+	})
+}
+
+func TestStorage_CreateIdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+	storage := newTestStorage(t)
+
+	first, err := storage.Create(ctx, knowledgebase.CreateRequest{Name: "Research", IdempotencyKey: "retry-1"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		req     knowledgebase.CreateRequest
+		wantID  func(id int64) bool
+		wantErr string
+	}{
+		{
+			name:   "repeated key returns the first knowledge base",
+			req:    knowledgebase.CreateRequest{Name: "Research again", IdempotencyKey: "retry-1"},
+			wantID: func(id int64) bool { return id == first.ID },
+		},
+		{
+			name:   "another key creates a knowledge base",
+			req:    knowledgebase.CreateRequest{Name: "Other", IdempotencyKey: "retry-2"},
+			wantID: func(id int64) bool { return id != first.ID },
+		},
+		{
+			name:   "no key creates a knowledge base",
+			req:    knowledgebase.CreateRequest{Name: "Research"},
+			wantID: func(id int64) bool { return id != first.ID },
+		},
+		{
+			name:    "blank key",
+			req:     knowledgebase.CreateRequest{Name: "Blank", IdempotencyKey: "\t"},
+			wantErr: "idempotency key must not be blank",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := storage.Create(ctx, tt.req)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.wantID(got.ID), "unexpected ID %d", got.ID)
+		})
+	}
+
+	kb, err := storage.Get(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Research", kb.Name)
+}
+
+func TestStorage_NotFound(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	name := "Missing"
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{name: "get", call: func() error { _, err := storage.Get(ctx, 99999); return err }},
+		{name: "update", call: func() error {
+			_, err := storage.Update(ctx, 99999, knowledgebase.UpdateRequest{Name: &name})
+			return err
+		}},
+		{name: "delete", call: func() error { return storage.Delete(ctx, 99999) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			assert.ErrorIs(t, err, knowledgebase.ErrNotFound)
+			assert.EqualError(t, err, "knowledge base not found: 99999")
+		})
+	}
+}
@@ -22,4 +22,7 @@ type Storage interface {
 	
 	// List lists knowledge bases with pagination and filtering
 	List(ctx context.Context, req ListRequest) (*ListResponse, error)
+
+	// Count counts the knowledge bases List would find, without fetching them
+	Count(ctx context.Context, req ListRequest) (int64, error)
 }
\ No newline at end of file
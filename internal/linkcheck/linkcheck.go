@@ -0,0 +1,215 @@
+// Package linkcheck finds link notes whose URL no longer answers, so a
+// link-heavy knowledge base can be pruned or repaired.
+//
+// Each URL gets a HEAD request. Servers that refuse HEAD are asked again with
+// GET, and the body is discarded. A link is reachable when the final response,
+// after redirects, has a status below 400.
+package linkcheck
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/logging"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+const (
+	// DefaultTimeout is the time limit for each request when none is configured
+	DefaultTimeout = 10 * time.Second
+	// DefaultConcurrency is the number of requests in flight when none is configured
+	DefaultConcurrency = 8
+	// MaxConcurrency caps the requests in flight regardless of configuration
+	MaxConcurrency = 32
+
+	// pageSize is the number of link notes read per List call
+	pageSize = 100
+)
+
+// Notes lists notes; note.Storage implements it
+type Notes interface {
+	List(ctx context.Context, req note.ListNotesRequest) (*note.ListNotesResponse, error)
+}
+
+// HTTPClient sends requests; *http.Client implements it
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Checker checks the URLs of link notes
+type Checker struct {
+	notes  Notes
+	client HTTPClient
+	logger *slog.Logger
+}
+
+// Option configures a Checker
+type Option func(*Checker)
+
+// WithHTTPClient sets the client used for requests. The default is
+// http.DefaultClient, with time limits applied per request.
+func WithHTTPClient(client HTTPClient) Option {
+	return func(c *Checker) {
+		c.client = client
+	}
+}
+
+// WithLogger sets the logger used to report each finished check
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Checker) {
+		c.logger = logging.OrDiscard(logger)
+	}
+}
+
+// New creates a Checker for the link notes in notes
+func New(notes Notes, opts ...Option) *Checker {
+	c := &Checker{notes: notes, client: http.DefaultClient, logger: logging.Discard()}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// CheckLinks requests the URL of every link note and returns one status per
+// note, ordered by note ID. A failed request is reported in its status, not as
+// an error; the error is reserved for listing notes and cancellation.
+func (c *Checker) CheckLinks(ctx context.Context, opts note.CheckLinksOptions) ([]note.LinkStatus, error) {
+	if opts.Timeout < 0 {
+		return nil, fmt.Errorf("timeout must not be negative")
+	}
+	if opts.Concurrency < 0 {
+		return nil, fmt.Errorf("concurrency must not be negative")
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.Concurrency == 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+	if opts.Concurrency > MaxConcurrency {
+		opts.Concurrency = MaxConcurrency
+	}
+
+	links, err := c.linkNotes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	statuses := make([]note.LinkStatus, len(links))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Concurrency && w < len(links); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				statuses[i] = c.check(ctx, links[i], opts.Timeout)
+			}
+		}()
+	}
+
+feed:
+	for i := range links {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("link check cancelled: %w", err)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].NoteID < statuses[j].NoteID })
+
+	dead := 0
+	for _, status := range statuses {
+		if !status.Reachable {
+			dead++
+		}
+	}
+	c.logger.Info("checked links", "checked", len(statuses), "unreachable", dead, "duration", time.Since(start))
+
+	return statuses, nil
+}
+
+// linkNotes reads every link note a page at a time
+func (c *Checker) linkNotes(ctx context.Context) ([]note.Note, error) {
+	var links []note.Note
+	for offset := 0; ; offset += pageSize {
+		resp, err := c.notes.List(ctx, note.ListNotesRequest{
+			Type:     string(note.NoteTypeLink),
+			Limit:    pageSize,
+			Offset:   offset,
+			OrderBy:  "id",
+			OrderDir: "asc",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list link notes: %w", err)
+		}
+		links = append(links, resp.Items...)
+		if len(resp.Items) < pageSize || int64(len(links)) >= resp.Total {
+			return links, nil
+		}
+	}
+}
+
+// check requests the URL of one link note
+func (c *Checker) check(ctx context.Context, n note.Note, timeout time.Duration) note.LinkStatus {
+	url := strings.TrimSpace(n.Content)
+	status := note.LinkStatus{NoteID: n.ID, Title: n.Title, URL: url}
+
+	if err := note.ValidateURL(url); err != nil {
+		status.Error = err.Error()
+		return status
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	code, err := c.request(ctx, http.MethodHead, url)
+	if err == nil && (code == http.StatusMethodNotAllowed || code == http.StatusNotImplemented) {
+		code, err = c.request(ctx, http.MethodGet, url)
+	}
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+
+	status.StatusCode = code
+	status.Reachable = code < http.StatusBadRequest
+	if !status.Reachable {
+		status.Error = http.StatusText(code)
+	}
+	return status
+}
+
+// request sends one request and returns the response status code
+func (c *Checker) request(ctx context.Context, method, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Draining a little lets the connection be reused without reading a large page
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	return resp.StatusCode, nil
+}
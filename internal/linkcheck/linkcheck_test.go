@@ -0,0 +1,225 @@
+package linkcheck_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/linkcheck"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// fakeNotes pages through a fixed list of link notes
+type fakeNotes struct {
+	notes    []note.Note
+	err      error
+	requests []note.ListNotesRequest
+}
+
+func (f *fakeNotes) List(_ context.Context, req note.ListNotesRequest) (*note.ListNotesResponse, error) {
+	f.requests = append(f.requests, req)
+	if f.err != nil {
+		return nil, f.err
+	}
+	end := req.Offset + req.Limit
+	if end > len(f.notes) {
+		end = len(f.notes)
+	}
+	return &note.ListNotesResponse{Items: f.notes[req.Offset:end], Total: int64(len(f.notes))}, nil
+}
+
+// linkNotes returns one link note per URL, numbered from 1
+func linkNotes(urls ...string) []note.Note {
+	notes := make([]note.Note, len(urls))
+	for i, url := range urls {
+		notes[i] = note.Note{ID: int64(i + 1), Title: fmt.Sprintf("link %d", i+1), Content: url, Type: "link"}
+	}
+	return notes
+}
+
+// clientFunc adapts a function to linkcheck.HTTPClient
+type clientFunc func(req *http.Request) (*http.Response, error)
+
+func (f clientFunc) Do(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestChecker_CheckLinks(t *testing.T) {
+	ctx := context.Background()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) { http.NotFound(w, r) })
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) { http.Redirect(w, r, "/ok", http.StatusMovedPermanently) })
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, r *http.Request) { http.Redirect(w, r, "/missing", http.StatusFound) })
+	mux.HandleFunc("/get-only", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	closed := httptest.NewServer(mux)
+	closed.Close()
+
+	tests := []struct {
+		name          string
+		url           string
+		wantReachable bool
+		wantCode      int
+		wantErr       string
+	}{
+		{name: "reachable", url: server.URL + "/ok", wantReachable: true, wantCode: http.StatusOK},
+		{name: "not found", url: server.URL + "/missing", wantCode: http.StatusNotFound, wantErr: "Not Found"},
+		{name: "redirect to a live page", url: server.URL + "/moved", wantReachable: true, wantCode: http.StatusOK},
+		{name: "redirect to a dead page", url: server.URL + "/gone", wantCode: http.StatusNotFound},
+		{name: "head refused falls back to get", url: server.URL + "/get-only", wantReachable: true, wantCode: http.StatusOK},
+		{name: "surrounding whitespace", url: "  " + server.URL + "/ok\n", wantReachable: true, wantCode: http.StatusOK},
+		{name: "server down", url: closed.URL + "/ok", wantErr: "connection refused"},
+		{name: "timeout", url: server.URL + "/slow", wantErr: "deadline exceeded"},
+		{name: "not a url", url: "example.com/page", wantErr: "scheme must be http or https"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := linkcheck.New(&fakeNotes{notes: linkNotes(tt.url)})
+
+			statuses, err := checker.CheckLinks(ctx, note.CheckLinksOptions{Timeout: 200 * time.Millisecond})
+			require.NoError(t, err)
+			require.Len(t, statuses, 1)
+
+			status := statuses[0]
+			assert.Equal(t, int64(1), status.NoteID)
+			assert.Equal(t, "link 1", status.Title)
+			assert.Equal(t, strings.TrimSpace(tt.url), status.URL)
+			assert.Equal(t, tt.wantReachable, status.Reachable)
+			assert.Equal(t, tt.wantCode, status.StatusCode)
+			assert.Contains(t, status.Error, tt.wantErr)
+		})
+	}
+
+	t.Run("pages through every link note in id order", func(t *testing.T) {
+		urls := make([]string, 250)
+		for i := range urls {
+			urls[i] = server.URL + "/ok"
+		}
+		urls[137] = server.URL + "/missing"
+		notes := &fakeNotes{notes: linkNotes(urls...)}
+
+		statuses, err := linkcheck.New(notes).CheckLinks(ctx, note.CheckLinksOptions{})
+		require.NoError(t, err)
+		require.Len(t, statuses, 250)
+		for i, status := range statuses {
+			assert.Equal(t, int64(i+1), status.NoteID)
+			assert.Equal(t, i != 137, status.Reachable, status.NoteID)
+		}
+
+		require.Len(t, notes.requests, 3)
+		for _, req := range notes.requests {
+			assert.Equal(t, "link", req.Type)
+		}
+	})
+
+	t.Run("caps requests in flight", func(t *testing.T) {
+		var inFlight, peak int64
+		client := clientFunc(func(req *http.Request) (*http.Response, error) {
+			n := atomic.AddInt64(&inFlight, 1)
+			defer atomic.AddInt64(&inFlight, -1)
+			for {
+				old := atomic.LoadInt64(&peak)
+				if n <= old || atomic.CompareAndSwapInt64(&peak, old, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+		})
+
+		urls := make([]string, 20)
+		for i := range urls {
+			urls[i] = fmt.Sprintf("https://example.com/%d", i)
+		}
+
+		checker := linkcheck.New(&fakeNotes{notes: linkNotes(urls...)}, linkcheck.WithHTTPClient(client))
+		statuses, err := checker.CheckLinks(ctx, note.CheckLinksOptions{Concurrency: 3})
+		require.NoError(t, err)
+		assert.Len(t, statuses, 20)
+		assert.LessOrEqual(t, atomic.LoadInt64(&peak), int64(3))
+		assert.Greater(t, atomic.LoadInt64(&peak), int64(1))
+	})
+
+	t.Run("uses the injected client with HEAD", func(t *testing.T) {
+		var mu sync.Mutex
+		var methods []string
+		client := clientFunc(func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			methods = append(methods, req.Method)
+			mu.Unlock()
+			return nil, errors.New("offline")
+		})
+
+		checker := linkcheck.New(&fakeNotes{notes: linkNotes("https://example.com")}, linkcheck.WithHTTPClient(client))
+		statuses, err := checker.CheckLinks(ctx, note.CheckLinksOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{http.MethodHead}, methods)
+		assert.False(t, statuses[0].Reachable)
+		assert.Contains(t, statuses[0].Error, "offline")
+	})
+
+	t.Run("cancellation stops the check", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		var calls int64
+		client := clientFunc(func(req *http.Request) (*http.Response, error) {
+			if atomic.AddInt64(&calls, 1) == 1 {
+				cancel()
+			}
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		})
+
+		urls := make([]string, 50)
+		for i := range urls {
+			urls[i] = fmt.Sprintf("https://example.com/%d", i)
+		}
+
+		checker := linkcheck.New(&fakeNotes{notes: linkNotes(urls...)}, linkcheck.WithHTTPClient(client))
+		_, err := checker.CheckLinks(ctx, note.CheckLinksOptions{Concurrency: 2})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Less(t, atomic.LoadInt64(&calls), int64(50))
+	})
+
+	t.Run("no link notes", func(t *testing.T) {
+		statuses, err := linkcheck.New(&fakeNotes{}).CheckLinks(ctx, note.CheckLinksOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, statuses)
+	})
+
+	t.Run("listing fails", func(t *testing.T) {
+		_, err := linkcheck.New(&fakeNotes{err: errors.New("locked")}).CheckLinks(ctx, note.CheckLinksOptions{})
+		assert.ErrorContains(t, err, "failed to list link notes: locked")
+	})
+
+	t.Run("negative options", func(t *testing.T) {
+		checker := linkcheck.New(&fakeNotes{})
+		_, err := checker.CheckLinks(ctx, note.CheckLinksOptions{Timeout: -time.Second})
+		assert.ErrorContains(t, err, "timeout must not be negative")
+		_, err = checker.CheckLinks(ctx, note.CheckLinksOptions{Concurrency: -1})
+		assert.ErrorContains(t, err, "concurrency must not be negative")
+	})
+}
@@ -0,0 +1,116 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/linkcheck"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// maxTimeoutSeconds bounds the per-request timeout a caller can ask for
+const maxTimeoutSeconds = 60
+
+// NewDeadLinksHandler creates a new handler for checking the URLs of link notes
+func NewDeadLinksHandler(checker *linkcheck.Checker) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		opts := note.CheckLinksOptions{}
+
+		// Parse timeout_seconds
+		if raw, ok := arguments["timeout_seconds"]; ok {
+			seconds, err := mcpx.ParseInt(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid timeout_seconds: %w", err)
+			}
+			if seconds < 1 || seconds > maxTimeoutSeconds {
+				return nil, fmt.Errorf("timeout_seconds must be between 1 and %d", maxTimeoutSeconds)
+			}
+			opts.Timeout = time.Duration(seconds) * time.Second
+		}
+
+		// Parse concurrency
+		if raw, ok := arguments["concurrency"]; ok {
+			concurrency, err := mcpx.ParseInt(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid concurrency: %w", err)
+			}
+			if concurrency < 1 || concurrency > linkcheck.MaxConcurrency {
+				return nil, fmt.Errorf("concurrency must be between 1 and %d", linkcheck.MaxConcurrency)
+			}
+			opts.Concurrency = int(concurrency)
+		}
+
+		// Parse include_reachable
+		includeReachable := false
+		if raw, ok := arguments["include_reachable"]; ok {
+			includeReachable, ok = raw.(bool)
+			if !ok {
+				return nil, fmt.Errorf("include_reachable must be a boolean")
+			}
+		}
+
+		statuses, err := checker.CheckLinks(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check links: %w", err)
+		}
+
+		if len(statuses) == 0 {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.TextContent{
+						Type: "text",
+						Text: "No link notes found",
+					},
+				},
+			}, nil
+		}
+
+		dead := []note.LinkStatus{}
+		for _, status := range statuses {
+			if !status.Reachable {
+				dead = append(dead, status)
+			}
+		}
+
+		summary := fmt.Sprintf("Checked %d links, %d unreachable", len(statuses), len(dead))
+		if len(dead) == 0 && !includeReachable {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.TextContent{
+						Type: "text",
+						Text: summary,
+					},
+				},
+			}, nil
+		}
+
+		reported := dead
+		if includeReachable {
+			reported = statuses
+		}
+
+		jsonData, err := mcpx.MarshalJSON(reported)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("%s:\n\n%s", summary, string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,143 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/linkcheck"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/linkcheck/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// fakeNotes returns a fixed page of notes
+type fakeNotes struct {
+	notes []note.Note
+	err   error
+}
+
+func (f fakeNotes) List(context.Context, note.ListNotesRequest) (*note.ListNotesResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &note.ListNotesResponse{Items: f.notes, Total: int64(len(f.notes))}, nil
+}
+
+// statusClient answers every request with the status code mapped to its URL path
+type statusClient map[string]int
+
+func (c statusClient) Do(req *http.Request) (*http.Response, error) {
+	code, ok := c[req.URL.Path]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return &http.Response{StatusCode: code, Body: io.NopCloser(strings.NewReader(""))}, nil
+}
+
+func TestDeadLinksHandler(t *testing.T) {
+	links := []note.Note{
+		{ID: 1, Title: "Live", Content: "https://example.com/live", Type: "link"},
+		{ID: 2, Title: "Dead", Content: "https://example.com/dead", Type: "link"},
+	}
+	client := statusClient{"/live": http.StatusOK, "/dead": http.StatusNotFound}
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		notes       fakeNotes
+		wantErr     bool
+		wantContent string
+		notContent  string
+	}{
+		{
+			name:        "reports unreachable links only",
+			args:        map[string]interface{}{},
+			notes:       fakeNotes{notes: links},
+			wantContent: "Checked 2 links, 1 unreachable:",
+			notContent:  "example.com/live",
+		},
+		{
+			name:        "include reachable links",
+			args:        map[string]interface{}{"include_reachable": true, "timeout_seconds": float64(5), "concurrency": float64(2)},
+			notes:       fakeNotes{notes: links},
+			wantContent: `"url": "https://example.com/live"`,
+		},
+		{
+			name:        "all links reachable",
+			args:        map[string]interface{}{},
+			notes:       fakeNotes{notes: links[:1]},
+			wantContent: "Checked 1 links, 0 unreachable",
+			notContent:  "[",
+		},
+		{
+			name:        "no link notes",
+			args:        map[string]interface{}{},
+			notes:       fakeNotes{},
+			wantContent: "No link notes found",
+		},
+		{
+			name:        "timeout out of range",
+			args:        map[string]interface{}{"timeout_seconds": float64(61)},
+			wantErr:     true,
+			wantContent: "timeout_seconds must be between 1 and 60",
+		},
+		{
+			name:        "fractional concurrency",
+			args:        map[string]interface{}{"concurrency": float64(1.5)},
+			wantErr:     true,
+			wantContent: "invalid concurrency: 1.5 is not an integer",
+		},
+		{
+			name:        "concurrency out of range",
+			args:        map[string]interface{}{"concurrency": float64(33)},
+			wantErr:     true,
+			wantContent: "concurrency must be between 1 and 32",
+		},
+		{
+			name:        "include reachable not a boolean",
+			args:        map[string]interface{}{"include_reachable": "yes"},
+			wantErr:     true,
+			wantContent: "include_reachable must be a boolean",
+		},
+		{
+			name:        "listing fails",
+			args:        map[string]interface{}{},
+			notes:       fakeNotes{err: errors.New("locked")},
+			wantErr:     true,
+			wantContent: "failed to check links",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := mcp.NewDeadLinksHandler(linkcheck.New(tt.notes, linkcheck.WithHTTPClient(client)))
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+				return
+			}
+			require.NoError(t, err)
+			text := result.Content[0].(gomcp.TextContent).Text
+			assert.Contains(t, text, tt.wantContent)
+			if tt.notContent != "" {
+				assert.NotContains(t, text, tt.notContent)
+			}
+		})
+	}
+}
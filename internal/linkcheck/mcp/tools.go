@@ -0,0 +1,57 @@
+package mcp
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/linkcheck"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// RegisterTools registers the link check MCP tools with the server
+func RegisterTools(s *server.MCPServer, checker *linkcheck.Checker, middleware ...mcpx.Middleware) error {
+	tools := []struct {
+		name        string
+		description string
+		handler     server.ToolHandlerFunc
+		schema      mcp.ToolInputSchema
+	}{
+		{
+			name:        "check_dead_links",
+			description: "Request the URL of every link note and report the ones that are unreachable or answer with an error status",
+			handler:     NewDeadLinksHandler(checker),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"timeout_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "Time limit for each request in seconds (default: 10)",
+						"minimum":     1,
+						"maximum":     maxTimeoutSeconds,
+					},
+					"concurrency": map[string]interface{}{
+						"type":        "integer",
+						"description": "Number of requests in flight at once (default: 8)",
+						"minimum":     1,
+						"maximum":     linkcheck.MaxConcurrency,
+					},
+					"include_reachable": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Also list the links that answered (default: false)",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tool := range tools {
+		t := mcp.Tool{
+			Name:        tool.name,
+			Description: tool.description,
+			InputSchema: tool.schema,
+		}
+		s.AddTool(t, mcpx.Wrap(tool.name, tool.handler, middleware...))
+	}
+
+	return nil
+}
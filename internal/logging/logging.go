@@ -0,0 +1,138 @@
+// Package logging provides the structured logger plumbing shared by the MCP
+// tool registrations and the storages.
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+const (
+	// maxLoggedStringLen is the longest argument string logged verbatim
+	maxLoggedStringLen = 120
+
+	// maxLoggedItems is the largest argument array logged item by item
+	maxLoggedItems = 20
+)
+
+// redactedFields are argument names whose values are never logged, however
+// short, because they carry note or description bodies: directly, as CSV
+// rows, or inside graph bundles
+var redactedFields = map[string]bool{
+	"content":     true,
+	"description": true,
+	"text":        true,
+	"csv":         true,
+	"bundle":      true,
+	"old_bundle":  true,
+	"new_bundle":  true,
+}
+
+// Discard returns a logger that drops every record
+func Discard() *slog.Logger {
+	return slog.New(slog.DiscardHandler)
+}
+
+// OrDiscard returns logger, or a discarding logger when logger is nil
+func OrDiscard(logger *slog.Logger) *slog.Logger {
+	if logger == nil {
+		return Discard()
+	}
+	return logger
+}
+
+// Middleware returns an mcpx.Middleware that logs every tool call to logger
+func Middleware(logger *slog.Logger) mcpx.Middleware {
+	return func(tool string, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return WrapToolHandler(logger, tool, next)
+	}
+}
+
+// WrapToolHandler logs every invocation of handler with the tool name, an
+// argument summary, the duration and the error, if any
+func WrapToolHandler(logger *slog.Logger, tool string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	logger = OrDiscard(logger)
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := time.Now()
+		result, err := handler(ctx, req)
+
+		attrs := []slog.Attr{
+			slog.String("tool", tool),
+			slog.Any("args", SummarizeArguments(req.Params.Arguments)),
+			slog.Duration("duration", time.Since(start)),
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+			logger.LogAttrs(ctx, slog.LevelError, "tool call failed", attrs...)
+		} else {
+			logger.LogAttrs(ctx, slog.LevelInfo, "tool call", attrs...)
+		}
+
+		return result, err
+	}
+}
+
+// SummarizeArguments returns a copy of tool arguments that is safe to log.
+// Content fields are replaced by their length, long strings are truncated and
+// long arrays are replaced by their item count.
+func SummarizeArguments(arguments any) any {
+	return summarize("", arguments)
+}
+
+func summarize(key string, value any) any {
+	if redactedFields[key] && value != nil {
+		return redact(value)
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		summary := make(map[string]any, len(v))
+		for k, item := range v {
+			summary[k] = summarize(k, item)
+		}
+		return summary
+	case []any:
+		if len(v) > maxLoggedItems {
+			return fmt.Sprintf("[%d items]", len(v))
+		}
+		summary := make([]any, len(v))
+		for i, item := range v {
+			summary[i] = summarize(key, item)
+		}
+		return summary
+	case string:
+		if len(v) > maxLoggedStringLen {
+			// Cut on a rune boundary so the log stays valid UTF-8
+			cut := maxLoggedStringLen
+			for cut > 0 && !utf8.RuneStart(v[cut]) {
+				cut--
+			}
+			return fmt.Sprintf("%s... [%d bytes]", v[:cut], len(v))
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// redact replaces a redacted value by its size. Bundles may arrive as objects
+// instead of JSON strings, so those are measured in their JSON encoding.
+func redact(value any) string {
+	if v, ok := value.(string); ok {
+		return fmt.Sprintf("[redacted, %d bytes]", len(v))
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "[redacted]"
+	}
+	return fmt.Sprintf("[redacted, %d bytes]", len(data))
+}
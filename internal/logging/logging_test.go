@@ -0,0 +1,162 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/logging"
+)
+
+func TestSummarizeArguments(t *testing.T) {
+	longTitle := strings.Repeat("é", 100)
+	noteIDs := make([]any, 25)
+	for i := range noteIDs {
+		noteIDs[i] = float64(i)
+	}
+
+	tests := []struct {
+		name string
+		args any
+		want any
+	}{
+		{
+			name: "short values are kept",
+			args: map[string]any{"id": float64(1), "title": "Hello", "tags": []any{"a", "b"}},
+			want: map[string]any{"id": float64(1), "title": "Hello", "tags": []any{"a", "b"}},
+		},
+		{
+			name: "content fields are redacted",
+			args: map[string]any{"content": "secret", "description": "also secret"},
+			want: map[string]any{"content": "[redacted, 6 bytes]", "description": "[redacted, 11 bytes]"},
+		},
+		{
+			name: "append_note_content text is redacted",
+			args: map[string]any{"id": float64(3), "text": "more secret", "separator": "\n"},
+			want: map[string]any{"id": float64(3), "text": "[redacted, 11 bytes]", "separator": "\n"},
+		},
+		{
+			name: "import_connections_csv rows are redacted",
+			args: map[string]any{"csv": "from_note_id,to_note_id,type\n1,2,supports\n"},
+			want: map[string]any{"csv": "[redacted, 42 bytes]"},
+		},
+		{
+			name: "diff_graph_bundles bundles are redacted as strings and objects",
+			args: map[string]any{
+				"old_bundle": `{"notes":[]}`,
+				"new_bundle": map[string]any{"notes": []any{map[string]any{"title": "Secret"}}},
+			},
+			want: map[string]any{"old_bundle": "[redacted, 12 bytes]", "new_bundle": "[redacted, 30 bytes]"},
+		},
+		{
+			name: "bulk_import_graph bundle is redacted",
+			args: map[string]any{"bundle": map[string]any{"notes": []any{}, "connections": []any{}}},
+			want: map[string]any{"bundle": "[redacted, 29 bytes]"},
+		},
+		{
+			name: "long strings are truncated on a rune boundary",
+			args: map[string]any{"title": longTitle},
+			want: map[string]any{"title": strings.Repeat("é", 60) + "... [200 bytes]"},
+		},
+		{
+			name: "long arrays are counted",
+			args: map[string]any{"note_ids": noteIDs},
+			want: map[string]any{"note_ids": "[25 items]"},
+		},
+		{
+			name: "nested objects are summarized",
+			args: map[string]any{"metadata": map[string]any{"content": "secret", "source": "web"}},
+			want: map[string]any{"metadata": map[string]any{"content": "[redacted, 6 bytes]", "source": "web"}},
+		},
+		{
+			name: "nil arguments",
+			args: nil,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, logging.SummarizeArguments(tt.args))
+		})
+	}
+}
+
+func TestWrapToolHandler(t *testing.T) {
+	req := gomcp.CallToolRequest{
+		Params: gomcp.CallToolParams{
+			Arguments: map[string]any{"id": float64(7), "content": "note body"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		handler   func(context.Context, gomcp.CallToolRequest) (*gomcp.CallToolResult, error)
+		wantLevel string
+		wantMsg   string
+		wantError string
+	}{
+		{
+			name: "successful call",
+			handler: func(context.Context, gomcp.CallToolRequest) (*gomcp.CallToolResult, error) {
+				return &gomcp.CallToolResult{}, nil
+			},
+			wantLevel: "INFO",
+			wantMsg:   "tool call",
+		},
+		{
+			name: "failed call",
+			handler: func(context.Context, gomcp.CallToolRequest) (*gomcp.CallToolResult, error) {
+				return nil, errors.New("note not found")
+			},
+			wantLevel: "ERROR",
+			wantMsg:   "tool call failed",
+			wantError: "note not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+			_, err := logging.WrapToolHandler(logger, "update_note", tt.handler)(context.Background(), req)
+			if tt.wantError != "" {
+				assert.EqualError(t, err, tt.wantError)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			var record map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+			assert.Equal(t, tt.wantLevel, record["level"])
+			assert.Equal(t, tt.wantMsg, record["msg"])
+			assert.Equal(t, "update_note", record["tool"])
+			assert.Equal(t, map[string]any{"id": float64(7), "content": "[redacted, 9 bytes]"}, record["args"])
+			assert.Contains(t, record, "duration")
+			if tt.wantError != "" {
+				assert.Equal(t, tt.wantError, record["error"])
+			} else {
+				assert.NotContains(t, record, "error")
+			}
+		})
+	}
+}
+
+func TestWrapToolHandler_NilLogger(t *testing.T) {
+	handler := logging.WrapToolHandler(nil, "list_notes", func(context.Context, gomcp.CallToolRequest) (*gomcp.CallToolResult, error) {
+		return &gomcp.CallToolResult{}, nil
+	})
+
+	result, err := handler(context.Background(), gomcp.CallToolRequest{})
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+}
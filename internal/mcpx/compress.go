@@ -0,0 +1,97 @@
+package mcpx
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// CompressedPrefix starts an export body that is gzip-compressed and then
+// base64-encoded. Clients strip it, decode the rest as standard base64 and
+// gunzip the result to get the export.
+const CompressedPrefix = "gzip+base64:"
+
+// ParseCompress reads the optional "compress" argument of an export tool,
+// false when missing
+func ParseCompress(arguments map[string]interface{}) (bool, error) {
+	raw, ok := arguments["compress"]
+	if !ok {
+		return false, nil
+	}
+	compress, ok := raw.(bool)
+	if !ok {
+		return false, fmt.Errorf("compress must be a boolean")
+	}
+	return compress, nil
+}
+
+// CompressProperty returns the input schema property for the "compress" argument
+func CompressProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "boolean",
+		"description": "Return the export gzip-compressed and base64-encoded after a " + CompressedPrefix + " prefix, for large exports (default: false)",
+	}
+}
+
+// CompressText gzips text and returns it base64-encoded after CompressedPrefix
+func CompressText(text string) (string, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(text)); err != nil {
+		return "", fmt.Errorf("failed to compress: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return "", fmt.Errorf("failed to compress: %w", err)
+	}
+	return CompressedPrefix + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// DecompressText reverses CompressText
+func DecompressText(body string) (string, error) {
+	encoded, ok := strings.CutPrefix(body, CompressedPrefix)
+	if !ok {
+		return "", fmt.Errorf("body does not start with %q", CompressedPrefix)
+	}
+	compressed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode base64: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return "", fmt.Errorf("failed to decompress: %w", err)
+	}
+	defer zr.Close()
+	text, err := io.ReadAll(zr)
+	if err != nil {
+		return "", fmt.Errorf("failed to decompress: %w", err)
+	}
+	return string(text), nil
+}
+
+// ExportResult builds the result of an export tool: summary, a blank line and
+// body. With compress the body is passed through CompressText and the summary
+// notes the encoding and the uncompressed size.
+func ExportResult(summary, body string, compress bool) (*mcp.CallToolResult, error) {
+	if compress {
+		compressed, err := CompressText(body)
+		if err != nil {
+			return nil, err
+		}
+		summary = fmt.Sprintf("%s, gzip-compressed from %d bytes and base64-encoded", summary, len(body))
+		body = compressed
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.TextContent{
+				Type: "text",
+				Text: summary + ":\n\n" + body,
+			},
+		},
+	}, nil
+}
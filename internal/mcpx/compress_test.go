@@ -0,0 +1,112 @@
+package mcpx_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+func TestCompressText_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{name: "empty", text: ""},
+		{name: "csv", text: "id,from_note_id,to_note_id\n1,2,3\n"},
+		{name: "unicode", text: `<node label="Tiếng Việt – café ☕"/>`},
+		{name: "large repetitive export", text: strings.Repeat(`<edge source="1" target="2" weight="5"/>`+"\n", 50000)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compressed, err := mcpx.CompressText(tt.text)
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(compressed, mcpx.CompressedPrefix))
+
+			got, err := mcpx.DecompressText(compressed)
+			require.NoError(t, err)
+			assert.Equal(t, tt.text, got)
+		})
+	}
+
+	t.Run("large exports shrink", func(t *testing.T) {
+		text := strings.Repeat(`<edge source="1" target="2" weight="5"/>`+"\n", 50000)
+		compressed, err := mcpx.CompressText(text)
+		require.NoError(t, err)
+		assert.Less(t, len(compressed), len(text)/10)
+	})
+}
+
+func TestDecompressText_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{name: "missing prefix", body: "H4sIAAAAAAAA", wantErr: `does not start with "gzip+base64:"`},
+		{name: "not base64", body: mcpx.CompressedPrefix + "not base64!", wantErr: "failed to decode base64"},
+		{name: "not gzip", body: mcpx.CompressedPrefix + base64.StdEncoding.EncodeToString([]byte("plain")), wantErr: "failed to decompress"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mcpx.DecompressText(tt.body)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParseCompress(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		want    bool
+		wantErr string
+	}{
+		{name: "missing", args: map[string]interface{}{}, want: false},
+		{name: "true", args: map[string]interface{}{"compress": true}, want: true},
+		{name: "false", args: map[string]interface{}{"compress": false}, want: false},
+		{name: "not a boolean", args: map[string]interface{}{"compress": "yes"}, wantErr: "compress must be a boolean"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mcpx.ParseCompress(tt.args)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExportResult(t *testing.T) {
+	body := "id,type\n1,supports\n"
+
+	t.Run("plain", func(t *testing.T) {
+		result, err := mcpx.ExportResult("Exported connections as CSV", body, false)
+		require.NoError(t, err)
+		assert.Equal(t, "Exported connections as CSV:\n\n"+body, result.Content[0].(gomcp.TextContent).Text)
+	})
+
+	t.Run("compressed", func(t *testing.T) {
+		result, err := mcpx.ExportResult("Exported connections as CSV", body, true)
+		require.NoError(t, err)
+
+		summary, compressed, found := strings.Cut(result.Content[0].(gomcp.TextContent).Text, "\n\n")
+		require.True(t, found)
+		assert.Equal(t, "Exported connections as CSV, gzip-compressed from 19 bytes and base64-encoded:", summary)
+
+		got, err := mcpx.DecompressText(compressed)
+		require.NoError(t, err)
+		assert.Equal(t, body, got)
+	})
+}
@@ -0,0 +1,42 @@
+package mcpx
+
+import (
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// pageArguments are the list tool arguments that pick a page or its order
+// rather than filter, so count tools leave them out
+var pageArguments = []string{"limit", "offset", "order_by", "order_dir"}
+
+// CountProperties returns the input schema properties of a count tool built
+// from those of its list tool: the same filters, without the page and order
+// arguments or the extra names given
+func CountProperties(listProperties map[string]interface{}, extra ...string) map[string]interface{} {
+	properties := make(map[string]interface{}, len(listProperties))
+	for name, property := range listProperties {
+		properties[name] = property
+	}
+	for _, name := range append(append([]string{}, pageArguments...), extra...) {
+		delete(properties, name)
+	}
+	return properties
+}
+
+// CountResult formats the result of a count tool, whose items are called noun
+func CountResult(noun string, total int64) (*mcp.CallToolResult, error) {
+	jsonData, err := MarshalJSON(map[string]int64{"total": total})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal result: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.TextContent{
+				Type: "text",
+				Text: fmt.Sprintf("Found %d %s\n\n%s", total, noun, string(jsonData)),
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,53 @@
+package mcpx_test
+
+import (
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+func TestCountProperties(t *testing.T) {
+	list := map[string]interface{}{
+		"limit":          mcpx.DefaultListLimits().LimitProperty("notes"),
+		"offset":         map[string]interface{}{"type": "integer"},
+		"order_by":       map[string]interface{}{"type": "string"},
+		"order_dir":      map[string]interface{}{"type": "string"},
+		"search":         map[string]interface{}{"type": "string"},
+		"tags":           map[string]interface{}{"type": "array"},
+		"include_counts": map[string]interface{}{"type": "boolean"},
+	}
+
+	tests := []struct {
+		name  string
+		extra []string
+		want  []string
+	}{
+		{name: "drops page and order arguments", want: []string{"include_counts", "search", "tags"}},
+		{name: "drops extra names", extra: []string{"include_counts"}, want: []string{"search", "tags"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mcpx.CountProperties(list, tt.extra...)
+
+			names := make([]string, 0, len(got))
+			for name := range got {
+				names = append(names, name)
+			}
+			assert.ElementsMatch(t, tt.want, names)
+			assert.Len(t, list, 7, "the list properties are left unchanged")
+		})
+	}
+}
+
+func TestCountResult(t *testing.T) {
+	result, err := mcpx.CountResult("notes", 42)
+	require.NoError(t, err)
+
+	text := result.Content[0].(gomcp.TextContent).Text
+	assert.Equal(t, "Found 42 notes\n\n{\n  \"total\": 42\n}", text)
+}
@@ -0,0 +1,27 @@
+package mcpx
+
+import "fmt"
+
+// ParseIdempotencyKey reads the optional "idempotency_key" argument of a
+// create tool, empty when missing. The storage validates the key itself.
+func ParseIdempotencyKey(arguments map[string]interface{}) (string, error) {
+	raw, ok := arguments["idempotency_key"]
+	if !ok {
+		return "", nil
+	}
+	key, ok := raw.(string)
+	if !ok {
+		return "", fmt.Errorf("idempotency_key must be a string")
+	}
+	return key, nil
+}
+
+// IdempotencyKeyProperty returns the input schema property for the
+// "idempotency_key" argument; entity names what the tool creates
+func IdempotencyKeyProperty(entity string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"description": "Client-chosen key that makes retries safe: repeating a call with the same key returns the " + entity + " the first call created instead of creating another",
+		"maxLength":   255,
+	}
+}
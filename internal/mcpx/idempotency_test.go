@@ -0,0 +1,35 @@
+package mcpx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+func TestParseIdempotencyKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments map[string]interface{}
+		want      string
+		wantErr   string
+	}{
+		{name: "missing", arguments: map[string]interface{}{}, want: ""},
+		{name: "key", arguments: map[string]interface{}{"idempotency_key": "retry-1"}, want: "retry-1"},
+		{name: "not a string", arguments: map[string]interface{}{"idempotency_key": float64(1)}, wantErr: "idempotency_key must be a string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mcpx.ParseIdempotencyKey(tt.arguments)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package mcpx
+
+import (
+	"encoding/json"
+)
+
+// compactJSON drops the indentation from the JSON in tool results
+var compactJSON bool
+
+// SetCompactJSON switches the JSON in tool results between indented and
+// compact. Indentation makes results easier for people to read but costs
+// clients tokens. Call it before the server starts serving.
+func SetCompactJSON(compact bool) {
+	compactJSON = compact
+}
+
+// MarshalJSON encodes v for a tool result: indented with two spaces by
+// default, on a single line when compact JSON is on
+func MarshalJSON(v interface{}) ([]byte, error) {
+	if compactJSON {
+		return json.Marshal(v)
+	}
+	return json.MarshalIndent(v, "", "  ")
+}
@@ -0,0 +1,62 @@
+package mcpx_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	defer mcpx.SetCompactJSON(false)
+
+	description := "Line one\nline two"
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{name: "object", value: map[string]interface{}{"id": 1, "title": "Note", "tags": []string{"a", "b"}}},
+		{name: "nested list", value: map[string]interface{}{"items": []map[string]interface{}{{"id": 1}, {"id": 2}}, "total": 2}},
+		{name: "struct with nil and newline", value: struct {
+			ID          int64     `json:"id"`
+			Description *string   `json:"description"`
+			Source      *string   `json:"source"`
+			CreatedAt   time.Time `json:"created_at"`
+		}{ID: 7, Description: &description, CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}},
+		{name: "empty list", value: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mcpx.SetCompactJSON(false)
+			pretty, err := mcpx.MarshalJSON(tt.value)
+			require.NoError(t, err)
+
+			mcpx.SetCompactJSON(true)
+			compact, err := mcpx.MarshalJSON(tt.value)
+			require.NoError(t, err)
+
+			require.True(t, json.Valid(pretty))
+			require.True(t, json.Valid(compact))
+			assert.NotContains(t, string(compact), "\n")
+			assert.LessOrEqual(t, len(compact), len(pretty))
+
+			var fromPretty, fromCompact interface{}
+			require.NoError(t, json.Unmarshal(pretty, &fromPretty))
+			require.NoError(t, json.Unmarshal(compact, &fromCompact))
+			assert.Equal(t, fromPretty, fromCompact)
+		})
+	}
+
+	t.Run("pretty output is indented by default", func(t *testing.T) {
+		mcpx.SetCompactJSON(false)
+		data, err := mcpx.MarshalJSON(map[string]int{"id": 1})
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(data), "{\n  \"id\""))
+	})
+}
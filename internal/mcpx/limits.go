@@ -0,0 +1,38 @@
+package mcpx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultMaxArgumentBytes is the default limit on the serialized size of a tool call's arguments
+const DefaultMaxArgumentBytes = 1 << 20
+
+// ErrArgumentsTooLarge is returned when a tool call's arguments exceed the configured size limit
+var ErrArgumentsTooLarge = errors.New("tool arguments too large")
+
+// MaxArgumentSize rejects calls whose JSON-serialized arguments exceed
+// maxBytes before they reach the handler. A limit of 0 or less disables the
+// check.
+func MaxArgumentSize(maxBytes int) Middleware {
+	return func(tool string, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		if maxBytes <= 0 {
+			return next
+		}
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			data, err := json.Marshal(req.Params.Arguments)
+			if err != nil {
+				return nil, fmt.Errorf("invalid arguments: %w", err)
+			}
+			if len(data) > maxBytes {
+				return nil, fmt.Errorf("%w: %s arguments are %d bytes, the limit is %d bytes", ErrArgumentsTooLarge, tool, len(data), maxBytes)
+			}
+			return next(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package mcpx_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+func TestMaxArgumentSize(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxBytes   int
+		args       map[string]interface{}
+		wantCalled bool
+		wantErr    string
+	}{
+		{
+			name:       "within limit",
+			maxBytes:   100,
+			args:       map[string]interface{}{"title": "Short note"},
+			wantCalled: true,
+		},
+		{
+			name:       "exactly at limit",
+			maxBytes:   len(`{"title":"abc"}`),
+			args:       map[string]interface{}{"title": "abc"},
+			wantCalled: true,
+		},
+		{
+			name:     "over limit",
+			maxBytes: 100,
+			args:     map[string]interface{}{"content": strings.Repeat("x", 200)},
+			wantErr:  "create_note arguments are 214 bytes, the limit is 100 bytes",
+		},
+		{
+			name:       "zero disables the limit",
+			maxBytes:   0,
+			args:       map[string]interface{}{"content": strings.Repeat("x", 200)},
+			wantCalled: true,
+		},
+		{
+			name:       "no arguments",
+			maxBytes:   10,
+			args:       nil,
+			wantCalled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := func(context.Context, gomcp.CallToolRequest) (*gomcp.CallToolResult, error) {
+				called = true
+				return &gomcp.CallToolResult{}, nil
+			}
+
+			wrapped := mcpx.Wrap("create_note", handler, mcpx.MaxArgumentSize(tt.maxBytes))
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+			result, err := wrapped(context.Background(), req)
+
+			assert.Equal(t, tt.wantCalled, called)
+			if tt.wantErr != "" {
+				assert.ErrorIs(t, err, mcpx.ErrArgumentsTooLarge)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package mcpx
+
+import "fmt"
+
+const (
+	// DefaultListLimit is the number of items a list tool returns when a call gives no limit
+	DefaultListLimit = 100
+
+	// DefaultMaxListLimit is the largest limit a call may ask a list tool for
+	DefaultMaxListLimit = 1000
+)
+
+// ListLimits configures the page sizes of the list tools
+type ListLimits struct {
+	Default int // Items returned when a call gives no limit
+	Max     int // Largest limit a call may ask for
+}
+
+// DefaultListLimits returns the limits used when the server is not configured otherwise
+func DefaultListLimits() ListLimits {
+	return ListLimits{Default: DefaultListLimit, Max: DefaultMaxListLimit}
+}
+
+// Validate checks that the default limit is at least 1 and no larger than the maximum
+func (l ListLimits) Validate() error {
+	if l.Default < 1 {
+		return fmt.Errorf("default list limit must be at least 1, got: %d", l.Default)
+	}
+	if l.Max < l.Default {
+		return fmt.Errorf("max list limit must be at least the default list limit %d, got: %d", l.Default, l.Max)
+	}
+	return nil
+}
+
+// ParseLimit reads the optional "limit" argument of a list tool. A missing
+// limit is l.Default; a given one must be an integer from 1 to l.Max.
+func (l ListLimits) ParseLimit(arguments map[string]interface{}) (int, error) {
+	raw, ok := arguments["limit"]
+	if !ok {
+		return l.Default, nil
+	}
+
+	limit, err := ParseInt(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid limit: %w", err)
+	}
+
+	if limit < 1 || limit > l.Max {
+		return 0, fmt.Errorf("limit must be between 1 and %d, got: %d", l.Max, limit)
+	}
+	return limit, nil
+}
+
+// LimitProperty returns the input schema property for the "limit" argument,
+// describing what is counted as items
+func (l ListLimits) LimitProperty(items string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "integer",
+		"description": fmt.Sprintf("Maximum number of %s to return (default: %d, max: %d)", items, l.Default, l.Max),
+		"minimum":     1,
+		"maximum":     l.Max,
+	}
+}
@@ -0,0 +1,80 @@
+package mcpx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+func TestListLimits_ParseLimit(t *testing.T) {
+	custom := mcpx.ListLimits{Default: 20, Max: 50}
+
+	tests := []struct {
+		name    string
+		limits  mcpx.ListLimits
+		args    map[string]interface{}
+		want    int
+		wantErr string
+	}{
+		{name: "default limits without a limit", limits: mcpx.DefaultListLimits(), args: map[string]interface{}{}, want: 100},
+		{name: "default limits at the maximum", limits: mcpx.DefaultListLimits(), args: map[string]interface{}{"limit": float64(1000)}, want: 1000},
+		{name: "default limits above the maximum", limits: mcpx.DefaultListLimits(), args: map[string]interface{}{"limit": float64(1001)}, wantErr: "limit must be between 1 and 1000, got: 1001"},
+		{name: "custom default", limits: custom, args: map[string]interface{}{}, want: 20},
+		{name: "custom maximum", limits: custom, args: map[string]interface{}{"limit": float64(50)}, want: 50},
+		{name: "above the custom maximum", limits: custom, args: map[string]interface{}{"limit": float64(51)}, wantErr: "limit must be between 1 and 50, got: 51"},
+		{name: "zero", limits: custom, args: map[string]interface{}{"limit": float64(0)}, wantErr: "limit must be between 1 and 50, got: 0"},
+		{name: "int", limits: custom, args: map[string]interface{}{"limit": 30}, want: 30},
+		{name: "numeric string", limits: custom, args: map[string]interface{}{"limit": "30"}, want: 30},
+		{name: "fraction", limits: custom, args: map[string]interface{}{"limit": 2.5}, wantErr: "invalid limit: 2.5 is not an integer"},
+		{name: "non-numeric string", limits: custom, args: map[string]interface{}{"limit": "ten"}, wantErr: "invalid limit"},
+		{name: "wrong type", limits: custom, args: map[string]interface{}{"limit": true}, wantErr: "cannot convert bool to int"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.limits.ParseLimit(tt.args)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestListLimits_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  mcpx.ListLimits
+		wantErr string
+	}{
+		{name: "defaults", limits: mcpx.DefaultListLimits()},
+		{name: "default equals maximum", limits: mcpx.ListLimits{Default: 50, Max: 50}},
+		{name: "zero default", limits: mcpx.ListLimits{Default: 0, Max: 50}, wantErr: "default list limit must be at least 1"},
+		{name: "maximum below default", limits: mcpx.ListLimits{Default: 100, Max: 50}, wantErr: "max list limit must be at least the default list limit 100, got: 50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.limits.Validate()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestListLimits_LimitProperty(t *testing.T) {
+	property := mcpx.ListLimits{Default: 20, Max: 50}.LimitProperty("notes")
+
+	assert.Equal(t, "integer", property["type"])
+	assert.Equal(t, "Maximum number of notes to return (default: 20, max: 50)", property["description"])
+	assert.Equal(t, 1, property["minimum"])
+	assert.Equal(t, 50, property["maximum"])
+}
@@ -0,0 +1,21 @@
+// Package mcpx holds reusable decorators for MCP tool handlers and the stdio
+// transport that serves them.
+package mcpx
+
+import (
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Middleware decorates the handler registered for tool
+type Middleware func(tool string, next server.ToolHandlerFunc) server.ToolHandlerFunc
+
+// Wrap applies middleware to handler. The first middleware is the outermost,
+// so it sees every call, including calls rejected by later middleware.
+func Wrap(tool string, handler server.ToolHandlerFunc, middleware ...Middleware) server.ToolHandlerFunc {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		if middleware[i] != nil {
+			handler = middleware[i](tool, handler)
+		}
+	}
+	return handler
+}
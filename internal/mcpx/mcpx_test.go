@@ -0,0 +1,47 @@
+package mcpx_test
+
+import (
+	"context"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+func TestWrap(t *testing.T) {
+	var calls []string
+	record := func(name string) mcpx.Middleware {
+		return func(tool string, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+			return func(ctx context.Context, req gomcp.CallToolRequest) (*gomcp.CallToolResult, error) {
+				calls = append(calls, name+":"+tool)
+				return next(ctx, req)
+			}
+		}
+	}
+	handler := func(context.Context, gomcp.CallToolRequest) (*gomcp.CallToolResult, error) {
+		calls = append(calls, "handler")
+		return &gomcp.CallToolResult{}, nil
+	}
+
+	wrapped := mcpx.Wrap("get_note", handler, record("outer"), nil, record("inner"))
+	result, err := wrapped(context.Background(), gomcp.CallToolRequest{})
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, []string{"outer:get_note", "inner:get_note", "handler"}, calls)
+}
+
+func TestWrap_NoMiddleware(t *testing.T) {
+	called := false
+	handler := func(context.Context, gomcp.CallToolRequest) (*gomcp.CallToolResult, error) {
+		called = true
+		return &gomcp.CallToolResult{}, nil
+	}
+
+	_, err := mcpx.Wrap("get_note", handler)(context.Background(), gomcp.CallToolRequest{})
+	require.NoError(t, err)
+	assert.True(t, called)
+}
@@ -0,0 +1,68 @@
+package mcpx
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ParseInt64 parses a whole number argument. JSON clients send numbers as
+// float64, but some send them as decimal strings, so both are accepted, as
+// are the int types tests and Go callers pass. A fractional number is an error
+// rather than being truncated.
+func ParseInt64(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+			return 0, fmt.Errorf("%v is not an integer", v)
+		}
+		return int64(v), nil
+	case string:
+		return strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to int64", value)
+	}
+}
+
+// ParseInt parses a whole number argument like ParseInt64
+func ParseInt(value interface{}) (int, error) {
+	i, err := ParseInt64(value)
+	if err != nil {
+		return 0, err
+	}
+	return int(i), nil
+}
+
+// ParseFloat64 parses a number argument sent as a JSON number or a decimal string
+func ParseFloat64(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(v), 64)
+	default:
+		return 0, fmt.Errorf("cannot convert %T to float64", value)
+	}
+}
+
+// ParseID parses an entity ID, a positive whole number sent as a JSON number
+// or a decimal string
+func ParseID(value interface{}) (int64, error) {
+	id, err := ParseInt64(value)
+	if err != nil {
+		return 0, err
+	}
+	if id <= 0 {
+		return 0, fmt.Errorf("IDs must be positive, got: %d", id)
+	}
+	return id, nil
+}
@@ -0,0 +1,129 @@
+package mcpx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+func TestParseInt64(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    int64
+		wantErr string
+	}{
+		{name: "json number", value: float64(42), want: 42},
+		{name: "negative json number", value: float64(-3), want: -3},
+		{name: "int", value: 7, want: 7},
+		{name: "int64", value: int64(8), want: 8},
+		{name: "decimal string", value: "42", want: 42},
+		{name: "padded string", value: " 42 ", want: 42},
+		{name: "fraction", value: 1.5, wantErr: "1.5 is not an integer"},
+		{name: "out of range", value: 1e19, wantErr: "1e+19 is not an integer"},
+		{name: "non-numeric string", value: "abc", wantErr: "invalid syntax"},
+		{name: "fractional string", value: "1.5", wantErr: "invalid syntax"},
+		{name: "empty string", value: "", wantErr: "invalid syntax"},
+		{name: "bool", value: true, wantErr: "cannot convert bool to int64"},
+		{name: "nil", value: nil, wantErr: "cannot convert <nil> to int64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mcpx.ParseInt64(tt.value)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseInt(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    int
+		wantErr string
+	}{
+		{name: "json number", value: float64(5), want: 5},
+		{name: "decimal string", value: "5", want: 5},
+		{name: "fraction", value: 5.5, wantErr: "5.5 is not an integer"},
+		{name: "wrong type", value: []interface{}{}, wantErr: "cannot convert []interface {} to int64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mcpx.ParseInt(tt.value)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseFloat64(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    float64
+		wantErr string
+	}{
+		{name: "json number", value: 0.25, want: 0.25},
+		{name: "int", value: 2, want: 2},
+		{name: "int64", value: int64(3), want: 3},
+		{name: "decimal string", value: "0.5", want: 0.5},
+		{name: "non-numeric string", value: "half", wantErr: "invalid syntax"},
+		{name: "bool", value: false, wantErr: "cannot convert bool to float64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mcpx.ParseFloat64(tt.value)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseID(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    int64
+		wantErr string
+	}{
+		{name: "json number", value: float64(12), want: 12},
+		{name: "decimal string", value: "12", want: 12},
+		{name: "zero", value: float64(0), wantErr: "IDs must be positive, got: 0"},
+		{name: "negative string", value: "-4", wantErr: "IDs must be positive, got: -4"},
+		{name: "fraction", value: 1.5, wantErr: "1.5 is not an integer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mcpx.ParseID(tt.value)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
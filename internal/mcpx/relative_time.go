@@ -0,0 +1,134 @@
+package mcpx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	day  = 24 * time.Hour
+	week = 7 * day
+)
+
+var (
+	// dayWeekPattern matches a number of days or weeks such as "7d" or "1.5w"
+	dayWeekPattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)([dw])$`)
+	// isoDurationPattern matches the ISO 8601 durations without years and
+	// months, whose length depends on the calendar, such as "P7D" or "PT36H"
+	isoDurationPattern = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+	// errDurationOutOfRange is returned for durations longer than time.Duration holds
+	errDurationOutOfRange = errors.New("duration out of range")
+)
+
+// ParseRelativeDuration parses how far back a "since"-style argument reaches.
+// It accepts Go durations ("90m", "24h"), days and weeks ("7d", "2w") and ISO
+// 8601 durations without years or months ("P7D", "PT24H"). Negative and empty
+// durations are rejected.
+func ParseRelativeDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("relative time must not be empty")
+	}
+
+	d, err := parseDuration(value)
+	if errors.Is(err, errDurationOutOfRange) {
+		return 0, fmt.Errorf("relative time must not be longer than %s, got: %s", time.Duration(math.MaxInt64), value)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid relative time %q, use a duration such as 24h, 7d, 2w or P7D", value)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("relative time must not be negative, got: %s", value)
+	}
+	return d, nil
+}
+
+// ParseRelativeTime resolves a relative time such as "24h" or "7d" to that
+// long before now, in UTC
+func ParseRelativeTime(value string) (time.Time, error) {
+	d, err := ParseRelativeDuration(value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Now().UTC().Add(-d), nil
+}
+
+// ParseRelativeTimeArgument reads the optional argument name as a relative
+// time, nil when it is missing
+func ParseRelativeTimeArgument(arguments map[string]interface{}, name string) (*time.Time, error) {
+	raw, ok := arguments[name]
+	if !ok {
+		return nil, nil
+	}
+	value, ok := raw.(string)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a string", name)
+	}
+	t, err := ParseRelativeTime(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return &t, nil
+}
+
+// parseDuration parses value in any of the formats ParseRelativeDuration accepts
+func parseDuration(value string) (time.Duration, error) {
+	if m := dayWeekPattern.FindStringSubmatch(value); m != nil {
+		n, err := strconv.ParseFloat(m[1], 64)
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, errDurationOutOfRange
+		}
+		if err != nil {
+			return 0, err
+		}
+		unit := day
+		if m[2] == "w" {
+			unit = week
+		}
+		ns := n * float64(unit)
+		// float64(math.MaxInt64) rounds up to 2^63, which no longer fits
+		if !(ns < math.MaxInt64) {
+			return 0, errDurationOutOfRange
+		}
+		return time.Duration(ns), nil
+	}
+
+	if strings.HasPrefix(value, "P") {
+		return parseISODuration(value)
+	}
+
+	return time.ParseDuration(value)
+}
+
+// parseISODuration parses an ISO 8601 duration matched by isoDurationPattern
+func parseISODuration(value string) (time.Duration, error) {
+	m := isoDurationPattern.FindStringSubmatch(value)
+	if m == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, fmt.Errorf("invalid ISO 8601 duration: %s", value)
+	}
+
+	var total time.Duration
+	for i, unit := range []time.Duration{week, day, time.Hour, time.Minute, time.Second} {
+		if m[i+1] == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(m[i+1], 10, 64)
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, errDurationOutOfRange
+		}
+		if err != nil {
+			return 0, err
+		}
+		if n > int64(math.MaxInt64/unit) || time.Duration(n)*unit > math.MaxInt64-total {
+			return 0, errDurationOutOfRange
+		}
+		total += time.Duration(n) * unit
+	}
+	return total, nil
+}
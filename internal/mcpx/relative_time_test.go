@@ -0,0 +1,121 @@
+package mcpx_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+func TestParseRelativeDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Duration
+		wantErr string
+	}{
+		{name: "go duration", value: "24h", want: 24 * time.Hour},
+		{name: "compound go duration", value: "1h30m", want: 90 * time.Minute},
+		{name: "days", value: "7d", want: 7 * 24 * time.Hour},
+		{name: "weeks", value: "2w", want: 14 * 24 * time.Hour},
+		{name: "fractional days", value: "1.5d", want: 36 * time.Hour},
+		{name: "surrounding spaces", value: " 3d ", want: 3 * 24 * time.Hour},
+		{name: "iso days", value: "P7D", want: 7 * 24 * time.Hour},
+		{name: "iso weeks and days", value: "P1W2D", want: 9 * 24 * time.Hour},
+		{name: "iso time", value: "PT1H30M", want: 90 * time.Minute},
+		{name: "iso date and time", value: "P1DT12H", want: 36 * time.Hour},
+		{name: "zero", value: "0s", want: 0},
+		{name: "empty", value: "", wantErr: "relative time must not be empty"},
+		{name: "negative", value: "-24h", wantErr: "relative time must not be negative, got: -24h"},
+		{name: "unknown unit", value: "7y", wantErr: `invalid relative time "7y", use a duration such as 24h, 7d, 2w or P7D`},
+		{name: "bare number", value: "7", wantErr: `invalid relative time "7"`},
+		{name: "combined days and hours", value: "1d12h", wantErr: `invalid relative time "1d12h"`},
+		{name: "iso months", value: "P1M", wantErr: `invalid relative time "P1M"`},
+		{name: "iso without components", value: "P", wantErr: `invalid relative time "P"`},
+		{name: "iso with empty time", value: "P1DT", wantErr: `invalid relative time "P1DT"`},
+		{name: "text", value: "yesterday", wantErr: `invalid relative time "yesterday"`},
+		{name: "longest days", value: "106751d", want: 106751 * 24 * time.Hour},
+		{name: "days beyond duration range", value: "106752d", wantErr: "relative time must not be longer than 2562047h47m16.854775807s, got: 106752d"},
+		{name: "exponent notation", value: "1e400w", wantErr: `invalid relative time "1e400w"`},
+		{name: "days overflowing float", value: "1" + strings.Repeat("0", 400) + "d", wantErr: "relative time must not be longer than"},
+		{name: "iso weeks beyond duration range", value: "P15251W", wantErr: "relative time must not be longer than"},
+		{name: "iso sum beyond duration range", value: "P15250W6DT23H59M59S", wantErr: "relative time must not be longer than"},
+		{name: "iso number beyond int64", value: "PT99999999999999999999S", wantErr: "relative time must not be longer than"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mcpx.ParseRelativeDuration(tt.value)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseRelativeTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		ago     time.Duration
+		wantErr bool
+	}{
+		{name: "hours", value: "24h", ago: 24 * time.Hour},
+		{name: "days", value: "7d", ago: 7 * 24 * time.Hour},
+		{name: "weeks", value: "2w", ago: 14 * 24 * time.Hour},
+		{name: "invalid", value: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mcpx.ParseRelativeTime(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, time.UTC, got.Location())
+			assert.WithinDuration(t, time.Now().UTC().Add(-tt.ago), got, time.Minute)
+		})
+	}
+}
+
+func TestParseRelativeTimeArgument(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments map[string]interface{}
+		wantNil   bool
+		wantErr   string
+	}{
+		{name: "missing", arguments: map[string]interface{}{}, wantNil: true},
+		{name: "relative time", arguments: map[string]interface{}{"since": "7d"}},
+		{name: "not a string", arguments: map[string]interface{}{"since": float64(7)}, wantErr: "since must be a string"},
+		{name: "invalid", arguments: map[string]interface{}{"since": "7x"}, wantErr: `invalid since: invalid relative time "7x"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mcpx.ParseRelativeTimeArgument(tt.arguments, "since")
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantNil {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.WithinDuration(t, time.Now().UTC().Add(-7*24*time.Hour), *got, time.Minute)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package mcpx
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ServeStdio serves s over stdin and stdout until stdin closes or the process
+// receives SIGINT or SIGTERM. Transport errors are logged with logger at
+// error level; a nil logger drops them.
+//
+// Stdout carries the JSON-RPC stream and nothing else. The server writes to
+// the stdout it was started with, and os.Stdout is pointed at os.Stderr while
+// it runs, so a stray fmt.Println in a handler or a dependency shows up in the
+// logs instead of corrupting the protocol.
+func ServeStdio(s *server.MCPServer, logger *slog.Logger) error {
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+
+	protocol := os.Stdout
+	os.Stdout = os.Stderr
+	defer func() { os.Stdout = protocol }()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	stdio := server.NewStdioServer(s)
+	stdio.SetErrorLogger(slog.NewLogLogger(logger.Handler(), slog.LevelError))
+	return stdio.Listen(ctx, os.Stdin, protocol)
+}
@@ -0,0 +1,128 @@
+package mcpx_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// TestServeStdio swaps the process-wide standard streams, so it must not run in parallel
+func TestServeStdio(t *testing.T) {
+	tests := []struct {
+		name    string
+		chatter func()
+	}{
+		{
+			name:    "fmt.Println",
+			chatter: func() { fmt.Println("stray output from a handler") },
+		},
+		{
+			name:    "write to os.Stdout",
+			chatter: func() { fmt.Fprintf(os.Stdout, "stray output from a handler\n") },
+		},
+		{
+			name:    "partial line without a newline",
+			chatter: func() { os.Stdout.WriteString("stray output from a handler") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdin, stdout, stderr := redirectStdio(t)
+
+			s := server.NewMCPServer("test", "1.0.0")
+			s.AddTool(gomcp.NewTool("chatty"), func(ctx context.Context, req gomcp.CallToolRequest) (*gomcp.CallToolResult, error) {
+				tt.chatter()
+				return gomcp.NewToolResultText("done"), nil
+			})
+
+			served := make(chan error, 1)
+			go func() { served <- mcpx.ServeStdio(s, nil) }()
+
+			lines := bufio.NewScanner(stdout.reader)
+			send(t, stdin, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1.0.0"}}}`)
+			assertProtocolLine(t, lines, 1)
+			send(t, stdin, `{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+			send(t, stdin, `{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"chatty","arguments":{}}}`)
+			result := assertProtocolLine(t, lines, 2)
+			assert.Contains(t, string(result["result"]), "done")
+
+			require.NoError(t, stdin.Close())
+			require.NoError(t, <-served)
+			assert.Same(t, stdout.writer, os.Stdout, "os.Stdout is restored after serving")
+
+			// Nothing but the two responses reached stdout
+			require.NoError(t, stdout.writer.Close())
+			rest, err := io.ReadAll(stdout.reader)
+			require.NoError(t, err)
+			assert.Empty(t, string(rest))
+
+			require.NoError(t, stderr.writer.Close())
+			logged, err := io.ReadAll(stderr.reader)
+			require.NoError(t, err)
+			assert.Contains(t, string(logged), "stray output from a handler")
+		})
+	}
+}
+
+// pipe is both ends of an os.Pipe
+type pipe struct {
+	reader *os.File
+	writer *os.File
+}
+
+func newPipe(t *testing.T) pipe {
+	t.Helper()
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		r.Close()
+		w.Close()
+	})
+	return pipe{reader: r, writer: w}
+}
+
+// redirectStdio points os.Stdin, os.Stdout and os.Stderr at pipes for the
+// rest of the test. It returns the writer of stdin and the other two pipes.
+func redirectStdio(t *testing.T) (*os.File, pipe, pipe) {
+	t.Helper()
+	stdin, stdout, stderr := newPipe(t), newPipe(t), newPipe(t)
+
+	origStdin, origStdout, origStderr := os.Stdin, os.Stdout, os.Stderr
+	os.Stdin, os.Stdout, os.Stderr = stdin.reader, stdout.writer, stderr.writer
+	t.Cleanup(func() {
+		os.Stdin, os.Stdout, os.Stderr = origStdin, origStdout, origStderr
+	})
+
+	return stdin.writer, stdout, stderr
+}
+
+func send(t *testing.T, stdin *os.File, message string) {
+	t.Helper()
+	_, err := stdin.WriteString(message + "\n")
+	require.NoError(t, err)
+}
+
+// assertProtocolLine reads the next stdout line and checks that it is the
+// JSON-RPC response with the given id
+func assertProtocolLine(t *testing.T, lines *bufio.Scanner, id int) map[string]json.RawMessage {
+	t.Helper()
+	require.True(t, lines.Scan(), "expected a response on stdout: %v", lines.Err())
+
+	var message map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(lines.Bytes(), &message), "stdout line is not JSON: %q", lines.Text())
+	assert.JSONEq(t, `"2.0"`, string(message["jsonrpc"]))
+	assert.JSONEq(t, fmt.Sprint(id), string(message["id"]))
+	return message
+}
@@ -0,0 +1,164 @@
+package mcpx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MaxResultChars truncates the text content of tool results to at most limit
+// characters, using TruncateText. perTool overrides the limit for the named
+// tools. A limit of 0 or less leaves that tool's results untouched.
+func MaxResultChars(limit int, perTool map[string]int) Middleware {
+	return func(tool string, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		toolLimit := limit
+		if override, ok := perTool[tool]; ok {
+			toolLimit = override
+		}
+		if toolLimit <= 0 {
+			return next
+		}
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := next(ctx, req)
+			if err != nil || result == nil {
+				return result, err
+			}
+			for i, content := range result.Content {
+				if text, ok := content.(mcp.TextContent); ok {
+					text.Text = TruncateText(text.Text, toolLimit)
+					result.Content[i] = text
+				}
+			}
+			return result, nil
+		}
+	}
+}
+
+// TruncateText shortens a tool result to at most maxChars characters. Results
+// are a summary line, a blank line and a body; the summary is always kept,
+// even when it alone is longer than maxChars.
+//
+// A JSON body that is an array, or an object with an items array, loses
+// trailing elements until it fits and ends with "… truncated, N items
+// omitted". Any other body is cut and ends with "… truncated, N characters
+// omitted". A body starting with CompressedPrefix is returned whole, since any
+// cut would leave it impossible to decompress.
+func TruncateText(text string, maxChars int) string {
+	if maxChars <= 0 || utf8.RuneCountInString(text) <= maxChars {
+		return text
+	}
+
+	summary, body, found := strings.Cut(text, "\n\n")
+	if !found {
+		summary, body = "", text
+	}
+	if strings.HasPrefix(body, CompressedPrefix) {
+		return text
+	}
+	prefix := ""
+	if found {
+		prefix = summary + "\n\n"
+	}
+	budget := maxChars - utf8.RuneCountInString(prefix)
+
+	if trimmed, ok := truncateJSON(body, budget); ok {
+		return prefix + trimmed
+	}
+	return prefix + truncateChars(body, budget)
+}
+
+// truncateJSON drops trailing elements from the array in body until the
+// re-encoded body and its note fit in budget characters. It reports false when
+// body holds no such array or even an empty array does not fit.
+func truncateJSON(body string, budget int) (string, bool) {
+	var items []json.RawMessage
+	var object map[string]json.RawMessage
+	inObject := false
+
+	if err := json.Unmarshal([]byte(body), &items); err != nil {
+		if err := json.Unmarshal([]byte(body), &object); err != nil {
+			return "", false
+		}
+		if err := json.Unmarshal(object["items"], &items); err != nil {
+			return "", false
+		}
+		inObject = true
+	}
+
+	render := func(kept int) string {
+		var value interface{} = items[:kept]
+		if inObject {
+			trimmed := make(map[string]interface{}, len(object))
+			for key, raw := range object {
+				trimmed[key] = raw
+			}
+			trimmed["items"] = items[:kept]
+			value = trimmed
+		}
+		data, err := MarshalJSON(value)
+		if err != nil {
+			return ""
+		}
+		return fmt.Sprintf("%s\n… truncated, %d items omitted", data, len(items)-kept)
+	}
+	fits := func(kept int) bool {
+		rendered := render(kept)
+		return rendered != "" && utf8.RuneCountInString(rendered) <= budget
+	}
+
+	if !fits(0) {
+		return "", false
+	}
+	// The largest number of leading items that still fits
+	kept := sort.Search(len(items), func(n int) bool { return !fits(n + 1) })
+	return render(kept), true
+}
+
+// truncateChars keeps the start of body so that it and its note fit in
+// budget characters
+func truncateChars(body string, budget int) string {
+	total := utf8.RuneCountInString(body)
+
+	// Reserve room for the note with the widest possible count
+	note := "\n… truncated, " + strconv.Itoa(total) + " characters omitted"
+	keep := budget - utf8.RuneCountInString(note)
+	if keep < 0 {
+		keep = 0
+	}
+
+	runes := []rune(body)
+	if keep > len(runes) {
+		keep = len(runes)
+	}
+	return fmt.Sprintf("%s\n… truncated, %d characters omitted", string(runes[:keep]), total-keep)
+}
+
+// ParseToolLimits parses per-tool limits written as tool=limit pairs
+// separated by commas, e.g. "list_notes=20000,export_connections_csv=0"
+func ParseToolLimits(value string) (map[string]int, error) {
+	limits := make(map[string]int)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		tool, raw, found := strings.Cut(pair, "=")
+		tool = strings.TrimSpace(tool)
+		if !found || tool == "" {
+			return nil, fmt.Errorf("invalid tool limit %q, expected tool=limit", pair)
+		}
+		limit, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("invalid limit for %s: %q is not a non-negative integer", tool, raw)
+		}
+		limits[tool] = limit
+	}
+	return limits, nil
+}
@@ -0,0 +1,238 @@
+package mcpx_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// listResult builds a handler-style result: a summary line and n indented items
+func listResult(t *testing.T, n int, wrapped bool) string {
+	t.Helper()
+	items := make([]map[string]interface{}, n)
+	for i := range items {
+		items[i] = map[string]interface{}{"id": i + 1, "title": fmt.Sprintf("Note %d", i+1)}
+	}
+	var value interface{} = items
+	if wrapped {
+		value = map[string]interface{}{"items": items, "total": n}
+	}
+	data, err := json.MarshalIndent(value, "", "  ")
+	require.NoError(t, err)
+	return fmt.Sprintf("Found %d notes (total: %d):\n\n%s", n, n, data)
+}
+
+func TestTruncateText(t *testing.T) {
+	const summary = "Found 50 notes (total: 50):"
+
+	tests := []struct {
+		name     string
+		text     string
+		maxChars int
+		wantSame bool
+		wantJSON bool // trailing items dropped rather than characters
+		wantNote string
+	}{
+		{
+			name:     "under the limit",
+			text:     listResult(t, 50, true),
+			maxChars: 100000,
+			wantSame: true,
+		},
+		{
+			name:     "exactly at the limit",
+			text:     listResult(t, 50, true),
+			maxChars: utf8.RuneCountInString(listResult(t, 50, true)),
+			wantSame: true,
+		},
+		{
+			name:     "zero disables truncation",
+			text:     listResult(t, 50, true),
+			maxChars: 0,
+			wantSame: true,
+		},
+		{
+			name:     "object with items",
+			text:     listResult(t, 50, true),
+			maxChars: 1000,
+			wantJSON: true,
+			wantNote: "items omitted",
+		},
+		{
+			name:     "top-level array",
+			text:     listResult(t, 50, false),
+			maxChars: 1000,
+			wantJSON: true,
+			wantNote: "items omitted",
+		},
+		{
+			name:     "room for no items",
+			text:     listResult(t, 50, true),
+			maxChars: 100,
+			wantJSON: true,
+			wantNote: "… truncated, 50 items omitted",
+		},
+		{
+			name:     "plain text body",
+			text:     "Exported 3 connections:\n\n" + strings.Repeat("a,b,c\n", 100),
+			maxChars: 200,
+			wantNote: "characters omitted",
+		},
+		{
+			name:     "multibyte characters are not split",
+			text:     "Summary\n\n" + strings.Repeat("é", 300),
+			maxChars: 100,
+			wantNote: "… truncated, 245 characters omitted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mcpx.TruncateText(tt.text, tt.maxChars)
+			if tt.wantSame {
+				assert.Equal(t, tt.text, got)
+				return
+			}
+
+			assert.LessOrEqual(t, utf8.RuneCountInString(got), tt.maxChars)
+			assert.True(t, utf8.ValidString(got))
+			assert.True(t, strings.HasSuffix(got, tt.wantNote), got)
+
+			// The summary line survives unchanged
+			originalSummary, _, _ := strings.Cut(tt.text, "\n\n")
+			gotSummary, body, found := strings.Cut(got, "\n\n")
+			require.True(t, found)
+			assert.Equal(t, originalSummary, gotSummary)
+
+			if !tt.wantJSON {
+				return
+			}
+			jsonBody, note, found := strings.Cut(body, "\n… truncated, ")
+			require.True(t, found)
+			var omitted int
+			_, err := fmt.Sscanf(note, "%d items omitted", &omitted)
+			require.NoError(t, err)
+
+			var items []map[string]interface{}
+			if strings.HasPrefix(jsonBody, "{") {
+				var object struct {
+					Items []map[string]interface{} `json:"items"`
+					Total int                      `json:"total"`
+				}
+				require.NoError(t, json.Unmarshal([]byte(jsonBody), &object))
+				assert.Equal(t, 50, object.Total)
+				items = object.Items
+			} else {
+				require.NoError(t, json.Unmarshal([]byte(jsonBody), &items))
+			}
+			assert.Equal(t, 50, len(items)+omitted)
+			if len(items) > 0 {
+				assert.Equal(t, float64(1), items[0]["id"], "leading items are kept")
+			}
+		})
+	}
+
+	t.Run("summary longer than the limit is kept", func(t *testing.T) {
+		text := summary + "\n\n" + strings.Repeat("x", 100)
+		got := mcpx.TruncateText(text, 10)
+		assert.True(t, strings.HasPrefix(got, summary+"\n\n"))
+		assert.True(t, strings.HasSuffix(got, "… truncated, 100 characters omitted"))
+	})
+
+	t.Run("compressed bodies are not cut", func(t *testing.T) {
+		body := strings.Repeat("id,title\n", 1000)
+		result, err := mcpx.ExportResult("Exported 1000 rows", body, true)
+		require.NoError(t, err)
+		text := result.Content[0].(gomcp.TextContent).Text
+
+		got := mcpx.TruncateText(text, 50)
+		assert.Equal(t, text, got)
+		_, compressed, _ := strings.Cut(got, "\n\n")
+		decompressed, err := mcpx.DecompressText(compressed)
+		require.NoError(t, err)
+		assert.Equal(t, body, decompressed)
+	})
+
+	t.Run("text without a summary", func(t *testing.T) {
+		got := mcpx.TruncateText(strings.Repeat("y", 500), 100)
+		assert.LessOrEqual(t, utf8.RuneCountInString(got), 100)
+		assert.True(t, strings.HasPrefix(got, "yyy"))
+	})
+}
+
+func TestMaxResultChars(t *testing.T) {
+	long := "Summary\n\n" + strings.Repeat("z", 1000)
+
+	tests := []struct {
+		name     string
+		limit    int
+		perTool  map[string]int
+		tool     string
+		wantLen  int
+		wantSame bool
+	}{
+		{name: "global limit", limit: 100, tool: "list_notes", wantLen: 100},
+		{name: "per-tool override", limit: 100, perTool: map[string]int{"list_notes": 300}, tool: "list_notes", wantLen: 300},
+		{name: "per-tool zero disables", limit: 100, perTool: map[string]int{"export_connections_csv": 0}, tool: "export_connections_csv", wantSame: true},
+		{name: "override for another tool", limit: 100, perTool: map[string]int{"get_note": 0}, tool: "list_notes", wantLen: 100},
+		{name: "disabled", limit: 0, tool: "list_notes", wantSame: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := func(context.Context, gomcp.CallToolRequest) (*gomcp.CallToolResult, error) {
+				return &gomcp.CallToolResult{
+					Content: []gomcp.Content{gomcp.TextContent{Type: "text", Text: long}},
+				}, nil
+			}
+
+			result, err := mcpx.Wrap(tt.tool, handler, mcpx.MaxResultChars(tt.limit, tt.perTool))(context.Background(), gomcp.CallToolRequest{})
+			require.NoError(t, err)
+
+			text := result.Content[0].(gomcp.TextContent).Text
+			if tt.wantSame {
+				assert.Equal(t, long, text)
+				return
+			}
+			assert.LessOrEqual(t, utf8.RuneCountInString(text), tt.wantLen)
+			assert.Greater(t, utf8.RuneCountInString(text), tt.wantLen-10)
+			assert.True(t, strings.HasPrefix(text, "Summary\n\n"))
+		})
+	}
+}
+
+func TestParseToolLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]int
+		wantErr string
+	}{
+		{name: "empty", value: "", want: map[string]int{}},
+		{name: "pairs", value: "list_notes=20000, export_connections_csv=0", want: map[string]int{"list_notes": 20000, "export_connections_csv": 0}},
+		{name: "missing limit", value: "list_notes", wantErr: "expected tool=limit"},
+		{name: "negative limit", value: "list_notes=-1", wantErr: "not a non-negative integer"},
+		{name: "not a number", value: "list_notes=lots", wantErr: "not a non-negative integer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mcpx.ParseToolLimits(tt.value)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/metrics"
+)
+
+// MetricsResponse is the result of get_server_metrics
+type MetricsResponse struct {
+	Tools      []metrics.ToolStats `json:"tools"`
+	TotalCalls int64               `json:"total_calls"`
+}
+
+// NewMetricsHandler creates a new handler for reporting per-tool call counts and latencies
+func NewMetricsHandler(m *metrics.Metrics) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result := MetricsResponse{Tools: m.Snapshot()}
+		for _, stats := range result.Tools {
+			result.TotalCalls += stats.Calls
+		}
+
+		jsonData, err := mcpx.MarshalJSON(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Recorded %d calls across %d tools\n\n%s", result.TotalCalls, len(result.Tools), string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
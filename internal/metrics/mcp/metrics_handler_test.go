@@ -0,0 +1,50 @@
+package mcp_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/metrics"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/metrics/mcp"
+)
+
+func TestMetricsHandler(t *testing.T) {
+	m := metrics.New()
+	m.Observe("list_notes", 2*time.Millisecond, false)
+	m.Observe("list_notes", 3*time.Millisecond, false)
+	m.Observe("get_note", time.Millisecond, true)
+
+	handler := mcp.NewMetricsHandler(m)
+	result, err := handler(context.Background(), gomcp.CallToolRequest{})
+	require.NoError(t, err)
+
+	text := result.Content[0].(gomcp.TextContent).Text
+	assert.Contains(t, text, "Recorded 3 calls across 2 tools")
+
+	var response mcp.MetricsResponse
+	require.NoError(t, json.Unmarshal([]byte(text[strings.Index(text, "{"):]), &response))
+	assert.Equal(t, int64(3), response.TotalCalls)
+	require.Len(t, response.Tools, 2)
+	assert.Equal(t, "list_notes", response.Tools[0].Tool)
+	assert.Equal(t, int64(2), response.Tools[0].Calls)
+	assert.Equal(t, 2.0, response.Tools[0].P50Ms)
+	assert.Equal(t, 3.0, response.Tools[0].P95Ms)
+	assert.Equal(t, int64(1), response.Tools[1].Errors)
+}
+
+func TestMetricsHandler_NoCalls(t *testing.T) {
+	handler := mcp.NewMetricsHandler(metrics.New())
+	result, err := handler(context.Background(), gomcp.CallToolRequest{})
+	require.NoError(t, err)
+
+	text := result.Content[0].(gomcp.TextContent).Text
+	assert.Contains(t, text, "Recorded 0 calls across 0 tools")
+	assert.Contains(t, text, `"tools": []`)
+}
@@ -0,0 +1,40 @@
+package mcp
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/metrics"
+)
+
+// RegisterTools registers the server metrics MCP tools with the server
+func RegisterTools(s *server.MCPServer, m *metrics.Metrics, middleware ...mcpx.Middleware) error {
+	tools := []struct {
+		name        string
+		description string
+		handler     server.ToolHandlerFunc
+		schema      mcp.ToolInputSchema
+	}{
+		{
+			name:        "get_server_metrics",
+			description: "Get call counts, error counts and p50/p95 latencies for every tool called since the server started",
+			handler:     NewMetricsHandler(m),
+			schema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: map[string]interface{}{},
+			},
+		},
+	}
+
+	for _, tool := range tools {
+		t := mcp.Tool{
+			Name:        tool.name,
+			Description: tool.description,
+			InputSchema: tool.schema,
+		}
+		s.AddTool(t, mcpx.Wrap(tool.name, tool.handler, middleware...))
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+// Package metrics records per-tool call counts and latency histograms for the
+// MCP server.
+package metrics
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+)
+
+// latencyBuckets are the histogram upper bounds. Calls slower than the last
+// bound fall into an overflow bucket.
+var latencyBuckets = []time.Duration{
+	time.Millisecond,
+	2 * time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// ToolStats is a snapshot of the metrics for a single tool. Percentiles are
+// estimated from the histogram and report the upper bound of the bucket the
+// percentile falls in, capped at the slowest observed call.
+type ToolStats struct {
+	Tool   string  `json:"tool"`
+	Calls  int64   `json:"calls"`
+	Errors int64   `json:"errors"`
+	MeanMs float64 `json:"mean_ms"`
+	P50Ms  float64 `json:"p50_ms"`
+	P95Ms  float64 `json:"p95_ms"`
+	MaxMs  float64 `json:"max_ms"`
+}
+
+// toolMetrics accumulates the observations for one tool
+type toolMetrics struct {
+	calls   int64
+	errors  int64
+	total   time.Duration
+	max     time.Duration
+	buckets []int64
+}
+
+// Metrics records tool invocations. It is safe for concurrent use.
+type Metrics struct {
+	mu    sync.Mutex
+	tools map[string]*toolMetrics
+}
+
+// New creates an empty Metrics
+func New() *Metrics {
+	return &Metrics{tools: make(map[string]*toolMetrics)}
+}
+
+// Observe records one call of tool that took duration and failed if failed is set
+func (m *Metrics) Observe(tool string, duration time.Duration, failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tm, ok := m.tools[tool]
+	if !ok {
+		tm = &toolMetrics{buckets: make([]int64, len(latencyBuckets)+1)}
+		m.tools[tool] = tm
+	}
+
+	tm.calls++
+	if failed {
+		tm.errors++
+	}
+	tm.total += duration
+	if duration > tm.max {
+		tm.max = duration
+	}
+	tm.buckets[sort.Search(len(latencyBuckets), func(i int) bool { return duration <= latencyBuckets[i] })]++
+}
+
+// Snapshot returns the stats for every called tool, busiest first
+func (m *Metrics) Snapshot() []ToolStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := make([]ToolStats, 0, len(m.tools))
+	for tool, tm := range m.tools {
+		stats = append(stats, ToolStats{
+			Tool:   tool,
+			Calls:  tm.calls,
+			Errors: tm.errors,
+			MeanMs: milliseconds(tm.total / time.Duration(tm.calls)),
+			P50Ms:  milliseconds(tm.percentile(0.50)),
+			P95Ms:  milliseconds(tm.percentile(0.95)),
+			MaxMs:  milliseconds(tm.max),
+		})
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Calls != stats[j].Calls {
+			return stats[i].Calls > stats[j].Calls
+		}
+		return stats[i].Tool < stats[j].Tool
+	})
+	return stats
+}
+
+// WrapToolHandler records every invocation of handler under tool. A nil
+// Metrics returns handler unchanged.
+func (m *Metrics) WrapToolHandler(tool string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	if m == nil {
+		return handler
+	}
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		start := time.Now()
+		result, err := handler(ctx, req)
+		m.Observe(tool, time.Since(start), err != nil || (result != nil && result.IsError))
+		return result, err
+	}
+}
+
+// Middleware returns an mcpx.Middleware that records every tool call in m
+func (m *Metrics) Middleware() mcpx.Middleware {
+	return m.WrapToolHandler
+}
+
+// percentile returns the upper bound of the bucket holding quantile q
+func (tm *toolMetrics) percentile(q float64) time.Duration {
+	rank := int64(math.Ceil(q * float64(tm.calls)))
+	var seen int64
+	for i, count := range tm.buckets {
+		seen += count
+		if seen >= rank {
+			if i < len(latencyBuckets) && latencyBuckets[i] < tm.max {
+				return latencyBuckets[i]
+			}
+			return tm.max
+		}
+	}
+	return tm.max
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,102 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/metrics"
+)
+
+func TestMetrics_Snapshot(t *testing.T) {
+	m := metrics.New()
+
+	// 19 fast calls and one slow one: p50 stays fast, p95 lands in the 5ms bucket
+	for i := 0; i < 18; i++ {
+		m.Observe("list_notes", 800*time.Microsecond, false)
+	}
+	m.Observe("list_notes", 4*time.Millisecond, false)
+	m.Observe("list_notes", 300*time.Millisecond, true)
+	m.Observe("get_note", 3*time.Millisecond, false)
+
+	stats := m.Snapshot()
+	require.Len(t, stats, 2)
+
+	assert.Equal(t, "list_notes", stats[0].Tool)
+	assert.Equal(t, int64(20), stats[0].Calls)
+	assert.Equal(t, int64(1), stats[0].Errors)
+	assert.Equal(t, 1.0, stats[0].P50Ms)
+	assert.Equal(t, 5.0, stats[0].P95Ms)
+	assert.Equal(t, 300.0, stats[0].MaxMs)
+	assert.InDelta(t, 15.92, stats[0].MeanMs, 0.001)
+
+	// A percentile is never reported above the slowest call
+	assert.Equal(t, "get_note", stats[1].Tool)
+	assert.Equal(t, int64(1), stats[1].Calls)
+	assert.Equal(t, 3.0, stats[1].P50Ms)
+	assert.Equal(t, 3.0, stats[1].P95Ms)
+}
+
+func TestMetrics_SnapshotEmpty(t *testing.T) {
+	assert.Empty(t, metrics.New().Snapshot())
+}
+
+func TestMetrics_OverflowBucket(t *testing.T) {
+	m := metrics.New()
+	m.Observe("compute_pagerank", 42*time.Second, false)
+
+	stats := m.Snapshot()
+	require.Len(t, stats, 1)
+	assert.Equal(t, 42000.0, stats[0].P95Ms)
+}
+
+func TestMetrics_WrapToolHandler(t *testing.T) {
+	m := metrics.New()
+
+	ok := m.WrapToolHandler("get_note", func(context.Context, gomcp.CallToolRequest) (*gomcp.CallToolResult, error) {
+		return &gomcp.CallToolResult{}, nil
+	})
+	failed := m.WrapToolHandler("get_note", func(context.Context, gomcp.CallToolRequest) (*gomcp.CallToolResult, error) {
+		return nil, errors.New("note not found")
+	})
+	toolError := m.WrapToolHandler("get_note", func(context.Context, gomcp.CallToolRequest) (*gomcp.CallToolResult, error) {
+		return &gomcp.CallToolResult{IsError: true}, nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = ok(context.Background(), gomcp.CallToolRequest{})
+		}()
+	}
+	wg.Wait()
+
+	_, err := failed(context.Background(), gomcp.CallToolRequest{})
+	assert.EqualError(t, err, "note not found")
+	_, err = toolError(context.Background(), gomcp.CallToolRequest{})
+	assert.NoError(t, err)
+
+	stats := m.Snapshot()
+	require.Len(t, stats, 1)
+	assert.Equal(t, int64(12), stats[0].Calls)
+	assert.Equal(t, int64(2), stats[0].Errors)
+}
+
+func TestMetrics_WrapToolHandlerNil(t *testing.T) {
+	var m *metrics.Metrics
+	handler := m.WrapToolHandler("get_note", func(context.Context, gomcp.CallToolRequest) (*gomcp.CallToolResult, error) {
+		return &gomcp.CallToolResult{}, nil
+	})
+
+	result, err := handler(context.Background(), gomcp.CallToolRequest{})
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+}
@@ -1,9 +1,11 @@
 package ncruces
 
 import (
+	"database/sql"
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 // Config holds the configuration for the ncruces SQLite driver
@@ -67,6 +69,17 @@ func ParseConfig(rawurl string) (*Config, error) {
 				return nil, fmt.Errorf("invalid transaction mode: %s", txMode)
 			}
 		}
+
+		// Remaining parameters belong to the SQLite URI (e.g. vfs=memdb)
+		dsnValues := url.Values{}
+		for key, vals := range values {
+			if !strings.HasPrefix(key, "x-") {
+				dsnValues[key] = vals
+			}
+		}
+		if len(dsnValues) > 0 {
+			config.DatabaseName += "?" + dsnValues.Encode()
+		}
 	}
 
 	return config, nil
@@ -84,4 +97,18 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid transaction mode: %s", c.TxMode)
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// txOptions returns the options that begin a transaction in TxMode. SQLite
+// sets the mode with BEGIN IMMEDIATE or BEGIN EXCLUSIVE, which the ncruces
+// driver issues for the serializable and linearizable isolation levels.
+func (c *Config) txOptions() *sql.TxOptions {
+	switch c.TxMode {
+	case "IMMEDIATE":
+		return &sql.TxOptions{Isolation: sql.LevelSerializable}
+	case "EXCLUSIVE":
+		return &sql.TxOptions{Isolation: sql.LevelLinearizable}
+	default:
+		return nil
+	}
+}
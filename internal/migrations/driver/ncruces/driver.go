@@ -9,7 +9,8 @@ import (
 	"strings"
 
 	"github.com/golang-migrate/migrate/v4/database"
-	_ "github.com/ncruces/go-sqlite3/driver"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/sqlitedb"
 )
 
 // Driver implements the database.Driver interface for ncruces/go-sqlite3
@@ -35,10 +36,8 @@ func (d *Driver) Open(url string) (database.Driver, error) {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	// The ncruces driver is already registered via the import
-
 	// Open database connection
-	db, err := sql.Open("sqlite3", config.DatabaseName)
+	db, err := sqlitedb.Open(config.DatabaseName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -114,19 +113,12 @@ func (d *Driver) Run(migration io.Reader) error {
 
 // executeWithTransaction executes migration within a transaction
 func (d *Driver) executeWithTransaction(migration string) error {
-	tx, err := d.db.Begin()
+	tx, err := d.db.BeginTx(context.Background(), d.config.txOptions())
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
-	// Set transaction mode if specified
-	if d.config.TxMode != "DEFERRED" {
-		if _, err := tx.Exec(fmt.Sprintf("PRAGMA %s", d.config.TxMode)); err != nil {
-			return fmt.Errorf("failed to set transaction mode: %w", err)
-		}
-	}
-
 	// Execute migration
 	if _, err := tx.Exec(migration); err != nil {
 		return fmt.Errorf("failed to execute migration: %w", err)
@@ -2,8 +2,10 @@ package ncruces
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"sync"
@@ -785,6 +787,71 @@ func TestTransactionHandling(t *testing.T) {
 	}
 }
 
+// TestTransactionModes tests that migrations begin in the configured mode
+func TestTransactionModes(t *testing.T) {
+	tests := []struct {
+		name        string
+		txMode      string
+		wantBlocked bool // whether another writer's lock stops the migration from beginning
+	}{
+		{name: "deferred", txMode: "DEFERRED", wantBlocked: false},
+		{name: "immediate", txMode: "IMMEDIATE", wantBlocked: true},
+		{name: "exclusive", txMode: "EXCLUSIVE", wantBlocked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbPath := filepath.Join(t.TempDir(), "test.db")
+
+			driver, err := NewDriverWithConfig(&Config{
+				DatabaseName:    dbPath,
+				MigrationsTable: "schema_migrations",
+				TxMode:          tt.txMode,
+			})
+			require.NoError(t, err)
+			defer driver.Close()
+			require.Equal(t, tt.txMode, driver.config.TxMode)
+
+			t.Run("migration runs and commits", func(t *testing.T) {
+				err := driver.Run(bytes.NewReader([]byte(`
+					CREATE TABLE mode_test (id INTEGER PRIMARY KEY);
+					INSERT INTO mode_test (id) VALUES (1);`)))
+				require.NoError(t, err)
+
+				var count int
+				require.NoError(t, driver.db.QueryRow("SELECT COUNT(*) FROM mode_test").Scan(&count))
+				assert.Equal(t, 1, count)
+			})
+
+			t.Run("begin takes the write lock", func(t *testing.T) {
+				// Fail at once instead of waiting out the default busy timeout
+				driver.db.SetMaxOpenConns(1)
+				_, err := driver.db.Exec("PRAGMA busy_timeout = 0")
+				require.NoError(t, err)
+
+				writer, err := sql.Open("sqlite3", dbPath)
+				require.NoError(t, err)
+				defer writer.Close()
+				conn, err := writer.Conn(context.Background())
+				require.NoError(t, err)
+				defer conn.Close()
+				_, err = conn.ExecContext(context.Background(), "BEGIN IMMEDIATE")
+				require.NoError(t, err)
+				defer conn.ExecContext(context.Background(), "ROLLBACK")
+
+				// A read-only migration only needs the write lock if BEGIN takes it
+				err = driver.Run(bytes.NewReader([]byte("SELECT COUNT(*) FROM mode_test;")))
+				if tt.wantBlocked {
+					require.Error(t, err)
+					assert.Contains(t, err.Error(), "failed to begin transaction")
+				} else {
+					assert.NoError(t, err)
+				}
+			})
+		})
+	}
+}
+
 // TestSchemaInitialization tests database schema setup
 func TestSchemaInitialization(t *testing.T) {
 	tempDir, _ := os.MkdirTemp("", "schema-test-*")
@@ -981,18 +1048,23 @@ func NewDriver(dbPath string) (*Driver, error) {
 // NewDriverWithConfig creates a new driver instance with custom configuration
 func NewDriverWithConfig(config *Config) (*Driver, error) {
 	driver := &Driver{}
-	url := fmt.Sprintf("sqlite3://%s", config.DatabaseName)
+	params := url.Values{}
 	if config.MigrationsTable != "schema_migrations" {
-		url += fmt.Sprintf("?x-migrations-table=%s", config.MigrationsTable)
+		params.Set("x-migrations-table", config.MigrationsTable)
 	}
 	if config.NoTxWrap {
-		url += "&x-no-tx-wrap=true"
+		params.Set("x-no-tx-wrap", "true")
 	}
 	if config.TxMode != "DEFERRED" {
-		url += fmt.Sprintf("&x-tx-mode=%s", config.TxMode)
+		params.Set("x-tx-mode", config.TxMode)
+	}
+
+	dsn := fmt.Sprintf("sqlite3://%s", config.DatabaseName)
+	if len(params) > 0 {
+		dsn += "?" + params.Encode()
 	}
 
-	result, err := driver.Open(url)
+	result, err := driver.Open(dsn)
 	if err != nil {
 		return nil, err
 	}
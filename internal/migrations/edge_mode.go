@@ -0,0 +1,63 @@
+package migrations
+
+import (
+	"fmt"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/sqlitedb"
+)
+
+// WithEdgeMode makes RunMigrations leave idx_connections_unique in place for
+// the unique edge mode and drop it for the multi mode, which stores parallel
+// connections of the same type. An empty mode keeps whatever the database has.
+func WithEdgeMode(mode connection.EdgeMode) Option {
+	return func(mr *MigrationRunner) {
+		mr.edgeMode = mode
+	}
+}
+
+// applyEdgeMode creates or drops idx_connections_unique to match the
+// configured edge mode. Creating it fails while parallel connections exist,
+// for example after running in the multi mode, and the error names how many
+// note pairs have them so the operator can delete the extras first.
+func (mr *MigrationRunner) applyEdgeMode() error {
+	if mr.edgeMode == "" {
+		return nil
+	}
+
+	db, err := sqlitedb.Open(mr.dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	switch mr.edgeMode {
+	case connection.EdgeModeMulti:
+		if _, err := db.Exec("DROP INDEX IF EXISTS idx_connections_unique"); err != nil {
+			return fmt.Errorf("failed to drop the unique connection index: %w", err)
+		}
+		return nil
+	case connection.EdgeModeUnique:
+		var duplicated int64
+		err := db.QueryRow(`
+			SELECT COUNT(*) FROM (
+				SELECT 1 FROM connections
+				GROUP BY from_note_id, to_note_id, type
+				HAVING COUNT(*) > 1
+			)
+		`).Scan(&duplicated)
+		if err != nil {
+			return fmt.Errorf("failed to check for parallel connections: %w", err)
+		}
+		if duplicated > 0 {
+			return fmt.Errorf("cannot use the unique edge mode: %d note pairs have parallel connections of the same type, delete the extras with the multi edge mode first", duplicated)
+		}
+
+		if _, err := db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_connections_unique ON connections(from_note_id, to_note_id, type)"); err != nil {
+			return fmt.Errorf("failed to create the unique connection index: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid edge mode: %s", mr.edgeMode)
+	}
+}
@@ -1,36 +1,142 @@
 package migrations
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
 	// Import the ncruces SQLite driver for go-migrate
 
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/source"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
 
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
 	_ "github.com/red1r3ct/knowledge-graph-mcp/internal/migrations/driver/ncruces"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/sqlitedb"
 )
 
+// migrationFilePattern matches golang-migrate file names such as 000001_create_notes.up.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_[^.]+\.(up|down)\.sql$`)
+
 // MigrationRunner handles database migrations using golang-migrate
 type MigrationRunner struct {
 	dbPath string
+	// source holds the migration files in sourceDir
+	source    fs.FS
+	sourceDir string
+	// noteTokenizer is the tokenizer notes_fts is left with; empty keeps it as it is
+	noteTokenizer NoteTokenizer
+	// edgeMode decides whether idx_connections_unique is kept; empty keeps it as it is
+	edgeMode connection.EdgeMode
+}
+
+// NewMigrationRunner creates a new migration runner instance that uses the
+// migrations embedded in the binary
+func NewMigrationRunner(dbPath string, opts ...Option) *MigrationRunner {
+	mr := &MigrationRunner{
+		dbPath:    dbPath,
+		source:    MigrationsFS,
+		sourceDir: "sqlite",
+	}
+	for _, opt := range opts {
+		opt(mr)
+	}
+	return mr
+}
+
+// NewMigrationRunnerFromDir creates a migration runner that reads migrations
+// from migrationsDir instead of the binary, so operators can patch them without
+// rebuilding. An empty migrationsDir selects the embedded migrations. The
+// directory must hold at least one NNN_name.up.sql file, and every .sql file
+// in it must follow the NNN_name.up.sql or NNN_name.down.sql pattern.
+func NewMigrationRunnerFromDir(dbPath, migrationsDir string, opts ...Option) (*MigrationRunner, error) {
+	if migrationsDir == "" {
+		return NewMigrationRunner(dbPath, opts...), nil
+	}
+
+	source := os.DirFS(migrationsDir)
+	if err := validateMigrationsDir(source, migrationsDir); err != nil {
+		return nil, err
+	}
+
+	mr := &MigrationRunner{
+		dbPath:    dbPath,
+		source:    source,
+		sourceDir: ".",
+	}
+	for _, opt := range opts {
+		opt(mr)
+	}
+	return mr, nil
+}
+
+// validateMigrationsDir checks that dir holds well-named migration files with
+// one up migration per version
+func validateMigrationsDir(source fs.FS, dir string) error {
+	entries, err := fs.ReadDir(source, ".")
+	if err != nil {
+		return fmt.Errorf("failed to read migrations directory %q: %w", dir, err)
+	}
+
+	upVersions := make(map[uint64]string)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".sql" {
+			continue
+		}
+
+		match := migrationFilePattern.FindStringSubmatch(name)
+		if match == nil {
+			return fmt.Errorf("invalid migration file name %q in %q: expected NNN_name.up.sql or NNN_name.down.sql", name, dir)
+		}
+		if match[2] != "up" {
+			continue
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid migration version in %q: %w", name, err)
+		}
+		if other, ok := upVersions[version]; ok {
+			return fmt.Errorf("duplicate migration version %d in %q: %s and %s", version, dir, other, name)
+		}
+		upVersions[version] = name
+	}
+
+	if len(upVersions) == 0 {
+		return fmt.Errorf("migrations directory %q has no NNN_name.up.sql files", dir)
+	}
+
+	return nil
 }
 
-// NewMigrationRunner creates a new migration runner instance
-func NewMigrationRunner(dbPath string) *MigrationRunner {
-	return &MigrationRunner{
-		dbPath: dbPath,
+// sourceDriver opens the runner's migration files for golang-migrate
+func (mr *MigrationRunner) sourceDriver() (source.Driver, error) {
+	sourceDriver, err := iofs.New(mr.source, mr.sourceDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create source driver: %w", err)
 	}
+	return sourceDriver, nil
 }
 
-// RunMigrations runs all pending migrations up to the latest version
+// RunMigrations runs all pending migrations up to the latest version, then
+// rebuilds notes_fts if it does not use the configured note tokenizer and
+// creates or drops the unique connection index for the configured edge mode
 func (mr *MigrationRunner) RunMigrations() error {
 	// Create database URL for SQLite
-	dbURL := fmt.Sprintf("sqlite3://%s", mr.dbPath)
+	dbURL, err := databaseURL(mr.dbPath)
+	if err != nil {
+		return err
+	}
 
-	// Create source driver from embedded filesystem
-	sourceDriver, err := iofs.New(MigrationsFS, "sqlite")
+	// Create source driver from the migration files
+	sourceDriver, err := mr.sourceDriver()
 	if err != nil {
-		return fmt.Errorf("failed to create source driver: %w", err)
+		return err
 	}
 
 	// Create migrate instance
@@ -45,18 +151,24 @@ func (mr *MigrationRunner) RunMigrations() error {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
-	return nil
+	if err := mr.applyNoteTokenizer(); err != nil {
+		return err
+	}
+	return mr.applyEdgeMode()
 }
 
 // GetVersion returns the current migration version
 func (mr *MigrationRunner) GetVersion() (uint, bool, error) {
 	// Create database URL for SQLite
-	dbURL := fmt.Sprintf("sqlite3://%s", mr.dbPath)
+	dbURL, err := databaseURL(mr.dbPath)
+	if err != nil {
+		return 0, false, err
+	}
 
-	// Create source driver from embedded filesystem
-	sourceDriver, err := iofs.New(MigrationsFS, "sqlite")
+	// Create source driver from the migration files
+	sourceDriver, err := mr.sourceDriver()
 	if err != nil {
-		return 0, false, fmt.Errorf("failed to create source driver: %w", err)
+		return 0, false, err
 	}
 
 	// Create migrate instance
@@ -76,3 +188,41 @@ func (mr *MigrationRunner) GetVersion() (uint, bool, error) {
 
 	return version, dirty, nil
 }
+
+// LatestVersion returns the highest migration version in the runner's source,
+// which GetVersion reports once every migration has been applied
+func (mr *MigrationRunner) LatestVersion() (uint, error) {
+	sourceDriver, err := mr.sourceDriver()
+	if err != nil {
+		return 0, err
+	}
+	defer sourceDriver.Close()
+
+	version, err := sourceDriver.First()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read first migration: %w", err)
+	}
+	for {
+		next, err := sourceDriver.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read migration after %d: %w", version, err)
+		}
+		version = next
+	}
+}
+
+// databaseURL builds the golang-migrate URL for dbPath. Shared in-memory DSNs
+// are resolved so migrations reach the same database as the storages.
+func databaseURL(dbPath string) (string, error) {
+	dsn, kind, err := sqlitedb.Resolve(dbPath)
+	if err != nil {
+		return "", err
+	}
+	if kind == sqlitedb.PrivateMemory {
+		return "", fmt.Errorf("cannot migrate private in-memory database %q from a separate connection, use a shared one such as file::memory:?cache=shared", dbPath)
+	}
+	return fmt.Sprintf("sqlite3://%s", dsn), nil
+}
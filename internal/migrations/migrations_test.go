@@ -2,15 +2,19 @@ package migrations_test
 
 import (
 	"database/sql"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
 	_ "github.com/ncruces/go-sqlite3/driver"
 	_ "github.com/ncruces/go-sqlite3/embed"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/migrations"
 )
 
@@ -62,6 +66,21 @@ func TestMigrationRunner(t *testing.T) {
 		assert.False(t, dirty)
 		assert.Greater(t, version, uint(0))
 	})
+
+	t.Run("LatestVersion matches the applied version", func(t *testing.T) {
+		runner := migrations.NewMigrationRunner(dbPath)
+
+		latest, err := runner.LatestVersion()
+		require.NoError(t, err)
+
+		version, _, err := runner.GetVersion()
+		require.NoError(t, err)
+		assert.Equal(t, version, latest)
+
+		files, err := migrations.MigrationsFS.ReadDir("sqlite")
+		require.NoError(t, err)
+		assert.Equal(t, uint(len(files)/2), latest)
+	})
 }
 
 func TestMigrationRunner_InvalidPath(t *testing.T) {
@@ -136,3 +155,559 @@ func TestMigrationRunner_Concurrent(t *testing.T) {
 	assert.False(t, dirty)
 	assert.Greater(t, version, uint(0))
 }
+
+func TestConnectionCountsMigration(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	sourceDriver, err := iofs.New(migrations.MigrationsFS, "sqlite")
+	require.NoError(t, err)
+	m, err := migrate.NewWithSourceInstance("iofs", sourceDriver, "sqlite3://"+dbPath)
+	require.NoError(t, err)
+	defer m.Close()
+
+	require.NoError(t, m.Migrate(11))
+
+	db, err := sql.Open("sqlite3", dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`
+		INSERT INTO notes (id, title, content, type, tags, metadata, updated_at) VALUES
+			(1, 'A', 'a', 'text', '[]', '{}', '2000-01-01 00:00:00'),
+			(2, 'B', 'b', 'text', '[]', '{}', '2000-01-01 00:00:00'),
+			(3, 'C', 'c', 'text', '[]', '{}', '2000-01-01 00:00:00');
+		INSERT INTO connections (from_note_id, to_note_id, type, strength) VALUES
+			(1, 2, 'relates_to', 5), (1, 3, 'supports', 5), (2, 3, 'relates_to', 5)`)
+	require.NoError(t, err)
+
+	require.NoError(t, m.Migrate(12))
+
+	t.Run("backfills counts without touching updated_at", func(t *testing.T) {
+		rows, err := db.Query("SELECT incoming_count, outgoing_count, updated_at FROM notes ORDER BY id")
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var got [][2]int64
+		for rows.Next() {
+			var counts [2]int64
+			var updatedAt string
+			require.NoError(t, rows.Scan(&counts[0], &counts[1], &updatedAt))
+			assert.Contains(t, updatedAt, "2000-01-01")
+			got = append(got, counts)
+		}
+		require.NoError(t, rows.Err())
+		assert.Equal(t, [][2]int64{{0, 2}, {1, 1}, {2, 0}}, got)
+	})
+
+	t.Run("down drops the columns", func(t *testing.T) {
+		require.NoError(t, m.Migrate(11))
+
+		var columns int
+		require.NoError(t, db.QueryRow(
+			"SELECT COUNT(*) FROM pragma_table_info('notes') WHERE name IN ('incoming_count', 'outgoing_count')").Scan(&columns))
+		assert.Equal(t, 0, columns)
+
+		// The restored triggers still allow connections
+		_, err := db.Exec("INSERT INTO connections (from_note_id, to_note_id, type, strength) VALUES (3, 1, 'relates_to', 5)")
+		assert.NoError(t, err)
+	})
+}
+
+func TestNoteTypeCheckMigration(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	sourceDriver, err := iofs.New(migrations.MigrationsFS, "sqlite")
+	require.NoError(t, err)
+	m, err := migrate.NewWithSourceInstance("iofs", sourceDriver, "sqlite3://"+dbPath)
+	require.NoError(t, err)
+	defer m.Close()
+
+	require.NoError(t, m.Migrate(16))
+
+	db, err := sql.Open("sqlite3", dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`
+		INSERT INTO notes (id, title, content, type, tags, metadata) VALUES
+			(1, 'A', 'a', 'text', '[]', '{}'),
+			(2, 'B', 'b', 'code', '[]', '{}');
+		INSERT INTO connections (from_note_id, to_note_id, type, strength) VALUES (1, 2, 'relates_to', 5);
+		INSERT INTO note_bookmarks (session, note_id) VALUES ('s', 2)`)
+	require.NoError(t, err)
+
+	_, err = db.Exec("INSERT INTO notes (title, content, type) VALUES ('C', 'c', 'dataset')")
+	require.ErrorContains(t, err, "CHECK constraint failed")
+
+	count := func(query string) int {
+		var n int
+		require.NoError(t, db.QueryRow(query).Scan(&n))
+		return n
+	}
+
+	require.NoError(t, m.Migrate(17))
+
+	t.Run("up allows custom types and keeps related rows", func(t *testing.T) {
+		// A connection opened before the migration sees the new schema too
+		_, err := db.Exec("INSERT INTO notes (id, title, content, type) VALUES (3, 'C', 'c', 'dataset')")
+		require.NoError(t, err)
+
+		_, err = db.Exec("INSERT INTO notes (title, content, type) VALUES ('D', 'd', '')")
+		assert.ErrorContains(t, err, "CHECK constraint failed")
+
+		assert.Equal(t, 1, count("SELECT COUNT(*) FROM connections"))
+		assert.Equal(t, 1, count("SELECT COUNT(*) FROM note_bookmarks"))
+		assert.Equal(t, 1, count("SELECT COUNT(*) FROM notes_fts WHERE notes_fts MATCH 'c'"))
+
+		var integrity string
+		require.NoError(t, db.QueryRow("PRAGMA integrity_check").Scan(&integrity))
+		assert.Equal(t, "ok", integrity)
+	})
+
+	t.Run("down turns custom types into text", func(t *testing.T) {
+		require.NoError(t, m.Migrate(16))
+
+		var noteType string
+		require.NoError(t, db.QueryRow("SELECT type FROM notes WHERE id = 3").Scan(&noteType))
+		assert.Equal(t, "text", noteType)
+		assert.Equal(t, 3, count("SELECT COUNT(*) FROM notes"))
+		assert.Equal(t, 1, count("SELECT COUNT(*) FROM connections"))
+
+		_, err := db.Exec("INSERT INTO notes (title, content, type) VALUES ('E', 'e', 'dataset')")
+		assert.ErrorContains(t, err, "CHECK constraint failed")
+	})
+}
+
+func TestNoteFTSTriggerMigration(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	sourceDriver, err := iofs.New(migrations.MigrationsFS, "sqlite")
+	require.NoError(t, err)
+	m, err := migrate.NewWithSourceInstance("iofs", sourceDriver, "sqlite3://"+dbPath)
+	require.NoError(t, err)
+	defer m.Close()
+
+	require.NoError(t, m.Migrate(18))
+
+	db, err := sql.Open("sqlite3", dbPath)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(`
+		INSERT INTO notes (id, title, content, type, tags, metadata) VALUES
+			(1, 'A', 'alpha', 'text', '[]', '{}'),
+			(2, 'B', 'bravo', 'text', '[]', '{}')`)
+	require.NoError(t, err)
+
+	count := func(query string) int {
+		var n int
+		require.NoError(t, db.QueryRow(query).Scan(&n))
+		return n
+	}
+
+	// The old delete trigger leaves the deleted note's terms in the index
+	_, err = db.Exec("DELETE FROM notes WHERE id = 2")
+	require.NoError(t, err)
+	require.Equal(t, 1, count("SELECT COUNT(*) FROM notes_fts WHERE notes_fts MATCH 'bravo'"))
+
+	require.NoError(t, m.Migrate(19))
+
+	t.Run("up rebuilds the index", func(t *testing.T) {
+		assert.Equal(t, 0, count("SELECT COUNT(*) FROM notes_fts WHERE notes_fts MATCH 'bravo'"))
+		assert.Equal(t, 1, count("SELECT COUNT(*) FROM notes_fts WHERE notes_fts MATCH 'alpha'"))
+	})
+
+	t.Run("updates replace the indexed content", func(t *testing.T) {
+		_, err := db.Exec("UPDATE notes SET content = 'charlie' WHERE id = 1")
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, count("SELECT COUNT(*) FROM notes_fts WHERE notes_fts MATCH 'alpha'"))
+		assert.Equal(t, 1, count("SELECT COUNT(*) FROM notes_fts WHERE notes_fts MATCH 'charlie'"))
+
+		_, err = db.Exec("UPDATE notes SET title = 'Delta' WHERE id = 1")
+		require.NoError(t, err)
+		assert.Equal(t, 1, count("SELECT COUNT(*) FROM notes_fts WHERE notes_fts MATCH 'delta'"))
+	})
+
+	t.Run("deletes remove the indexed content", func(t *testing.T) {
+		_, err := db.Exec("DELETE FROM notes WHERE id = 1")
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, count("SELECT COUNT(*) FROM notes_fts WHERE notes_fts MATCH 'charlie'"))
+
+		_, err = db.Exec("INSERT INTO notes_fts(notes_fts) VALUES ('integrity-check')")
+		assert.NoError(t, err)
+	})
+
+	t.Run("down restores the old triggers", func(t *testing.T) {
+		require.NoError(t, m.Migrate(18))
+
+		var triggerSQL string
+		require.NoError(t, db.QueryRow("SELECT sql FROM sqlite_master WHERE type = 'trigger' AND name = 'notes_fts_update'").Scan(&triggerSQL))
+		assert.Contains(t, triggerSQL, "UPDATE notes_fts SET")
+	})
+}
+
+func TestNewMigrationRunnerFromDir(t *testing.T) {
+	// writeDir creates a migrations directory holding files, keyed by name
+	writeDir := func(t *testing.T, files map[string]string) string {
+		dir := t.TempDir()
+		for name, content := range files {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+		}
+		return dir
+	}
+
+	tests := []struct {
+		name    string
+		dir     func(t *testing.T) string
+		wantErr string
+	}{
+		{
+			name:    "missing directory",
+			dir:     func(t *testing.T) string { return filepath.Join(t.TempDir(), "missing") },
+			wantErr: "failed to read migrations directory",
+		},
+		{
+			name: "file instead of a directory",
+			dir: func(t *testing.T) string {
+				return filepath.Join(writeDir(t, map[string]string{"001_init.up.sql": ""}), "001_init.up.sql")
+			},
+			wantErr: "failed to read migrations directory",
+		},
+		{
+			name:    "empty directory",
+			dir:     func(t *testing.T) string { return t.TempDir() },
+			wantErr: "has no NNN_name.up.sql files",
+		},
+		{
+			name: "only down migrations",
+			dir: func(t *testing.T) string {
+				return writeDir(t, map[string]string{"001_init.down.sql": "", "README.md": "notes"})
+			},
+			wantErr: "has no NNN_name.up.sql files",
+		},
+		{
+			name: "badly named migration",
+			dir: func(t *testing.T) string {
+				return writeDir(t, map[string]string{"001_init.up.sql": "", "init_v2.sql": ""})
+			},
+			wantErr: `invalid migration file name "init_v2.sql"`,
+		},
+		{
+			name: "duplicate version",
+			dir: func(t *testing.T) string {
+				return writeDir(t, map[string]string{"001_init.up.sql": "", "1_other.up.sql": ""})
+			},
+			wantErr: "duplicate migration version 1",
+		},
+		{
+			name: "valid directory with other files",
+			dir: func(t *testing.T) string {
+				return writeDir(t, map[string]string{"001_init.up.sql": "", "001_init.down.sql": "", "README.md": "notes"})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner, err := migrations.NewMigrationRunnerFromDir(filepath.Join(t.TempDir(), "test.db"), tt.dir(t))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Nil(t, runner)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, runner)
+		})
+	}
+}
+
+func TestMigrationRunnerSources(t *testing.T) {
+	embedded, err := migrations.NewMigrationRunner(filepath.Join(t.TempDir(), "embedded.db")).LatestVersion()
+	require.NoError(t, err)
+
+	// patchedDir holds the embedded migrations plus one patched in by an operator
+	patchedDir := func(t *testing.T) string {
+		dir := t.TempDir()
+		files, err := migrations.MigrationsFS.ReadDir("sqlite")
+		require.NoError(t, err)
+		for _, f := range files {
+			data, err := migrations.MigrationsFS.ReadFile("sqlite/" + f.Name())
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(filepath.Join(dir, f.Name()), data, 0o644))
+		}
+		next := fmt.Sprintf("%06d_create_operator_table", embedded+1)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, next+".up.sql"), []byte("CREATE TABLE operator_patch (id INTEGER PRIMARY KEY);"), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, next+".down.sql"), []byte("DROP TABLE operator_patch;"), 0o644))
+		return dir
+	}
+
+	tests := []struct {
+		name        string
+		dir         func(t *testing.T) string
+		wantVersion uint
+		wantPatch   bool
+	}{
+		{name: "no directory uses embedded migrations", dir: func(t *testing.T) string { return "" }, wantVersion: embedded},
+		{name: "external directory", dir: patchedDir, wantVersion: embedded + 1, wantPatch: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbPath := filepath.Join(t.TempDir(), "test.db")
+			runner, err := migrations.NewMigrationRunnerFromDir(dbPath, tt.dir(t))
+			require.NoError(t, err)
+
+			latest, err := runner.LatestVersion()
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantVersion, latest)
+
+			require.NoError(t, runner.RunMigrations())
+			version, dirty, err := runner.GetVersion()
+			require.NoError(t, err)
+			assert.False(t, dirty)
+			assert.Equal(t, tt.wantVersion, version)
+
+			db, err := sql.Open("sqlite3", dbPath)
+			require.NoError(t, err)
+			defer db.Close()
+
+			var count int
+			require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name IN ('notes', 'operator_patch')").Scan(&count))
+			if tt.wantPatch {
+				assert.Equal(t, 2, count)
+			} else {
+				assert.Equal(t, 1, count)
+			}
+		})
+	}
+}
+
+func TestParseNoteTokenizer(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    migrations.NoteTokenizer
+		wantErr string
+	}{
+		{name: "empty keeps the database's", input: "", want: ""},
+		{name: "default", input: "default", want: migrations.NoteTokenizerDefault},
+		{name: "unicode61 any case", input: " Unicode61 ", want: migrations.NoteTokenizerUnicode61},
+		{name: "unknown", input: "porter", wantErr: "invalid note tokenizer: porter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := migrations.ParseNoteTokenizer(tt.input)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMigrationRunner_NoteTokenizer(t *testing.T) {
+	// searchNotes returns the IDs of notes matching query in notes_fts
+	searchNotes := func(t *testing.T, db *sql.DB, query string) []int64 {
+		rows, err := db.Query("SELECT rowid FROM notes_fts WHERE notes_fts MATCH ? ORDER BY rowid", query)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		ids := []int64{}
+		for rows.Next() {
+			var id int64
+			require.NoError(t, rows.Scan(&id))
+			ids = append(ids, id)
+		}
+		require.NoError(t, rows.Err())
+		return ids
+	}
+
+	tests := []struct {
+		name string
+		// runs are the tokenizers of successive RunMigrations calls; notes are
+		// added after the first one
+		runs     []migrations.NoteTokenizer
+		wantViet []int64
+		wantCafe []int64
+		// wantTokenize is the tokenize option of notes_fts, empty for none
+		wantTokenize string
+	}{
+		{
+			name:     "no option keeps the default",
+			runs:     []migrations.NoteTokenizer{""},
+			wantViet: []int64{},
+			wantCafe: []int64{2},
+		},
+		{
+			name:         "unicode61 on a fresh database",
+			runs:         []migrations.NoteTokenizer{migrations.NoteTokenizerUnicode61},
+			wantViet:     []int64{1},
+			wantCafe:     []int64{2},
+			wantTokenize: "tokenize='unicode61 remove_diacritics 2'",
+		},
+		{
+			name:         "unicode61 reindexes an existing database",
+			runs:         []migrations.NoteTokenizer{"", migrations.NoteTokenizerUnicode61},
+			wantViet:     []int64{1},
+			wantCafe:     []int64{2},
+			wantTokenize: "tokenize='unicode61 remove_diacritics 2'",
+		},
+		{
+			name:         "no option keeps unicode61",
+			runs:         []migrations.NoteTokenizer{migrations.NoteTokenizerUnicode61, ""},
+			wantViet:     []int64{1},
+			wantCafe:     []int64{2},
+			wantTokenize: "tokenize='unicode61 remove_diacritics 2'",
+		},
+		{
+			name:     "default switches back",
+			runs:     []migrations.NoteTokenizer{migrations.NoteTokenizerUnicode61, migrations.NoteTokenizerDefault},
+			wantViet: []int64{},
+			wantCafe: []int64{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbPath := filepath.Join(t.TempDir(), "test.db")
+			db, err := sql.Open("sqlite3", dbPath)
+			require.NoError(t, err)
+			defer db.Close()
+
+			for i, tokenizer := range tt.runs {
+				require.NoError(t, migrations.NewMigrationRunner(dbPath, migrations.WithNoteTokenizer(tokenizer)).RunMigrations())
+				if i == 0 {
+					_, err := db.Exec(`
+						INSERT INTO notes (id, title, content, type) VALUES
+							(1, 'Tiếng Việt', 'Ghi chú bằng tiếng Việt', 'text'),
+							(2, 'Café', 'Une crème brûlée au café', 'text')`)
+					require.NoError(t, err)
+				}
+			}
+
+			var createSQL string
+			require.NoError(t, db.QueryRow("SELECT sql FROM sqlite_master WHERE name = 'notes_fts'").Scan(&createSQL))
+			if tt.wantTokenize == "" {
+				assert.NotContains(t, createSQL, "tokenize")
+			} else {
+				assert.Contains(t, createSQL, tt.wantTokenize)
+			}
+
+			assert.Equal(t, tt.wantViet, searchNotes(t, db, "viet"))
+			assert.Equal(t, tt.wantCafe, searchNotes(t, db, "cafe"))
+
+			// The sync triggers still reach the recreated table
+			_, err = db.Exec("INSERT INTO notes (id, title, content, type) VALUES (3, 'Phở', 'A dish from Hanoi', 'text')")
+			require.NoError(t, err)
+			assert.Equal(t, []int64{3}, searchNotes(t, db, "hanoi"))
+		})
+	}
+
+	t.Run("missing notes_fts", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "001_init.up.sql"), []byte("CREATE TABLE notes (id INTEGER PRIMARY KEY);"), 0o644))
+		runner, err := migrations.NewMigrationRunnerFromDir(filepath.Join(t.TempDir(), "test.db"), dir, migrations.WithNoteTokenizer(migrations.NoteTokenizerUnicode61))
+		require.NoError(t, err)
+
+		assert.ErrorContains(t, runner.RunMigrations(), "notes_fts table does not exist")
+	})
+}
+
+func TestMigrationRunner_EdgeMode(t *testing.T) {
+	// hasUniqueIndex reports whether idx_connections_unique exists
+	hasUniqueIndex := func(t *testing.T, db *sql.DB) bool {
+		var count int
+		require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'index' AND name = 'idx_connections_unique'").Scan(&count))
+		return count == 1
+	}
+
+	tests := []struct {
+		name string
+		// runs are the edge modes of successive RunMigrations calls; notes are
+		// added after the first one, and a parallel connection after the
+		// first multi run when parallel is set
+		runs      []connection.EdgeMode
+		parallel  bool
+		wantIndex bool
+		wantErr   string
+	}{
+		{
+			name:      "no option keeps the index",
+			runs:      []connection.EdgeMode{""},
+			wantIndex: true,
+		},
+		{
+			name:      "unique keeps the index",
+			runs:      []connection.EdgeMode{connection.EdgeModeUnique},
+			wantIndex: true,
+		},
+		{
+			name: "multi drops the index",
+			runs: []connection.EdgeMode{connection.EdgeModeMulti},
+		},
+		{
+			name: "no option keeps multi",
+			runs: []connection.EdgeMode{connection.EdgeModeMulti, ""},
+		},
+		{
+			name:      "unique recreates the index",
+			runs:      []connection.EdgeMode{connection.EdgeModeMulti, connection.EdgeModeUnique},
+			wantIndex: true,
+		},
+		{
+			name:     "unique refuses parallel connections",
+			runs:     []connection.EdgeMode{connection.EdgeModeMulti, connection.EdgeModeUnique},
+			parallel: true,
+			wantErr:  "cannot use the unique edge mode: 1 note pairs have parallel connections",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbPath := filepath.Join(t.TempDir(), "test.db")
+			db, err := sql.Open("sqlite3", dbPath)
+			require.NoError(t, err)
+			defer db.Close()
+
+			const insertConnection = "INSERT INTO connections (from_note_id, to_note_id, type, strength) VALUES (1, 2, 'supports', 5)"
+			var runErr error
+			for i, mode := range tt.runs {
+				runErr = migrations.NewMigrationRunner(dbPath, migrations.WithEdgeMode(mode)).RunMigrations()
+				if i < len(tt.runs)-1 {
+					require.NoError(t, runErr)
+				}
+				if i == 0 {
+					_, err := db.Exec("INSERT INTO notes (id, title, content, type) VALUES (1, 'A', 'a', 'text'), (2, 'B', 'b', 'text')")
+					require.NoError(t, err)
+					_, err = db.Exec(insertConnection)
+					require.NoError(t, err)
+					if tt.parallel {
+						_, err = db.Exec(insertConnection)
+						require.NoError(t, err)
+					}
+				}
+			}
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, runErr, tt.wantErr)
+				return
+			}
+			require.NoError(t, runErr)
+			assert.Equal(t, tt.wantIndex, hasUniqueIndex(t, db))
+
+			// Without the index the database accepts a parallel connection
+			_, err = db.Exec(insertConnection)
+			if tt.wantIndex {
+				assert.ErrorContains(t, err, "UNIQUE constraint failed")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+-- Move connection type validation to the application so operators can
+-- configure custom types. SQLite cannot drop a CHECK constraint, so the
+-- table is rebuilt without it.
+-- The strength CHECK must match connection.MinStrength and
+-- connection.MaxStrength, which the application validates against.
+CREATE TABLE connections_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    from_note_id INTEGER NOT NULL,
+    to_note_id INTEGER NOT NULL,
+    type TEXT NOT NULL CHECK (length(type) > 0),
+    description TEXT,
+    strength INTEGER NOT NULL CHECK (strength >= 1 AND strength <= 10) DEFAULT 5,
+    metadata TEXT, -- JSON object for additional properties
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (from_note_id) REFERENCES notes(id) ON DELETE CASCADE,
+    FOREIGN KEY (to_note_id) REFERENCES notes(id) ON DELETE CASCADE
+);
+
+INSERT INTO connections_new (id, from_note_id, to_note_id, type, description, strength, metadata, created_at, updated_at)
+SELECT id, from_note_id, to_note_id, type, description, strength, metadata, created_at, updated_at
+FROM connections;
+
+DROP TABLE connections;
+
+ALTER TABLE connections_new RENAME TO connections;
+
+-- Recreate indexes
+CREATE UNIQUE INDEX IF NOT EXISTS idx_connections_unique 
+ON connections(from_note_id, to_note_id, type);
+CREATE INDEX IF NOT EXISTS idx_connections_from_note_id ON connections(from_note_id);
+CREATE INDEX IF NOT EXISTS idx_connections_to_note_id ON connections(to_note_id);
+CREATE INDEX IF NOT EXISTS idx_connections_type ON connections(type);
+CREATE INDEX IF NOT EXISTS idx_connections_strength ON connections(strength);
+CREATE INDEX IF NOT EXISTS idx_connections_created_at ON connections(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_connections_updated_at ON connections(updated_at DESC);
+CREATE INDEX IF NOT EXISTS idx_connections_from_to ON connections(from_note_id, to_note_id);
+
+-- Recreate triggers
+CREATE TRIGGER IF NOT EXISTS update_connections_updated_at 
+AFTER UPDATE ON connections
+FOR EACH ROW
+BEGIN
+    UPDATE connections SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS prevent_self_connection
+BEFORE INSERT ON connections
+FOR EACH ROW
+WHEN NEW.from_note_id = NEW.to_note_id
+BEGIN
+    SELECT RAISE(ABORT, 'Self-connections are not allowed');
+END;
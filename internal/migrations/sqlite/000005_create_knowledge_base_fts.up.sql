@@ -0,0 +1,27 @@
+-- Create full-text search virtual table for knowledge bases.
+-- remove_diacritics folds accents so "cafe" matches "Café".
+CREATE VIRTUAL TABLE IF NOT EXISTS knowledge_base_fts USING fts5(
+    name,
+    description,
+    content='knowledge_base',
+    content_rowid='id',
+    tokenize='unicode61 remove_diacritics 2'
+);
+
+-- Index existing rows
+INSERT INTO knowledge_base_fts(knowledge_base_fts) VALUES ('rebuild');
+
+-- Create triggers to keep FTS table in sync with knowledge_base table.
+-- External content tables must be told the old values when a row changes.
+CREATE TRIGGER IF NOT EXISTS knowledge_base_fts_insert AFTER INSERT ON knowledge_base BEGIN
+    INSERT INTO knowledge_base_fts(rowid, name, description) VALUES (NEW.id, NEW.name, NEW.description);
+END;
+
+CREATE TRIGGER IF NOT EXISTS knowledge_base_fts_update AFTER UPDATE ON knowledge_base BEGIN
+    INSERT INTO knowledge_base_fts(knowledge_base_fts, rowid, name, description) VALUES ('delete', OLD.id, OLD.name, OLD.description);
+    INSERT INTO knowledge_base_fts(rowid, name, description) VALUES (NEW.id, NEW.name, NEW.description);
+END;
+
+CREATE TRIGGER IF NOT EXISTS knowledge_base_fts_delete AFTER DELETE ON knowledge_base BEGIN
+    INSERT INTO knowledge_base_fts(knowledge_base_fts, rowid, name, description) VALUES ('delete', OLD.id, OLD.name, OLD.description);
+END;
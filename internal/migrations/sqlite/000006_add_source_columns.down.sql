@@ -0,0 +1,9 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_connections_source;
+DROP INDEX IF EXISTS idx_notes_source;
+DROP INDEX IF EXISTS idx_knowledge_base_source;
+
+-- Drop source columns
+ALTER TABLE connections DROP COLUMN source;
+ALTER TABLE notes DROP COLUMN source;
+ALTER TABLE knowledge_base DROP COLUMN source;
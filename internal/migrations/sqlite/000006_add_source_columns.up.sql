@@ -0,0 +1,9 @@
+-- Record who or what created each record, e.g. an agent name
+ALTER TABLE knowledge_base ADD COLUMN source TEXT;
+ALTER TABLE notes ADD COLUMN source TEXT;
+ALTER TABLE connections ADD COLUMN source TEXT;
+
+-- Create indexes for filtering by source
+CREATE INDEX IF NOT EXISTS idx_knowledge_base_source ON knowledge_base(source);
+CREATE INDEX IF NOT EXISTS idx_notes_source ON notes(source);
+CREATE INDEX IF NOT EXISTS idx_connections_source ON connections(source);
@@ -0,0 +1,5 @@
+-- Drop index
+DROP INDEX IF EXISTS idx_notes_knowledge_base_id;
+
+-- Drop knowledge base link
+ALTER TABLE notes DROP COLUMN knowledge_base_id;
@@ -0,0 +1,6 @@
+-- Link notes to the knowledge base they belong to; NULL means unassigned.
+-- Deleting a knowledge base clears the link in the storage layer.
+ALTER TABLE notes ADD COLUMN knowledge_base_id INTEGER;
+
+-- Create index for listing a knowledge base's notes and finding unassigned ones
+CREATE INDEX IF NOT EXISTS idx_notes_knowledge_base_id ON notes(knowledge_base_id);
@@ -0,0 +1,2 @@
+-- Drop case-insensitive title index
+DROP INDEX IF EXISTS idx_notes_title_nocase;
@@ -0,0 +1,3 @@
+-- Create case-insensitive title index for get_note_by_title; exact lookups
+-- keep using idx_notes_title_unique
+CREATE INDEX IF NOT EXISTS idx_notes_title_nocase ON notes(title COLLATE NOCASE);
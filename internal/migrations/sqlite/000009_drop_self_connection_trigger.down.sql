@@ -0,0 +1,9 @@
+-- Restore the blanket self-connection trigger; existing self-connections are
+-- kept since the trigger only checks inserts
+CREATE TRIGGER IF NOT EXISTS prevent_self_connection
+BEFORE INSERT ON connections
+FOR EACH ROW
+WHEN NEW.from_note_id = NEW.to_note_id
+BEGIN
+    SELECT RAISE(ABORT, 'Self-connections are not allowed');
+END;
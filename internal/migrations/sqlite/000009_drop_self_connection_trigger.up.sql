@@ -0,0 +1,3 @@
+-- Self-connections are now allowed or rejected per connection type by the
+-- application, so the blanket trigger is dropped
+DROP TRIGGER IF EXISTS prevent_self_connection;
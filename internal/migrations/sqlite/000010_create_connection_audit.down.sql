@@ -0,0 +1,3 @@
+-- Drop connection audit log
+DROP INDEX IF EXISTS idx_connection_audit_connection_id;
+DROP TABLE IF EXISTS connection_audit;
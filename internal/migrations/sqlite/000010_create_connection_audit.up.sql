@@ -0,0 +1,12 @@
+-- Create the connection audit log. connection_id has no foreign key so the
+-- history of a connection survives its deletion.
+CREATE TABLE IF NOT EXISTS connection_audit (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    connection_id INTEGER NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('create', 'update', 'delete')),
+    changes TEXT NOT NULL DEFAULT '{}', -- JSON object of the recorded fields
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index for reading one connection's history in order
+CREATE INDEX IF NOT EXISTS idx_connection_audit_connection_id ON connection_audit(connection_id, id);
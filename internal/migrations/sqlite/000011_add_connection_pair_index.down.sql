@@ -0,0 +1,6 @@
+-- Restore the unique index in case the multi edge mode dropped it; this fails
+-- while parallel connections of the same type exist, so delete them before
+-- migrating down
+DROP INDEX IF EXISTS idx_connections_from_to_type;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_connections_unique
+ON connections(from_note_id, to_note_id, type);
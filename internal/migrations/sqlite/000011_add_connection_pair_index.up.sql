@@ -0,0 +1,4 @@
+-- The multi edge mode drops idx_connections_unique at startup so it can store
+-- parallel connections of the same type. A plain index keeps the duplicate
+-- check and pair lookups fast in either mode.
+CREATE INDEX IF NOT EXISTS idx_connections_from_to_type ON connections(from_note_id, to_note_id, type);
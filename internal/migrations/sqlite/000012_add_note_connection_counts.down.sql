@@ -0,0 +1,25 @@
+-- Drop the count triggers
+DROP TRIGGER IF EXISTS connections_count_update;
+DROP TRIGGER IF EXISTS connections_count_delete;
+DROP TRIGGER IF EXISTS connections_count_insert;
+
+-- Restore the note triggers from 000002
+DROP TRIGGER IF EXISTS notes_fts_update;
+CREATE TRIGGER notes_fts_update AFTER UPDATE ON notes BEGIN
+    UPDATE notes_fts SET 
+        title = NEW.title,
+        content = NEW.content
+    WHERE rowid = NEW.id;
+END;
+
+DROP TRIGGER IF EXISTS update_notes_updated_at;
+CREATE TRIGGER update_notes_updated_at 
+AFTER UPDATE ON notes
+FOR EACH ROW
+BEGIN
+    UPDATE notes SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+
+-- Drop count columns
+ALTER TABLE notes DROP COLUMN outgoing_count;
+ALTER TABLE notes DROP COLUMN incoming_count;
@@ -0,0 +1,52 @@
+-- Cache each note's connection counts so neighbor counts and centrality
+-- queries need no aggregate over connections. Triggers on connections keep
+-- them current; RecomputeConnectionCounts repairs drift.
+ALTER TABLE notes ADD COLUMN incoming_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE notes ADD COLUMN outgoing_count INTEGER NOT NULL DEFAULT 0;
+
+-- Count changes are not edits of the note: keep them from bumping updated_at
+-- and from touching the search index
+DROP TRIGGER IF EXISTS update_notes_updated_at;
+CREATE TRIGGER update_notes_updated_at
+AFTER UPDATE ON notes
+FOR EACH ROW
+WHEN OLD.incoming_count = NEW.incoming_count AND OLD.outgoing_count = NEW.outgoing_count
+BEGIN
+    UPDATE notes SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+
+DROP TRIGGER IF EXISTS notes_fts_update;
+CREATE TRIGGER notes_fts_update AFTER UPDATE OF title, content ON notes BEGIN
+    UPDATE notes_fts SET 
+        title = NEW.title,
+        content = NEW.content
+    WHERE rowid = NEW.id;
+END;
+
+-- Backfill from existing connections. Only connected notes change, so the
+-- updated_at trigger above skips them all.
+UPDATE notes SET
+    incoming_count = (SELECT COUNT(*) FROM connections WHERE to_note_id = notes.id),
+    outgoing_count = (SELECT COUNT(*) FROM connections WHERE from_note_id = notes.id)
+WHERE id IN (SELECT from_note_id FROM connections UNION SELECT to_note_id FROM connections);
+
+CREATE TRIGGER IF NOT EXISTS connections_count_insert AFTER INSERT ON connections BEGIN
+    UPDATE notes SET outgoing_count = outgoing_count + 1 WHERE id = NEW.from_note_id;
+    UPDATE notes SET incoming_count = incoming_count + 1 WHERE id = NEW.to_note_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS connections_count_delete AFTER DELETE ON connections BEGIN
+    UPDATE notes SET outgoing_count = outgoing_count - 1 WHERE id = OLD.from_note_id;
+    UPDATE notes SET incoming_count = incoming_count - 1 WHERE id = OLD.to_note_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS connections_count_update
+AFTER UPDATE OF from_note_id, to_note_id ON connections
+FOR EACH ROW
+WHEN OLD.from_note_id != NEW.from_note_id OR OLD.to_note_id != NEW.to_note_id
+BEGIN
+    UPDATE notes SET outgoing_count = outgoing_count - 1 WHERE id = OLD.from_note_id;
+    UPDATE notes SET incoming_count = incoming_count - 1 WHERE id = OLD.to_note_id;
+    UPDATE notes SET outgoing_count = outgoing_count + 1 WHERE id = NEW.from_note_id;
+    UPDATE notes SET incoming_count = incoming_count + 1 WHERE id = NEW.to_note_id;
+END;
@@ -0,0 +1,3 @@
+-- Drop the trash; soft-deleted connections are lost
+DROP INDEX IF EXISTS idx_connection_trash_deleted_at;
+DROP TABLE IF EXISTS connection_trash;
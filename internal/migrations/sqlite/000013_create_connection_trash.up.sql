@@ -0,0 +1,25 @@
+-- Soft-deleted connections move here with the time they were deleted, and
+-- move back on restore. Keeping them out of the connections table means every
+-- existing query, count trigger and duplicate check ignores them without a
+-- deleted_at filter. id keeps the original connection ID: AUTOINCREMENT never
+-- hands it out again, so a restored connection cannot collide with a new one.
+-- Deleting a note removes its trashed connections too, since they could not
+-- be restored without it.
+CREATE TABLE IF NOT EXISTS connection_trash (
+    id INTEGER PRIMARY KEY,
+    from_note_id INTEGER NOT NULL,
+    to_note_id INTEGER NOT NULL,
+    type TEXT NOT NULL,
+    description TEXT,
+    strength INTEGER NOT NULL,
+    metadata TEXT,
+    source TEXT,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL,
+    deleted_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (from_note_id) REFERENCES notes(id) ON DELETE CASCADE,
+    FOREIGN KEY (to_note_id) REFERENCES notes(id) ON DELETE CASCADE
+);
+
+-- Create index for listing the trash newest first
+CREATE INDEX IF NOT EXISTS idx_connection_trash_deleted_at ON connection_trash(deleted_at DESC);
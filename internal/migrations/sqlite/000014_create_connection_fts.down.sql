@@ -0,0 +1,7 @@
+-- Drop triggers
+DROP TRIGGER IF EXISTS connections_fts_delete;
+DROP TRIGGER IF EXISTS connections_fts_update;
+DROP TRIGGER IF EXISTS connections_fts_insert;
+
+-- Drop full-text search virtual table
+DROP TABLE IF EXISTS connections_fts;
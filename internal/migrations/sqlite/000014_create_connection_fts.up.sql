@@ -0,0 +1,27 @@
+-- Create full-text search virtual table for connection descriptions.
+-- remove_diacritics folds accents so "cafe" matches "Café".
+CREATE VIRTUAL TABLE IF NOT EXISTS connections_fts USING fts5(
+    description,
+    content='connections',
+    content_rowid='id',
+    tokenize='unicode61 remove_diacritics 2'
+);
+
+-- Index existing rows
+INSERT INTO connections_fts(connections_fts) VALUES ('rebuild');
+
+-- Create triggers to keep FTS table in sync with connections table.
+-- External content tables must be told the old values when a row changes,
+-- and only a new description changes what is indexed.
+CREATE TRIGGER IF NOT EXISTS connections_fts_insert AFTER INSERT ON connections BEGIN
+    INSERT INTO connections_fts(rowid, description) VALUES (NEW.id, NEW.description);
+END;
+
+CREATE TRIGGER IF NOT EXISTS connections_fts_update AFTER UPDATE OF description ON connections BEGIN
+    INSERT INTO connections_fts(connections_fts, rowid, description) VALUES ('delete', OLD.id, OLD.description);
+    INSERT INTO connections_fts(rowid, description) VALUES (NEW.id, NEW.description);
+END;
+
+CREATE TRIGGER IF NOT EXISTS connections_fts_delete AFTER DELETE ON connections BEGIN
+    INSERT INTO connections_fts(connections_fts, rowid, description) VALUES ('delete', OLD.id, OLD.description);
+END;
@@ -0,0 +1,12 @@
+-- Restore the updated_at trigger from 000012
+DROP TRIGGER IF EXISTS update_notes_updated_at;
+CREATE TRIGGER update_notes_updated_at
+AFTER UPDATE ON notes
+FOR EACH ROW
+WHEN OLD.incoming_count = NEW.incoming_count AND OLD.outgoing_count = NEW.outgoing_count
+BEGIN
+    UPDATE notes SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+
+DROP INDEX IF EXISTS idx_notes_content_hash;
+ALTER TABLE notes DROP COLUMN content_hash;
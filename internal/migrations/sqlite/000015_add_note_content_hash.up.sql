@@ -0,0 +1,19 @@
+-- Hash of each note's whitespace-normalized content for exact-duplicate
+-- detection. SQLite has no SHA-256, so the note storage computes it on every
+-- write and fills in rows that are still NULL, such as the ones that exist
+-- before this migration, before it looks notes up by hash.
+ALTER TABLE notes ADD COLUMN content_hash TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_notes_content_hash ON notes(content_hash);
+
+-- Filling in a missing hash is not an edit of the note either: keep it from
+-- bumping updated_at
+DROP TRIGGER IF EXISTS update_notes_updated_at;
+CREATE TRIGGER update_notes_updated_at
+AFTER UPDATE ON notes
+FOR EACH ROW
+WHEN OLD.incoming_count = NEW.incoming_count AND OLD.outgoing_count = NEW.outgoing_count
+    AND (OLD.content_hash IS NOT NULL OR NEW.content_hash IS NULL)
+BEGIN
+    UPDATE notes SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
@@ -0,0 +1,3 @@
+-- Drop the bookmarks of every session
+DROP INDEX IF EXISTS idx_note_bookmarks_note_id;
+DROP TABLE IF EXISTS note_bookmarks;
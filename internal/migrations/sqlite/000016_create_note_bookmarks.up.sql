@@ -0,0 +1,13 @@
+-- Notes an agent has bookmarked, keyed by a session name the client chooses.
+-- A note is bookmarked at most once per session. Deleting a note removes its
+-- bookmarks from every session.
+CREATE TABLE IF NOT EXISTS note_bookmarks (
+    session TEXT NOT NULL,
+    note_id INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (session, note_id),
+    FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE
+);
+
+-- Create index for the cascade when a note is deleted
+CREATE INDEX IF NOT EXISTS idx_note_bookmarks_note_id ON note_bookmarks(note_id);
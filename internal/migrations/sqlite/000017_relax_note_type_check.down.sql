@@ -0,0 +1,17 @@
+-- Restore the built-in note type CHECK constraint, in place as in the up
+-- migration. Notes using custom types become text notes rather than being
+-- dropped, which would also delete their connections.
+UPDATE notes SET type = 'text' WHERE type NOT IN ('text', 'markdown', 'code', 'link', 'image');
+
+PRAGMA writable_schema = ON;
+
+UPDATE sqlite_schema
+SET sql = replace(sql,
+    'type TEXT NOT NULL CHECK (length(type) > 0)',
+    'type TEXT NOT NULL CHECK (type IN (''text'', ''markdown'', ''code'', ''link'', ''image''))')
+WHERE type = 'table' AND name = 'notes';
+
+PRAGMA writable_schema = RESET;
+
+DROP INDEX IF EXISTS idx_notes_type;
+CREATE INDEX IF NOT EXISTS idx_notes_type ON notes(type);
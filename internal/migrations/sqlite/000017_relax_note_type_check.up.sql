@@ -0,0 +1,24 @@
+-- Move note type validation to the application so operators can configure
+-- custom types.
+-- Connections, trashed connections and bookmarks reference notes with ON
+-- DELETE CASCADE. Rebuilding the table as 000004 did for connections would
+-- delete them all: dropping a table deletes its rows first, and renaming it
+-- rewrites the references. Foreign keys cannot be turned off inside the
+-- migration transaction, so the CHECK is edited in place instead. SQLite
+-- supports this for removing CHECK constraints, since rows are stored the
+-- same either way.
+PRAGMA writable_schema = ON;
+
+UPDATE sqlite_schema
+SET sql = replace(sql,
+    'type TEXT NOT NULL CHECK (type IN (''text'', ''markdown'', ''code'', ''link'', ''image''))',
+    'type TEXT NOT NULL CHECK (length(type) > 0)')
+WHERE type = 'table' AND name = 'notes';
+
+-- Reload the schema on this connection
+PRAGMA writable_schema = RESET;
+
+-- Editing sqlite_schema does not bump the schema version. Recreating an
+-- index does, so other connections reload the schema too.
+DROP INDEX IF EXISTS idx_notes_type;
+CREATE INDEX IF NOT EXISTS idx_notes_type ON notes(type);
@@ -0,0 +1,14 @@
+-- Idempotency keys of create calls, so a client retrying a create gets the
+-- record the first call made. Keys are scoped to the table of the created
+-- record. Records are not referenced with a foreign key because the table
+-- varies; a key whose record is gone is treated as unknown.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    entity TEXT NOT NULL,
+    key TEXT NOT NULL,
+    entity_id INTEGER NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (entity, key)
+);
+
+-- Create index for removing expired keys
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_created_at ON idempotency_keys(created_at);
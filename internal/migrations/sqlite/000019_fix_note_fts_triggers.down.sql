@@ -0,0 +1,13 @@
+-- Restore the note search triggers from 000012 and 000002
+DROP TRIGGER IF EXISTS notes_fts_update;
+CREATE TRIGGER notes_fts_update AFTER UPDATE OF title, content ON notes BEGIN
+    UPDATE notes_fts SET 
+        title = NEW.title,
+        content = NEW.content
+    WHERE rowid = NEW.id;
+END;
+
+DROP TRIGGER IF EXISTS notes_fts_delete;
+CREATE TRIGGER notes_fts_delete AFTER DELETE ON notes BEGIN
+    DELETE FROM notes_fts WHERE rowid = OLD.id;
+END;
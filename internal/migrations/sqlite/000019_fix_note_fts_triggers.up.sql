@@ -0,0 +1,20 @@
+-- notes_fts is an external content table, so it must be told the old values
+-- of a row to remove them from the index. The triggers from 000002 and 000012
+-- did not: an UPDATE on notes_fts reads the old values back from notes, which
+-- already holds the new ones, and fails with "database disk image is
+-- malformed" once the content changes, and the DELETE ran after the note was
+-- gone, leaving its terms behind. Use the 'delete' command as connections_fts
+-- does.
+DROP TRIGGER IF EXISTS notes_fts_update;
+CREATE TRIGGER notes_fts_update AFTER UPDATE OF title, content ON notes BEGIN
+    INSERT INTO notes_fts(notes_fts, rowid, title, content) VALUES ('delete', OLD.id, OLD.title, OLD.content);
+    INSERT INTO notes_fts(rowid, title, content) VALUES (NEW.id, NEW.title, NEW.content);
+END;
+
+DROP TRIGGER IF EXISTS notes_fts_delete;
+CREATE TRIGGER notes_fts_delete AFTER DELETE ON notes BEGIN
+    INSERT INTO notes_fts(notes_fts, rowid, title, content) VALUES ('delete', OLD.id, OLD.title, OLD.content);
+END;
+
+-- Drop the stale entries the old triggers left behind
+INSERT INTO notes_fts(notes_fts) VALUES ('rebuild');
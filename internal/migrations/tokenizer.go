@@ -0,0 +1,125 @@
+package migrations
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/sqlitedb"
+)
+
+// NoteTokenizer selects how notes_fts splits note titles and content into
+// search terms
+type NoteTokenizer string
+
+const (
+	// NoteTokenizerDefault is FTS5's default tokenizer. It folds a single
+	// accent on a Latin letter but keeps letters that carry several, such as
+	// the Vietnamese "ệ".
+	NoteTokenizerDefault NoteTokenizer = "default"
+	// NoteTokenizerUnicode61 is unicode61 with remove_diacritics 2, which folds
+	// every diacritic, the way knowledge base and connection search already do
+	NoteTokenizerUnicode61 NoteTokenizer = "unicode61"
+)
+
+// tokenizeClausePattern finds the tokenize option in a CREATE VIRTUAL TABLE statement
+var tokenizeClausePattern = regexp.MustCompile(`(?i)tokenize\s*=\s*'([^']*)'`)
+
+// ValidNoteTokenizers returns the note tokenizer names in a stable order
+func ValidNoteTokenizers() []NoteTokenizer {
+	return []NoteTokenizer{NoteTokenizerDefault, NoteTokenizerUnicode61}
+}
+
+// ParseNoteTokenizer parses a note tokenizer name. An empty name returns an
+// empty tokenizer, which leaves notes_fts as it is.
+func ParseNoteTokenizer(name string) (NoteTokenizer, error) {
+	tokenizer := NoteTokenizer(strings.ToLower(strings.TrimSpace(name)))
+	switch tokenizer {
+	case "", NoteTokenizerDefault, NoteTokenizerUnicode61:
+		return tokenizer, nil
+	default:
+		return "", fmt.Errorf("invalid note tokenizer: %s. Valid values are: %v", name, ValidNoteTokenizers())
+	}
+}
+
+// tokenize returns the FTS5 tokenize option for the tokenizer, empty for the default
+func (t NoteTokenizer) tokenize() string {
+	if t == NoteTokenizerUnicode61 {
+		return "unicode61 remove_diacritics 2"
+	}
+	return ""
+}
+
+// Option configures a MigrationRunner
+type Option func(*MigrationRunner)
+
+// WithNoteTokenizer makes RunMigrations leave notes_fts built with tokenizer.
+// A database whose index uses another tokenizer has notes_fts recreated and
+// every note reindexed. An empty tokenizer keeps whatever the database has.
+func WithNoteTokenizer(tokenizer NoteTokenizer) Option {
+	return func(mr *MigrationRunner) {
+		mr.noteTokenizer = tokenizer
+	}
+}
+
+// applyNoteTokenizer recreates notes_fts with the configured tokenizer when it
+// was built with another one. The table holds only the index of the notes
+// table, so it is dropped and rebuilt from the notes; the triggers that keep
+// it in sync refer to it by name and carry on working.
+func (mr *MigrationRunner) applyNoteTokenizer() error {
+	if mr.noteTokenizer == "" {
+		return nil
+	}
+
+	db, err := sqlitedb.Open(mr.dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var createSQL string
+	err = tx.QueryRow("SELECT sql FROM sqlite_master WHERE type = 'table' AND name = 'notes_fts'").Scan(&createSQL)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("cannot set note tokenizer: notes_fts table does not exist")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read notes_fts schema: %w", err)
+	}
+
+	current := ""
+	if match := tokenizeClausePattern.FindStringSubmatch(createSQL); match != nil {
+		current = strings.Join(strings.Fields(match[1]), " ")
+	}
+	want := mr.noteTokenizer.tokenize()
+	if current == want {
+		return nil
+	}
+
+	options := "content='notes', content_rowid='id'"
+	if want != "" {
+		options += fmt.Sprintf(", tokenize='%s'", want)
+	}
+	statements := []string{
+		"DROP TABLE notes_fts",
+		fmt.Sprintf("CREATE VIRTUAL TABLE notes_fts USING fts5(title, content, %s)", options),
+		"INSERT INTO notes_fts(notes_fts) VALUES('rebuild')",
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement); err != nil {
+			return fmt.Errorf("failed to recreate notes_fts with the %s tokenizer: %w", mr.noteTokenizer, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
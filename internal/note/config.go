@@ -0,0 +1,48 @@
+package note
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+// customTypePattern restricts custom note type names to snake_case identifiers
+var customTypePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// builtinNoteTypes lists the note types that are always valid
+var builtinNoteTypes = []string{
+	string(NoteTypeText),
+	string(NoteTypeMarkdown),
+	string(NoteTypeCode),
+	string(NoteTypeLink),
+	string(NoteTypeImage),
+}
+
+// noteTypes is the active set of valid note types, set with Configure
+var noteTypes = builtinNoteTypes
+
+// Config holds operator settings for notes, applied once at startup
+type Config struct {
+	// ExtraTypes extends the built-in note types with custom types, such as
+	// dataset or experiment for research notes
+	ExtraTypes []string
+}
+
+// Configure replaces the active note type set. It is not safe for concurrent
+// use and must be called before tools are registered, since tool schemas
+// embed the valid types. Configure(Config{}) restores the built-in types.
+func Configure(cfg Config) error {
+	types := slices.Clone(builtinNoteTypes)
+	for _, t := range cfg.ExtraTypes {
+		if slices.Contains(types, t) {
+			continue
+		}
+		if !customTypePattern.MatchString(t) {
+			return fmt.Errorf("invalid custom note type %q: must be lowercase snake_case", t)
+		}
+		types = append(types, t)
+	}
+
+	noteTypes = types
+	return nil
+}
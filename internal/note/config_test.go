@@ -0,0 +1,63 @@
+package note_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+func TestConfigure(t *testing.T) {
+	t.Cleanup(func() {
+		require.NoError(t, note.Configure(note.Config{}))
+	})
+
+	tests := []struct {
+		name      string
+		cfg       note.Config
+		wantErr   bool
+		wantTypes []string
+	}{
+		{
+			name:      "defaults",
+			cfg:       note.Config{},
+			wantTypes: []string{"text", "markdown", "code", "link", "image"},
+		},
+		{
+			name:      "extra types after the built-in ones",
+			cfg:       note.Config{ExtraTypes: []string{"dataset", "experiment", "code", "dataset"}},
+			wantTypes: []string{"text", "markdown", "code", "link", "image", "dataset", "experiment"},
+		},
+		{
+			name:    "invalid custom type name",
+			cfg:     note.Config{ExtraTypes: []string{"Data Set"}},
+			wantErr: true,
+		},
+	}
+
+	require.NoError(t, note.Configure(note.Config{}))
+	builtinTypes := note.ValidNoteTypes()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, note.Configure(note.Config{}))
+
+			err := note.Configure(tt.cfg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				// Failed configuration leaves the previous types untouched
+				assert.Equal(t, builtinTypes, note.ValidNoteTypes())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTypes, note.ValidNoteTypes())
+			for _, noteType := range tt.wantTypes {
+				assert.True(t, note.IsValidNoteType(noteType))
+			}
+			assert.False(t, note.IsValidNoteType("video"))
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package note
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// NormalizeContent collapses every run of whitespace in content to a single
+// space and trims both ends, so content that differs only in spacing, line
+// breaks or indentation normalizes to the same text
+func NormalizeContent(content string) string {
+	return strings.Join(strings.Fields(content), " ")
+}
+
+// ContentHash returns the hex SHA-256 of the normalized content. Notes with
+// equal hashes hold the same text up to whitespace.
+func ContentHash(content string) string {
+	sum := sha256.Sum256([]byte(NormalizeContent(content)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,40 @@
+package note_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+func TestContentHash(t *testing.T) {
+	base := note.ContentHash("Pasted paragraph about graphs.\nSecond line.")
+
+	tests := []struct {
+		name     string
+		content  string
+		wantSame bool
+	}{
+		{name: "identical", content: "Pasted paragraph about graphs.\nSecond line.", wantSame: true},
+		{name: "surrounding whitespace", content: "  Pasted paragraph about graphs.\nSecond line.\n\n", wantSame: true},
+		{name: "runs of spaces and tabs", content: "Pasted  paragraph\tabout graphs.\nSecond   line.", wantSame: true},
+		{name: "windows line endings", content: "Pasted paragraph about graphs.\r\nSecond line.", wantSame: true},
+		{name: "line break instead of space", content: "Pasted paragraph\nabout graphs. Second line.", wantSame: true},
+		{name: "different case", content: "pasted paragraph about graphs.\nSecond line."},
+		{name: "different punctuation", content: "Pasted paragraph about graphs\nSecond line."},
+		{name: "missing whitespace", content: "Pasted paragraph aboutgraphs.\nSecond line."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := note.ContentHash(tt.content)
+			assert.Len(t, got, 64)
+			if tt.wantSame {
+				assert.Equal(t, base, got)
+			} else {
+				assert.NotEqual(t, base, got)
+			}
+		})
+	}
+}
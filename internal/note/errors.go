@@ -0,0 +1,22 @@
+package note
+
+import (
+	"errors"
+)
+
+var (
+	// ErrNotFound is returned when no note has the requested ID
+	ErrNotFound = errors.New("note not found")
+
+	// ErrConflict is returned when an update's expected updated_at no longer matches the stored note
+	ErrConflict = errors.New("note was modified by someone else, get it again and retry")
+
+	// ErrInvalidURL is returned when a link or image note's content is not a usable URL
+	ErrInvalidURL = errors.New("content must be an absolute http or https URL")
+
+	// ErrDuplicateContent is returned in unique content mode when another note already has the same content
+	ErrDuplicateContent = errors.New("a note with the same content already exists")
+
+	// ErrOutsideFilesRoot is returned when a directory export or import names a path outside the files root
+	ErrOutsideFilesRoot = errors.New("path is outside the files root")
+)
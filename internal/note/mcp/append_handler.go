@@ -0,0 +1,96 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// NewAppendHandler creates a new handler for appending text to a note's content
+func NewAppendHandler(storage note.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse ID
+		idRaw, ok := arguments["id"]
+		if !ok {
+			return nil, fmt.Errorf("id is required")
+		}
+
+		id, err := mcpx.ParseInt64(idRaw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id: %w", err)
+		}
+
+		if id <= 0 {
+			return nil, fmt.Errorf("id must be a positive integer")
+		}
+
+		// Parse text
+		text, ok := arguments["text"].(string)
+		if !ok || text == "" {
+			return nil, fmt.Errorf("text is required")
+		}
+
+		// Parse optional separator
+		separator := note.DefaultAppendSeparator
+		if separatorRaw, ok := arguments["separator"]; ok {
+			separator, ok = separatorRaw.(string)
+			if !ok {
+				return nil, fmt.Errorf("separator must be a string")
+			}
+		}
+
+		n, err := storage.AppendContent(ctx, id, text, separator)
+		if errors.Is(err, note.ErrNotFound) {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.TextContent{
+						Type: "text",
+						Text: fmt.Sprintf("Note with ID %d not found", id),
+					},
+				},
+			}, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to append note content: %w", err)
+		}
+
+		result := map[string]interface{}{
+			"id":                n.ID,
+			"title":             n.Title,
+			"content":           n.Content,
+			"content_hash":      n.ContentHash,
+			"type":              n.Type,
+			"tags":              n.Tags,
+			"metadata":          n.Metadata,
+			"source":            n.Source,
+			"knowledge_base_id": n.KnowledgeBaseID,
+			"created_at":        n.CreatedAt,
+			"updated_at":        n.UpdatedAt,
+		}
+
+		jsonData, err := mcpx.MarshalJSON(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Successfully appended to note with ID: %d\n\n%s", n.ID, string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
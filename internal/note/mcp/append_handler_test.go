@@ -0,0 +1,160 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+)
+
+func TestAppendHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewAppendHandler(mockStorage)
+
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "default separator",
+			args: map[string]interface{}{
+				"id":   float64(1),
+				"text": "Tuesday: continued",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					AppendContent(gomock.Any(), int64(1), "Tuesday: continued", "\n\n").
+					Return(&note.Note{
+						ID: 1, Title: "Journal", Content: "Monday: started\n\nTuesday: continued", Type: "text",
+						CreatedAt: now, UpdatedAt: now,
+					}, nil)
+			},
+			wantContent: "Successfully appended to note with ID: 1",
+		},
+		{
+			name: "custom separator",
+			args: map[string]interface{}{
+				"id":        float64(1),
+				"text":      "- two",
+				"separator": "\n",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					AppendContent(gomock.Any(), int64(1), "- two", "\n").
+					Return(&note.Note{ID: 1, Title: "List", Content: "- one\n- two", Type: "text", CreatedAt: now, UpdatedAt: now}, nil)
+			},
+			wantContent: `"content": "- one\n- two"`,
+		},
+		{
+			name: "empty separator",
+			args: map[string]interface{}{
+				"id":        float64(1),
+				"text":      "def",
+				"separator": "",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					AppendContent(gomock.Any(), int64(1), "def", "").
+					Return(&note.Note{ID: 1, Title: "Letters", Content: "abcdef", Type: "text", CreatedAt: now, UpdatedAt: now}, nil)
+			},
+			wantContent: `"content": "abcdef"`,
+		},
+		{
+			name: "note not found",
+			args: map[string]interface{}{
+				"id":   float64(999),
+				"text": "Entry",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					AppendContent(gomock.Any(), int64(999), "Entry", "\n\n").
+					Return(nil, fmt.Errorf("%w: 999", note.ErrNotFound))
+			},
+			wantContent: "Note with ID 999 not found",
+		},
+		{
+			name: "missing text",
+			args: map[string]interface{}{
+				"id": float64(1),
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "text is required",
+		},
+		{
+			name: "non-string separator",
+			args: map[string]interface{}{
+				"id":        float64(1),
+				"text":      "Entry",
+				"separator": 5,
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "separator must be a string",
+		},
+		{
+			name: "non-positive id",
+			args: map[string]interface{}{
+				"id":   float64(-1),
+				"text": "Entry",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "id must be a positive integer",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{
+				"id":   float64(1),
+				"text": "Entry",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					AppendContent(gomock.Any(), int64(1), "Entry", "\n\n").
+					Return(nil, errors.New("storage error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to append note content",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
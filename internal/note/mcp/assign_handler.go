@@ -0,0 +1,84 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// maxAssignNoteIDs caps how many notes one assign call may touch
+const maxAssignNoteIDs = 500
+
+// NewAssignHandler creates a new handler for assigning notes to a knowledge base in bulk
+func NewAssignHandler(storage note.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse knowledge_base_id
+		kbRaw, ok := arguments["knowledge_base_id"]
+		if !ok {
+			return nil, fmt.Errorf("knowledge_base_id is required")
+		}
+		kbID, err := mcpx.ParseID(kbRaw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid knowledge_base_id: %w", err)
+		}
+
+		// Parse note_ids
+		noteIDsRaw, ok := arguments["note_ids"].([]interface{})
+		if !ok || len(noteIDsRaw) == 0 {
+			return nil, fmt.Errorf("note_ids is required")
+		}
+		if len(noteIDsRaw) > maxAssignNoteIDs {
+			return nil, fmt.Errorf("note_ids must contain at most %d IDs, got: %d", maxAssignNoteIDs, len(noteIDsRaw))
+		}
+		noteIDs := make([]int64, 0, len(noteIDsRaw))
+		seen := make(map[int64]bool, len(noteIDsRaw))
+		for _, raw := range noteIDsRaw {
+			noteID, err := mcpx.ParseID(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid note_ids: %w", err)
+			}
+			if !seen[noteID] {
+				seen[noteID] = true
+				noteIDs = append(noteIDs, noteID)
+			}
+		}
+
+		// Parse optional strict
+		strict := true
+		if strictRaw, ok := arguments["strict"]; ok {
+			strict, ok = strictRaw.(bool)
+			if !ok {
+				return nil, fmt.Errorf("strict must be a boolean")
+			}
+		}
+
+		updated, err := storage.AssignNotesToKnowledgeBase(ctx, kbID, noteIDs, strict)
+		if err != nil {
+			return nil, fmt.Errorf("failed to assign notes: %w", err)
+		}
+
+		text := fmt.Sprintf("Successfully assigned %d notes to knowledge base %d", updated, kbID)
+		if skipped := int64(len(noteIDs)) - updated; skipped > 0 {
+			text += fmt.Sprintf(", skipped %d unknown note IDs", skipped)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: text,
+				},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,147 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+)
+
+func TestAssignHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewAssignHandler(mockStorage)
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "assign notes strictly by default",
+			args: map[string]interface{}{
+				"knowledge_base_id": "3",
+				"note_ids":          []interface{}{float64(1), float64(2), float64(2)},
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					AssignNotesToKnowledgeBase(gomock.Any(), int64(3), []int64{1, 2}, true).
+					Return(int64(2), nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully assigned 2 notes to knowledge base 3",
+		},
+		{
+			name: "lenient assign reports skipped notes",
+			args: map[string]interface{}{
+				"knowledge_base_id": float64(3),
+				"note_ids":          []interface{}{float64(1), float64(99)},
+				"strict":            false,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					AssignNotesToKnowledgeBase(gomock.Any(), int64(3), []int64{1, 99}, false).
+					Return(int64(1), nil)
+			},
+			wantErr:     false,
+			wantContent: "skipped 1 unknown note IDs",
+		},
+		{
+			name: "missing knowledge_base_id",
+			args: map[string]interface{}{
+				"note_ids": []interface{}{float64(1)},
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "knowledge_base_id is required",
+		},
+		{
+			name: "invalid knowledge_base_id",
+			args: map[string]interface{}{
+				"knowledge_base_id": "abc",
+				"note_ids":          []interface{}{float64(1)},
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid knowledge_base_id",
+		},
+		{
+			name: "missing note_ids",
+			args: map[string]interface{}{
+				"knowledge_base_id": "3",
+				"note_ids":          []interface{}{},
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "note_ids is required",
+		},
+		{
+			name: "non-positive note ID",
+			args: map[string]interface{}{
+				"knowledge_base_id": "3",
+				"note_ids":          []interface{}{float64(0)},
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid note_ids",
+		},
+		{
+			name: "invalid strict type",
+			args: map[string]interface{}{
+				"knowledge_base_id": "3",
+				"note_ids":          []interface{}{float64(1)},
+				"strict":            "no",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "strict must be a boolean",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{
+				"knowledge_base_id": "3",
+				"note_ids":          []interface{}{float64(1)},
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					AssignNotesToKnowledgeBase(gomock.Any(), int64(3), []int64{1}, true).
+					Return(int64(0), errors.New("knowledge base not found: 3"))
+			},
+			wantErr:     true,
+			wantContent: "failed to assign notes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// NewBookmarkHandler creates a new handler for bookmarking a note in a session
+func NewBookmarkHandler(storage note.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		session, noteID, err := parseBookmarkArguments(req)
+		if err != nil {
+			return nil, err
+		}
+
+		added, err := storage.BookmarkNote(ctx, session, noteID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to bookmark note: %w", err)
+		}
+
+		text := fmt.Sprintf("Successfully bookmarked note %d in session %q", noteID, session)
+		if !added {
+			text = fmt.Sprintf("Note %d is already bookmarked in session %q", noteID, session)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: text,
+				},
+			},
+		}, nil
+	}
+}
+
+// parseBookmarkArguments reads the session and note_id arguments shared by
+// bookmark_note and unbookmark_note
+func parseBookmarkArguments(req mcp.CallToolRequest) (string, int64, error) {
+	arguments, ok := req.Params.Arguments.(map[string]interface{})
+	if !ok {
+		return "", 0, fmt.Errorf("invalid arguments format")
+	}
+
+	// Parse session
+	session, ok := arguments["session"].(string)
+	if !ok || session == "" {
+		return "", 0, fmt.Errorf("session is required")
+	}
+
+	// Parse note_id
+	noteIDRaw, ok := arguments["note_id"]
+	if !ok {
+		return "", 0, fmt.Errorf("note_id is required")
+	}
+	noteID, err := mcpx.ParseID(noteIDRaw)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid note_id: %w", err)
+	}
+
+	return session, noteID, nil
+}
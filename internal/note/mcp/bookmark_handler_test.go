@@ -0,0 +1,105 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+)
+
+func TestBookmarkHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewBookmarkHandler(mockStorage)
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "bookmarked",
+			args: map[string]interface{}{"session": "agent-1", "note_id": float64(7)},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					BookmarkNote(gomock.Any(), "agent-1", int64(7)).
+					Return(true, nil)
+			},
+			wantErr:     false,
+			wantContent: `Successfully bookmarked note 7 in session "agent-1"`,
+		},
+		{
+			name: "already bookmarked",
+			args: map[string]interface{}{"session": "agent-1", "note_id": "7"},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					BookmarkNote(gomock.Any(), "agent-1", int64(7)).
+					Return(false, nil)
+			},
+			wantErr:     false,
+			wantContent: `Note 7 is already bookmarked in session "agent-1"`,
+		},
+		{
+			name:        "missing session",
+			args:        map[string]interface{}{"note_id": float64(7)},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "session is required",
+		},
+		{
+			name:        "missing note ID",
+			args:        map[string]interface{}{"session": "agent-1"},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "note_id is required",
+		},
+		{
+			name:        "invalid note ID",
+			args:        map[string]interface{}{"session": "agent-1", "note_id": float64(1.5)},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid note_id",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{"session": "agent-1", "note_id": float64(99)},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					BookmarkNote(gomock.Any(), "agent-1", int64(99)).
+					Return(false, errors.New("note not found: 99"))
+			},
+			wantErr:     true,
+			wantContent: "failed to bookmark note: note not found: 99",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{}
+			req.Params.Arguments = tt.args
+
+			result, err := handler(context.Background(), req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotNil(t, result)
+			assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// NewFindByContentHashHandler creates a new handler for finding notes with the
+// same content, given either a content hash or the content itself
+func NewFindByContentHashHandler(storage note.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse content_hash or content, exactly one of them
+		hash, hasHash := arguments["content_hash"].(string)
+		content, hasContent := arguments["content"].(string)
+		if hasHash == hasContent || (hasHash && hash == "") {
+			return nil, fmt.Errorf("exactly one of content_hash or content is required")
+		}
+		if hasContent {
+			hash = note.ContentHash(content)
+		}
+
+		notes, err := storage.FindNotesByContentHash(ctx, hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find notes by content hash: %w", err)
+		}
+
+		if len(notes) == 0 {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.TextContent{
+						Type: "text",
+						Text: fmt.Sprintf("No notes found with content hash %s", hash),
+					},
+				},
+			}, nil
+		}
+
+		results := make([]map[string]interface{}, 0, len(notes))
+		for _, n := range notes {
+			results = append(results, map[string]interface{}{
+				"id":           n.ID,
+				"title":        n.Title,
+				"type":         n.Type,
+				"content_hash": n.ContentHash,
+				"created_at":   n.CreatedAt,
+				"updated_at":   n.UpdatedAt,
+			})
+		}
+
+		jsonData, err := mcpx.MarshalJSON(results)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal results: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Found %d notes with content hash %s:\n\n%s", len(notes), hash, string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,141 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+)
+
+func TestFindByContentHashHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewFindByContentHashHandler(mockStorage)
+
+	now := time.Now()
+	hash := note.ContentHash("Pasted twice")
+	duplicates := []note.Note{
+		{ID: 1, Title: "First", Content: "Pasted twice", Type: "text", ContentHash: hash, CreatedAt: now, UpdatedAt: now},
+		{ID: 2, Title: "Second", Content: "Pasted  twice\n", Type: "text", ContentHash: hash, CreatedAt: now, UpdatedAt: now},
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "by hash",
+			args: map[string]interface{}{
+				"content_hash": hash,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindNotesByContentHash(gomock.Any(), hash).
+					Return(duplicates, nil)
+			},
+			wantErr:     false,
+			wantContent: "Found 2 notes with content hash " + hash,
+		},
+		{
+			name: "by content hashes it first",
+			args: map[string]interface{}{
+				"content": "  Pasted\ttwice ",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindNotesByContentHash(gomock.Any(), hash).
+					Return(duplicates[:1], nil)
+			},
+			wantErr:     false,
+			wantContent: `"title": "First"`,
+		},
+		{
+			name: "no match",
+			args: map[string]interface{}{
+				"content_hash": "abc",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindNotesByContentHash(gomock.Any(), "abc").
+					Return([]note.Note{}, nil)
+			},
+			wantErr:     false,
+			wantContent: "No notes found with content hash abc",
+		},
+		{
+			name:        "neither argument",
+			args:        map[string]interface{}{},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "exactly one of content_hash or content is required",
+		},
+		{
+			name: "both arguments",
+			args: map[string]interface{}{
+				"content_hash": hash,
+				"content":      "Pasted twice",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "exactly one of content_hash or content is required",
+		},
+		{
+			name: "empty hash",
+			args: map[string]interface{}{
+				"content_hash": "",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "exactly one of content_hash or content is required",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{
+				"content_hash": hash,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindNotesByContentHash(gomock.Any(), hash).
+					Return(nil, errors.New("storage error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to find notes by content hash",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+)
+
+func TestCountHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewCountHandler(mockStorage)
+
+	connType := "supports"
+
+	tests := []struct {
+		name        string
+		args        interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "count all notes",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Count(gomock.Any(), note.ListNotesRequest{}).
+					Return(int64(42), nil)
+			},
+			wantContent: "Found 42 notes\n\n{\n  \"total\": 42\n}",
+		},
+		{
+			name: "count with filters",
+			args: map[string]interface{}{
+				"search":              "goroutines",
+				"types":               []interface{}{"code", "markdown"},
+				"tags":                []interface{}{"go"},
+				"exclude_tags":        []interface{}{"draft"},
+				"source":              "agent",
+				"has_connection_type": "supports",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Count(gomock.Any(), note.ListNotesRequest{
+						Search:            "goroutines",
+						Types:             []string{"code", "markdown"},
+						Tags:              []string{"go"},
+						ExcludeTags:       []string{"draft"},
+						Source:            "agent",
+						HasConnectionType: &connType,
+					}).
+					Return(int64(3), nil)
+			},
+			wantContent: "Found 3 notes",
+		},
+		{
+			name: "no matching notes",
+			args: map[string]interface{}{"tags": []interface{}{"missing"}},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Count(gomock.Any(), note.ListNotesRequest{Tags: []string{"missing"}}).
+					Return(int64(0), nil)
+			},
+			wantContent: "Found 0 notes",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Count(gomock.Any(), note.ListNotesRequest{}).
+					Return(int64(0), errors.New("database error"))
+			},
+			wantErr: true,
+		},
+		{
+			name:      "invalid arguments",
+			args:      "not a map",
+			mockSetup: func() {},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{}
+			req.Params.Arguments = tt.args
+
+			result, err := handler(context.Background(), req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			text := result.Content[0].(gomcp.TextContent).Text
+			assert.Contains(t, text, tt.wantContent)
+		})
+	}
+}
@@ -2,12 +2,12 @@ package mcp
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
 )
 
@@ -33,6 +33,9 @@ func NewCreateHandler(storage note.Storage) server.ToolHandlerFunc {
 		if typ, ok := arguments["type"].(string); ok && typ != "" {
 			noteType = typ
 		}
+		if !note.IsValidNoteType(noteType) {
+			return nil, fmt.Errorf("invalid note type: %s. Valid types are: %v", noteType, note.ValidNoteTypes())
+		}
 
 		var tags []string
 		if tagsRaw, ok := arguments["tags"].([]interface{}); ok {
@@ -48,12 +51,24 @@ func NewCreateHandler(storage note.Storage) server.ToolHandlerFunc {
 			metadata = metadataRaw
 		}
 
+		var source *string
+		if src, ok := arguments["source"].(string); ok && src != "" {
+			source = &src
+		}
+
+		idempotencyKey, err := mcpx.ParseIdempotencyKey(arguments)
+		if err != nil {
+			return nil, err
+		}
+
 		createReq := note.CreateNoteRequest{
-			Title:    title,
-			Content:  content,
-			Type:     noteType,
-			Tags:     tags,
-			Metadata: metadata,
+			Title:          title,
+			Content:        content,
+			Type:           noteType,
+			Tags:           tags,
+			Metadata:       metadata,
+			Source:         source,
+			IdempotencyKey: idempotencyKey,
 		}
 
 		n, err := storage.Create(ctx, createReq)
@@ -62,17 +77,19 @@ func NewCreateHandler(storage note.Storage) server.ToolHandlerFunc {
 		}
 
 		result := map[string]interface{}{
-			"id":         n.ID,
-			"title":      n.Title,
-			"content":    n.Content,
-			"type":       n.Type,
-			"tags":       n.Tags,
-			"metadata":   n.Metadata,
-			"created_at": n.CreatedAt,
-			"updated_at": n.UpdatedAt,
+			"id":                n.ID,
+			"title":             n.Title,
+			"content":           n.Content,
+			"type":              n.Type,
+			"tags":              n.Tags,
+			"metadata":          n.Metadata,
+			"source":            n.Source,
+			"knowledge_base_id": n.KnowledgeBaseID,
+			"created_at":        n.CreatedAt,
+			"updated_at":        n.UpdatedAt,
 		}
 
-		jsonData, err := json.MarshalIndent(result, "", "  ")
+		jsonData, err := mcpx.MarshalJSON(result)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal result: %w", err)
 		}
@@ -86,4 +103,4 @@ func NewCreateHandler(storage note.Storage) server.ToolHandlerFunc {
 			},
 		}, nil
 	}
-}
\ No newline at end of file
+}
@@ -90,6 +90,66 @@ func TestCreateHandler(t *testing.T) {
 			wantErr:     false,
 			wantContent: "Successfully created note with ID: 2",
 		},
+		{
+			name: "successful creation with source",
+			args: map[string]interface{}{
+				"title":   "Test Note",
+				"content": "Test Content",
+				"source":  "research-agent",
+			},
+			mockSetup: func() {
+				source := "research-agent"
+				mockStorage.EXPECT().
+					Create(gomock.Any(), note.CreateNoteRequest{
+						Title:   "Test Note",
+						Content: "Test Content",
+						Type:    "text",
+						Source:  &source,
+					}).
+					Return(&note.Note{
+						ID:        3,
+						Title:     "Test Note",
+						Content:   "Test Content",
+						Type:      "text",
+						Source:    &source,
+						CreatedAt: now,
+						UpdatedAt: now,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: `"source": "research-agent"`,
+		},
+		{
+			name: "idempotency key is passed to storage",
+			args: map[string]interface{}{
+				"title":           "Test Note",
+				"content":         "Test Content",
+				"idempotency_key": "retry-1",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Create(gomock.Any(), note.CreateNoteRequest{
+						Title:          "Test Note",
+						Content:        "Test Content",
+						Type:           "text",
+						IdempotencyKey: "retry-1",
+					}).
+					Return(&note.Note{ID: 4, Title: "Test Note", Content: "Test Content", Type: "text", CreatedAt: now, UpdatedAt: now}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully created note with ID: 4",
+		},
+		{
+			name: "invalid idempotency key type",
+			args: map[string]interface{}{
+				"title":           "Test Note",
+				"content":         "Test Content",
+				"idempotency_key": float64(1),
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "idempotency_key must be a string",
+		},
 		{
 			name: "missing title",
 			args: map[string]interface{}{
@@ -146,4 +206,39 @@ func TestCreateHandler(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestCreateHandler_ConfiguredTypes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	assert.NoError(t, note.Configure(note.Config{ExtraTypes: []string{"dataset"}}))
+	t.Cleanup(func() {
+		assert.NoError(t, note.Configure(note.Config{}))
+	})
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewCreateHandler(mockStorage)
+
+	mockStorage.EXPECT().
+		Create(gomock.Any(), note.CreateNoteRequest{Title: "Survey", Content: "survey.csv", Type: "dataset"}).
+		Return(&note.Note{ID: 1, Title: "Survey", Content: "survey.csv", Type: "dataset"}, nil)
+
+	req := gomcp.CallToolRequest{
+		Params: gomcp.CallToolParams{
+			Arguments: map[string]interface{}{
+				"title":   "Survey",
+				"content": "survey.csv",
+				"type":    "dataset",
+			},
+		},
+	}
+
+	result, err := handler(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, "Successfully created note with ID: 1")
+
+	// Types that are not configured are rejected before reaching the storage
+	req.Params.Arguments = map[string]interface{}{"title": "Run", "content": "run 1", "type": "experiment"}
+	_, err = handler(context.Background(), req)
+	assert.ErrorContains(t, err, "invalid note type: experiment. Valid types are: [text markdown code link image dataset]")
+}
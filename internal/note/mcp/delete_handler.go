@@ -3,11 +3,11 @@ package mcp
 import (
 	"context"
 	"fmt"
-	"strconv"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
 )
 
@@ -19,14 +19,19 @@ func NewDeleteHandler(storage note.Storage) server.ToolHandlerFunc {
 			return nil, fmt.Errorf("invalid arguments format")
 		}
 
-		idStr, ok := arguments["id"].(string)
-		if !ok || idStr == "" {
+		// Parse ID
+		idRaw, ok := arguments["id"]
+		if !ok {
 			return nil, fmt.Errorf("id is required")
 		}
 
-		id, err := strconv.ParseInt(idStr, 10, 64)
+		id, err := mcpx.ParseInt64(idRaw)
 		if err != nil {
-			return nil, fmt.Errorf("invalid id format: %w", err)
+			return nil, fmt.Errorf("invalid id: %w", err)
+		}
+
+		if id <= 0 {
+			return nil, fmt.Errorf("id must be a positive integer")
 		}
 
 		err = storage.Delete(ctx, id)
@@ -40,6 +40,28 @@ func TestDeleteHandler(t *testing.T) {
 			wantErr:     false,
 			wantContent: "Successfully deleted note with ID: 1",
 		},
+		{
+			name: "numeric id",
+			args: map[string]interface{}{
+				"id": float64(2),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Delete(gomock.Any(), int64(2)).
+					Return(nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully deleted note with ID: 2",
+		},
+		{
+			name: "fractional id",
+			args: map[string]interface{}{
+				"id": 2.5,
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid id: 2.5 is not an integer",
+		},
 		{
 			name: "missing id",
 			args: map[string]interface{}{},
@@ -54,7 +76,16 @@ func TestDeleteHandler(t *testing.T) {
 			},
 			mockSetup:   func() {},
 			wantErr:     true,
-			wantContent: "invalid id format",
+			wantContent: "invalid id: ",
+		},
+		{
+			name: "non-positive id",
+			args: map[string]interface{}{
+				"id": float64(0),
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "id must be a positive integer",
 		},
 		{
 			name: "storage error",
@@ -0,0 +1,70 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// maxDeleteNoteIDs caps how many notes one delete_notes call may delete
+const maxDeleteNoteIDs = 500
+
+// NewDeleteManyHandler creates a new handler for deleting several notes by ID in one call
+func NewDeleteManyHandler(storage note.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse ids
+		idsRaw, ok := arguments["ids"].([]interface{})
+		if !ok || len(idsRaw) == 0 {
+			return nil, fmt.Errorf("ids is required")
+		}
+		if len(idsRaw) > maxDeleteNoteIDs {
+			return nil, fmt.Errorf("ids must contain at most %d IDs, got: %d", maxDeleteNoteIDs, len(idsRaw))
+		}
+		ids := make([]int64, 0, len(idsRaw))
+		seen := make(map[int64]bool, len(idsRaw))
+		for _, raw := range idsRaw {
+			id, err := mcpx.ParseID(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ids: %w", err)
+			}
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+
+		result, err := storage.DeleteMany(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete notes: %w", err)
+		}
+
+		summary := fmt.Sprintf("Deleted %d of %d notes and %d connections", len(result.Deleted), len(ids), result.ConnectionsDeleted)
+		if len(result.NotFound) > 0 {
+			summary += fmt.Sprintf("\nNotes not found: %v", result.NotFound)
+		}
+
+		jsonData, err := mcpx.MarshalJSON(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("%s\n\n%s", summary, string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
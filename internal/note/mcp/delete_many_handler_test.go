@@ -0,0 +1,145 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+)
+
+func TestDeleteManyHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewDeleteManyHandler(mockStorage)
+
+	tooMany := make([]interface{}, 501)
+	for i := range tooMany {
+		tooMany[i] = float64(i + 1)
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "summary counts notes and connections",
+			args: map[string]interface{}{
+				"ids": []interface{}{float64(1), "2", float64(1)},
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					DeleteMany(gomock.Any(), []int64{1, 2}).
+					Return(&note.DeleteResult{
+						Deleted:            []note.DeletedNote{{ID: 1, Connections: 3}, {ID: 2, Connections: 2}},
+						NotFound:           []int64{},
+						ConnectionsDeleted: 4,
+					}, nil)
+			},
+			wantContent: "Deleted 2 of 2 notes and 4 connections",
+		},
+		{
+			name: "per-note connection counts",
+			args: map[string]interface{}{
+				"ids": []interface{}{float64(1)},
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					DeleteMany(gomock.Any(), []int64{1}).
+					Return(&note.DeleteResult{
+						Deleted:            []note.DeletedNote{{ID: 1, Connections: 3}},
+						NotFound:           []int64{},
+						ConnectionsDeleted: 3,
+					}, nil)
+			},
+			wantContent: `"connections": 3`,
+		},
+		{
+			name: "missing notes are listed",
+			args: map[string]interface{}{
+				"ids": []interface{}{float64(1), float64(99)},
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					DeleteMany(gomock.Any(), []int64{1, 99}).
+					Return(&note.DeleteResult{
+						Deleted:  []note.DeletedNote{{ID: 1}},
+						NotFound: []int64{99},
+					}, nil)
+			},
+			wantContent: "Notes not found: [99]",
+		},
+		{
+			name:        "missing ids",
+			args:        map[string]interface{}{},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "ids is required",
+		},
+		{
+			name: "invalid id",
+			args: map[string]interface{}{
+				"ids": []interface{}{float64(0)},
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid ids",
+		},
+		{
+			name: "too many ids",
+			args: map[string]interface{}{
+				"ids": tooMany,
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "ids must contain at most 500 IDs",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{
+				"ids": []interface{}{float64(1)},
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					DeleteMany(gomock.Any(), []int64{1}).
+					Return(nil, errors.New("storage error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to delete notes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// NewDeleteTagHandler creates a new handler for removing a tag from every note
+func NewDeleteTagHandler(storage note.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse tag
+		tag, ok := arguments["tag"].(string)
+		if !ok || tag == "" {
+			return nil, fmt.Errorf("tag is required")
+		}
+
+		deleted, err := storage.DeleteTag(ctx, tag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete tag: %w", err)
+		}
+
+		text := fmt.Sprintf("Successfully removed tag %q from %d notes", tag, deleted)
+		if deleted == 0 {
+			text = fmt.Sprintf("No notes have tag %q", tag)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: text,
+				},
+			},
+		}, nil
+	}
+}
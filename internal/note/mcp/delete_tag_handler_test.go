@@ -0,0 +1,101 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+)
+
+func TestDeleteTagHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewDeleteTagHandler(mockStorage)
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "removed from several notes",
+			args: map[string]interface{}{"tag": "obsolete"},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					DeleteTag(gomock.Any(), "obsolete").
+					Return(int64(4), nil)
+			},
+			wantErr:     false,
+			wantContent: `Successfully removed tag "obsolete" from 4 notes`,
+		},
+		{
+			name: "no notes with the tag",
+			args: map[string]interface{}{"tag": "unused"},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					DeleteTag(gomock.Any(), "unused").
+					Return(int64(0), nil)
+			},
+			wantErr:     false,
+			wantContent: `No notes have tag "unused"`,
+		},
+		{
+			name:        "missing tag",
+			args:        map[string]interface{}{},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "tag is required",
+		},
+		{
+			name:        "tag not a string",
+			args:        map[string]interface{}{"tag": true},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "tag is required",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{"tag": "obsolete"},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					DeleteTag(gomock.Any(), "obsolete").
+					Return(int64(0), errors.New("database is locked"))
+			},
+			wantErr:     true,
+			wantContent: "failed to delete tag: database is locked",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
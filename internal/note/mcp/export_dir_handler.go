@@ -0,0 +1,54 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// NewExportToDirHandler creates a new handler for exporting notes to markdown files
+func NewExportToDirHandler(storage note.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse directory
+		dir, ok := arguments["directory"].(string)
+		if !ok || dir == "" {
+			return nil, fmt.Errorf("directory is required")
+		}
+
+		listReq, err := parseListNotesRequest(arguments)
+		if err != nil {
+			return nil, err
+		}
+		// Unlike list_notes, an export without a limit covers every matching note
+		if limitRaw, ok := arguments["limit"]; ok {
+			listReq.Limit, err = mcpx.ParseInt(limitRaw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid limit: %w", err)
+			}
+		}
+
+		written, err := storage.ExportNotesToDir(ctx, dir, listReq)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export notes after writing %d files: %w", written, err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Successfully exported %d notes to %s", written, dir),
+				},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,104 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+)
+
+func TestExportToDirHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewExportToDirHandler(mockStorage)
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "export every note by default",
+			args: map[string]interface{}{
+				"directory": "/tmp/vault",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ExportNotesToDir(gomock.Any(), "/tmp/vault", note.ListNotesRequest{Limit: 0}).
+					Return(12, nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully exported 12 notes to /tmp/vault",
+		},
+		{
+			name: "export with filters and a limit",
+			args: map[string]interface{}{
+				"directory": "vault",
+				"type":      "markdown",
+				"tags":      []interface{}{"go"},
+				"limit":     float64(5),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ExportNotesToDir(gomock.Any(), "vault", note.ListNotesRequest{Limit: 5, Type: "markdown", Tags: []string{"go"}}).
+					Return(5, nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully exported 5 notes to vault",
+		},
+		{
+			name:        "missing directory",
+			args:        map[string]interface{}{"type": "markdown"},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "directory is required",
+		},
+		{
+			name: "storage error reports files already written",
+			args: map[string]interface{}{
+				"directory": "vault",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ExportNotesToDir(gomock.Any(), "vault", gomock.Any()).
+					Return(3, errors.New("permission denied"))
+			},
+			wantErr:     true,
+			wantContent: "failed to export notes after writing 3 files",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
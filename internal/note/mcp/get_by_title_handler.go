@@ -0,0 +1,90 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// NewGetByTitleHandler creates a new handler for looking up notes by title
+func NewGetByTitleHandler(storage note.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse title
+		title, ok := arguments["title"].(string)
+		if !ok || title == "" {
+			return nil, fmt.Errorf("title is required")
+		}
+
+		// Parse optional case_insensitive
+		caseInsensitive := false
+		if caseInsensitiveRaw, ok := arguments["case_insensitive"]; ok {
+			caseInsensitive, ok = caseInsensitiveRaw.(bool)
+			if !ok {
+				return nil, fmt.Errorf("case_insensitive must be a boolean")
+			}
+		}
+
+		notes, err := storage.GetByTitle(ctx, title, caseInsensitive)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get notes by title: %w", err)
+		}
+
+		if len(notes) == 0 {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.TextContent{
+						Type: "text",
+						Text: fmt.Sprintf("No notes found with title %q", title),
+					},
+				},
+			}, nil
+		}
+
+		results := make([]map[string]interface{}, 0, len(notes))
+		for _, n := range notes {
+			result := map[string]interface{}{
+				"id":                n.ID,
+				"title":             n.Title,
+				"content":           n.Content,
+				"content_hash":      n.ContentHash,
+				"type":              n.Type,
+				"tags":              n.Tags,
+				"metadata":          n.Metadata,
+				"source":            n.Source,
+				"knowledge_base_id": n.KnowledgeBaseID,
+				"incoming_count":    n.IncomingCount,
+				"outgoing_count":    n.OutgoingCount,
+				"created_at":        n.CreatedAt,
+				"updated_at":        n.UpdatedAt,
+			}
+			if rendered := note.Render(n); rendered != nil {
+				result["rendered"] = rendered
+			}
+			results = append(results, result)
+		}
+
+		jsonData, err := mcpx.MarshalJSON(results)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal results: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Found %d notes with title %q:\n\n%s", len(notes), title, string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,133 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+)
+
+func TestGetByTitleHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewGetByTitleHandler(mockStorage)
+
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "exact match",
+			args: map[string]interface{}{
+				"title": "Go Basics",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetByTitle(gomock.Any(), "Go Basics", false).
+					Return([]note.Note{
+						{ID: 1, Title: "Go Basics", Content: "Content", Type: "text", CreatedAt: now, UpdatedAt: now},
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: `Found 1 notes with title "Go Basics"`,
+		},
+		{
+			name: "case-insensitive returns every match",
+			args: map[string]interface{}{
+				"title":            "go basics",
+				"case_insensitive": true,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetByTitle(gomock.Any(), "go basics", true).
+					Return([]note.Note{
+						{ID: 1, Title: "Go Basics", Content: "Content", Type: "text", CreatedAt: now, UpdatedAt: now},
+						{ID: 2, Title: "go basics", Content: "Content", Type: "text", CreatedAt: now, UpdatedAt: now},
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: `Found 2 notes with title "go basics"`,
+		},
+		{
+			name: "no match",
+			args: map[string]interface{}{
+				"title": "Python",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetByTitle(gomock.Any(), "Python", false).
+					Return([]note.Note{}, nil)
+			},
+			wantErr:     false,
+			wantContent: `No notes found with title "Python"`,
+		},
+		{
+			name:        "missing title",
+			args:        map[string]interface{}{},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "title is required",
+		},
+		{
+			name: "invalid case_insensitive type",
+			args: map[string]interface{}{
+				"title":            "Go Basics",
+				"case_insensitive": "yes",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "case_insensitive must be a boolean",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{
+				"title": "Go Basics",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetByTitle(gomock.Any(), "Go Basics", false).
+					Return(nil, errors.New("storage error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to get notes by title",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
@@ -2,13 +2,13 @@ package mcp
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"strconv"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
 )
 
@@ -20,22 +20,19 @@ func NewGetHandler(storage note.Storage) server.ToolHandlerFunc {
 			return nil, fmt.Errorf("invalid arguments format")
 		}
 
-		idStr, ok := arguments["id"].(string)
-		if !ok || idStr == "" {
+		// Parse ID
+		idRaw, ok := arguments["id"]
+		if !ok {
 			return nil, fmt.Errorf("id is required")
 		}
 
-		id, err := strconv.ParseInt(idStr, 10, 64)
+		id, err := mcpx.ParseID(idRaw)
 		if err != nil {
-			return nil, fmt.Errorf("invalid id format: %w", err)
+			return nil, fmt.Errorf("invalid id: %w", err)
 		}
 
 		n, err := storage.Get(ctx, id)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get note: %w", err)
-		}
-
-		if n == nil {
+		if errors.Is(err, note.ErrNotFound) {
 			return &mcp.CallToolResult{
 				Content: []mcp.Content{
 					mcp.TextContent{
@@ -45,19 +42,30 @@ func NewGetHandler(storage note.Storage) server.ToolHandlerFunc {
 				},
 			}, nil
 		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get note: %w", err)
+		}
 
 		result := map[string]interface{}{
-			"id":         n.ID,
-			"title":      n.Title,
-			"content":    n.Content,
-			"type":       n.Type,
-			"tags":       n.Tags,
-			"metadata":   n.Metadata,
-			"created_at": n.CreatedAt,
-			"updated_at": n.UpdatedAt,
+			"id":                n.ID,
+			"title":             n.Title,
+			"content":           n.Content,
+			"content_hash":      n.ContentHash,
+			"type":              n.Type,
+			"tags":              n.Tags,
+			"metadata":          n.Metadata,
+			"source":            n.Source,
+			"knowledge_base_id": n.KnowledgeBaseID,
+			"incoming_count":    n.IncomingCount,
+			"outgoing_count":    n.OutgoingCount,
+			"created_at":        n.CreatedAt,
+			"updated_at":        n.UpdatedAt,
+		}
+		if rendered := note.Render(*n); rendered != nil {
+			result["rendered"] = rendered
 		}
 
-		jsonData, err := json.MarshalIndent(result, "", "  ")
+		jsonData, err := mcpx.MarshalJSON(result)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal result: %w", err)
 		}
@@ -71,4 +79,4 @@ func NewGetHandler(storage note.Storage) server.ToolHandlerFunc {
 			},
 		}, nil
 	}
-}
\ No newline at end of file
+}
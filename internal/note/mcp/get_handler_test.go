@@ -3,6 +3,7 @@ package mcp_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -54,6 +55,57 @@ func TestGetHandler(t *testing.T) {
 			wantErr:     false,
 			wantContent: "Test Note",
 		},
+		{
+			name: "includes the content hash",
+			args: map[string]interface{}{
+				"id": "3",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Get(gomock.Any(), int64(3)).
+					Return(&note.Note{ID: 3, Title: "Hashed", Content: "Body", Type: "text", ContentHash: "abc123", CreatedAt: now, UpdatedAt: now}, nil)
+			},
+			wantErr:     false,
+			wantContent: `"content_hash": "abc123"`,
+		},
+		{
+			name: "link note includes its domain",
+			args: map[string]interface{}{
+				"id": "2",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Get(gomock.Any(), int64(2)).
+					Return(&note.Note{ID: 2, Title: "Article", Content: "https://www.example.com/post", Type: "link", CreatedAt: now, UpdatedAt: now}, nil)
+			},
+			wantContent: `"rendered": {
+    "domain": "example.com"
+  }`,
+		},
+		{
+			name: "includes connection counts",
+			args: map[string]interface{}{
+				"id": "3",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Get(gomock.Any(), int64(3)).
+					Return(&note.Note{ID: 3, Title: "Hub", Content: "c", Type: "text", IncomingCount: 4, OutgoingCount: 2, CreatedAt: now, UpdatedAt: now}, nil)
+			},
+			wantContent: `"incoming_count": 4`,
+		},
+		{
+			name: "code note includes its language",
+			args: map[string]interface{}{
+				"id": "3",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Get(gomock.Any(), int64(3)).
+					Return(&note.Note{ID: 3, Title: "Snippet", Content: "fmt.Println()", Type: "code", Metadata: map[string]interface{}{"language": "Go"}, CreatedAt: now, UpdatedAt: now}, nil)
+			},
+			wantContent: `"language": "go"`,
+		},
 		{
 			name: "note not found",
 			args: map[string]interface{}{
@@ -62,11 +114,24 @@ func TestGetHandler(t *testing.T) {
 			mockSetup: func() {
 				mockStorage.EXPECT().
 					Get(gomock.Any(), int64(999)).
-					Return(nil, nil)
+					Return(nil, fmt.Errorf("%w: 999", note.ErrNotFound))
 			},
 			wantErr:     false,
 			wantContent: "Note with ID 999 not found",
 		},
+		{
+			name: "numeric id",
+			args: map[string]interface{}{
+				"id": float64(4),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Get(gomock.Any(), int64(4)).
+					Return(&note.Note{ID: 4, Title: "Numeric", Content: "Body", Type: "text", CreatedAt: now, UpdatedAt: now}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Numeric",
+		},
 		{
 			name: "missing id",
 			args: map[string]interface{}{},
@@ -81,7 +146,16 @@ func TestGetHandler(t *testing.T) {
 			},
 			mockSetup:   func() {},
 			wantErr:     true,
-			wantContent: "invalid id format",
+			wantContent: "invalid id: ",
+		},
+		{
+			name: "non-positive id",
+			args: map[string]interface{}{
+				"id": float64(0),
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid id: IDs must be positive, got: 0",
 		},
 		{
 			name: "storage error",
@@ -0,0 +1,100 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// maxGetNoteIDs caps how many notes one get_notes call may fetch
+const maxGetNoteIDs = 500
+
+// NewGetManyHandler creates a new handler for getting several notes by ID in one call
+func NewGetManyHandler(storage note.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse ids
+		idsRaw, ok := arguments["ids"].([]interface{})
+		if !ok || len(idsRaw) == 0 {
+			return nil, fmt.Errorf("ids is required")
+		}
+		if len(idsRaw) > maxGetNoteIDs {
+			return nil, fmt.Errorf("ids must contain at most %d IDs, got: %d", maxGetNoteIDs, len(idsRaw))
+		}
+		ids := make([]int64, 0, len(idsRaw))
+		seen := make(map[int64]bool, len(idsRaw))
+		for _, raw := range idsRaw {
+			id, err := mcpx.ParseID(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ids: %w", err)
+			}
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+
+		notes, err := storage.GetMany(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get notes: %w", err)
+		}
+
+		found := make(map[int64]bool, len(notes))
+		items := make([]map[string]interface{}, 0, len(notes))
+		for _, n := range notes {
+			found[n.ID] = true
+			result := map[string]interface{}{
+				"id":                n.ID,
+				"title":             n.Title,
+				"content":           n.Content,
+				"content_hash":      n.ContentHash,
+				"type":              n.Type,
+				"tags":              n.Tags,
+				"metadata":          n.Metadata,
+				"source":            n.Source,
+				"knowledge_base_id": n.KnowledgeBaseID,
+				"incoming_count":    n.IncomingCount,
+				"outgoing_count":    n.OutgoingCount,
+				"created_at":        n.CreatedAt,
+				"updated_at":        n.UpdatedAt,
+			}
+			if rendered := note.Render(n); rendered != nil {
+				result["rendered"] = rendered
+			}
+			items = append(items, result)
+		}
+
+		missing := []int64{}
+		for _, id := range ids {
+			if !found[id] {
+				missing = append(missing, id)
+			}
+		}
+
+		jsonData, err := mcpx.MarshalJSON(map[string]interface{}{
+			"items":       items,
+			"missing_ids": missing,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal results: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Found %d of %d notes:\n\n%s", len(notes), len(ids), string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,141 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+)
+
+func TestGetManyHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewGetManyHandler(mockStorage)
+
+	now := time.Now()
+	tooMany := make([]interface{}, 501)
+	for i := range tooMany {
+		tooMany[i] = float64(i + 1)
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "mix of existing and missing IDs",
+			args: map[string]interface{}{
+				"ids": []interface{}{float64(3), "1", float64(99), float64(3)},
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetMany(gomock.Any(), []int64{3, 1, 99}).
+					Return([]note.Note{
+						{ID: 3, Title: "Third", Content: "c", Type: "text", CreatedAt: now, UpdatedAt: now},
+						{ID: 1, Title: "First", Content: "a", Type: "text", CreatedAt: now, UpdatedAt: now},
+					}, nil)
+			},
+			wantContent: `"missing_ids": [
+    99
+  ]`,
+		},
+		{
+			name: "summary counts found notes",
+			args: map[string]interface{}{
+				"ids": []interface{}{float64(1), float64(2)},
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetMany(gomock.Any(), []int64{1, 2}).
+					Return([]note.Note{{ID: 1, Title: "First", Type: "text"}}, nil)
+			},
+			wantContent: "Found 1 of 2 notes",
+		},
+		{
+			name: "all found",
+			args: map[string]interface{}{
+				"ids": []interface{}{float64(1)},
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetMany(gomock.Any(), []int64{1}).
+					Return([]note.Note{{ID: 1, Title: "First", Type: "text"}}, nil)
+			},
+			wantContent: `"missing_ids": []`,
+		},
+		{
+			name:        "missing ids",
+			args:        map[string]interface{}{},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "ids is required",
+		},
+		{
+			name: "invalid id",
+			args: map[string]interface{}{
+				"ids": []interface{}{float64(1.5)},
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid ids",
+		},
+		{
+			name: "too many ids",
+			args: map[string]interface{}{
+				"ids": tooMany,
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "ids must contain at most 500 IDs",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{
+				"ids": []interface{}{float64(1)},
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetMany(gomock.Any(), []int64{1}).
+					Return(nil, errors.New("storage error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to get notes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// NewImportFromDirHandler creates a new handler for importing a directory of markdown files as notes
+func NewImportFromDirHandler(storage note.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse directory
+		dir, ok := arguments["directory"].(string)
+		if !ok || dir == "" {
+			return nil, fmt.Errorf("directory is required")
+		}
+
+		// Parse optional strict
+		strict := false
+		if strictRaw, ok := arguments["strict"]; ok {
+			strict, ok = strictRaw.(bool)
+			if !ok {
+				return nil, fmt.Errorf("strict must be a boolean")
+			}
+		}
+
+		result, err := storage.ImportNotesFromDir(ctx, dir, strict)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import notes: %w", err)
+		}
+
+		jsonData, err := mcpx.MarshalJSON(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Imported %d notes from %s, %d files failed\n\n%s", result.Created, dir, len(result.Errors), string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,117 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+)
+
+func TestImportFromDirHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewImportFromDirHandler(mockStorage)
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "lenient import by default",
+			args: map[string]interface{}{
+				"directory": "/tmp/vault",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ImportNotesFromDir(gomock.Any(), "/tmp/vault", false).
+					Return(&note.ImportResult{
+						Created:    2,
+						CreatedIDs: []int64{4, 5},
+						Errors:     []note.ImportFileError{{File: "broken.md", Error: "front matter is not closed with ---"}},
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Imported 2 notes from /tmp/vault, 1 files failed",
+		},
+		{
+			name: "strict import",
+			args: map[string]interface{}{
+				"directory": "vault",
+				"strict":    true,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ImportNotesFromDir(gomock.Any(), "vault", true).
+					Return(&note.ImportResult{Created: 1, CreatedIDs: []int64{9}, Errors: []note.ImportFileError{}}, nil)
+			},
+			wantErr:     false,
+			wantContent: `"created_ids": [`,
+		},
+		{
+			name:        "missing directory",
+			args:        map[string]interface{}{},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "directory is required",
+		},
+		{
+			name: "invalid strict",
+			args: map[string]interface{}{
+				"directory": "vault",
+				"strict":    "yes",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "strict must be a boolean",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{
+				"directory": "vault",
+				"strict":    true,
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ImportNotesFromDir(gomock.Any(), "vault", true).
+					Return(nil, errors.New("failed to import broken.md: invalid note type: video"))
+			},
+			wantErr:     true,
+			wantContent: "failed to import notes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
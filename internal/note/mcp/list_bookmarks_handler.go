@@ -0,0 +1,58 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// NewListBookmarksHandler creates a new handler for listing the notes bookmarked in a session
+func NewListBookmarksHandler(storage note.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse session
+		session, ok := arguments["session"].(string)
+		if !ok || session == "" {
+			return nil, fmt.Errorf("session is required")
+		}
+
+		bookmarks, err := storage.ListBookmarks(ctx, session)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list bookmarks: %w", err)
+		}
+
+		if len(bookmarks) == 0 {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.TextContent{
+						Type: "text",
+						Text: fmt.Sprintf("No notes bookmarked in session %q", session),
+					},
+				},
+			}, nil
+		}
+
+		jsonData, err := mcpx.MarshalJSON(bookmarks)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Found %d bookmarked notes in session %q:\n\n%s", len(bookmarks), session, string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
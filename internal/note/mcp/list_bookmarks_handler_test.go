@@ -0,0 +1,107 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+)
+
+func TestListBookmarksHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewListBookmarksHandler(mockStorage)
+
+	bookmarkedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent []string
+	}{
+		{
+			name: "bookmarks found",
+			args: map[string]interface{}{"session": "agent-1"},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ListBookmarks(gomock.Any(), "agent-1").
+					Return([]note.Bookmark{
+						{Note: note.Note{ID: 2, Title: "Second"}, BookmarkedAt: bookmarkedAt},
+						{Note: note.Note{ID: 1, Title: "First"}, BookmarkedAt: bookmarkedAt},
+					}, nil)
+			},
+			wantErr: false,
+			wantContent: []string{
+				`Found 2 bookmarked notes in session "agent-1"`,
+				`"title": "Second"`,
+				`"bookmarked_at": "2024-03-01T12:00:00Z"`,
+			},
+		},
+		{
+			name: "no bookmarks",
+			args: map[string]interface{}{"session": "agent-2"},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ListBookmarks(gomock.Any(), "agent-2").
+					Return([]note.Bookmark{}, nil)
+			},
+			wantErr:     false,
+			wantContent: []string{`No notes bookmarked in session "agent-2"`},
+		},
+		{
+			name:        "missing session",
+			args:        map[string]interface{}{},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: []string{"session is required"},
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{"session": "agent-1"},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					ListBookmarks(gomock.Any(), "agent-1").
+					Return(nil, errors.New("database is locked"))
+			},
+			wantErr:     true,
+			wantContent: []string{"failed to list bookmarks: database is locked"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{}
+			req.Params.Arguments = tt.args
+
+			result, err := handler(context.Background(), req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				for _, want := range tt.wantContent {
+					assert.Contains(t, err.Error(), want)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotNil(t, result)
+			text := result.Content[0].(gomcp.TextContent).Text
+			for _, want := range tt.wantContent {
+				assert.Contains(t, text, want)
+			}
+		})
+	}
+}
@@ -2,119 +2,182 @@ package mcp
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
 )
 
-// NewListHandler creates a new handler for listing notes
-func NewListHandler(storage note.Storage) server.ToolHandlerFunc {
+// NewListHandler creates a new handler for listing notes, with page sizes bounded by limits
+func NewListHandler(storage note.Storage, limits mcpx.ListLimits) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		arguments, ok := req.Params.Arguments.(map[string]interface{})
 		if !ok {
 			return nil, fmt.Errorf("invalid arguments format")
 		}
 
-		listReq := note.ListNotesRequest{}
-
-		// Parse limit
-		if limit, ok := arguments["limit"].(float64); ok {
-			listReq.Limit = int(limit)
-		} else {
-			listReq.Limit = 100 // default
+		listReq, err := parseListNotesRequest(arguments)
+		if err != nil {
+			return nil, err
 		}
 
-		// Parse offset
-		if offset, ok := arguments["offset"].(float64); ok {
-			listReq.Offset = int(offset)
-		} else {
-			listReq.Offset = 0 // default
+		// Parse optional limit
+		limit, err := limits.ParseLimit(arguments)
+		if err != nil {
+			return nil, err
 		}
+		listReq.Limit = limit
 
-		// Parse search
-		if search, ok := arguments["search"].(string); ok {
-			listReq.Search = search
+		response, err := storage.List(ctx, listReq)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list notes: %w", err)
 		}
 
-		// Parse type
-		if noteType, ok := arguments["type"].(string); ok {
-			listReq.Type = noteType
-		}
+		return listNotesResult(listReq, response, "notes")
+	}
+}
 
-		// Parse order_by
-		if orderBy, ok := arguments["order_by"].(string); ok {
-			listReq.OrderBy = orderBy
-		}
+// parseListNotesRequest reads the filtering, ordering and offset arguments
+// shared by the note listing tools. Each tool parses its own limit.
+func parseListNotesRequest(arguments map[string]interface{}) (note.ListNotesRequest, error) {
+	listReq := note.ListNotesRequest{}
 
-		// Parse order_dir
-		if orderDir, ok := arguments["order_dir"].(string); ok {
-			listReq.OrderDir = orderDir
+	// Parse offset
+	if offsetRaw, ok := arguments["offset"]; ok {
+		offset, err := mcpx.ParseInt(offsetRaw)
+		if err != nil {
+			return note.ListNotesRequest{}, fmt.Errorf("invalid offset: %w", err)
 		}
+		listReq.Offset = offset
+	}
 
-		// Parse tags
-		if tagsRaw, ok := arguments["tags"].([]interface{}); ok {
-			var tags []string
-			for _, tag := range tagsRaw {
-				if tagStr, ok := tag.(string); ok {
-					tags = append(tags, tagStr)
-				}
+	// Parse search
+	if search, ok := arguments["search"].(string); ok {
+		listReq.Search = search
+	}
+
+	// Parse type
+	if noteType, ok := arguments["type"].(string); ok {
+		listReq.Type = noteType
+	}
+
+	// Parse types
+	if typesRaw, ok := arguments["types"].([]interface{}); ok {
+		var types []string
+		for _, noteType := range typesRaw {
+			if typeStr, ok := noteType.(string); ok {
+				types = append(types, typeStr)
 			}
-			listReq.Tags = tags
 		}
+		listReq.Types = types
+	}
 
-		response, err := storage.List(ctx, listReq)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list notes: %w", err)
-		}
+	// Parse order_by
+	if orderBy, ok := arguments["order_by"].(string); ok {
+		listReq.OrderBy = orderBy
+	}
 
-		if len(response.Items) == 0 {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					mcp.TextContent{
-						Type: "text",
-						Text: "No notes found",
-					},
-				},
-			}, nil
-		}
+	// Parse order_dir
+	if orderDir, ok := arguments["order_dir"].(string); ok {
+		listReq.OrderDir = orderDir
+	}
 
-		var results []map[string]interface{}
-		for _, n := range response.Items {
-			result := map[string]interface{}{
-				"id":         n.ID,
-				"title":      n.Title,
-				"content":    n.Content,
-				"type":       n.Type,
-				"tags":       n.Tags,
-				"metadata":   n.Metadata,
-				"created_at": n.CreatedAt,
-				"updated_at": n.UpdatedAt,
+	// Parse tags
+	if tagsRaw, ok := arguments["tags"].([]interface{}); ok {
+		var tags []string
+		for _, tag := range tagsRaw {
+			if tagStr, ok := tag.(string); ok {
+				tags = append(tags, tagStr)
 			}
-			results = append(results, result)
 		}
+		listReq.Tags = tags
+	}
 
-		summary := map[string]interface{}{
-			"total": response.Total,
-			"count": len(response.Items),
-			"items": results,
+	// Parse exclude_tags
+	if tagsRaw, ok := arguments["exclude_tags"].([]interface{}); ok {
+		var tags []string
+		for _, tag := range tagsRaw {
+			if tagStr, ok := tag.(string); ok {
+				tags = append(tags, tagStr)
+			}
 		}
+		listReq.ExcludeTags = tags
+	}
 
-		jsonData, err := json.MarshalIndent(summary, "", "  ")
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal results: %w", err)
+	// Parse source
+	if source, ok := arguments["source"].(string); ok {
+		listReq.Source = source
+	}
+
+	// Parse has_connection_type; connection types are lowercase, so match them case-insensitively
+	if connType, ok := arguments["has_connection_type"].(string); ok {
+		if connType = strings.ToLower(strings.TrimSpace(connType)); connType != "" {
+			listReq.HasConnectionType = &connType
 		}
+	}
+
+	return listReq, nil
+}
 
+// listNotesResult formats a page of notes; noun names what was listed in the summary line
+func listNotesResult(listReq note.ListNotesRequest, response *note.ListNotesResponse, noun string) (*mcp.CallToolResult, error) {
+	if len(response.Items) == 0 {
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				mcp.TextContent{
 					Type: "text",
-					Text: fmt.Sprintf("Found %d notes (total: %d):\n\n%s", len(response.Items), response.Total, string(jsonData)),
+					Text: fmt.Sprintf("No %s found", noun),
 				},
 			},
 		}, nil
 	}
-}
\ No newline at end of file
+
+	var results []map[string]interface{}
+	for _, n := range response.Items {
+		result := map[string]interface{}{
+			"id":                n.ID,
+			"title":             n.Title,
+			"content":           n.Content,
+			"type":              n.Type,
+			"tags":              n.Tags,
+			"metadata":          n.Metadata,
+			"source":            n.Source,
+			"knowledge_base_id": n.KnowledgeBaseID,
+			"incoming_count":    n.IncomingCount,
+			"outgoing_count":    n.OutgoingCount,
+			"created_at":        n.CreatedAt,
+			"updated_at":        n.UpdatedAt,
+		}
+		if rendered := note.Render(n); rendered != nil {
+			result["rendered"] = rendered
+		}
+		results = append(results, result)
+	}
+
+	summary := map[string]interface{}{
+		"items":    results,
+		"limit":    listReq.Limit,
+		"offset":   listReq.Offset,
+		"total":    response.Total,
+		"count":    len(response.Items),
+		"has_more": int64(listReq.Offset+len(response.Items)) < response.Total,
+	}
+
+	jsonData, err := mcpx.MarshalJSON(summary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal results: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.TextContent{
+				Type: "text",
+				Text: fmt.Sprintf("Found %d %s (total: %d):\n\n%s", len(response.Items), noun, response.Total, string(jsonData)),
+			},
+		},
+	}, nil
+}
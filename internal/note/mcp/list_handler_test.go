@@ -10,6 +10,7 @@ import (
 	gomcp "github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mcp"
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
@@ -20,7 +21,7 @@ func TestListHandler(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockStorage := mock.NewMockStorage(ctrl)
-	handler := mcp.NewListHandler(mockStorage)
+	handler := mcp.NewListHandler(mockStorage, mcpx.DefaultListLimits())
 
 	now := time.Now()
 	metadata := map[string]interface{}{"key": "value"}
@@ -110,6 +111,218 @@ func TestListHandler(t *testing.T) {
 			wantErr:     false,
 			wantContent: "Found 1 notes (total: 1)",
 		},
+		{
+			name: "list with several types",
+			args: map[string]interface{}{
+				"type":  "text",
+				"types": []interface{}{"code", "markdown"},
+				"tags":  []interface{}{"go"},
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					List(gomock.Any(), note.ListNotesRequest{
+						Limit:  100,
+						Offset: 0,
+						Type:   "text",
+						Types:  []string{"code", "markdown"},
+						Tags:   []string{"go"},
+					}).
+					Return(&note.ListNotesResponse{
+						Items: []note.Note{},
+						Total: 0,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "No notes found",
+		},
+		{
+			name: "list with source filter",
+			args: map[string]interface{}{
+				"source": "research-agent",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					List(gomock.Any(), note.ListNotesRequest{
+						Limit:  100,
+						Offset: 0,
+						Source: "research-agent",
+					}).
+					Return(&note.ListNotesResponse{
+						Items: []note.Note{},
+						Total: 0,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "No notes found",
+		},
+		{
+			name: "list with connection type filter",
+			args: map[string]interface{}{
+				"has_connection_type": "contradicts",
+			},
+			mockSetup: func() {
+				connType := "contradicts"
+				mockStorage.EXPECT().
+					List(gomock.Any(), note.ListNotesRequest{
+						Limit:             100,
+						Offset:            0,
+						HasConnectionType: &connType,
+					}).
+					Return(&note.ListNotesResponse{
+						Items: []note.Note{{ID: 1, Title: "Claim", Type: "text", CreatedAt: now, UpdatedAt: now}},
+						Total: 1,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Found 1 notes (total: 1)",
+		},
+		{
+			name: "list with included and excluded tags",
+			args: map[string]interface{}{
+				"tags":         []interface{}{"go"},
+				"exclude_tags": []interface{}{"draft", "archived"},
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					List(gomock.Any(), note.ListNotesRequest{
+						Limit:       100,
+						Offset:      0,
+						Tags:        []string{"go"},
+						ExcludeTags: []string{"draft", "archived"},
+					}).
+					Return(&note.ListNotesResponse{
+						Items: []note.Note{{ID: 3, Title: "Go final", Type: "text", Tags: []string{"go"}, CreatedAt: now, UpdatedAt: now}},
+						Total: 1,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Found 1 notes (total: 1)",
+		},
+		{
+			name: "mixed-case and padded connection type is normalized",
+			args: map[string]interface{}{
+				"has_connection_type": " Contradicts ",
+			},
+			mockSetup: func() {
+				connType := "contradicts"
+				mockStorage.EXPECT().
+					List(gomock.Any(), note.ListNotesRequest{
+						Limit:             100,
+						Offset:            0,
+						HasConnectionType: &connType,
+					}).
+					Return(&note.ListNotesResponse{
+						Items: []note.Note{},
+						Total: 0,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "No notes found",
+		},
+		{
+			name: "blank connection type is ignored",
+			args: map[string]interface{}{
+				"has_connection_type": "  ",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					List(gomock.Any(), note.ListNotesRequest{
+						Limit:  100,
+						Offset: 0,
+					}).
+					Return(&note.ListNotesResponse{
+						Items: []note.Note{},
+						Total: 0,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "No notes found",
+		},
+		{
+			name: "empty connection type is ignored",
+			args: map[string]interface{}{
+				"has_connection_type": "",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					List(gomock.Any(), note.ListNotesRequest{
+						Limit:  100,
+						Offset: 0,
+					}).
+					Return(&note.ListNotesResponse{
+						Items: []note.Note{},
+						Total: 0,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "No notes found",
+		},
+		{
+			name: "more pages available",
+			args: map[string]interface{}{
+				"limit":  float64(1),
+				"offset": float64(1),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					List(gomock.Any(), note.ListNotesRequest{
+						Limit:  1,
+						Offset: 1,
+					}).
+					Return(&note.ListNotesResponse{
+						Items: []note.Note{{ID: 2, Title: "Note 2", Content: "Content 2", Type: "markdown", CreatedAt: now, UpdatedAt: now}},
+						Total: 3,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: `"has_more": true`,
+		},
+		{
+			name: "numeric arguments as strings",
+			args: map[string]interface{}{
+				"limit":  "1",
+				"offset": "2",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					List(gomock.Any(), note.ListNotesRequest{
+						Limit:  1,
+						Offset: 2,
+					}).
+					Return(&note.ListNotesResponse{
+						Items: []note.Note{{ID: 3, Title: "Note 3", Content: "Content 3", Type: "markdown", CreatedAt: now, UpdatedAt: now}},
+						Total: 3,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Note 3",
+		},
+		{
+			name: "invalid offset",
+			args: map[string]interface{}{
+				"offset": "second",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid offset",
+		},
+		{
+			name: "link notes include their domain",
+			args: map[string]interface{}{
+				"type": "link",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					List(gomock.Any(), note.ListNotesRequest{Limit: 100, Type: "link"}).
+					Return(&note.ListNotesResponse{
+						Items: []note.Note{
+							{ID: 1, Title: "Article", Content: "https://blog.example.org/a", Type: "link", CreatedAt: now, UpdatedAt: now},
+						},
+						Total: 1,
+					}, nil)
+			},
+			wantContent: `"domain": "blog.example.org"`,
+		},
 		{
 			name: "empty results",
 			args: map[string]interface{}{},
@@ -162,4 +375,69 @@ func TestListHandler(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestListHandler_CustomLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewListHandler(mockStorage, mcpx.ListLimits{Default: 5, Max: 20})
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "configured default",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					List(gomock.Any(), note.ListNotesRequest{Limit: 5}).
+					Return(&note.ListNotesResponse{Items: []note.Note{}}, nil)
+			},
+			wantContent: "No notes found",
+		},
+		{
+			name: "configured maximum",
+			args: map[string]interface{}{"limit": float64(20)},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					List(gomock.Any(), note.ListNotesRequest{Limit: 20}).
+					Return(&note.ListNotesResponse{Items: []note.Note{}}, nil)
+			},
+			wantContent: "No notes found",
+		},
+		{
+			name:        "above the configured maximum",
+			args:        map[string]interface{}{"limit": float64(21)},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "limit must be between 1 and 20, got: 21",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
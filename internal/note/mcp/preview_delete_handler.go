@@ -0,0 +1,67 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// NewPreviewDeleteHandler creates a new handler for previewing what deleting a note would remove
+func NewPreviewDeleteHandler(storage note.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse ID
+		idRaw, ok := arguments["id"]
+		if !ok {
+			return nil, fmt.Errorf("id is required")
+		}
+
+		id, err := mcpx.ParseInt64(idRaw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id: %w", err)
+		}
+
+		if id <= 0 {
+			return nil, fmt.Errorf("id must be a positive integer")
+		}
+
+		preview, err := storage.PreviewDelete(ctx, id)
+		if errors.Is(err, note.ErrNotFound) {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.TextContent{
+						Type: "text",
+						Text: fmt.Sprintf("Note with ID %d not found", id),
+					},
+				},
+			}, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to preview note deletion: %w", err)
+		}
+
+		jsonData, err := mcpx.MarshalJSON(preview)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Deleting note %d would remove %d connections affecting %d other notes:\n\n%s", id, preview.Connections, len(preview.Neighbors), string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
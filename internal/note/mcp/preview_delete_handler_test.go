@@ -0,0 +1,144 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+)
+
+func TestPreviewDeleteHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewPreviewDeleteHandler(mockStorage)
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "summary counts connections and neighbors",
+			args: map[string]interface{}{
+				"id": float64(1),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					PreviewDelete(gomock.Any(), int64(1)).
+					Return(&note.DeletePreview{
+						NoteID:      1,
+						Connections: 3,
+						Neighbors: []note.AffectedNeighbor{
+							{ID: 2, Title: "Two", Connections: 2},
+							{ID: 3, Title: "Three", Connections: 1},
+						},
+					}, nil)
+			},
+			wantContent: "Deleting note 1 would remove 3 connections affecting 2 other notes",
+		},
+		{
+			name: "neighbors are listed",
+			args: map[string]interface{}{
+				"id": float64(1),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					PreviewDelete(gomock.Any(), int64(1)).
+					Return(&note.DeletePreview{
+						NoteID:      1,
+						Connections: 1,
+						Neighbors:   []note.AffectedNeighbor{{ID: 2, Title: "Two", Connections: 1}},
+					}, nil)
+			},
+			wantContent: `"title": "Two"`,
+		},
+		{
+			name: "note without connections",
+			args: map[string]interface{}{
+				"id": float64(1),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					PreviewDelete(gomock.Any(), int64(1)).
+					Return(&note.DeletePreview{NoteID: 1, Neighbors: []note.AffectedNeighbor{}}, nil)
+			},
+			wantContent: `"neighbors": []`,
+		},
+		{
+			name: "note not found",
+			args: map[string]interface{}{
+				"id": float64(999),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					PreviewDelete(gomock.Any(), int64(999)).
+					Return(nil, fmt.Errorf("%w: 999", note.ErrNotFound))
+			},
+			wantContent: "Note with ID 999 not found",
+		},
+		{
+			name:        "missing id",
+			args:        map[string]interface{}{},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "id is required",
+		},
+		{
+			name: "non-positive id",
+			args: map[string]interface{}{
+				"id": float64(0),
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "id must be a positive integer",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{
+				"id": float64(1),
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					PreviewDelete(gomock.Any(), int64(1)).
+					Return(nil, errors.New("storage error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to preview note deletion",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
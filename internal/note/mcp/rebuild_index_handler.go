@@ -0,0 +1,36 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// NewRebuildIndexHandler creates a new handler for rebuilding the note search index
+func NewRebuildIndexHandler(storage note.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		stats, err := storage.RebuildSearchIndex(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to rebuild search index: %w", err)
+		}
+
+		jsonData, err := mcpx.MarshalJSON(stats)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Rebuilt search index: %d of %d notes indexed (was %d):\n\n%s", stats.IndexedAfter, stats.Notes, stats.IndexedBefore, string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,30 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// NewRecomputeCountsHandler creates a new handler for repairing cached note connection counts
+func NewRecomputeCountsHandler(storage note.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		corrected, err := storage.RecomputeConnectionCounts(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to recompute connection counts: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Recomputed connection counts: %d notes corrected", corrected),
+				},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,72 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+)
+
+func TestRecomputeCountsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewRecomputeCountsHandler(mockStorage)
+
+	tests := []struct {
+		name        string
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "counts corrected",
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					RecomputeConnectionCounts(gomock.Any()).
+					Return(int64(3), nil)
+			},
+			wantContent: "Recomputed connection counts: 3 notes corrected",
+		},
+		{
+			name: "storage error",
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					RecomputeConnectionCounts(gomock.Any()).
+					Return(int64(0), errors.New("storage error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to recompute connection counts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: map[string]interface{}{},
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// NewRenameTagHandler creates a new handler for renaming a tag on every note
+func NewRenameTagHandler(storage note.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse old_tag
+		oldTag, ok := arguments["old_tag"].(string)
+		if !ok || oldTag == "" {
+			return nil, fmt.Errorf("old_tag is required")
+		}
+
+		// Parse new_tag
+		newTag, ok := arguments["new_tag"].(string)
+		if !ok || newTag == "" {
+			return nil, fmt.Errorf("new_tag is required")
+		}
+
+		renamed, err := storage.RenameTag(ctx, oldTag, newTag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to rename tag: %w", err)
+		}
+
+		text := fmt.Sprintf("Successfully renamed tag %q to %q on %d notes", oldTag, newTag, renamed)
+		if renamed == 0 {
+			text = fmt.Sprintf("No notes have tag %q", oldTag)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: text,
+				},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,101 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+)
+
+func TestRenameTagHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewRenameTagHandler(mockStorage)
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "renamed on several notes",
+			args: map[string]interface{}{"old_tag": "golang", "new_tag": "go"},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					RenameTag(gomock.Any(), "golang", "go").
+					Return(int64(3), nil)
+			},
+			wantErr:     false,
+			wantContent: `Successfully renamed tag "golang" to "go" on 3 notes`,
+		},
+		{
+			name: "no notes with the tag",
+			args: map[string]interface{}{"old_tag": "python", "new_tag": "py"},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					RenameTag(gomock.Any(), "python", "py").
+					Return(int64(0), nil)
+			},
+			wantErr:     false,
+			wantContent: `No notes have tag "python"`,
+		},
+		{
+			name:        "missing old tag",
+			args:        map[string]interface{}{"new_tag": "go"},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "old_tag is required",
+		},
+		{
+			name:        "new tag not a string",
+			args:        map[string]interface{}{"old_tag": "golang", "new_tag": float64(1)},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "new_tag is required",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{"old_tag": "go", "new_tag": "go"},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					RenameTag(gomock.Any(), "go", "go").
+					Return(int64(0), errors.New(`new tag must differ from old tag "go"`))
+			},
+			wantErr:     true,
+			wantContent: "failed to rename tag: new tag must differ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
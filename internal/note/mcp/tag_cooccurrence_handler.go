@@ -0,0 +1,74 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// defaultCooccurrenceLimit is the number of related tags returned when no limit is given
+const defaultCooccurrenceLimit = 20
+
+// NewTagCooccurrenceHandler creates a new handler for finding tags used alongside a tag
+func NewTagCooccurrenceHandler(storage note.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse tag
+		tag, ok := arguments["tag"].(string)
+		if !ok || tag == "" {
+			return nil, fmt.Errorf("tag is required")
+		}
+
+		// Parse optional limit
+		limit := defaultCooccurrenceLimit
+		if limitRaw, ok := arguments["limit"]; ok {
+			parsed, err := mcpx.ParseInt(limitRaw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid limit: %w", err)
+			}
+			if parsed < 1 || parsed > 100 {
+				return nil, fmt.Errorf("limit must be between 1 and 100, got: %d", parsed)
+			}
+			limit = parsed
+		}
+
+		counts, err := storage.GetTagCooccurrence(ctx, tag, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tag co-occurrence: %w", err)
+		}
+
+		if len(counts) == 0 {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.TextContent{
+						Type: "text",
+						Text: fmt.Sprintf("No tags found alongside %q", tag),
+					},
+				},
+			}, nil
+		}
+
+		jsonData, err := mcpx.MarshalJSON(counts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("Found %d tags used alongside %q:\n\n%s", len(counts), tag, string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
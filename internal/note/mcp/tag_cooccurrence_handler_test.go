@@ -0,0 +1,120 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+)
+
+func TestTagCooccurrenceHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewTagCooccurrenceHandler(mockStorage)
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "related tags with default limit",
+			args: map[string]interface{}{"tag": "go"},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetTagCooccurrence(gomock.Any(), "go", 20).
+					Return([]note.TagCount{{Tag: "concurrency", Count: 3}, {Tag: "testing", Count: 1}}, nil)
+			},
+			wantErr:     false,
+			wantContent: `Found 2 tags used alongside "go"`,
+		},
+		{
+			name: "explicit limit",
+			args: map[string]interface{}{"tag": "go", "limit": float64(5)},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetTagCooccurrence(gomock.Any(), "go", 5).
+					Return([]note.TagCount{{Tag: "concurrency", Count: 3}}, nil)
+			},
+			wantErr:     false,
+			wantContent: `"tag": "concurrency"`,
+		},
+		{
+			name: "no related tags",
+			args: map[string]interface{}{"tag": "lonely"},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetTagCooccurrence(gomock.Any(), "lonely", 20).
+					Return([]note.TagCount{}, nil)
+			},
+			wantErr:     false,
+			wantContent: `No tags found alongside "lonely"`,
+		},
+		{
+			name:        "missing tag",
+			args:        map[string]interface{}{},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "tag is required",
+		},
+		{
+			name:        "limit out of range",
+			args:        map[string]interface{}{"tag": "go", "limit": float64(101)},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "limit must be between 1 and 100",
+		},
+		{
+			name:        "fractional limit",
+			args:        map[string]interface{}{"tag": "go", "limit": 2.5},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid limit: 2.5 is not an integer",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{"tag": "go"},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetTagCooccurrence(gomock.Any(), "go", 20).
+					Return(nil, errors.New("storage error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to get tag co-occurrence",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// NewTimelineHandler creates a new handler for counting notes created over time
+func NewTimelineHandler(storage note.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse optional granularity
+		granularity := note.GranularityDay
+		if granularityRaw, ok := arguments["granularity"]; ok {
+			granularity, ok = granularityRaw.(string)
+			if !ok {
+				return nil, fmt.Errorf("granularity must be a string")
+			}
+		}
+
+		// Parse optional since
+		since, err := mcpx.ParseRelativeTimeArgument(arguments, "since")
+		if err != nil {
+			return nil, err
+		}
+
+		timeline, err := storage.GetCreationTimeline(ctx, granularity, since)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get note timeline: %w", err)
+		}
+
+		if len(timeline) == 0 {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.TextContent{
+						Type: "text",
+						Text: "No notes found",
+					},
+				},
+			}, nil
+		}
+
+		var total int64
+		for _, b := range timeline {
+			total += b.Count
+		}
+
+		jsonData, err := mcpx.MarshalJSON(timeline)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("%d notes created over %d periods by %s (UTC):\n\n%s", total, len(timeline), granularity, string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,138 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+)
+
+func TestTimelineHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewTimelineHandler(mockStorage)
+
+	march := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	april := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "daily by default",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetCreationTimeline(gomock.Any(), "day", gomock.Nil()).
+					Return([]note.TimeBucket{{Start: march, Count: 3}}, nil)
+			},
+			wantErr:     false,
+			wantContent: "3 notes created over 1 periods by day (UTC)",
+		},
+		{
+			name: "monthly",
+			args: map[string]interface{}{"granularity": "month"},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetCreationTimeline(gomock.Any(), "month", gomock.Nil()).
+					Return([]note.TimeBucket{{Start: march, Count: 2}, {Start: april, Count: 5}}, nil)
+			},
+			wantErr:     false,
+			wantContent: `"start": "2024-04-01T00:00:00Z"`,
+		},
+		{
+			name: "no notes",
+			args: map[string]interface{}{"granularity": "week"},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetCreationTimeline(gomock.Any(), "week", gomock.Nil()).
+					Return([]note.TimeBucket{}, nil)
+			},
+			wantErr:     false,
+			wantContent: "No notes found",
+		},
+		{
+			name: "since a relative time",
+			args: map[string]interface{}{"since": "7d"},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetCreationTimeline(gomock.Any(), "day", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, since *time.Time) ([]note.TimeBucket, error) {
+						assert.WithinDuration(t, time.Now().UTC().Add(-7*24*time.Hour), *since, time.Minute)
+						return []note.TimeBucket{{Start: march, Count: 1}}, nil
+					})
+			},
+			wantErr:     false,
+			wantContent: "1 notes created over 1 periods by day (UTC)",
+		},
+		{
+			name:        "invalid since",
+			args:        map[string]interface{}{"since": "yesterday"},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: `invalid since: invalid relative time "yesterday"`,
+		},
+		{
+			name:        "since not a string",
+			args:        map[string]interface{}{"since": float64(7)},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "since must be a string",
+		},
+		{
+			name:        "granularity not a string",
+			args:        map[string]interface{}{"granularity": float64(7)},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "granularity must be a string",
+		},
+		{
+			name: "invalid granularity",
+			args: map[string]interface{}{"granularity": "year"},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					GetCreationTimeline(gomock.Any(), "year", gomock.Nil()).
+					Return(nil, errors.New(`invalid granularity "year", must be one of [day week month]`))
+			},
+			wantErr:     true,
+			wantContent: "failed to get note timeline: invalid granularity",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
@@ -4,11 +4,12 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
 )
 
 // RegisterTools registers all note MCP tools with the server
-func RegisterTools(s *server.MCPServer, storage note.Storage) error {
+func RegisterTools(s *server.MCPServer, storage note.Storage, limits mcpx.ListLimits, middleware ...mcpx.Middleware) error {
 	tools := []struct {
 		name        string
 		description string
@@ -22,6 +23,7 @@ func RegisterTools(s *server.MCPServer, storage note.Storage) error {
 			schema: mcp.ToolInputSchema{
 				Type: "object",
 				Properties: map[string]interface{}{
+					"idempotency_key": mcpx.IdempotencyKeyProperty("note"),
 					"title": map[string]interface{}{
 						"type":        "string",
 						"description": "Title of the note",
@@ -32,8 +34,8 @@ func RegisterTools(s *server.MCPServer, storage note.Storage) error {
 					},
 					"type": map[string]interface{}{
 						"type":        "string",
-						"description": "Type of the note (text, markdown, code, link, image)",
-						"enum":        []string{"text", "markdown", "code", "link", "image"},
+						"description": "Type of the note (default: text)",
+						"enum":        note.ValidNoteTypes(),
 					},
 					"tags": map[string]interface{}{
 						"type":        "array",
@@ -46,25 +48,86 @@ func RegisterTools(s *server.MCPServer, storage note.Storage) error {
 						"type":        "object",
 						"description": "Additional metadata for the note",
 					},
+					"source": map[string]interface{}{
+						"type":        "string",
+						"description": "Optional name of the agent or tool creating the note, for attribution",
+					},
 				},
 				Required: []string{"title", "content"},
 			},
 		},
 		{
 			name:        "get_note",
-			description: "Get a note by ID",
+			description: "Get a note by ID. Link notes include the URL domain and code notes their language under rendered",
 			handler:     NewGetHandler(storage),
 			schema: mcp.ToolInputSchema{
 				Type: "object",
 				Properties: map[string]interface{}{
 					"id": map[string]interface{}{
-						"type":        "string",
+						"type":        "integer",
 						"description": "Unique identifier of the note",
 					},
 				},
 				Required: []string{"id"},
 			},
 		},
+		{
+			name:        "get_notes",
+			description: "Get several notes by ID in one call, e.g. to load the notes of a path or neighborhood. Notes come back in the requested order and unknown IDs are listed under missing_ids",
+			handler:     NewGetManyHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"ids": map[string]interface{}{
+						"type":        "array",
+						"description": "IDs of the notes to get",
+						"items": map[string]interface{}{
+							"type": "integer",
+						},
+						"minItems": 1,
+						"maxItems": maxGetNoteIDs,
+					},
+				},
+				Required: []string{"ids"},
+			},
+		},
+		{
+			name:        "get_note_by_title",
+			description: "Look up notes by exact title to find their IDs. With case_insensitive several notes can match; all of them are returned so you can pick one",
+			handler:     NewGetByTitleHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"title": map[string]interface{}{
+						"type":        "string",
+						"description": "Title to match exactly",
+					},
+					"case_insensitive": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Ignore the case of ASCII letters when matching (default: false)",
+					},
+				},
+				Required: []string{"title"},
+			},
+		},
+		{
+			name:        "find_notes_by_content_hash",
+			description: "Find notes with the same content up to whitespace, e.g. to check for a pasted-twice note before creating one. Pass the content_hash from get_note, or the content itself",
+			handler:     NewFindByContentHashHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"content_hash": map[string]interface{}{
+						"type":        "string",
+						"description": "Content hash of a note, as returned by get_note",
+					},
+					"content": map[string]interface{}{
+						"type":        "string",
+						"description": "Content to look for instead of a hash; whitespace differences are ignored",
+					},
+				},
+			},
+		},
 		{
 			name:        "update_note",
 			description: "Update an existing note",
@@ -73,7 +136,7 @@ func RegisterTools(s *server.MCPServer, storage note.Storage) error {
 				Type: "object",
 				Properties: map[string]interface{}{
 					"id": map[string]interface{}{
-						"type":        "string",
+						"type":        "integer",
 						"description": "Unique identifier of the note",
 					},
 					"title": map[string]interface{}{
@@ -86,8 +149,8 @@ func RegisterTools(s *server.MCPServer, storage note.Storage) error {
 					},
 					"type": map[string]interface{}{
 						"type":        "string",
-						"description": "Updated type of the note (text, markdown, code, link, image)",
-						"enum":        []string{"text", "markdown", "code", "link", "image"},
+						"description": "Updated type of the note",
+						"enum":        note.ValidNoteTypes(),
 					},
 					"tags": map[string]interface{}{
 						"type":        "array",
@@ -100,10 +163,37 @@ func RegisterTools(s *server.MCPServer, storage note.Storage) error {
 						"type":        "object",
 						"description": "Updated metadata for the note",
 					},
+					"expected_updated_at": map[string]interface{}{
+						"type":        "string",
+						"description": "updated_at of the note as last read (RFC 3339). The update fails without changes if the note has been modified since",
+					},
 				},
 				Required: []string{"id"},
 			},
 		},
+		{
+			name:        "append_note_content",
+			description: "Append text to the end of a note's content, e.g. to add an entry to a running log or journal. The append is atomic, so concurrent appends to the same note are all kept, unlike reading the note and calling update_note",
+			handler:     NewAppendHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"id": map[string]interface{}{
+						"type":        "integer",
+						"description": "Unique identifier of the note to append to",
+					},
+					"text": map[string]interface{}{
+						"type":        "string",
+						"description": "Text to append",
+					},
+					"separator": map[string]interface{}{
+						"type":        "string",
+						"description": "Text placed between the existing content and the appended text, left out when the note is empty (default: a blank line)",
+					},
+				},
+				Required: []string{"id", "text"},
+			},
+		},
 		{
 			name:        "delete_note",
 			description: "Delete a note by ID",
@@ -112,60 +202,251 @@ func RegisterTools(s *server.MCPServer, storage note.Storage) error {
 				Type: "object",
 				Properties: map[string]interface{}{
 					"id": map[string]interface{}{
-						"type":        "string",
+						"type":        "integer",
 						"description": "Unique identifier of the note to delete",
 					},
 				},
 				Required: []string{"id"},
 			},
 		},
+		{
+			name:        "delete_notes",
+			description: "Delete several notes by ID in one transaction. Their connections are deleted with them; the result reports how many each note had and lists IDs that did not exist under not_found",
+			handler:     NewDeleteManyHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"ids": map[string]interface{}{
+						"type":        "array",
+						"description": "IDs of the notes to delete",
+						"items": map[string]interface{}{
+							"type": "integer",
+						},
+						"minItems": 1,
+						"maxItems": maxDeleteNoteIDs,
+					},
+				},
+				Required: []string{"ids"},
+			},
+		},
+		{
+			name:        "preview_note_deletion",
+			description: "Preview what deleting a note would remove without deleting anything: the number of connections that would be deleted with it and the neighbor notes that would lose them. Use it to warn before delete_note or delete_notes",
+			handler:     NewPreviewDeleteHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"id": map[string]interface{}{
+						"type":        "integer",
+						"description": "Unique identifier of the note to preview deleting",
+					},
+				},
+				Required: []string{"id"},
+			},
+		},
 		{
 			name:        "list_notes",
 			description: "List all notes with optional filtering and pagination",
-			handler:     NewListHandler(storage),
+			handler:     NewListHandler(storage, limits),
+			schema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: listNotesProperties(limits),
+			},
+		},
+		{
+			name:        "count_notes",
+			description: "Count the notes matching the list_notes filters without fetching them, e.g. to size a query before paging through it",
+			handler:     NewCountHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: mcpx.CountProperties(listNotesProperties(limits)),
+			},
+		},
+		{
+			name:        "find_unassigned_notes",
+			description: "Find notes that do not belong to any knowledge base, to triage uncategorized content. Supports the same filtering, ordering and pagination as list_notes",
+			handler:     NewUnassignedHandler(storage, limits),
+			schema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: listNotesProperties(limits),
+			},
+		},
+		{
+			name:        "export_notes_to_directory",
+			description: "Export notes as markdown files with YAML front matter (title, type, tags, timestamps), one <id>-<slug>.md file per note, e.g. to open them as an Obsidian vault. Existing files are never overwritten. The directory must be inside the server's files root. Supports the same filters as list_notes",
+			handler:     NewExportToDirHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: exportNotesProperties(),
+				Required:   []string{"directory"},
+			},
+		},
+		{
+			name:        "import_notes_from_directory",
+			description: "Import the markdown files in a directory and its subdirectories as notes, e.g. to migrate an Obsidian vault. YAML front matter may set title, tags and type; otherwise the file name is the title and the type is markdown. Hidden directories such as .obsidian are skipped. The directory must be inside the server's files root",
+			handler:     NewImportFromDirHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"directory": map[string]interface{}{
+						"type":        "string",
+						"description": "Directory to read .md files from, relative to the server's files root",
+					},
+					"strict": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Fail without creating any notes if a file cannot be imported; when false failing files are skipped and listed under errors (default: false)",
+					},
+				},
+				Required: []string{"directory"},
+			},
+		},
+		{
+			name:        "assign_notes_to_knowledge_base",
+			description: "Assign many notes to a knowledge base at once, e.g. to organize notes found with find_unassigned_notes. Notes already in another knowledge base are moved",
+			handler:     NewAssignHandler(storage),
 			schema: mcp.ToolInputSchema{
 				Type: "object",
 				Properties: map[string]interface{}{
+					"knowledge_base_id": map[string]interface{}{
+						"type":        "integer",
+						"description": "Unique identifier of the knowledge base to assign the notes to",
+					},
+					"note_ids": map[string]interface{}{
+						"type":        "array",
+						"description": "IDs of the notes to assign",
+						"items": map[string]interface{}{
+							"type": "integer",
+						},
+						"minItems": 1,
+						"maxItems": 500,
+					},
+					"strict": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Fail without changes if any note ID does not exist; when false unknown IDs are skipped (default: true)",
+					},
+				},
+				Required: []string{"knowledge_base_id", "note_ids"},
+			},
+		},
+		{
+			name:        "get_tag_cooccurrence",
+			description: "Find the tags most often used on the same notes as a given tag, with shared note counts. Useful for learning the tagging vocabulary and suggesting related tags",
+			handler:     NewTagCooccurrenceHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"tag": map[string]interface{}{
+						"type":        "string",
+						"description": "Tag to find related tags for",
+					},
 					"limit": map[string]interface{}{
 						"type":        "integer",
-						"description": "Maximum number of notes to return (default: 100)",
+						"description": "Maximum number of related tags to return (default: 20, max: 100)",
 						"minimum":     1,
-						"maximum":     1000,
+						"maximum":     100,
 					},
-					"offset": map[string]interface{}{
-						"type":        "integer",
-						"description": "Number of notes to skip (default: 0)",
-						"minimum":     0,
+				},
+				Required: []string{"tag"},
+			},
+		},
+		{
+			name:        "rename_tag",
+			description: "Rename a tag on every note that has it, for consolidating synonyms such as golang and go. A note that already has the new tag keeps a single copy",
+			handler:     NewRenameTagHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"old_tag": map[string]interface{}{
+						"type":        "string",
+						"description": "Tag to replace",
 					},
-					"search": map[string]interface{}{
+					"new_tag": map[string]interface{}{
 						"type":        "string",
-						"description": "Search term to filter notes by title or content",
+						"description": "Tag to use instead",
 					},
-					"type": map[string]interface{}{
+				},
+				Required: []string{"old_tag", "new_tag"},
+			},
+		},
+		{
+			name:        "delete_tag",
+			description: "Remove a tag from every note that has it, leaving the notes otherwise unchanged. Useful for purging an obsolete tag",
+			handler:     NewDeleteTagHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"tag": map[string]interface{}{
 						"type":        "string",
-						"description": "Filter by note type",
-						"enum":        []string{"text", "markdown", "code", "link", "image"},
+						"description": "Tag to remove",
 					},
-					"tags": map[string]interface{}{
-						"type":        "array",
-						"description": "Filter by tags (returns notes that have any of the specified tags)",
-						"items": map[string]interface{}{
-							"type": "string",
-						},
+				},
+				Required: []string{"tag"},
+			},
+		},
+		{
+			name:        "bookmark_note",
+			description: "Bookmark a note you are working with, to find it again later in the conversation with list_bookmarks. Bookmarks belong to a session name you choose and go away when the note is deleted",
+			handler:     NewBookmarkHandler(storage),
+			schema:      bookmarkSchema("ID of the note to bookmark"),
+		},
+		{
+			name:        "unbookmark_note",
+			description: "Remove a note's bookmark from a session. The note itself is not changed",
+			handler:     NewUnbookmarkHandler(storage),
+			schema:      bookmarkSchema("ID of the note whose bookmark to remove"),
+		},
+		{
+			name:        "list_bookmarks",
+			description: "List the notes bookmarked in a session, most recently bookmarked first, with the time each was bookmarked",
+			handler:     NewListBookmarksHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"session": map[string]interface{}{
+						"type":        "string",
+						"description": "Session name the bookmarks were made in",
 					},
-					"order_by": map[string]interface{}{
+				},
+				Required: []string{"session"},
+			},
+		},
+		{
+			name:        "get_note_timeline",
+			description: "Count the notes created per day, week or month to show how the knowledge base grows. Periods are UTC calendar days, weeks starting on Monday, or months; empty periods between the first and last note are included with a zero count, up to 5000 periods in all",
+			handler:     NewTimelineHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"granularity": map[string]interface{}{
 						"type":        "string",
-						"description": "Field to order by (created_at, updated_at, title)",
-						"enum":        []string{"created_at", "updated_at", "title"},
+						"description": "Length of each period (default: day)",
+						"enum":        []string{"day", "week", "month"},
 					},
-					"order_dir": map[string]interface{}{
+					"since": map[string]interface{}{
 						"type":        "string",
-						"description": "Order direction (asc, desc)",
-						"enum":        []string{"asc", "desc"},
+						"description": "Only count notes created in this long before now, such as 24h, 7d, 2w or P30D",
 					},
 				},
 			},
 		},
+		{
+			name:        "rebuild_search_index",
+			description: "Rebuild the note full-text search index when search results look out of sync with stored notes",
+			handler:     NewRebuildIndexHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: map[string]interface{}{},
+			},
+		},
+		{
+			name:        "recompute_connection_counts",
+			description: "Recount the incoming_count and outgoing_count of every note from its connections when they look out of sync",
+			handler:     NewRecomputeCountsHandler(storage),
+			schema: mcp.ToolInputSchema{
+				Type:       "object",
+				Properties: map[string]interface{}{},
+			},
+		},
 	}
 
 	for _, tool := range tools {
@@ -174,8 +455,103 @@ func RegisterTools(s *server.MCPServer, storage note.Storage) error {
 			Description: tool.description,
 			InputSchema: tool.schema,
 		}
-		s.AddTool(t, tool.handler)
+		s.AddTool(t, mcpx.Wrap(tool.name, tool.handler, middleware...))
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// listNotesProperties returns the input schema properties shared by the note listing tools
+func listNotesProperties(limits mcpx.ListLimits) map[string]interface{} {
+	return map[string]interface{}{
+		"limit": limits.LimitProperty("notes"),
+		"offset": map[string]interface{}{
+			"type":        "integer",
+			"description": "Number of notes to skip (default: 0)",
+			"minimum":     0,
+		},
+		"search": map[string]interface{}{
+			"type":        "string",
+			"description": "Search term to filter notes by title or content",
+		},
+		"type": map[string]interface{}{
+			"type":        "string",
+			"description": "Filter by note type",
+			"enum":        note.ValidNoteTypes(),
+		},
+		"types": map[string]interface{}{
+			"type":        "array",
+			"description": "Filter by any of these note types, combined with type when both are given",
+			"items": map[string]interface{}{
+				"type": "string",
+				"enum": note.ValidNoteTypes(),
+			},
+		},
+		"tags": map[string]interface{}{
+			"type":        "array",
+			"description": "Filter by tags (returns notes that have any of the specified tags)",
+			"items": map[string]interface{}{
+				"type": "string",
+			},
+		},
+		"exclude_tags": map[string]interface{}{
+			"type":        "array",
+			"description": "Leave out notes that have any of these tags, e.g. archived or draft. Applied after tags, so a tag in both lists matches nothing",
+			"items": map[string]interface{}{
+				"type": "string",
+			},
+		},
+		"order_by": map[string]interface{}{
+			"type":        "string",
+			"description": "Field to order by (default: created_at)",
+			"enum":        note.ValidOrderByFields(),
+		},
+		"order_dir": map[string]interface{}{
+			"type":        "string",
+			"description": "Order direction (asc, desc)",
+			"enum":        []string{"asc", "desc"},
+		},
+		"source": map[string]interface{}{
+			"type":        "string",
+			"description": "Filter by the source that created the notes",
+		},
+		"has_connection_type": map[string]interface{}{
+			"type":        "string",
+			"description": "Only notes with at least one connection of this type, as source or target, e.g. contradicts, case-insensitive",
+		},
+	}
+}
+
+// exportNotesProperties is the list_notes schema plus the export directory,
+// with a limit that defaults to every matching note
+func exportNotesProperties() map[string]interface{} {
+	properties := listNotesProperties(mcpx.DefaultListLimits())
+	properties["directory"] = map[string]interface{}{
+		"type":        "string",
+		"description": "Directory to write the markdown files to, created if missing; relative to the server's files root",
+	}
+	properties["limit"] = map[string]interface{}{
+		"type":        "integer",
+		"description": "Maximum number of notes to export (default: all matching notes)",
+		"minimum":     1,
+	}
+	return properties
+}
+
+// bookmarkSchema is the input schema shared by bookmark_note and unbookmark_note
+func bookmarkSchema(noteIDDescription string) mcp.ToolInputSchema {
+	return mcp.ToolInputSchema{
+		Type: "object",
+		Properties: map[string]interface{}{
+			"session": map[string]interface{}{
+				"type":        "string",
+				"description": "Session name that groups the bookmarks, such as a conversation or agent ID. Any non-empty name works",
+			},
+			"note_id": map[string]interface{}{
+				"type":        "integer",
+				"description": noteIDDescription,
+			},
+		},
+		Required: []string{"session", "note_id"},
+	}
+}
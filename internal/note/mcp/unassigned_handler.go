@@ -0,0 +1,41 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// NewUnassignedHandler creates a new handler for finding notes that belong to no knowledge base
+func NewUnassignedHandler(storage note.Storage, limits mcpx.ListLimits) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		listReq, err := parseListNotesRequest(arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		// Parse optional limit
+		limit, err := limits.ParseLimit(arguments)
+		if err != nil {
+			return nil, err
+		}
+		listReq.Limit = limit
+
+		response, err := storage.FindUnassignedNotes(ctx, listReq)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find unassigned notes: %w", err)
+		}
+
+		return listNotesResult(listReq, response, "unassigned notes")
+	}
+}
@@ -0,0 +1,133 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+)
+
+func TestUnassignedHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewUnassignedHandler(mockStorage, mcpx.DefaultListLimits())
+
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "unassigned notes with defaults",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindUnassignedNotes(gomock.Any(), note.ListNotesRequest{
+						Limit:  100,
+						Offset: 0,
+					}).
+					Return(&note.ListNotesResponse{
+						Items: []note.Note{
+							{
+								ID:        1,
+								Title:     "Loose Note",
+								Content:   "Content",
+								Type:      "text",
+								CreatedAt: now,
+								UpdatedAt: now,
+							},
+						},
+						Total: 1,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Found 1 unassigned notes (total: 1)",
+		},
+		{
+			name: "pagination and ordering",
+			args: map[string]interface{}{
+				"limit":     float64(10),
+				"offset":    float64(20),
+				"order_by":  "title",
+				"order_dir": "asc",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindUnassignedNotes(gomock.Any(), note.ListNotesRequest{
+						Limit:    10,
+						Offset:   20,
+						OrderBy:  "title",
+						OrderDir: "asc",
+					}).
+					Return(&note.ListNotesResponse{
+						Items: []note.Note{
+							{ID: 21, Title: "A", Content: "Content", Type: "text", CreatedAt: now, UpdatedAt: now},
+						},
+						Total: 40,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: `"has_more": true`,
+		},
+		{
+			name: "no unassigned notes",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindUnassignedNotes(gomock.Any(), gomock.Any()).
+					Return(&note.ListNotesResponse{Items: []note.Note{}, Total: 0}, nil)
+			},
+			wantErr:     false,
+			wantContent: "No unassigned notes found",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					FindUnassignedNotes(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("storage error"))
+			},
+			wantErr:     true,
+			wantContent: "failed to find unassigned notes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// NewUnbookmarkHandler creates a new handler for removing a note's bookmark in a session
+func NewUnbookmarkHandler(storage note.Storage) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		session, noteID, err := parseBookmarkArguments(req)
+		if err != nil {
+			return nil, err
+		}
+
+		removed, err := storage.UnbookmarkNote(ctx, session, noteID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove bookmark: %w", err)
+		}
+
+		text := fmt.Sprintf("Successfully removed the bookmark on note %d in session %q", noteID, session)
+		if !removed {
+			text = fmt.Sprintf("Note %d is not bookmarked in session %q", noteID, session)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: text,
+				},
+			},
+		}, nil
+	}
+}
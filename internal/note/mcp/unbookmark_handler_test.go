@@ -0,0 +1,91 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mcp"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+)
+
+func TestUnbookmarkHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mock.NewMockStorage(ctrl)
+	handler := mcp.NewUnbookmarkHandler(mockStorage)
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name: "removed",
+			args: map[string]interface{}{"session": "agent-1", "note_id": float64(7)},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					UnbookmarkNote(gomock.Any(), "agent-1", int64(7)).
+					Return(true, nil)
+			},
+			wantErr:     false,
+			wantContent: `Successfully removed the bookmark on note 7 in session "agent-1"`,
+		},
+		{
+			name: "not bookmarked",
+			args: map[string]interface{}{"session": "agent-1", "note_id": float64(8)},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					UnbookmarkNote(gomock.Any(), "agent-1", int64(8)).
+					Return(false, nil)
+			},
+			wantErr:     false,
+			wantContent: `Note 8 is not bookmarked in session "agent-1"`,
+		},
+		{
+			name:        "session not a string",
+			args:        map[string]interface{}{"session": float64(1), "note_id": float64(7)},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "session is required",
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{"session": "agent-1", "note_id": float64(7)},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					UnbookmarkNote(gomock.Any(), "agent-1", int64(7)).
+					Return(false, errors.New("database is locked"))
+			},
+			wantErr:     true,
+			wantContent: "failed to remove bookmark: database is locked",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{}
+			req.Params.Arguments = tt.args
+
+			result, err := handler(context.Background(), req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantContent)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotNil(t, result)
+			assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, tt.wantContent)
+		})
+	}
+}
@@ -2,13 +2,14 @@ package mcp
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"strconv"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
 )
 
@@ -20,14 +21,19 @@ func NewUpdateHandler(storage note.Storage) server.ToolHandlerFunc {
 			return nil, fmt.Errorf("invalid arguments format")
 		}
 
-		idStr, ok := arguments["id"].(string)
-		if !ok || idStr == "" {
+		// Parse ID
+		idRaw, ok := arguments["id"]
+		if !ok {
 			return nil, fmt.Errorf("id is required")
 		}
 
-		id, err := strconv.ParseInt(idStr, 10, 64)
+		id, err := mcpx.ParseInt64(idRaw)
 		if err != nil {
-			return nil, fmt.Errorf("invalid id format: %w", err)
+			return nil, fmt.Errorf("invalid id: %w", err)
+		}
+
+		if id <= 0 {
+			return nil, fmt.Errorf("id must be a positive integer")
 		}
 
 		updateReq := note.UpdateNoteRequest{}
@@ -41,6 +47,9 @@ func NewUpdateHandler(storage note.Storage) server.ToolHandlerFunc {
 		}
 
 		if noteType, ok := arguments["type"].(string); ok && noteType != "" {
+			if !note.IsValidNoteType(noteType) {
+				return nil, fmt.Errorf("invalid note type: %s. Valid types are: %v", noteType, note.ValidNoteTypes())
+			}
 			updateReq.Type = &noteType
 		}
 
@@ -58,12 +67,16 @@ func NewUpdateHandler(storage note.Storage) server.ToolHandlerFunc {
 			updateReq.Metadata = metadataRaw
 		}
 
-		n, err := storage.Update(ctx, id, updateReq)
-		if err != nil {
-			return nil, fmt.Errorf("failed to update note: %w", err)
+		if expectedRaw, ok := arguments["expected_updated_at"].(string); ok && expectedRaw != "" {
+			expected, err := time.Parse(time.RFC3339, expectedRaw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid expected_updated_at, use the RFC 3339 updated_at from get_note: %w", err)
+			}
+			updateReq.ExpectedUpdatedAt = &expected
 		}
 
-		if n == nil {
+		n, err := storage.Update(ctx, id, updateReq)
+		if errors.Is(err, note.ErrNotFound) {
 			return &mcp.CallToolResult{
 				Content: []mcp.Content{
 					mcp.TextContent{
@@ -73,19 +86,24 @@ func NewUpdateHandler(storage note.Storage) server.ToolHandlerFunc {
 				},
 			}, nil
 		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to update note: %w", err)
+		}
 
 		result := map[string]interface{}{
-			"id":         n.ID,
-			"title":      n.Title,
-			"content":    n.Content,
-			"type":       n.Type,
-			"tags":       n.Tags,
-			"metadata":   n.Metadata,
-			"created_at": n.CreatedAt,
-			"updated_at": n.UpdatedAt,
+			"id":                n.ID,
+			"title":             n.Title,
+			"content":           n.Content,
+			"type":              n.Type,
+			"tags":              n.Tags,
+			"metadata":          n.Metadata,
+			"source":            n.Source,
+			"knowledge_base_id": n.KnowledgeBaseID,
+			"created_at":        n.CreatedAt,
+			"updated_at":        n.UpdatedAt,
 		}
 
-		jsonData, err := json.MarshalIndent(result, "", "  ")
+		jsonData, err := mcpx.MarshalJSON(result)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal result: %w", err)
 		}
@@ -99,4 +117,4 @@ func NewUpdateHandler(storage note.Storage) server.ToolHandlerFunc {
 			},
 		}, nil
 	}
-}
\ No newline at end of file
+}
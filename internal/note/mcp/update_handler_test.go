@@ -3,6 +3,7 @@ package mcp_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -92,6 +93,58 @@ func TestUpdateHandler(t *testing.T) {
 			wantErr:     false,
 			wantContent: "Successfully updated note with ID: 1",
 		},
+		{
+			name: "update with expected_updated_at",
+			args: map[string]interface{}{
+				"id":                  "1",
+				"tags":                []interface{}{"tag1"},
+				"expected_updated_at": "2026-10-14T10:00:00Z",
+			},
+			mockSetup: func() {
+				expected := time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)
+				mockStorage.EXPECT().
+					Update(gomock.Any(), int64(1), note.UpdateNoteRequest{
+						Tags:              []string{"tag1"},
+						ExpectedUpdatedAt: &expected,
+					}).
+					Return(&note.Note{
+						ID:        1,
+						Title:     "Title",
+						Content:   "Content",
+						Type:      "text",
+						Tags:      []string{"tag1"},
+						CreatedAt: now,
+						UpdatedAt: now,
+					}, nil)
+			},
+			wantErr:     false,
+			wantContent: "Successfully updated note with ID: 1",
+		},
+		{
+			name: "stale expected_updated_at",
+			args: map[string]interface{}{
+				"id":                  "1",
+				"tags":                []interface{}{"tag1"},
+				"expected_updated_at": "2026-10-14T10:00:00Z",
+			},
+			mockSetup: func() {
+				mockStorage.EXPECT().
+					Update(gomock.Any(), int64(1), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: note 1 was updated at 2026-10-14T10:05:00Z, expected 2026-10-14T10:00:00Z", note.ErrConflict))
+			},
+			wantErr:     true,
+			wantContent: "modified by someone else",
+		},
+		{
+			name: "invalid expected_updated_at",
+			args: map[string]interface{}{
+				"id":                  "1",
+				"expected_updated_at": "yesterday",
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "invalid expected_updated_at",
+		},
 		{
 			name: "note not found",
 			args: map[string]interface{}{
@@ -101,7 +154,7 @@ func TestUpdateHandler(t *testing.T) {
 			mockSetup: func() {
 				mockStorage.EXPECT().
 					Update(gomock.Any(), int64(999), gomock.Any()).
-					Return(nil, nil)
+					Return(nil, fmt.Errorf("%w: 999", note.ErrNotFound))
 			},
 			wantErr:     false,
 			wantContent: "Note with ID 999 not found",
@@ -123,7 +176,16 @@ func TestUpdateHandler(t *testing.T) {
 			},
 			mockSetup:   func() {},
 			wantErr:     true,
-			wantContent: "invalid id format",
+			wantContent: "invalid id: ",
+		},
+		{
+			name: "non-positive id",
+			args: map[string]interface{}{
+				"id": float64(0),
+			},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: "id must be a positive integer",
 		},
 		{
 			name: "storage error",
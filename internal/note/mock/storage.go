@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	note "github.com/red1r3ct/knowledge-graph-mcp/internal/note"
@@ -35,6 +36,66 @@ func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
 	return m.recorder
 }
 
+// AppendContent mocks base method.
+func (m *MockStorage) AppendContent(ctx context.Context, noteID int64, text, separator string) (*note.Note, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendContent", ctx, noteID, text, separator)
+	ret0, _ := ret[0].(*note.Note)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AppendContent indicates an expected call of AppendContent.
+func (mr *MockStorageMockRecorder) AppendContent(ctx, noteID, text, separator interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendContent", reflect.TypeOf((*MockStorage)(nil).AppendContent), ctx, noteID, text, separator)
+}
+
+// AssignNotesToKnowledgeBase mocks base method.
+func (m *MockStorage) AssignNotesToKnowledgeBase(ctx context.Context, kbID int64, noteIDs []int64, strict bool) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignNotesToKnowledgeBase", ctx, kbID, noteIDs, strict)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignNotesToKnowledgeBase indicates an expected call of AssignNotesToKnowledgeBase.
+func (mr *MockStorageMockRecorder) AssignNotesToKnowledgeBase(ctx, kbID, noteIDs, strict interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignNotesToKnowledgeBase", reflect.TypeOf((*MockStorage)(nil).AssignNotesToKnowledgeBase), ctx, kbID, noteIDs, strict)
+}
+
+// BookmarkNote mocks base method.
+func (m *MockStorage) BookmarkNote(ctx context.Context, session string, noteID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BookmarkNote", ctx, session, noteID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BookmarkNote indicates an expected call of BookmarkNote.
+func (mr *MockStorageMockRecorder) BookmarkNote(ctx, session, noteID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BookmarkNote", reflect.TypeOf((*MockStorage)(nil).BookmarkNote), ctx, session, noteID)
+}
+
+// Count mocks base method.
+func (m *MockStorage) Count(ctx context.Context, req note.ListNotesRequest) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, req)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockStorageMockRecorder) Count(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockStorage)(nil).Count), ctx, req)
+}
+
 // Create mocks base method.
 func (m *MockStorage) Create(ctx context.Context, req note.CreateNoteRequest) (*note.Note, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +125,81 @@ func (mr *MockStorageMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStorage)(nil).Delete), ctx, id)
 }
 
+// DeleteMany mocks base method.
+func (m *MockStorage) DeleteMany(ctx context.Context, ids []int64) (*note.DeleteResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMany", ctx, ids)
+	ret0, _ := ret[0].(*note.DeleteResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMany indicates an expected call of DeleteMany.
+func (mr *MockStorageMockRecorder) DeleteMany(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockStorage)(nil).DeleteMany), ctx, ids)
+}
+
+// DeleteTag mocks base method.
+func (m *MockStorage) DeleteTag(ctx context.Context, tag string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", ctx, tag)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockStorageMockRecorder) DeleteTag(ctx, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockStorage)(nil).DeleteTag), ctx, tag)
+}
+
+// ExportNotesToDir mocks base method.
+func (m *MockStorage) ExportNotesToDir(ctx context.Context, dir string, req note.ListNotesRequest) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportNotesToDir", ctx, dir, req)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportNotesToDir indicates an expected call of ExportNotesToDir.
+func (mr *MockStorageMockRecorder) ExportNotesToDir(ctx, dir, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportNotesToDir", reflect.TypeOf((*MockStorage)(nil).ExportNotesToDir), ctx, dir, req)
+}
+
+// FindNotesByContentHash mocks base method.
+func (m *MockStorage) FindNotesByContentHash(ctx context.Context, hash string) ([]note.Note, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindNotesByContentHash", ctx, hash)
+	ret0, _ := ret[0].([]note.Note)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindNotesByContentHash indicates an expected call of FindNotesByContentHash.
+func (mr *MockStorageMockRecorder) FindNotesByContentHash(ctx, hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindNotesByContentHash", reflect.TypeOf((*MockStorage)(nil).FindNotesByContentHash), ctx, hash)
+}
+
+// FindUnassignedNotes mocks base method.
+func (m *MockStorage) FindUnassignedNotes(ctx context.Context, req note.ListNotesRequest) (*note.ListNotesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUnassignedNotes", ctx, req)
+	ret0, _ := ret[0].(*note.ListNotesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUnassignedNotes indicates an expected call of FindUnassignedNotes.
+func (mr *MockStorageMockRecorder) FindUnassignedNotes(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUnassignedNotes", reflect.TypeOf((*MockStorage)(nil).FindUnassignedNotes), ctx, req)
+}
+
 // Get mocks base method.
 func (m *MockStorage) Get(ctx context.Context, id int64) (*note.Note, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +215,81 @@ func (mr *MockStorageMockRecorder) Get(ctx, id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStorage)(nil).Get), ctx, id)
 }
 
+// GetByTitle mocks base method.
+func (m *MockStorage) GetByTitle(ctx context.Context, title string, caseInsensitive bool) ([]note.Note, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTitle", ctx, title, caseInsensitive)
+	ret0, _ := ret[0].([]note.Note)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTitle indicates an expected call of GetByTitle.
+func (mr *MockStorageMockRecorder) GetByTitle(ctx, title, caseInsensitive interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTitle", reflect.TypeOf((*MockStorage)(nil).GetByTitle), ctx, title, caseInsensitive)
+}
+
+// GetCreationTimeline mocks base method.
+func (m *MockStorage) GetCreationTimeline(ctx context.Context, granularity string, since *time.Time) ([]note.TimeBucket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCreationTimeline", ctx, granularity, since)
+	ret0, _ := ret[0].([]note.TimeBucket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCreationTimeline indicates an expected call of GetCreationTimeline.
+func (mr *MockStorageMockRecorder) GetCreationTimeline(ctx, granularity, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCreationTimeline", reflect.TypeOf((*MockStorage)(nil).GetCreationTimeline), ctx, granularity, since)
+}
+
+// GetMany mocks base method.
+func (m *MockStorage) GetMany(ctx context.Context, ids []int64) ([]note.Note, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMany", ctx, ids)
+	ret0, _ := ret[0].([]note.Note)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMany indicates an expected call of GetMany.
+func (mr *MockStorageMockRecorder) GetMany(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockStorage)(nil).GetMany), ctx, ids)
+}
+
+// GetTagCooccurrence mocks base method.
+func (m *MockStorage) GetTagCooccurrence(ctx context.Context, tag string, limit int) ([]note.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagCooccurrence", ctx, tag, limit)
+	ret0, _ := ret[0].([]note.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagCooccurrence indicates an expected call of GetTagCooccurrence.
+func (mr *MockStorageMockRecorder) GetTagCooccurrence(ctx, tag, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagCooccurrence", reflect.TypeOf((*MockStorage)(nil).GetTagCooccurrence), ctx, tag, limit)
+}
+
+// ImportNotesFromDir mocks base method.
+func (m *MockStorage) ImportNotesFromDir(ctx context.Context, dir string, strict bool) (*note.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportNotesFromDir", ctx, dir, strict)
+	ret0, _ := ret[0].(*note.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportNotesFromDir indicates an expected call of ImportNotesFromDir.
+func (mr *MockStorageMockRecorder) ImportNotesFromDir(ctx, dir, strict interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportNotesFromDir", reflect.TypeOf((*MockStorage)(nil).ImportNotesFromDir), ctx, dir, strict)
+}
+
 // List mocks base method.
 func (m *MockStorage) List(ctx context.Context, req note.ListNotesRequest) (*note.ListNotesResponse, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +305,96 @@ func (mr *MockStorageMockRecorder) List(ctx, req interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockStorage)(nil).List), ctx, req)
 }
 
+// ListBookmarks mocks base method.
+func (m *MockStorage) ListBookmarks(ctx context.Context, session string) ([]note.Bookmark, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBookmarks", ctx, session)
+	ret0, _ := ret[0].([]note.Bookmark)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBookmarks indicates an expected call of ListBookmarks.
+func (mr *MockStorageMockRecorder) ListBookmarks(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBookmarks", reflect.TypeOf((*MockStorage)(nil).ListBookmarks), ctx, session)
+}
+
+// PreviewDelete mocks base method.
+func (m *MockStorage) PreviewDelete(ctx context.Context, noteID int64) (*note.DeletePreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewDelete", ctx, noteID)
+	ret0, _ := ret[0].(*note.DeletePreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewDelete indicates an expected call of PreviewDelete.
+func (mr *MockStorageMockRecorder) PreviewDelete(ctx, noteID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewDelete", reflect.TypeOf((*MockStorage)(nil).PreviewDelete), ctx, noteID)
+}
+
+// RebuildSearchIndex mocks base method.
+func (m *MockStorage) RebuildSearchIndex(ctx context.Context) (*note.SearchIndexStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebuildSearchIndex", ctx)
+	ret0, _ := ret[0].(*note.SearchIndexStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RebuildSearchIndex indicates an expected call of RebuildSearchIndex.
+func (mr *MockStorageMockRecorder) RebuildSearchIndex(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildSearchIndex", reflect.TypeOf((*MockStorage)(nil).RebuildSearchIndex), ctx)
+}
+
+// RecomputeConnectionCounts mocks base method.
+func (m *MockStorage) RecomputeConnectionCounts(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecomputeConnectionCounts", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecomputeConnectionCounts indicates an expected call of RecomputeConnectionCounts.
+func (mr *MockStorageMockRecorder) RecomputeConnectionCounts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecomputeConnectionCounts", reflect.TypeOf((*MockStorage)(nil).RecomputeConnectionCounts), ctx)
+}
+
+// RenameTag mocks base method.
+func (m *MockStorage) RenameTag(ctx context.Context, oldTag, newTag string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTag", ctx, oldTag, newTag)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameTag indicates an expected call of RenameTag.
+func (mr *MockStorageMockRecorder) RenameTag(ctx, oldTag, newTag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTag", reflect.TypeOf((*MockStorage)(nil).RenameTag), ctx, oldTag, newTag)
+}
+
+// UnbookmarkNote mocks base method.
+func (m *MockStorage) UnbookmarkNote(ctx context.Context, session string, noteID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnbookmarkNote", ctx, session, noteID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnbookmarkNote indicates an expected call of UnbookmarkNote.
+func (mr *MockStorageMockRecorder) UnbookmarkNote(ctx, session, noteID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbookmarkNote", reflect.TypeOf((*MockStorage)(nil).UnbookmarkNote), ctx, session, noteID)
+}
+
 // Update mocks base method.
 func (m *MockStorage) Update(ctx context.Context, id int64, req note.UpdateNoteRequest) (*note.Note, error) {
 	m.ctrl.T.Helper()
@@ -1,19 +1,25 @@
 package note
 
 import (
+	"slices"
 	"time"
 )
 
 // Note represents the domain model for a note entity
 type Note struct {
-	ID        int64                  `json:"id"`
-	Title     string                 `json:"title"`
-	Content   string                 `json:"content"`
-	Type      string                 `json:"type"`
-	Tags      []string               `json:"tags,omitempty"`
-	Metadata  map[string]interface{} `json:"metadata,omitempty"`
-	CreatedAt time.Time              `json:"created_at"`
-	UpdatedAt time.Time              `json:"updated_at"`
+	ID              int64                  `json:"id"`
+	Title           string                 `json:"title"`
+	Content         string                 `json:"content"`
+	Type            string                 `json:"type"`
+	Tags            []string               `json:"tags,omitempty"`
+	Metadata        map[string]interface{} `json:"metadata,omitempty"`
+	Source          *string                `json:"source,omitempty"`
+	KnowledgeBaseID *int64                 `json:"knowledge_base_id,omitempty"` // Knowledge base the note belongs to, nil when unassigned
+	IncomingCount   int64                  `json:"incoming_count"`              // Connections pointing to the note, maintained by triggers
+	OutgoingCount   int64                  `json:"outgoing_count"`              // Connections starting at the note, maintained by triggers
+	ContentHash     string                 `json:"content_hash"`                // ContentHash of Content, equal for notes whose content differs only in whitespace
+	CreatedAt       time.Time              `json:"created_at"`
+	UpdatedAt       time.Time              `json:"updated_at"`
 }
 
 // NoteType represents the type classification of a note
@@ -27,6 +33,17 @@ const (
 	NoteTypeImage    NoteType = "image"
 )
 
+// ValidNoteTypes returns a slice of all valid note types, the built-in ones
+// followed by any configured custom types
+func ValidNoteTypes() []string {
+	return slices.Clone(noteTypes)
+}
+
+// IsValidNoteType checks if the given type is a valid note type
+func IsValidNoteType(noteType string) bool {
+	return slices.Contains(noteTypes, noteType)
+}
+
 // CreateNoteRequest represents the DTO for creating a note
 type CreateNoteRequest struct {
 	Title    string                 `json:"title"`
@@ -34,6 +51,11 @@ type CreateNoteRequest struct {
 	Type     string                 `json:"type"`
 	Tags     []string               `json:"tags,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Source   *string                `json:"source,omitempty"` // Who or what created the record, e.g. an agent name
+
+	// IdempotencyKey, when set, makes Create return the note an earlier
+	// Create with the same key made instead of creating another
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
 }
 
 // UpdateNoteRequest represents the DTO for updating a note
@@ -43,6 +65,10 @@ type UpdateNoteRequest struct {
 	Type     *string                `json:"type,omitempty"`
 	Tags     []string               `json:"tags,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+
+	// ExpectedUpdatedAt makes the update fail with ErrConflict unless the note's
+	// updated_at still equals it, to the second
+	ExpectedUpdatedAt *time.Time `json:"expected_updated_at,omitempty"`
 }
 
 // ListNotesRequest represents the DTO for listing notes
@@ -52,12 +78,127 @@ type ListNotesRequest struct {
 	Search   string   `json:"search,omitempty"`
 	Tags     []string `json:"tags,omitempty"`
 	Type     string   `json:"type,omitempty"`
-	OrderBy  string   `json:"order_by,omitempty"`
-	OrderDir string   `json:"order_dir,omitempty"`
+	Types    []string `json:"types,omitempty"` // Keeps notes of any of these types, or of Type when both are set
+	Source   string   `json:"source,omitempty"`
+	OrderBy  string   `json:"order_by,omitempty"`  // id, created_at (default), updated_at or title
+	OrderDir string   `json:"order_dir,omitempty"` // asc or desc (default)
+	// HasConnectionType keeps notes with at least one connection of this type, in either direction
+	HasConnectionType *string `json:"has_connection_type,omitempty"`
+	// ExcludeTags leaves out notes that have any of these tags
+	ExcludeTags []string `json:"exclude_tags,omitempty"`
+}
+
+// ValidOrderByFields returns the fields notes can be ordered by
+func ValidOrderByFields() []string {
+	return []string{"id", "created_at", "updated_at", "title"}
 }
 
 // ListNotesResponse represents the DTO for listing response
 type ListNotesResponse struct {
 	Items []Note `json:"items"`
 	Total int64  `json:"total"`
-}
\ No newline at end of file
+}
+
+// DefaultAppendSeparator goes between a note's content and appended text
+// when the caller does not choose a separator
+const DefaultAppendSeparator = "\n\n"
+
+// DeleteResult reports a batch delete. ConnectionsDeleted counts every
+// connection removed with the notes once, while each DeletedNote counts the
+// connections of that note, so a connection between two deleted notes is
+// counted on both.
+type DeleteResult struct {
+	Deleted            []DeletedNote `json:"deleted"`
+	NotFound           []int64       `json:"not_found"`
+	ConnectionsDeleted int64         `json:"connections_deleted"`
+}
+
+// DeletedNote is a deleted note and the number of connections deleted with it
+type DeletedNote struct {
+	ID          int64 `json:"id"`
+	Connections int64 `json:"connections"`
+}
+
+// DeletePreview describes what deleting a note would remove with it.
+// Connections counts every connection of the note, including connections to
+// itself, and Neighbors lists the other notes those connections lead to.
+type DeletePreview struct {
+	NoteID      int64              `json:"note_id"`
+	Connections int64              `json:"connections"`
+	Neighbors   []AffectedNeighbor `json:"neighbors"`
+}
+
+// AffectedNeighbor is a note that would lose Connections connections
+type AffectedNeighbor struct {
+	ID          int64  `json:"id"`
+	Title       string `json:"title"`
+	Connections int64  `json:"connections"`
+}
+
+// ImportResult reports the outcome of importing a directory of markdown files
+type ImportResult struct {
+	Created    int64             `json:"created"`
+	CreatedIDs []int64           `json:"created_ids"`
+	Errors     []ImportFileError `json:"errors"`
+}
+
+// ImportFileError describes a file that could not be imported. File is the
+// path relative to the import directory, with forward slashes.
+type ImportFileError struct {
+	File  string `json:"file"`
+	Error string `json:"error"`
+}
+
+// Bookmark is a note bookmarked in a session, with the time it was bookmarked
+type Bookmark struct {
+	Note
+	BookmarkedAt time.Time `json:"bookmarked_at"`
+}
+
+// TagCount is a tag and the number of notes it was counted on
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
+
+// Timeline granularities accepted by GetCreationTimeline
+const (
+	GranularityDay   = "day"
+	GranularityWeek  = "week"
+	GranularityMonth = "month"
+)
+
+// ValidGranularities returns the creation timeline granularities
+func ValidGranularities() []string {
+	return []string{GranularityDay, GranularityWeek, GranularityMonth}
+}
+
+// TimeBucket is the number of notes created in the period starting at Start,
+// which is midnight UTC on the first day of the day, week or month
+type TimeBucket struct {
+	Start time.Time `json:"start"`
+	Count int64     `json:"count"`
+}
+
+// SearchIndexStats reports the state of the full-text search index around a rebuild
+type SearchIndexStats struct {
+	Notes         int64 `json:"notes"`          // Rows in the notes table
+	IndexedBefore int64 `json:"indexed_before"` // Documents in the index before the rebuild
+	IndexedAfter  int64 `json:"indexed_after"`  // Documents in the index after the rebuild
+}
+// CheckLinksOptions configures a dead-link check over link notes. Zero fields
+// use the checker's defaults.
+type CheckLinksOptions struct {
+	Timeout     time.Duration `json:"timeout,omitempty"`     // Time limit for each request
+	Concurrency int           `json:"concurrency,omitempty"` // Requests in flight at once
+}
+
+// LinkStatus is the outcome of checking one link note's URL
+type LinkStatus struct {
+	NoteID     int64  `json:"note_id"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	Reachable  bool   `json:"reachable"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
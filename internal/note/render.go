@@ -0,0 +1,74 @@
+package note
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Rendered holds structured hints derived from a note's type and content.
+// It is computed on read and never stored.
+type Rendered struct {
+	Domain   string `json:"domain,omitempty"`   // Host of a link note's URL, without a leading www.
+	Language string `json:"language,omitempty"` // Language of a code note, from its metadata
+}
+
+// Render derives the type-specific hints for n, or returns nil when its type
+// has none or nothing could be derived
+func Render(n Note) *Rendered {
+	var rendered Rendered
+
+	switch NoteType(n.Type) {
+	case NoteTypeLink:
+		u, err := url.Parse(strings.TrimSpace(n.Content))
+		if err != nil || u.Hostname() == "" {
+			return nil
+		}
+		rendered.Domain = strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	case NoteTypeCode:
+		rendered.Language = codeLanguage(n.Metadata)
+	}
+
+	if rendered == (Rendered{}) {
+		return nil
+	}
+	return &rendered
+}
+
+// codeLanguage reads the language of a code note from the language or lang
+// metadata key
+func codeLanguage(metadata map[string]interface{}) string {
+	for _, key := range []string{"language", "lang"} {
+		if language, ok := metadata[key].(string); ok && strings.TrimSpace(language) != "" {
+			return strings.ToLower(strings.TrimSpace(language))
+		}
+	}
+	return ""
+}
+
+// ValidateURL checks that raw is an absolute http or https URL with a host
+func ValidateURL(raw string) error {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w: scheme must be http or https", ErrInvalidURL)
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("%w: host is missing", ErrInvalidURL)
+	}
+	return nil
+}
+
+// ValidateContent checks the content of a note of the given type. Link and
+// image notes must hold a URL; other types accept any content.
+func ValidateContent(noteType, content string) error {
+	switch NoteType(noteType) {
+	case NoteTypeLink, NoteTypeImage:
+		if err := ValidateURL(content); err != nil {
+			return fmt.Errorf("%s note content: %w", noteType, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package note_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name string
+		note note.Note
+		want *note.Rendered
+	}{
+		{
+			name: "link domain",
+			note: note.Note{Type: "link", Content: "https://www.Example.com/articles/1?ref=x"},
+			want: &note.Rendered{Domain: "example.com"},
+		},
+		{
+			name: "link with port and whitespace",
+			note: note.Note{Type: "link", Content: "  http://docs.go.dev:8080/ref  "},
+			want: &note.Rendered{Domain: "docs.go.dev"},
+		},
+		{
+			name: "link without a host",
+			note: note.Note{Type: "link", Content: "see the wiki"},
+		},
+		{
+			name: "code language",
+			note: note.Note{Type: "code", Content: "fmt.Println()", Metadata: map[string]interface{}{"language": " Go "}},
+			want: &note.Rendered{Language: "go"},
+		},
+		{
+			name: "code lang key",
+			note: note.Note{Type: "code", Content: "print()", Metadata: map[string]interface{}{"lang": "python"}},
+			want: &note.Rendered{Language: "python"},
+		},
+		{
+			name: "code without language",
+			note: note.Note{Type: "code", Content: "x := 1", Metadata: map[string]interface{}{"language": 3}},
+		},
+		{
+			name: "image has no hints",
+			note: note.Note{Type: "image", Content: "https://example.com/cat.png"},
+		},
+		{
+			name: "text has no hints",
+			note: note.Note{Type: "text", Content: "https://example.com"},
+		},
+		{
+			name: "markdown has no hints",
+			note: note.Note{Type: "markdown", Content: "# Title", Metadata: map[string]interface{}{"language": "en"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, note.Render(tt.note))
+		})
+	}
+}
+
+func TestValidateContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		noteType string
+		content  string
+		wantErr  string
+	}{
+		{name: "link url", noteType: "link", content: "https://example.com/page"},
+		{name: "image url", noteType: "image", content: "http://example.com/cat.png"},
+		{name: "link without scheme", noteType: "link", content: "example.com/page", wantErr: "scheme must be http or https"},
+		{name: "image with other scheme", noteType: "image", content: "ftp://example.com/cat.png", wantErr: "scheme must be http or https"},
+		{name: "link without host", noteType: "link", content: "https:///page", wantErr: "host is missing"},
+		{name: "link that does not parse", noteType: "link", content: "http://[::1", wantErr: "link note content"},
+		{name: "text is not checked", noteType: "text", content: "not a url"},
+		{name: "code is not checked", noteType: "code", content: "ftp://example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := note.ValidateContent(tt.noteType, tt.content)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, note.ErrInvalidURL)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// AppendContent appends text to a note's content, with separator between the
+// existing content and text; an empty note gets text alone. The append is
+// done by SQL in a transaction, so concurrent appends to the same note are
+// serialized and none is lost, unlike a Get followed by an Update. The search
+// index follows through the notes triggers.
+func (s *Storage) AppendContent(ctx context.Context, noteID int64, text string, separator string) (*note.Note, error) {
+	var result *note.Note
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.appendContent(ctx, noteID, text, separator)
+		return err
+	})
+	return result, err
+}
+
+// appendContent makes a single attempt at AppendContent
+func (s *Storage) appendContent(ctx context.Context, noteID int64, text string, separator string) (*note.Note, error) {
+	if text == "" {
+		return nil, fmt.Errorf("text is required")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Writing first takes the write lock before the content is read back, so
+	// another append waits for this transaction instead of racing it
+	result, err := tx.ExecContext(ctx, `
+		UPDATE notes
+		SET content = CASE WHEN content = '' THEN ? ELSE content || ? || ? END, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`, text, separator, text, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to append note content: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return nil, fmt.Errorf("%w: %d", note.ErrNotFound, noteID)
+	}
+
+	var content, noteType string
+	if err := tx.QueryRowContext(ctx, "SELECT content, type FROM notes WHERE id = ?", noteID).Scan(&content, &noteType); err != nil {
+		return nil, fmt.Errorf("failed to read note content: %w", err)
+	}
+
+	if s.validateURLs {
+		if err := note.ValidateContent(noteType, content); err != nil {
+			return nil, err
+		}
+	}
+
+	contentHash := note.ContentHash(content)
+	if err := s.checkUniqueContent(ctx, tx, noteID, content, contentHash); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE notes SET content_hash = ? WHERE id = ?", contentHash, noteID); err != nil {
+		return nil, fmt.Errorf("failed to update content hash: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Info("appended note content", "note_id", noteID, "bytes", len(text))
+	return s.Get(ctx, noteID)
+}
@@ -0,0 +1,175 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/migrations"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+func TestStorage_AppendContent(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		content     string
+		missing     bool
+		text        string
+		separator   string
+		wantContent string
+		wantErr     string
+		wantErrIs   error
+	}{
+		{
+			name:        "default separator",
+			content:     "Monday: started",
+			text:        "Tuesday: continued",
+			separator:   note.DefaultAppendSeparator,
+			wantContent: "Monday: started\n\nTuesday: continued",
+		},
+		{
+			name:        "custom separator",
+			content:     "- one",
+			text:        "- two",
+			separator:   "\n",
+			wantContent: "- one\n- two",
+		},
+		{
+			name:        "no separator",
+			content:     "abc",
+			text:        "def",
+			wantContent: "abcdef",
+		},
+		{
+			name:        "empty note gets text alone",
+			content:     "",
+			text:        "First entry",
+			separator:   note.DefaultAppendSeparator,
+			wantContent: "First entry",
+		},
+		{
+			name:      "empty text",
+			content:   "Log",
+			separator: note.DefaultAppendSeparator,
+			wantErr:   "text is required",
+		},
+		{
+			name:      "missing note",
+			missing:   true,
+			text:      "Entry",
+			separator: note.DefaultAppendSeparator,
+			wantErrIs: note.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newTestStorage(t)
+
+			id := int64(99999)
+			if !tt.missing {
+				created, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Journal", Content: tt.content, Type: "text"})
+				require.NoError(t, err)
+				id = created.ID
+			}
+
+			n, err := storage.AppendContent(ctx, id, tt.text, tt.separator)
+			if tt.wantErrIs != nil {
+				assert.True(t, errors.Is(err, tt.wantErrIs), "got %v", err)
+				return
+			}
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantContent, n.Content)
+			assert.Equal(t, note.ContentHash(tt.wantContent), n.ContentHash)
+		})
+	}
+
+	t.Run("appended text is searchable", func(t *testing.T) {
+		storage := newTestStorage(t)
+		created, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Journal", Content: "Monday entry", Type: "text"})
+		require.NoError(t, err)
+
+		_, err = storage.AppendContent(ctx, created.ID, "zeppelin sighting", note.DefaultAppendSeparator)
+		require.NoError(t, err)
+
+		resp, err := storage.List(ctx, note.ListNotesRequest{Search: "zeppelin", Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, resp.Items, 1)
+		assert.Equal(t, created.ID, resp.Items[0].ID)
+	})
+
+	t.Run("unique content is enforced", func(t *testing.T) {
+		storage := newTestStorage(t, WithUniqueContent(true))
+		_, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Full", Content: "a\n\nb", Type: "text"})
+		require.NoError(t, err)
+		partial, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Partial", Content: "a", Type: "text"})
+		require.NoError(t, err)
+
+		_, err = storage.AppendContent(ctx, partial.ID, "b", note.DefaultAppendSeparator)
+		require.Error(t, err)
+
+		// The failed append is rolled back
+		current, err := storage.Get(ctx, partial.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "a", current.Content)
+	})
+}
+
+func TestStorage_AppendContentConcurrent(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "notes.db")
+	require.NoError(t, migrations.NewMigrationRunner(path).RunMigrations())
+
+	// Two storages have separate pools, like two server processes on one file
+	var storages []*Storage
+	for i := 0; i < 2; i++ {
+		storage, err := NewStorage(path)
+		require.NoError(t, err)
+		defer storage.Close()
+		storages = append(storages, storage)
+	}
+
+	created, err := storages[0].Create(ctx, note.CreateNoteRequest{Title: "Log", Content: "start", Type: "text"})
+	require.NoError(t, err)
+
+	const appends = 40
+	var wg sync.WaitGroup
+	errs := make(chan error, appends)
+	for i := 0; i < appends; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := storages[i%2].AppendContent(ctx, created.ID, fmt.Sprintf("line %d", i), "\n")
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	final, err := storages[0].Get(ctx, created.ID)
+	require.NoError(t, err)
+	lines := strings.Split(final.Content, "\n")
+	assert.Len(t, lines, appends+1)
+	assert.Equal(t, "start", lines[0])
+	for i := 0; i < appends; i++ {
+		assert.Contains(t, lines, fmt.Sprintf("line %d", i))
+	}
+	assert.Equal(t, note.ContentHash(final.Content), final.ContentHash)
+}
@@ -0,0 +1,114 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase"
+)
+
+// AssignNotesToKnowledgeBase sets knowledge_base_id for every listed note in
+// one transaction and returns the number of notes updated. The knowledge base
+// must exist. With strict set, any unknown note ID fails the whole call and
+// nothing is updated; otherwise unknown IDs are skipped.
+func (s *Storage) AssignNotesToKnowledgeBase(ctx context.Context, kbID int64, noteIDs []int64, strict bool) (int64, error) {
+	var result int64
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.assignNotesToKnowledgeBase(ctx, kbID, noteIDs, strict)
+		return err
+	})
+	return result, err
+}
+
+// assignNotesToKnowledgeBase makes a single attempt at AssignNotesToKnowledgeBase
+func (s *Storage) assignNotesToKnowledgeBase(ctx context.Context, kbID int64, noteIDs []int64, strict bool) (int64, error) {
+	if len(noteIDs) == 0 {
+		return 0, fmt.Errorf("at least one note ID is required")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists int64
+	err = tx.QueryRowContext(ctx, "SELECT id FROM knowledge_base WHERE id = ?", kbID).Scan(&exists)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("%w: %d", knowledgebase.ErrNotFound, kbID)
+		}
+		return 0, fmt.Errorf("failed to get knowledge base: %w", err)
+	}
+
+	placeholders := make([]string, len(noteIDs))
+	args := make([]interface{}, len(noteIDs))
+	for i, id := range noteIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+	inClause := strings.Join(placeholders, ", ")
+
+	if strict {
+		missing, err := missingNoteIDs(ctx, tx, noteIDs, inClause, args)
+		if err != nil {
+			return 0, err
+		}
+		if len(missing) > 0 {
+			return 0, fmt.Errorf("notes not found: %v", missing)
+		}
+	}
+
+	result, err := tx.ExecContext(ctx,
+		fmt.Sprintf("UPDATE notes SET knowledge_base_id = ?, updated_at = CURRENT_TIMESTAMP WHERE id IN (%s)", inClause),
+		append([]interface{}{kbID}, args...)...,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to assign notes: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Info("assigned notes to knowledge base", "knowledge_base_id", kbID, "requested", len(noteIDs), "updated", rowsAffected)
+	return rowsAffected, nil
+}
+
+// missingNoteIDs returns the IDs from noteIDs that have no note, in request order
+func missingNoteIDs(ctx context.Context, tx *sql.Tx, noteIDs []int64, inClause string, args []interface{}) ([]int64, error) {
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf("SELECT id FROM notes WHERE id IN (%s)", inClause), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up notes: %w", err)
+	}
+	defer rows.Close()
+
+	found := make(map[int64]bool, len(noteIDs))
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan note ID: %w", err)
+		}
+		found[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	var missing []int64
+	for _, id := range noteIDs {
+		if !found[id] {
+			missing = append(missing, id)
+			found[id] = true // report duplicates once
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,114 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+func TestStorage_AssignNotesToKnowledgeBase(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	result, err := storage.db.Exec("INSERT INTO knowledge_base (name) VALUES ('Research')")
+	require.NoError(t, err)
+	kbID, err := result.LastInsertId()
+	require.NoError(t, err)
+
+	var noteIDs []int64
+	for _, title := range []string{"First", "Second", "Third"} {
+		n, err := storage.Create(ctx, note.CreateNoteRequest{Title: title, Content: "Content", Type: "text"})
+		require.NoError(t, err)
+		noteIDs = append(noteIDs, n.ID)
+	}
+	missingID := noteIDs[len(noteIDs)-1] + 100
+
+	assignedTo := func(id int64) *int64 {
+		n, err := storage.Get(ctx, id)
+		require.NoError(t, err)
+		return n.KnowledgeBaseID
+	}
+
+	tests := []struct {
+		name      string
+		kbID      int64
+		noteIDs   []int64
+		strict    bool
+		want      int64
+		wantErr   string
+		wantErrIs error
+		assigned  []int64
+	}{
+		{
+			name:      "unknown knowledge base",
+			kbID:      kbID + 100,
+			noteIDs:   noteIDs[:1],
+			strict:    true,
+			wantErr:   "knowledge base not found",
+			wantErrIs: knowledgebase.ErrNotFound,
+		},
+		{
+			name:    "no note IDs",
+			kbID:    kbID,
+			strict:  true,
+			wantErr: "at least one note ID is required",
+		},
+		{
+			name:    "strict rejects unknown note and assigns nothing",
+			kbID:    kbID,
+			noteIDs: []int64{noteIDs[0], missingID},
+			strict:  true,
+			wantErr: "notes not found",
+		},
+		{
+			name:     "lenient skips unknown note",
+			kbID:     kbID,
+			noteIDs:  []int64{noteIDs[0], missingID},
+			strict:   false,
+			want:     1,
+			assigned: noteIDs[:1],
+		},
+		{
+			name:     "strict assigns all",
+			kbID:     kbID,
+			noteIDs:  noteIDs[1:],
+			strict:   true,
+			want:     2,
+			assigned: noteIDs,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, err := storage.AssignNotesToKnowledgeBase(ctx, tt.kbID, tt.noteIDs, tt.strict)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
+				if len(tt.noteIDs) > 0 {
+					assert.Nil(t, assignedTo(tt.noteIDs[0]))
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, updated)
+			for _, id := range tt.assigned {
+				got := assignedTo(id)
+				require.NotNil(t, got)
+				assert.Equal(t, kbID, *got)
+			}
+		})
+	}
+
+	unassigned, err := storage.FindUnassignedNotes(ctx, note.ListNotesRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), unassigned.Total)
+}
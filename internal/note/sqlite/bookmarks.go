@@ -0,0 +1,137 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// BookmarkNote bookmarks a note in a session. Bookmarking a note that is
+// already bookmarked in the session keeps the original bookmark and reports
+// false. Sessions are plain names chosen by the client and need no setup.
+func (s *Storage) BookmarkNote(ctx context.Context, session string, noteID int64) (bool, error) {
+	var result bool
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.bookmarkNote(ctx, session, noteID)
+		return err
+	})
+	return result, err
+}
+
+// bookmarkNote makes a single attempt at BookmarkNote
+func (s *Storage) bookmarkNote(ctx context.Context, session string, noteID int64) (bool, error) {
+	if strings.TrimSpace(session) == "" {
+		return false, fmt.Errorf("session is required")
+	}
+
+	result, err := s.db.ExecContext(ctx,
+		"INSERT OR IGNORE INTO note_bookmarks (session, note_id) VALUES (?, ?)",
+		session, noteID,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") {
+			return false, fmt.Errorf("%w: %d", note.ErrNotFound, noteID)
+		}
+		return false, fmt.Errorf("failed to bookmark note: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	s.logger.Info("bookmarked note", "session", session, "note_id", noteID, "added", rowsAffected > 0)
+	return rowsAffected > 0, nil
+}
+
+// UnbookmarkNote removes a note's bookmark in a session, reporting false when
+// the note was not bookmarked there
+func (s *Storage) UnbookmarkNote(ctx context.Context, session string, noteID int64) (bool, error) {
+	var result bool
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.unbookmarkNote(ctx, session, noteID)
+		return err
+	})
+	return result, err
+}
+
+// unbookmarkNote makes a single attempt at UnbookmarkNote
+func (s *Storage) unbookmarkNote(ctx context.Context, session string, noteID int64) (bool, error) {
+	if strings.TrimSpace(session) == "" {
+		return false, fmt.Errorf("session is required")
+	}
+
+	result, err := s.db.ExecContext(ctx,
+		"DELETE FROM note_bookmarks WHERE session = ? AND note_id = ?",
+		session, noteID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove bookmark: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	s.logger.Info("removed bookmark", "session", session, "note_id", noteID, "removed", rowsAffected > 0)
+	return rowsAffected > 0, nil
+}
+
+// ListBookmarks lists the notes bookmarked in a session, most recently
+// bookmarked first. An unknown session has no bookmarks.
+func (s *Storage) ListBookmarks(ctx context.Context, session string) ([]note.Bookmark, error) {
+	if strings.TrimSpace(session) == "" {
+		return nil, fmt.Errorf("session is required")
+	}
+
+	// The bookmark columns are renamed in a subquery so they cannot clash
+	// with the unqualified note columns
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+noteColumns+`, bookmarked_at
+		FROM notes
+		JOIN (
+			SELECT note_id, created_at AS bookmarked_at, rowid AS bookmark_seq
+			FROM note_bookmarks
+			WHERE session = ?
+		) ON note_id = notes.id
+		ORDER BY bookmarked_at DESC, bookmark_seq DESC
+	`, session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+	defer rows.Close()
+
+	bookmarks := []note.Bookmark{}
+	for rows.Next() {
+		var b note.Bookmark
+		b.Note, err = scanNote(bookmarkScanner{rows: rows, bookmarkedAt: &b.BookmarkedAt})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bookmark: %w", err)
+		}
+		bookmarks = append(bookmarks, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return bookmarks, nil
+}
+
+// bookmarkScanner lets scanNote read a row that has bookmarked_at after the
+// note columns
+type bookmarkScanner struct {
+	rows         rowScanner
+	bookmarkedAt *time.Time
+}
+
+// Scan scans the note columns into dest and the last column into bookmarkedAt
+func (b bookmarkScanner) Scan(dest ...interface{}) error {
+	return b.rows.Scan(append(dest, b.bookmarkedAt)...)
+}
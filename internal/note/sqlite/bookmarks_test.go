@@ -0,0 +1,126 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+func TestStorage_Bookmarks(t *testing.T) {
+	ctx := context.Background()
+	storage := newTestStorage(t)
+
+	var ids []int64
+	for _, title := range []string{"First", "Second", "Third"} {
+		n, err := storage.Create(ctx, note.CreateNoteRequest{Title: title, Content: title, Type: "text"})
+		require.NoError(t, err)
+		ids = append(ids, n.ID)
+	}
+
+	bookmarkedIDs := func(session string) []int64 {
+		bookmarks, err := storage.ListBookmarks(ctx, session)
+		require.NoError(t, err)
+		got := []int64{}
+		for _, b := range bookmarks {
+			assert.False(t, b.BookmarkedAt.IsZero())
+			got = append(got, b.ID)
+		}
+		return got
+	}
+
+	tests := []struct {
+		name    string
+		run     func() (bool, error)
+		want    bool
+		wantErr string
+		wantA   []int64
+		wantB   []int64
+	}{
+		{
+			name:  "bookmark",
+			run:   func() (bool, error) { return storage.BookmarkNote(ctx, "a", ids[0]) },
+			want:  true,
+			wantA: []int64{ids[0]},
+			wantB: []int64{},
+		},
+		{
+			name:  "newest bookmark first",
+			run:   func() (bool, error) { return storage.BookmarkNote(ctx, "a", ids[1]) },
+			want:  true,
+			wantA: []int64{ids[1], ids[0]},
+			wantB: []int64{},
+		},
+		{
+			name:  "bookmarking again keeps the bookmark",
+			run:   func() (bool, error) { return storage.BookmarkNote(ctx, "a", ids[0]) },
+			want:  false,
+			wantA: []int64{ids[1], ids[0]},
+			wantB: []int64{},
+		},
+		{
+			name:  "sessions are separate",
+			run:   func() (bool, error) { return storage.BookmarkNote(ctx, "b", ids[0]) },
+			want:  true,
+			wantA: []int64{ids[1], ids[0]},
+			wantB: []int64{ids[0]},
+		},
+		{
+			name:  "unbookmark",
+			run:   func() (bool, error) { return storage.UnbookmarkNote(ctx, "a", ids[1]) },
+			want:  true,
+			wantA: []int64{ids[0]},
+			wantB: []int64{ids[0]},
+		},
+		{
+			name:  "unbookmark a note without a bookmark",
+			run:   func() (bool, error) { return storage.UnbookmarkNote(ctx, "a", ids[2]) },
+			want:  false,
+			wantA: []int64{ids[0]},
+			wantB: []int64{ids[0]},
+		},
+		{
+			name:  "deleting a note removes its bookmarks",
+			run:   func() (bool, error) { return true, storage.Delete(ctx, ids[0]) },
+			want:  true,
+			wantA: []int64{},
+			wantB: []int64{},
+		},
+		{
+			name:    "unknown note",
+			run:     func() (bool, error) { return storage.BookmarkNote(ctx, "a", 9999) },
+			wantErr: "note not found: 9999",
+		},
+		{
+			name:    "blank session",
+			run:     func() (bool, error) { return storage.BookmarkNote(ctx, " ", ids[2]) },
+			wantErr: "session is required",
+		},
+		{
+			name:    "blank session on unbookmark",
+			run:     func() (bool, error) { return storage.UnbookmarkNote(ctx, "", ids[2]) },
+			wantErr: "session is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.run()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantA, bookmarkedIDs("a"))
+			assert.Equal(t, tt.wantB, bookmarkedIDs("b"))
+		})
+	}
+
+	_, err := storage.ListBookmarks(ctx, "")
+	assert.EqualError(t, err, "session is required")
+}
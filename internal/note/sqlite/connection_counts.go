@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+)
+
+// RecomputeConnectionCounts recounts incoming_count and outgoing_count from
+// the connections table and returns how many notes had drifted. The
+// connection triggers keep the counts current; this repairs databases where
+// they were bypassed, for example by editing the file with triggers disabled.
+// Only drifted notes are written, so correct notes keep their updated_at.
+func (s *Storage) RecomputeConnectionCounts(ctx context.Context) (int64, error) {
+	var result int64
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.recomputeConnectionCounts(ctx)
+		return err
+	})
+	return result, err
+}
+
+// recomputeConnectionCounts makes a single attempt at RecomputeConnectionCounts
+func (s *Storage) recomputeConnectionCounts(ctx context.Context) (int64, error) {
+	query := `
+		WITH counts AS (
+			SELECT n.id,
+				(SELECT COUNT(*) FROM connections WHERE to_note_id = n.id) AS incoming,
+				(SELECT COUNT(*) FROM connections WHERE from_note_id = n.id) AS outgoing
+			FROM notes n
+		)
+		UPDATE notes
+		SET incoming_count = counts.incoming, outgoing_count = counts.outgoing
+		FROM counts
+		WHERE counts.id = notes.id
+			AND (notes.incoming_count != counts.incoming OR notes.outgoing_count != counts.outgoing)
+	`
+
+	result, err := s.db.ExecContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to recompute connection counts: %w", err)
+	}
+
+	corrected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	s.logger.Info("recomputed connection counts", "corrected", corrected)
+	return corrected, nil
+}
@@ -0,0 +1,178 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+func TestStorage_ConnectionCountTriggers(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	var ids []int64
+	for _, title := range []string{"A", "B", "C"} {
+		n, err := storage.Create(ctx, note.CreateNoteRequest{Title: title, Content: "content", Type: "text"})
+		require.NoError(t, err)
+		ids = append(ids, n.ID)
+	}
+	a, b, c := ids[0], ids[1], ids[2]
+
+	connect := func(t *testing.T, from, to int64) int64 {
+		result, err := storage.db.Exec(
+			"INSERT INTO connections (from_note_id, to_note_id, type, strength) VALUES (?, ?, 'relates_to', 5)", from, to)
+		require.NoError(t, err)
+		id, err := result.LastInsertId()
+		require.NoError(t, err)
+		return id
+	}
+	exec := func(t *testing.T, query string, args ...interface{}) {
+		_, err := storage.db.Exec(query, args...)
+		require.NoError(t, err)
+	}
+
+	// counts holds incoming and outgoing counts for a, b and c
+	type counts [3][2]int64
+
+	tests := []struct {
+		name   string
+		change func(t *testing.T)
+		want   counts
+	}{
+		{
+			name:   "new notes have no connections",
+			change: func(t *testing.T) {},
+			want:   counts{{0, 0}, {0, 0}, {0, 0}},
+		},
+		{
+			name: "insert",
+			change: func(t *testing.T) {
+				connect(t, a, b)
+				connect(t, a, c)
+			},
+			want: counts{{0, 2}, {1, 0}, {1, 0}},
+		},
+		{
+			name: "delete",
+			change: func(t *testing.T) {
+				exec(t, "DELETE FROM connections WHERE from_note_id = ? AND to_note_id = ?", a, c)
+			},
+			want: counts{{0, 1}, {1, 0}, {0, 0}},
+		},
+		{
+			name: "move an endpoint",
+			change: func(t *testing.T) {
+				exec(t, "UPDATE connections SET to_note_id = ? WHERE from_note_id = ? AND to_note_id = ?", c, a, b)
+			},
+			want: counts{{0, 1}, {0, 0}, {1, 0}},
+		},
+		{
+			name: "other connection fields leave counts alone",
+			change: func(t *testing.T) {
+				exec(t, "UPDATE connections SET strength = 9")
+			},
+			want: counts{{0, 1}, {0, 0}, {1, 0}},
+		},
+		{
+			name: "self-loop counts both ways",
+			change: func(t *testing.T) {
+				connect(t, b, b)
+			},
+			want: counts{{0, 1}, {1, 1}, {1, 0}},
+		},
+		{
+			name: "delete everything",
+			change: func(t *testing.T) {
+				exec(t, "DELETE FROM connections")
+			},
+			want: counts{{0, 0}, {0, 0}, {0, 0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.change(t)
+
+			notes, err := storage.GetMany(ctx, ids)
+			require.NoError(t, err)
+			require.Len(t, notes, 3)
+			for i, n := range notes {
+				assert.Equal(t, tt.want[i][0], n.IncomingCount, "%s incoming", n.Title)
+				assert.Equal(t, tt.want[i][1], n.OutgoingCount, "%s outgoing", n.Title)
+			}
+		})
+	}
+
+	t.Run("count changes keep updated_at", func(t *testing.T) {
+		// Backdate the note. Changing a count in the same statement keeps the
+		// updated_at trigger from overwriting the old timestamp.
+		exec(t, "UPDATE notes SET updated_at = '2000-01-01 00:00:00', incoming_count = incoming_count + 1 WHERE id = ?", a)
+		exec(t, "UPDATE notes SET incoming_count = incoming_count - 1 WHERE id = ?", a)
+
+		connect(t, a, b)
+
+		n, err := storage.Get(ctx, a)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), n.OutgoingCount)
+		assert.Equal(t, 2000, n.UpdatedAt.Year())
+	})
+
+	t.Run("note edits still bump updated_at", func(t *testing.T) {
+		exec(t, "UPDATE notes SET content = 'edited' WHERE id = ?", a)
+
+		n, err := storage.Get(ctx, a)
+		require.NoError(t, err)
+		assert.NotEqual(t, 2000, n.UpdatedAt.Year())
+	})
+}
+
+func TestStorage_RecomputeConnectionCounts(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	var ids []int64
+	for _, title := range []string{"A", "B", "C"} {
+		n, err := storage.Create(ctx, note.CreateNoteRequest{Title: title, Content: "content", Type: "text"})
+		require.NoError(t, err)
+		ids = append(ids, n.ID)
+	}
+	_, err := storage.db.Exec(
+		"INSERT INTO connections (from_note_id, to_note_id, type, strength) VALUES (?, ?, 'relates_to', 5), (?, ?, 'supports', 5)",
+		ids[0], ids[1], ids[0], ids[2])
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		drift         string
+		wantCorrected int64
+	}{
+		{name: "nothing to correct", wantCorrected: 0},
+		{name: "one note drifted", drift: "UPDATE notes SET outgoing_count = 7 WHERE title = 'A'", wantCorrected: 1},
+		{name: "every note drifted", drift: "UPDATE notes SET incoming_count = 3, outgoing_count = 3", wantCorrected: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.drift != "" {
+				_, err := storage.db.Exec(tt.drift)
+				require.NoError(t, err)
+			}
+
+			corrected, err := storage.RecomputeConnectionCounts(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCorrected, corrected)
+
+			notes, err := storage.GetMany(ctx, ids)
+			require.NoError(t, err)
+			assert.Equal(t, [][2]int64{{0, 2}, {1, 0}, {1, 0}}, [][2]int64{
+				{notes[0].IncomingCount, notes[0].OutgoingCount},
+				{notes[1].IncomingCount, notes[1].OutgoingCount},
+				{notes[2].IncomingCount, notes[2].OutgoingCount},
+			})
+		})
+	}
+}
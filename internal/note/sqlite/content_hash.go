@@ -0,0 +1,104 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// FindNotesByContentHash retrieves the notes whose content hashes to hash,
+// as returned by note.ContentHash, ordered by ID. Several notes match when
+// the same content was stored more than once. No match returns an empty
+// slice, not an error.
+func (s *Storage) FindNotesByContentHash(ctx context.Context, hash string) ([]note.Note, error) {
+	hash = strings.ToLower(strings.TrimSpace(hash))
+	if hash == "" {
+		return nil, fmt.Errorf("content hash is required")
+	}
+
+	if err := s.withRetry(ctx, func() error {
+		return fillContentHashes(ctx, s.db)
+	}); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, "SELECT "+noteColumns+" FROM notes WHERE content_hash = ? ORDER BY id", hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notes by content hash: %w", err)
+	}
+	defer rows.Close()
+
+	items := []note.Note{}
+	for rows.Next() {
+		n, err := scanNote(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan note: %w", err)
+		}
+		items = append(items, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return items, nil
+}
+
+// checkUniqueContent returns ErrDuplicateContent in unique content mode when
+// a note other than excludeID already has contentHash. Blank content is
+// always allowed.
+func (s *Storage) checkUniqueContent(ctx context.Context, db dbtx, excludeID int64, content, contentHash string) error {
+	if !s.uniqueContent || note.NormalizeContent(content) == "" {
+		return nil
+	}
+
+	if err := fillContentHashes(ctx, db); err != nil {
+		return err
+	}
+
+	var existingID int64
+	err := db.QueryRowContext(ctx, "SELECT id FROM notes WHERE content_hash = ? AND id != ? ORDER BY id LIMIT 1", contentHash, excludeID).Scan(&existingID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check for duplicate content: %w", err)
+	}
+	return fmt.Errorf("%w: note %d", note.ErrDuplicateContent, existingID)
+}
+
+// fillContentHashes stores the hash of every note that has none yet: notes
+// created before migration 000015 or inserted without this storage. The
+// migration keeps this from changing updated_at.
+func fillContentHashes(ctx context.Context, db dbtx) error {
+	rows, err := db.QueryContext(ctx, "SELECT id, content FROM notes WHERE content_hash IS NULL")
+	if err != nil {
+		return fmt.Errorf("failed to find notes without a content hash: %w", err)
+	}
+
+	hashes := map[int64]string{}
+	for rows.Next() {
+		var id int64
+		var content string
+		if err := rows.Scan(&id, &content); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan note: %w", err)
+		}
+		hashes[id] = note.ContentHash(content)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	for id, hash := range hashes {
+		if _, err := db.ExecContext(ctx, "UPDATE notes SET content_hash = ? WHERE id = ? AND content_hash IS NULL", hash, id); err != nil {
+			return fmt.Errorf("failed to store content hash of note %d: %w", id, err)
+		}
+	}
+	return nil
+}
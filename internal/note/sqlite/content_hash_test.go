@@ -0,0 +1,218 @@
+package sqlite
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// noteIDs returns the IDs of notes in order
+func noteIDs(notes []note.Note) []int64 {
+	ids := []int64{}
+	for _, n := range notes {
+		ids = append(ids, n.ID)
+	}
+	return ids
+}
+
+func TestStorage_FindNotesByContentHash(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	original, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Original", Content: "Graphs have vertices\nand edges.", Type: "text"})
+	require.NoError(t, err)
+	pasted, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Pasted", Content: "  Graphs have  vertices and edges.\n", Type: "markdown"})
+	require.NoError(t, err)
+	other, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Other", Content: "Trees are graphs without cycles.", Type: "text"})
+	require.NoError(t, err)
+
+	assert.Equal(t, note.ContentHash("Graphs have vertices and edges."), original.ContentHash)
+	assert.Equal(t, original.ContentHash, pasted.ContentHash)
+	assert.NotEqual(t, original.ContentHash, other.ContentHash)
+
+	tests := []struct {
+		name    string
+		hash    string
+		wantIDs []int64
+		wantErr bool
+	}{
+		{name: "duplicates", hash: original.ContentHash, wantIDs: []int64{original.ID, pasted.ID}},
+		{name: "single note", hash: other.ContentHash, wantIDs: []int64{other.ID}},
+		{name: "upper case and spaces", hash: "  " + strings.ToUpper(other.ContentHash), wantIDs: []int64{other.ID}},
+		{name: "unknown hash", hash: note.ContentHash("nothing like this"), wantIDs: []int64{}},
+		{name: "blank hash", hash: " ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notes, err := storage.FindNotesByContentHash(ctx, tt.hash)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantIDs, noteIDs(notes))
+		})
+	}
+
+	t.Run("update rehashes the content", func(t *testing.T) {
+		updated, err := storage.Update(ctx, other.ID, note.UpdateNoteRequest{Content: strPtr("Graphs have vertices and\tedges.")})
+		require.NoError(t, err)
+		assert.Equal(t, original.ContentHash, updated.ContentHash)
+
+		notes, err := storage.FindNotesByContentHash(ctx, original.ContentHash)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{original.ID, pasted.ID, other.ID}, noteIDs(notes))
+
+		retitled, err := storage.Update(ctx, other.ID, note.UpdateNoteRequest{Title: strPtr("Renamed")})
+		require.NoError(t, err)
+		assert.Equal(t, original.ContentHash, retitled.ContentHash)
+	})
+}
+
+func TestStorage_FillContentHashes(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	// A note from before migration 000015 has no stored hash
+	_, err := storage.db.ExecContext(ctx, `INSERT INTO notes (title, content, type, tags, metadata, updated_at) VALUES ('Old', 'Written long ago', 'text', '[]', 'null', '2020-01-02 03:04:05')`)
+	require.NoError(t, err)
+
+	var stored *string
+	require.NoError(t, storage.db.QueryRowContext(ctx, "SELECT content_hash FROM notes WHERE title = 'Old'").Scan(&stored))
+	assert.Nil(t, stored)
+
+	old, err := storage.GetByTitle(ctx, "Old", false)
+	require.NoError(t, err)
+	require.Len(t, old, 1)
+	assert.Equal(t, note.ContentHash("Written long ago"), old[0].ContentHash, "the hash is computed on read")
+
+	notes, err := storage.FindNotesByContentHash(ctx, note.ContentHash("Written  long ago"))
+	require.NoError(t, err)
+	assert.Equal(t, []int64{old[0].ID}, noteIDs(notes))
+
+	require.NoError(t, storage.db.QueryRowContext(ctx, "SELECT content_hash FROM notes WHERE title = 'Old'").Scan(&stored))
+	require.NotNil(t, stored)
+	assert.Equal(t, note.ContentHash("Written long ago"), *stored)
+
+	filled, err := storage.Get(ctx, old[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), filled.UpdatedAt.UTC(), "filling in the hash is not an edit")
+}
+
+func TestStorage_UniqueContent(t *testing.T) {
+	ctx := context.Background()
+	const content = "Paxos is a consensus protocol."
+
+	tests := []struct {
+		name    string
+		enabled bool
+		change  func(storage *Storage, existing *note.Note) error
+		wantErr bool
+	}{
+		{
+			name:    "exact duplicate",
+			enabled: true,
+			change: func(storage *Storage, _ *note.Note) error {
+				_, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Copy", Content: content, Type: "text"})
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate up to whitespace",
+			enabled: true,
+			change: func(storage *Storage, _ *note.Note) error {
+				_, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Copy", Content: "\nPaxos is a\nconsensus protocol.  ", Type: "markdown"})
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name:    "different content",
+			enabled: true,
+			change: func(storage *Storage, _ *note.Note) error {
+				_, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Raft", Content: "Raft is a consensus protocol.", Type: "text"})
+				return err
+			},
+		},
+		{
+			name:    "blank content twice",
+			enabled: true,
+			change: func(storage *Storage, _ *note.Note) error {
+				if _, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Empty", Content: "", Type: "text"}); err != nil {
+					return err
+				}
+				_, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Blank", Content: " \n ", Type: "text"})
+				return err
+			},
+		},
+		{
+			name:    "update to another note's content",
+			enabled: true,
+			change: func(storage *Storage, _ *note.Note) error {
+				n, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Raft", Content: "Raft is a consensus protocol.", Type: "text"})
+				if err != nil {
+					return err
+				}
+				_, err = storage.Update(ctx, n.ID, note.UpdateNoteRequest{Content: strPtr(content)})
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name:    "update keeping its own content",
+			enabled: true,
+			change: func(storage *Storage, existing *note.Note) error {
+				_, err := storage.Update(ctx, existing.ID, note.UpdateNoteRequest{Content: strPtr(content + "\n")})
+				return err
+			},
+		},
+		{
+			name: "disabled stores duplicates",
+			change: func(storage *Storage, _ *note.Note) error {
+				_, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Copy", Content: content, Type: "text"})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newTestStorage(t, WithUniqueContent(tt.enabled))
+			existing, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Paxos", Content: content, Type: "text"})
+			require.NoError(t, err)
+
+			err = tt.change(storage, existing)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, note.ErrDuplicateContent)
+				assert.ErrorContains(t, err, "note 1")
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("import reports duplicates per file", func(t *testing.T) {
+		storage := newTestStorage(t, WithUniqueContent(true), WithFilesRoot(os.TempDir()))
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "a.md"), []byte("Same body"), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "b.md"), []byte("Same  body\n"), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "c.md"), []byte("Other body"), 0o644))
+
+		result, err := storage.ImportNotesFromDir(ctx, dir, false)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), result.Created)
+		require.Len(t, result.Errors, 1)
+		assert.Equal(t, "b.md", result.Errors[0].File)
+		assert.Contains(t, result.Errors[0].Error, note.ErrDuplicateContent.Error())
+	})
+}
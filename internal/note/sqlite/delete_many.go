@@ -0,0 +1,95 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// DeleteMany deletes the notes with the given IDs in one transaction. Their
+// connections go with them through the cascading foreign keys, so they are
+// counted before anything is deleted. A repeated ID is deleted once, and IDs
+// without a note are reported in NotFound rather than failing the batch.
+func (s *Storage) DeleteMany(ctx context.Context, ids []int64) (*note.DeleteResult, error) {
+	var result *note.DeleteResult
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.deleteMany(ctx, ids)
+		return err
+	})
+	return result, err
+}
+
+// deleteMany makes a single attempt at DeleteMany
+func (s *Storage) deleteMany(ctx context.Context, ids []int64) (*note.DeleteResult, error) {
+	result := &note.DeleteResult{Deleted: []note.DeletedNote{}, NotFound: []int64{}}
+
+	unique := make([]int64, 0, len(ids))
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) == 0 {
+		return result, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Count while every note is still there; deleting one note first would
+	// cascade away its connections to the others
+	counts := make([]int64, len(unique))
+	for i, id := range unique {
+		err := tx.QueryRowContext(ctx,
+			"SELECT COUNT(*) FROM connections WHERE from_note_id = ? OR to_note_id = ?", id, id,
+		).Scan(&counts[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to count connections of note %d: %w", id, err)
+		}
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(unique)), ", ")
+	args := make([]interface{}, 0, 2*len(unique))
+	for _, id := range unique {
+		args = append(args, id)
+	}
+	args = append(args, args...)
+	err = tx.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM connections WHERE from_note_id IN ("+placeholders+") OR to_note_id IN ("+placeholders+")", args...,
+	).Scan(&result.ConnectionsDeleted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count connections: %w", err)
+	}
+
+	for i, id := range unique {
+		res, err := tx.ExecContext(ctx, "DELETE FROM notes WHERE id = ?", id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete note %d: %w", id, err)
+		}
+
+		rowsAffected, err := res.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rowsAffected == 0 {
+			result.NotFound = append(result.NotFound, id)
+			continue
+		}
+		result.Deleted = append(result.Deleted, note.DeletedNote{ID: id, Connections: counts[i]})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Info("deleted notes", "deleted", len(result.Deleted), "not_found", len(result.NotFound), "connections", result.ConnectionsDeleted)
+	return result, nil
+}
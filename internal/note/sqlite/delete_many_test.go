@@ -0,0 +1,132 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+func TestStorage_DeleteMany(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name               string
+		titles             []string // Titles of the notes to delete; "missing" is an unknown ID
+		wantDeleted        map[string]int64
+		wantNotFound       int
+		wantConnections    int64
+		wantRemainingNotes int64
+		wantRemainingConns int64
+	}{
+		{
+			name:               "one note and its connections",
+			titles:             []string{"A"},
+			wantDeleted:        map[string]int64{"A": 3},
+			wantConnections:    3,
+			wantRemainingNotes: 5,
+			wantRemainingConns: 2,
+		},
+		{
+			name:               "connection between deleted notes counted once in the total",
+			titles:             []string{"A", "B"},
+			wantDeleted:        map[string]int64{"A": 3, "B": 2},
+			wantConnections:    4,
+			wantRemainingNotes: 4,
+			wantRemainingConns: 1,
+		},
+		{
+			name:               "self-connection",
+			titles:             []string{"Self"},
+			wantDeleted:        map[string]int64{"Self": 1},
+			wantConnections:    1,
+			wantRemainingNotes: 5,
+			wantRemainingConns: 4,
+		},
+		{
+			name:               "note without connections",
+			titles:             []string{"Loner"},
+			wantDeleted:        map[string]int64{"Loner": 0},
+			wantRemainingNotes: 5,
+			wantRemainingConns: 5,
+		},
+		{
+			name:               "missing and repeated IDs",
+			titles:             []string{"D", "missing", "D"},
+			wantDeleted:        map[string]int64{"D": 1},
+			wantNotFound:       1,
+			wantConnections:    1,
+			wantRemainingNotes: 5,
+			wantRemainingConns: 4,
+		},
+		{
+			name:               "only missing IDs",
+			titles:             []string{"missing"},
+			wantDeleted:        map[string]int64{},
+			wantNotFound:       1,
+			wantRemainingNotes: 6,
+			wantRemainingConns: 5,
+		},
+		{
+			name:               "no IDs",
+			wantDeleted:        map[string]int64{},
+			wantRemainingNotes: 6,
+			wantRemainingConns: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newTestStorage(t)
+
+			ids := make(map[string]int64)
+			titles := make(map[int64]string)
+			for _, title := range []string{"A", "B", "C", "D", "Self", "Loner"} {
+				n, err := storage.Create(ctx, note.CreateNoteRequest{Title: title, Content: "Content", Type: "text"})
+				require.NoError(t, err)
+				ids[title] = n.ID
+				titles[n.ID] = title
+			}
+			ids["missing"] = 99999
+
+			_, err := storage.db.Exec(`
+				INSERT INTO connections (from_note_id, to_note_id, type, strength) VALUES
+					(?, ?, 'relates_to', 5),
+					(?, ?, 'supports', 5),
+					(?, ?, 'references', 5),
+					(?, ?, 'relates_to', 5),
+					(?, ?, 'relates_to', 5)`,
+				ids["A"], ids["B"],
+				ids["B"], ids["C"],
+				ids["C"], ids["A"],
+				ids["A"], ids["D"],
+				ids["Self"], ids["Self"])
+			require.NoError(t, err)
+
+			req := make([]int64, 0, len(tt.titles))
+			for _, title := range tt.titles {
+				req = append(req, ids[title])
+			}
+
+			result, err := storage.DeleteMany(ctx, req)
+			require.NoError(t, err)
+
+			deleted := make(map[string]int64)
+			for _, d := range result.Deleted {
+				deleted[titles[d.ID]] = d.Connections
+			}
+			assert.Equal(t, tt.wantDeleted, deleted)
+			assert.Len(t, result.NotFound, tt.wantNotFound)
+			assert.Equal(t, tt.wantConnections, result.ConnectionsDeleted)
+
+			var notes, connections int64
+			require.NoError(t, storage.db.QueryRow("SELECT COUNT(*) FROM notes").Scan(&notes))
+			require.NoError(t, storage.db.QueryRow("SELECT COUNT(*) FROM connections").Scan(&connections))
+			assert.Equal(t, tt.wantRemainingNotes, notes)
+			assert.Equal(t, tt.wantRemainingConns, connections)
+		})
+	}
+}
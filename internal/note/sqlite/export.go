@@ -0,0 +1,148 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// maxSlugLength caps the title part of an exported file name, in runes
+const maxSlugLength = 80
+
+// ExportNotesToDir writes every note matching req to dir as a markdown file
+// named <id>-<slug>.md, with YAML front matter holding the title, type, tags
+// and timestamps followed by the note content. The result opens as an
+// Obsidian vault. dir is created if needed, and must be inside the files
+// root set with WithFilesRoot.
+//
+// A zero or negative req.Limit exports all matching notes. Existing files are
+// never overwritten: when a name is taken a number is appended, as in
+// 12-meeting-notes-2.md. It returns the number of files written, including
+// those written before an error.
+func (s *Storage) ExportNotesToDir(ctx context.Context, dir string, req note.ListNotesRequest) (int, error) {
+	if strings.TrimSpace(dir) == "" {
+		return 0, fmt.Errorf("directory is required")
+	}
+	_, dir, err := s.resolveDir(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	if req.Limit <= 0 {
+		// SQLite treats a negative LIMIT as no limit
+		req.Limit = -1
+	}
+	response, err := s.list(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	written := 0
+	for _, n := range response.Items {
+		if err := ctx.Err(); err != nil {
+			return written, err
+		}
+		if err := writeNoteFile(dir, n); err != nil {
+			return written, fmt.Errorf("failed to export note %d: %w", n.ID, err)
+		}
+		written++
+	}
+
+	s.logger.Info("exported notes to directory", "dir", dir, "notes", written)
+	return written, nil
+}
+
+// writeNoteFile writes n to a file in dir that does not exist yet
+func writeNoteFile(dir string, n note.Note) error {
+	base := fmt.Sprintf("%d-%s", n.ID, slugify(n.Title))
+	for i := 1; ; i++ {
+		name := base + ".md"
+		if i > 1 {
+			name = fmt.Sprintf("%s-%d.md", base, i)
+		}
+
+		// O_EXCL makes taking the name atomic with checking it
+		f, err := os.OpenFile(filepath.Join(dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, err := f.Write(noteMarkdown(n)); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	}
+}
+
+// noteMarkdown renders n as front matter followed by its content. Strings are
+// written as JSON, which YAML reads as double-quoted scalars, so titles and
+// tags need no YAML escaping of their own.
+func noteMarkdown(n note.Note) []byte {
+	tags := n.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	title, _ := json.Marshal(n.Title)
+	tagList, _ := json.Marshal(tags)
+
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "id: %d\n", n.ID)
+	fmt.Fprintf(&b, "title: %s\n", title)
+	fmt.Fprintf(&b, "type: %s\n", n.Type)
+	fmt.Fprintf(&b, "tags: %s\n", tagList)
+	fmt.Fprintf(&b, "created_at: %s\n", n.CreatedAt.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "updated_at: %s\n", n.UpdatedAt.UTC().Format(time.RFC3339))
+	b.WriteString("---\n\n")
+	b.WriteString(n.Content)
+	if !strings.HasSuffix(n.Content, "\n") {
+		b.WriteString("\n")
+	}
+	return []byte(b.String())
+}
+
+// slugify turns a title into a file name part: lowercase letters and digits
+// joined by single hyphens. Path separators, dots and anything else that is
+// not a letter or digit become hyphens, so the result can never leave the
+// export directory. Titles with nothing usable become "untitled".
+func slugify(title string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(title) {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			hyphen = true
+			continue
+		}
+		if hyphen && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		hyphen = false
+		b.WriteRune(r)
+	}
+
+	slug := []rune(b.String())
+	if len(slug) > maxSlugLength {
+		slug = slug[:maxSlugLength]
+	}
+	if s := strings.TrimSuffix(string(slug), "-"); s != "" {
+		return s
+	}
+	return "untitled"
+}
@@ -0,0 +1,268 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		want  string
+	}{
+		{name: "words", title: "Meeting Notes", want: "meeting-notes"},
+		{name: "punctuation collapses", title: "  Go: tips & tricks!! ", want: "go-tips-tricks"},
+		{name: "path separators", title: "../../etc/passwd", want: "etc-passwd"},
+		{name: "windows separators and dots", title: `C:\Users\..\note.md`, want: "c-users-note-md"},
+		{name: "unicode letters kept", title: "Заметка über Café", want: "заметка-über-café"},
+		{name: "digits kept", title: "Q3 2024 review", want: "q3-2024-review"},
+		{name: "nothing usable", title: "?!/...", want: "untitled"},
+		{name: "empty", title: "", want: "untitled"},
+		{name: "long title is cut without a trailing hyphen", title: strings.Repeat("a", 79) + " b", want: strings.Repeat("a", 79)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, slugify(tt.title))
+		})
+	}
+}
+
+func TestNoteMarkdown(t *testing.T) {
+	created := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	updated := time.Date(2024, 3, 2, 18, 0, 5, 0, time.FixedZone("CET", 3600))
+
+	tests := []struct {
+		name string
+		note note.Note
+		want string
+	}{
+		{
+			name: "front matter and content",
+			note: note.Note{ID: 7, Title: "Meeting Notes", Type: "markdown", Tags: []string{"work", "q3"}, Content: "# Agenda\n- one", CreatedAt: created, UpdatedAt: updated},
+			want: "---\nid: 7\ntitle: \"Meeting Notes\"\ntype: markdown\ntags: [\"work\",\"q3\"]\n" +
+				"created_at: 2024-03-01T09:30:00Z\nupdated_at: 2024-03-02T17:00:05Z\n---\n\n# Agenda\n- one\n",
+		},
+		{
+			name: "quotes in the title and no tags",
+			note: note.Note{ID: 8, Title: `Say "hi": a: b`, Type: "text", Content: "hello\n", CreatedAt: created, UpdatedAt: created},
+			want: "---\nid: 8\ntitle: \"Say \\\"hi\\\": a: b\"\ntype: text\ntags: []\n" +
+				"created_at: 2024-03-01T09:30:00Z\nupdated_at: 2024-03-01T09:30:00Z\n---\n\nhello\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, string(noteMarkdown(tt.note)))
+		})
+	}
+}
+
+func TestStorage_ExportNotesToDir(t *testing.T) {
+	ctx := context.Background()
+	storage := newTestStorage(t, WithFilesRoot(os.TempDir()))
+
+	var ids []int64
+	for _, req := range []note.CreateNoteRequest{
+		{Title: "Go Tips", Content: "Use gofmt", Type: "markdown", Tags: []string{"go"}},
+		{Title: "Rust Tips", Content: "Use clippy", Type: "markdown", Tags: []string{"rust"}},
+		{Title: "Shopping", Content: "Milk", Type: "text"},
+	} {
+		n, err := storage.Create(ctx, req)
+		require.NoError(t, err)
+		ids = append(ids, n.ID)
+	}
+
+	tests := []struct {
+		name      string
+		req       note.ListNotesRequest
+		existing  []string
+		wantCount int
+		wantFiles []string
+	}{
+		{
+			name:      "all notes",
+			wantCount: 3,
+			wantFiles: []string{fileName(ids[0], "go-tips"), fileName(ids[1], "rust-tips"), fileName(ids[2], "shopping")},
+		},
+		{
+			name:      "filtered by type",
+			req:       note.ListNotesRequest{Type: "markdown"},
+			wantCount: 2,
+			wantFiles: []string{fileName(ids[0], "go-tips"), fileName(ids[1], "rust-tips")},
+		},
+		{
+			name:      "limit",
+			req:       note.ListNotesRequest{Tags: []string{"go"}, Limit: 1},
+			wantCount: 1,
+			wantFiles: []string{fileName(ids[0], "go-tips")},
+		},
+		{
+			name:      "existing files are numbered around",
+			req:       note.ListNotesRequest{Tags: []string{"go"}},
+			existing:  []string{fileName(ids[0], "go-tips"), fileName(ids[0], "go-tips-2")},
+			wantCount: 1,
+			wantFiles: []string{fileName(ids[0], "go-tips"), fileName(ids[0], "go-tips-2"), fileName(ids[0], "go-tips-3")},
+		},
+		{
+			name: "no matches",
+			req:  note.ListNotesRequest{Type: "code"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "vault")
+			require.NoError(t, os.MkdirAll(dir, 0o755))
+			for _, name := range tt.existing {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("keep me"), 0o644))
+			}
+
+			count, err := storage.ExportNotesToDir(ctx, dir, tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCount, count)
+
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			var files []string
+			for _, entry := range entries {
+				files = append(files, entry.Name())
+			}
+			sort.Strings(tt.wantFiles)
+			assert.Equal(t, tt.wantFiles, files)
+
+			for _, name := range tt.existing {
+				data, err := os.ReadFile(filepath.Join(dir, name))
+				require.NoError(t, err)
+				assert.Equal(t, "keep me", string(data))
+			}
+		})
+	}
+
+	t.Run("creates the directory and writes content", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "nested", "vault")
+
+		count, err := storage.ExportNotesToDir(ctx, dir, note.ListNotesRequest{Type: "text"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		data, err := os.ReadFile(filepath.Join(dir, fileName(ids[2], "shopping")))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "title: \"Shopping\"\ntype: text\ntags: []\n")
+		assert.True(t, strings.HasSuffix(string(data), "---\n\nMilk\n"))
+	})
+
+	t.Run("directory is required", func(t *testing.T) {
+		_, err := storage.ExportNotesToDir(ctx, " ", note.ListNotesRequest{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "directory is required")
+	})
+}
+
+func TestStorage_ExportNotesToDirFilesRoot(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		noRoot    bool
+		dir       func(root string) string
+		wantDir   string // Directory the note is written to, relative to the root
+		wantErrIs error
+		wantErr   string
+	}{
+		{
+			name:    "relative directory",
+			dir:     func(string) string { return "vault" },
+			wantDir: "vault",
+		},
+		{
+			name:    "absolute directory inside the root",
+			dir:     func(root string) string { return filepath.Join(root, "nested", "vault") },
+			wantDir: "nested/vault",
+		},
+		{
+			name:    "the root itself",
+			dir:     func(root string) string { return root },
+			wantDir: ".",
+		},
+		{
+			name:    "name starting with dots stays inside",
+			dir:     func(string) string { return "..vault" },
+			wantDir: "..vault",
+		},
+		{
+			name:      "parent directory",
+			dir:       func(string) string { return "../vault" },
+			wantErrIs: note.ErrOutsideFilesRoot,
+		},
+		{
+			name:      "absolute directory outside the root",
+			dir:       func(root string) string { return filepath.Join(filepath.Dir(root), "outside", "vault") },
+			wantErrIs: note.ErrOutsideFilesRoot,
+		},
+		{
+			name:      "symlink leading outside the root",
+			dir:       func(string) string { return "escape/vault" },
+			wantErrIs: note.ErrOutsideFilesRoot,
+		},
+		{
+			name:    "no files root",
+			noRoot:  true,
+			dir:     func(root string) string { return filepath.Join(root, "vault") },
+			wantErr: "no files root is configured",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := t.TempDir()
+			root := filepath.Join(base, "root")
+			outside := filepath.Join(base, "outside")
+			require.NoError(t, os.MkdirAll(root, 0o755))
+			require.NoError(t, os.MkdirAll(outside, 0o755))
+			require.NoError(t, os.Symlink(outside, filepath.Join(root, "escape")))
+
+			var opts []Option
+			if !tt.noRoot {
+				opts = append(opts, WithFilesRoot(root))
+			}
+			storage := newTestStorage(t, opts...)
+			n, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Go Tips", Content: "Use gofmt", Type: "markdown"})
+			require.NoError(t, err)
+
+			count, err := storage.ExportNotesToDir(ctx, tt.dir(root), note.ListNotesRequest{})
+
+			if tt.wantErrIs != nil || tt.wantErr != "" {
+				require.Error(t, err)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
+				assert.Contains(t, err.Error(), tt.wantErr)
+				entries, err := os.ReadDir(outside)
+				require.NoError(t, err)
+				assert.Empty(t, entries, "nothing may be written outside the root")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 1, count)
+			assert.FileExists(t, filepath.Join(root, filepath.FromSlash(tt.wantDir), fileName(n.ID, "go-tips")))
+		})
+	}
+}
+
+// fileName is the export file name for a note ID and slug
+func fileName(id int64, slug string) string {
+	return fmt.Sprintf("%d-%s.md", id, slug)
+}
@@ -0,0 +1,74 @@
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// resolveDir returns the resolved files root and dir as an absolute path with
+// symlinks resolved, taking a relative dir relative to the root. Parts of dir
+// that do not exist yet are kept as given, so ExportNotesToDir can create
+// them. It fails with note.ErrOutsideFilesRoot when dir leaves the root.
+func (s *Storage) resolveDir(dir string) (string, string, error) {
+	if s.filesRoot == "" {
+		return "", "", fmt.Errorf("directory export and import are disabled: no files root is configured")
+	}
+
+	root, err := filepath.Abs(s.filesRoot)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to resolve files root: %w", err)
+	}
+	root, err = filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to resolve files root: %w", err)
+	}
+
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(root, dir)
+	}
+	resolved, err := resolveExisting(filepath.Clean(dir))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to resolve directory: %w", err)
+	}
+	if !withinRoot(root, resolved) {
+		return "", "", fmt.Errorf("%w: %s", note.ErrOutsideFilesRoot, dir)
+	}
+	return root, resolved, nil
+}
+
+// resolveExisting resolves the symlinks in the longest existing prefix of
+// path and appends the rest unchanged
+func resolveExisting(path string) (string, error) {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err == nil {
+		return resolved, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+
+	parent := filepath.Dir(path)
+	if parent == path {
+		return path, nil
+	}
+	resolvedParent, err := resolveExisting(parent)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(resolvedParent, filepath.Base(path)), nil
+}
+
+// withinRoot reports whether path is root or inside it. Both must be clean
+// absolute paths.
+func withinRoot(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
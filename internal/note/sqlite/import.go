@@ -0,0 +1,298 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// maxImportFiles caps the number of markdown files in one directory import
+const maxImportFiles = 10000
+
+// maxImportFileBytes caps the size of one imported markdown file, matching
+// the default cap on a tool call's arguments that create_note is held to
+const maxImportFileBytes = 1 << 20
+
+// markdownFile is a markdown file parsed into a create request
+type markdownFile struct {
+	path string
+	req  note.CreateNoteRequest
+}
+
+// ImportNotesFromDir creates a note from every .md file under dir, including
+// subdirectories but skipping hidden ones such as .obsidian and .git. YAML
+// front matter may set the title, tags and type; other keys, including the
+// id and timestamps written by ExportNotesToDir, are ignored. Without a title
+// the file name is used, and without a type the note is markdown.
+//
+// dir must be inside the files root set with WithFilesRoot. Files that are
+// symlinks leading outside the root, are not regular files or are larger
+// than 1 MiB fail like unreadable files.
+//
+// Without strict, files that cannot be read, parsed or stored are skipped and
+// reported in the result, and the rest are created. With strict, the first
+// failing file fails the whole import and no notes are created.
+func (s *Storage) ImportNotesFromDir(ctx context.Context, dir string, strict bool) (*note.ImportResult, error) {
+	if strings.TrimSpace(dir) == "" {
+		return nil, fmt.Errorf("directory is required")
+	}
+	root, dir, err := s.resolveDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files, result, err := readMarkdownDir(root, dir, strict)
+	if err != nil {
+		return nil, err
+	}
+
+	// Files are read once; only the inserts are repeated on a busy database
+	var imported *note.ImportResult
+	err = s.withRetry(ctx, func() error {
+		var err error
+		imported, err = s.importNotes(ctx, files, strict, result.Errors)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("imported notes from directory", "dir", dir, "created", imported.Created, "failed", len(imported.Errors))
+
+	return imported, nil
+}
+
+// importNotes makes a single attempt at creating the parsed files in one
+// transaction. fileErrors holds the files that failed to parse.
+func (s *Storage) importNotes(ctx context.Context, files []markdownFile, strict bool, fileErrors []note.ImportFileError) (*note.ImportResult, error) {
+	result := &note.ImportResult{CreatedIDs: []int64{}, Errors: slices.Clone(fileErrors)}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// A failed insert only aborts its own statement, so later files still apply
+	for _, file := range files {
+		id, err := s.insertNote(ctx, tx, file.req)
+		if err != nil {
+			if strict || ctx.Err() != nil {
+				return nil, fmt.Errorf("failed to import %s: %w", file.path, err)
+			}
+			result.Errors = append(result.Errors, note.ImportFileError{File: file.path, Error: err.Error()})
+			continue
+		}
+		result.CreatedIDs = append(result.CreatedIDs, id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	result.Created = int64(len(result.CreatedIDs))
+
+	// Parse errors were collected first, so report everything in path order
+	slices.SortStableFunc(result.Errors, func(a, b note.ImportFileError) int { return strings.Compare(a.File, b.File) })
+
+	return result, nil
+}
+
+// readMarkdownDir parses the markdown files under dir, which is inside root,
+// in path order. Files that fail are returned in the result's errors, or fail
+// the call when strict.
+func readMarkdownDir(root, dir string, strict bool) ([]markdownFile, *note.ImportResult, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open import directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, nil, fmt.Errorf("import path is not a directory: %s", dir)
+	}
+
+	result := &note.ImportResult{Errors: []note.ImportFileError{}}
+	var files []markdownFile
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(path), ".md") {
+			return nil
+		}
+		if len(files)+len(result.Errors) >= maxImportFiles {
+			return fmt.Errorf("directory has more than %d markdown files", maxImportFiles)
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		req, err := readMarkdownNote(root, path)
+		if err != nil {
+			if strict {
+				return fmt.Errorf("failed to import %s: %w", rel, err)
+			}
+			result.Errors = append(result.Errors, note.ImportFileError{File: rel, Error: err.Error()})
+			return nil
+		}
+		files = append(files, markdownFile{path: rel, req: req})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return files, result, nil
+}
+
+// readMarkdownNote reads a markdown file into a create request. WalkDir does
+// not follow symlinks, so only the file itself can lead outside root.
+func readMarkdownNote(root, path string) (note.CreateNoteRequest, error) {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return note.CreateNoteRequest{}, err
+	}
+	if !withinRoot(root, resolved) {
+		return note.CreateNoteRequest{}, fmt.Errorf("%w: the file is a symlink", note.ErrOutsideFilesRoot)
+	}
+
+	// Checking first keeps a FIFO from blocking the open and a huge file from being read
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return note.CreateNoteRequest{}, err
+	}
+	if !info.Mode().IsRegular() {
+		return note.CreateNoteRequest{}, fmt.Errorf("not a regular file")
+	}
+	if info.Size() > maxImportFileBytes {
+		return note.CreateNoteRequest{}, fmt.Errorf("file is larger than %d bytes", maxImportFileBytes)
+	}
+
+	f, err := os.Open(resolved)
+	if err != nil {
+		return note.CreateNoteRequest{}, err
+	}
+	defer f.Close()
+
+	// The file may have grown since the check
+	data, err := io.ReadAll(io.LimitReader(f, maxImportFileBytes+1))
+	if err != nil {
+		return note.CreateNoteRequest{}, err
+	}
+	if len(data) > maxImportFileBytes {
+		return note.CreateNoteRequest{}, fmt.Errorf("file is larger than %d bytes", maxImportFileBytes)
+	}
+
+	title := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return parseMarkdownNote(title, string(data))
+}
+
+// parseMarkdownNote turns markdown text with optional front matter into a
+// create request, using defaultTitle when the front matter has no title
+func parseMarkdownNote(defaultTitle, text string) (note.CreateNoteRequest, error) {
+	text = strings.TrimPrefix(text, "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	frontMatter, body, err := splitFrontMatter(text)
+	if err != nil {
+		return note.CreateNoteRequest{}, err
+	}
+
+	req := note.CreateNoteRequest{
+		Title:   defaultTitle,
+		Type:    string(note.NoteTypeMarkdown),
+		Content: strings.TrimSuffix(strings.TrimPrefix(body, "\n"), "\n"),
+	}
+
+	var fields map[string]interface{}
+	if err := yaml.Unmarshal([]byte(frontMatter), &fields); err != nil {
+		return note.CreateNoteRequest{}, fmt.Errorf("invalid front matter: %w", err)
+	}
+
+	if title, ok := fields["title"]; ok && title != nil {
+		if t := strings.TrimSpace(fmt.Sprint(title)); t != "" {
+			req.Title = t
+		}
+	}
+
+	if noteType, ok := fields["type"]; ok && noteType != nil {
+		req.Type = strings.ToLower(strings.TrimSpace(fmt.Sprint(noteType)))
+		if !note.IsValidNoteType(req.Type) {
+			return note.CreateNoteRequest{}, fmt.Errorf("invalid note type: %s. Valid types are: %v", req.Type, note.ValidNoteTypes())
+		}
+	}
+
+	req.Tags = frontMatterTags(fields["tags"])
+
+	return req, nil
+}
+
+// splitFrontMatter separates a leading block fenced by --- lines from the
+// rest of text. Text without a block has empty front matter.
+func splitFrontMatter(text string) (string, string, error) {
+	if !strings.HasPrefix(text, "---\n") {
+		return "", text, nil
+	}
+
+	rest := text[len("---\n"):]
+	for offset := 0; offset <= len(rest); {
+		line, _, found := strings.Cut(rest[offset:], "\n")
+		if strings.TrimRight(line, " \t") == "---" {
+			end := offset + len(line)
+			if found {
+				end++
+			}
+			return rest[:offset], rest[end:], nil
+		}
+		if !found {
+			break
+		}
+		offset += len(line) + 1
+	}
+
+	return "", "", fmt.Errorf("front matter is not closed with ---")
+}
+
+// frontMatterTags reads tags given as a YAML list or as one string separated
+// by commas or spaces, dropping the # that Obsidian allows in front of a tag
+func frontMatterTags(value interface{}) []string {
+	var raw []string
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if item != nil {
+				raw = append(raw, fmt.Sprint(item))
+			}
+		}
+	case string:
+		raw = strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == ' ' })
+	case nil:
+		return nil
+	default:
+		raw = []string{fmt.Sprint(v)}
+	}
+
+	var tags []string
+	for _, tag := range raw {
+		if tag = strings.TrimPrefix(strings.TrimSpace(tag), "#"); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,327 @@
+package sqlite
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+func TestParseMarkdownNote(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    note.CreateNoteRequest
+		wantErr string
+	}{
+		{
+			name: "no front matter uses the file name",
+			text: "# Heading\n\nBody\n",
+			want: note.CreateNoteRequest{Title: "file name", Type: "markdown", Content: "# Heading\n\nBody"},
+		},
+		{
+			name: "front matter with a tag list",
+			text: "---\ntitle: Go Tips\ntype: text\ntags: [go, \"#tips\"]\n---\n\nUse gofmt\n",
+			want: note.CreateNoteRequest{Title: "Go Tips", Type: "text", Tags: []string{"go", "tips"}, Content: "Use gofmt"},
+		},
+		{
+			name: "block list tags and extra keys",
+			text: "---\naliases: [tips]\ntags:\n  - go\n  - 2024\ncreated_at: 2024-03-01T09:30:00Z\n---\nBody",
+			want: note.CreateNoteRequest{Title: "file name", Type: "markdown", Tags: []string{"go", "2024"}, Content: "Body"},
+		},
+		{
+			name: "tags as one string",
+			text: "---\ntags: \"#go, tips web\"\n---\nBody",
+			want: note.CreateNoteRequest{Title: "file name", Type: "markdown", Tags: []string{"go", "tips", "web"}, Content: "Body"},
+		},
+		{
+			name: "quoted title as written by the export",
+			text: "---\nid: 8\ntitle: \"Say \\\"hi\\\": a: b\"\ntype: Markdown\ntags: []\n---\n\nhello\n",
+			want: note.CreateNoteRequest{Title: `Say "hi": a: b`, Type: "markdown", Content: "hello"},
+		},
+		{
+			name: "empty front matter and windows line endings",
+			text: "\ufeff---\r\n---\r\nline one\r\nline two\r\n",
+			want: note.CreateNoteRequest{Title: "file name", Type: "markdown", Content: "line one\nline two"},
+		},
+		{
+			name: "blank title falls back to the file name",
+			text: "---\ntitle: \"  \"\n---\nBody",
+			want: note.CreateNoteRequest{Title: "file name", Type: "markdown", Content: "Body"},
+		},
+		{
+			name: "horizontal rule later in the body is kept",
+			text: "Intro\n---\nMore",
+			want: note.CreateNoteRequest{Title: "file name", Type: "markdown", Content: "Intro\n---\nMore"},
+		},
+		{
+			name:    "unclosed front matter",
+			text:    "---\ntitle: Oops\nBody",
+			wantErr: "front matter is not closed",
+		},
+		{
+			name:    "front matter that is not a mapping",
+			text:    "---\n- a\n- b\n---\nBody",
+			wantErr: "invalid front matter",
+		},
+		{
+			name:    "unknown type",
+			text:    "---\ntype: video\n---\nBody",
+			wantErr: "invalid note type: video",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMarkdownNote("file name", tt.text)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStorage_ImportNotesFromDir(t *testing.T) {
+	ctx := context.Background()
+
+	vault := map[string]string{
+		"Inbox.md":              "Plain note",
+		"projects/Go Tips.md":   "---\ntitle: Go tips\ntags: [go]\n---\nUse gofmt",
+		"projects/broken.md":    "---\ntype: video\n---\nBody",
+		"projects/readme.txt":   "not markdown",
+		".obsidian/template.md": "hidden",
+		".draft.md":             "hidden",
+	}
+
+	tests := []struct {
+		name       string
+		files      map[string]string
+		strict     bool
+		wantTitles []string
+		wantErrors []note.ImportFileError
+		wantErr    string
+	}{
+		{
+			name:       "bad files are reported and the rest imported",
+			files:      vault,
+			wantTitles: []string{"Inbox", "Go tips"},
+			wantErrors: []note.ImportFileError{{File: "projects/broken.md", Error: "invalid note type: video. Valid types are: [text markdown code link image]"}},
+		},
+		{
+			name:    "strict fails on the first bad file",
+			files:   vault,
+			strict:  true,
+			wantErr: "failed to import projects/broken.md: invalid note type: video",
+		},
+		{
+			name:       "strict with only good files",
+			files:      map[string]string{"a.md": "A", "b.MD": "---\ntitle: Bee\n---\nB"},
+			strict:     true,
+			wantTitles: []string{"a", "Bee"},
+			wantErrors: []note.ImportFileError{},
+		},
+		{
+			name:       "empty directory",
+			files:      map[string]string{},
+			wantTitles: []string{},
+			wantErrors: []note.ImportFileError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newTestStorage(t, WithFilesRoot(os.TempDir()))
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(dir, filepath.FromSlash(name))
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+			}
+
+			result, err := storage.ImportNotesFromDir(ctx, dir, tt.strict)
+
+			listed, listErr := storage.List(ctx, note.ListNotesRequest{Limit: 100, OrderBy: "id", OrderDir: "asc"})
+			require.NoError(t, listErr)
+			titles := []string{}
+			for _, n := range listed.Items {
+				titles = append(titles, n.Title)
+			}
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Empty(t, titles, "strict import must not create notes")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTitles, titles)
+			assert.Equal(t, int64(len(tt.wantTitles)), result.Created)
+			assert.Len(t, result.CreatedIDs, len(tt.wantTitles))
+			assert.Equal(t, tt.wantErrors, result.Errors)
+		})
+	}
+
+	t.Run("round trip through the markdown export", func(t *testing.T) {
+		source := newTestStorage(t, WithFilesRoot(os.TempDir()))
+		_, err := source.Create(ctx, note.CreateNoteRequest{Title: `Say "hi"`, Content: "line one\n---\nline two", Type: "code", Tags: []string{"go", "tips"}})
+		require.NoError(t, err)
+
+		dir := t.TempDir()
+		_, err = source.ExportNotesToDir(ctx, dir, note.ListNotesRequest{})
+		require.NoError(t, err)
+
+		target := newTestStorage(t, WithFilesRoot(os.TempDir()))
+		result, err := target.ImportNotesFromDir(ctx, dir, true)
+		require.NoError(t, err)
+		require.Len(t, result.CreatedIDs, 1)
+
+		imported, err := target.Get(ctx, result.CreatedIDs[0])
+		require.NoError(t, err)
+		assert.Equal(t, `Say "hi"`, imported.Title)
+		assert.Equal(t, "code", imported.Type)
+		assert.Equal(t, []string{"go", "tips"}, imported.Tags)
+		assert.Equal(t, "line one\n---\nline two", imported.Content)
+	})
+
+	t.Run("invalid directory", func(t *testing.T) {
+		storage := newTestStorage(t, WithFilesRoot(os.TempDir()))
+		file := filepath.Join(t.TempDir(), "note.md")
+		require.NoError(t, os.WriteFile(file, []byte("x"), 0o644))
+
+		for dir, wantErr := range map[string]string{
+			"":                                 "directory is required",
+			filepath.Join(t.TempDir(), "none"): "failed to open import directory",
+			file:                               "import path is not a directory",
+		} {
+			_, err := storage.ImportNotesFromDir(ctx, dir, false)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), wantErr)
+		}
+	})
+}
+
+func TestStorage_ImportNotesFromDirFilesRoot(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		noRoot     bool
+		dir        string // Directory to import, relative to the root
+		setup      func(t *testing.T, root, outside string)
+		wantTitles []string
+		wantErrors []note.ImportFileError
+		wantErrIs  error
+		wantErr    string
+	}{
+		{
+			name:       "relative directory",
+			dir:        "vault",
+			wantTitles: []string{"Inbox"},
+			wantErrors: []note.ImportFileError{},
+		},
+		{
+			name:      "parent directory",
+			dir:       "../outside",
+			wantErrIs: note.ErrOutsideFilesRoot,
+		},
+		{
+			name: "symlinked directory outside the root",
+			dir:  "escape",
+			setup: func(t *testing.T, root, outside string) {
+				require.NoError(t, os.Symlink(outside, filepath.Join(root, "escape")))
+			},
+			wantErrIs: note.ErrOutsideFilesRoot,
+		},
+		{
+			name: "symlinked file outside the root is skipped",
+			dir:  "vault",
+			setup: func(t *testing.T, root, outside string) {
+				require.NoError(t, os.Symlink(filepath.Join(outside, "secret.md"), filepath.Join(root, "vault", "secret.md")))
+			},
+			wantTitles: []string{"Inbox"},
+			wantErrors: []note.ImportFileError{{File: "secret.md", Error: "path is outside the files root: the file is a symlink"}},
+		},
+		{
+			name: "symlinked file inside the root is imported",
+			dir:  "vault",
+			setup: func(t *testing.T, root, outside string) {
+				require.NoError(t, os.WriteFile(filepath.Join(root, "shared.md"), []byte("Shared"), 0o644))
+				require.NoError(t, os.Symlink(filepath.Join(root, "shared.md"), filepath.Join(root, "vault", "linked.md")))
+			},
+			wantTitles: []string{"Inbox", "linked"},
+			wantErrors: []note.ImportFileError{},
+		},
+		{
+			name: "file over the size cap is skipped",
+			dir:  "vault",
+			setup: func(t *testing.T, root, outside string) {
+				big := strings.Repeat("x", maxImportFileBytes+1)
+				require.NoError(t, os.WriteFile(filepath.Join(root, "vault", "big.md"), []byte(big), 0o644))
+			},
+			wantTitles: []string{"Inbox"},
+			wantErrors: []note.ImportFileError{{File: "big.md", Error: "file is larger than 1048576 bytes"}},
+		},
+		{
+			name:    "no files root",
+			noRoot:  true,
+			dir:     "vault",
+			wantErr: "no files root is configured",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := t.TempDir()
+			root := filepath.Join(base, "root")
+			outside := filepath.Join(base, "outside")
+			require.NoError(t, os.MkdirAll(filepath.Join(root, "vault"), 0o755))
+			require.NoError(t, os.MkdirAll(outside, 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(root, "vault", "Inbox.md"), []byte("Plain note"), 0o644))
+			require.NoError(t, os.WriteFile(filepath.Join(outside, "secret.md"), []byte("Secret"), 0o644))
+			if tt.setup != nil {
+				tt.setup(t, root, outside)
+			}
+
+			var opts []Option
+			if !tt.noRoot {
+				opts = append(opts, WithFilesRoot(root))
+			}
+			storage := newTestStorage(t, opts...)
+
+			dir := tt.dir
+			if tt.noRoot {
+				dir = filepath.Join(root, dir)
+			}
+			result, err := storage.ImportNotesFromDir(ctx, dir, false)
+
+			if tt.wantErrIs != nil || tt.wantErr != "" {
+				require.Error(t, err)
+				if tt.wantErrIs != nil {
+					assert.ErrorIs(t, err, tt.wantErrIs)
+				}
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantErrors, result.Errors)
+
+			titles := []string{}
+			for _, id := range result.CreatedIDs {
+				n, err := storage.Get(ctx, id)
+				require.NoError(t, err)
+				titles = append(titles, n.Title)
+			}
+			assert.Equal(t, tt.wantTitles, titles)
+		})
+	}
+}
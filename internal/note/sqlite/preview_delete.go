@@ -0,0 +1,61 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// PreviewDelete reports what deleting a note would cascade to: how many of
+// its connections would go and which notes are on the other end of them.
+// Nothing is deleted. Neighbors are ordered by the number of connections
+// they would lose, most first.
+func (s *Storage) PreviewDelete(ctx context.Context, noteID int64) (*note.DeletePreview, error) {
+	var exists bool
+	err := s.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM notes WHERE id = ?)", noteID).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check note: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: %d", note.ErrNotFound, noteID)
+	}
+
+	preview := &note.DeletePreview{NoteID: noteID, Neighbors: []note.AffectedNeighbor{}}
+
+	err = s.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM connections WHERE from_note_id = ? OR to_note_id = ?", noteID, noteID,
+	).Scan(&preview.Connections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count connections: %w", err)
+	}
+
+	query := `
+		SELECT n.id, n.title, COUNT(*) AS connections
+		FROM connections c
+		JOIN notes n ON n.id = CASE WHEN c.from_note_id = ? THEN c.to_note_id ELSE c.from_note_id END
+		WHERE (c.from_note_id = ? OR c.to_note_id = ?) AND n.id != ?
+		GROUP BY n.id, n.title
+		ORDER BY connections DESC, n.id ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, noteID, noteID, noteID, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list affected neighbors: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var neighbor note.AffectedNeighbor
+		if err := rows.Scan(&neighbor.ID, &neighbor.Title, &neighbor.Connections); err != nil {
+			return nil, fmt.Errorf("failed to scan neighbor: %w", err)
+		}
+		preview.Neighbors = append(preview.Neighbors, neighbor)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return preview, nil
+}
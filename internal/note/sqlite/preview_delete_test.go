@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+func TestStorage_PreviewDelete(t *testing.T) {
+	ctx := context.Background()
+	storage := newTestStorage(t)
+
+	ids := make(map[string]int64)
+	for _, title := range []string{"Hub", "A", "B", "Loner"} {
+		n, err := storage.Create(ctx, note.CreateNoteRequest{Title: title, Content: "Content", Type: "text"})
+		require.NoError(t, err)
+		ids[title] = n.ID
+	}
+
+	_, err := storage.db.Exec(`
+		INSERT INTO connections (from_note_id, to_note_id, type, strength) VALUES
+			(?, ?, 'relates_to', 5),
+			(?, ?, 'supports', 5),
+			(?, ?, 'references', 5),
+			(?, ?, 'relates_to', 5)`,
+		ids["Hub"], ids["A"],
+		ids["B"], ids["Hub"],
+		ids["A"], ids["Hub"],
+		ids["Hub"], ids["Hub"])
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		noteID          int64
+		wantConnections int64
+		wantNeighbors   []note.AffectedNeighbor
+		wantNotFound    bool
+	}{
+		{
+			name:            "neighbors in both directions, ordered by connections",
+			noteID:          ids["Hub"],
+			wantConnections: 4,
+			wantNeighbors: []note.AffectedNeighbor{
+				{ID: ids["A"], Title: "A", Connections: 2},
+				{ID: ids["B"], Title: "B", Connections: 1},
+			},
+		},
+		{
+			name:            "leaf note",
+			noteID:          ids["B"],
+			wantConnections: 1,
+			wantNeighbors: []note.AffectedNeighbor{
+				{ID: ids["Hub"], Title: "Hub", Connections: 1},
+			},
+		},
+		{
+			name:            "note without connections",
+			noteID:          ids["Loner"],
+			wantConnections: 0,
+			wantNeighbors:   []note.AffectedNeighbor{},
+		},
+		{
+			name:         "missing note",
+			noteID:       99999,
+			wantNotFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preview, err := storage.PreviewDelete(ctx, tt.noteID)
+			if tt.wantNotFound {
+				assert.True(t, errors.Is(err, note.ErrNotFound))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.noteID, preview.NoteID)
+			assert.Equal(t, tt.wantConnections, preview.Connections)
+			assert.Equal(t, tt.wantNeighbors, preview.Neighbors)
+		})
+	}
+
+	// Previewing deletes nothing
+	var connections int64
+	require.NoError(t, storage.db.QueryRow("SELECT COUNT(*) FROM connections").Scan(&connections))
+	assert.Equal(t, int64(4), connections)
+}
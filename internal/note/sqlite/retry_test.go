@@ -0,0 +1,69 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/migrations"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/sqlitedb"
+)
+
+func TestStorage_RetryBusyWrites(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		retries  int
+		holdLock time.Duration
+		wantBusy bool
+	}{
+		{name: "lock released while retrying", retries: 20, holdLock: 50 * time.Millisecond},
+		{name: "retries disabled", retries: 0, holdLock: 50 * time.Millisecond, wantBusy: true},
+		{name: "lock held longer than the retries", retries: 2, holdLock: time.Second, wantBusy: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "notes.db")
+			require.NoError(t, migrations.NewMigrationRunner(path).RunMigrations())
+
+			// Without a busy timeout SQLite fails right away instead of waiting for the lock
+			storage, err := NewStorage("file:"+path+"?_pragma=busy_timeout(0)",
+				WithRetry(sqlitedb.RetryPolicy{MaxRetries: tt.retries, Delay: 10 * time.Millisecond, MaxDelay: 20 * time.Millisecond}))
+			require.NoError(t, err)
+			defer storage.Close()
+
+			holder, err := sqlitedb.Open(path)
+			require.NoError(t, err)
+			defer holder.Close()
+			lock, err := holder.Conn(ctx)
+			require.NoError(t, err)
+			defer lock.Close()
+			_, err = lock.ExecContext(ctx, "BEGIN IMMEDIATE")
+			require.NoError(t, err)
+
+			released := make(chan struct{})
+			go func() {
+				defer close(released)
+				time.Sleep(tt.holdLock)
+				_, _ = lock.ExecContext(ctx, "ROLLBACK")
+			}()
+			defer func() { <-released }()
+
+			n, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Busy", Content: "content", Type: "text"})
+			if tt.wantBusy {
+				require.Error(t, err)
+				assert.True(t, sqlitedb.IsBusy(err), "got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "Busy", n.Title)
+		})
+	}
+}
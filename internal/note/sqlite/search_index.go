@@ -0,0 +1,58 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// RebuildSearchIndex rebuilds notes_fts from the notes table and verifies that
+// every note is indexed afterwards. Indexed documents are counted through the
+// notes_fts_docsize shadow table, since counting an external content FTS table
+// reads the content table instead of the index.
+func (s *Storage) RebuildSearchIndex(ctx context.Context) (*note.SearchIndexStats, error) {
+	var result *note.SearchIndexStats
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.rebuildSearchIndex(ctx)
+		return err
+	})
+	return result, err
+}
+
+// rebuildSearchIndex makes a single attempt at RebuildSearchIndex
+func (s *Storage) rebuildSearchIndex(ctx context.Context) (*note.SearchIndexStats, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var stats note.SearchIndexStats
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM notes_fts_docsize").Scan(&stats.IndexedBefore); err != nil {
+		return nil, fmt.Errorf("failed to count indexed notes: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "INSERT INTO notes_fts(notes_fts) VALUES('rebuild')"); err != nil {
+		return nil, fmt.Errorf("failed to rebuild search index: %w", err)
+	}
+
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM notes_fts_docsize").Scan(&stats.IndexedAfter); err != nil {
+		return nil, fmt.Errorf("failed to count indexed notes: %w", err)
+	}
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM notes").Scan(&stats.Notes); err != nil {
+		return nil, fmt.Errorf("failed to count notes: %w", err)
+	}
+
+	if stats.IndexedAfter != stats.Notes {
+		return nil, fmt.Errorf("search index has %d documents after rebuild, expected %d", stats.IndexedAfter, stats.Notes)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Info("rebuilt search index", "notes", stats.Notes, "indexed_before", stats.IndexedBefore, "indexed_after", stats.IndexedAfter)
+	return &stats, nil
+}
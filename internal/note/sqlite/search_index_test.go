@@ -0,0 +1,133 @@
+package sqlite
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/migrations"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+func TestStorage_RebuildSearchIndex(t *testing.T) {
+	tempFile, err := os.CreateTemp("", "test-note-*.db")
+	require.NoError(t, err)
+	tempFile.Close()
+	defer os.Remove(tempFile.Name())
+
+	storage, err := NewStorage(tempFile.Name())
+	require.NoError(t, err)
+	defer storage.Close()
+
+	require.NoError(t, migrations.NewMigrationRunner(tempFile.Name()).RunMigrations())
+
+	ctx := context.Background()
+	lost, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Quantum entanglement", Content: "Spooky action", Type: "text"})
+	require.NoError(t, err)
+	_, err = storage.Create(ctx, note.CreateNoteRequest{Title: "Classical mechanics", Content: "Newton", Type: "text"})
+	require.NoError(t, err)
+
+	search := func() int64 {
+		resp, err := storage.List(ctx, note.ListNotesRequest{Limit: 10, Search: "entanglement"})
+		require.NoError(t, err)
+		return resp.Total
+	}
+	require.Equal(t, int64(1), search())
+
+	// Drop the note from the index behind the storage's back
+	_, err = storage.db.ExecContext(ctx,
+		"INSERT INTO notes_fts(notes_fts, rowid, title, content) VALUES('delete', ?, ?, ?)",
+		lost.ID, lost.Title, lost.Content)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), search())
+
+	stats, err := storage.RebuildSearchIndex(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, note.SearchIndexStats{Notes: 2, IndexedBefore: 1, IndexedAfter: 2}, *stats)
+	assert.Equal(t, int64(1), search())
+
+	// Rebuilding a healthy index is a no-op
+	stats, err = storage.RebuildSearchIndex(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, note.SearchIndexStats{Notes: 2, IndexedBefore: 2, IndexedAfter: 2}, *stats)
+}
+
+// TestStorage_SearchIndexFollowsChanges guards against the notes_fts triggers
+// of migrations 000002 and 000012: the update trigger corrupted the database
+// on any content change, and the delete trigger left the old terms searchable
+func TestStorage_SearchIndexFollowsChanges(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		change  func(t *testing.T, storage *Storage, id int64)
+		oldTerm string
+		newTerm string
+	}{
+		{
+			name: "update content",
+			change: func(t *testing.T, storage *Storage, id int64) {
+				_, err := storage.Update(ctx, id, note.UpdateNoteRequest{Content: strPtr("Bosons")})
+				require.NoError(t, err)
+			},
+			oldTerm: "fermions",
+			newTerm: "bosons",
+		},
+		{
+			name: "update title",
+			change: func(t *testing.T, storage *Storage, id int64) {
+				_, err := storage.Update(ctx, id, note.UpdateNoteRequest{Title: strPtr("Relativity")})
+				require.NoError(t, err)
+			},
+			oldTerm: "quantum",
+			newTerm: "relativity",
+		},
+		{
+			name: "append content",
+			change: func(t *testing.T, storage *Storage, id int64) {
+				_, err := storage.AppendContent(ctx, id, "Leptons", " ")
+				require.NoError(t, err)
+			},
+			newTerm: "leptons",
+		},
+		{
+			name: "delete",
+			change: func(t *testing.T, storage *Storage, id int64) {
+				require.NoError(t, storage.Delete(ctx, id))
+			},
+			oldTerm: "fermions",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newTestStorage(t)
+			n, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Quantum", Content: "Fermions", Type: "text"})
+			require.NoError(t, err)
+
+			search := func(term string) int64 {
+				resp, err := storage.List(ctx, note.ListNotesRequest{Limit: 10, Search: term})
+				require.NoError(t, err)
+				return resp.Total
+			}
+
+			tt.change(t, storage, n.ID)
+
+			if tt.oldTerm != "" {
+				assert.Equal(t, int64(0), search(tt.oldTerm), "old term %q", tt.oldTerm)
+			}
+			if tt.newTerm != "" {
+				assert.Equal(t, int64(1), search(tt.newTerm), "new term %q", tt.newTerm)
+			}
+
+			var integrity string
+			require.NoError(t, storage.db.QueryRowContext(ctx, "PRAGMA integrity_check").Scan(&integrity))
+			assert.Equal(t, "ok", integrity)
+			_, err = storage.db.ExecContext(ctx, "INSERT INTO notes_fts(notes_fts) VALUES ('integrity-check')")
+			assert.NoError(t, err)
+		})
+	}
+}
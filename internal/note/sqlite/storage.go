@@ -4,23 +4,115 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"slices"
 	"strings"
+	"time"
 
-	_ "github.com/ncruces/go-sqlite3/driver"
-	_ "github.com/ncruces/go-sqlite3/embed"
-
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/logging"
 	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/sqlitedb"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/tags"
 )
 
 // Storage implements the note.Storage interface using SQLite
 type Storage struct {
-	db *sql.DB
+	db                *sql.DB
+	logger            *slog.Logger
+	normalizeTags     bool
+	validateURLs      bool
+	uniqueContent     bool
+	retry             sqlitedb.RetryPolicy
+	idempotencyWindow time.Duration
+	filesRoot         string
+}
+
+// Option configures a Storage
+type Option func(*Storage)
+
+// WithLogger sets the logger used for database lifecycle and maintenance events
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Storage) {
+		s.logger = logging.OrDiscard(logger)
+	}
+}
+
+// WithTagNormalization makes Create, Update and List tag filters trim,
+// lowercase and deduplicate tags. Without it tags are stored verbatim.
+func WithTagNormalization(enabled bool) Option {
+	return func(s *Storage) {
+		s.normalizeTags = enabled
+	}
+}
+
+// WithURLValidation makes Create and Update reject link and image notes whose
+// content is not an absolute http or https URL. Without it content is stored
+// as given.
+func WithURLValidation(enabled bool) Option {
+	return func(s *Storage) {
+		s.validateURLs = enabled
+	}
+}
+
+// WithUniqueContent makes Create, Update and ImportNotesFromDir reject a note
+// with ErrDuplicateContent when another note already has the same content up
+// to whitespace. Notes with blank content are never duplicates. Without it
+// duplicates are stored and can be found with FindNotesByContentHash.
+func WithUniqueContent(enabled bool) Option {
+	return func(s *Storage) {
+		s.uniqueContent = enabled
+	}
+}
+
+// normalizedTags applies tag normalization when it is enabled
+func (s *Storage) normalizedTags(t []string) []string {
+	if !s.normalizeTags {
+		return t
+	}
+	return tags.Normalize(t)
+}
+
+// WithRetry sets how writes are retried when another connection holds the
+// database lock. The default is sqlitedb.DefaultRetryPolicy.
+func WithRetry(policy sqlitedb.RetryPolicy) Option {
+	return func(s *Storage) {
+		s.retry = policy
+	}
+}
+
+// WithIdempotencyWindow sets how long Create remembers an idempotency key. A
+// key older than window is forgotten and creates a new note; 0 keeps keys
+// forever. The default is sqlitedb.DefaultIdempotencyWindow.
+func WithIdempotencyWindow(window time.Duration) Option {
+	return func(s *Storage) {
+		s.idempotencyWindow = window
+	}
+}
+
+// WithFilesRoot confines ExportNotesToDir and ImportNotesFromDir to root and
+// the directories below it, so MCP clients cannot read or write files
+// anywhere else the server can. Relative directories are taken relative to
+// root. Without a root both fail.
+func WithFilesRoot(root string) Option {
+	return func(s *Storage) {
+		s.filesRoot = root
+	}
+}
+
+// withRetry runs fn under the retry policy and logs writes that stay busy
+func (s *Storage) withRetry(ctx context.Context, fn func() error) error {
+	err := sqlitedb.Retry(ctx, s.retry, fn)
+	if sqlitedb.IsBusy(err) {
+		s.logger.Warn("database still locked after retries", "storage", "note", "retries", s.retry.MaxRetries, "error", err)
+	}
+	return err
 }
 
 // NewStorage creates a new SQLite storage instance
-func NewStorage(dbPath string) (*Storage, error) {
-	db, err := sql.Open("sqlite3", dbPath)
+func NewStorage(dbPath string, opts ...Option) (*Storage, error) {
+	db, err := sqlitedb.Open(dbPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -29,7 +121,13 @@ func NewStorage(dbPath string) (*Storage, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &Storage{db: db}, nil
+	s := &Storage{db: db, logger: logging.Discard(), retry: sqlitedb.DefaultRetryPolicy(), idempotencyWindow: sqlitedb.DefaultIdempotencyWindow}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.logger.Debug("opened database", "storage", "note", "dsn", dbPath)
+	return s, nil
 }
 
 // Close closes the database connection
@@ -40,15 +138,77 @@ func (s *Storage) Close() error {
 	return nil
 }
 
-// Create creates a new note
+// Create creates a new note. With an idempotency key that an earlier Create
+// used within the idempotency window, the note that call created is returned
+// and req is otherwise ignored.
 func (s *Storage) Create(ctx context.Context, req note.CreateNoteRequest) (*note.Note, error) {
+	var result *note.Note
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.create(ctx, req)
+		return err
+	})
+	return result, err
+}
+
+// create makes a single attempt at Create
+func (s *Storage) create(ctx context.Context, req note.CreateNoteRequest) (*note.Note, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	id, replayed, err := sqlitedb.CreateOnce(ctx, tx, sqlitedb.IdempotencyNotes, req.IdempotencyKey, s.idempotencyWindow, func() (int64, error) {
+		return s.insertNote(ctx, tx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if replayed {
+		s.logger.Info("returned note for repeated idempotency key", "note_id", id)
+	}
+	return s.Get(ctx, id)
+}
+
+// dbtx is implemented by *sql.DB and *sql.Tx
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// insertNote normalizes and validates req and inserts it with db, returning the new note's ID
+func (s *Storage) insertNote(ctx context.Context, db dbtx, req note.CreateNoteRequest) (int64, error) {
+	req.Tags = s.normalizedTags(req.Tags)
+
+	if !note.IsValidNoteType(req.Type) {
+		return 0, fmt.Errorf("invalid note type: %s", req.Type)
+	}
+
+	if s.validateURLs {
+		if err := note.ValidateContent(req.Type, req.Content); err != nil {
+			return 0, err
+		}
+	}
+
+	contentHash := note.ContentHash(req.Content)
+	if err := s.checkUniqueContent(ctx, db, 0, req.Content, contentHash); err != nil {
+		return 0, err
+	}
+
 	var tagsJSON string
 	var metadataJSON string
 
 	if req.Tags != nil {
 		tagsBytes, err := json.Marshal(req.Tags)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal tags: %w", err)
+			return 0, fmt.Errorf("failed to marshal tags: %w", err)
 		}
 		tagsJSON = string(tagsBytes)
 	} else {
@@ -58,7 +218,7 @@ func (s *Storage) Create(ctx context.Context, req note.CreateNoteRequest) (*note
 	if req.Metadata != nil {
 		metadataBytes, err := json.Marshal(req.Metadata)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+			return 0, fmt.Errorf("failed to marshal metadata: %w", err)
 		}
 		metadataJSON = string(metadataBytes)
 	} else {
@@ -66,67 +226,142 @@ func (s *Storage) Create(ctx context.Context, req note.CreateNoteRequest) (*note
 	}
 
 	query := `
-		INSERT INTO notes (title, content, type, tags, metadata)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO notes (title, content, content_hash, type, tags, metadata, source)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
 	`
 
-	result, err := s.db.ExecContext(ctx, query, req.Title, req.Content, req.Type, tagsJSON, metadataJSON)
+	result, err := db.ExecContext(ctx, query, req.Title, req.Content, contentHash, req.Type, tagsJSON, metadataJSON, req.Source)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create note: %w", err)
+		return 0, fmt.Errorf("failed to create note: %w", err)
 	}
 
 	id, err := result.LastInsertId()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get last insert ID: %w", err)
+		return 0, fmt.Errorf("failed to get last insert ID: %w", err)
 	}
 
-	return s.Get(ctx, id)
+	return id, nil
+}
+
+// InsertNote inserts req in tx, which belongs to a transaction another
+// storage opened on the same database, and returns the new note's ID. It
+// applies Create's tag normalization, URL validation and unique content
+// check, but not its idempotency key.
+func (s *Storage) InsertNote(ctx context.Context, tx *sql.Tx, req note.CreateNoteRequest) (int64, error) {
+	return s.insertNote(ctx, tx, req)
 }
 
 // Get retrieves a note by ID
 func (s *Storage) Get(ctx context.Context, id int64) (*note.Note, error) {
 	query := `
-		SELECT id, title, content, type, tags, metadata, created_at, updated_at
+		SELECT `+noteColumns+`
 		FROM notes
 		WHERE id = ?
 	`
 
-	var n note.Note
-	var tagsJSON string
-	var metadataJSON string
-
-	err := s.db.QueryRowContext(ctx, query, id).Scan(
-		&n.ID,
-		&n.Title,
-		&n.Content,
-		&n.Type,
-		&tagsJSON,
-		&metadataJSON,
-		&n.CreatedAt,
-		&n.UpdatedAt,
-	)
+	n, err := scanNote(s.db.QueryRowContext(ctx, query, id))
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("note not found: %d", id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %d", note.ErrNotFound, id)
 		}
 		return nil, fmt.Errorf("failed to get note: %w", err)
 	}
 
-	if err := json.Unmarshal([]byte(tagsJSON), &n.Tags); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal tags: %w", err)
+	return &n, nil
+}
+
+// GetMany retrieves the notes with the given IDs in one query. Notes are
+// returned in the order of ids, once each even if an ID repeats; unknown IDs
+// are left out, so callers compare IDs to find the missing ones.
+func (s *Storage) GetMany(ctx context.Context, ids []int64) ([]note.Note, error) {
+	if len(ids) == 0 {
+		return []note.Note{}, nil
 	}
 
-	if metadataJSON != "null" {
-		if err := json.Unmarshal([]byte(metadataJSON), &n.Metadata); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	query := "SELECT " + noteColumns + " FROM notes WHERE id IN (" + strings.Join(placeholders, ", ") + ")"
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notes: %w", err)
+	}
+	defer rows.Close()
+
+	byID := make(map[int64]note.Note, len(ids))
+	for rows.Next() {
+		n, err := scanNote(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan note: %w", err)
 		}
+		byID[n.ID] = n
 	}
 
-	return &n, nil
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	items := make([]note.Note, 0, len(byID))
+	for _, id := range ids {
+		if n, ok := byID[id]; ok {
+			items = append(items, n)
+			delete(byID, id)
+		}
+	}
+	return items, nil
+}
+
+// GetByTitle retrieves the notes whose title equals title, ordered by ID.
+// Titles are unique, so an exact match returns at most one note; with
+// caseInsensitive the comparison uses COLLATE NOCASE (ASCII letters only) and
+// can return several notes. No match returns an empty slice, not an error.
+func (s *Storage) GetByTitle(ctx context.Context, title string, caseInsensitive bool) ([]note.Note, error) {
+	condition := "title = ?"
+	if caseInsensitive {
+		condition = "title = ? COLLATE NOCASE"
+	}
+
+	rows, err := s.db.QueryContext(ctx, "SELECT "+noteColumns+" FROM notes WHERE "+condition+" ORDER BY id", title)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notes by title: %w", err)
+	}
+	defer rows.Close()
+
+	items := []note.Note{}
+	for rows.Next() {
+		n, err := scanNote(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan note: %w", err)
+		}
+		items = append(items, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return items, nil
 }
 
 // Update updates an existing note
 func (s *Storage) Update(ctx context.Context, id int64, req note.UpdateNoteRequest) (*note.Note, error) {
+	var result *note.Note
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.update(ctx, id, req)
+		return err
+	})
+	return result, err
+}
+
+// update makes a single attempt at Update
+func (s *Storage) update(ctx context.Context, id int64, req note.UpdateNoteRequest) (*note.Note, error) {
+	req.Tags = s.normalizedTags(req.Tags)
+
 	// Build dynamic update query
 	var setClauses []string
 	var args []interface{}
@@ -137,11 +372,14 @@ func (s *Storage) Update(ctx context.Context, id int64, req note.UpdateNoteReque
 	}
 
 	if req.Content != nil {
-		setClauses = append(setClauses, "content = ?")
-		args = append(args, *req.Content)
+		setClauses = append(setClauses, "content = ?", "content_hash = ?")
+		args = append(args, *req.Content, note.ContentHash(*req.Content))
 	}
 
 	if req.Type != nil {
+		if !note.IsValidNoteType(*req.Type) {
+			return nil, fmt.Errorf("invalid note type: %s", *req.Type)
+		}
 		setClauses = append(setClauses, "type = ?")
 		args = append(args, *req.Type)
 	}
@@ -168,13 +406,46 @@ func (s *Storage) Update(ctx context.Context, id int64, req note.UpdateNoteReque
 		return s.Get(ctx, id)
 	}
 
+	// Validate the content and type the note will have after the update
+	if s.validateURLs && (req.Content != nil || req.Type != nil) {
+		current, err := s.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		noteType, content := current.Type, current.Content
+		if req.Type != nil {
+			noteType = *req.Type
+		}
+		if req.Content != nil {
+			content = *req.Content
+		}
+		if err := note.ValidateContent(noteType, content); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Content != nil {
+		if err := s.checkUniqueContent(ctx, s.db, id, *req.Content, note.ContentHash(*req.Content)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Optimistic concurrency: only update the version the caller last read
+	versionClause := ""
+	if req.ExpectedUpdatedAt != nil {
+		versionClause = " AND datetime(updated_at) = ?"
+	}
+
 	query := fmt.Sprintf(`
 		UPDATE notes
 		SET %s, updated_at = CURRENT_TIMESTAMP
-		WHERE id = ?
-	`, strings.Join(setClauses, ", "))
+		WHERE id = ?%s
+	`, strings.Join(setClauses, ", "), versionClause)
 
 	args = append(args, id)
+	if req.ExpectedUpdatedAt != nil {
+		args = append(args, req.ExpectedUpdatedAt.UTC().Format(sqlitedb.TimestampLayout))
+	}
 
 	result, err := s.db.ExecContext(ctx, query, args...)
 	if err != nil {
@@ -187,7 +458,15 @@ func (s *Storage) Update(ctx context.Context, id int64, req note.UpdateNoteReque
 	}
 
 	if rowsAffected == 0 {
-		return nil, fmt.Errorf("note not found: %d", id)
+		if req.ExpectedUpdatedAt != nil {
+			current, err := s.Get(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: note %d was updated at %s, expected %s", note.ErrConflict, id,
+				current.UpdatedAt.UTC().Format(time.RFC3339), req.ExpectedUpdatedAt.UTC().Format(time.RFC3339))
+		}
+		return nil, fmt.Errorf("%w: %d", note.ErrNotFound, id)
 	}
 
 	return s.Get(ctx, id)
@@ -195,6 +474,13 @@ func (s *Storage) Update(ctx context.Context, id int64, req note.UpdateNoteReque
 
 // Delete deletes a note by ID
 func (s *Storage) Delete(ctx context.Context, id int64) error {
+	return s.withRetry(ctx, func() error {
+		return s.delete(ctx, id)
+	})
+}
+
+// delete makes a single attempt at Delete
+func (s *Storage) delete(ctx context.Context, id int64) error {
 	query := "DELETE FROM notes WHERE id = ?"
 
 	result, err := s.db.ExecContext(ctx, query, id)
@@ -208,7 +494,7 @@ func (s *Storage) Delete(ctx context.Context, id int64) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("note not found: %d", id)
+		return fmt.Errorf("%w: %d", note.ErrNotFound, id)
 	}
 
 	return nil
@@ -216,58 +502,52 @@ func (s *Storage) Delete(ctx context.Context, id int64) error {
 
 // List lists notes with pagination and filtering
 func (s *Storage) List(ctx context.Context, req note.ListNotesRequest) (*note.ListNotesResponse, error) {
-	// Build query
-	var whereClauses []string
-	var args []interface{}
-
-	if req.Search != "" {
-		// Use FTS for full-text search
-		whereClauses = append(whereClauses, "notes.id IN (SELECT rowid FROM notes_fts WHERE notes_fts MATCH ?)")
-		args = append(args, req.Search)
-	}
+	return s.list(ctx, req)
+}
 
-	if len(req.Tags) > 0 {
-		for _, tag := range req.Tags {
-			whereClauses = append(whereClauses, "tags LIKE ?")
-			args = append(args, "%\""+tag+"\"%")
-		}
-	}
+// FindUnassignedNotes lists notes whose knowledge_base_id is NULL, with the
+// same filtering, ordering and pagination as List
+func (s *Storage) FindUnassignedNotes(ctx context.Context, req note.ListNotesRequest) (*note.ListNotesResponse, error) {
+	return s.list(ctx, req, "knowledge_base_id IS NULL")
+}
 
-	if req.Type != "" {
-		whereClauses = append(whereClauses, "type = ?")
-		args = append(args, req.Type)
+// Count returns the number of notes List would find for req, ignoring its
+// pagination and ordering
+func (s *Storage) Count(ctx context.Context, req note.ListNotesRequest) (int64, error) {
+	whereClause, args, err := s.listFilter(req)
+	if err != nil {
+		return 0, err
 	}
+	return s.countNotes(ctx, whereClause, args)
+}
 
-	whereClause := ""
-	if len(whereClauses) > 0 {
-		whereClause = "WHERE " + strings.Join(whereClauses, " AND ")
+// list runs List with extra fixed conditions ANDed onto the request filters
+func (s *Storage) list(ctx context.Context, req note.ListNotesRequest, conditions ...string) (*note.ListNotesResponse, error) {
+	whereClause, args, err := s.listFilter(req, conditions...)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get total count
-	countQuery := "SELECT COUNT(*) FROM notes " + whereClause
-	var total int64
-	if err := s.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
-		return nil, fmt.Errorf("failed to count notes: %w", err)
+	total, err := s.countNotes(ctx, whereClause, args)
+	if err != nil {
+		return nil, err
 	}
 
 	// Build order clause
-	orderBy := "created_at"
-	orderDir := "DESC"
-	if req.OrderBy != "" {
-		orderBy = req.OrderBy
-	}
-	if req.OrderDir != "" {
-		orderDir = strings.ToUpper(req.OrderDir)
+	orderClause, err := buildOrderClause(req.OrderBy, req.OrderDir)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get items
 	query := fmt.Sprintf(`
-		SELECT id, title, content, type, tags, metadata, created_at, updated_at
+		SELECT `+noteColumns+`
 		FROM notes
 		%s
-		ORDER BY %s %s
+		%s
 		LIMIT ? OFFSET ?
-	`, whereClause, orderBy, orderDir)
+	`, whereClause, orderClause)
 
 	args = append(args, req.Limit, req.Offset)
 
@@ -279,33 +559,11 @@ func (s *Storage) List(ctx context.Context, req note.ListNotesRequest) (*note.Li
 
 	var items []note.Note
 	for rows.Next() {
-		var n note.Note
-		var tagsJSON string
-		var metadataJSON string
-
-		if err := rows.Scan(
-			&n.ID,
-			&n.Title,
-			&n.Content,
-			&n.Type,
-			&tagsJSON,
-			&metadataJSON,
-			&n.CreatedAt,
-			&n.UpdatedAt,
-		); err != nil {
+		n, err := scanNote(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan note: %w", err)
 		}
 
-		if err := json.Unmarshal([]byte(tagsJSON), &n.Tags); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal tags: %w", err)
-		}
-
-		if metadataJSON != "null" {
-			if err := json.Unmarshal([]byte(metadataJSON), &n.Metadata); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
-			}
-		}
-
 		items = append(items, n)
 	}
 
@@ -317,4 +575,191 @@ func (s *Storage) List(ctx context.Context, req note.ListNotesRequest) (*note.Li
 		Items: items,
 		Total: total,
 	}, nil
-}
\ No newline at end of file
+}
+
+// listFilter builds the WHERE clause and its arguments for the request
+// filters of List and Count, with extra fixed conditions ANDed on
+func (s *Storage) listFilter(req note.ListNotesRequest, conditions ...string) (string, []interface{}, error) {
+	req.Tags = s.normalizedTags(req.Tags)
+	req.ExcludeTags = s.normalizedTags(req.ExcludeTags)
+
+	// Build query
+	whereClauses := append([]string{}, conditions...)
+	var args []interface{}
+
+	if req.Search != "" {
+		// Use FTS for full-text search
+		whereClauses = append(whereClauses, "notes.id IN (SELECT rowid FROM notes_fts WHERE notes_fts MATCH ?)")
+		args = append(args, req.Search)
+	}
+
+	// Include and exclude tags both match whole tags exactly through json_each
+	if len(req.Tags) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(req.Tags)), ", ")
+		whereClauses = append(whereClauses, "EXISTS (SELECT 1 FROM json_each(notes.tags) WHERE json_each.value IN ("+placeholders+"))")
+		for _, tag := range req.Tags {
+			args = append(args, tag)
+		}
+	}
+
+	if len(req.ExcludeTags) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(req.ExcludeTags)), ", ")
+		whereClauses = append(whereClauses, "NOT EXISTS (SELECT 1 FROM json_each(notes.tags) WHERE json_each.value IN ("+placeholders+"))")
+		for _, tag := range req.ExcludeTags {
+			args = append(args, tag)
+		}
+	}
+
+	typeClause, typeArgs, err := typesClause(req)
+	if err != nil {
+		return "", nil, err
+	}
+	if typeClause != "" {
+		whereClauses = append(whereClauses, typeClause)
+		args = append(args, typeArgs...)
+	}
+
+	if req.Source != "" {
+		whereClauses = append(whereClauses, "source = ?")
+		args = append(args, req.Source)
+	}
+
+	if req.HasConnectionType != nil {
+		// Connections share the database file, so they can be checked in place
+		whereClauses = append(whereClauses, `EXISTS (
+			SELECT 1 FROM connections c
+			WHERE c.type = ? AND (c.from_note_id = notes.id OR c.to_note_id = notes.id)
+		)`)
+		args = append(args, *req.HasConnectionType)
+	}
+
+	whereClause := ""
+	if len(whereClauses) > 0 {
+		whereClause = "WHERE " + strings.Join(whereClauses, " AND ")
+	}
+	return whereClause, args, nil
+}
+
+// countNotes counts the notes matching whereClause
+func (s *Storage) countNotes(ctx context.Context, whereClause string, args []interface{}) (int64, error) {
+	var total int64
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM notes "+whereClause, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count notes: %w", err)
+	}
+	return total, nil
+}
+
+// typesClause builds the type filter for list from Type and Types. Types
+// must all be valid note types; Type is matched as given, as it always was.
+func typesClause(req note.ListNotesRequest) (string, []interface{}, error) {
+	types := make([]string, 0, len(req.Types)+1)
+	if req.Type != "" {
+		types = append(types, req.Type)
+	}
+	for _, noteType := range req.Types {
+		if !note.IsValidNoteType(noteType) {
+			return "", nil, fmt.Errorf("invalid note type: %s", noteType)
+		}
+		if !slices.Contains(types, noteType) {
+			types = append(types, noteType)
+		}
+	}
+	if len(types) == 0 {
+		return "", nil, nil
+	}
+
+	args := make([]interface{}, len(types))
+	for i, noteType := range types {
+		args[i] = noteType
+	}
+	return "type IN (" + strings.TrimSuffix(strings.Repeat("?, ", len(types)), ", ") + ")", args, nil
+}
+
+// noteColumns lists the columns scanNote expects, in order
+const noteColumns = "id, title, content, content_hash, type, tags, metadata, source, knowledge_base_id, incoming_count, outgoing_count, created_at, updated_at"
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanNote scans a row selected with noteColumns
+func scanNote(row rowScanner) (note.Note, error) {
+	var n note.Note
+	var tagsJSON string
+	var metadataJSON string
+	var source sql.NullString
+	var knowledgeBaseID sql.NullInt64
+	var contentHash sql.NullString
+
+	if err := row.Scan(
+		&n.ID,
+		&n.Title,
+		&n.Content,
+		&contentHash,
+		&n.Type,
+		&tagsJSON,
+		&metadataJSON,
+		&source,
+		&knowledgeBaseID,
+		&n.IncomingCount,
+		&n.OutgoingCount,
+		&n.CreatedAt,
+		&n.UpdatedAt,
+	); err != nil {
+		return n, err
+	}
+
+	if err := json.Unmarshal([]byte(tagsJSON), &n.Tags); err != nil {
+		return n, fmt.Errorf("failed to unmarshal tags: %w", err)
+	}
+
+	if metadataJSON != "null" {
+		if err := json.Unmarshal([]byte(metadataJSON), &n.Metadata); err != nil {
+			return n, fmt.Errorf("failed to unmarshal metadata: %w", err)
+		}
+	}
+
+	if source.Valid {
+		n.Source = &source.String
+	}
+	if knowledgeBaseID.Valid {
+		n.KnowledgeBaseID = &knowledgeBaseID.Int64
+	}
+	// Rows written outside this storage have no stored hash until fillContentHashes runs
+	n.ContentHash = contentHash.String
+	if !contentHash.Valid {
+		n.ContentHash = note.ContentHash(n.Content)
+	}
+
+	return n, nil
+}
+// orderByColumns whitelists the sortable columns so user input never reaches the SQL text
+var orderByColumns = map[string]string{
+	"id":         "id",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+	"title":      "title",
+}
+
+// buildOrderClause builds the ORDER BY clause for List, defaulting to newest first
+func buildOrderClause(orderBy, orderDir string) (string, error) {
+	if orderBy == "" {
+		orderBy = "created_at"
+	}
+	column, ok := orderByColumns[orderBy]
+	if !ok {
+		return "", fmt.Errorf("invalid order_by: %s. Valid values are: %v", orderBy, note.ValidOrderByFields())
+	}
+
+	direction := "DESC"
+	switch strings.ToLower(orderDir) {
+	case "", "desc":
+	case "asc":
+		direction = "ASC"
+	default:
+		return "", fmt.Errorf("invalid order_dir: %s. Valid values are: asc, desc", orderDir)
+	}
+
+	return fmt.Sprintf("ORDER BY %s %s", column, direction), nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -48,9 +49,11 @@ func TestStorage(t *testing.T) {
 					Type:     "text",
 					Tags:     []string{"tag1", "tag2"},
 					Metadata: map[string]interface{}{"key": "value", "number": 42},
+					Source:   strPtr("research-agent"),
 				},
 				wantErr: false,
 				validate: func(t *testing.T, n *note.Note) {
+					assert.Equal(t, "research-agent", *n.Source)
 					assert.Equal(t, "Test Note", n.Title)
 					assert.Equal(t, "This is a test note content", n.Content)
 					assert.Equal(t, "text", n.Type)
@@ -313,6 +316,7 @@ func TestStorage(t *testing.T) {
 			Type:     "markdown",
 			Tags:     []string{"special", "test"},
 			Metadata: map[string]interface{}{"special": true},
+			Source:   strPtr("research-agent"),
 		})
 		require.NoError(t, err)
 
@@ -386,6 +390,17 @@ func TestStorage(t *testing.T) {
 				wantItems: 1,
 				wantErr:   false,
 			},
+			{
+				name: "list with source filter",
+				req: note.ListNotesRequest{
+					Limit:  10,
+					Offset: 0,
+					Source: "research-agent",
+				},
+				wantTotal: 1,
+				wantItems: 1,
+				wantErr:   false,
+			},
 			{
 				name: "list with order by created_at desc",
 				req: note.ListNotesRequest{
@@ -424,6 +439,910 @@ func TestStorage(t *testing.T) {
 	})
 }
 
+func newTestStorage(t *testing.T, opts ...Option) *Storage {
+	t.Helper()
+
+	tempFile, err := os.CreateTemp("", "test-note-*.db")
+	require.NoError(t, err)
+	tempFile.Close()
+	t.Cleanup(func() { os.Remove(tempFile.Name()) })
+
+	storage, err := NewStorage(tempFile.Name(), opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { storage.Close() })
+
+	err = migrations.NewMigrationRunner(tempFile.Name()).RunMigrations()
+	require.NoError(t, err)
+
+	return storage
+}
+
+func TestStorage_FindUnassignedNotes(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	result, err := storage.db.Exec("INSERT INTO knowledge_base (name) VALUES ('Research')")
+	require.NoError(t, err)
+	kbID, err := result.LastInsertId()
+	require.NoError(t, err)
+
+	var assigned *note.Note
+	for _, title := range []string{"Charlie", "Alpha", "Assigned", "Bravo"} {
+		n, err := storage.Create(ctx, note.CreateNoteRequest{Title: title, Content: "Content", Type: "text"})
+		require.NoError(t, err)
+		if title == "Assigned" {
+			assigned = n
+		}
+	}
+	_, err = storage.db.Exec("UPDATE notes SET knowledge_base_id = ? WHERE id = ?", kbID, assigned.ID)
+	require.NoError(t, err)
+
+	got, err := storage.Get(ctx, assigned.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.KnowledgeBaseID)
+	assert.Equal(t, kbID, *got.KnowledgeBaseID)
+
+	resp, err := storage.FindUnassignedNotes(ctx, note.ListNotesRequest{Limit: 10, OrderBy: "title", OrderDir: "asc"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), resp.Total)
+	var titles []string
+	for _, n := range resp.Items {
+		assert.Nil(t, n.KnowledgeBaseID)
+		titles = append(titles, n.Title)
+	}
+	assert.Equal(t, []string{"Alpha", "Bravo", "Charlie"}, titles)
+
+	resp, err = storage.FindUnassignedNotes(ctx, note.ListNotesRequest{Limit: 1, Offset: 1, OrderBy: "title", OrderDir: "asc"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), resp.Total)
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, "Bravo", resp.Items[0].Title)
+
+	// Regular listing is unaffected
+	resp, err = storage.List(ctx, note.ListNotesRequest{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), resp.Total)
+}
+
+func TestStorage_UpdateExpectedUpdatedAt(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	created, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Shared", Content: "Original", Type: "text"})
+	require.NoError(t, err)
+
+	read, err := storage.Get(ctx, created.ID)
+	require.NoError(t, err)
+	version := read.UpdatedAt
+	// The second agent read the note before a write that has since landed
+	stale := version.Add(-time.Minute)
+
+	// The first agent writes with the current version
+	updated, err := storage.Update(ctx, created.ID, note.UpdateNoteRequest{
+		Tags:              []string{"first-agent"},
+		ExpectedUpdatedAt: &version,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first-agent"}, updated.Tags)
+
+	// The second agent's write is rejected
+	_, err = storage.Update(ctx, created.ID, note.UpdateNoteRequest{
+		Tags:              []string{"second-agent"},
+		ExpectedUpdatedAt: &stale,
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, note.ErrConflict)
+
+	current, err := storage.Get(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first-agent"}, current.Tags)
+
+	// Unconditional updates keep last-write-wins behaviour
+	_, err = storage.Update(ctx, created.ID, note.UpdateNoteRequest{Tags: []string{"unconditional"}})
+	require.NoError(t, err)
+
+	// A missing note is still reported as not found
+	_, err = storage.Update(ctx, created.ID+100, note.UpdateNoteRequest{
+		Tags:              []string{"nobody"},
+		ExpectedUpdatedAt: &version,
+	})
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, note.ErrConflict)
+	assert.ErrorIs(t, err, note.ErrNotFound)
+	assert.Contains(t, err.Error(), "note not found")
+}
+
+func TestStorage_TagNormalization(t *testing.T) {
+	ctx := context.Background()
+	messy := []string{" Go", "go", "GO ", "", "Machine Learning", "  "}
+
+	t.Run("enabled", func(t *testing.T) {
+		storage := newTestStorage(t, WithTagNormalization(true))
+
+		n, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Messy", Content: "Content", Type: "text", Tags: messy})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"go", "machine learning"}, n.Tags)
+
+		updated, err := storage.Update(ctx, n.ID, note.UpdateNoteRequest{Tags: []string{"Research ", "research", "AI"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"research", "ai"}, updated.Tags)
+
+		// Filters are normalized the same way
+		resp, err := storage.List(ctx, note.ListNotesRequest{Limit: 10, Tags: []string{" RESEARCH"}})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), resp.Total)
+	})
+
+	t.Run("disabled keeps tags verbatim", func(t *testing.T) {
+		storage := newTestStorage(t)
+
+		n, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Messy", Content: "Content", Type: "text", Tags: messy})
+		require.NoError(t, err)
+		assert.Equal(t, messy, n.Tags)
+	})
+}
+
+func TestStorage_URLValidation(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		enabled bool
+		change  func(storage *Storage) error
+		wantErr bool
+	}{
+		{
+			name:    "link with url",
+			enabled: true,
+			change: func(storage *Storage) error {
+				_, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Link", Content: "https://example.com", Type: "link"})
+				return err
+			},
+		},
+		{
+			name:    "link without url",
+			enabled: true,
+			change: func(storage *Storage) error {
+				_, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Link", Content: "example", Type: "link"})
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name:    "update image content to a non-url",
+			enabled: true,
+			change: func(storage *Storage) error {
+				n, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Image", Content: "https://example.com/cat.png", Type: "image"})
+				if err != nil {
+					return err
+				}
+				_, err = storage.Update(ctx, n.ID, note.UpdateNoteRequest{Content: strPtr("cat.png")})
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name:    "update text to link keeping non-url content",
+			enabled: true,
+			change: func(storage *Storage) error {
+				n, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Text", Content: "plain words", Type: "text"})
+				if err != nil {
+					return err
+				}
+				_, err = storage.Update(ctx, n.ID, note.UpdateNoteRequest{Type: strPtr("link")})
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name:    "update link to text with non-url content",
+			enabled: true,
+			change: func(storage *Storage) error {
+				n, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Link", Content: "https://example.com", Type: "link"})
+				if err != nil {
+					return err
+				}
+				_, err = storage.Update(ctx, n.ID, note.UpdateNoteRequest{Type: strPtr("text"), Content: strPtr("plain words")})
+				return err
+			},
+		},
+		{
+			name: "disabled stores any content",
+			change: func(storage *Storage) error {
+				_, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Link", Content: "example", Type: "link"})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newTestStorage(t, WithURLValidation(tt.enabled))
+
+			err := tt.change(storage)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, note.ErrInvalidURL)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("content is stored unchanged", func(t *testing.T) {
+		storage := newTestStorage(t, WithURLValidation(true))
+
+		n, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Link", Content: " https://www.example.com/a ", Type: "link"})
+		require.NoError(t, err)
+		assert.Equal(t, " https://www.example.com/a ", n.Content)
+	})
+}
+
+func TestStorage_GetMany(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	var ids []int64
+	for _, title := range []string{"First", "Second", "Third"} {
+		n, err := storage.Create(ctx, note.CreateNoteRequest{Title: title, Content: title + " content", Type: "text", Tags: []string{"batch"}})
+		require.NoError(t, err)
+		ids = append(ids, n.ID)
+	}
+
+	tests := []struct {
+		name       string
+		ids        []int64
+		wantTitles []string
+	}{
+		{name: "requested order", ids: []int64{ids[2], ids[0], ids[1]}, wantTitles: []string{"Third", "First", "Second"}},
+		{name: "mix of existing and missing", ids: []int64{9999, ids[1], 8888, ids[0]}, wantTitles: []string{"Second", "First"}},
+		{name: "repeated ID returned once", ids: []int64{ids[0], ids[0], ids[2]}, wantTitles: []string{"First", "Third"}},
+		{name: "only missing", ids: []int64{9999}, wantTitles: []string{}},
+		{name: "no IDs", ids: nil, wantTitles: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notes, err := storage.GetMany(ctx, tt.ids)
+			require.NoError(t, err)
+
+			titles := make([]string, len(notes))
+			for i, n := range notes {
+				titles[i] = n.Title
+				assert.Equal(t, []string{"batch"}, n.Tags)
+			}
+			assert.Equal(t, tt.wantTitles, titles)
+		})
+	}
+}
+
+func TestStorage_GetByTitle(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	var ids []int64
+	for _, title := range []string{"Go Basics", "go basics", "Rust"} {
+		n, err := storage.Create(ctx, note.CreateNoteRequest{Title: title, Content: "Content", Type: "text"})
+		require.NoError(t, err)
+		ids = append(ids, n.ID)
+	}
+
+	tests := []struct {
+		name            string
+		title           string
+		caseInsensitive bool
+		wantIDs         []int64
+	}{
+		{name: "exact match", title: "Go Basics", wantIDs: []int64{ids[0]}},
+		{name: "exact match is case-sensitive", title: "GO BASICS", wantIDs: []int64{}},
+		{name: "case-insensitive returns every match", title: "GO BASICS", caseInsensitive: true, wantIDs: []int64{ids[0], ids[1]}},
+		{name: "case-insensitive single match", title: "rust", caseInsensitive: true, wantIDs: []int64{ids[2]}},
+		{name: "no match", title: "Python", caseInsensitive: true, wantIDs: []int64{}},
+		{name: "no partial match", title: "Go", wantIDs: []int64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notes, err := storage.GetByTitle(ctx, tt.title, tt.caseInsensitive)
+			require.NoError(t, err)
+
+			gotIDs := []int64{}
+			for _, n := range notes {
+				gotIDs = append(gotIDs, n.ID)
+			}
+			assert.Equal(t, tt.wantIDs, gotIDs)
+		})
+	}
+
+	t.Run("lookups use a title index", func(t *testing.T) {
+		for condition, index := range map[string]string{
+			"title = ?":                "idx_notes_title_unique",
+			"title = ? COLLATE NOCASE": "idx_notes_title_nocase",
+		} {
+			var plan strings.Builder
+			rows, err := storage.db.QueryContext(ctx, "EXPLAIN QUERY PLAN SELECT "+noteColumns+" FROM notes WHERE "+condition, "Go Basics")
+			require.NoError(t, err)
+			for rows.Next() {
+				var id, parent, notUsed int
+				var detail string
+				require.NoError(t, rows.Scan(&id, &parent, &notUsed, &detail))
+				plan.WriteString(detail)
+			}
+			require.NoError(t, rows.Err())
+			rows.Close()
+			assert.Contains(t, plan.String(), index)
+		}
+	})
+}
+
 func strPtr(s string) *string {
 	return &s
 }
+
+func TestStorage_ListHasConnectionType(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	ids := make(map[string]int64)
+	for _, title := range []string{"Claim", "Rebuttal", "Evidence", "Loner", "Self"} {
+		n, err := storage.Create(ctx, note.CreateNoteRequest{Title: title, Content: "Content", Type: "text"})
+		require.NoError(t, err)
+		ids[title] = n.ID
+	}
+	_, err := storage.db.Exec(`
+		INSERT INTO connections (from_note_id, to_note_id, type, strength) VALUES
+			(?, ?, 'contradicts', 5),
+			(?, ?, 'supports', 5),
+			(?, ?, 'supports', 5),
+			(?, ?, 'relates_to', 5)`,
+		ids["Rebuttal"], ids["Claim"],
+		ids["Evidence"], ids["Claim"],
+		ids["Evidence"], ids["Rebuttal"],
+		ids["Self"], ids["Self"])
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		req        note.ListNotesRequest
+		wantTitles []string
+	}{
+		{
+			name:       "source and target both match",
+			req:        note.ListNotesRequest{HasConnectionType: strPtr("contradicts")},
+			wantTitles: []string{"Claim", "Rebuttal"},
+		},
+		{
+			name:       "several connections count once",
+			req:        note.ListNotesRequest{HasConnectionType: strPtr("supports")},
+			wantTitles: []string{"Claim", "Evidence", "Rebuttal"},
+		},
+		{
+			name:       "self-connection",
+			req:        note.ListNotesRequest{HasConnectionType: strPtr("relates_to")},
+			wantTitles: []string{"Self"},
+		},
+		{
+			name:       "unused type",
+			req:        note.ListNotesRequest{HasConnectionType: strPtr("cites")},
+			wantTitles: nil,
+		},
+		{
+			name:       "combined with other filters",
+			req:        note.ListNotesRequest{HasConnectionType: strPtr("supports"), Search: "Evidence"},
+			wantTitles: []string{"Evidence"},
+		},
+		{
+			name:       "no filter",
+			req:        note.ListNotesRequest{},
+			wantTitles: []string{"Claim", "Evidence", "Loner", "Rebuttal", "Self"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Limit = 10
+			tt.req.OrderBy = "title"
+			tt.req.OrderDir = "asc"
+
+			resp, err := storage.List(ctx, tt.req)
+			require.NoError(t, err)
+
+			var titles []string
+			for _, n := range resp.Items {
+				titles = append(titles, n.Title)
+			}
+			assert.Equal(t, tt.wantTitles, titles)
+			assert.Equal(t, int64(len(tt.wantTitles)), resp.Total)
+		})
+	}
+}
+
+func TestStorage_ListTypes(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	for _, n := range []note.CreateNoteRequest{
+		{Title: "Go snippet", Content: "Channels and goroutines", Type: "code", Tags: []string{"go"}},
+		{Title: "Go guide", Content: "Writing goroutines well", Type: "markdown", Tags: []string{"go"}},
+		{Title: "Rust snippet", Content: "Ownership and borrowing", Type: "code", Tags: []string{"rust"}},
+		{Title: "Go thoughts", Content: "Goroutines are cheap", Type: "text", Tags: []string{"go"}},
+	} {
+		_, err := storage.Create(ctx, n)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name       string
+		req        note.ListNotesRequest
+		wantTitles []string
+		wantErr    string
+	}{
+		{
+			name:       "several types",
+			req:        note.ListNotesRequest{Types: []string{"code", "markdown"}},
+			wantTitles: []string{"Go guide", "Go snippet", "Rust snippet"},
+		},
+		{
+			name:       "combined with type",
+			req:        note.ListNotesRequest{Type: "text", Types: []string{"markdown"}},
+			wantTitles: []string{"Go guide", "Go thoughts"},
+		},
+		{
+			name:       "type repeated in types",
+			req:        note.ListNotesRequest{Type: "code", Types: []string{"code"}},
+			wantTitles: []string{"Go snippet", "Rust snippet"},
+		},
+		{
+			name:       "with tags",
+			req:        note.ListNotesRequest{Types: []string{"code", "text"}, Tags: []string{"go"}},
+			wantTitles: []string{"Go snippet", "Go thoughts"},
+		},
+		{
+			name:       "with search",
+			req:        note.ListNotesRequest{Types: []string{"code", "markdown"}, Search: "goroutines"},
+			wantTitles: []string{"Go guide", "Go snippet"},
+		},
+		{
+			name:       "with tags and search",
+			req:        note.ListNotesRequest{Types: []string{"markdown", "text"}, Tags: []string{"go"}, Search: "cheap"},
+			wantTitles: []string{"Go thoughts"},
+		},
+		{
+			name:       "empty types is no filter",
+			req:        note.ListNotesRequest{Types: []string{}},
+			wantTitles: []string{"Go guide", "Go snippet", "Go thoughts", "Rust snippet"},
+		},
+		{
+			name:    "invalid type",
+			req:     note.ListNotesRequest{Types: []string{"code", "video"}},
+			wantErr: "invalid note type: video",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Limit = 10
+			tt.req.OrderBy = "title"
+			tt.req.OrderDir = "asc"
+
+			resp, err := storage.List(ctx, tt.req)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var titles []string
+			for _, n := range resp.Items {
+				titles = append(titles, n.Title)
+			}
+			assert.Equal(t, tt.wantTitles, titles)
+			assert.Equal(t, int64(len(tt.wantTitles)), resp.Total)
+		})
+	}
+}
+
+func TestStorage_ListExcludeTags(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	for _, n := range []note.CreateNoteRequest{
+		{Title: "Go draft", Content: "Unfinished thoughts on channels", Type: "text", Tags: []string{"go", "draft"}},
+		{Title: "Go archived", Content: "Old notes on goroutines", Type: "text", Tags: []string{"go", "archived"}},
+		{Title: "Go final", Content: "Finished guide to goroutines", Type: "text", Tags: []string{"go"}},
+		{Title: "Rust draft", Content: "Unfinished thoughts on ownership", Type: "text", Tags: []string{"rust", "draft"}},
+		{Title: "Untagged", Content: "A note without tags", Type: "text"},
+		// Excluding draft must not drop a tag that only contains it
+		{Title: "Drafting", Content: "How to write a first version", Type: "text", Tags: []string{"drafting"}},
+		{Title: "Mixed case", Content: "A tag with a capital", Type: "text", Tags: []string{"Review"}},
+		// LIKE wildcards in a tag must match only themselves
+		{Title: "Underscore", Content: "Snake case tag", Type: "text", Tags: []string{"go_lang"}},
+		{Title: "Percent", Content: "Percent sign tag", Type: "text", Tags: []string{"go%lang"}},
+	} {
+		_, err := storage.Create(ctx, n)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name       string
+		req        note.ListNotesRequest
+		wantTitles []string
+	}{
+		{
+			name:       "exclude one tag",
+			req:        note.ListNotesRequest{ExcludeTags: []string{"draft"}},
+			wantTitles: []string{"Drafting", "Go archived", "Go final", "Mixed case", "Percent", "Underscore", "Untagged"},
+		},
+		{
+			name:       "exclude several tags drops notes with any of them",
+			req:        note.ListNotesRequest{ExcludeTags: []string{"draft", "archived"}},
+			wantTitles: []string{"Drafting", "Go final", "Mixed case", "Percent", "Underscore", "Untagged"},
+		},
+		{
+			name:       "include several tags keeps notes with any of them",
+			req:        note.ListNotesRequest{Tags: []string{"rust", "archived"}},
+			wantTitles: []string{"Go archived", "Rust draft"},
+		},
+		{
+			name:       "include and exclude",
+			req:        note.ListNotesRequest{Tags: []string{"go"}, ExcludeTags: []string{"draft"}},
+			wantTitles: []string{"Go archived", "Go final"},
+		},
+		{
+			name:       "include and exclude several",
+			req:        note.ListNotesRequest{Tags: []string{"go"}, ExcludeTags: []string{"draft", "archived"}},
+			wantTitles: []string{"Go final"},
+		},
+		{
+			name:       "tag both included and excluded matches nothing",
+			req:        note.ListNotesRequest{Tags: []string{"draft"}, ExcludeTags: []string{"draft"}},
+			wantTitles: nil,
+		},
+		{
+			name:       "include matches case exactly, like exclude",
+			req:        note.ListNotesRequest{Tags: []string{"review"}},
+			wantTitles: nil,
+		},
+		{
+			name:       "mixed-case tag both included and excluded matches nothing",
+			req:        note.ListNotesRequest{Tags: []string{"Review"}, ExcludeTags: []string{"Review"}},
+			wantTitles: nil,
+		},
+		{
+			name:       "mixed-case tag included",
+			req:        note.ListNotesRequest{Tags: []string{"Review"}},
+			wantTitles: []string{"Mixed case"},
+		},
+		{
+			name:       "underscore is not a wildcard",
+			req:        note.ListNotesRequest{Tags: []string{"go_lang"}},
+			wantTitles: []string{"Underscore"},
+		},
+		{
+			name:       "percent is not a wildcard",
+			req:        note.ListNotesRequest{Tags: []string{"go%lang"}, ExcludeTags: []string{"go_lang"}},
+			wantTitles: []string{"Percent"},
+		},
+		{
+			name:       "exclude an unused tag",
+			req:        note.ListNotesRequest{Tags: []string{"rust"}, ExcludeTags: []string{"python"}},
+			wantTitles: []string{"Rust draft"},
+		},
+		{
+			name:       "with search",
+			req:        note.ListNotesRequest{Search: "unfinished", ExcludeTags: []string{"rust"}},
+			wantTitles: []string{"Go draft"},
+		},
+		{
+			name:       "empty exclude list is no filter",
+			req:        note.ListNotesRequest{ExcludeTags: []string{}},
+			wantTitles: []string{"Drafting", "Go archived", "Go draft", "Go final", "Mixed case", "Percent", "Rust draft", "Underscore", "Untagged"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.Limit = 10
+			tt.req.OrderBy = "title"
+			tt.req.OrderDir = "asc"
+
+			resp, err := storage.List(ctx, tt.req)
+			require.NoError(t, err)
+
+			var titles []string
+			for _, n := range resp.Items {
+				titles = append(titles, n.Title)
+			}
+			assert.Equal(t, tt.wantTitles, titles)
+			assert.Equal(t, int64(len(tt.wantTitles)), resp.Total)
+		})
+	}
+}
+
+func TestStorage_Count(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	for _, n := range []note.CreateNoteRequest{
+		{Title: "Go draft", Content: "Unfinished thoughts on channels", Type: "text", Tags: []string{"go", "draft"}, Source: strPtr("agent")},
+		{Title: "Go final", Content: "Finished guide to goroutines", Type: "markdown", Tags: []string{"go"}},
+		{Title: "Rust draft", Content: "Unfinished thoughts on ownership", Type: "text", Tags: []string{"rust", "draft"}, Source: strPtr("agent")},
+		{Title: "Untagged", Content: "A note without tags", Type: "code"},
+	} {
+		_, err := storage.Create(ctx, n)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name string
+		req  note.ListNotesRequest
+		want int64
+	}{
+		{name: "no filter", req: note.ListNotesRequest{}, want: 4},
+		{name: "tags", req: note.ListNotesRequest{Tags: []string{"draft"}}, want: 2},
+		{name: "exclude tags", req: note.ListNotesRequest{ExcludeTags: []string{"draft"}}, want: 2},
+		{name: "types", req: note.ListNotesRequest{Types: []string{"markdown", "code"}}, want: 2},
+		{name: "source", req: note.ListNotesRequest{Source: "agent"}, want: 2},
+		{name: "search", req: note.ListNotesRequest{Search: "unfinished"}, want: 2},
+		{name: "combined", req: note.ListNotesRequest{Search: "unfinished", Tags: []string{"go"}}, want: 1},
+		{name: "no match", req: note.ListNotesRequest{Tags: []string{"python"}}, want: 0},
+		// Paging does not change the total
+		{name: "limit and offset ignored", req: note.ListNotesRequest{Limit: 1, Offset: 3}, want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, err := storage.Count(ctx, tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, count)
+
+			listReq := tt.req
+			listReq.Limit = 1
+			resp, err := storage.List(ctx, listReq)
+			require.NoError(t, err)
+			assert.Equal(t, resp.Total, count)
+		})
+	}
+}
+
+func TestStorage_ListOrdering(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+
+	// Insert with explicit timestamps so created_at ordering is deterministic
+	for i, title := range []string{"beta", "alpha", "gamma"} {
+		_, err := storage.db.ExecContext(ctx,
+			"INSERT INTO notes (title, content, type, tags, metadata, created_at, updated_at) VALUES (?, 'Content', 'text', '[]', '{}', ?, ?)",
+			title, fmt.Sprintf("2024-01-0%d 00:00:00", i+1), fmt.Sprintf("2024-02-0%d 00:00:00", 3-i))
+		require.NoError(t, err)
+	}
+
+	titles := func(resp *note.ListNotesResponse) []string {
+		var result []string
+		for _, n := range resp.Items {
+			result = append(result, n.Title)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name       string
+		req        note.ListNotesRequest
+		wantErr    string
+		wantTitles []string
+	}{
+		{
+			name:       "default is newest first",
+			req:        note.ListNotesRequest{Limit: 10},
+			wantTitles: []string{"gamma", "alpha", "beta"},
+		},
+		{
+			name:       "title ascending",
+			req:        note.ListNotesRequest{Limit: 10, OrderBy: "title", OrderDir: "asc"},
+			wantTitles: []string{"alpha", "beta", "gamma"},
+		},
+		{
+			name:       "updated_at ascending ignores the case of the direction",
+			req:        note.ListNotesRequest{Limit: 10, OrderBy: "updated_at", OrderDir: "ASC"},
+			wantTitles: []string{"gamma", "alpha", "beta"},
+		},
+		{
+			name:       "id descending",
+			req:        note.ListNotesRequest{Limit: 10, OrderBy: "id", OrderDir: "desc"},
+			wantTitles: []string{"gamma", "alpha", "beta"},
+		},
+		{
+			name:    "injected order_by",
+			req:     note.ListNotesRequest{Limit: 10, OrderBy: "title; DROP TABLE notes; --"},
+			wantErr: "invalid order_by: title; DROP TABLE notes; --",
+		},
+		{
+			name:    "subquery in order_by",
+			req:     note.ListNotesRequest{Limit: 10, OrderBy: "(SELECT content FROM notes LIMIT 1)"},
+			wantErr: "invalid order_by",
+		},
+		{
+			name:    "injected order_dir",
+			req:     note.ListNotesRequest{Limit: 10, OrderBy: "title", OrderDir: "ASC; DROP TABLE notes; --"},
+			wantErr: "invalid order_dir: ASC; DROP TABLE notes; --",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := storage.List(ctx, tt.req)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTitles, titles(resp))
+		})
+	}
+
+	var count int
+	require.NoError(t, storage.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM notes").Scan(&count))
+	assert.Equal(t, 3, count)
+}
+
+func TestStorage_CustomNoteTypes(t *testing.T) {
+	ctx := context.Background()
+	storage := newTestStorage(t)
+
+	require.NoError(t, note.Configure(note.Config{ExtraTypes: []string{"dataset", "experiment"}}))
+	t.Cleanup(func() {
+		require.NoError(t, note.Configure(note.Config{}))
+	})
+
+	created, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Survey", Content: "survey.csv", Type: "dataset"})
+	require.NoError(t, err)
+	assert.Equal(t, "dataset", created.Type)
+
+	tests := []struct {
+		name    string
+		run     func() (*note.Note, error)
+		want    string
+		wantErr string
+	}{
+		{
+			name: "get",
+			run:  func() (*note.Note, error) { return storage.Get(ctx, created.ID) },
+			want: "dataset",
+		},
+		{
+			name: "update to another custom type",
+			run: func() (*note.Note, error) {
+				return storage.Update(ctx, created.ID, note.UpdateNoteRequest{Type: strPtr("experiment")})
+			},
+			want: "experiment",
+		},
+		{
+			name: "create with an unknown type",
+			run: func() (*note.Note, error) {
+				return storage.Create(ctx, note.CreateNoteRequest{Title: "Clip", Content: "clip.mp4", Type: "video"})
+			},
+			wantErr: "invalid note type: video",
+		},
+		{
+			name: "update to an unknown type",
+			run: func() (*note.Note, error) {
+				return storage.Update(ctx, created.ID, note.UpdateNoteRequest{Type: strPtr("video")})
+			},
+			wantErr: "invalid note type: video",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.run()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Type)
+		})
+	}
+
+	// Listing by a custom type filters like a built-in one
+	list, err := storage.List(ctx, note.ListNotesRequest{Type: "experiment"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), list.Total)
+}
+
+func TestStorage_CreateIdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+	storage := newTestStorage(t)
+
+	first, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Retry", Content: "First call", Type: "text", IdempotencyKey: "retry-1"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		req     note.CreateNoteRequest
+		wantID  func(id int64) bool
+		wantErr string
+	}{
+		{
+			name:   "repeated key returns the first note unchanged",
+			req:    note.CreateNoteRequest{Title: "Retry again", Content: "Second call", Type: "text", IdempotencyKey: "retry-1"},
+			wantID: func(id int64) bool { return id == first.ID },
+		},
+		{
+			name:   "another key creates a note",
+			req:    note.CreateNoteRequest{Title: "Other", Content: "Other call", Type: "text", IdempotencyKey: "retry-2"},
+			wantID: func(id int64) bool { return id != first.ID },
+		},
+		{
+			name:    "invalid request with a new key fails",
+			req:     note.CreateNoteRequest{Title: "Bad", Content: "Bad", Type: "video", IdempotencyKey: "retry-3"},
+			wantErr: "invalid note type: video",
+		},
+		{
+			name:    "blank key",
+			req:     note.CreateNoteRequest{Title: "Blank", Content: "Blank", Type: "text", IdempotencyKey: " "},
+			wantErr: "idempotency key must not be blank",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := storage.Create(ctx, tt.req)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.wantID(got.ID), "unexpected ID %d", got.ID)
+		})
+	}
+
+	replayed, err := storage.Get(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "First call", replayed.Content)
+
+	var count int
+	require.NoError(t, storage.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM notes").Scan(&count))
+	assert.Equal(t, 2, count)
+
+	t.Run("expired key creates a note", func(t *testing.T) {
+		short := newTestStorage(t, WithIdempotencyWindow(time.Minute))
+		a, err := short.Create(ctx, note.CreateNoteRequest{Title: "A", Content: "A", Type: "text", IdempotencyKey: "k"})
+		require.NoError(t, err)
+		_, err = short.db.ExecContext(ctx, "UPDATE idempotency_keys SET created_at = datetime('now', '-2 minutes')")
+		require.NoError(t, err)
+
+		b, err := short.Create(ctx, note.CreateNoteRequest{Title: "B", Content: "B", Type: "text", IdempotencyKey: "k"})
+		require.NoError(t, err)
+		assert.NotEqual(t, a.ID, b.ID)
+	})
+}
+
+func TestStorage_NotFound(t *testing.T) {
+	storage := newTestStorage(t)
+	ctx := context.Background()
+	title := "Missing"
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{name: "get", call: func() error { _, err := storage.Get(ctx, 99999); return err }},
+		{name: "update", call: func() error {
+			_, err := storage.Update(ctx, 99999, note.UpdateNoteRequest{Title: &title})
+			return err
+		}},
+		{name: "delete", call: func() error { return storage.Delete(ctx, 99999) }},
+		{name: "bookmark", call: func() error { _, err := storage.BookmarkNote(ctx, "session", 99999); return err }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			assert.ErrorIs(t, err, note.ErrNotFound)
+			assert.EqualError(t, err, "note not found: 99999")
+		})
+	}
+}
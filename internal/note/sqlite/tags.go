@@ -0,0 +1,232 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// GetTagCooccurrence returns the tags that appear on the same notes as tag,
+// with the number of notes they share, most frequent first. Ties are broken by
+// tag name. The input tag itself is never returned.
+func (s *Storage) GetTagCooccurrence(ctx context.Context, tag string, limit int) ([]note.TagCount, error) {
+	if strings.TrimSpace(tag) == "" {
+		return nil, fmt.Errorf("tag is required")
+	}
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive, got: %d", limit)
+	}
+
+	// Stored tags are normalized, so the lookup must be too
+	if normalized := s.normalizedTags([]string{tag}); len(normalized) == 1 {
+		tag = normalized[0]
+	}
+
+	query := `
+		WITH note_tags AS (
+			SELECT DISTINCT notes.id AS note_id, t.value AS tag
+			FROM notes, json_each(notes.tags) t
+			WHERE json_valid(notes.tags) AND t.type = 'text'
+		)
+		SELECT other.tag, COUNT(*) AS count
+		FROM note_tags base
+		JOIN note_tags other ON other.note_id = base.note_id
+		WHERE base.tag = ? AND other.tag != base.tag
+		GROUP BY other.tag
+		ORDER BY count DESC, other.tag ASC
+		LIMIT ?
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, tag, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag co-occurrence: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []note.TagCount{}
+	for rows.Next() {
+		var tc note.TagCount
+		if err := rows.Scan(&tc.Tag, &tc.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan tag count: %w", err)
+		}
+		counts = append(counts, tc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return counts, nil
+}
+
+// RenameTag replaces oldTag with newTag on every note that has it, in one
+// transaction, and returns the number of notes changed. A note that already
+// has newTag keeps a single copy, where the first of the two tags was. Other
+// tags keep their order. Both tags are normalized first when normalization is
+// enabled.
+func (s *Storage) RenameTag(ctx context.Context, oldTag, newTag string) (int64, error) {
+	var result int64
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.renameTag(ctx, oldTag, newTag)
+		return err
+	})
+	return result, err
+}
+
+// renameTag makes a single attempt at RenameTag
+func (s *Storage) renameTag(ctx context.Context, oldTag, newTag string) (int64, error) {
+	if strings.TrimSpace(oldTag) == "" || strings.TrimSpace(newTag) == "" {
+		return 0, fmt.Errorf("old and new tag are required")
+	}
+
+	// Stored tags are normalized, so both tags must be too
+	if normalized := s.normalizedTags([]string{oldTag}); len(normalized) == 1 {
+		oldTag = normalized[0]
+	}
+	if normalized := s.normalizedTags([]string{newTag}); len(normalized) == 1 {
+		newTag = normalized[0]
+	}
+	if oldTag == newTag {
+		return 0, fmt.Errorf("new tag must differ from old tag %q", oldTag)
+	}
+
+	renamed, err := s.rewriteTags(ctx, oldTag, func(tags []string) []string {
+		return replaceTag(tags, oldTag, newTag)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	s.logger.Info("renamed tag", "old_tag", oldTag, "new_tag", newTag, "notes", renamed)
+	return renamed, nil
+}
+
+// DeleteTag removes tag from every note that has it, in one transaction, and
+// returns the number of notes changed. A note left without tags keeps an
+// empty tags array. The tag is normalized first when normalization is
+// enabled.
+func (s *Storage) DeleteTag(ctx context.Context, tag string) (int64, error) {
+	var result int64
+	err := s.withRetry(ctx, func() error {
+		var err error
+		result, err = s.deleteTag(ctx, tag)
+		return err
+	})
+	return result, err
+}
+
+// deleteTag makes a single attempt at DeleteTag
+func (s *Storage) deleteTag(ctx context.Context, tag string) (int64, error) {
+	if strings.TrimSpace(tag) == "" {
+		return 0, fmt.Errorf("tag is required")
+	}
+
+	// Stored tags are normalized, so the tag must be too
+	if normalized := s.normalizedTags([]string{tag}); len(normalized) == 1 {
+		tag = normalized[0]
+	}
+
+	deleted, err := s.rewriteTags(ctx, tag, func(tags []string) []string {
+		return removeTag(tags, tag)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	s.logger.Info("deleted tag", "tag", tag, "notes", deleted)
+	return deleted, nil
+}
+
+// rewriteTags replaces the tags of every note that has tag with rewrite's
+// result, in one transaction, and returns the number of notes changed
+func (s *Storage) rewriteTags(ctx context.Context, tag string, rewrite func([]string) []string) (int64, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, tags FROM notes
+		WHERE json_valid(tags) AND EXISTS (SELECT 1 FROM json_each(notes.tags) t WHERE t.value = ?)
+		ORDER BY id
+	`, tag)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find notes with tag: %w", err)
+	}
+
+	rewritten := make(map[int64][]string)
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		var tagsJSON string
+		if err := rows.Scan(&id, &tagsJSON); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan note tags: %w", err)
+		}
+		var noteTags []string
+		if err := json.Unmarshal([]byte(tagsJSON), &noteTags); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to unmarshal tags of note %d: %w", id, err)
+		}
+		rewritten[id] = rewrite(noteTags)
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return 0, fmt.Errorf("error iterating rows: %w", err)
+	}
+	rows.Close()
+
+	for _, id := range ids {
+		tagsJSON, err := json.Marshal(rewritten[id])
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal tags: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE notes SET tags = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?", string(tagsJSON), id); err != nil {
+			return 0, fmt.Errorf("failed to update tags of note %d: %w", id, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return int64(len(ids)), nil
+}
+
+// replaceTag returns tags with every oldTag turned into newTag, keeping only
+// the first newTag
+func replaceTag(tags []string, oldTag, newTag string) []string {
+	result := make([]string, 0, len(tags))
+	seenNew := false
+	for _, tag := range tags {
+		if tag == oldTag {
+			tag = newTag
+		}
+		if tag == newTag {
+			if seenNew {
+				continue
+			}
+			seenNew = true
+		}
+		result = append(result, tag)
+	}
+	return result
+}
+
+// removeTag returns tags without any copy of tag, as an empty rather than a
+// nil slice so it is stored as []
+func removeTag(tags []string, tag string) []string {
+	result := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if t != tag {
+			result = append(result, t)
+		}
+	}
+	return result
+}
@@ -0,0 +1,322 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+func TestStorage_GetTagCooccurrence(t *testing.T) {
+	ctx := context.Background()
+	storage := newTestStorage(t)
+
+	for i, tags := range [][]string{
+		{"go", "concurrency", "testing"},
+		{"go", "concurrency"},
+		{"go", "web", "go"},
+		{"rust", "concurrency"},
+		{"go"},
+		nil,
+	} {
+		_, err := storage.Create(ctx, note.CreateNoteRequest{
+			Title:   fmt.Sprintf("Note %d", i),
+			Content: "Content",
+			Type:    "text",
+			Tags:    tags,
+		})
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		name    string
+		tag     string
+		limit   int
+		want    []note.TagCount
+		wantErr string
+	}{
+		{
+			name:  "ordered by count then tag",
+			tag:   "go",
+			limit: 10,
+			want:  []note.TagCount{{Tag: "concurrency", Count: 2}, {Tag: "testing", Count: 1}, {Tag: "web", Count: 1}},
+		},
+		{
+			name:  "limit",
+			tag:   "go",
+			limit: 2,
+			want:  []note.TagCount{{Tag: "concurrency", Count: 2}, {Tag: "testing", Count: 1}},
+		},
+		{
+			name:  "tag on several vocabularies",
+			tag:   "concurrency",
+			limit: 10,
+			want:  []note.TagCount{{Tag: "go", Count: 2}, {Tag: "rust", Count: 1}, {Tag: "testing", Count: 1}},
+		},
+		{
+			name:  "unknown tag",
+			tag:   "python",
+			limit: 10,
+			want:  []note.TagCount{},
+		},
+		{
+			name:  "matching is exact without normalization",
+			tag:   "GO",
+			limit: 10,
+			want:  []note.TagCount{},
+		},
+		{
+			name:    "empty tag",
+			tag:     " ",
+			limit:   10,
+			wantErr: "tag is required",
+		},
+		{
+			name:    "invalid limit",
+			tag:     "go",
+			limit:   0,
+			wantErr: "limit must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := storage.GetTagCooccurrence(ctx, tt.tag, tt.limit)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("input tag is normalized when normalization is enabled", func(t *testing.T) {
+		normalizing := newTestStorage(t, WithTagNormalization(true))
+		_, err := normalizing.Create(ctx, note.CreateNoteRequest{Title: "Tagged", Content: "Content", Type: "text", Tags: []string{"Go", "Web"}})
+		require.NoError(t, err)
+
+		got, err := normalizing.GetTagCooccurrence(ctx, " GO", 10)
+		require.NoError(t, err)
+		assert.Equal(t, []note.TagCount{{Tag: "web", Count: 1}}, got)
+	})
+}
+
+func TestStorage_RenameTag(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		normalize bool
+		tags      [][]string
+		oldTag    string
+		newTag    string
+		want      int64
+		wantTags  [][]string
+		wantErr   string
+	}{
+		{
+			name:     "renames in place on notes with the tag only",
+			tags:     [][]string{{"golang", "web"}, {"rust"}, {"testing", "golang"}, nil},
+			oldTag:   "golang",
+			newTag:   "go",
+			want:     2,
+			wantTags: [][]string{{"go", "web"}, {"rust"}, {"testing", "go"}, nil},
+		},
+		{
+			name:     "note that already has the new tag keeps one copy",
+			tags:     [][]string{{"go", "web", "golang"}, {"golang", "api", "go"}},
+			oldTag:   "golang",
+			newTag:   "go",
+			want:     2,
+			wantTags: [][]string{{"go", "web"}, {"go", "api"}},
+		},
+		{
+			name:     "unknown tag changes nothing",
+			tags:     [][]string{{"go"}, {"web"}},
+			oldTag:   "python",
+			newTag:   "py",
+			want:     0,
+			wantTags: [][]string{{"go"}, {"web"}},
+		},
+		{
+			name:     "matching is exact without normalization",
+			tags:     [][]string{{"Go"}, {"go"}},
+			oldTag:   "Go",
+			newTag:   "golang",
+			want:     1,
+			wantTags: [][]string{{"golang"}, {"go"}},
+		},
+		{
+			name:      "tags are normalized when normalization is enabled",
+			normalize: true,
+			tags:      [][]string{{"Golang", "Web"}},
+			oldTag:    " GOLANG",
+			newTag:    "Go ",
+			want:      1,
+			wantTags:  [][]string{{"go", "web"}},
+		},
+		{
+			name:     "same tag",
+			tags:     [][]string{{"go"}},
+			oldTag:   "go",
+			newTag:   "go",
+			wantErr:  `new tag must differ from old tag "go"`,
+			wantTags: [][]string{{"go"}},
+		},
+		{
+			name:      "same tag after normalization",
+			normalize: true,
+			tags:      [][]string{{"go"}},
+			oldTag:    "Go",
+			newTag:    "GO",
+			wantErr:   "new tag must differ",
+			wantTags:  [][]string{{"go"}},
+		},
+		{
+			name:     "empty new tag",
+			tags:     [][]string{{"go"}},
+			oldTag:   "go",
+			newTag:   " ",
+			wantErr:  "old and new tag are required",
+			wantTags: [][]string{{"go"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newTestStorage(t, WithTagNormalization(tt.normalize))
+			var ids []int64
+			for i, tags := range tt.tags {
+				n, err := storage.Create(ctx, note.CreateNoteRequest{
+					Title:   fmt.Sprintf("Note %d", i),
+					Content: "Content",
+					Type:    "text",
+					Tags:    tags,
+				})
+				require.NoError(t, err)
+				ids = append(ids, n.ID)
+			}
+
+			got, err := storage.RenameTag(ctx, tt.oldTag, tt.newTag)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+
+			notes, err := storage.GetMany(ctx, ids)
+			require.NoError(t, err)
+			gotTags := make([][]string, len(notes))
+			for i, n := range notes {
+				if len(n.Tags) > 0 {
+					gotTags[i] = n.Tags
+				}
+			}
+			assert.Equal(t, tt.wantTags, gotTags)
+		})
+	}
+}
+
+func TestStorage_DeleteTag(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		normalize bool
+		tags      [][]string
+		tag       string
+		want      int64
+		wantJSON  []string
+		wantErr   string
+	}{
+		{
+			name:     "removed from notes with the tag only",
+			tags:     [][]string{{"go", "obsolete", "web"}, {"rust"}, {"obsolete", "go", "obsolete"}, nil},
+			tag:      "obsolete",
+			want:     2,
+			wantJSON: []string{`["go","web"]`, `["rust"]`, `["go"]`, `[]`},
+		},
+		{
+			name:     "only tag leaves an empty array",
+			tags:     [][]string{{"obsolete"}},
+			tag:      "obsolete",
+			want:     1,
+			wantJSON: []string{`[]`},
+		},
+		{
+			name:     "unknown tag changes nothing",
+			tags:     [][]string{{"go"}},
+			tag:      "python",
+			want:     0,
+			wantJSON: []string{`["go"]`},
+		},
+		{
+			name:     "matching is exact without normalization",
+			tags:     [][]string{{"Go"}, {"go"}},
+			tag:      "go",
+			want:     1,
+			wantJSON: []string{`["Go"]`, `[]`},
+		},
+		{
+			name:      "tag is normalized when normalization is enabled",
+			normalize: true,
+			tags:      [][]string{{"Go", "Web"}},
+			tag:       " GO",
+			want:      1,
+			wantJSON:  []string{`["web"]`},
+		},
+		{
+			name:     "empty tag",
+			tags:     [][]string{{"go"}},
+			tag:      " ",
+			wantErr:  "tag is required",
+			wantJSON: []string{`["go"]`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newTestStorage(t, WithTagNormalization(tt.normalize))
+			var created []*note.Note
+			for i, tags := range tt.tags {
+				n, err := storage.Create(ctx, note.CreateNoteRequest{
+					Title:   fmt.Sprintf("Note %d", i),
+					Content: fmt.Sprintf("Content %d", i),
+					Type:    "text",
+					Tags:    tags,
+				})
+				require.NoError(t, err)
+				created = append(created, n)
+			}
+
+			got, err := storage.DeleteTag(ctx, tt.tag)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+
+			for i, n := range created {
+				var tagsJSON string
+				require.NoError(t, storage.db.QueryRowContext(ctx, "SELECT tags FROM notes WHERE id = ?", n.ID).Scan(&tagsJSON))
+				assert.Equal(t, tt.wantJSON[i], tagsJSON)
+
+				// Nothing but the tags changes
+				after, err := storage.Get(ctx, n.ID)
+				require.NoError(t, err)
+				assert.Equal(t, n.Title, after.Title)
+				assert.Equal(t, n.Content, after.Content)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+// timelinePeriods maps each granularity to the SQLite expression for the
+// first day of the period containing created_at. The date functions read any
+// UTC offset in the stored timestamp and return a UTC date, so notes are
+// bucketed by UTC calendar whatever the offset they were stored with. Weeks
+// start on Monday: 'weekday 0' moves forward to Sunday, then back six days.
+var timelinePeriods = map[string]string{
+	note.GranularityDay:   "date(created_at)",
+	note.GranularityWeek:  "date(created_at, 'weekday 0', '-6 days')",
+	note.GranularityMonth: "date(created_at, 'start of month')",
+}
+
+// maxTimelineBuckets caps the periods of a timeline, zero-filled ones
+// included, so one note with a far-off created_at cannot make the gap filling
+// allocate millions of buckets. It is over 13 years of days.
+const maxTimelineBuckets = 5000
+
+// GetCreationTimeline counts the notes created in each day, week or month,
+// oldest first. Periods between the first and last note with no notes are
+// included with a zero count, so the result can be charted as is. A non-nil
+// since leaves out the notes created before it. An empty database gives an
+// empty timeline, and one spanning more than maxTimelineBuckets periods is an
+// error.
+func (s *Storage) GetCreationTimeline(ctx context.Context, granularity string, since *time.Time) ([]note.TimeBucket, error) {
+	period, ok := timelinePeriods[granularity]
+	if !ok {
+		return nil, fmt.Errorf("invalid granularity %q, must be one of %v", granularity, note.ValidGranularities())
+	}
+
+	conditions := []string{period + " IS NOT NULL"}
+	var args []interface{}
+	if since != nil {
+		// datetime() reads any UTC offset, so this compares UTC times
+		conditions = append(conditions, "datetime(created_at) >= ?")
+		args = append(args, since.UTC().Format(time.DateTime))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s AS period, COUNT(*)
+		FROM notes
+		WHERE %s
+		GROUP BY period
+		ORDER BY period
+	`, period, strings.Join(conditions, " AND "))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get creation timeline: %w", err)
+	}
+	defer rows.Close()
+
+	timeline := []note.TimeBucket{}
+	for rows.Next() {
+		var start string
+		var count int64
+		if err := rows.Scan(&start, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan time bucket: %w", err)
+		}
+		startTime, err := time.Parse(time.DateOnly, start)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse period start %q: %w", start, err)
+		}
+
+		// Fill the periods without notes since the previous bucket
+		if len(timeline) > 0 {
+			for next := nextPeriod(timeline[len(timeline)-1].Start, granularity); next.Before(startTime); next = nextPeriod(next, granularity) {
+				if len(timeline) == maxTimelineBuckets {
+					return nil, timelineTooLong(granularity)
+				}
+				timeline = append(timeline, note.TimeBucket{Start: next})
+			}
+		}
+		if len(timeline) == maxTimelineBuckets {
+			return nil, timelineTooLong(granularity)
+		}
+		timeline = append(timeline, note.TimeBucket{Start: startTime, Count: count})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return timeline, nil
+}
+
+// timelineTooLong is the error for a timeline over maxTimelineBuckets periods
+func timelineTooLong(granularity string) error {
+	return fmt.Errorf("timeline spans more than %d %s periods, use a later since or a coarser granularity", maxTimelineBuckets, granularity)
+}
+
+// nextPeriod returns the start of the period after the one starting at start
+func nextPeriod(start time.Time, granularity string) time.Time {
+	switch granularity {
+	case note.GranularityWeek:
+		return start.AddDate(0, 0, 7)
+	case note.GranularityMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
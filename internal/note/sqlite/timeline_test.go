@@ -0,0 +1,192 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+func TestStorage_GetCreationTimeline(t *testing.T) {
+	ctx := context.Background()
+
+	day := func(value string) time.Time {
+		d, err := time.Parse(time.DateOnly, value)
+		require.NoError(t, err)
+		return d
+	}
+
+	tests := []struct {
+		name        string
+		createdAt   []string
+		granularity string
+		since       *time.Time
+		want        []note.TimeBucket
+		wantLen     int // checked instead of want when set
+		wantErr     string
+	}{
+		{
+			name: "days around midnight with offsets and gaps",
+			createdAt: []string{
+				"2024-02-29 23:59:59",
+				"2024-03-01 00:00:00",
+				"2024-03-01T01:30:00+02:00", // 2024-02-29 23:30 UTC
+				"2024-03-03 10:00:00",
+			},
+			granularity: "day",
+			want: []note.TimeBucket{
+				{Start: day("2024-02-29"), Count: 2},
+				{Start: day("2024-03-01"), Count: 1},
+				{Start: day("2024-03-02"), Count: 0},
+				{Start: day("2024-03-03"), Count: 1},
+			},
+		},
+		{
+			name: "weeks start on monday across a year boundary",
+			createdAt: []string{
+				"2024-12-29 23:59:59", // Sunday
+				"2024-12-30 00:00:00", // Monday
+				"2025-01-01 09:00:00",
+				"2025-01-05 23:59:59",
+				"2025-01-06T00:30:00+01:00", // 2025-01-05 23:30 UTC
+				"2025-01-20 08:00:00",
+			},
+			granularity: "week",
+			want: []note.TimeBucket{
+				{Start: day("2024-12-23"), Count: 1},
+				{Start: day("2024-12-30"), Count: 4},
+				{Start: day("2025-01-06"), Count: 0},
+				{Start: day("2025-01-13"), Count: 0},
+				{Start: day("2025-01-20"), Count: 1},
+			},
+		},
+		{
+			name: "months in utc",
+			createdAt: []string{
+				"2024-01-31 23:59:59",
+				"2024-02-01T00:30:00+01:00", // 2024-01-31 23:30 UTC
+				"2024-02-15 12:00:00",
+				"2024-03-31T23:30:00-02:00", // 2024-04-01 01:30 UTC
+			},
+			granularity: "month",
+			want: []note.TimeBucket{
+				{Start: day("2024-01-01"), Count: 2},
+				{Start: day("2024-02-01"), Count: 1},
+				{Start: day("2024-03-01"), Count: 0},
+				{Start: day("2024-04-01"), Count: 1},
+			},
+		},
+		{
+			name: "since leaves out older notes in utc",
+			createdAt: []string{
+				"2024-03-01 09:59:59",
+				"2024-03-01T11:30:00+02:00", // 2024-03-01 09:30 UTC
+				"2024-03-01 10:00:00",
+				"2024-03-01T09:00:00-02:00", // 2024-03-01 11:00 UTC
+				"2024-03-03 10:00:00",
+			},
+			granularity: "day",
+			since:       timePtr(time.Date(2024, 3, 1, 12, 0, 0, 0, time.FixedZone("", 2*60*60))), // 10:00 UTC
+			want: []note.TimeBucket{
+				{Start: day("2024-03-01"), Count: 2},
+				{Start: day("2024-03-02"), Count: 0},
+				{Start: day("2024-03-03"), Count: 1},
+			},
+		},
+		{
+			name:        "since after every note",
+			createdAt:   []string{"2024-03-01 10:00:00"},
+			granularity: "day",
+			since:       timePtr(time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)),
+			want:        []note.TimeBucket{},
+		},
+		{
+			name:        "longest timeline",
+			createdAt:   []string{"2000-01-01 00:00:00", "2013-09-08 12:00:00"}, // 4999 days later
+			granularity: "day",
+			wantLen:     maxTimelineBuckets,
+		},
+		{
+			name:        "timeline over the bucket limit",
+			createdAt:   []string{"2000-01-01 00:00:00", "2013-09-09 12:00:00"},
+			granularity: "day",
+			wantErr:     "timeline spans more than 5000 day periods, use a later since or a coarser granularity",
+		},
+		{
+			name:        "far-off note",
+			createdAt:   []string{"0001-01-01 00:00:00", "2024-03-01 10:00:00"},
+			granularity: "week",
+			wantErr:     "timeline spans more than 5000 week periods",
+		},
+		{
+			name:        "far-off note left out by since",
+			createdAt:   []string{"0001-01-01 00:00:00", "2024-03-01 10:00:00"},
+			granularity: "week",
+			since:       timePtr(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			want:        []note.TimeBucket{{Start: day("2024-02-26"), Count: 1}},
+		},
+		{
+			name:        "no notes",
+			granularity: "month",
+			want:        []note.TimeBucket{},
+		},
+		{
+			name:        "invalid granularity",
+			granularity: "year",
+			wantErr:     `invalid granularity "year", must be one of [day week month]`,
+		},
+		{
+			name:        "granularity is case sensitive",
+			granularity: "Day",
+			wantErr:     "invalid granularity",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newTestStorage(t)
+			for i, createdAt := range tt.createdAt {
+				n, err := storage.Create(ctx, note.CreateNoteRequest{Title: fmt.Sprintf("Note %d", i), Content: "Content", Type: "text"})
+				require.NoError(t, err)
+				_, err = storage.db.ExecContext(ctx, "UPDATE notes SET created_at = ? WHERE id = ?", createdAt, n.ID)
+				require.NoError(t, err)
+			}
+
+			timeline, err := storage.GetCreationTimeline(ctx, tt.granularity, tt.since)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantLen > 0 {
+				assert.Len(t, timeline, tt.wantLen)
+				return
+			}
+			assert.Equal(t, tt.want, timeline)
+		})
+	}
+
+	t.Run("notes created now land in today's bucket", func(t *testing.T) {
+		storage := newTestStorage(t)
+		before := time.Now().UTC().Format(time.DateOnly)
+		_, err := storage.Create(ctx, note.CreateNoteRequest{Title: "Today", Content: "Content", Type: "text"})
+		require.NoError(t, err)
+		after := time.Now().UTC().Format(time.DateOnly)
+
+		timeline, err := storage.GetCreationTimeline(ctx, "day", nil)
+		require.NoError(t, err)
+		require.Len(t, timeline, 1)
+		assert.Contains(t, []string{before, after}, timeline[0].Start.Format(time.DateOnly))
+		assert.Equal(t, int64(1), timeline[0].Count)
+	})
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
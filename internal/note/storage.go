@@ -2,6 +2,7 @@ package note
 
 import (
 	"context"
+	"time"
 )
 
 //go:generate mockgen -source=storage.go -destination=mock/storage.go -package=mock
@@ -17,9 +18,70 @@ type Storage interface {
 	// Update updates an existing note
 	Update(ctx context.Context, id int64, req UpdateNoteRequest) (*Note, error)
 	
+	// AppendContent appends text to a note's content after separator in one atomic write
+	AppendContent(ctx context.Context, noteID int64, text string, separator string) (*Note, error)
+	
 	// Delete deletes a note by ID
 	Delete(ctx context.Context, id int64) error
 	
+	// DeleteMany deletes the notes with the given IDs in one transaction,
+	// reporting the connections deleted with each and the IDs that did not exist
+	DeleteMany(ctx context.Context, ids []int64) (*DeleteResult, error)
+	
+	// PreviewDelete reports the connections and neighbors deleting a note would affect, without deleting it
+	PreviewDelete(ctx context.Context, noteID int64) (*DeletePreview, error)
+	
+	// GetMany retrieves the notes with the given IDs in the requested order, omitting unknown IDs
+	GetMany(ctx context.Context, ids []int64) ([]Note, error)
+	
+	// GetByTitle retrieves the notes whose title matches, optionally ignoring case
+	GetByTitle(ctx context.Context, title string, caseInsensitive bool) ([]Note, error)
+	
+	// FindNotesByContentHash retrieves the notes whose normalized content has the given hash
+	FindNotesByContentHash(ctx context.Context, hash string) ([]Note, error)
+	
 	// List lists notes with pagination and filtering
 	List(ctx context.Context, req ListNotesRequest) (*ListNotesResponse, error)
+
+	// Count counts the notes List would find, without fetching them
+	Count(ctx context.Context, req ListNotesRequest) (int64, error)
+
+	// FindUnassignedNotes lists notes that do not belong to any knowledge base
+	FindUnassignedNotes(ctx context.Context, req ListNotesRequest) (*ListNotesResponse, error)
+
+	// AssignNotesToKnowledgeBase links notes to a knowledge base, erroring on unknown note IDs when strict
+	AssignNotesToKnowledgeBase(ctx context.Context, kbID int64, noteIDs []int64, strict bool) (int64, error)
+
+	// RebuildSearchIndex rebuilds the full-text search index from the notes table
+	RebuildSearchIndex(ctx context.Context) (*SearchIndexStats, error)
+
+	// RecomputeConnectionCounts recounts every note's incoming and outgoing connections, returning the notes corrected
+	RecomputeConnectionCounts(ctx context.Context) (int64, error)
+
+	// ExportNotesToDir writes each note matching req to a markdown file in dir, returning the number written
+	ExportNotesToDir(ctx context.Context, dir string, req ListNotesRequest) (int, error)
+
+	// ImportNotesFromDir creates notes from the markdown files in dir, reporting files that fail unless strict
+	ImportNotesFromDir(ctx context.Context, dir string, strict bool) (*ImportResult, error)
+
+	// GetCreationTimeline counts the notes created in each day, week or month, from since when it is set
+	GetCreationTimeline(ctx context.Context, granularity string, since *time.Time) ([]TimeBucket, error)
+
+	// GetTagCooccurrence returns the tags most often found on the same notes as tag
+	GetTagCooccurrence(ctx context.Context, tag string, limit int) ([]TagCount, error)
+
+	// RenameTag replaces a tag on every note that has it, returning the number of notes changed
+	RenameTag(ctx context.Context, oldTag, newTag string) (int64, error)
+
+	// DeleteTag removes a tag from every note that has it, returning the number of notes changed
+	DeleteTag(ctx context.Context, tag string) (int64, error)
+
+	// BookmarkNote bookmarks a note in a session, reporting false when it already was
+	BookmarkNote(ctx context.Context, session string, noteID int64) (bool, error)
+
+	// UnbookmarkNote removes a note's bookmark in a session, reporting false when it had none
+	UnbookmarkNote(ctx context.Context, session string, noteID int64) (bool, error)
+
+	// ListBookmarks lists the notes bookmarked in a session, most recently bookmarked first
+	ListBookmarks(ctx context.Context, session string) ([]Bookmark, error)
 }
\ No newline at end of file
@@ -0,0 +1,65 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/search"
+)
+
+// NewSearchAllHandler creates a new handler for searching every entity type at once
+func NewSearchAllHandler(searcher *search.Searcher) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		arguments, ok := req.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments format")
+		}
+
+		// Parse query
+		query, ok := arguments["query"].(string)
+		if !ok || strings.TrimSpace(query) == "" {
+			return nil, fmt.Errorf("query is required")
+		}
+
+		// Parse optional limit
+		limit := search.DefaultLimit
+		if raw, ok := arguments["limit"]; ok {
+			value, err := mcpx.ParseInt(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid limit: %w", err)
+			}
+			if value < 1 || value > search.MaxLimit {
+				return nil, fmt.Errorf("limit must be between 1 and %d", search.MaxLimit)
+			}
+			limit = value
+		}
+
+		results, err := searcher.SearchAll(ctx, query, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search: %w", err)
+		}
+
+		jsonData, err := mcpx.MarshalJSON(results)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		summary := fmt.Sprintf("Found %d knowledge bases, %d notes and %d connections matching %q (showing %d)",
+			results.Totals[search.EntityKnowledgeBase], results.Totals[search.EntityNote],
+			results.Totals[search.EntityConnection], query, len(results.Results))
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{
+					Type: "text",
+					Text: fmt.Sprintf("%s\n\n%s", summary, string(jsonData)),
+				},
+			},
+		}, nil
+	}
+}
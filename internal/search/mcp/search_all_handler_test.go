@@ -0,0 +1,133 @@
+package mcp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gomcp "github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	connmock "github.com/red1r3ct/knowledge-graph-mcp/internal/connection/mock"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase"
+	kbmock "github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase/mock"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	notemock "github.com/red1r3ct/knowledge-graph-mcp/internal/note/mock"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/search"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/search/mcp"
+)
+
+func TestSearchAllHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	kbStorage := kbmock.NewMockStorage(ctrl)
+	noteStorage := notemock.NewMockStorage(ctrl)
+	connStorage := connmock.NewMockStorage(ctrl)
+	handler := mcp.NewSearchAllHandler(search.New(kbStorage, noteStorage, connStorage))
+
+	description := "Explains the graph layout"
+	expectSearch := func(candidates int) {
+		kbStorage.EXPECT().
+			List(gomock.Any(), knowledgebase.ListRequest{Limit: candidates, Search: "graph"}).
+			Return(&knowledgebase.ListResponse{Items: []knowledgebase.KnowledgeBase{{ID: 1, Name: "Graphs"}}, Total: 1}, nil)
+		noteStorage.EXPECT().
+			List(gomock.Any(), note.ListNotesRequest{Limit: candidates, Search: `"graph"*`}).
+			Return(&note.ListNotesResponse{Items: []note.Note{{ID: 2, Title: "Layout", Content: "A graph drawing"}}, Total: 4}, nil)
+		connStorage.EXPECT().
+			List(gomock.Any(), gomock.Any()).
+			Return(&connection.ListConnectionsResponse{Items: []connection.Connection{{ID: 3, FromNoteID: 2, ToNoteID: 5, Type: "supports", Description: &description}}, Total: 1}, nil)
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		mockSetup   func()
+		wantErr     bool
+		wantContent []string
+	}{
+		{
+			name:      "successful search",
+			args:      map[string]interface{}{"query": "graph"},
+			mockSetup: func() { expectSearch(30) },
+			wantContent: []string{
+				`Found 1 knowledge bases, 4 notes and 1 connections matching "graph" (showing 3)`,
+				`"type": "knowledge_base"`,
+				`"title": "note 2 supports note 5"`,
+				`"snippet": "A graph drawing"`,
+			},
+		},
+		{
+			name:        "with limit",
+			args:        map[string]interface{}{"query": "graph", "limit": float64(1)},
+			mockSetup:   func() { expectSearch(3) },
+			wantContent: []string{"(showing 1)"},
+		},
+		{
+			name:        "missing query",
+			args:        map[string]interface{}{},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: []string{"query is required"},
+		},
+		{
+			name:        "blank query",
+			args:        map[string]interface{}{"query": "  "},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: []string{"query is required"},
+		},
+		{
+			name:        "limit out of range",
+			args:        map[string]interface{}{"query": "graph", "limit": float64(101)},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: []string{"limit must be between 1 and 100"},
+		},
+		{
+			name:        "fractional limit",
+			args:        map[string]interface{}{"query": "graph", "limit": 2.5},
+			mockSetup:   func() {},
+			wantErr:     true,
+			wantContent: []string{"invalid limit: 2.5 is not an integer"},
+		},
+		{
+			name: "storage error",
+			args: map[string]interface{}{"query": "graph"},
+			mockSetup: func() {
+				kbStorage.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+			},
+			wantErr:     true,
+			wantContent: []string{"failed to search: failed to search knowledge bases"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			req := gomcp.CallToolRequest{
+				Params: gomcp.CallToolParams{
+					Arguments: tt.args,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				for _, want := range tt.wantContent {
+					assert.Contains(t, err.Error(), want)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				for _, want := range tt.wantContent {
+					assert.Contains(t, result.Content[0].(gomcp.TextContent).Text, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package mcp
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/mcpx"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/search"
+)
+
+// RegisterTools registers the cross-entity search MCP tools with the server
+func RegisterTools(s *server.MCPServer, searcher *search.Searcher, middleware ...mcpx.Middleware) error {
+	tools := []struct {
+		name        string
+		description string
+		handler     server.ToolHandlerFunc
+		schema      mcp.ToolInputSchema
+	}{
+		{
+			name:        "search_all",
+			description: "Search knowledge bases, notes and connection descriptions at once and return one list ranked by relevance, each result tagged with its type and ID. The number of results of each type is capped so no type crowds out the others",
+			handler:     NewSearchAllHandler(searcher),
+			schema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: map[string]interface{}{
+					"query": map[string]interface{}{
+						"type":        "string",
+						"description": "Words to search for; every word must match the start of a word",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum number of results across all types (default: 20)",
+						"minimum":     1,
+						"maximum":     search.MaxLimit,
+					},
+				},
+				Required: []string{"query"},
+			},
+		},
+	}
+
+	for _, tool := range tools {
+		t := mcp.Tool{
+			Name:        tool.name,
+			Description: tool.description,
+			InputSchema: tool.schema,
+		}
+		s.AddTool(t, mcpx.Wrap(tool.name, tool.handler, middleware...))
+	}
+
+	return nil
+}
@@ -0,0 +1,319 @@
+// Package search finds knowledge bases, notes and connections matching one
+// query, so a client has a single entry point to locate anything in the graph.
+//
+// Each storage is searched with its own full-text search: knowledge bases by
+// name and description, notes by title and content, and connections by
+// description. The matches are then scored against the query in the same way,
+// whatever their type, and merged into one ranked list.
+package search
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+)
+
+const (
+	// DefaultLimit is the number of results returned when no limit is given
+	DefaultLimit = 20
+	// MaxLimit caps the number of results returned
+	MaxLimit = 100
+	// DefaultPerTypeLimit caps the results of each entity type when none is configured
+	DefaultPerTypeLimit = 10
+
+	// candidateFactor is how many more matches than the per-type cap each
+	// storage is asked for, so scoring can pick the best of a larger pool
+	candidateFactor = 3
+	// maxCandidates caps the matches read from each storage
+	maxCandidates = 100
+	// snippetLength is the length of a result snippet in runes
+	snippetLength = 160
+)
+
+// EntityType names the kind of entity a result refers to
+type EntityType string
+
+const (
+	EntityKnowledgeBase EntityType = "knowledge_base"
+	EntityNote          EntityType = "note"
+	EntityConnection    EntityType = "connection"
+)
+
+// Result is one matching entity
+type Result struct {
+	Type    EntityType `json:"type"`
+	ID      int64      `json:"id"`
+	Title   string     `json:"title"`             // Knowledge base name, note title or a description of the connection
+	Snippet string     `json:"snippet,omitempty"` // Text around the first match in the description or content
+	Score   float64    `json:"score"`             // Relevance to the query; higher is better, comparable across types
+}
+
+// SearchResults holds the ranked results of a search
+type SearchResults struct {
+	Query   string               `json:"query"`
+	Results []Result             `json:"results"`
+	Totals  map[EntityType]int64 `json:"totals"` // Matches of each type before any cap was applied
+}
+
+// KnowledgeBases lists knowledge bases; knowledgebase.Storage implements it
+type KnowledgeBases interface {
+	List(ctx context.Context, req knowledgebase.ListRequest) (*knowledgebase.ListResponse, error)
+}
+
+// Notes lists notes; note.Storage implements it
+type Notes interface {
+	List(ctx context.Context, req note.ListNotesRequest) (*note.ListNotesResponse, error)
+}
+
+// Connections lists connections; connection.Storage implements it
+type Connections interface {
+	List(ctx context.Context, req connection.ListConnectionsRequest) (*connection.ListConnectionsResponse, error)
+}
+
+// Searcher searches the three storages together
+type Searcher struct {
+	knowledgeBases KnowledgeBases
+	notes          Notes
+	connections    Connections
+	perTypeLimit   int
+}
+
+// Option configures a Searcher
+type Option func(*Searcher)
+
+// WithPerTypeLimit caps the results of each entity type. Values below 1 are
+// ignored.
+func WithPerTypeLimit(limit int) Option {
+	return func(s *Searcher) {
+		if limit > 0 {
+			s.perTypeLimit = limit
+		}
+	}
+}
+
+// New creates a Searcher over the given storages
+func New(knowledgeBases KnowledgeBases, notes Notes, connections Connections, opts ...Option) *Searcher {
+	s := &Searcher{
+		knowledgeBases: knowledgeBases,
+		notes:          notes,
+		connections:    connections,
+		perTypeLimit:   DefaultPerTypeLimit,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// SearchAll returns up to limit entities of any type matching every word of
+// query, best first. Each type contributes at most the per-type limit. A zero
+// limit means DefaultLimit and larger limits are capped at MaxLimit. Equal
+// scores keep knowledge bases first, then notes, then connections, each in
+// the order their storage returned them.
+func (s *Searcher) SearchAll(ctx context.Context, query string, limit int) (*SearchResults, error) {
+	words := textWords(query)
+	if len(words) == 0 {
+		return nil, fmt.Errorf("query is required")
+	}
+	if limit < 0 {
+		return nil, fmt.Errorf("limit must not be negative")
+	}
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	perType := min(s.perTypeLimit, limit)
+	candidates := min(perType*candidateFactor, maxCandidates)
+	results := &SearchResults{Query: query, Totals: map[EntityType]int64{}}
+
+	kbs, err := s.knowledgeBases.List(ctx, knowledgebase.ListRequest{Limit: candidates, Search: query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search knowledge bases: %w", err)
+	}
+	results.Totals[EntityKnowledgeBase] = kbs.Total
+	var kbResults []Result
+	for _, kb := range kbs.Items {
+		description := ""
+		if kb.Description != nil {
+			description = *kb.Description
+		}
+		kbResults = append(kbResults, Result{
+			Type:    EntityKnowledgeBase,
+			ID:      kb.ID,
+			Title:   kb.Name,
+			Snippet: snippet(description, words),
+			Score:   score(kb.Name, description, words),
+		})
+	}
+
+	// Note search takes FTS5 syntax, so the words are quoted first
+	notes, err := s.notes.List(ctx, note.ListNotesRequest{Limit: candidates, Search: ftsQuery(words)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search notes: %w", err)
+	}
+	results.Totals[EntityNote] = notes.Total
+	var noteResults []Result
+	for _, n := range notes.Items {
+		noteResults = append(noteResults, Result{
+			Type:    EntityNote,
+			ID:      n.ID,
+			Title:   n.Title,
+			Snippet: snippet(n.Content, words),
+			Score:   score(n.Title, n.Content, words),
+		})
+	}
+
+	conns, err := s.connections.List(ctx, connection.ListConnectionsRequest{Limit: candidates, DescriptionSearch: &query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search connections: %w", err)
+	}
+	results.Totals[EntityConnection] = conns.Total
+	var connResults []Result
+	for _, conn := range conns.Items {
+		description := ""
+		if conn.Description != nil {
+			description = *conn.Description
+		}
+		// A connection has no title of its own, so its description is scored as one
+		connResults = append(connResults, Result{
+			Type:    EntityConnection,
+			ID:      conn.ID,
+			Title:   fmt.Sprintf("note %d %s note %d", conn.FromNoteID, conn.Type, conn.ToNoteID),
+			Snippet: snippet(description, words),
+			Score:   score(description, "", words),
+		})
+	}
+
+	results.Results = []Result{}
+	for _, typeResults := range [][]Result{kbResults, noteResults, connResults} {
+		results.Results = append(results.Results, best(typeResults, perType)...)
+	}
+	results.Results = best(results.Results, limit)
+	return results, nil
+}
+
+// best returns the n highest scoring results. Ties keep their order in
+// results, which lists the types in order and each type in storage order.
+func best(results []Result, n int) []Result {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	if len(results) > n {
+		results = results[:n]
+	}
+	return results
+}
+
+// score rates how well a title and body match the query words, from 0 to 6.
+// Each word scores 3 when a word of the title starts with it and 1 when a
+// word of the body does, averaged over the query words, and a title holding
+// the whole query as a phrase scores 2 more.
+func score(title, body string, words []string) float64 {
+	titleWords := textWords(title)
+	bodyWords := textWords(body)
+
+	total := 0.0
+	for _, word := range words {
+		if hasPrefixWord(titleWords, word) {
+			total += 3
+		}
+		if hasPrefixWord(bodyWords, word) {
+			total++
+		}
+	}
+	total /= float64(len(words))
+
+	if len(words) > 1 && strings.Contains(strings.Join(titleWords, " "), strings.Join(words, " ")) {
+		total += 2
+	}
+	return total
+}
+
+// hasPrefixWord reports whether any of words starts with prefix
+func hasPrefixWord(words []string, prefix string) bool {
+	for _, word := range words {
+		if strings.HasPrefix(word, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// textWords splits text into lowercase words of letters and digits, the way
+// the full-text indexes tokenize it
+func textWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !isWordRune(r) })
+}
+
+// ftsQuery turns query words into an FTS5 query that matches every word as a
+// prefix. Words are quoted so they cannot be read as FTS5 syntax.
+func ftsQuery(words []string) string {
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = `"` + word + `"*`
+	}
+	return strings.Join(terms, " ")
+}
+
+// snippet returns up to snippetLength runes of text starting shortly before
+// the first word that begins with a query word, marking cut ends with "…".
+// Text without such a word is cut from the start.
+func snippet(text string, words []string) string {
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	if len(runes) <= snippetLength {
+		return string(runes)
+	}
+
+	start := 0
+	if at := firstMatch(runes, words); at > 0 {
+		start = max(at-snippetLength/4, 0)
+	}
+	end := min(start+snippetLength, len(runes))
+	start = max(end-snippetLength, 0)
+
+	out := string(runes[start:end])
+	if start > 0 {
+		out = "…" + out
+	}
+	if end < len(runes) {
+		out += "…"
+	}
+	return out
+}
+
+// firstMatch returns the rune index in text of the first word that starts
+// with one of words, or -1
+func firstMatch(text []rune, words []string) int {
+	for i := 0; i < len(text); {
+		if !isWordRune(text[i]) {
+			i++
+			continue
+		}
+		end := i
+		for end < len(text) && isWordRune(text[end]) {
+			end++
+		}
+		word := strings.ToLower(string(text[i:end]))
+		for _, prefix := range words {
+			if strings.HasPrefix(word, prefix) {
+				return i
+			}
+		}
+		i = end
+	}
+	return -1
+}
+
+// isWordRune reports whether r is part of a word
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
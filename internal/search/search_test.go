@@ -0,0 +1,282 @@
+package search_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/connection"
+	connstorage "github.com/red1r3ct/knowledge-graph-mcp/internal/connection/sqlite"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase"
+	kbstorage "github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase/sqlite"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/migrations"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/note"
+	notestorage "github.com/red1r3ct/knowledge-graph-mcp/internal/note/sqlite"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/search"
+)
+
+// fakeKnowledgeBases returns fixed knowledge bases and records the requests
+type fakeKnowledgeBases struct {
+	items    []knowledgebase.KnowledgeBase
+	err      error
+	requests []knowledgebase.ListRequest
+}
+
+func (f *fakeKnowledgeBases) List(_ context.Context, req knowledgebase.ListRequest) (*knowledgebase.ListResponse, error) {
+	f.requests = append(f.requests, req)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &knowledgebase.ListResponse{Items: f.items, Total: int64(len(f.items))}, nil
+}
+
+// fakeNotes returns fixed notes and records the requests
+type fakeNotes struct {
+	items    []note.Note
+	err      error
+	requests []note.ListNotesRequest
+}
+
+func (f *fakeNotes) List(_ context.Context, req note.ListNotesRequest) (*note.ListNotesResponse, error) {
+	f.requests = append(f.requests, req)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &note.ListNotesResponse{Items: f.items, Total: int64(len(f.items))}, nil
+}
+
+// fakeConnections returns fixed connections and records the requests
+type fakeConnections struct {
+	items    []connection.Connection
+	err      error
+	requests []connection.ListConnectionsRequest
+}
+
+func (f *fakeConnections) List(_ context.Context, req connection.ListConnectionsRequest) (*connection.ListConnectionsResponse, error) {
+	f.requests = append(f.requests, req)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &connection.ListConnectionsResponse{Items: f.items, Total: int64(len(f.items))}, nil
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+// resultKeys renders results as "type:id" for compact comparison
+func resultKeys(results []search.Result) []string {
+	keys := []string{}
+	for _, r := range results {
+		keys = append(keys, key(r.Type, r.ID))
+	}
+	return keys
+}
+
+func key(entityType search.EntityType, id int64) string {
+	return fmt.Sprintf("%s:%d", entityType, id)
+}
+
+func TestSearcher_SearchAll(t *testing.T) {
+	ctx := context.Background()
+
+	kbs := []knowledgebase.KnowledgeBase{
+		{ID: 1, Name: "Graph theory", Description: strPtr("Papers on graphs")},
+		{ID: 2, Name: "Recipes", Description: strPtr("Dishes that use a graph of flavours")},
+	}
+	notes := []note.Note{
+		{ID: 10, Title: "Weekly review", Content: "Mentions graph theory once"},
+		{ID: 11, Title: "Graph theory basics", Content: "Vertices and edges"},
+	}
+	conns := []connection.Connection{
+		{ID: 20, FromNoteID: 10, ToNoteID: 11, Type: "references", Description: strPtr("Builds on graph theory")},
+	}
+
+	tests := []struct {
+		name         string
+		query        string
+		limit        int
+		opts         []search.Option
+		wantKeys     []string
+		wantErr      string
+		wantRequests int
+	}{
+		{
+			name:         "ranked across types",
+			query:        "graph theory",
+			wantKeys:     []string{"knowledge_base:1", "note:11", "connection:20", "note:10", "knowledge_base:2"},
+			wantRequests: 30,
+		},
+		{
+			name:         "overall limit",
+			query:        "graph theory",
+			limit:        2,
+			wantKeys:     []string{"knowledge_base:1", "note:11"},
+			wantRequests: 6,
+		},
+		{
+			name:         "per type limit",
+			query:        "graph theory",
+			opts:         []search.Option{search.WithPerTypeLimit(1)},
+			wantKeys:     []string{"knowledge_base:1", "note:11", "connection:20"},
+			wantRequests: 3,
+		},
+		{
+			name:         "limit above the maximum is capped",
+			query:        "graph",
+			limit:        search.MaxLimit + 50,
+			wantKeys:     []string{"knowledge_base:1", "note:11", "connection:20", "knowledge_base:2", "note:10"},
+			wantRequests: 30,
+		},
+		{
+			name:    "empty query",
+			query:   "  ",
+			wantErr: "query is required",
+		},
+		{
+			name:    "punctuation only",
+			query:   "?!",
+			wantErr: "query is required",
+		},
+		{
+			name:    "negative limit",
+			query:   "graph",
+			limit:   -1,
+			wantErr: "limit must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kbFake := &fakeKnowledgeBases{items: kbs}
+			noteFake := &fakeNotes{items: notes}
+			connFake := &fakeConnections{items: conns}
+			searcher := search.New(kbFake, noteFake, connFake, tt.opts...)
+
+			results, err := searcher.SearchAll(ctx, tt.query, tt.limit)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Empty(t, kbFake.requests)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantKeys, resultKeys(results.Results))
+			assert.Equal(t, map[search.EntityType]int64{
+				search.EntityKnowledgeBase: 2,
+				search.EntityNote:          2,
+				search.EntityConnection:    1,
+			}, results.Totals)
+
+			require.Len(t, kbFake.requests, 1)
+			assert.Equal(t, tt.wantRequests, kbFake.requests[0].Limit)
+			assert.Equal(t, tt.query, kbFake.requests[0].Search)
+			require.Len(t, noteFake.requests, 1)
+			assert.Equal(t, tt.wantRequests, noteFake.requests[0].Limit)
+			require.Len(t, connFake.requests, 1)
+			assert.Equal(t, tt.query, *connFake.requests[0].DescriptionSearch)
+		})
+	}
+
+	t.Run("note query is quoted", func(t *testing.T) {
+		noteFake := &fakeNotes{}
+		searcher := search.New(&fakeKnowledgeBases{}, noteFake, &fakeConnections{})
+
+		_, err := searcher.SearchAll(ctx, `Graph OR "theory`, 0)
+		require.NoError(t, err)
+		assert.Equal(t, `"graph"* "or"* "theory"*`, noteFake.requests[0].Search)
+	})
+
+	t.Run("results carry a snippet around the match", func(t *testing.T) {
+		content := strings.Repeat("filler words here ", 30) + "the graph appears late" + strings.Repeat(" and more text", 20)
+		searcher := search.New(&fakeKnowledgeBases{}, &fakeNotes{items: []note.Note{{ID: 1, Title: "Long", Content: content}}}, &fakeConnections{})
+
+		results, err := searcher.SearchAll(ctx, "graph", 0)
+		require.NoError(t, err)
+		require.Len(t, results.Results, 1)
+
+		snippet := results.Results[0].Snippet
+		assert.Contains(t, snippet, "the graph appears late")
+		assert.True(t, strings.HasPrefix(snippet, "…"))
+		assert.True(t, strings.HasSuffix(snippet, "…"))
+		assert.LessOrEqual(t, len([]rune(snippet)), 162)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		searcher := search.New(&fakeKnowledgeBases{}, &fakeNotes{err: errors.New("database is locked")}, &fakeConnections{})
+
+		_, err := searcher.SearchAll(ctx, "graph", 0)
+		assert.ErrorContains(t, err, "failed to search notes: database is locked")
+	})
+}
+
+func TestSearcher_SearchAllStorages(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "search.db")
+	require.NoError(t, migrations.NewMigrationRunner(dbPath).RunMigrations())
+
+	kbs, err := kbstorage.NewStorage(dbPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { kbs.Close() })
+	notes, err := notestorage.NewStorage(dbPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { notes.Close() })
+	conns, err := connstorage.NewStorage(dbPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { conns.Close() })
+
+	kb, err := kbs.Create(ctx, knowledgebase.CreateRequest{Name: "Distributed systems", Description: strPtr("Consensus and replication")})
+	require.NoError(t, err)
+	paxos, err := notes.Create(ctx, note.CreateNoteRequest{Title: "Paxos", Content: "A consensus protocol for unreliable networks", Type: "text"})
+	require.NoError(t, err)
+	raft, err := notes.Create(ctx, note.CreateNoteRequest{Title: "Raft", Content: "Leader election and log replication", Type: "text"})
+	require.NoError(t, err)
+	conn, err := conns.Create(ctx, connection.CreateConnectionRequest{
+		FromNoteID: raft.ID, ToNoteID: paxos.ID, Type: "references", Strength: 5,
+		Description: strPtr("Raft was designed as an understandable consensus algorithm"),
+	})
+	require.NoError(t, err)
+
+	searcher := search.New(kbs, notes, conns)
+
+	tests := []struct {
+		name     string
+		query    string
+		wantKeys []string
+	}{
+		{
+			name:     "every type",
+			query:    "consensus",
+			wantKeys: []string{key(search.EntityConnection, conn.ID), key(search.EntityKnowledgeBase, kb.ID), key(search.EntityNote, paxos.ID)},
+		},
+		{
+			name:     "prefix match",
+			query:    "replic",
+			wantKeys: []string{key(search.EntityKnowledgeBase, kb.ID), key(search.EntityNote, raft.ID)},
+		},
+		{
+			name:     "fts syntax in the query",
+			query:    `consensus "networks`,
+			wantKeys: []string{key(search.EntityNote, paxos.ID)},
+		},
+		{
+			name:     "no match",
+			query:    "blockchain",
+			wantKeys: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := searcher.SearchAll(ctx, tt.query, 0)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantKeys, resultKeys(results.Results))
+		})
+	}
+}
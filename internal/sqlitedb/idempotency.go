@@ -0,0 +1,123 @@
+package sqlitedb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultIdempotencyWindow is how long an idempotency key is remembered
+	DefaultIdempotencyWindow = 24 * time.Hour
+	// MaxIdempotencyKeyLength bounds the length of an idempotency key in bytes
+	MaxIdempotencyKeyLength = 255
+)
+
+// Tables whose creates take an idempotency key. Keys are scoped to the table,
+// so the same key may create a note and a connection.
+const (
+	IdempotencyNotes          = "notes"
+	IdempotencyConnections    = "connections"
+	IdempotencyKnowledgeBases = "knowledge_base"
+)
+
+// Execer is the subset of *sql.DB and *sql.Tx the idempotency keys need
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// ValidateIdempotencyKey reports a key that is blank or too long
+func ValidateIdempotencyKey(key string) error {
+	if strings.TrimSpace(key) == "" {
+		return fmt.Errorf("idempotency key must not be blank")
+	}
+	if len(key) > MaxIdempotencyKeyLength {
+		return fmt.Errorf("idempotency key must be at most %d bytes, got: %d", MaxIdempotencyKeyLength, len(key))
+	}
+	return nil
+}
+
+// CreateOnce runs create, which inserts a row into table with db and returns
+// its ID, unless key already created a row there within window. It reports
+// the row's ID and whether it was created earlier. db should be a transaction
+// so the lookup, the insert and the saved key commit together. An empty key
+// always runs create.
+func CreateOnce(ctx context.Context, db Execer, table, key string, window time.Duration, create func() (int64, error)) (int64, bool, error) {
+	if key == "" {
+		id, err := create()
+		return id, false, err
+	}
+	if err := ValidateIdempotencyKey(key); err != nil {
+		return 0, false, err
+	}
+
+	id, found, err := LookupIdempotencyKey(ctx, db, table, key, window)
+	if err != nil || found {
+		return id, found, err
+	}
+
+	id, err = create()
+	if err != nil {
+		return 0, false, err
+	}
+	if err := SaveIdempotencyKey(ctx, db, table, key, id, window); err != nil {
+		return 0, false, err
+	}
+	return id, false, nil
+}
+
+// LookupIdempotencyKey returns the ID of the row of table created with key.
+// It reports false when the key is unknown, older than window or its row no
+// longer exists. A window of 0 keeps keys forever.
+func LookupIdempotencyKey(ctx context.Context, db Execer, table, key string, window time.Duration) (int64, bool, error) {
+	// table is one of the Idempotency constants, never client input
+	query := fmt.Sprintf(`
+		SELECT k.entity_id
+		FROM idempotency_keys k
+		JOIN %s e ON e.id = k.entity_id
+		WHERE k.entity = ? AND k.key = ?`, table)
+	args := []interface{}{table, key}
+	if window > 0 {
+		query += " AND k.created_at >= ?"
+		args = append(args, expiryCutoff(window))
+	}
+
+	var id int64
+	err := db.QueryRowContext(ctx, query, args...).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to look up idempotency key: %w", err)
+	}
+	return id, true, nil
+}
+
+// SaveIdempotencyKey records that key created the row id of table, replacing
+// an expired or stale record of the key. Expired keys of every table are
+// removed at the same time, so the table only grows with live keys.
+func SaveIdempotencyKey(ctx context.Context, db Execer, table, key string, id int64, window time.Duration) error {
+	if window > 0 {
+		if _, err := db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE created_at < ?", expiryCutoff(window)); err != nil {
+			return fmt.Errorf("failed to remove expired idempotency keys: %w", err)
+		}
+	}
+
+	_, err := db.ExecContext(ctx,
+		"INSERT OR REPLACE INTO idempotency_keys (entity, key, entity_id) VALUES (?, ?, ?)",
+		table, key, id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save idempotency key: %w", err)
+	}
+	return nil
+}
+
+// expiryCutoff is the created_at before which keys have outlived window
+func expiryCutoff(window time.Duration) string {
+	return time.Now().UTC().Add(-window).Format(TimestampLayout)
+}
@@ -0,0 +1,167 @@
+package sqlitedb_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/migrations"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/sqlitedb"
+)
+
+func TestCreateOnce(t *testing.T) {
+	ctx := context.Background()
+	dsn := filepath.Join(t.TempDir(), "idempotency.db")
+	require.NoError(t, migrations.NewMigrationRunner(dsn).RunMigrations())
+	db, err := sqlitedb.Open(dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	// create inserts a knowledge base and counts the calls that reached it
+	calls := 0
+	create := func() (int64, error) {
+		calls++
+		result, err := db.ExecContext(ctx, "INSERT INTO knowledge_base (name, tags) VALUES (?, '[]')", "kb")
+		if err != nil {
+			return 0, err
+		}
+		return result.LastInsertId()
+	}
+	createOnce := func(key string, window time.Duration) (int64, bool, error) {
+		return sqlitedb.CreateOnce(ctx, db, sqlitedb.IdempotencyKnowledgeBases, key, window, create)
+	}
+
+	firstID, replayed, err := createOnce("retry-1", time.Hour)
+	require.NoError(t, err)
+	require.False(t, replayed)
+
+	tests := []struct {
+		name         string
+		setup        func(t *testing.T)
+		key          string
+		window       time.Duration
+		wantID       func(id int64) bool
+		wantReplayed bool
+		wantCalls    int
+		wantErr      string
+	}{
+		{
+			name:         "repeated key returns the first row",
+			key:          "retry-1",
+			window:       time.Hour,
+			wantID:       func(id int64) bool { return id == firstID },
+			wantReplayed: true,
+		},
+		{
+			name:      "another key creates a row",
+			key:       "retry-2",
+			window:    time.Hour,
+			wantID:    func(id int64) bool { return id != firstID },
+			wantCalls: 1,
+		},
+		{
+			name:      "no key always creates",
+			key:       "",
+			window:    time.Hour,
+			wantID:    func(id int64) bool { return id != firstID },
+			wantCalls: 1,
+		},
+		{
+			name: "expired key creates a row",
+			setup: func(t *testing.T) {
+				_, err := db.ExecContext(ctx, "UPDATE idempotency_keys SET created_at = datetime('now', '-2 hours') WHERE key = 'retry-1'")
+				require.NoError(t, err)
+			},
+			key:       "retry-1",
+			window:    time.Hour,
+			wantID:    func(id int64) bool { return id != firstID },
+			wantCalls: 1,
+		},
+		{
+			name: "window of zero never expires",
+			setup: func(t *testing.T) {
+				_, err := db.ExecContext(ctx, "INSERT INTO idempotency_keys (entity, key, entity_id, created_at) VALUES ('knowledge_base', 'old', ?, datetime('now', '-400 days'))", firstID)
+				require.NoError(t, err)
+			},
+			key:          "old",
+			window:       0,
+			wantID:       func(id int64) bool { return id == firstID },
+			wantReplayed: true,
+		},
+		{
+			name: "key of a deleted row creates a row",
+			setup: func(t *testing.T) {
+				_, err := db.ExecContext(ctx, "DELETE FROM knowledge_base WHERE id = ?", firstID)
+				require.NoError(t, err)
+			},
+			key:       "old",
+			window:    0,
+			wantID:    func(id int64) bool { return id != firstID },
+			wantCalls: 1,
+		},
+		{
+			name:    "blank key",
+			key:     "  ",
+			window:  time.Hour,
+			wantErr: "idempotency key must not be blank",
+		},
+		{
+			name:    "key too long",
+			key:     strings.Repeat("k", sqlitedb.MaxIdempotencyKeyLength+1),
+			window:  time.Hour,
+			wantErr: "idempotency key must be at most 255 bytes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup(t)
+			}
+			calls = 0
+
+			id, replayed, err := createOnce(tt.key, tt.window)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Zero(t, calls)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.wantID(id), "unexpected ID %d", id)
+			assert.Equal(t, tt.wantReplayed, replayed)
+			assert.Equal(t, tt.wantCalls, calls)
+
+			if tt.key != "" {
+				again, replayed, err := createOnce(tt.key, tt.window)
+				require.NoError(t, err)
+				assert.True(t, replayed)
+				assert.Equal(t, id, again)
+			}
+		})
+	}
+
+	t.Run("keys are scoped to the table", func(t *testing.T) {
+		_, found, err := sqlitedb.LookupIdempotencyKey(ctx, db, sqlitedb.IdempotencyNotes, "retry-2", 0)
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("saving removes expired keys", func(t *testing.T) {
+		_, err := db.ExecContext(ctx, "UPDATE idempotency_keys SET created_at = datetime('now', '-2 days') WHERE key = 'retry-2'")
+		require.NoError(t, err)
+		_, _, err = createOnce("retry-3", 24*time.Hour)
+		require.NoError(t, err)
+
+		var count int
+		err = db.QueryRowContext(ctx, "SELECT COUNT(*) FROM idempotency_keys WHERE key = 'retry-2'").Scan(&count)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+}
@@ -0,0 +1,70 @@
+package sqlitedb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ncruces/go-sqlite3"
+)
+
+const (
+	// DefaultMaxRetries is the default number of retries after a busy error
+	DefaultMaxRetries = 3
+	// DefaultRetryDelay is the default wait before the first retry
+	DefaultRetryDelay = 25 * time.Millisecond
+	// DefaultMaxRetryDelay caps the wait between retries
+	DefaultMaxRetryDelay = time.Second
+)
+
+// RetryPolicy bounds the retries of writes that fail because another
+// connection holds a lock. The wait doubles after every attempt, starting at
+// Delay and capped at MaxDelay. MaxRetries of 0 disables retries.
+type RetryPolicy struct {
+	MaxRetries int
+	Delay      time.Duration
+	MaxDelay   time.Duration
+}
+
+// DefaultRetryPolicy returns the policy applied when none is configured
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: DefaultMaxRetries,
+		Delay:      DefaultRetryDelay,
+		MaxDelay:   DefaultMaxRetryDelay,
+	}
+}
+
+// IsBusy reports whether err comes from SQLite failing to get a lock, which
+// is SQLITE_BUSY ("database is locked") or SQLITE_LOCKED. Any other error,
+// such as a constraint violation, would fail again on retry.
+func IsBusy(err error) bool {
+	return errors.Is(err, sqlite3.BUSY) || errors.Is(err, sqlite3.LOCKED)
+}
+
+// Retry runs fn, running it again after a busy error until it succeeds, fails
+// with another error or the policy runs out of retries. fn must be safe to
+// repeat: a write in a transaction that failed has been rolled back, so it is.
+// Retry stops waiting when ctx is done and returns the last error from fn.
+func Retry(ctx context.Context, policy RetryPolicy, fn func() error) error {
+	delay := policy.Delay
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || !IsBusy(err) || attempt >= policy.MaxRetries {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+
+		delay *= 2
+		if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+			delay = policy.MaxDelay
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package sqlitedb_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ncruces/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/sqlitedb"
+)
+
+func TestIsBusy(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "busy", err: sqlite3.BUSY, want: true},
+		{name: "locked", err: sqlite3.LOCKED, want: true},
+		{name: "extended busy code", err: sqlite3.BUSY_SNAPSHOT, want: true},
+		{name: "wrapped busy", err: fmt.Errorf("failed to create note: %w", sqlite3.BUSY), want: true},
+		{name: "constraint violation", err: sqlite3.CONSTRAINT, want: false},
+		{name: "message alone is not enough", err: errors.New("database is locked"), want: false},
+		{name: "no rows", err: sql.ErrNoRows, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sqlitedb.IsBusy(tt.err))
+		})
+	}
+}
+
+func TestIsBusy_DriverError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "busy.db")
+
+	holder, err := sqlitedb.Open(path)
+	require.NoError(t, err)
+	defer holder.Close()
+	_, err = holder.Exec("CREATE TABLE t (id INTEGER)")
+	require.NoError(t, err)
+
+	conn, err := holder.Conn(context.Background())
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.ExecContext(context.Background(), "BEGIN IMMEDIATE")
+	require.NoError(t, err)
+	defer conn.ExecContext(context.Background(), "ROLLBACK")
+
+	writer, err := sqlitedb.Open("file:" + path + "?_pragma=busy_timeout(0)")
+	require.NoError(t, err)
+	defer writer.Close()
+
+	_, err = writer.Exec("INSERT INTO t (id) VALUES (1)")
+	require.Error(t, err)
+	assert.True(t, sqlitedb.IsBusy(err), "got %v", err)
+}
+
+func TestRetry(t *testing.T) {
+	policy := sqlitedb.RetryPolicy{MaxRetries: 3, Delay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+	errOther := errors.New("constraint failed")
+
+	tests := []struct {
+		name      string
+		noRetries bool
+		errs      []error
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "success first time", errs: []error{nil}, wantCalls: 1},
+		{name: "busy then success", errs: []error{sqlite3.BUSY, sqlite3.BUSY, nil}, wantCalls: 3},
+		{name: "wrapped locked then success", errs: []error{fmt.Errorf("insert: %w", sqlite3.LOCKED), nil}, wantCalls: 2},
+		{name: "other error is not retried", errs: []error{errOther}, wantErr: errOther, wantCalls: 1},
+		{name: "busy then other error", errs: []error{sqlite3.BUSY, errOther}, wantErr: errOther, wantCalls: 2},
+		{
+			name:      "retries run out",
+			errs:      []error{sqlite3.BUSY, sqlite3.BUSY, sqlite3.BUSY, sqlite3.BUSY, nil},
+			wantErr:   sqlite3.BUSY,
+			wantCalls: 4,
+		},
+		{
+			name:      "retries disabled",
+			noRetries: true,
+			errs:      []error{sqlite3.BUSY, nil},
+			wantErr:   sqlite3.BUSY,
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := policy
+			if tt.noRetries {
+				p.MaxRetries = 0
+			}
+
+			calls := 0
+			err := sqlitedb.Retry(context.Background(), p, func() error {
+				err := tt.errs[calls]
+				calls++
+				return err
+			})
+
+			assert.Equal(t, tt.wantCalls, calls)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRetry_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	calls := 0
+	start := time.Now()
+	err := sqlitedb.Retry(ctx, sqlitedb.RetryPolicy{MaxRetries: 5, Delay: time.Hour}, func() error {
+		calls++
+		cancel()
+		return sqlite3.BUSY
+	})
+
+	assert.ErrorIs(t, err, sqlite3.BUSY)
+	assert.Equal(t, 1, calls)
+	assert.Less(t, time.Since(start), time.Minute)
+}
@@ -0,0 +1,127 @@
+// Package sqlitedb opens SQLite databases for the storages and migrations,
+// including in-memory databases.
+//
+// A private in-memory database (":memory:") exists only inside the single
+// connection that created it. Open pins such a pool to one connection so every
+// query sees the same data, but two pools opened with ":memory:" never share
+// anything.
+//
+// A shared in-memory database ("file::memory:?cache=shared" or
+// "file:NAME?mode=memory&cache=shared") is visible to every pool in the process
+// that opens the same name. The embedded SQLite build omits shared cache, so
+// these DSNs are mapped onto the driver's memdb VFS. The database lives until
+// the process exits.
+package sqlitedb
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/ncruces/go-sqlite3"
+	"github.com/ncruces/go-sqlite3/driver"
+	_ "github.com/ncruces/go-sqlite3/embed"
+	"github.com/ncruces/go-sqlite3/vfs/memdb"
+)
+
+// Kind classifies where a DSN stores its data
+type Kind int
+
+const (
+	// File is an on-disk database
+	File Kind = iota
+	// PrivateMemory is an in-memory database visible to a single connection
+	PrivateMemory
+	// SharedMemory is a named in-memory database shared across pools in the process
+	SharedMemory
+)
+
+// TimestampLayout is the format of CURRENT_TIMESTAMP, which the schema uses
+// for created_at and updated_at. Parameters compared against datetime() of
+// those columns must be formatted with it in UTC.
+const TimestampLayout = "2006-01-02 15:04:05"
+
+var (
+	sharedMu sync.Mutex
+	// sharedDBs records the memdb databases created by this process. memdb.Create
+	// replaces existing contents, so each name is created only once.
+	sharedDBs = map[string]bool{}
+)
+
+// Open opens dsn with the ncruces driver. fn is run on every new connection,
+// for example to register custom SQL functions.
+func Open(dsn string, fn ...func(*sqlite3.Conn) error) (*sql.DB, error) {
+	resolved, kind, err := Resolve(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := driver.Open(resolved, fn...)
+	if err != nil {
+		return nil, err
+	}
+
+	if kind == PrivateMemory {
+		// Every pooled connection would otherwise see its own empty database
+		db.SetMaxOpenConns(1)
+		db.SetMaxIdleConns(1)
+		db.SetConnMaxLifetime(0)
+		db.SetConnMaxIdleTime(0)
+	}
+
+	return db, nil
+}
+
+// Resolve maps dsn to the DSN passed to the driver and reports its kind.
+// Shared in-memory DSNs are rewritten to the memdb VFS and their database is
+// created on first use.
+func Resolve(dsn string) (string, Kind, error) {
+	if dsn == ":memory:" {
+		return dsn, PrivateMemory, nil
+	}
+	if !strings.HasPrefix(dsn, "file:") {
+		return dsn, File, nil
+	}
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", File, fmt.Errorf("invalid database URI %q: %w", dsn, err)
+	}
+
+	name := u.Opaque
+	if name == "" {
+		name = u.Path
+	}
+	query := u.Query()
+
+	if name != ":memory:" && query.Get("mode") != "memory" {
+		return dsn, File, nil
+	}
+	if query.Get("cache") != "shared" {
+		return dsn, PrivateMemory, nil
+	}
+
+	name = strings.TrimPrefix(name, "/")
+	if name == ":memory:" || name == "" {
+		name = "memory"
+	}
+	ensureShared(name)
+
+	query.Del("cache")
+	query.Del("mode")
+	query.Set("vfs", "memdb")
+	return "file:/" + name + "?" + query.Encode(), SharedMemory, nil
+}
+
+// ensureShared creates the named memdb database unless this process already has
+func ensureShared(name string) {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+
+	if !sharedDBs[name] {
+		memdb.Create(name, nil)
+		sharedDBs[name] = true
+	}
+}
@@ -0,0 +1,146 @@
+package sqlitedb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase"
+	kbstorage "github.com/red1r3ct/knowledge-graph-mcp/internal/knowledgebase/sqlite"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/migrations"
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/sqlitedb"
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		dsn      string
+		wantDSN  string
+		wantKind sqlitedb.Kind
+	}{
+		{
+			name:     "plain file path",
+			dsn:      "knowledge-base.db",
+			wantDSN:  "knowledge-base.db",
+			wantKind: sqlitedb.File,
+		},
+		{
+			name:     "file URI",
+			dsn:      "file:knowledge-base.db?_pragma=busy_timeout(5000)",
+			wantDSN:  "file:knowledge-base.db?_pragma=busy_timeout(5000)",
+			wantKind: sqlitedb.File,
+		},
+		{
+			name:     "private memory",
+			dsn:      ":memory:",
+			wantDSN:  ":memory:",
+			wantKind: sqlitedb.PrivateMemory,
+		},
+		{
+			name:     "private memory URI",
+			dsn:      "file::memory:",
+			wantDSN:  "file::memory:",
+			wantKind: sqlitedb.PrivateMemory,
+		},
+		{
+			name:     "shared memory",
+			dsn:      "file::memory:?cache=shared",
+			wantDSN:  "file:/memory?vfs=memdb",
+			wantKind: sqlitedb.SharedMemory,
+		},
+		{
+			name:     "named shared memory",
+			dsn:      "file:resolve-test?mode=memory&cache=shared",
+			wantDSN:  "file:/resolve-test?vfs=memdb",
+			wantKind: sqlitedb.SharedMemory,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dsn, kind, err := sqlitedb.Resolve(tt.dsn)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDSN, dsn)
+			assert.Equal(t, tt.wantKind, kind)
+		})
+	}
+}
+
+func TestOpen_PrivateMemoryKeepsData(t *testing.T) {
+	db, err := sqlitedb.Open(":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY)")
+	require.NoError(t, err)
+
+	// Every later query must reuse the connection that holds the table
+	for i := 0; i < 3; i++ {
+		_, err = db.Exec("INSERT INTO items DEFAULT VALUES")
+		require.NoError(t, err)
+	}
+
+	var count int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM items").Scan(&count))
+	assert.Equal(t, 3, count)
+}
+
+func TestOpen_SharedMemoryAcrossPools(t *testing.T) {
+	dsn := "file:shared-pools-test?mode=memory&cache=shared"
+
+	first, err := sqlitedb.Open(dsn)
+	require.NoError(t, err)
+	defer first.Close()
+
+	second, err := sqlitedb.Open(dsn)
+	require.NoError(t, err)
+	defer second.Close()
+
+	_, err = first.Exec("CREATE TABLE items (name TEXT)")
+	require.NoError(t, err)
+	_, err = first.Exec("INSERT INTO items (name) VALUES ('shared')")
+	require.NoError(t, err)
+
+	var name string
+	require.NoError(t, second.QueryRow("SELECT name FROM items").Scan(&name))
+	assert.Equal(t, "shared", name)
+
+	// Reopening must not wipe the existing database
+	third, err := sqlitedb.Open(dsn)
+	require.NoError(t, err)
+	defer third.Close()
+
+	var count int
+	require.NoError(t, third.QueryRow("SELECT COUNT(*) FROM items").Scan(&count))
+	assert.Equal(t, 1, count)
+}
+
+func TestSharedMemory_MigrationsAndStorage(t *testing.T) {
+	dsn := "file:migrations-test?mode=memory&cache=shared"
+	ctx := context.Background()
+
+	require.NoError(t, migrations.NewMigrationRunner(dsn).RunMigrations())
+
+	storage, err := kbstorage.NewStorage(dsn)
+	require.NoError(t, err)
+	defer storage.Close()
+
+	created, err := storage.Create(ctx, knowledgebase.CreateRequest{Name: "In memory", Tags: []string{}})
+	require.NoError(t, err)
+
+	other, err := kbstorage.NewStorage(dsn)
+	require.NoError(t, err)
+	defer other.Close()
+
+	got, err := other.Get(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "In memory", got.Name)
+}
+
+func TestPrivateMemory_MigrationsRejected(t *testing.T) {
+	err := migrations.NewMigrationRunner(":memory:").RunMigrations()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cache=shared")
+}
@@ -0,0 +1,28 @@
+// Package tags normalizes free-form tags so that spellings such as "Go",
+// "go" and " go " are stored as one tag.
+package tags
+
+import (
+	"strings"
+)
+
+// Normalize trims and lowercases every tag, drops tags that are empty after
+// trimming and removes duplicates, keeping the first occurrence's position.
+// A nil slice stays nil so callers can keep treating nil as "not provided".
+func Normalize(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
@@ -0,0 +1,54 @@
+package tags_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/red1r3ct/knowledge-graph-mcp/internal/tags"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		tags []string
+		want []string
+	}{
+		{
+			name: "nil stays nil",
+			tags: nil,
+			want: nil,
+		},
+		{
+			name: "empty stays empty",
+			tags: []string{},
+			want: []string{},
+		},
+		{
+			name: "case and whitespace variants collapse",
+			tags: []string{"Go", "go", " go ", "GO\t"},
+			want: []string{"go"},
+		},
+		{
+			name: "first occurrence order is kept",
+			tags: []string{" Research", "golang", "research ", "AI"},
+			want: []string{"research", "golang", "ai"},
+		},
+		{
+			name: "blank tags are dropped",
+			tags: []string{"", "   ", "notes"},
+			want: []string{"notes"},
+		},
+		{
+			name: "inner whitespace and non-ASCII are kept",
+			tags: []string{"Machine Learning", "ÉTUDE", "étude"},
+			want: []string{"machine learning", "étude"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tags.Normalize(tt.tags))
+		})
+	}
+}